@@ -126,7 +126,31 @@ const (
 	_smoduleLast
 )
 
-var smodules [_smoduleLast]Level
+var (
+	smodules     [_smoduleLast]Level
+	smoduleNames = [_smoduleLast]string{
+		SmoduleTransport: "transport",
+		SmoduleAIS:       "ais",
+		SmoduleMemsys:    "memsys",
+		SmoduleCluster:   "cluster",
+		SmoduleMirror:    "mirror",
+		SmoduleFS:        "fs",
+		SmoduleReb:       "reb",
+	}
+)
+
+// SmoduleByName returns static module given its name (e.g., "memsys").
+func SmoduleByName(name string) (uint8, bool) {
+	for smodule, n := range smoduleNames {
+		if n == name {
+			return uint8(smodule), true
+		}
+	}
+	return 0, false
+}
+
+// SmoduleNames returns the names of all static modules.
+func SmoduleNames() []string { return smoduleNames[:] }
 
 // get returns the value of the severity.
 func (s *severity) get() severity {
@@ -1070,15 +1094,17 @@ func V(level Level) Verbose {
 // message. It should only be used for datapath since it does not take vmodule
 // into account and was designed in need of very fast and inlined func.
 func FastV(level Level, smodule uint8) Verbose {
-	return Verbose(logging.verbosity.get() >= level || smodules[smodule] >= level)
+	return Verbose(logging.verbosity.get() >= level || smodules[smodule].get() >= level)
 }
 
-// SetV sets verbosity level for static module.
-//
-// NOTE: SetV should be set in the init. Bugs are expected when SetV is called
-// in-flight since no locks are taken.
+// SetV sets verbosity level for static module; can be called in-flight.
 func SetV(smodule uint8, level Level) {
-	smodules[smodule] = level
+	smodules[smodule].set(level)
+}
+
+// GetV returns verbosity level of static module.
+func GetV(smodule uint8) Level {
+	return smodules[smodule].get()
 }
 
 // Info is equivalent to the global Info function, guarded by the value of v.
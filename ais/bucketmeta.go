@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/fs"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -117,10 +118,71 @@ func (m *bucketMD) del(bck *cluster.Bck) (deleted bool) {
 	if !m.Del(bck) {
 		return
 	}
+	// aliases of the bucket are gone as well
+	for alias, aliased := range m.Aliases {
+		if aliased.Equal(bck.Bck) {
+			delete(m.Aliases, alias)
+		}
+	}
 	m.Version++
 	return true
 }
 
+// bucket aliases (see cluster.BMD.Alias)
+func (m *bucketMD) setAlias(alias string, bck cmn.Bck) {
+	m.SetAlias(alias, bck)
+	m.Version++
+}
+
+func (m *bucketMD) delAlias(alias string) (deleted bool) {
+	if deleted = m.DelAlias(alias); deleted {
+		m.Version++
+	}
+	return
+}
+
+// re-points the aliases of a renamed bucket
+func (m *bucketMD) renameAliases(bckFrom, bckTo cmn.Bck) {
+	for alias, aliased := range m.Aliases {
+		if aliased.Equal(bckFrom) {
+			m.SetAlias(alias, bckTo)
+		}
+	}
+}
+
+// download schedules and shared limits (see restoreDlJobs)
+func (m *bucketMD) setDlSched(id string, sched *cluster.DlSched) {
+	if m.DlScheds == nil {
+		m.DlScheds = make(cluster.DlScheds, 4)
+	}
+	m.DlScheds[id] = sched
+	m.Version++
+}
+
+func (m *bucketMD) delDlSched(id string) (deleted bool) {
+	if _, deleted = m.DlScheds[id]; deleted {
+		delete(m.DlScheds, id)
+		m.Version++
+	}
+	return
+}
+
+func (m *bucketMD) setDlShare(id string, limits *downloader.DlLimits) {
+	if m.DlShares == nil {
+		m.DlShares = make(cluster.DlShares, 4)
+	}
+	m.DlShares[id] = cmn.MustMarshal(limits)
+	m.Version++
+}
+
+func (m *bucketMD) delDlShare(id string) (deleted bool) {
+	if _, deleted = m.DlShares[id]; deleted {
+		delete(m.DlShares, id)
+		m.Version++
+	}
+	return
+}
+
 func (m *bucketMD) set(bck *cluster.Bck, p *cmn.BucketProps) {
 	if !cmn.IsValidProvider(bck.Provider) {
 		cmn.Assertf(false, "%s: invalid provider", bck)
@@ -133,6 +195,7 @@ func (m *bucketMD) set(bck *cluster.Bck, p *cmn.BucketProps) {
 
 	p.BID = prevProps.BID
 	p.Provider = bck.Provider
+	p.PropsVersion = prevProps.PropsVersion + 1
 	m.Set(bck, p)
 	m.Version++
 }
@@ -120,3 +120,43 @@ var _ = Describe("BMD marshal and unmarshal", func() {
 		})
 	}
 })
+
+var _ = Describe("BMD aliases", func() {
+	var (
+		bmd    *bucketMD
+		bck    *cluster.Bck
+		bckNew *cluster.Bck
+	)
+
+	BeforeEach(func() {
+		bmd = newBucketMD()
+		bck = cluster.NewBck("dataset-v1", cmn.ProviderAIS, cmn.NsGlobal)
+		bckNew = cluster.NewBck("dataset-v2", cmn.ProviderAIS, cmn.NsGlobal)
+		bmd.add(bck, cmn.DefaultAISBckProps())
+	})
+
+	It("should bump the version upon alias changes", func() {
+		ver := bmd.version()
+		bmd.setAlias("dataset", bck.Bck)
+		Expect(bmd.version()).To(Equal(ver + 1))
+		Expect(bmd.delAlias("dataset")).To(BeTrue())
+		Expect(bmd.version()).To(Equal(ver + 2))
+		Expect(bmd.delAlias("dataset")).To(BeFalse())
+		Expect(bmd.version()).To(Equal(ver + 2))
+	})
+
+	It("should remove the aliases of a destroyed bucket", func() {
+		bmd.setAlias("dataset", bck.Bck)
+		Expect(bmd.del(bck)).To(BeTrue())
+		_, ok := bmd.Alias("dataset")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should re-point the aliases of a renamed bucket", func() {
+		bmd.setAlias("dataset", bck.Bck)
+		bmd.renameAliases(bck.Bck, bckNew.Bck)
+		aliased, ok := bmd.Alias("dataset")
+		Expect(ok).To(BeTrue())
+		Expect(aliased.Equal(bckNew.Bck)).To(BeTrue())
+	})
+})
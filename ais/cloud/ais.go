@@ -273,6 +273,44 @@ ad:
 	return
 }
 
+// PutObjRemote and DeleteObjRemote are used to replicate ais buckets to the
+// (mirror) buckets of the attached remote clusters - see package replication.
+// `remoteBck.Ns.UUID` is the UUID or alias of the remote cluster.
+
+func (m *AisCloudProvider) PutObjRemote(remoteBck cmn.Bck, objName string, fh *cmn.FileHandle,
+	size int64, cksum *cmn.Cksum) error {
+	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	return m.try(remoteBck, func(bck cmn.Bck) error {
+		args := api.PutObjectArgs{
+			BaseParams: aisCluster.bp,
+			Bck:        bck,
+			Object:     objName,
+			Cksum:      cksum,
+			Reader:     fh,
+			Size:       uint64(size),
+		}
+		return api.PutObject(args)
+	})
+}
+
+func (m *AisCloudProvider) DeleteObjRemote(remoteBck cmn.Bck, objName string) error {
+	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
+	if err != nil {
+		return err
+	}
+	err = m.try(remoteBck, func(bck cmn.Bck) error {
+		return api.DeleteObject(aisCluster.bp, bck, objName)
+	})
+	if _, errCode := extractErrCode(err); errCode == http.StatusNotFound {
+		return nil // nothing to delete
+	}
+	return err
+}
+
 ////////////////////////////////
 // cluster.CloudProvider APIs //
 ////////////////////////////////
@@ -443,7 +481,11 @@ func (m *AisCloudProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (
 		return nil, nil, err, errCode
 	}
 
-	r, err := api.GetObjectReader(aisCluster.bp, remoteBck, lom.ObjName)
+	var options []api.GetObjectInput
+	if rng := readRange(ctx); rng != nil {
+		options = append(options, api.GetObjectInput{Range: rng})
+	}
+	r, err := api.GetObjectReader(aisCluster.bp, remoteBck, lom.ObjName, options...)
 	err, errCode = extractErrCode(err)
 	return r, nil, err, errCode
 }
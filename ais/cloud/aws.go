@@ -11,12 +11,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
@@ -32,52 +36,132 @@ const (
 
 type (
 	awsProvider struct {
-		t cluster.Target
+		t       cluster.Target
+		clients *s3Clients
 	}
 
 	sessConf struct {
 		bck    *cmn.Bck
 		region string
 	}
+
+	// S3 clients are cached by (region, endpoint, profile) - see s3Clients.get()
+	s3ClientKey struct {
+		region    string
+		endpoint  string
+		profile   string
+		pathStyle bool
+	}
+	s3Client struct {
+		svc     *s3.S3
+		created int64 // mono time
+	}
+	s3Clients struct {
+		mu     sync.RWMutex
+		m      map[s3ClientKey]*s3Client
+		client *http.Client // shared by all sessions
+	}
+)
+
+const (
+	// environment: S3-compatible endpoint (empty - AWS; superseded by the
+	// cluster config and bucket props - see s3Endpoint) and the profile in the
+	// shared config (~/.aws/credentials and ~/.aws/config; empty - default)
+	// NOTE: not S3_ENDPOINT - the latter is used by S3 clients (e.g., TensorFlow)
+	// to point at AIS itself (see docs/s3compat.md)
+	awsEndpointEnv = "AIS_S3_ENDPOINT"
+	awsProfileEnv  = "AWS_PROFILE"
+
+	// cached clients (sessions) get recreated after this interval - to pick up
+	// rotated credentials (shared credentials file, environment)
+	awsClientRefresh = 30 * time.Minute
 )
 
 var _ cluster.CloudProvider = &awsProvider{}
 
-func NewAWS(t cluster.Target) (cluster.CloudProvider, error) { return &awsProvider{t: t}, nil }
+func NewAWS(t cluster.Target) (cluster.CloudProvider, error) {
+	clients := &s3Clients{
+		m:      make(map[s3ClientKey]*s3Client, 4),
+		client: cmn.NewClient(cmn.TransportArgs{}),
+	}
+	return &awsProvider{t: t, clients: clients}, nil
+}
+
+// get returns cached S3 client for a given key, constructing it on the first use
+// (and periodically thereafter). Safe for concurrent use.
+func (c *s3Clients) get(key s3ClientKey) *s3.S3 {
+	now := mono.NanoTime()
+	c.mu.RLock()
+	cl, ok := c.m[key]
+	c.mu.RUnlock()
+	if ok && time.Duration(now-cl.created) < awsClientRefresh {
+		return cl.svc
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok = c.m[key]; ok && time.Duration(now-cl.created) < awsClientRefresh {
+		return cl.svc // constructed by another goroutine
+	}
+	cl = &s3Client{svc: s3.New(c.newSession(key)), created: now}
+	c.m[key] = cl
+	return cl.svc
+}
 
 // A session is created using default credentials from
 // configuration file in ~/.aws/credentials and environment variables
-func createSession() *session.Session {
-	// TODO: avoid creating sessions for each request
+func (c *s3Clients) newSession(key s3ClientKey) *session.Session {
+	conf := aws.Config{HTTPClient: c.client}
+	if key.region != "" {
+		conf.Region = aws.String(key.region)
+	}
+	if key.endpoint != "" {
+		conf.Endpoint = aws.String(key.endpoint)
+		conf.S3ForcePathStyle = aws.Bool(key.pathStyle)
+	}
 	return session.Must(session.NewSessionWithOptions(session.Options{
+		Profile:           key.profile,
 		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{HTTPClient: cmn.NewClient(cmn.TransportArgs{})},
+		Config:            conf,
 	}))
 }
 
-// newS3Client creates new S3 client that can be used to make requests. It is
+// newS3Client returns S3 client that can be used to make requests. It is
 // guaranteed that the client is initialized even in case of errors.
 func (awsp *awsProvider) newS3Client(conf sessConf, tag string) (svc *s3.S3, err error, regIsSet bool) {
-	var (
-		sess    = createSession()
-		awsConf = &aws.Config{}
-	)
-
+	key := s3ClientKey{profile: os.Getenv(awsProfileEnv)}
+	key.endpoint, key.pathStyle = s3Endpoint(conf.bck)
 	if conf.region != "" {
-		awsConf.Region = aws.String(conf.region)
+		key.region = conf.region
 		regIsSet = true
 	} else if conf.bck != nil {
 		if conf.bck.Props == nil || conf.bck.Props.Extra.CloudRegion == "" {
 			if tag != "" {
 				err = fmt.Errorf("%s: unknown region for bucket %s -- proceeding with default", tag, conf.bck)
 			}
-			svc = s3.New(sess)
-			return
+		} else {
+			key.region = conf.bck.Props.Extra.CloudRegion
+			regIsSet = true
 		}
-		regIsSet = true
-		awsConf.Region = aws.String(conf.bck.Props.Extra.CloudRegion)
 	}
-	svc = s3.New(sess, awsConf)
+	svc = awsp.clients.get(key)
+	return
+}
+
+// s3Endpoint returns S3-compatible endpoint of a given bucket, in the order of
+// precedence: bucket props, cluster config, environment. Empty endpoint means
+// AWS (default endpoint resolution).
+func s3Endpoint(bck *cmn.Bck) (endpoint string, pathStyle bool) {
+	if bck != nil && bck.Props != nil && bck.Props.Extra.AWS.Endpoint != "" {
+		return bck.Props.Extra.AWS.Endpoint, bck.Props.Extra.AWS.PathStyle
+	}
+	if v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAmazon); ok {
+		if awsConf, ok := v.(cmn.CloudConfAWS); ok && awsConf.Endpoint != "" {
+			return awsConf.Endpoint, awsConf.PathStyle
+		}
+	}
+	if endpoint = os.Getenv(awsEndpointEnv); endpoint != "" {
+		pathStyle = true
+	}
 	return
 }
 
@@ -386,10 +470,15 @@ func (awsp *awsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 		glog.Warning(err)
 	}
 
-	obj, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(cloudBck.Name),
 		Key:    aws.String(lom.ObjName),
-	})
+	}
+	rng := readRange(ctx)
+	if rng != nil {
+		input.Range = aws.String(rng.HdrVal())
+	}
+	obj, err := svc.GetObjectWithContext(ctx, input)
 	if err != nil {
 		err, errCode = awsp.awsErrorToAISError(err, cloudBck)
 		return
@@ -411,7 +500,9 @@ func (awsp *awsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 		customMD[cluster.VersionObjMD] = v
 	}
 	if v, ok := h.EncodeCksum(obj.ETag); ok {
-		expectedCksm = cmn.NewCksum(cmn.ChecksumMD5, v)
+		if rng == nil { // (ETag is the checksum of the entire object)
+			expectedCksm = cmn.NewCksum(cmn.ChecksumMD5, v)
+		}
 		customMD[cluster.MD5ObjMD] = v
 	}
 	lom.SetCksum(cksum)
@@ -0,0 +1,64 @@
+// +build aws
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestS3Endpoint(t *testing.T) {
+	const (
+		envEndpoint  = "minio.local:9000"
+		confEndpoint = "rgw.local:7480"
+		bckEndpoint  = "s3.bck.local"
+	)
+	for _, key := range []string{"S3_ENDPOINT", awsEndpointEnv} {
+		if prev, ok := os.LookupEnv(key); ok {
+			defer os.Setenv(key, prev)
+		} else {
+			defer os.Unsetenv(key)
+		}
+	}
+	check := func(bck *cmn.Bck, expected string, expectedPathStyle bool) {
+		t.Helper()
+		endpoint, pathStyle := s3Endpoint(bck)
+		if endpoint != expected || pathStyle != expectedPathStyle {
+			t.Errorf("expected (%q, %t), got (%q, %t)", expected, expectedPathStyle, endpoint, pathStyle)
+		}
+	}
+	bck := &cmn.Bck{Name: "bck", Provider: cmn.ProviderAmazon, Props: &cmn.BucketProps{}}
+
+	// S3_ENDPOINT points S3 clients at AIS itself and must not be picked up
+	if err := os.Setenv("S3_ENDPOINT", "10.0.0.20:8080/s3"); err != nil {
+		t.Fatal(err)
+	}
+	check(bck, "", false)
+
+	if err := os.Setenv(awsEndpointEnv, envEndpoint); err != nil {
+		t.Fatal(err)
+	}
+	check(bck, envEndpoint, true)
+
+	config := cmn.GCO.BeginUpdate()
+	if config.Cloud.Conf == nil {
+		config.Cloud.Conf = make(map[string]interface{})
+	}
+	config.Cloud.ProviderConf(cmn.ProviderAmazon, cmn.CloudConfAWS{Endpoint: confEndpoint})
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		delete(config.Cloud.Conf, cmn.ProviderAmazon)
+		cmn.GCO.CommitUpdate(config)
+	}()
+	check(bck, confEndpoint, false)
+
+	bck.Props.Extra.AWS = cmn.ExtraPropsAWS{Endpoint: bckEndpoint, PathStyle: true}
+	check(bck, bckEndpoint, true)
+}
@@ -370,7 +370,12 @@ func (ap *azureProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 		return nil, nil, fmt.Errorf("failed to get object props %s/%s", cloudBck, lom.ObjName), respProps.StatusCode()
 	}
 	// 0, 0 = read range: the whole object
-	resp, err := blobURL.Download(ctx, 0, 0, azblob.BlobAccessConditions{}, false)
+	var offset, count int64
+	rng := readRange(ctx)
+	if rng != nil {
+		offset, count = rng.Start, rng.Length
+	}
+	resp, err := blobURL.Download(ctx, offset, count, azblob.BlobAccessConditions{}, false)
 	if err != nil {
 		err, errCode = ap.azureErrorToAISError(err, cloudBck, lom.ObjName)
 		return nil, nil, err, errCode
@@ -392,7 +397,9 @@ func (ap *azureProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 	}
 	if v, ok := h.EncodeCksum(respProps.ContentMD5()); ok {
 		customMD[cluster.MD5ObjMD] = v
-		cksumToCheck = cmn.NewCksum(cmn.ChecksumMD5, v)
+		if rng == nil {
+			cksumToCheck = cmn.NewCksum(cmn.ChecksumMD5, v)
+		}
 	}
 
 	lom.SetCustomMD(customMD)
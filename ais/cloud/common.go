@@ -25,6 +25,14 @@ func setSize(ctx context.Context, size int64) {
 	}
 }
 
+// readRange returns the range of the object to read (nil: the entire object)
+func readRange(ctx context.Context) *cmn.HTTPRange {
+	if v := ctx.Value(cmn.CtxReadRange); v != nil {
+		return v.(*cmn.HTTPRange)
+	}
+	return nil
+}
+
 func calcPageSize(pageSize, maxPageSize uint) uint {
 	if pageSize == 0 {
 		return maxPageSize
@@ -82,19 +82,29 @@ func NewGCP(t cluster.Target) (cluster.CloudProvider, error) {
 	return &gcpProvider{t: t, projectID: projectID}, nil
 }
 
-func (gcpp *gcpProvider) createClient(ctx context.Context) (*storage.Client, context.Context, error) {
+func (gcpp *gcpProvider) clientOpts() []option.ClientOption {
 	opts := []option.ClientOption{option.WithScopes(storage.ScopeFullControl)}
 	if gcpp.projectID == "" {
 		opts = append(opts, option.WithoutAuthentication())
 	}
+	return opts
+}
 
-	// Create a custom HTTP client
-	transport, err := htransport.NewTransport(ctx, cmn.NewTransport(cmn.TransportArgs{}), opts...)
+// (authenticated) HTTP client - used by the storage client and by resumable uploads
+func (gcpp *gcpProvider) createHTTPClient(ctx context.Context) (*http.Client, error) {
+	transport, err := htransport.NewTransport(ctx, cmn.NewTransport(cmn.TransportArgs{}), gcpp.clientOpts()...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http client transport, err: %v", err)
+		return nil, fmt.Errorf("failed to create http client transport, err: %v", err)
 	}
-	opts = append(opts, option.WithHTTPClient(&http.Client{Transport: transport}))
+	return &http.Client{Transport: transport}, nil
+}
 
+func (gcpp *gcpProvider) createClient(ctx context.Context) (*storage.Client, context.Context, error) {
+	hclient, err := gcpp.createHTTPClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := append(gcpp.clientOpts(), option.WithHTTPClient(hclient))
 	client, err := storage.NewClient(ctx, opts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create client, err: %v", err)
@@ -315,8 +325,16 @@ func (gcpp *gcpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 		return nil, nil, err, errCode
 	}
 
-	cksum := cmn.NewCksum(attrs.Metadata[gcpChecksumType], attrs.Metadata[gcpChecksumVal])
-	rc, err := o.NewReader(gctx)
+	var (
+		rc    *storage.Reader
+		rng   = readRange(ctx)
+		cksum = cmn.NewCksum(attrs.Metadata[gcpChecksumType], attrs.Metadata[gcpChecksumVal])
+	)
+	if rng != nil {
+		rc, err = o.NewRangeReader(gctx, rng.Start, rng.Length)
+	} else {
+		rc, err = o.NewReader(gctx)
+	}
 	if err != nil {
 		return nil, nil, err, 0
 	}
@@ -330,7 +348,9 @@ func (gcpp *gcpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 		customMD[cluster.VersionObjMD] = v
 	}
 	if v, ok := h.EncodeCksum(attrs.MD5); ok {
-		expectedCksm = cmn.NewCksum(cmn.ChecksumMD5, v)
+		if rng == nil {
+			expectedCksm = cmn.NewCksum(cmn.ChecksumMD5, v)
+		}
 		customMD[cluster.MD5ObjMD] = v
 	}
 	if v, ok := h.EncodeCksum(attrs.CRC32C); ok {
@@ -339,7 +359,11 @@ func (gcpp *gcpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 
 	lom.SetCksum(cksum)
 	lom.SetCustomMD(customMD)
-	setSize(ctx, rc.Attrs.Size)
+	if rng != nil {
+		setSize(ctx, rc.Remain())
+	} else {
+		setSize(ctx, rc.Attrs.Size)
+	}
 	reader = wrapReader(ctx, rc)
 	return
 }
@@ -371,6 +395,10 @@ func (gcpp *gcpProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 ////////////////
 
 func (gcpp *gcpProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, err error, errCode int) {
+	conf := gcpConf()
+	if lom.Size() > conf.ChunkSize {
+		return gcpp.putResumable(ctx, r, lom, &conf)
+	}
 	gcpClient, gctx, err := gcpp.createClient(ctx)
 	if err != nil {
 		return
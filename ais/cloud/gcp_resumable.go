@@ -0,0 +1,332 @@
+// +build gcp
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Objects larger than the configured chunk size (see cmn.CloudConfGCP) are
+// uploaded via resumable upload session:
+// https://cloud.google.com/storage/docs/performing-resumable-uploads
+// The object is sent chunk by chunk; upon a transient error, the session gets
+// queried for the number of bytes persisted so far, and the upload resumes from
+// there - rather than from zero. Retries back off exponentially and give up
+// when the context is done (e.g., past its deadline).
+
+const (
+	gcpUploadURL = "https://storage.googleapis.com/upload/storage/v1/b/"
+
+	gcpStatusResumeIncomplete = http.StatusPermanentRedirect // 308: chunk persisted, upload incomplete
+
+	gcpBackoffMin = time.Second
+	gcpBackoffMax = 32 * time.Second
+)
+
+type (
+	// (subset of) object resource returned upon completing the upload
+	gcpObjResource struct {
+		Generation string `json:"generation"`
+	}
+	gcpUpload struct {
+		ctx        context.Context
+		client     *http.Client
+		objName    string
+		cksum      *cmn.Cksum
+		tag        string // (for logging)
+		sessionURL string
+		size       int64
+		maxRetries int
+	}
+)
+
+func gcpConf() (conf cmn.CloudConfGCP) {
+	if v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderGoogle); ok {
+		if conf, ok = v.(cmn.CloudConfGCP); ok && conf.ChunkSize > 0 {
+			return
+		}
+	}
+	return cmn.CloudConfGCP{ChunkSize: cmn.GCPDefaultChunkSize, MaxRetries: cmn.GCPDefaultMaxRetries}
+}
+
+func (gcpp *gcpProvider) putResumable(ctx context.Context, r io.Reader, lom *cluster.LOM,
+	conf *cmn.CloudConfGCP) (version string, err error, errCode int) {
+	var (
+		obj      *gcpObjResource
+		cloudBck = lom.Bck().RemoteBck()
+		up       = &gcpUpload{
+			ctx:        ctx,
+			objName:    lom.ObjName,
+			cksum:      lom.Cksum(),
+			tag:        lom.String(),
+			size:       lom.Size(),
+			maxRetries: conf.MaxRetries,
+		}
+	)
+	if up.client, err = gcpp.createHTTPClient(ctx); err != nil {
+		return
+	}
+	if err, errCode = up.start(gcpUploadURL, cloudBck.Name); err != nil {
+		if errCode == http.StatusNotFound {
+			err = cmn.NewErrorRemoteBucketDoesNotExist(*cloudBck, gcpp.t.Snode().Name())
+		}
+		return
+	}
+	sgl := gcpp.t.MMSA().NewSGL(conf.ChunkSize)
+	obj, err, errCode = up.upload(r, sgl, conf.ChunkSize)
+	sgl.Free()
+	if err != nil {
+		return
+	}
+	errCode = 0
+	if gen, errV := strconv.ParseInt(obj.Generation, 10, 64); errV == nil {
+		version, _ = cmn.CloudHelpers.Google.EncodeVersion(gen)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[put_object] %s, size %d, version %s (resumable)", lom, up.size, version)
+	}
+	return
+}
+
+// initiate resumable upload session
+func (up *gcpUpload) start(baseURL, bucket string) (err error, errCode int) {
+	var (
+		cksumType, cksumValue = up.cksum.Get()
+		query                 = url.Values{"uploadType": []string{"resumable"}, "name": []string{up.objName}}
+		body                  = cmn.MustMarshal(map[string]interface{}{
+			"name":     up.objName,
+			"metadata": cmn.SimpleKVs{gcpChecksumType: cksumType, gcpChecksumVal: cksumValue},
+		})
+		uploadURL = baseURL + url.PathEscape(bucket) + "/o?" + query.Encode()
+	)
+	for retry := 0; ; retry++ {
+		var (
+			req  *http.Request
+			resp *http.Response
+		)
+		errCode = 0
+		if req, err = http.NewRequestWithContext(up.ctx, http.MethodPost, uploadURL, bytes.NewReader(body)); err != nil {
+			return
+		}
+		req.Header.Set(cmn.HeaderContentType, "application/json; charset=UTF-8")
+		req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(up.size, 10))
+		resp, err = up.client.Do(req)
+		if err == nil {
+			errCode = resp.StatusCode
+			if errCode == http.StatusOK {
+				up.sessionURL = resp.Header.Get("Location")
+				drainBody(resp)
+				if up.sessionURL == "" {
+					err = errors.New("resumable upload: no session URL")
+				}
+				return
+			}
+			err = respError(resp)
+		}
+		if err = up.backoff(retry, err, errCode); err != nil {
+			return
+		}
+	}
+}
+
+// upload reads the object chunk by chunk into the (reused) SGL and sends it;
+// returns the object upon completion.
+// NOTE: the chunk is kept in memory, to be re-sent in part upon transient error
+func (up *gcpUpload) upload(r io.Reader, sgl *memsys.SGL, chunkSize int64) (obj *gcpObjResource, err error,
+	errCode int) {
+	for off := int64(0); off < up.size; {
+		n := cmn.MinI64(chunkSize, up.size-off)
+		sgl.Reset()
+		if _, err = io.CopyN(sgl, r, n); err != nil {
+			return
+		}
+		if obj, err, errCode = up.putChunk(sgl, off); err != nil {
+			return
+		}
+		off += n
+	}
+	if obj == nil {
+		err = fmt.Errorf("%s: resumable upload did not complete", up.tag)
+	}
+	return
+}
+
+// putChunk sends the chunk (at a given offset); returns the object upon
+// completing the upload (i.e., with the last chunk).
+func (up *gcpUpload) putChunk(chunk *memsys.SGL, off int64) (obj *gcpObjResource, err error, errCode int) {
+	var (
+		sent    int64 // bytes of the chunk persisted by GCP
+		size    = chunk.Size()
+		end     = off + size
+		retries int
+	)
+	for sent < size {
+		var (
+			req  *http.Request
+			resp *http.Response
+		)
+		errCode = 0
+		body := memsys.NewSliceReader(chunk, sent, size-sent)
+		if req, err = http.NewRequestWithContext(up.ctx, http.MethodPut, up.sessionURL, body); err != nil {
+			return
+		}
+		req.ContentLength = size - sent
+		req.Header.Set(cmn.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", off+sent, end-1, up.size))
+		if resp, err = up.client.Do(req); err == nil {
+			var (
+				persisted int64
+				done      bool
+			)
+			errCode = resp.StatusCode
+			if obj, persisted, done, err = up.parseStatus(resp); err == nil {
+				if done {
+					return
+				}
+				if persisted > off+sent {
+					sent = persisted - off // progress (as far as GCP is concerned)
+					continue
+				}
+				err = fmt.Errorf("resumable upload: no progress at offset %d", off+sent)
+			}
+		}
+		// transient error: back off, query the session, and resume
+		if err = up.backoff(retries, err, errCode); err != nil {
+			return
+		}
+		retries++
+		var (
+			persisted int64
+			done      bool
+		)
+		if obj, persisted, done, err, errCode = up.query(); err != nil || done {
+			return
+		}
+		if persisted < off || persisted > end {
+			err = fmt.Errorf("resumable upload: unexpected persisted size %d (chunk [%d, %d))", persisted, off, end)
+			return
+		}
+		sent = persisted - off
+	}
+	return
+}
+
+// query the session for the number of bytes persisted so far
+func (up *gcpUpload) query() (obj *gcpObjResource, persisted int64, done bool, err error, errCode int) {
+	for retry := 0; ; retry++ {
+		var (
+			req  *http.Request
+			resp *http.Response
+		)
+		errCode = 0
+		if req, err = http.NewRequestWithContext(up.ctx, http.MethodPut, up.sessionURL, nil); err != nil {
+			return
+		}
+		req.Header.Set(cmn.HeaderContentRange, fmt.Sprintf("bytes */%d", up.size))
+		if resp, err = up.client.Do(req); err == nil {
+			errCode = resp.StatusCode
+			if obj, persisted, done, err = up.parseStatus(resp); err == nil {
+				return
+			}
+		}
+		if err = up.backoff(retry, err, errCode); err != nil {
+			return
+		}
+	}
+}
+
+// parseStatus consumes the response to a chunk PUT or a status query
+func (up *gcpUpload) parseStatus(resp *http.Response) (obj *gcpObjResource, persisted int64, done bool, err error) {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		obj = &gcpObjResource{}
+		err = jsoniter.NewDecoder(resp.Body).Decode(obj)
+		drainBody(resp)
+		return obj, up.size, err == nil, err
+	case gcpStatusResumeIncomplete:
+		drainBody(resp)
+		// e.g. "Range: bytes=0-524287" (no header - nothing persisted yet)
+		if rng := resp.Header.Get("Range"); rng != "" {
+			i := strings.LastIndexByte(rng, '-')
+			if i < 0 {
+				return nil, 0, false, fmt.Errorf("resumable upload: invalid range %q", rng)
+			}
+			if persisted, err = strconv.ParseInt(rng[i+1:], 10, 64); err != nil {
+				return nil, 0, false, fmt.Errorf("resumable upload: invalid range %q", rng)
+			}
+			persisted++
+		}
+		return
+	default:
+		return nil, 0, false, respError(resp)
+	}
+}
+
+// backoff returns nil if the error is transient and the retry is due; otherwise,
+// returns the error itself (or the context's error)
+func (up *gcpUpload) backoff(retry int, err error, status int) error {
+	if !gcpTransient(up.ctx, err, status) {
+		return err
+	}
+	if retry >= up.maxRetries {
+		return fmt.Errorf("%s: resumable upload failed after %d retries: %v", up.tag, retry, err)
+	}
+	d := gcpBackoffMin << uint(retry)
+	if d > gcpBackoffMax {
+		d = gcpBackoffMax
+	}
+	glog.Warningf("%s: resumable upload: %v - retrying in %v", up.tag, err, d)
+	select {
+	case <-time.After(d):
+		return nil
+	case <-up.ctx.Done():
+		return fmt.Errorf("%s: resumable upload: %v (last error: %v)", up.tag, up.ctx.Err(), err)
+	}
+}
+
+// network errors and 408, 429, 5xx (but not when the context is done)
+func gcpTransient(ctx context.Context, err error, status int) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch {
+	case status == http.StatusRequestTimeout || status == http.StatusTooManyRequests:
+		return true
+	case status >= http.StatusInternalServerError:
+		return true
+	case status == 0 || status == gcpStatusResumeIncomplete:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+func respError(resp *http.Response) error {
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+}
+
+func drainBody(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,155 @@
+// +build gcp
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// gcpSession emulates (the relevant subset of) GCS resumable upload session
+type gcpSession struct {
+	mu       sync.Mutex
+	data     []byte
+	size     int64
+	failures int // number of chunk PUTs to persist only in part and fail with 503
+	puts     int
+	queries  int
+}
+
+func (s *gcpSession) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	// "bytes */<size>" - status query; otherwise "bytes <first>-<last>/<size>"
+	cr := strings.TrimPrefix(r.Header.Get(cmn.HeaderContentRange), "bytes ")
+	if strings.HasPrefix(cr, "*") {
+		s.queries++
+		s.status(w)
+		return
+	}
+	s.puts++
+	var first, last, size int64
+	if _, err := fmt.Sscanf(cr, "%d-%d/%d", &first, &last, &size); err != nil ||
+		first != int64(len(s.data)) || last-first+1 != int64(len(body)) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if s.failures > 0 {
+		s.failures--
+		s.data = append(s.data, body[:len(body)/2]...)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	s.data = append(s.data, body...)
+	s.status(w)
+}
+
+func (s *gcpSession) status(w http.ResponseWriter) {
+	if int64(len(s.data)) == s.size {
+		w.Write([]byte(`{"generation": "1234"}`))
+		return
+	}
+	if len(s.data) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.data)-1))
+	}
+	w.WriteHeader(gcpStatusResumeIncomplete)
+}
+
+func TestGCPResumableUpload(t *testing.T) {
+	const chunkSize = 64 * cmn.KiB
+	tests := []struct {
+		name     string
+		size     int64
+		failures int
+		puts     int
+		queries  int
+	}{
+		{name: "single-chunk", size: chunkSize / 2, puts: 1},
+		{name: "multi-chunk", size: 3*chunkSize + 100, puts: 4},
+		// each failure: partial PUT, status query, and the PUT of the rest of the chunk
+		{name: "resume", size: 3*chunkSize + 100, failures: 2, puts: 6, queries: 2},
+	}
+	mm := memsys.DefaultPageMM()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				session = &gcpSession{failures: test.failures}
+				srv     = httptest.NewServer(http.HandlerFunc(session.handler))
+				data    = []byte(cmn.RandString(int(test.size)))
+				up      = &gcpUpload{
+					ctx:        context.Background(),
+					client:     srv.Client(),
+					objName:    "obj",
+					cksum:      cmn.NewCksum(cmn.ChecksumXXHash, "01234567"),
+					tag:        "bck/obj",
+					size:       test.size,
+					maxRetries: 2,
+				}
+				sgl = mm.NewSGL(chunkSize)
+			)
+			defer srv.Close()
+			defer sgl.Free()
+
+			session.size, up.sessionURL = test.size, srv.URL+"/session" // (as if started)
+			obj, err, _ := up.upload(bytes.NewReader(data), sgl, chunkSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if obj.Generation != "1234" {
+				t.Errorf("expected generation 1234, got %q", obj.Generation)
+			}
+			if !bytes.Equal(session.data, data) {
+				t.Errorf("uploaded data differs (%d vs %d bytes)", len(session.data), len(data))
+			}
+			if session.puts != test.puts || session.queries != test.queries {
+				t.Errorf("expected %d PUTs and %d queries, got %d and %d",
+					test.puts, test.queries, session.puts, session.queries)
+			}
+		})
+	}
+}
+
+func TestGCPTransient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tests := []struct {
+		err       error
+		status    int
+		transient bool
+	}{
+		{status: http.StatusServiceUnavailable, transient: true},
+		{status: http.StatusTooManyRequests, transient: true},
+		{status: http.StatusRequestTimeout, transient: true},
+		{status: http.StatusNotFound, transient: false},
+		{status: http.StatusForbidden, transient: false},
+		{err: fmt.Errorf("connection reset"), transient: true},
+		{err: fmt.Errorf("no progress"), status: gcpStatusResumeIncomplete, transient: true},
+	}
+	for _, test := range tests {
+		if transient := gcpTransient(ctx, test.err, test.status); transient != test.transient {
+			t.Errorf("(%v, %d): expected transient=%t", test.err, test.status, test.transient)
+		}
+	}
+	cancel()
+	if gcpTransient(ctx, nil, http.StatusServiceUnavailable) {
+		t.Error("expected no retries once the context is done")
+	}
+}
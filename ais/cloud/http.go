@@ -44,6 +44,8 @@ func NewHTTP(t cluster.Target, config *cmn.Config) (cluster.CloudProvider, error
 		UseHTTPS:        true,
 		SkipVerify:      config.Net.HTTP.SkipVerify,
 	})
+	hp.httpClient.CheckRedirect = cmn.CheckRedirect
+	hp.httpsClient.CheckRedirect = cmn.CheckRedirect
 	return hp, nil
 }
 
@@ -54,6 +56,15 @@ func (hp *httpProvider) client(u string) *http.Client {
 	return hp.httpClient
 }
 
+// head issues HEAD request on behalf of the bucket (see residencyCtx).
+func (hp *httpProvider) head(bck *cluster.Bck, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(residencyCtx(bck), http.MethodHead, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return hp.client(u).Do(req)
+}
+
 func (hp *httpProvider) Provider() string  { return cmn.ProviderHTTP }
 func (hp *httpProvider) MaxPageSize() uint { return 10000 }
 
@@ -103,9 +114,9 @@ func (hp *httpProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckPr
 	}
 
 	// Contact the original URL - as long as we can make connection we assume it's good.
-	resp, err := hp.client(origURL).Head(origURL)
+	resp, err := hp.head(bck, origURL)
 	if err != nil {
-		return nil, err, http.StatusBadRequest
+		return nil, err, residencyErrCode(err, http.StatusBadRequest)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -139,9 +150,9 @@ func (hp *httpProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta
 		glog.Infof("[head_object] original_url: %q", origURL)
 	}
 
-	resp, err := hp.client(origURL).Head(origURL)
+	resp, err := hp.head(bck, origURL)
 	if err != nil {
-		return nil, err, http.StatusBadRequest
+		return nil, err, residencyErrCode(err, http.StatusBadRequest)
 	}
 	resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
@@ -197,11 +208,19 @@ func (hp *httpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (rea
 		glog.Infof("[HTTP CLOUD][GET] original_url: %q", origURL)
 	}
 
-	resp, err := hp.client(origURL).Get(origURL) // nolint:bodyclose // is closed by the caller
+	req, err := http.NewRequestWithContext(residencyCtx(bck), http.MethodGet, origURL, nil)
 	if err != nil {
 		return nil, nil, err, http.StatusInternalServerError
 	}
-	if resp.StatusCode != http.StatusOK {
+	rng := readRange(ctx)
+	if rng != nil {
+		req.Header.Set(cmn.HeaderRange, rng.HdrVal())
+	}
+	resp, err := hp.client(origURL).Do(req) // nolint:bodyclose // is closed by the caller
+	if err != nil {
+		return nil, nil, err, residencyErrCode(err, http.StatusInternalServerError)
+	}
+	if resp.StatusCode != http.StatusOK && (rng == nil || resp.StatusCode != http.StatusPartialContent) {
 		return nil, nil, fmt.Errorf("error occurred: %v", resp.StatusCode), resp.StatusCode
 	}
 
@@ -0,0 +1,132 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// residencyProvider enforces the bucket's data residency constraints
+// (cmn.ResidencyConf) for all Cloud operations of the wrapped provider:
+// HTTP buckets are checked against the allowlisted hosts, all other Cloud
+// buckets - against the allowlisted regions.
+type residencyProvider struct {
+	cluster.CloudProvider
+}
+
+// interface guard
+var _ cluster.CloudProvider = &residencyProvider{}
+
+// residencyCtx returns the context to issue the bucket's HTTP requests with
+// (see cmn.CheckRedirect).
+func residencyCtx(bck *cluster.Bck) context.Context {
+	ctx := context.Background()
+	if bck.Props == nil || !bck.Props.Residency.Enabled() {
+		return ctx
+	}
+	return context.WithValue(ctx, cmn.CtxResidency, &bck.Props.Residency)
+}
+
+// residencyErrCode returns http.StatusForbidden if err is (or wraps) the residency
+// violation, and the given code otherwise.
+func residencyErrCode(err error, errCode int) int {
+	var rerr *cmn.ResidencyError
+	if errors.As(err, &rerr) {
+		return http.StatusForbidden
+	}
+	return errCode
+}
+
+func WithResidency(c cluster.CloudProvider) cluster.CloudProvider {
+	return &residencyProvider{c}
+}
+
+func (rp *residencyProvider) check(ctx context.Context, bck *cluster.Bck, objName string) (error, int) {
+	if bck.Props == nil || !bck.Props.Residency.Enabled() {
+		return nil, 0
+	}
+	var err error
+	if bck.IsHTTP() {
+		var origURL string
+		if origURL, err = getOriginalURL(ctx, bck, objName); err == nil {
+			err = bck.Props.Residency.AllowURL(origURL)
+		}
+	} else if !bck.IsRemoteAIS() {
+		err = bck.Props.Residency.AllowRegion(bck.Props.Extra.CloudRegion)
+	}
+	if err != nil {
+		return err, http.StatusForbidden
+	}
+	return nil, 0
+}
+
+func (rp *residencyProvider) GetObj(ctx context.Context, fqn string, lom *cluster.LOM) (error, int) {
+	if err, errCode := rp.check(ctx, lom.Bck(), lom.ObjName); err != nil {
+		return err, errCode
+	}
+	return rp.CloudProvider.GetObj(ctx, fqn, lom)
+}
+
+func (rp *residencyProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (io.ReadCloser, *cmn.Cksum,
+	error, int) {
+	if err, errCode := rp.check(ctx, lom.Bck(), lom.ObjName); err != nil {
+		return nil, nil, err, errCode
+	}
+	return rp.CloudProvider.GetObjReader(ctx, lom)
+}
+
+func (rp *residencyProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (string, error, int) {
+	if err, errCode := rp.check(ctx, lom.Bck(), lom.ObjName); err != nil {
+		return "", err, errCode
+	}
+	return rp.CloudProvider.PutObj(ctx, r, lom)
+}
+
+func (rp *residencyProvider) DeleteObj(ctx context.Context, lom *cluster.LOM) (error, int) {
+	if err, errCode := rp.check(ctx, lom.Bck(), lom.ObjName); err != nil {
+		return err, errCode
+	}
+	return rp.CloudProvider.DeleteObj(ctx, lom)
+}
+
+func (rp *residencyProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (cmn.SimpleKVs, error, int) {
+	if err, errCode := rp.check(ctx, lom.Bck(), lom.ObjName); err != nil {
+		return nil, err, errCode
+	}
+	return rp.CloudProvider.HeadObj(ctx, lom)
+}
+
+func (rp *residencyProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (cmn.SimpleKVs, error, int) {
+	if bck.Props == nil || !bck.Props.Residency.Enabled() {
+		return rp.CloudProvider.HeadBucket(ctx, bck)
+	}
+	if bck.IsHTTP() {
+		if err, errCode := rp.check(ctx, bck, ""); err != nil {
+			return nil, err, errCode
+		}
+	}
+	bckProps, err, errCode := rp.CloudProvider.HeadBucket(ctx, bck)
+	if err == nil && !bck.IsHTTP() && !bck.IsRemoteAIS() {
+		// the region as reported by the Cloud (rather than the one stored in BMD)
+		if err = bck.Props.Residency.AllowRegion(bckProps[cmn.HeaderCloudRegion]); err != nil {
+			return nil, err, http.StatusForbidden
+		}
+	}
+	return bckProps, err, errCode
+}
+
+func (rp *residencyProvider) ListObjects(ctx context.Context, bck *cluster.Bck,
+	msg *cmn.SelectMsg) (*cmn.BucketList, error, int) {
+	if err, errCode := rp.check(ctx, bck, ""); err != nil {
+		return nil, err, errCode
+	}
+	return rp.CloudProvider.ListObjects(ctx, bck, msg)
+}
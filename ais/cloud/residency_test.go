@@ -0,0 +1,61 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestResidencyRedirect(t *testing.T) {
+	// the origin (127.0.0.1) is allowlisted while the redirect target
+	// (same server, addressed as localhost) is not
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			u := "http://" + strings.Replace(r.Host, "127.0.0.1", "localhost", 1) + "/obj"
+			http.Redirect(w, r, u, http.StatusFound)
+			return
+		}
+		w.Header().Set(cmn.HeaderETag, "etag")
+	}))
+	defer srv.Close()
+
+	cp, err := NewHTTP(nil, &cmn.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hp := cp.(*httpProvider)
+	head := func(hosts, path string) error {
+		bck := cluster.NewBck("bck", cmn.ProviderHTTP, cmn.NsGlobal)
+		bck.Props = &cmn.BucketProps{Residency: cmn.ResidencyConf{Hosts: hosts}}
+		resp, err := hp.head(bck, srv.URL+path)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := head("", "/redirect"); err != nil {
+		t.Errorf("residency disabled: unexpected error %v", err)
+	}
+	if err := head("127.0.0.1", "/obj"); err != nil {
+		t.Errorf("allowlisted host: unexpected error %v", err)
+	}
+	err = head("127.0.0.1", "/redirect")
+	if err == nil {
+		t.Fatal("expected redirect to a non-allowlisted host to fail")
+	}
+	if code := residencyErrCode(err, http.StatusBadRequest); code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d (%v)", http.StatusForbidden, code, err)
+	}
+	if err := head("127.0.0.1,localhost", "/redirect"); err != nil {
+		t.Errorf("allowlisted redirect: unexpected error %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Checking connectivity and credentials of the Cloud providers (backends)
+// configured in the cluster: the primary broadcasts the request to all targets,
+// and each target probes each provider (see cloudHealth). A target that fails
+// to respond gets reported with an error (and no provider).
+
+func (p *proxyrunner) queryCloudHealth(w http.ResponseWriter, r *http.Request, what string) {
+	query := r.URL.Query()
+	if _, err := parseCloudProbes(query); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	config := cmn.GCO.Get()
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  query,
+		},
+		timeout: config.Client.ListObjects + config.Timeout.CplaneOperation,
+		fv:      func() interface{} { return &[]cmn.CloudHealth{} },
+	})
+	out := make(cmn.CloudHealthResults, len(results))
+	for res := range results {
+		if res.err != nil {
+			out[res.si.ID()] = []cmn.CloudHealth{{Err: res.err.Error()}}
+			continue
+		}
+		out[res.si.ID()] = *res.v.(*[]cmn.CloudHealth)
+	}
+	p.writeJSON(w, r, out, what)
+}
+
+// probe buckets, if specified, one per provider
+func parseCloudProbes(query url.Values) (map[string]cmn.Bck, error) {
+	probes := make(map[string]cmn.Bck, 4)
+	for _, uri := range query[cmn.URLParamProbe] {
+		bck, _, err := cmn.ParseBckObjectURI(uri)
+		if err != nil {
+			return nil, err
+		}
+		if bck.Name == "" || (!bck.IsCloud() && !bck.IsRemoteAIS()) {
+			return nil, fmt.Errorf("invalid probe bucket %q: expecting Cloud or remote AIS bucket", uri)
+		}
+		if _, ok := probes[bck.Provider]; ok {
+			return nil, fmt.Errorf("duplicate probe bucket for provider %q", bck.Provider)
+		}
+		probes[bck.Provider] = bck
+	}
+	return probes, nil
+}
+
+// cloudHealth probes all configured Cloud providers (and attached remote AIS
+// clusters, if any) in parallel. Each provider is probed by listing a single
+// page of its probe bucket - the one specified by the user or else the first
+// (by name) bucket of this provider in the BMD. If there's none, the probe
+// lists the provider's buckets.
+func (t *targetrunner) cloudHealth(query url.Values) ([]cmn.CloudHealth, error) {
+	probes, err := parseCloudProbes(query)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config    = cmn.GCO.Get()
+		providers = make([]string, 0, len(config.Cloud.Providers)+1)
+	)
+	for provider := range config.Cloud.Providers {
+		providers = append(providers, provider)
+	}
+	if _, ok := config.Cloud.ProviderConf(cmn.ProviderAIS); ok {
+		providers = append(providers, cmn.ProviderAIS)
+	}
+	sort.Strings(providers)
+	var (
+		results = make([]cmn.CloudHealth, len(providers))
+		bmd     = t.owner.bmd.get()
+		wg      = &sync.WaitGroup{}
+	)
+	for i, provider := range providers {
+		bck, ok := probes[provider]
+		if !ok {
+			bck = probeBucket(bmd, provider)
+		}
+		wg.Add(1)
+		go func(i int, provider string, bck cmn.Bck) {
+			results[i] = t.probeCloud(provider, bck, config)
+			wg.Done()
+		}(i, provider, bck)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func probeBucket(bmd *bucketMD, provider string) (probe cmn.Bck) {
+	bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
+		if bck.IsCloud() || bck.IsRemoteAIS() {
+			if probe.Name == "" || bck.Name < probe.Name {
+				probe = bck.Bck
+			}
+		}
+		return false
+	})
+	return
+}
+
+func (t *targetrunner) probeCloud(provider string, bck cmn.Bck, config *cmn.Config) (res cmn.CloudHealth) {
+	var (
+		err     error
+		ctx, cn = context.WithTimeout(context.Background(), config.Client.ListObjects)
+		started = mono.NanoTime()
+		cloud   = t.cloud[provider]
+	)
+	defer cn()
+	res.Provider = provider
+	if bck.Name != "" {
+		res.Bck = bck.String()
+		_, err, _ = cloud.ListObjects(ctx, cluster.NewBckEmbed(bck), &cmn.SelectMsg{PageSize: 1})
+	} else {
+		query := cmn.QueryBcks{Provider: provider}
+		if provider == cmn.ProviderAIS {
+			query.Ns = cmn.NsAnyRemote
+		}
+		_, err, _ = cloud.ListBuckets(ctx, query)
+	}
+	res.Latency = mono.Since(started)
+	if err != nil {
+		res.Err = err.Error()
+	}
+	return
+}
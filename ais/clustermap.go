@@ -67,8 +67,9 @@ type (
 		nid    string
 		sid    string
 		flags  cluster.SnodeFlags
-		status int
-		exists bool
+		status  int
+		exists  bool
+		prefill bool // new target joins in prefill mode (see prxprefill.go)
 	}
 )
 
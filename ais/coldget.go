@@ -0,0 +1,278 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Cold GET admission: the cluster-wide `cold_get.max_concurrent` budget is
+// split equally between the targets; each target runs up to its share of
+// concurrent cold GETs (GET, prefetch, download) and queues the rest per bucket.
+// Whenever a slot frees up, it goes to the waiting bucket that has the fewest
+// running cold GETs relative to its weight (bucket property `cold_get.weight`),
+// ties broken in favor of the oldest waiter. That is, a cache-miss storm in one
+// bucket cannot consume the entire budget and starve the others. In addition,
+// a bucket can be capped by its own (cluster-wide) `cold_get.max_concurrent`.
+// Cold GET that waits longer than `cold_get.max_wait` fails with 503.
+
+const retryAfterColdGet = 5 * time.Second
+
+type (
+	coldGetQueue struct {
+		mu        sync.Mutex
+		bcks      map[string]*coldGetBck // by bucket's uname
+		running   int
+		limit     int // this target's share of the cluster-wide budget (0 - unlimited)
+		targetCnt int
+		seq       int64
+	}
+	coldGetBck struct {
+		waiting []*coldGetWaiter
+		running int
+		limit   int // this target's share of the bucket's budget (0 - unlimited)
+		weight  int
+	}
+	coldGetWaiter struct {
+		ch  chan struct{} // closed upon admission
+		seq int64
+	}
+	// objects being fetched in background (see coldGetBackground)
+	coldGetFetches struct {
+		mu sync.Mutex
+		m  map[string]struct{} // by object's uname
+	}
+)
+
+var (
+	coldGets   = &coldGetQueue{bcks: make(map[string]*coldGetBck)}
+	coldGetsBg = &coldGetFetches{m: make(map[string]struct{})}
+)
+
+// returns a given target's share of a cluster-wide limit
+func perTargetLimit(limit, targetCnt int) int {
+	if limit <= 0 || targetCnt <= 1 {
+		return limit
+	}
+	return (limit + targetCnt - 1) / targetCnt
+}
+
+func (t *targetrunner) acquireColdGet(ctx context.Context, lom *cluster.LOM) (release func(), err error, errCode int) {
+	var (
+		conf   = &cmn.GCO.Get().ColdGet
+		queued bool
+	)
+	release, queued, err = coldGets.acquire(ctx, lom.Bck(), conf, t.owner.smap.get().CountTargets())
+	if queued {
+		t.statsT.Add(stats.GetColdQueuedCount, 1)
+	}
+	if err != nil {
+		t.statsT.Add(stats.GetColdRejectCount, 1)
+		return nil, fmt.Errorf("%s: %v", lom, err), http.StatusServiceUnavailable
+	}
+	return release, nil, 0
+}
+
+// acquire blocks until the cold GET is admitted (see above); the caller must call
+// `release` when done
+func (q *coldGetQueue) acquire(ctx context.Context, bck *cluster.Bck, conf *cmn.ColdGetConf,
+	targetCnt int) (release func(), queued bool, err error) {
+	var (
+		uname = bck.MakeUname("")
+		w     *coldGetWaiter
+	)
+	q.mu.Lock()
+	q.targetCnt = targetCnt
+	q.limit = perTargetLimit(conf.MaxConcurrent, targetCnt)
+	b, ok := q.bcks[uname]
+	if !ok {
+		b = &coldGetBck{}
+		q.bcks[uname] = b
+	}
+	if bck.Props != nil {
+		b.limit = perTargetLimit(bck.Props.ColdGet.MaxConcurrent, targetCnt)
+		b.weight = bck.Props.ColdGet.EffectiveWeight()
+	} else {
+		b.limit, b.weight = 0, 1
+	}
+	release = func() { q.release(uname) }
+	if len(b.waiting) == 0 && q.fits(b) {
+		q.running++
+		b.running++
+		q.mu.Unlock()
+		return
+	}
+	q.seq++
+	w = &coldGetWaiter{ch: make(chan struct{}), seq: q.seq}
+	b.waiting = append(b.waiting, w)
+	q.mu.Unlock()
+
+	queued = true
+	var timeout <-chan time.Time
+	if conf.MaxWait > 0 {
+		timer := time.NewTimer(conf.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-w.ch:
+		return
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		err = fmt.Errorf("timed out waiting for cold GET (max %d concurrent) after %v", q.limit, conf.MaxWait)
+	}
+	q.mu.Lock()
+	admitted := q.remove(uname, w)
+	q.mu.Unlock()
+	if admitted { // raced with the timeout - proceed
+		err = nil
+	}
+	return
+}
+
+func (q *coldGetQueue) release(uname string) {
+	q.mu.Lock()
+	q.running--
+	b := q.bcks[uname]
+	b.running--
+	q.schedule()
+	if b.running == 0 && len(b.waiting) == 0 {
+		delete(q.bcks, uname)
+	}
+	q.mu.Unlock()
+}
+
+// ConfigUpdate implements cmn.ConfigListener - the limit may have been raised
+func (q *coldGetQueue) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if oldConf.ColdGet.MaxConcurrent == newConf.ColdGet.MaxConcurrent {
+		return
+	}
+	q.mu.Lock()
+	q.limit = perTargetLimit(newConf.ColdGet.MaxConcurrent, q.targetCnt)
+	q.schedule()
+	q.mu.Unlock()
+}
+
+// under lock
+func (q *coldGetQueue) fits(b *coldGetBck) bool {
+	if q.limit > 0 && q.running >= q.limit {
+		return false
+	}
+	return b.limit == 0 || b.running < b.limit
+}
+
+// under lock: admit waiters, one at a time, from the bucket that has the
+// fewest running cold GETs relative to its weight
+func (q *coldGetQueue) schedule() {
+	for {
+		var next *coldGetBck
+		for _, b := range q.bcks {
+			if len(b.waiting) == 0 || !q.fits(b) {
+				continue
+			}
+			if next == nil || b.before(next) {
+				next = b
+			}
+		}
+		if next == nil {
+			return
+		}
+		w := next.waiting[0]
+		next.waiting[0] = nil
+		next.waiting = next.waiting[1:]
+		q.running++
+		next.running++
+		close(w.ch)
+	}
+}
+
+// under lock: returns true if the waiter has already been admitted
+func (q *coldGetQueue) remove(uname string, w *coldGetWaiter) (admitted bool) {
+	b := q.bcks[uname]
+	for i, x := range b.waiting {
+		if x == w {
+			b.waiting = append(b.waiting[:i], b.waiting[i+1:]...)
+			if b.running == 0 && len(b.waiting) == 0 {
+				delete(q.bcks, uname)
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// weighted fair share: b.running/b.weight < o.running/o.weight
+func (b *coldGetBck) before(o *coldGetBck) bool {
+	l, r := b.running*o.weight, o.running*b.weight
+	if l != r {
+		return l < r
+	}
+	return b.waiting[0].seq < o.waiting[0].seq
+}
+
+////////////////////
+// coldGetFetches //
+////////////////////
+
+// returns false if the object is already being fetched
+func (f *coldGetFetches) start(uname string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.m[uname]; ok {
+		return false
+	}
+	f.m[uname] = struct{}{}
+	return true
+}
+
+func (f *coldGetFetches) done(uname string) {
+	f.mu.Lock()
+	delete(f.m, uname)
+	f.mu.Unlock()
+}
+
+// Ranged GET of an object that is not present locally reads the range directly
+// from the cloud (see getColdRange) - the entire object then gets fetched and
+// cached in background (at most once at a time) as any other cold GET would.
+func (t *targetrunner) coldGetBackground(lom *cluster.LOM) {
+	var (
+		uname   = lom.Uname()
+		bck     = lom.Bck().Bck
+		objName = lom.ObjName
+	)
+	if !coldGetsBg.start(uname) {
+		return
+	}
+	go func() {
+		defer coldGetsBg.done(uname)
+		if cs := fs.GetCapStatus(); cs.OOS {
+			return
+		}
+		lom := &cluster.LOM{T: t, ObjName: objName}
+		if err := lom.Init(bck); err != nil {
+			return
+		}
+		lom.Lock(false)
+		err := lom.Load()
+		lom.Unlock(false)
+		if err == nil {
+			return // cached in the meantime
+		}
+		if err, _ := t.GetCold(context.Background(), lom, true /*prefetch*/); err != nil && err != cmn.ErrSkip {
+			glog.Errorf("%s: failed to cache upon ranged GET, err: %v", lom, err)
+		}
+	}()
+}
@@ -0,0 +1,113 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestColdGetFairShare(t *testing.T) {
+	var (
+		q    = &coldGetQueue{bcks: make(map[string]*coldGetBck)}
+		conf = &cmn.ColdGetConf{MaxConcurrent: 8}
+		ctx  = context.Background()
+		bckA = cluster.NewBck("a", cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{})
+		bckB = cluster.NewBck("b", cmn.ProviderAmazon, cmn.NsGlobal,
+			&cmn.BucketProps{ColdGet: cmn.ColdGetShareConf{Weight: 3}})
+	)
+	// 2 targets: this target's share is 4
+	releaseA := make([]func(), 0, 4)
+	for i := 0; i < 4; i++ {
+		release, queued, err := q.acquire(ctx, bckA, conf, 2)
+		if err != nil || queued {
+			t.Fatalf("expected immediate admission, got queued=%t, err=%v", queued, err)
+		}
+		releaseA = append(releaseA, release)
+	}
+	// the storm continues while the other bucket starts cold-GETting
+	admitted := make(chan string, 16)
+	for i := 0; i < 4; i++ {
+		go func() {
+			release, _, err := q.acquire(ctx, bckA, conf, 2)
+			if err == nil {
+				admitted <- "a"
+				defer release()
+				time.Sleep(time.Hour) // hold the slot
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // let bucket "a" queue up first
+	for i := 0; i < 4; i++ {
+		go func() {
+			release, _, err := q.acquire(ctx, bckB, conf, 2)
+			if err == nil {
+				admitted <- "b"
+				defer release()
+				time.Sleep(time.Hour)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// freed slots go to "b" until its running/weight exceeds that of "a" (3/3 > 0/1)
+	expected := []string{"b", "b", "b", "a"}
+	for i, release := range releaseA {
+		release()
+		if got := <-admitted; got != expected[i] {
+			t.Fatalf("slot %d: expected bucket %q, got %q", i, expected[i], got)
+		}
+	}
+}
+
+func TestColdGetBucketLimitAndTimeout(t *testing.T) {
+	var (
+		q    = &coldGetQueue{bcks: make(map[string]*coldGetBck)}
+		conf = &cmn.ColdGetConf{MaxWait: 20 * time.Millisecond}
+		ctx  = context.Background()
+		bck  = cluster.NewBck("a", cmn.ProviderAmazon, cmn.NsGlobal,
+			&cmn.BucketProps{ColdGet: cmn.ColdGetShareConf{MaxConcurrent: 1}})
+	)
+	release, _, err := q.acquire(ctx, bck, conf, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, queued, err := q.acquire(ctx, bck, conf, 1); err == nil || !queued {
+		t.Fatalf("expected timeout, got queued=%t, err=%v", queued, err)
+	}
+	release()
+	if len(q.bcks) != 0 || q.running != 0 {
+		t.Fatalf("expected empty queue, got %d buckets, %d running", len(q.bcks), q.running)
+	}
+}
+
+func TestPerTargetLimit(t *testing.T) {
+	tests := []struct{ limit, targetCnt, expected int }{
+		{0, 10, 0}, {10, 1, 10}, {10, 3, 4}, {10, 20, 1},
+	}
+	for _, test := range tests {
+		if got := perTargetLimit(test.limit, test.targetCnt); got != test.expected {
+			t.Errorf("perTargetLimit(%d, %d): expected %d, got %d", test.limit, test.targetCnt, test.expected, got)
+		}
+	}
+}
+
+func TestColdGetFetches(t *testing.T) {
+	f := &coldGetFetches{m: make(map[string]struct{})}
+	if !f.start("a") || !f.start("b") {
+		t.Fatal("expected to start fetching")
+	}
+	if f.start("a") {
+		t.Fatal("expected at most one fetch of the same object at a time")
+	}
+	f.done("a")
+	if !f.start("a") {
+		t.Fatal("expected to start fetching once the previous fetch is done")
+	}
+}
@@ -35,6 +35,7 @@ const (
 	xtargetkeepalive = "targetkeepalive"
 	xmetasyncer      = "metasyncer"
 	xfshc            = "fshc"
+	xscrub           = "scrub"
 	xhk              = "housekeeper"
 )
 
@@ -62,6 +63,8 @@ type (
 		transient   bool   // false: make cmn.ConfigCLI settings permanent, true: leave them transient
 		skipStartup bool   // determines if the proxy should skip waiting for targets
 		ntargets    int    // expected number of targets in a starting-up cluster (proxy only)
+		shadow      bool   // read-only shadow of the target running on the same host (see tgtshadow.go)
+		shadowPort  int    // shadow's public port (the shadowed target keeps listening on its own)
 	}
 
 	// daemon instance: proxy or storage target
@@ -146,6 +149,11 @@ func init() {
 		"determines if primary proxy should skip waiting for target registrations when starting up")
 	flag.IntVar(&daemon.cli.ntargets, "ntargets", 0, "number of storage targets to expect at startup (hint, proxy-only)")
 
+	// blue-green upgrade
+	flag.BoolVar(&daemon.cli.shadow, "shadow", false,
+		"start read-only shadow of the target running on the same host (same config and mountpaths, target-only)")
+	flag.IntVar(&daemon.cli.shadowPort, "shadow_port", 0, "public port of the shadow (requires -shadow)")
+
 	// dry-run
 	flag.BoolVar(&daemon.dryRun.disk, "nodiskio", false, "dry-run: if true, no disk operations for GET and PUT")
 	flag.StringVar(&daemon.dryRun.sizeStr, "dryobjsize", "8m", "dry-run: in-memory random content")
@@ -191,6 +199,15 @@ func initDaemon(version, build string) (rmain cmn.Runner) {
 		str += "Usage: aisnode -role=<proxy|target> -config=</dir/config.json> ..."
 		cmn.ExitLogf(str)
 	}
+	if daemon.cli.shadow {
+		if daemon.cli.role != cmn.Target {
+			cmn.ExitLogf("Invalid flag `shadow`: only targets can run in shadow mode")
+		}
+		if daemon.cli.shadowPort <= 0 {
+			cmn.ExitLogf("Missing (or invalid) `shadow_port` flag: %d", daemon.cli.shadowPort)
+		}
+		daemon.cli.transient = true // never overwrite config of the shadowed target
+	}
 	jsp.MustLoadConfig(daemon.cli.confPath)
 
 	// even more config changes, e.g:
@@ -266,7 +283,7 @@ func initProxy() cmn.Runner {
 	daemon.rg.add(p, cmn.Proxy)
 
 	ps := &stats.Prunner{}
-	startedUp := ps.Init(p)
+	startedUp := ps.Init(p, p.gmm)
 	daemon.rg.add(ps, xproxystats)
 
 	daemon.rg.add(newProxyKeepaliveRunner(p, ps, startedUp), xproxykeepalive)
@@ -288,17 +305,19 @@ func initTarget() cmn.Runner {
 	t.initHostIP()
 	daemon.rg.add(t, cmn.Target)
 
-	ts := &stats.Trunner{T: t} // iostat below
+	ts := &stats.Trunner{T: t, RebStarted: t.rebStarted} // iostat below
 	startedUp := ts.Init(t)
 	daemon.rg.add(ts, xstorstats)
 
-	daemon.rg.add(newTargetKeepaliveRunner(t, ts, startedUp), xtargetkeepalive)
+	if !daemon.cli.shadow {
+		daemon.rg.add(newTargetKeepaliveRunner(t, ts, startedUp), xtargetkeepalive)
 
-	t.fsprg.init(t) // subgroup of the daemon.rg rungroup
+		t.fsprg.init(t) // subgroup of the daemon.rg rungroup
 
-	// Stream Collector - a singleton object with responsibilities that include:
-	sc := transport.Init()
-	daemon.rg.add(sc, xstreamc)
+		// Stream Collector - a singleton object with responsibilities that include:
+		sc := transport.Init()
+		daemon.rg.add(sc, xstreamc)
+	}
 
 	// fs.Mountpaths must be inited prior to all runners that utilize them
 	// for mountpath definition, see fs/mountfs.go
@@ -312,16 +331,22 @@ func initTarget() cmn.Runner {
 		for path := range config.FSpaths.Paths {
 			fsPaths = append(fsPaths, path)
 		}
-		if err := fs.SetMountpaths(fsPaths); err != nil {
+		if err := fs.SetMountpaths(fsPaths, config.FSpaths.Labels); err != nil {
 			cmn.ExitLogf("%s", err)
 		}
 	}
+	if !daemon.cli.shadow { // (volume metadata belongs to the shadowed target)
+		t.checkVMD(config)
+	}
 
-	fshc := health.NewFSHC(t, t.gmm, fs.CSM)
-	daemon.rg.add(fshc, xfshc)
+	if !daemon.cli.shadow {
+		fshc := health.NewFSHC(t, t.gmm, fs.CSM)
+		daemon.rg.add(fshc, xfshc)
+		daemon.rg.add(health.NewScrubber(t, ts), xscrub)
 
-	housekeep, initialInterval := cluster.LomCacheHousekeep(t.gmm, t)
-	hk.Reg("lom-cache", housekeep, initialInterval)
+		housekeep, initialInterval := cluster.LomCacheHousekeep(t.gmm, t)
+		hk.Reg("lom-cache", housekeep, initialInterval)
+	}
 	if err := ts.InitCapacity(); err != nil { // goes after fs.Init
 		cmn.ExitLogf("%s", err)
 	}
@@ -337,7 +362,9 @@ func Run(version, build string) int {
 
 	// NOTE: This must be done *after* `rg.run()` so we don't remove
 	//  marker on panic (which can happen in `rg.run()`).
-	defer fs.RemoveMarker(nodeRestartedMarker)
+	if !daemon.cli.shadow { // the marker belongs to the shadowed target
+		defer fs.RemoveMarker(nodeRestartedMarker)
+	}
 
 	if err == nil {
 		glog.Infoln("Terminated OK")
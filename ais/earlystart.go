@@ -293,6 +293,7 @@ func (p *proxyrunner) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntar
 	glog.Infof("%s: metasync %s, %s", p.si, smap.StringEx(), bmd.StringEx())
 	wg.Wait()
 	glog.Infof("%s: primary & cluster startup complete", p.si)
+	p.restoreDlJobs()
 	p.markClusterStarted()
 }
 
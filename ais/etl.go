@@ -220,6 +220,8 @@ func (p *proxyrunner) etlHandler(w http.ResponseWriter, r *http.Request) {
 			p.initETL(w, r)
 		case cmn.ETLBuild:
 			p.buildETL(w, r)
+		case cmn.ETLValidate:
+			p.validateETL(w, r)
 		default:
 			p.invalmsghdlrf(w, r, "invalid POST path: %s", apiItems[0])
 		}
@@ -348,6 +350,25 @@ func (p *proxyrunner) buildETL(w http.ResponseWriter, r *http.Request) {
 	p.invalmsghdlr(w, r, err.Error())
 }
 
+// POST /v1/etl/validate
+//
+// validateETL checks user-provided pod specification (or build message)
+// without starting anything, and returns all the problems found.
+func (p *proxyrunner) validateETL(w http.ResponseWriter, r *http.Request) {
+	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.ETL, cmn.ETLValidate); err != nil {
+		return
+	}
+	var msg etl.ValidateMsg
+	if err := cmn.ReadJSON(w, r, &msg); err != nil {
+		return
+	}
+	errs := etl.Validate(&msg)
+	if errs == nil {
+		errs = etl.ValidationErrs{}
+	}
+	p.writeJSON(w, r, errs, "validate-ETL")
+}
+
 // GET /v1/etl/list
 func (p *proxyrunner) listETL(w http.ResponseWriter, r *http.Request) {
 	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.ETL, cmn.ETLList); err != nil {
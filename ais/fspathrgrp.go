@@ -89,6 +89,7 @@ func (g *fsprungroup) removeMountpath(mpath string) (err error) {
 
 func (g *fsprungroup) addMpathEvent(action, mpath string) {
 	registry.Registry.AbortAllMountpathsXactions()
+	g.saveVMD()
 	go func() {
 		g.t.runResilver("", false /*skipGlobMisplaced*/)
 		registry.Registry.RenewMakeNCopies(g.t, "add-mp")
@@ -98,6 +99,7 @@ func (g *fsprungroup) addMpathEvent(action, mpath string) {
 
 func (g *fsprungroup) delMpathEvent(action string) {
 	registry.Registry.AbortAllMountpathsXactions()
+	g.saveVMD()
 	if g.checkZeroMountpaths(action) {
 		return
 	}
@@ -133,3 +135,9 @@ func (g *fsprungroup) checkEnable(action, mpath string) {
 		}
 	}
 }
+
+func (g *fsprungroup) saveVMD() {
+	if err := fs.SaveVMD(g.t.si.ID()); err != nil {
+		glog.Errorf("%s: %v", g.t.si, err)
+	}
+}
@@ -125,6 +125,10 @@ type (
 		s             *http.Server
 		mux           *mux.ServeMux
 		sndRcvBufSize int
+		routes        struct {
+			sync.RWMutex
+			paths []string // registered handlers' paths (see also specHandler)
+		}
 	}
 	httprunner struct {
 		cmn.Named
@@ -141,8 +145,9 @@ type (
 			bmd  bmdOwner
 			rmd  *rmdOwner
 		}
-		statsT  stats.Tracker
-		startup struct {
+		statsT    stats.Tracker
+		elections electionHistory
+		startup   struct {
 			cluster atomic.Bool // determines if the cluster has started up
 			node    struct {
 				time atomic.Time // determines time when the node started up
@@ -312,6 +317,19 @@ func (server *netServer) shutdown() {
 	cancel()
 }
 
+func (server *netServer) addRoute(path string) {
+	server.routes.Lock()
+	server.routes.paths = append(server.routes.paths, path)
+	server.routes.Unlock()
+}
+
+func (server *netServer) registeredRoutes() (paths []string) {
+	server.routes.RLock()
+	paths = append(paths, server.routes.paths...)
+	server.routes.RUnlock()
+	return
+}
+
 ////////////////
 // httprunner //
 ////////////////
@@ -360,6 +378,7 @@ func (h *httprunner) registerNetworkHandlers(networkHandlers []networkHandler) {
 
 func (h *httprunner) registerPublicNetHandler(path string, handler func(http.ResponseWriter, *http.Request)) {
 	h.publicServer.mux.HandleFunc(path, handler)
+	h.publicServer.addRoute(path)
 	if !strings.HasSuffix(path, "/") {
 		h.publicServer.mux.HandleFunc(path+"/", handler)
 	}
@@ -367,6 +386,9 @@ func (h *httprunner) registerPublicNetHandler(path string, handler func(http.Res
 
 func (h *httprunner) registerIntraControlNetHandler(path string, handler func(http.ResponseWriter, *http.Request)) {
 	h.intraControlServer.mux.HandleFunc(path, handler)
+	if h.intraControlServer == h.publicServer { // accessible via public network as well
+		h.publicServer.addRoute(path)
+	}
 	if !strings.HasSuffix(path, "/") {
 		h.intraControlServer.mux.HandleFunc(path+"/", handler)
 	}
@@ -381,18 +403,26 @@ func (h *httprunner) registerIntraDataNetHandler(path string, handler func(http.
 
 func (h *httprunner) init(s stats.Tracker, config *cmn.Config) {
 	h.statsT = s
-	h.httpclient = cmn.NewClient(cmn.TransportArgs{
-		Timeout:    config.Client.Timeout,
-		UseHTTPS:   config.Net.HTTP.UseHTTPS,
-		SkipVerify: config.Net.HTTP.SkipVerify,
-	})
-	h.httpclientGetPut = cmn.NewClient(cmn.TransportArgs{
-		Timeout:         config.Client.TimeoutLong,
-		WriteBufferSize: config.Net.HTTP.WriteBufferSize,
-		ReadBufferSize:  config.Net.HTTP.ReadBufferSize,
-		UseHTTPS:        config.Net.HTTP.UseHTTPS,
-		SkipVerify:      config.Net.HTTP.SkipVerify,
-	})
+	var (
+		args = cmn.TransportArgs{
+			Timeout:    config.Client.Timeout,
+			UseHTTPS:   config.Net.HTTP.UseHTTPS,
+			SkipVerify: config.Net.HTTP.SkipVerify,
+			Stats:      cmn.NewClientStats("intra"),
+		}
+		argsGetPut = cmn.TransportArgs{
+			Timeout:         config.Client.TimeoutLong,
+			WriteBufferSize: config.Net.HTTP.WriteBufferSize,
+			ReadBufferSize:  config.Net.HTTP.ReadBufferSize,
+			UseHTTPS:        config.Net.HTTP.UseHTTPS,
+			SkipVerify:      config.Net.HTTP.SkipVerify,
+			Stats:           cmn.NewClientStats("intra-getput"),
+		}
+	)
+	args.Tune(&config.Client.Transport)
+	argsGetPut.Tune(&config.Client.Transport)
+	h.httpclient = cmn.NewClient(args)
+	h.httpclientGetPut = cmn.NewClient(argsGetPut)
 
 	bufsize := config.Net.L4.SndRcvBufSize
 	if h.si.IsProxy() {
@@ -1022,6 +1052,24 @@ func (h *httprunner) writeMsgPack(w http.ResponseWriter, r *http.Request, v inte
 		err = mw.Flush()
 	}
 	if err != nil {
+		// NOTE: the caller may stop reading on purpose (see `listObjectsAISStream`)
+		if cmn.IsErrBrokenPipe(err) || cmn.IsErrConnectionReset(err) {
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("%s: %s closed the connection: %v", tag, r.RemoteAddr, err)
+			}
+			return false
+		}
+		h.handleWriteError(r, tag, err)
+		return false
+	}
+	return true
+}
+
+func (h *httprunner) writeProtobuf(w http.ResponseWriter, r *http.Request, bckList *cmn.BucketList, tag string) (ok bool) {
+	b := bckList.MarshalPB()
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentProtobuf)
+	w.Header().Set(cmn.HeaderContentLength, strconv.Itoa(len(b)))
+	if _, err := w.Write(b); err != nil {
 		h.handleWriteError(r, tag, err)
 		return false
 	}
@@ -1071,6 +1119,18 @@ func (h *httprunner) parseNCopies(value interface{}) (copies int64, err error) {
 	return
 }
 
+// parseMNCMsg parses the value of cmn.ActMakeNCopies: either the number of copies or cmn.MNCMsg
+func (h *httprunner) parseMNCMsg(value interface{}) (mncMsg *cmn.MNCMsg, err error) {
+	mncMsg = &cmn.MNCMsg{}
+	if _, ok := value.(map[string]interface{}); ok {
+		err = cmn.MorphMarshal(value, mncMsg)
+		return
+	}
+	copies, err := h.parseNCopies(value)
+	mncMsg.Copies = int(copies)
+	return
+}
+
 func (h *httprunner) checkAction(msg *cmn.ActionMsg, expectedActions ...string) (err error) {
 	found := false
 	for _, action := range expectedActions {
@@ -1104,6 +1164,14 @@ func (h *httprunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		body = msg
 	case cmn.GetWhatSnode:
 		body = h.si
+	case cmn.GetWhatClientStats:
+		body = cmn.ClientStatsAll()
+	case cmn.GetWhatMemsys:
+		body = h.statsT.MemStats()
+	case cmn.GetWhatElections:
+		body = h.elections.get()
+	case cmn.GetWhatPingMatrix:
+		body = h.pingPeers()
 	default:
 		s := fmt.Sprintf("Invalid GET /daemon request: unrecognized what=%s", what)
 		h.invalmsghdlr(w, r, s)
@@ -32,6 +32,9 @@ const (
 	// that have no associated cache other than start/end timestamps and stats counters
 	// (case in point: list/query-objects that MAY be cached, etc.)
 	equalIC = "\x00"
+
+	// max time to hold a long-polling status request (see waitFinished)
+	maxNotifWait = time.Minute
 )
 
 type (
@@ -220,6 +223,16 @@ func (ic *ic) writeStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ic.p.notifs.syncStats(nl, interval)
+	if wait := r.URL.Query().Get(cmn.URLParamNotifWait); wait != "" {
+		timeout, err := time.ParseDuration(wait)
+		if err != nil {
+			ic.p.invalmsghdlrf(w, r, "invalid %s=%q: %v", cmn.URLParamNotifWait, wait, err)
+			return
+		}
+		if !ic.waitFinished(r, nl, timeout, interval) {
+			return // client has gone away
+		}
+	}
 	nl.RLock()
 	defer nl.RUnlock()
 
@@ -232,6 +245,38 @@ func (ic *ic) writeStatus(w http.ResponseWriter, r *http.Request) {
 	w.Write(cmn.MustMarshal(nl.Status()))
 }
 
+// long-poll: block until the listener finishes or the timeout (capped at
+// `maxNotifWait`) expires, whichever comes first; in the meantime, keep syncing
+// stats in case a notification is lost
+func (ic *ic) waitFinished(r *http.Request, nl nl.NotifListener, timeout, interval time.Duration) bool {
+	timeout = cmn.MinDuration(timeout, maxNotifWait)
+	ch := ic.p.notifs.subscribe(nl.UUID())
+	defer ic.p.notifs.unsubscribe(nl.UUID(), ch)
+	if nl.Finished() {
+		return true
+	}
+	var (
+		timer  = time.NewTimer(timeout)
+		ticker = time.NewTicker(interval)
+	)
+	defer func() {
+		timer.Stop()
+		ticker.Stop()
+	}()
+	for {
+		select {
+		case <-ch:
+			return true
+		case <-timer.C:
+			return true
+		case <-ticker.C:
+			ic.p.notifs.syncStats(nl, interval)
+		case <-r.Context().Done():
+			return false
+		}
+	}
+}
+
 // verb /v1/ic
 func (ic *ic) handler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -0,0 +1,137 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// runtime log verbosity (ActSetLogLevel)
+//
+// Levels set with a TTL get reverted to what they were before the first such
+// (not yet reverted) change; a subsequent change with a TTL resets the timer,
+// while a change without one makes all current levels permanent (until restart).
+
+type logLevels struct {
+	mu    sync.Mutex
+	orig  *cmn.LogLevelMsg // levels to revert to (nil - nothing to revert)
+	timer *time.Timer
+	gen   int64 // to ignore the timer that fired while being stopped
+}
+
+var loglvl logLevels
+
+func validateLogLevel(msg *cmn.LogLevelMsg) error {
+	if msg.Level == "" && len(msg.Modules) == 0 {
+		return fmt.Errorf("%s: nothing to set", cmn.ActSetLogLevel)
+	}
+	if msg.Level != "" {
+		if v, err := strconv.Atoi(msg.Level); err != nil || v < 0 {
+			return fmt.Errorf("%s: invalid level %q", cmn.ActSetLogLevel, msg.Level)
+		}
+	}
+	for name, v := range msg.Modules {
+		if _, ok := glog.SmoduleByName(name); !ok {
+			return fmt.Errorf("%s: unknown module %q (expecting one of: %v)", cmn.ActSetLogLevel, name, glog.SmoduleNames())
+		}
+		if v < 0 {
+			return fmt.Errorf("%s: invalid level %d for module %q", cmn.ActSetLogLevel, v, name)
+		}
+	}
+	if msg.TTL < 0 {
+		return fmt.Errorf("%s: invalid TTL %v", cmn.ActSetLogLevel, msg.TTL)
+	}
+	return nil
+}
+
+func (ll *logLevels) set(msg *cmn.LogLevelMsg) error {
+	if err := validateLogLevel(msg); err != nil {
+		return err
+	}
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	ll.gen++
+	if ll.timer != nil {
+		ll.timer.Stop()
+		ll.timer = nil
+	}
+	if msg.TTL == 0 {
+		ll.orig = nil
+	} else {
+		ll.snapshot(msg)
+	}
+	if err := applyLogLevel(msg); err != nil {
+		return err
+	}
+	if msg.TTL != 0 {
+		gen := ll.gen
+		ll.timer = time.AfterFunc(msg.TTL, func() { ll.revert(gen) })
+		glog.Infof("%s: %+v, reverting in %v", cmn.ActSetLogLevel, msg, msg.TTL)
+	} else {
+		glog.Infof("%s: %+v", cmn.ActSetLogLevel, msg)
+	}
+	return nil
+}
+
+// remember the current levels that are about to change unless remembered already
+func (ll *logLevels) snapshot(msg *cmn.LogLevelMsg) {
+	if ll.orig == nil {
+		ll.orig = &cmn.LogLevelMsg{Modules: make(map[string]int, len(msg.Modules))}
+	}
+	if msg.Level != "" && ll.orig.Level == "" {
+		ll.orig.Level = cmn.GCO.Get().Log.Level
+	}
+	for name := range msg.Modules {
+		if _, ok := ll.orig.Modules[name]; !ok {
+			smodule, _ := glog.SmoduleByName(name)
+			ll.orig.Modules[name] = int(glog.GetV(smodule))
+		}
+	}
+}
+
+func (ll *logLevels) revert(gen int64) {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	if gen != ll.gen || ll.orig == nil {
+		return
+	}
+	if err := applyLogLevel(ll.orig); err != nil {
+		glog.Errorf("%s: failed to revert to %+v, err: %v", cmn.ActSetLogLevel, ll.orig, err)
+	} else {
+		glog.Infof("%s: reverted to %+v", cmn.ActSetLogLevel, ll.orig)
+	}
+	ll.orig, ll.timer = nil, nil
+}
+
+func applyLogLevel(msg *cmn.LogLevelMsg) error {
+	if msg.Level != "" {
+		config := cmn.GCO.BeginUpdate()
+		if err := cmn.SetLogLevel(config, msg.Level); err != nil {
+			cmn.GCO.DiscardUpdate()
+			return err
+		}
+		cmn.GCO.CommitUpdate(config)
+	}
+	for name, v := range msg.Modules {
+		smodule, _ := glog.SmoduleByName(name)
+		glog.SetV(smodule, glog.Level(v))
+	}
+	return nil
+}
+
+// PUT {action: setloglevel, value: cmn.LogLevelMsg} /v1/daemon
+func setLogLevel(value interface{}) error {
+	msg := &cmn.LogLevelMsg{}
+	if err := cmn.MorphMarshal(value, msg); err != nil {
+		return err
+	}
+	return loglvl.set(msg)
+}
@@ -0,0 +1,66 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestLogLevelRevert(t *testing.T) {
+	ll := &logLevels{}
+	glog.SetV(glog.SmoduleFS, 1)
+	defer glog.SetV(glog.SmoduleFS, 0)
+
+	// permanent
+	if err := ll.set(&cmn.LogLevelMsg{Modules: map[string]int{"fs": 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if v := glog.GetV(glog.SmoduleFS); v != 2 {
+		t.Fatalf("expected level 2, got %d", v)
+	}
+
+	// temporary, twice: the second one resets the timer, the revert goes to the original level
+	ttl := 100 * time.Millisecond
+	if err := ll.set(&cmn.LogLevelMsg{Modules: map[string]int{"fs": 4}, TTL: ttl}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ll.set(&cmn.LogLevelMsg{Modules: map[string]int{"fs": 5, "memsys": 3}, TTL: ttl}); err != nil {
+		t.Fatal(err)
+	}
+	defer glog.SetV(glog.SmoduleMemsys, 0)
+	if v := glog.GetV(glog.SmoduleFS); v != 5 {
+		t.Fatalf("expected level 5, got %d", v)
+	}
+	time.Sleep(3 * ttl)
+	if v := glog.GetV(glog.SmoduleFS); v != 2 {
+		t.Fatalf("expected level 2 after revert, got %d", v)
+	}
+	if v := glog.GetV(glog.SmoduleMemsys); v != 0 {
+		t.Fatalf("expected level 0 after revert, got %d", v)
+	}
+}
+
+func TestLogLevelValidate(t *testing.T) {
+	tests := []*cmn.LogLevelMsg{
+		{},
+		{Level: "abc"},
+		{Level: "-1"},
+		{Modules: map[string]int{"unknown": 1}},
+		{Modules: map[string]int{"fs": -1}},
+		{Level: "3", TTL: -time.Second},
+	}
+	for _, msg := range tests {
+		if err := validateLogLevel(msg); err == nil {
+			t.Errorf("expected %+v to fail validation", msg)
+		}
+	}
+	if err := validateLogLevel(&cmn.LogLevelMsg{Level: "3", Modules: map[string]int{"reb": 4}}); err != nil {
+		t.Error(err)
+	}
+}
@@ -349,28 +349,33 @@ outer:
 	if revsReqType == revsReqNotify {
 		to = cluster.Targets
 	}
-	res := y.p.bcastToGroup(bcastArgs{
-		req:               cmn.ReqArgs{Method: method, Path: urlPath, BodyR: body},
-		smap:              smap,
-		timeout:           config.Timeout.MaxKeepalive, // making exception for this critical op
-		to:                to,
-		ignoreMaintenance: true,
-	})
-
-	// step 4: count failures and fill-in refused
-	for r := range res {
-		if r.err == nil {
-			if revsReqType == revsReqSync {
-				y.syncDone(r.si.ID(), pairsToSend)
+	if revsReqType == revsReqSync && useGossip(smap, config) {
+		// step 3 and 4 (gossip): the nodes that were not reached get sync-ed directly
+		refused = y.gossip(urlPath, body, smap, pairsToSend, config)
+	} else {
+		res := y.p.bcastToGroup(bcastArgs{
+			req:               cmn.ReqArgs{Method: method, Path: urlPath, BodyR: body},
+			smap:              smap,
+			timeout:           config.Timeout.MaxKeepalive, // making exception for this critical op
+			to:                to,
+			ignoreMaintenance: true,
+		})
+
+		// step 4: count failures and fill-in refused
+		for r := range res {
+			if r.err == nil {
+				if revsReqType == revsReqSync {
+					y.syncDone(r.si.ID(), pairsToSend)
+				}
+				continue
+			}
+			glog.Warningf("Failed to sync %s, err: %v (%d)", r.si, r.err, r.status)
+			// in addition to "connection-refused" always retry newTargetID - the joining one
+			if cmn.IsErrConnectionRefused(r.err) || cmn.StringInSlice(r.si.ID(), newTargetIDs) {
+				refused.Add(r.si)
+			} else {
+				failedCnt++
 			}
-			continue
-		}
-		glog.Warningf("Failed to sync %s, err: %v (%d)", r.si, r.err, r.status)
-		// in addition to "connection-refused" always retry newTargetID - the joining one
-		if cmn.IsErrConnectionRefused(r.err) || cmn.StringInSlice(r.si.ID(), newTargetIDs) {
-			refused.Add(r.si)
-		} else {
-			failedCnt++
 		}
 	}
 	// step 5: handle connection-refused right away
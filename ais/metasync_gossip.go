@@ -0,0 +1,210 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Gossip (epidemic) metasync
+//
+// In a cluster with hundreds of nodes the primary sending each and every
+// update to all nodes (see metasyncer.doSync) becomes a bottleneck. With
+// metasync.mode = "gossip" the primary instead:
+//
+// * shuffles the IDs of all the other nodes and splits them into up to
+//   metasync.fanout groups;
+// * sends the payload to the first node of each group along with the IDs of
+//   the rest of the group (cmn.HeaderGossipNodes);
+// * each such relay applies the payload locally and then does the same with
+//   its own group, and so on - the resulting tree has log(fanout) depth;
+// * relays respond with the IDs of the nodes they (and their relays) failed to
+//   reach; the primary then syncs those directly, which guarantees convergence
+//   (see also metasyncer.pending).
+
+// gossipSplit splits node IDs into (at most) `fanout` contiguous groups of
+// nearly equal size
+func gossipSplit(ids []string, fanout int) (groups [][]string) {
+	if len(ids) == 0 {
+		return
+	}
+	if fanout > len(ids) {
+		fanout = len(ids)
+	}
+	groups = make([][]string, 0, fanout)
+	for i := 0; i < fanout; i++ {
+		from, to := i*len(ids)/fanout, (i+1)*len(ids)/fanout
+		groups = append(groups, ids[from:to])
+	}
+	return
+}
+
+// gossipTimeout returns the time to wait for a group of `cnt` nodes - one
+// (max) keepalive timeout per each level of the tree
+func gossipTimeout(cnt, fanout int, config *cmn.Config) time.Duration {
+	levels := 1
+	for n := fanout; n < cnt; n *= fanout {
+		levels++
+	}
+	return time.Duration(levels) * config.Timeout.MaxKeepalive
+}
+
+func useGossip(smap *smapX, config *cmn.Config) bool {
+	return config.Metasync.Mode == cmn.MetasyncModeGossip &&
+		smap.CountTargets()+smap.CountProxies() >= config.Metasync.MinNodes
+}
+
+// gossipSend sends the (same) body to the head of each group; the callback
+// is called once per group with the IDs that failed to receive the payload
+func (h *httprunner) gossipSend(urlPath string, body []byte, ids []string, smap *smapX,
+	config *cmn.Config, cb func(group, failed []string)) {
+	var (
+		wg     = &sync.WaitGroup{}
+		groups = gossipSplit(ids, config.Metasync.Fanout)
+	)
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group []string) {
+			defer wg.Done()
+			si := smap.GetNode(group[0])
+			if si == nil {
+				glog.Errorf("%s: gossip: node %s is not present in the %s", h.si, group[0], smap)
+				cb(group, group)
+				return
+			}
+			req := cmn.ReqArgs{Method: http.MethodPut, Path: urlPath, Body: body}
+			if len(group) > 1 {
+				req.Header = http.Header{}
+				req.Header.Set(cmn.HeaderGossipNodes, strings.Join(group[1:], ","))
+			}
+			timeout := gossipTimeout(len(group), config.Metasync.Fanout, config)
+			res := h.call(callArgs{si: si, req: req, timeout: timeout})
+			if res.err != nil {
+				glog.Warningf("%s: gossip: failed to sync %s (and %d more), err: %v (%d)",
+					h.si, si, len(group)-1, res.err, res.status)
+				cb(group, group)
+				return
+			}
+			var failed []string
+			if len(res.bytes) > 0 {
+				if err := jsoniter.Unmarshal(res.bytes, &failed); err != nil {
+					glog.Errorf("%s: gossip: invalid response from %s, err: %v", h.si, si, err)
+					failed = group[1:]
+				}
+			}
+			cb(group, failed)
+		}(group)
+	}
+	wg.Wait()
+}
+
+// decodeMetasync decodes metasync PUT payload; when the request comes with
+// a list of nodes to relay to (cmn.HeaderGossipNodes), also returns the raw body
+func (h *httprunner) decodeMetasync(w http.ResponseWriter, r *http.Request) (payload msPayload, body []byte, ok bool) {
+	var (
+		reader io.ReadCloser = r.Body
+		err    error
+	)
+	if r.Header.Get(cmn.HeaderGossipNodes) != "" {
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			h.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		reader = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	payload = make(msPayload)
+	if err = jsp.Decode(reader, &payload, jspMetasyncOpts, "metasync put"); err != nil {
+		cmn.InvalidHandlerDetailed(w, r, err.Error())
+		return
+	}
+	ok = true
+	return
+}
+
+// gossipRelay forwards the payload to the nodes listed in cmn.HeaderGossipNodes
+// and responds with the IDs of those that were not reached
+func (h *httprunner) gossipRelay(w http.ResponseWriter, r *http.Request, body []byte, errs []error) {
+	var (
+		ids    = strings.Split(r.Header.Get(cmn.HeaderGossipNodes), ",")
+		smap   = h.owner.smap.get() // NOTE: may have been just updated by the payload itself
+		config = cmn.GCO.Get()
+		mu     = &sync.Mutex{}
+		failed []string
+	)
+	if len(errs) > 0 {
+		glog.Errorf("%s: gossip: %v", h.si, errs)
+		failed = append(failed, h.si.ID())
+	}
+	h.gossipSend(r.URL.Path, body, ids, smap, config, func(_, f []string) {
+		mu.Lock()
+		failed = append(failed, f...)
+		mu.Unlock()
+	})
+	if failed == nil {
+		failed = []string{}
+	}
+	h.writeJSON(w, r, failed, "gossip")
+}
+
+// gossip is the primary's counterpart of the bcastToGroup in doSync (step 3);
+// returns the nodes that must be sync-ed directly
+func (y *metasyncer) gossip(urlPath string, body *memsys.SGL, smap *smapX, pairs []revsPair,
+	config *cmn.Config) (unconfirmed cluster.NodeMap) {
+	var (
+		ids     = make([]string, 0, smap.CountTargets()+smap.CountProxies())
+		mu      = &sync.Mutex{}
+		started = time.Now()
+	)
+	for _, nodeMap := range []cluster.NodeMap{smap.Tmap, smap.Pmap} {
+		for id := range nodeMap {
+			if id != y.p.si.ID() {
+				ids = append(ids, id)
+			}
+		}
+	}
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	payload, _ := body.ReadAll()
+	y.p.gossipSend(urlPath, payload, ids, smap, config, func(group, failed []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, id := range group {
+			if cmn.StringInSlice(id, failed) {
+				if si := smap.GetNode(id); si != nil {
+					unconfirmed.Add(si)
+				}
+				continue
+			}
+			y.syncDone(id, pairs)
+		}
+	})
+	y.p.statsT.AddMany(
+		stats.NamedVal64{Name: stats.MetasyncGossipCount, Value: 1},
+		stats.NamedVal64{Name: stats.MetasyncGossipLatency, Value: int64(time.Since(started))},
+		stats.NamedVal64{Name: stats.MetasyncGossipMissCount, Value: int64(len(unconfirmed))},
+	)
+	if len(unconfirmed) > 0 {
+		glog.Warningf("%s: gossip: %d (out of %d) node(s) unconfirmed - syncing directly",
+			y.p.si, len(unconfirmed), len(ids))
+	} else if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: gossip: %d node(s) sync-ed in %v", y.p.si, len(ids), time.Since(started))
+	}
+	return
+}
@@ -872,3 +872,31 @@ func testSyncer(p *proxyrunner) (syncer *metasyncer) {
 	syncer = newMetasyncer(p)
 	return
 }
+
+func TestMetaSyncGossipSplit(t *testing.T) {
+	ids := make([]string, 0, 100)
+	for i := 0; i < cap(ids); i++ {
+		ids = append(ids, fmt.Sprintf("node%d", i))
+	}
+	for _, fanout := range []int{2, 3, 8, 100, 1000} {
+		var (
+			groups = gossipSplit(ids, fanout)
+			seen   = make(map[string]struct{}, len(ids))
+			minLen = len(ids)
+			maxLen = 0
+		)
+		tassert.Fatalf(t, len(groups) == cmn.Min(fanout, len(ids)), "fanout %d: expected %d groups, got %d",
+			fanout, cmn.Min(fanout, len(ids)), len(groups))
+		for _, group := range groups {
+			minLen, maxLen = cmn.Min(minLen, len(group)), cmn.Max(maxLen, len(group))
+			for _, id := range group {
+				_, ok := seen[id]
+				tassert.Fatalf(t, !ok, "fanout %d: %s is duplicated", fanout, id)
+				seen[id] = struct{}{}
+			}
+		}
+		tassert.Fatalf(t, len(seen) == len(ids), "fanout %d: expected %d nodes, got %d", fanout, len(ids), len(seen))
+		tassert.Errorf(t, maxLen-minLen <= 1, "fanout %d: unbalanced groups (%d, %d)", fanout, minLen, maxLen)
+	}
+	tassert.Errorf(t, len(gossipSplit(nil, 8)) == 0, "expected no groups")
+}
@@ -0,0 +1,48 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// GET /metrics - node's stats in Prometheus text format (see stats/prometheus.go)
+
+func (p *proxyrunner) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /"+cmn.Metrics+" path")
+		return
+	}
+	w.Header().Set(cmn.HeaderContentType, stats.PromContentType)
+	pw := stats.NewPromWriter(w, p.si.ID())
+	getproxystatsrunner().WriteProm(pw)
+	if err := pw.Err(); err != nil {
+		glog.Errorf("%s: failed to write metrics to %s: %v", p.si, r.RemoteAddr, err)
+	}
+}
+
+func (t *targetrunner) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /"+cmn.Metrics+" path")
+		return
+	}
+	w.Header().Set(cmn.HeaderContentType, stats.PromContentType)
+	pw := stats.NewPromWriter(w, t.si.ID())
+	getstorstatsrunner().WriteProm(pw)
+	xacts, err := registry.Registry.GetStats(registry.XactFilter{})
+	if err == nil {
+		stats.WriteXactProm(pw, xacts)
+	}
+	downloader.WriteProm(pw)
+	if err := pw.Err(); err != nil {
+		glog.Errorf("%s: failed to write metrics to %s: %v", t.si, r.RemoteAddr, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Node-to-node latency matrix: the primary broadcasts the request to all nodes,
+// and each node health-pings every other node in the cluster map - separately
+// over the intra-cluster control and data networks (see pingPeers). The primary
+// adds its own measurements and returns the resulting N x N matrix.
+
+func (p *proxyrunner) queryPingMatrix(w http.ResponseWriter, r *http.Request, what string) {
+	config := cmn.GCO.Get()
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  r.URL.Query(),
+		},
+		timeout: config.Timeout.CplaneOperation*2 + config.Timeout.MaxKeepalive,
+		to:      cluster.AllNodes,
+		fv:      func() interface{} { return &cmn.NodePings{} },
+	})
+	out := make(cmn.PingMatrix, len(results)+1)
+	out[p.si.ID()] = p.pingPeers()
+	for res := range results {
+		if res.err != nil {
+			// the node itself is unreachable: report it as such on behalf of the primary
+			out[res.si.ID()] = cmn.NodePings{p.si.ID(): {Err: res.err.Error()}}
+			continue
+		}
+		out[res.si.ID()] = *res.v.(*cmn.NodePings)
+	}
+	p.writeJSON(w, r, out, what)
+}
+
+// health-ping all other nodes, in parallel
+func (h *httprunner) pingPeers() cmn.NodePings {
+	var (
+		smap   = h.owner.smap.get()
+		config = cmn.GCO.Get()
+		out    = make(cmn.NodePings, smap.CountTargets()+smap.CountProxies())
+		mu     = &sync.Mutex{}
+		wg     = &sync.WaitGroup{}
+	)
+	for _, nodeMap := range []cluster.NodeMap{smap.Pmap, smap.Tmap} {
+		for sid, si := range nodeMap {
+			if sid == h.si.ID() {
+				continue
+			}
+			wg.Add(1)
+			go func(si *cluster.Snode) {
+				ping := h.pingPeer(si, config)
+				mu.Lock()
+				out[si.ID()] = ping
+				mu.Unlock()
+				wg.Done()
+			}(si)
+		}
+	}
+	wg.Wait()
+	return out
+}
+
+func (h *httprunner) pingPeer(si *cluster.Snode, config *cmn.Config) *cmn.NodePing {
+	ping := &cmn.NodePing{}
+	latency, err := h.ping(si, cmn.NetworkIntraControl, config)
+	if err != nil {
+		ping.Err = err.Error()
+		return ping
+	}
+	ping.Control = latency
+	if !config.Net.UseIntraData {
+		return ping
+	}
+	if latency, err = h.ping(si, cmn.NetworkIntraData, config); err != nil {
+		ping.Err = err.Error()
+		return ping
+	}
+	ping.Data = latency
+	return ping
+}
+
+func (h *httprunner) ping(si *cluster.Snode, network string, config *cmn.Config) (latency time.Duration, err error) {
+	args := callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodGet,
+			Base:   si.URL(network),
+			Path:   cmn.JoinWords(cmn.Version, cmn.Health),
+		},
+		timeout: config.Timeout.CplaneOperation,
+	}
+	started := mono.NanoTime()
+	res := h.call(args)
+	return mono.Since(started), res.err
+}
@@ -50,6 +50,9 @@ type (
 		fin     map[string]nl.NotifListener // finished [UUID => NotifListener]
 		fmu     sync.RWMutex
 		smapVer int64
+		// long-polling clients waiting for the listeners to finish [UUID => channels]
+		waiters map[string][]chan struct{}
+		wmu     sync.Mutex
 	}
 	// TODO: simplify using alternate encoding formats (e.g. GOB)
 	jsonNotifs struct {
@@ -87,6 +90,7 @@ func (n *notifs) init(p *proxyrunner) {
 	n.p = p
 	n.m = make(map[string]nl.NotifListener, 64)
 	n.fin = make(map[string]nl.NotifListener, 64)
+	n.waiters = make(map[string][]chan struct{}, 8)
 	hk.Reg(notifsName+".gc", n.housekeep, notifsHousekeepT)
 	n.p.Sowner().Listeners().Reg(n)
 }
@@ -101,6 +105,9 @@ func (n *notifs) add(nl nl.NotifListener) {
 		n.Unlock()
 		return
 	}
+	if dnl, ok := nl.(*downloader.NotifDownloadListerner); ok && dnl.NotifyURL != "" {
+		dnl.F = n.p.dlNotifyJob // job completion webhook
+	}
 	n.m[nl.UUID()] = nl
 	nl.SetAddedTime()
 	n.Unlock()
@@ -324,6 +331,43 @@ func (n *notifs) done(nl nl.NotifListener) {
 		n.p.bcastToNodesAsync(args)
 	}
 	nl.Callback(nl, time.Now().UnixNano())
+	n.wakeup(nl.UUID())
+}
+
+// subscribe returns a channel that gets closed once the listener finishes;
+// the caller must unsubscribe when done waiting
+func (n *notifs) subscribe(uuid string) chan struct{} {
+	ch := make(chan struct{})
+	n.wmu.Lock()
+	n.waiters[uuid] = append(n.waiters[uuid], ch)
+	n.wmu.Unlock()
+	return ch
+}
+
+func (n *notifs) unsubscribe(uuid string, ch chan struct{}) {
+	n.wmu.Lock()
+	chs := n.waiters[uuid]
+	for i, c := range chs {
+		if c == ch {
+			chs = append(chs[:i], chs[i+1:]...)
+			break
+		}
+	}
+	if len(chs) == 0 {
+		delete(n.waiters, uuid)
+	} else {
+		n.waiters[uuid] = chs
+	}
+	n.wmu.Unlock()
+}
+
+func (n *notifs) wakeup(uuid string) {
+	n.wmu.Lock()
+	for _, ch := range n.waiters[uuid] {
+		close(ch)
+	}
+	delete(n.waiters, uuid)
+	n.wmu.Unlock()
 }
 
 //
@@ -500,6 +544,7 @@ func (n *notifs) ListenSmapChanged() {
 
 	for uuid, nl := range remnl {
 		nl.Callback(nl, now)
+		n.wakeup(uuid)
 		// cleanup
 		delete(remnl, uuid)
 		delete(remid, uuid)
@@ -594,7 +639,8 @@ func isDLType(t string) bool {
 	return t == string(downloader.DlTypeMulti) ||
 		t == string(downloader.DlTypeCloud) ||
 		t == string(downloader.DlTypeSingle) ||
-		t == string(downloader.DlTypeRange)
+		t == string(downloader.DlTypeRange) ||
+		t == string(downloader.DlTypePrefetch)
 }
 
 //
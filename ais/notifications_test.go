@@ -210,6 +210,25 @@ var _ = Describe("Notifications xaction test", func() {
 		})
 	})
 
+	Describe("subscribe", func() {
+		It("should wake up subscribers when done", func() {
+			n.add(nl)
+			ch := n.subscribe(xactID)
+			other := n.subscribe(xactID)
+			n.unsubscribe(xactID, other)
+
+			err := n.handleFinished(nl, targets[target1ID], nil, nil)
+			Expect(err).To(BeNil())
+			Expect(ch).NotTo(BeClosed())
+
+			err = n.handleFinished(nl, targets[target2ID], nil, nil)
+			Expect(err).To(BeNil())
+			Expect(ch).To(BeClosed())
+			Expect(other).NotTo(BeClosed())
+			Expect(n.waiters).To(BeEmpty())
+		})
+	})
+
 	Describe("ListenSmapChanged", func() {
 		It("should mark xaction Aborted when node not in smap", func() {
 			notifiers := getNodeMap(target1ID, target2ID)
@@ -29,7 +29,9 @@ import (
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/etl"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
@@ -74,6 +76,9 @@ type (
 		notifs     notifs
 		ic         ic
 		qm         queryMem
+		bckExist   bckExistCache
+		dlScheds   dlSchedules
+		dlShares   dlShares
 		gmm        *memsys.MMSA // system pagesize-based memory manager and slab allocator
 	}
 )
@@ -125,12 +130,30 @@ func (p *proxyrunner) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.bckExist.init()
+	hk.Reg(ephemeralName, p.housekeepEphemeral, ephemeralHousekeepT)
 
 	//
 	// REST API: register proxy handlers and start listening
 	// NOTE: additional handlers are registered upon startup (see markClusterStarted)
 	//
-	networkHandlers := []networkHandler{
+	p.registerNetworkHandlers(p.networkHandlers())
+
+	glog.Infof("%s: [public net] listening on: %s", p.si, p.si.PublicNet.DirectURL)
+	if p.si.PublicNet.DirectURL != p.si.IntraControlNet.DirectURL {
+		glog.Infof("%s: [intra control net] listening on: %s", p.si, p.si.IntraControlNet.DirectURL)
+	}
+	if p.si.PublicNet.DirectURL != p.si.IntraDataNet.DirectURL {
+		glog.Infof("%s: [intra data net] listening on: %s", p.si, p.si.IntraDataNet.DirectURL)
+	}
+
+	dsort.RegisterNode(p.owner.smap, p.owner.bmd, p.si, nil, nil, p.statsT)
+	return p.httprunner.run()
+}
+
+// the handlers that are registered upon startup; see also clusterStartedHandlers
+func (p *proxyrunner) networkHandlers() []networkHandler {
+	return []networkHandler{
 		{r: cmn.Reverse, h: p.reverseHandler, net: []string{cmn.NetworkPublic}},
 
 		{r: cmn.ETL, h: p.etlHandler, net: []string{cmn.NetworkPublic}},
@@ -141,31 +164,21 @@ func (p *proxyrunner) Run() error {
 		{r: cmn.Sort, h: p.dsortHandler, net: []string{cmn.NetworkPublic}},
 
 		{r: cmn.Metasync, h: p.metasyncHandler, net: []string{cmn.NetworkIntraControl}},
-		{r: cmn.Health, h: p.healthHandler, net: []string{cmn.NetworkIntraControl}},
+		{r: cmn.Health, h: p.healthHandler, net: []string{cmn.NetworkIntraControl, cmn.NetworkIntraData}},
 		{r: cmn.Vote, h: p.voteHandler, net: []string{cmn.NetworkIntraControl}},
 
 		{r: cmn.Notifs, h: p.notifs.handler, net: []string{cmn.NetworkIntraControl}},
 
-		{r: "/", h: p.httpCloudHandler, net: []string{cmn.NetworkPublic}},
-	}
+		{r: cmn.Spec, h: p.specHandler, net: []string{cmn.NetworkPublic}},
+		{r: "/" + cmn.Metrics, h: p.metricsHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 
-	p.registerNetworkHandlers(networkHandlers)
-
-	glog.Infof("%s: [public net] listening on: %s", p.si, p.si.PublicNet.DirectURL)
-	if p.si.PublicNet.DirectURL != p.si.IntraControlNet.DirectURL {
-		glog.Infof("%s: [intra control net] listening on: %s", p.si, p.si.IntraControlNet.DirectURL)
-	}
-	if p.si.PublicNet.DirectURL != p.si.IntraDataNet.DirectURL {
-		glog.Infof("%s: [intra data net] listening on: %s", p.si, p.si.IntraDataNet.DirectURL)
+		{r: "/", h: p.httpCloudHandler, net: []string{cmn.NetworkPublic}},
 	}
-
-	dsort.RegisterNode(p.owner.smap, p.owner.bmd, p.si, nil, nil, p.statsT)
-	return p.httprunner.run()
 }
 
-// enable handlers that must be accessible only upon cluster-startup-done
-func (p *proxyrunner) markClusterStarted() {
-	netH := []networkHandler{
+// the handlers that must be accessible only upon cluster-startup-done
+func (p *proxyrunner) clusterStartedHandlers() []networkHandler {
+	return []networkHandler{
 		{r: cmn.Buckets, h: p.bucketHandler, net: []string{cmn.NetworkPublic}},
 		{r: cmn.Objects, h: p.objectHandler, net: []string{cmn.NetworkPublic}},
 		{r: cmn.Download, h: p.downloadHandler, net: []string{cmn.NetworkPublic}},
@@ -173,7 +186,10 @@ func (p *proxyrunner) markClusterStarted() {
 
 		{r: "/" + cmn.S3, h: p.s3Handler, net: []string{cmn.NetworkPublic}},
 	}
-	p.registerNetworkHandlers(netH)
+}
+
+func (p *proxyrunner) markClusterStarted() {
+	p.registerNetworkHandlers(p.clusterStartedHandlers())
 	p.httprunner.markClusterStarted()
 }
 
@@ -345,6 +361,14 @@ func (p *proxyrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 		}
 		p.listBuckets(w, r, cmn.QueryBcks(bck.Bck))
 	default:
+		switch r.URL.Query().Get(cmn.URLParamWhat) {
+		case cmn.GetWhatReplication:
+			p.replicationStatus(w, r, apiItems[0])
+			return
+		case cmn.GetWhatMDQuery:
+			p.mdQuery(w, r, apiItems[0])
+			return
+		}
 		p.invalmsghdlrf(w, r, "Invalid route /buckets/%s", apiItems[0])
 	}
 }
@@ -369,7 +393,7 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request, origURL
 		return
 	}
 	if err := bck.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	smap := p.owner.smap.get()
@@ -378,6 +402,9 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request, origURL
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if bck.Props.EC.Enabled && cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamECRestore)) {
+		si = p.ecRestoreTarget(bck, objName, smap, si)
+	}
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("%s %s/%s => %s", r.Method, bucket, objName, si)
 	}
@@ -386,6 +413,36 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request, origURL
 	p.statsT.Add(stats.GetCount, 1)
 }
 
+// ecRestoreTarget returns the target to redirect EC-restore-enabled GET to:
+// the object's main target `si` if it responds, otherwise the next (responding)
+// one in the HRW order - the one that will own the object once `si` is gone
+// from the cluster map. The latter won't find the object locally and will
+// restore it from the surviving slices (see tryRestoreObject).
+// Note that the extra health check is the reason why EC restore is opt-in.
+func (p *proxyrunner) ecRestoreTarget(bck *cluster.Bck, objName string, smap *smapX, si *cluster.Snode) *cluster.Snode {
+	timeout := cmn.GCO.Get().Timeout.CplaneOperation
+	if _, err, _ := p.Health(si, timeout, nil); err == nil {
+		return si
+	}
+	cnt := cmn.Min(bck.Props.EC.ParitySlices+1, smap.CountTargets())
+	sis, err := cluster.HrwTargetList(bck.MakeUname(objName), &smap.Smap, cnt)
+	if err != nil {
+		glog.Errorf("%s: main target %s of %s/%s is down, failed to fall back: %v", p.si, si, bck, objName, err)
+		return si
+	}
+	for _, tsi := range sis {
+		if tsi.ID() == si.ID() {
+			continue
+		}
+		if _, err, _ := p.Health(tsi, timeout, nil); err == nil {
+			glog.Warningf("%s: main target %s of %s/%s is down, redirecting GET to %s (EC restore)",
+				p.si, si, bck, objName, tsi)
+			return tsi
+		}
+	}
+	return si
+}
+
 // PUT /v1/objects/bucket-name/object-name
 func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -432,7 +489,7 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 
@@ -482,7 +539,7 @@ func (p *proxyrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	smap := p.owner.smap.get()
@@ -521,7 +578,7 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err = bck.Allow(cmn.AccessBckDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	switch msg.Action {
@@ -532,6 +589,7 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		fallthrough // fallthrough
 	case cmn.ActDestroyLB:
+		p.bckExist.invalidate(bck.Bck)
 		if p.forwardCP(w, r, &msg, bucket) {
 			return
 		}
@@ -560,6 +618,12 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			p.invalmsghdlrf(w, r, fmtNotCloud, bucket)
 			return
 		}
+		if window := query.Get(cmn.URLParamUndoWindow); window != "" {
+			if err := p.validateUndoWindow(&msg, bck, window); err != nil {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
+		}
 		var xactID string
 		if xactID, err = p.doListRange(http.MethodDelete, bucket, &msg, query); err != nil {
 			p.invalmsghdlr(w, r, err.Error())
@@ -590,9 +654,8 @@ func (p *proxyrunner) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		p.invalmsghdlrf(w, r, msg, p.si, detail)
 		return
 	}
-	payload := make(msPayload)
-	if err := jsp.Decode(r.Body, &payload, jspMetasyncOpts, "metasync put"); err != nil {
-		cmn.InvalidHandlerDetailed(w, r, err.Error())
+	payload, body, ok := p.decodeMetasync(w, r)
+	if !ok {
 		return
 	}
 
@@ -660,6 +723,10 @@ ExtractRMD:
 		p.authn.updateRevokedList(revokedTokens)
 	}
 
+	if body != nil {
+		p.gossipRelay(w, r, body, errs)
+		return
+	}
 	if len(errs) > 0 {
 		p.invalmsghdlrf(w, r, "%v", errs)
 		return
@@ -753,6 +820,9 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if msg.Action == cmn.ActCreateLB || msg.Action == cmn.ActCreateLBFrom {
+		p.bckExist.invalidate(bck.Bck)
+	}
 	if bck.Bck.IsRemoteAIS() {
 		// forward to remote AIS as is, with a few distinct exceptions
 		switch msg.Action {
@@ -768,6 +838,15 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 		p.hpostCreateBucket(w, r, msg, bck)
 		return
 	}
+	if msg.Action == cmn.ActCreateLBFrom {
+		p.createBucketFrom(w, r, msg, bck)
+		return
+	}
+	// unalias (prior to bck.Init that would resolve the alias)
+	if msg.Action == cmn.ActUnaliasBck {
+		p.unaliasBucket(w, r, msg, bucket)
+		return
+	}
 	// only the primary can do metasync
 	xactDtor := xaction.XactsDtor[msg.Action]
 	if xactDtor.Metasync {
@@ -801,7 +880,7 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		if err = bck.Allow(cmn.AccessBckRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		bckFrom, bucketTo := bck, msg.Name
@@ -826,6 +905,8 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		w.Write([]byte(xactID))
+	case cmn.ActAliasBck:
+		p.aliasBucket(w, r, msg, bck)
 	case cmn.ActCopyBucket, cmn.ActETLBucket:
 		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessGET); err != nil {
 			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
@@ -853,6 +934,10 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 				p.invalmsghdlr(w, r, etl.ErrMissingUUID.Error(), http.StatusBadRequest)
 				return
 			}
+			if _, err := mirror.NewTransferFilter(internalMsg); err != nil {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
 		case cmn.ActCopyBucket:
 			cpyBckMsg := &cmn.CopyBckMsg{}
 			if err = cmn.MorphMarshal(msg.Value, cpyBckMsg); err != nil {
@@ -861,6 +946,14 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			internalMsg.BckTo = cpyBckMsg.BckTo
 			internalMsg.DryRun = cpyBckMsg.DryRun
 			internalMsg.Prefix = cpyBckMsg.Prefix
+			internalMsg.Ephemeral = cpyBckMsg.Ephemeral
+		}
+
+		if internalMsg.Ephemeral != nil {
+			if err := internalMsg.Ephemeral.ValidateAsProps(nil); err != nil {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
 		}
 
 		bckFrom, msgBckTo := bck, cluster.NewBckEmbed(internalMsg.BckTo)
@@ -880,7 +973,7 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 
 		if bckTo != nil {
 			if err = bckTo.Allow(cmn.AccessSYNC); err != nil {
-				p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+				p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 				return
 			}
 
@@ -894,12 +987,14 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 		}
 
 		var xactID string
-		if xactID, err = p.bucketToBucketTxn(bckFrom, bckTo, msg, internalMsg.DryRun); err != nil {
+		if xactID, err = p.bucketToBucketTxn(bckFrom, bckTo, msg, internalMsg); err != nil {
 			p.invalmsghdlr(w, r, err.Error())
 			return
 		}
 
 		w.Write([]byte(xactID))
+	case cmn.ActExportBck:
+		p.exportBucket(w, r, bck, msg)
 	case cmn.ActRegisterCB:
 		// TODO: choose the best permission
 		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessBckCREATE); err != nil {
@@ -925,7 +1020,7 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		if err = bck.Allow(cmn.AccessSYNC); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		var xactID string
@@ -940,13 +1035,13 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		if err = bck.Allow(cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		p.listObjects(w, r, bck, msg, begin)
 	case cmn.ActInvalListCache:
 		if err = bck.Allow(cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		p.qm.c.invalidate(bck.Bck)
@@ -956,17 +1051,50 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		p.bucketSummary(w, r, bck, msg)
+	case cmn.ActAnalyzeBucket:
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessObjLIST|cmn.AccessPUT); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessObjLIST); err == nil {
+			err = bck.Allow(cmn.AccessPUT)
+		}
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+		p.bucketAnalysis(w, r, bck, msg)
+	case cmn.ActHeadObjects:
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessObjHEAD); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessObjHEAD); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+		p.headObjects(w, r, msg, bck)
+	case cmn.ActPlanBprops:
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+		p.planBprops(w, r, msg, bck)
 	case cmn.ActMakeNCopies:
 		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessMAKENCOPIES); err != nil {
 			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessMAKENCOPIES); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		var xactID string
@@ -981,7 +1109,7 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		if err = bck.Allow(cmn.AccessEC); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		var xactID string
@@ -995,7 +1123,9 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 	}
 }
 
-func (p *proxyrunner) hpostCreateBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+// returns true upon successful creation
+func (p *proxyrunner) hpostCreateBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg,
+	bck *cluster.Bck) (ok bool) {
 	bucket := bck.Name
 	err := p.checkPermissions(r.Header, nil, cmn.AccessBckCREATE)
 	if err != nil {
@@ -1032,7 +1162,7 @@ func (p *proxyrunner) hpostCreateBucket(w http.ResponseWriter, r *http.Request,
 			// initialize backend
 			backend := cluster.BackendBck(bck)
 			if err = backend.InitNoBackend(p.owner.bmd, p.si); err != nil {
-				if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); !ok {
+				if _, notExist := err.(*cmn.ErrorRemoteBucketDoesNotExist); !notExist {
 					p.invalmsghdlrf(w, r,
 						"cannot create %s: failing to initialize backend %s, err: %v",
 						bck, backend, err)
@@ -1051,7 +1181,9 @@ func (p *proxyrunner) hpostCreateBucket(w http.ResponseWriter, r *http.Request,
 			errCode = http.StatusConflict
 		}
 		p.invalmsghdlr(w, r, err.Error(), errCode)
+		return
 	}
+	return true
 }
 
 func (p *proxyrunner) hpostAllBuckets(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
@@ -1071,6 +1203,8 @@ func (p *proxyrunner) hpostAllBuckets(w http.ResponseWriter, r *http.Request, ms
 		bmd := p.owner.bmd.get()
 		msg := p.newAisMsg(msg, nil, bmd)
 		_ = p.metasyncer.sync(revsPair{bmd, msg})
+	case cmn.ActBcksExist:
+		p.bcksExist(w, r, msg)
 	case cmn.ActSummaryBucket:
 		bck, err := newBckFromQuery("", query)
 		if err != nil {
@@ -1080,7 +1214,7 @@ func (p *proxyrunner) hpostAllBuckets(w http.ResponseWriter, r *http.Request, ms
 		// bck might be a query bck..
 		if err = bck.Init(p.owner.bmd, p.si); err == nil {
 			if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
-				p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+				p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 				return
 			}
 		}
@@ -1118,27 +1252,29 @@ func (p *proxyrunner) listObjects(w http.ResponseWriter, r *http.Request, bck *c
 
 	locationIsAIS := bck.IsAIS() || smsg.IsFlagSet(cmn.SelectCached)
 	if smsg.UUID == "" {
-		var nl nl.NotifListener
-		smsg.UUID = cmn.GenUUID()
-		if locationIsAIS || smsg.NeedLocalMD() {
-			nl = xaction.NewXactNL(smsg.UUID, &smap.Smap, smap.Tmap.Clone(),
-				cmn.ActListObjects, bck.Bck)
-		} else {
-			// random target to execute `list-objects` on a Cloud bucket
-			for sid, si := range smap.Tmap {
-				nl = xaction.NewXactNL(smsg.UUID, &smap.Smap, cluster.NodeMap{sid: si},
-					cmn.ActListObjects, bck.Bck)
-				break
-			}
-		}
-		nl.SetHrwOwner(&smap.Smap)
-		p.ic.registerEqual(regIC{nl: nl, smap: smap, msg: amsg})
+		p.regListObjects(bck, &smsg, smap, amsg, locationIsAIS)
 	}
-
+	amsg.Value = &smsg // hand over the UUID (and versions) to the owner
 	if p.ic.reverseToOwner(w, r, smsg.UUID, amsg) {
 		return
 	}
 
+	// continuation of a multi-page listing
+	if smsg.SmapVersion != 0 {
+		restart, err := p.checkListObjects(bck, &smsg, smap, locationIsAIS || smsg.NeedLocalMD())
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		if restart {
+			glog.Infof("%s: restarting list-objects %q (%s) after %q", p.si, smsg.UUID, bck, smsg.ContinuationToken)
+			p.regListObjects(bck, &smsg, smap, amsg, locationIsAIS)
+			if p.ic.reverseToOwner(w, r, smsg.UUID, amsg) {
+				return
+			}
+		}
+	}
+
 	if locationIsAIS {
 		bckList, err = p.listObjectsAIS(bck, smsg)
 	} else {
@@ -1154,11 +1290,17 @@ func (p *proxyrunner) listObjects(w http.ResponseWriter, r *http.Request, bck *c
 	}
 
 	cmn.Assert(bckList != nil)
+	bckList.UUID = smsg.UUID
+	bckList.SmapVersion, bckList.BMDVersion = smsg.SmapVersion, smsg.BMDVersion
 
-	if strings.Contains(r.Header.Get(cmn.HeaderAccept), cmn.ContentMsgPack) {
+	if accept := r.Header.Get(cmn.HeaderAccept); strings.Contains(accept, cmn.ContentMsgPack) {
 		if !p.writeMsgPack(w, r, bckList, "list_objects") {
 			return
 		}
+	} else if strings.Contains(accept, cmn.ContentProtobuf) {
+		if !p.writeProtobuf(w, r, bckList, "list_objects") {
+			return
+		}
 	} else if !p.writeJSON(w, r, bckList, "list_objects") {
 		return
 	}
@@ -1179,6 +1321,107 @@ func (p *proxyrunner) listObjects(w http.ResponseWriter, r *http.Request, bck *c
 	)
 }
 
+// registers a new (or restarted) listing with IC; the listing
+// gets versioned with the current cluster map and BMD
+func (p *proxyrunner) regListObjects(bck *cluster.Bck, smsg *cmn.SelectMsg, smap *smapX, amsg *cmn.ActionMsg,
+	locationIsAIS bool) {
+	var nl nl.NotifListener
+	smsg.UUID = cmn.GenUUID()
+	if locationIsAIS || smsg.NeedLocalMD() {
+		nl = xaction.NewXactNL(smsg.UUID, &smap.Smap, smap.Tmap.Clone(),
+			cmn.ActListObjects, bck.Bck)
+	} else {
+		// random target to execute `list-objects` on a Cloud bucket
+		for sid, si := range smap.Tmap {
+			nl = xaction.NewXactNL(smsg.UUID, &smap.Smap, cluster.NodeMap{sid: si},
+				cmn.ActListObjects, bck.Bck)
+			break
+		}
+	}
+	nl.SetHrwOwner(&smap.Smap)
+	p.ic.registerEqual(regIC{nl: nl, smap: smap, msg: amsg})
+	smsg.SmapVersion, smsg.BMDVersion = smap.Version, p.owner.bmd.get().Version
+}
+
+// checkListObjects validates the continuation of a given listing against the
+// current cluster map and BMD (the versions of which may have changed since
+// the listing has started):
+// * the listing gets invalidated if the bucket has been re-created, or if
+//   the cluster is rebalancing - the objects are moving between the targets;
+// * otherwise, the listing needs to be restarted (new UUID, same continuation
+//   token) if some of the targets it has been running on are gone, or
+//   there are new ones (`allTargets`) - the objects were (re)distributed
+//   among the current targets by the rebalance that has already finished.
+func (p *proxyrunner) checkListObjects(bck *cluster.Bck, smsg *cmn.SelectMsg, smap *smapX,
+	allTargets bool) (restart bool, err error) {
+	bmd := p.owner.bmd.get()
+	if smsg.BMDVersion != bmd.Version {
+		if bck.CreatedBMDVersion() > smsg.BMDVersion {
+			return false, cmn.NewErrListingInvalidated(smsg.UUID, fmt.Sprintf("%s has been re-created", bck))
+		}
+		smsg.BMDVersion = bmd.Version
+	}
+	if smsg.SmapVersion == smap.Version {
+		return
+	}
+	if p.rebalanceRunning() {
+		return false, cmn.NewErrListingInvalidated(smsg.UUID,
+			fmt.Sprintf("cluster map has changed (v%d => v%d) and rebalance is running", smsg.SmapVersion, smap.Version))
+	}
+	nl, exists := p.notifs.entry(smsg.UUID)
+	if !exists {
+		return true, nil
+	}
+	nl.RLock()
+	srcs := nl.Notifiers()
+	for sid := range srcs {
+		if smap.GetTarget(sid) == nil {
+			restart = true
+			break
+		}
+	}
+	if allTargets && !restart {
+		for sid := range smap.Tmap {
+			if _, ok := srcs[sid]; !ok {
+				restart = true
+				break
+			}
+		}
+	}
+	nl.RUnlock()
+	if !restart {
+		smsg.SmapVersion = smap.Version
+	}
+	return
+}
+
+// rebalanceRunning checks the notification listeners of this (IC) proxy and,
+// if none of those is a running rebalance, asks the targets; a target that
+// fails to respond is presumed to be rebalancing
+func (p *proxyrunner) rebalanceRunning() bool {
+	onlyRunning := true
+	if _, running := p.notifs.find(nlFilter{Kind: cmn.ActRebalance, OnlyRunning: &onlyRunning}); running {
+		return true
+	}
+	var (
+		body    = cmn.MustMarshal(xaction.XactReqMsg{Kind: cmn.ActRebalance, OnlyRunning: &onlyRunning})
+		query   = url.Values{cmn.URLParamWhat: []string{cmn.QueryXactStats}}
+		results = p.callTargets(http.MethodGet, cmn.JoinWords(cmn.Version, cmn.Xactions), body, query)
+		running bool
+	)
+	for res := range results {
+		if res.err != nil {
+			running = true
+			continue
+		}
+		var xacts []jsoniter.RawMessage
+		if err := jsoniter.Unmarshal(res.bytes, &xacts); err != nil || len(xacts) > 0 {
+			running = true
+		}
+	}
+	return running
+}
+
 // bucket == "": all buckets for a given provider
 func (p *proxyrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, amsg *cmn.ActionMsg) {
 	var (
@@ -1217,7 +1460,7 @@ func (p *proxyrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 func (p *proxyrunner) gatherBucketSummary(bck *cluster.Bck, msg *cmn.BucketSummaryMsg) (
 	summaries cmn.BucketsSummaries, uuid string, err error) {
 	var (
-		isNew, q = p.initAsyncQuery(bck, msg, cmn.GenUUID())
+		isNew, q = p.initAsyncQuery(bck, &msg.UUID, cmn.GenUUID())
 		config   = cmn.GCO.Get()
 	)
 	var (
@@ -1305,7 +1548,7 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err = bck.Allow(cmn.AccessObjRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		if bck.Props.EC.Enabled {
@@ -1314,13 +1557,27 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		}
 		p.objRename(w, r, bck)
 		return
+	case cmn.ActMoveObject:
+		p.objMove(w, r, bck, &msg)
+		return
+	case cmn.ActPinObject, cmn.ActSetCustomMD:
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessPUT); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessPUT); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+		p.objUpdateMD(w, r, bck)
+		return
 	case cmn.ActPromote:
 		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessPROMOTE); err != nil {
 			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessPROMOTE); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 			return
 		}
 		if !filepath.IsAbs(msg.Name) {
@@ -1447,7 +1704,7 @@ func (p *proxyrunner) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := bck.Allow(cmn.AccessPATCH); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	if err = p.checkAction(msg, cmn.ActSetBprops, cmn.ActResetBprops); err != nil {
@@ -1457,7 +1714,11 @@ func (p *proxyrunner) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 
 	var xactID string
 	if xactID, err = p.setBucketProps(w, r, msg, bck, propsToUpdate); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		errCode := http.StatusBadRequest
+		if _, ok := err.(*cmn.ErrBpropsChanged); ok {
+			errCode = http.StatusConflict
+		}
+		p.invalmsghdlr(w, r, err.Error(), errCode)
 		return
 	}
 	w.Write([]byte(xactID))
@@ -1486,7 +1747,7 @@ func (p *proxyrunner) httpobjhead(w http.ResponseWriter, r *http.Request, origUR
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	smap := p.owner.smap.get()
@@ -1678,21 +1939,21 @@ func (p *proxyrunner) redirectURL(r *http.Request, si *cluster.Snode, ts time.Ti
 	return
 }
 
-func (p *proxyrunner) initAsyncQuery(bck *cluster.Bck, msg *cmn.BucketSummaryMsg, newTaskID string) (bool, url.Values) {
-	isNew := msg.UUID == ""
+func (p *proxyrunner) initAsyncQuery(bck *cluster.Bck, uuid *string, newTaskID string) (bool, url.Values) {
+	isNew := *uuid == ""
 	q := url.Values{}
 	if isNew {
-		msg.UUID = newTaskID
+		*uuid = newTaskID
 		q.Set(cmn.URLParamTaskAction, cmn.TaskStart)
 		if glog.FastV(4, glog.SmoduleAIS) {
-			glog.Infof("proxy: starting new async task %s", msg.UUID)
+			glog.Infof("proxy: starting new async task %s", *uuid)
 		}
 	} else {
 		// First request is always 'Status' to avoid wasting gigabytes of
 		// traffic in case when few targets have finished their tasks.
 		q.Set(cmn.URLParamTaskAction, cmn.TaskStatus)
 		if glog.FastV(4, glog.SmoduleAIS) {
-			glog.Infof("proxy: reading async task %s result", msg.UUID)
+			glog.Infof("proxy: reading async task %s result", *uuid)
 		}
 	}
 
@@ -1732,6 +1993,9 @@ func (p *proxyrunner) listObjectsAIS(bck *cluster.Bck, smsg cmn.SelectMsg) (allE
 	if smsg.PageSize == 0 {
 		smsg.PageSize = cmn.DefaultListPageSizeAIS
 	}
+	if smsg.PageSize >= listStreamPageSize && !smsg.UseCache {
+		return p.listObjectsAISStream(bck, smsg)
+	}
 
 	var (
 		aisMsg *aisMsg
@@ -1918,6 +2182,107 @@ func (p *proxyrunner) objRename(w http.ResponseWriter, r *http.Request, bck *clu
 	p.statsT.Add(stats.RenameCount, 1)
 }
 
+// (un)pinning and custom metadata updates are done by the object's target
+func (p *proxyrunner) objUpdateMD(w http.ResponseWriter, r *http.Request, bck *cluster.Bck) {
+	started := time.Now()
+	apiItems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(apiItems[1]), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+// Starts moving the object to another bucket (of any provider) on the object's
+// target and returns the ID of the task that can be used to track the progress.
+func (p *proxyrunner) objMove(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	apiItems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	objName := apiItems[1]
+	mv := &cmn.ActValMoveObject{}
+	if err := cmn.MorphMarshal(msg.Value, mv); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if mv.ObjName == "" {
+		mv.ObjName = objName
+	}
+	args := remBckAddArgs{p: p, w: w, r: r, queryBck: cluster.NewBckEmbed(mv.Bck), msg: msg}
+	bckTo, err := args.initAndTry(mv.Bck.Name)
+	if err != nil {
+		return
+	}
+	if bckTo.Equal(bck, false, false) && mv.ObjName == objName {
+		p.invalmsghdlrf(w, r, "%q: source and destination are the same (%s/%s)", msg.Action, bck, objName)
+		return
+	}
+	if bckTo.IsHTTP() {
+		p.invalmsghdlrf(w, r, "%q is not supported for destination %s", msg.Action, bckTo)
+		return
+	}
+	if bck.Props.EC.Enabled {
+		p.invalmsghdlrf(w, r, "%q is not supported for erasure-coded buckets: %s", msg.Action, bck)
+		return
+	}
+	for _, perm := range []cmn.AccessAttrs{cmn.AccessGET, cmn.AccessObjDELETE} {
+		if err := p.checkPermissions(r.Header, &bck.Bck, perm); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err := bck.Allow(int(perm)); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+	}
+	if err := p.checkPermissions(r.Header, &bckTo.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := bckTo.Allow(cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+		return
+	}
+
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	mv.Bck = bckTo.Bck
+	mv.UUID = cmn.GenUUID()
+	nl := xaction.NewXactNL(mv.UUID, &smap.Smap, cluster.NodeMap{si.ID(): si}, cmn.ActMoveObject, bck.Bck, bckTo.Bck)
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: smap, query: r.URL.Query()})
+
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   r.URL.Path,
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+			Body:   cmn.MustMarshal(cmn.ActionMsg{Action: msg.Action, Value: mv}),
+		},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+	if res.err != nil {
+		p.invalmsghdlr(w, r, res.err.Error(), res.status)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("MOVE %s/%s => %s/%s @ %s (%s)", bck, objName, bckTo, mv.ObjName, si, mv.UUID)
+	}
+	w.Write([]byte(mv.UUID))
+}
+
 func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
 	apiItems, err := p.checkRESTItems(w, r, 1, false, cmn.Version, cmn.Objects)
 	if err != nil {
@@ -1984,7 +2349,7 @@ func (p *proxyrunner) doListRange(method, bucket string, msg *cmn.ActionMsg, que
 		timeout = cmn.DefaultTimeout
 	}
 
-	nlb := xaction.NewXactNL(aisMsg.UUID, &smap.Smap, smap.Tmap.Clone(), aisMsg.Action)
+	nlb := xaction.NewXactNL(aisMsg.UUID, &smap.Smap, smap.Tmap.ActiveMap(), aisMsg.Action)
 	nlb.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{smap: smap, query: query, nl: nlb})
 	results = p.bcastToGroup(bcastArgs{
@@ -2222,6 +2587,11 @@ func (p *proxyrunner) httpdaeput(w http.ResponseWriter, r *http.Request) {
 			p.invalmsghdlr(w, r, err.Error())
 			return
 		}
+	case cmn.ActSetLogLevel:
+		if err := setLogLevel(msg.Value); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
 	case cmn.ActShutdown:
 		var (
 			query = r.URL.Query()
@@ -2392,6 +2762,7 @@ func (p *proxyrunner) becomeNewPrimary(proxyIDToRemove string) {
 	}
 	err := p.owner.smap.modify(ctx)
 	cmn.AssertNoErr(err)
+	p.restoreDlJobs()
 }
 
 // Must be called under Lock, preferably inside `smap.modify` `pre()` callback.
@@ -2590,6 +2961,14 @@ func (p *proxyrunner) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.ic.writeStatus(w, r)
 	case cmn.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
+	case cmn.GetWhatCloudHealth:
+		p.queryCloudHealth(w, r, what)
+	case cmn.GetWhatCapForecast:
+		p.queryCapForecast(w, r, what)
+	case cmn.GetWhatPrefill:
+		p.queryPrefill(w, r, what)
+	case cmn.GetWhatPingMatrix:
+		p.queryPingMatrix(w, r, what)
 	case cmn.GetWhatRemoteAIS:
 		config := cmn.GCO.Get()
 		smap := p.owner.smap.get()
@@ -2722,6 +3101,28 @@ func (p *proxyrunner) queryClusterMountpaths(w http.ResponseWriter, r *http.Requ
 	_ = p.writeJSON(w, r, out, what)
 }
 
+// the targets' capacity forecasts plus the cluster-wide one (see stats/capforecast.go)
+func (p *proxyrunner) queryCapForecast(w http.ResponseWriter, r *http.Request, what string) {
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  r.URL.Query(),
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxKeepalive,
+		fv:      func() interface{} { return &cmn.CapForecast{} },
+	})
+	targets := make(map[string]*cmn.CapForecast, len(results))
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		targets[res.si.ID()] = res.v.(*cmn.CapForecast)
+	}
+	_ = p.writeJSON(w, r, stats.AggregateCapForecast(targets, cmn.GCO.Get()), what)
+}
+
 // helper methods for querying targets
 
 func (p *proxyrunner) _queryTargets(w http.ResponseWriter, r *http.Request) cmn.JSONRawMsgs {
@@ -2955,6 +3356,7 @@ func (p *proxyrunner) _updPre(ctx *smapModifier, clone *smapX) error {
 	}
 	ctx.exists = clone.putNode(ctx.nsi, ctx.flags)
 	if ctx.nsi.IsTarget() {
+		p._prefillPre(ctx, clone)
 		// Notify targets that they need to set up GFN
 		aisMsg := p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActStartGFN}, clone, nil)
 		notifyPairs := revsPair{clone, aisMsg}
@@ -2976,7 +3378,9 @@ func (p *proxyrunner) _updPost(ctx *smapModifier, clone *smapX) {
 		// Trigger rebalance (in case target with given ID already exists
 		// we must trigger the rebalance and assume it is a new target
 		// with the same ID).
-		if ctx.exists || p.requiresRebalance(ctx.smap, clone) {
+		if ctx.prefill {
+			glog.Infof("%s: %s joins in prefill mode (no global rebalance)", p.si, ctx.nsi)
+		} else if ctx.exists || p.requiresRebalance(ctx.smap, clone) {
 			rmdClone := p.owner.rmd.modify(func(clone *rebMD) {
 				clone.TargetIDs = []string{ctx.nsi.ID()}
 				clone.inc()
@@ -2995,10 +3399,16 @@ func (p *proxyrunner) _updPost(ctx *smapModifier, clone *smapX) {
 	if len(tokens.Tokens) > 0 {
 		pairs = append(pairs, revsPair{tokens, aisMsg})
 	}
-	_ = p.metasyncer.sync(pairs...)
+	wg := p.metasyncer.sync(pairs...)
 	if nl != nil {
 		p.ic.registerEqual(regIC{smap: clone, nl: nl})
 	}
+	if ctx.prefill {
+		go func() {
+			wg.Wait()
+			p.startPrefill(clone)
+		}()
+	}
 	p.syncNewICOwners(ctx.smap, clone)
 }
 
@@ -3074,6 +3484,9 @@ func (p *proxyrunner) httpcludel(w http.ResponseWriter, r *http.Request) {
 func (p *proxyrunner) _unregNodePre(ctx *smapModifier, clone *smapX) (err error) {
 	ctx.smap = p.owner.smap.get()
 	ctx.status, err = p.unregisterNode(clone, ctx.sid)
+	if err == nil && p.requiresRebalance(ctx.smap, clone) {
+		clearPrefill(clone) // global rebalance supersedes prefill
+	}
 	return err
 }
 
@@ -3159,6 +3572,7 @@ func (p *proxyrunner) unregisterNode(clone *smapX, sid string) (status int, err
 // '{"action": "syncsmap"}' /v1/cluster => (proxy) => PUT '{Smap}' /v1/daemon/syncsmap => target(s)
 // '{"action": cmn.ActXactStart}' /v1/cluster
 // '{"action": cmn.ActXactStop}' /v1/cluster
+// '{"action": cmn.ActXactLimits}' /v1/cluster
 // '{"action": cmn.ActSendOwnershipTbl}' /v1/cluster
 // '{"action": cmn.ActRebalance}' /v1/cluster => (proxy) => PUT '{Smap}' /v1/daemon/rebalance => target(s)
 // '{"action": "setconfig"}' /v1/cluster => (proxy) =>
@@ -3333,23 +3747,56 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
+	case cmn.ActSetLogLevel:
+		if err := setLogLevel(msg.Value); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		// same message -> all targets and proxies
+		results := p.callAll(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Daemon), cmn.MustMarshal(msg))
+		for res := range results {
+			if res.err != nil {
+				p.invalmsghdlrf(w, r, "%s: %s failed, err: %s", msg.Action, res.si, res.details)
+				return
+			}
+		}
+	case cmn.ActUndoDelete:
+		p.undoDelete(w, r, msg)
 	case cmn.ActShutdown:
 		glog.Infoln("Proxy-controlled cluster shutdown...")
 		p.callAll(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Daemon), cmn.MustMarshal(msg))
 		time.Sleep(time.Second)
 		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
-	case cmn.ActXactStart, cmn.ActXactStop:
+	case cmn.ActXactStart, cmn.ActXactStop, cmn.ActXactLimits:
 		xactMsg := xaction.XactReqMsg{}
 		if err := cmn.MorphMarshal(msg.Value, &xactMsg); err != nil {
 			p.invalmsghdlr(w, r, err.Error())
 			return
 		}
+		if msg.Action == cmn.ActXactLimits {
+			if xactMsg.ID == "" || xactMsg.Limits == nil {
+				p.invalmsghdlrf(w, r, "%q requires job ID and limits", msg.Action)
+				return
+			}
+			if xactMsg.Limits.BatchSize < 0 {
+				p.invalmsghdlrf(w, r, "'limits.batch_size' must be non-negative (got: %d)", xactMsg.Limits.BatchSize)
+				return
+			}
+			if p.setJobLimits(w, r, msg, &xactMsg) {
+				return
+			}
+		}
 		if msg.Action == cmn.ActXactStart && xactMsg.Kind == cmn.ActRebalance {
 			smap := p.owner.smap.get()
 			if err := p.canStartRebalance(true /*skip config*/); err != nil {
 				p.invalmsghdlr(w, r, err.Error())
 				return
 			}
+			if err := p.endPrefill(); err != nil {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
+			smap = p.owner.smap.get()
 			rmdClone := p.owner.rmd.modify(func(clone *rebMD) {
 				clone.inc()
 			})
@@ -3365,6 +3812,28 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 			w.Write([]byte(xaction.RebID(rmdClone.version()).String()))
 			return
 		}
+		if msg.Action == cmn.ActXactStart && xactMsg.Kind == cmn.ActPrefill {
+			p.invalmsghdlrf(w, r, "%q is started by the primary when a target joins (see rebalance.prefill)",
+				xactMsg.Kind)
+			return
+		}
+		if msg.Action == cmn.ActXactStart && xactMsg.Kind == cmn.ActEvacuate {
+			if xactMsg.Evacuate == nil {
+				p.invalmsghdlrf(w, r, "%q requires destination bucket", xactMsg.Kind)
+				return
+			}
+			// remote destination (not yet in BMD) gets added on the fly
+			args := remBckAddArgs{p: p, w: w, r: r, queryBck: cluster.NewBckEmbed(xactMsg.Evacuate.Dst), msg: msg}
+			if _, err := args.initAndTry(xactMsg.Evacuate.Dst.Name); err != nil {
+				return
+			}
+		}
+
+		if msg.Action == cmn.ActXactStart && (xactMsg.Kind == cmn.ActBackupBck || xactMsg.Kind == cmn.ActRestoreBck) {
+			if !p.prepBackup(w, r, msg, &xactMsg) {
+				return
+			}
+		}
 
 		if msg.Action == cmn.ActXactStart {
 			xactMsg.ID = cmn.GenUUID()
@@ -3382,6 +3851,9 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		if msg.Action == cmn.ActXactStart {
 			smap := p.owner.smap.get()
 			nl := xaction.NewXactNL(xactMsg.ID, &smap.Smap, smap.Tmap.Clone(), xactMsg.Kind)
+			if xactMsg.Kind == cmn.ActBackupBck {
+				p.regBackupDone(nl, xactMsg)
+			}
 			p.ic.registerEqual(regIC{smap: smap, nl: nl})
 			w.Write([]byte(xactMsg.ID))
 		}
@@ -0,0 +1,84 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Bucket aliases are cluster-wide (BMD-stored) names that resolve to existing
+// buckets - see cluster.BMD.Alias and cluster.Bck.Init. An alias can be
+// re-pointed (by aliasing another bucket with the same name) and removed;
+// removing or re-pointing an alias never affects the data. Renaming the bucket
+// re-points its aliases, destroying (evicting) the bucket removes them.
+
+// POST {action: aliasbck, name: alias} /v1/buckets/bucket-name
+func (p *proxyrunner) aliasBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	alias := msg.Name
+	if err := cmn.ValidateBckName(alias); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessBckRENAME); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if p.forwardCP(w, r, msg, alias) {
+		return
+	}
+	aliasBck := cluster.NewBck(alias, cmn.ProviderAIS, cmn.NsGlobal)
+	err := p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
+		if _, present := clone.Get(aliasBck); present {
+			return false, cmn.NewErrorBucketAlreadyExists(aliasBck.Bck, p.si.String())
+		}
+		if curr, ok := clone.Alias(alias); ok && curr.Equal(bck.Bck) {
+			return false, nil
+		}
+		clone.setAlias(alias, bck.Bck)
+		return true, nil
+	}, func(clone *bucketMD) {
+		msg := p.newAisMsg(msg, nil, clone)
+		_ = p.metasyncer.sync(revsPair{clone, msg})
+	})
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusConflict)
+		return
+	}
+	glog.Infof("%s: alias %q => %s", p.si, alias, bck)
+}
+
+// POST {action: unaliasbck} /v1/buckets/alias
+func (p *proxyrunner) unaliasBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, alias string) {
+	bck, ok := p.owner.bmd.get().Alias(alias)
+	if !ok {
+		p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "bucket alias %q does not exist", alias)
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bck, cmn.AccessBckRENAME); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if p.forwardCP(w, r, msg, alias) {
+		return
+	}
+	err := p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
+		if !clone.delAlias(alias) {
+			return false, cmn.NewNotFoundError("bucket alias %q", alias)
+		}
+		return true, nil
+	}, func(clone *bucketMD) {
+		msg := p.newAisMsg(msg, nil, clone)
+		_ = p.metasyncer.sync(revsPair{clone, msg})
+	})
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	glog.Infof("%s: alias %q removed", p.si, alias)
+}
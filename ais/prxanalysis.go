@@ -0,0 +1,136 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket analysis is an asynchronous task (same as bucket summary): the first
+// request starts the task on all targets and returns its ID; subsequent requests
+// (with the ID) return 202 while any target is still walking. Once all targets
+// are done, the proxy merges their results, stores the report in the bucket,
+// and returns it to the caller.
+
+// POST {action: analyzebck, value: {...}} /v1/buckets/bucket-name
+func (p *proxyrunner) bucketAnalysis(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, amsg *cmn.ActionMsg) {
+	amsgJSON := cmn.MustMarshal(amsg.Value)
+	msg := cmn.BucketAnalysisMsg{}
+	if err := jsoniter.Unmarshal(amsgJSON, &msg); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if msg.Depth < 0 {
+		p.invalmsghdlrf(w, r, "invalid prefix depth %d", msg.Depth)
+		return
+	}
+	if id := r.URL.Query().Get(cmn.URLParamUUID); id != "" {
+		msg.UUID = id
+	}
+	analysis, uuid, err := p.gatherBucketAnalysis(bck, &msg)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if uuid != "" {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(uuid))
+		return
+	}
+	p.writeJSON(w, r, analysis, "bucket_analysis")
+}
+
+func (p *proxyrunner) gatherBucketAnalysis(bck *cluster.Bck, msg *cmn.BucketAnalysisMsg) (
+	analysis *cmn.BucketAnalysis, uuid string, err error) {
+	var (
+		isNew, q = p.initAsyncQuery(bck, &msg.UUID, cmn.GenUUID())
+		config   = cmn.GCO.Get()
+		smap     = p.owner.smap.get()
+		aisMsg   = p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActAnalyzeBucket, Value: msg}, smap, nil)
+		args     = bcastArgs{
+			req: cmn.ReqArgs{
+				Method: http.MethodPost,
+				Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+				Query:  q,
+				Body:   cmn.MustMarshal(aisMsg),
+			},
+			smap:    smap,
+			timeout: config.Timeout.MaxHostBusy + config.Timeout.CplaneOperation,
+		}
+	)
+	results := p.bcastToGroup(args)
+	allOK, _, err := p.checkBckTaskResp(msg.UUID, results)
+	if err != nil {
+		return nil, "", err
+	}
+	if !allOK || isNew {
+		return nil, msg.UUID, nil
+	}
+
+	// all targets are done - merge
+	q = cmn.AddBckToQuery(url.Values{}, bck.Bck)
+	q.Set(cmn.URLParamTaskAction, cmn.TaskResult)
+	q.Set(cmn.URLParamSilent, "true")
+	args.req.Query = q
+	args.fv = func() interface{} { return &cmn.BucketAnalysis{} }
+	for res := range p.bcastToGroup(args) {
+		if res.err != nil {
+			return nil, "", res.err
+		}
+		targetAnalysis := res.v.(*cmn.BucketAnalysis)
+		if analysis == nil {
+			analysis = cmn.NewBucketAnalysis(bck.Bck, targetAnalysis.Started)
+		}
+		analysis.Merge(targetAnalysis)
+	}
+	if analysis == nil {
+		return nil, "", fmt.Errorf("task %s: no results", msg.UUID)
+	}
+	if analysis.Dedup != nil {
+		analysis.Dedup.Finalize()
+	}
+	analysis.Report = msg.Report
+	if analysis.Report == "" {
+		analysis.Report = "analysis/" + msg.UUID + ".json"
+	}
+	if err = p.putAnalysisReport(bck, analysis, smap); err != nil {
+		return nil, "", err
+	}
+	return analysis, "", nil
+}
+
+// store the report as a regular object (the proxy "redirects" to itself)
+func (p *proxyrunner) putAnalysisReport(bck *cluster.Bck, analysis *cmn.BucketAnalysis, smap *smapX) error {
+	si, err := cluster.HrwTarget(bck.MakeUname(analysis.Report), &smap.Smap)
+	if err != nil {
+		return err
+	}
+	q := cmn.AddBckToQuery(nil, bck.Bck)
+	q.Set(cmn.URLParamProxyID, p.si.ID())
+	q.Set(cmn.URLParamUnixTime, cmn.UnixNano2S(time.Now().UnixNano()))
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodPut,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, analysis.Report),
+			Query:  q,
+			Body:   cmn.MustMarshal(analysis),
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxHostBusy,
+	})
+	if res.err != nil {
+		return fmt.Errorf("failed to store analysis report %s/%s: %v", bck, analysis.Report, res.err)
+	}
+	glog.Infof("%s: stored analysis report %s/%s", p.si, bck, analysis.Report)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/runners"
+)
+
+// prepBackup validates backup (restore) request prior to starting the
+// corresponding xaction on all targets; in particular, restore creates the
+// destination bucket with the props of the backed-up one.
+// Returns false if the request has failed (and the error has been written).
+func (p *proxyrunner) prepBackup(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg,
+	xactMsg *xaction.XactReqMsg) bool {
+	if xactMsg.Backup == nil {
+		p.invalmsghdlrf(w, r, "%q requires backup location", xactMsg.Kind)
+		return false
+	}
+	// Cloud (not yet in BMD) gets added on the fly
+	args := remBckAddArgs{p: p, w: w, r: r, queryBck: cluster.NewBckEmbed(xactMsg.Backup.Cloud), msg: msg}
+	cloud, err := args.initAndTry(xactMsg.Backup.Cloud.Name)
+	if err != nil {
+		return false
+	}
+	if !cloud.IsCloud() && !cloud.IsRemoteAIS() {
+		p.invalmsghdlrf(w, r, "%q: backup location %s must be a Cloud or remote AIS bucket", xactMsg.Kind, cloud)
+		return false
+	}
+	if xactMsg.Bck.Provider == "" {
+		xactMsg.Bck.Provider = cmn.ProviderAIS
+	}
+	bck := cluster.NewBckEmbed(xactMsg.Bck)
+	if !bck.IsAIS() {
+		p.invalmsghdlrf(w, r, "%q: %s is not an ais bucket", xactMsg.Kind, bck)
+		return false
+	}
+	if xactMsg.Kind == cmn.ActBackupBck {
+		if err := bck.Init(p.owner.bmd, p.si); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			return false
+		}
+		return true
+	}
+
+	// restore
+	if err := cmn.ValidateBckName(bck.Name); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return false
+	}
+	if _, present := p.owner.bmd.get().Get(bck); present {
+		p.invalmsghdlr(w, r, cmn.NewErrorBucketAlreadyExists(bck.Bck, p.si.String()).Error(), http.StatusConflict)
+		return false
+	}
+	meta, err := p.backupMeta(cloud, xactMsg.Backup.Prefix)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return false
+	}
+	props := meta.Props
+	props.BID, props.Created, props.PropsVersion = 0, 0, 0
+	bck.Props = props
+	if err := p.createBucket(&cmn.ActionMsg{Action: cmn.ActCreateLB}, bck); err != nil {
+		errCode := http.StatusInternalServerError
+		if _, ok := err.(*cmn.ErrorBucketAlreadyExists); ok {
+			errCode = http.StatusConflict
+		}
+		p.invalmsghdlr(w, r, err.Error(), errCode)
+		return false
+	}
+	return true
+}
+
+// get the backup metadata from the target that "owns" it
+func (p *proxyrunner) backupMeta(cloud *cluster.Bck, prefix string) (*xaction.BackupMeta, error) {
+	var (
+		smap     = p.owner.smap.get()
+		metaName = runners.BackupMetaName(prefix)
+	)
+	si, err := cluster.HrwTarget(cloud.MakeUname(metaName), &smap.Smap)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set(cmn.URLParamWhat, cmn.GetWhatBackupMeta)
+	query.Set(cmn.URLParamBackup, cloud.Bck.String()+"/"+prefix)
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodGet,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  query,
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxKeepalive,
+		v:       &xaction.BackupMeta{},
+	})
+	if res.err != nil {
+		return nil, res.err
+	}
+	return res.v.(*xaction.BackupMeta), nil
+}
+
+// the backup is complete only when all targets have uploaded their objects and
+// manifests - only then the bucket's metadata gets stored (see backupDone)
+func (p *proxyrunner) regBackupDone(xnl *xaction.NotifXactListener, xactMsg xaction.XactReqMsg) {
+	xnl.F = func(n nl.NotifListener) { p.backupDone(n, &xactMsg) }
+}
+
+func (p *proxyrunner) backupDone(n nl.NotifListener, xactMsg *xaction.XactReqMsg) {
+	if err := n.Err(false); err != nil || n.Aborted() {
+		glog.Errorf("backup[%s] of %s didn't finish successfully, err: %v, aborted: %v (not storing its metadata)",
+			n.UUID(), xactMsg.Bck, err, n.Aborted())
+		return
+	}
+	smap := p.owner.smap.get()
+	if !smap.isPrimary(p.si) {
+		return
+	}
+	bck := cluster.NewBckEmbed(xactMsg.Bck)
+	if err := bck.Init(p.owner.bmd, p.si); err != nil {
+		glog.Errorf("backup[%s]: %v (not storing its metadata)", n.UUID(), err)
+		return
+	}
+	meta := &xaction.BackupMeta{
+		ID:      n.UUID(),
+		Bck:     bck.Bck,
+		Props:   bck.Props.Clone(),
+		Targets: make([]string, 0, len(n.Notifiers())),
+		Time:    time.Now(),
+	}
+	for tid := range n.Notifiers() {
+		meta.Targets = append(meta.Targets, tid)
+	}
+	sort.Strings(meta.Targets)
+
+	var (
+		cloud    = cluster.NewBckEmbed(xactMsg.Backup.Cloud)
+		metaName = runners.BackupMetaName(xactMsg.Backup.Prefix)
+	)
+	si, err := cluster.HrwTarget(cloud.MakeUname(metaName), &smap.Smap)
+	if err != nil {
+		glog.Errorf("backup[%s]: failed to store metadata: %v", n.UUID(), err)
+		return
+	}
+	query := url.Values{}
+	query.Set(cmn.URLParamBackup, cloud.Bck.String()+"/"+xactMsg.Backup.Prefix)
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodPut,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  query,
+			Body:   cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActBackupBck, Value: meta}),
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxHostBusy,
+	})
+	if res.err != nil {
+		glog.Errorf("backup[%s]: %s failed to store metadata: %v", n.UUID(), si, res.err)
+		return
+	}
+	glog.Infof("backup[%s] of %s => %s/%s done", n.UUID(), bck, cloud, xactMsg.Backup.Prefix)
+}
@@ -0,0 +1,175 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Bucket existence checks (see api.DoesBucketExist and api.DoBucketsExist):
+// buckets in the BMD exist; existence of a remote (Cloud or remote AIS) bucket
+// that is not (yet) in the BMD is determined by HEAD-ing it via a random
+// target - which, in turn, asks the remote. To not hit the remote provider on
+// every check, the proxy caches the results (both positive and negative) for a
+// short while. Cached results are dropped when the BMD changes and when the
+// bucket gets created, destroyed, or evicted via this proxy.
+
+const (
+	bckExistTTL        = 30 * time.Second
+	bckNotExistTTL     = 10 * time.Second
+	bckExistHousekeepT = time.Minute
+
+	bckExistConcurrency = 16 // max concurrent HEADs per batch
+)
+
+type (
+	bckExistEntry struct {
+		exists bool
+		bmdVer int64 // BMD version at the time of the check
+		ts     int64 // mono time
+	}
+	bckExistCache struct {
+		mtx sync.RWMutex
+		m   map[cmn.Bck]bckExistEntry // keyed by bucket without props
+	}
+)
+
+///////////////////
+// bckExistCache //
+///////////////////
+
+func (c *bckExistCache) init() {
+	c.m = make(map[cmn.Bck]bckExistEntry, 16)
+	hk.Reg("bck-exist", c.housekeep, bckExistHousekeepT)
+}
+
+func bckExistKey(bck cmn.Bck) cmn.Bck {
+	return cmn.Bck{Name: bck.Name, Provider: bck.Provider, Ns: bck.Ns}
+}
+
+func (e *bckExistEntry) expired(now int64) bool {
+	ttl := bckExistTTL
+	if !e.exists {
+		ttl = bckNotExistTTL
+	}
+	return time.Duration(now-e.ts) > ttl
+}
+
+func (c *bckExistCache) get(bck cmn.Bck, bmdVer int64) (exists, ok bool) {
+	c.mtx.RLock()
+	e, ok := c.m[bckExistKey(bck)]
+	c.mtx.RUnlock()
+	if !ok || e.bmdVer != bmdVer || e.expired(mono.NanoTime()) {
+		return false, false
+	}
+	return e.exists, true
+}
+
+func (c *bckExistCache) set(bck cmn.Bck, bmdVer int64, exists bool) {
+	c.mtx.Lock()
+	c.m[bckExistKey(bck)] = bckExistEntry{exists: exists, bmdVer: bmdVer, ts: mono.NanoTime()}
+	c.mtx.Unlock()
+}
+
+func (c *bckExistCache) invalidate(bck cmn.Bck) {
+	c.mtx.Lock()
+	delete(c.m, bckExistKey(bck))
+	c.mtx.Unlock()
+}
+
+func (c *bckExistCache) housekeep() time.Duration {
+	now := mono.NanoTime()
+	c.mtx.Lock()
+	for bck, e := range c.m {
+		if e.expired(now) {
+			delete(c.m, bck)
+		}
+	}
+	c.mtx.Unlock()
+	return bckExistHousekeepT
+}
+
+/////////////////
+// proxyrunner //
+/////////////////
+
+// POST {action: ActBcksExist, value: [bck, ...]} /v1/buckets
+func (p *proxyrunner) bcksExist(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	var bcks []cmn.Bck
+	if err := cmn.MorphMarshal(msg.Value, &bcks); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, bck := range bcks {
+		if bck.Name == "" || !cmn.IsValidProvider(bck.Provider) {
+			p.invalmsghdlrf(w, r, "%s: invalid bucket %s (expecting name and provider)", msg.Action, bck)
+			return
+		}
+	}
+	var (
+		exist = make([]bool, len(bcks))
+		errs  = make([]error, len(bcks))
+		sema  = make(chan struct{}, bckExistConcurrency)
+		wg    = &sync.WaitGroup{}
+	)
+	for i := range bcks {
+		wg.Add(1)
+		sema <- struct{}{}
+		go func(i int) {
+			exist[i], errs[i] = p.bckExists(bcks[i])
+			<-sema
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			p.invalmsghdlrf(w, r, "%s: %v", bcks[i], err)
+			return
+		}
+	}
+	_ = p.writeJSON(w, r, exist, msg.Action)
+}
+
+func (p *proxyrunner) bckExists(qbck cmn.Bck) (bool, error) {
+	var (
+		bmd = p.owner.bmd.get()
+		bck = cluster.NewBckEmbed(bckExistKey(qbck))
+		err = bck.Init(p.owner.bmd, p.si)
+	)
+	if err == nil {
+		return true, nil
+	}
+	if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); !ok {
+		if _, ok := err.(*cmn.ErrorBucketDoesNotExist); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	// remote bucket that is not in the BMD
+	if bck.IsHTTP() {
+		return false, nil // (requires the original URL)
+	}
+	if exists, ok := p.bckExist.get(bck.Bck, bmd.version()); ok {
+		return exists, nil
+	}
+	_, err, _ = p.headCloudBck(bck.Bck, nil)
+	if err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); !ok {
+			return false, fmt.Errorf("failed to check existence: %v", err)
+		}
+	}
+	exists := err == nil
+	p.bckExist.set(bck.Bck, bmd.version(), exists)
+	return exists, nil
+}
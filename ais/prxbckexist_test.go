@@ -0,0 +1,58 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+func TestBckExistCache(t *testing.T) {
+	var (
+		c     = &bckExistCache{m: make(map[cmn.Bck]bckExistEntry)}
+		bckA  = cmn.Bck{Name: "a", Provider: cmn.ProviderAmazon, Ns: cmn.NsGlobal}
+		bckB  = cmn.Bck{Name: "b", Provider: cmn.ProviderAmazon, Ns: cmn.NsGlobal}
+		check = func(bck cmn.Bck, bmdVer int64, expectedExists, expectedOK bool) {
+			t.Helper()
+			if exists, ok := c.get(bck, bmdVer); exists != expectedExists || ok != expectedOK {
+				t.Fatalf("%s: expected (%t, %t), got (%t, %t)", bck, expectedExists, expectedOK, exists, ok)
+			}
+		}
+	)
+	c.set(bckA, 1, true)
+	c.set(bckB, 1, false)
+	check(bckA, 1, true, true)
+	check(bckB, 1, false, true)
+
+	// props are not part of the key
+	withProps := bckA
+	withProps.Props = &cmn.BucketProps{}
+	check(withProps, 1, true, true)
+
+	// BMD has changed
+	check(bckA, 2, false, false)
+
+	c.invalidate(bckA)
+	check(bckA, 1, false, false)
+
+	// negative results expire sooner
+	c.set(bckA, 1, true)
+	c.mtx.Lock()
+	for bck, e := range c.m {
+		e.ts = mono.NanoTime() - int64(bckNotExistTTL+time.Second)
+		c.m[bck] = e
+	}
+	c.mtx.Unlock()
+	check(bckA, 1, true, true)
+	check(bckB, 1, false, false)
+
+	c.housekeep()
+	if len(c.m) != 1 {
+		t.Fatalf("expected expired entries to be removed, got %d entries", len(c.m))
+	}
+}
@@ -0,0 +1,65 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// POST {action: planbprops, value: propsToUpdate} /v1/buckets/bucket-name
+// Returns the plan (cost estimate) of the props change without changing anything.
+func (p *proxyrunner) planBprops(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	propsToUpdate := cmn.BucketPropsToUpdate{}
+	if err := cmn.MorphMarshal(msg.Value, &propsToUpdate); err != nil {
+		p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	nprops, err := p.makeNprops(bck, propsToUpdate)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	plan, err := p.gatherBpropsPlan(bck, nprops)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	p.writeJSON(w, r, plan, "planbprops")
+}
+
+// gatherBpropsPlan merges the targets' estimates; heavy changes only (see
+// cmn.BpropsChanges) - otherwise, the plan is empty.
+func (p *proxyrunner) gatherBpropsPlan(bck *cluster.Bck, nprops *cmn.BucketProps) (*cmn.BpropsPlan, error) {
+	plan := cmn.NewBpropsPlan(bck.Bck, cmn.BpropsChanges(bck.Props, nprops))
+	if len(plan.Changes) == 0 {
+		return plan, nil
+	}
+	var (
+		smap    = p.owner.smap.get()
+		config  = cmn.GCO.Get()
+		aisMsg  = p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActPlanBprops, Value: nprops}, smap, nil)
+		results = p.bcastToGroup(bcastArgs{
+			req: cmn.ReqArgs{
+				Method: http.MethodPost,
+				Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+				Query:  cmn.AddBckToQuery(nil, bck.Bck),
+				Body:   cmn.MustMarshal(aisMsg),
+			},
+			smap:    smap,
+			timeout: config.Timeout.MaxHostBusy + config.Timeout.CplaneOperation,
+			fv:      func() interface{} { return &cmn.BpropsEstimate{} },
+		})
+	)
+	for res := range results {
+		if res.err != nil {
+			return nil, res.err
+		}
+		plan.Merge(res.si.ID(), res.v.(*cmn.BpropsEstimate))
+	}
+	return plan, nil
+}
@@ -5,10 +5,13 @@
 package ais
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -16,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/nl"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -31,7 +35,7 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 		notFoundCnt int
 		err         error
 	)
-	if msg.ID != "" && method == http.MethodGet && msg.OnlyActiveTasks {
+	if msg.ID != "" && method == http.MethodGet && msg.OnlyActiveTasks && msg.ObjName == "" {
 		if stats, exists := p.notifs.queryStats(msg.ID); exists {
 			var resp *downloader.DlStatusResp
 			stats.Range(func(_ string, status interface{}) bool {
@@ -113,6 +117,12 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 			}
 			stResp = stResp.Aggregate(status)
 		}
+		if msg.Logs {
+			sort.Sort(downloader.DlLogsByTime(stResp.Logs))
+		}
+		if msg.ObjName != "" && stResp.Object == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("object %q not found in download job %q", msg.ObjName, msg.ID)
+		}
 		body := cmn.MustMarshal(stResp)
 		return body, http.StatusOK, nil
 	case http.MethodDelete:
@@ -124,11 +134,11 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 	}
 }
 
-func (p *proxyrunner) broadcastStartDownloadRequest(r *http.Request, id string, body []byte) (err error, errCode int) {
-	query := r.URL.Query()
+func (p *proxyrunner) broadcastStartDownloadRequest(path string, query url.Values, id string,
+	body []byte) (err error, errCode int) {
 	query.Set(cmn.URLParamUUID, id)
 
-	responses := p.broadcastDownloadRequest(http.MethodPost, r.URL.Path, body, query)
+	responses := p.broadcastDownloadRequest(http.MethodPost, path, body, query)
 	failures := make([]error, 0, len(responses))
 	for resp := range responses {
 		if resp.err != nil {
@@ -162,6 +172,7 @@ func (p *proxyrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // httpDownloadAdmin is meant for aborting, removing and getting status updates for downloads.
+// (limits of a running job are changed via cmn.ActXactLimits - see setJobLimits)
 // GET /v1/download?id=...
 // DELETE /v1/download/{abort, remove}?id=...
 func (p *proxyrunner) httpDownloadAdmin(w http.ResponseWriter, r *http.Request) {
@@ -191,8 +202,11 @@ func (p *proxyrunner) httpDownloadAdmin(w http.ResponseWriter, r *http.Request)
 			cmn.InvalidHandlerWithMsg(w, r, s)
 			return
 		}
+		if strings.HasPrefix(payload.ID, downloader.DlSchedulePrefix) {
+			p.unscheduleDownload(w, r, payload, items[0])
+			return
+		}
 	}
-
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("httpDownloadAdmin payload %v", payload)
 	}
@@ -244,18 +258,77 @@ func (p *proxyrunner) httpDownloadPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	id := cmn.GenUUID()
-	smap := p.owner.smap.get()
+	if dlBase.ScheduleID != "" || !dlBase.NextRun.IsZero() {
+		p.invalmsghdlr(w, r, "'schedule_id' and 'next_run' are set by the cluster and cannot be specified")
+		return
+	}
+	if len(dlBase.Limits.Shares) > 0 {
+		p.invalmsghdlr(w, r, "'limits.shares' are set by the cluster and cannot be specified")
+		return
+	}
+	if dlBase.Schedule != "" {
+		p.scheduleDownload(w, r, dlb, body, progressInterval)
+		return
+	}
+	if dlBase.Limits.Shared {
+		// shared limits are rebalanced by the primary (see dlShare)
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		if p.forwardCP(w, r, nil, "start download") {
+			return
+		}
+	}
 
-	if err, errCode := p.broadcastStartDownloadRequest(r, id, body); err != nil {
+	id, err, errCode := p.startDownload(r.URL.Path, r.URL.Query(), dlb.Type, body, dlBase.Limits, dlBase.NotifyURL,
+		progressInterval)
+	if err != nil {
 		p.invalmsghdlrstatusf(w, r, errCode, "Error starting download: %v.", err.Error())
 		return
 	}
-	nl := downloader.NewDownloadNL(id, &smap.Smap, smap.Tmap.Clone(), string(dlb.Type), progressInterval)
+	p.respondWithID(w, downloader.DlPostResp{ID: id})
+}
+
+// startDownload starts a new download job on all targets and registers it with IC.
+func (p *proxyrunner) startDownload(path string, query url.Values, dlType downloader.DlType, body []byte,
+	limits downloader.DlLimits, notifyURL string, progressInterval time.Duration) (id string, err error, errCode int) {
+	id = cmn.GenUUID()
+	smap := p.owner.smap.get()
+
+	if err, errCode = p.broadcastStartDownloadRequest(path, query, id, body); err != nil {
+		return
+	}
+	nl := downloader.NewDownloadNL(id, &smap.Smap, smap.Tmap.ActiveMap(), string(dlType), progressInterval)
+	nl.NotifyURL = notifyURL
 	nl.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	if limits.Shared {
+		p.dlShares.add(p, id, limits)
+	}
+	return
+}
 
-	p.respondWithID(w, id)
+// dlNotifyJob posts the job completion webhook (see downloader.DlBase.NotifyURL).
+// Each IC member gets notified of the job's completion; the one HRW-responsible
+// for the job posts the webhook.
+func (p *proxyrunner) dlNotifyJob(n nl.NotifListener) {
+	dnl := n.(*downloader.NotifDownloadListerner)
+	smap := p.owner.smap.get()
+	if psi, err := cluster.HrwIC(&smap.Smap, dnl.UUID()); err != nil || psi.ID() != p.si.ID() {
+		return
+	}
+	msg := &downloader.DlWebhookMsg{
+		Event: downloader.DlWebhookJob,
+		JobID: dnl.UUID(),
+		Time:  time.Now(),
+		Job:   dnl.JobInfo(),
+	}
+	if err := dnl.Err(false); err != nil {
+		msg.Error = err.Error()
+	}
+	go func() {
+		if err := downloader.PostWebhook(dnl.NotifyURL, msg); err != nil {
+			glog.Errorf("%s: %v", p.si, err)
+		}
+	}()
 }
 
 // Helper methods
@@ -280,16 +353,40 @@ func (p *proxyrunner) validateStartDownloadRequest(w http.ResponseWriter, r *htt
 		}
 	}
 	if err := bck.Allow(cmn.AccessDOWNLOAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
+	if dlBase.Politeness != nil {
+		if err := dlBase.Politeness.ValidateTargets(p.owner.smap.get().CountTargets()); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	for _, route := range dlBase.Routes {
+		if route.Bck.Name == "" {
+			continue
+		}
+		rbck := cluster.NewBckEmbed(route.Bck)
+		if err := rbck.Init(p.owner.bmd, p.si); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			return
+		}
+		if !rbck.IsAIS() {
+			p.invalmsghdlrf(w, r, "download route: destination bucket %s must be ais bucket", rbck)
+			return
+		}
+		if err := rbck.Allow(cmn.AccessDOWNLOAD); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+			return
+		}
+	}
 	ok = true
 	return
 }
 
-func (p *proxyrunner) respondWithID(w http.ResponseWriter, id string) {
+func (p *proxyrunner) respondWithID(w http.ResponseWriter, resp downloader.DlPostResp) {
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentJSON)
-	b := cmn.MustMarshal(downloader.DlPostResp{ID: id})
+	b := cmn.MustMarshal(resp)
 	_, err := w.Write(b)
 	debug.AssertNoErr(err)
 }
@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
+)
+
+// POST {action: createlbfrom, value: downloader.DlBucketSource} /v1/buckets/bucket-name
+//
+// Creates ais bucket - with the Cloud source, if any, as its backend - and
+// starts the download job that populates the bucket and, optionally, the
+// schedule to keep it in sync with the source. If the job fails to start the
+// bucket gets destroyed, so that the whole thing either succeeds or not.
+func (p *proxyrunner) createBucketFrom(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	if err := p.checkPermissions(r.Header, nil, cmn.AccessDOWNLOAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	src := &downloader.DlBucketSource{}
+	if err := cmn.MorphMarshal(msg.Value, src); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := src.Validate(); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	// the primary creates the bucket and owns the schedule (see dlSchedule)
+	if p.forwardCP(w, r, msg, bck.Name) {
+		return
+	}
+
+	// 1. create
+	var props cmn.BucketPropsToUpdate
+	if src.Props != nil {
+		props = *src.Props
+	}
+	if !src.Cloud.IsEmpty() {
+		props.BackendBck = &cmn.BckToUpdate{Name: &src.Cloud.Name, Provider: &src.Cloud.Provider}
+	}
+	if !p.hpostCreateBucket(w, r, &cmn.ActionMsg{Action: cmn.ActCreateLB, Value: props}, bck) {
+		return
+	}
+
+	// 2. populate
+	var (
+		resp    downloader.DlPostResp
+		err     error
+		errCode int
+		dlBck   = cmn.Bck{Name: bck.Name, Provider: bck.Provider, Ns: bck.Ns}
+		path    = cmn.JoinWords(cmn.Version, cmn.Download)
+	)
+	if src.Schedule != "" {
+		resp, err, errCode = p.startDlSchedule(src.CloudBody(dlBck), path, url.Values{},
+			downloader.DownloadProgressInterval)
+	} else {
+		dlb := src.Body(dlBck)
+		resp.ID, err, errCode = p.startDownload(path, url.Values{}, dlb.Type, cmn.MustMarshal(dlb), src.Limits,
+			"" /*notifyURL*/, downloader.DownloadProgressInterval)
+	}
+	if err != nil {
+		// 3. rollback
+		if errDestroy := p.destroyBucket(&cmn.ActionMsg{Action: cmn.ActDestroyLB}, bck); errDestroy != nil {
+			glog.Errorf("%s: failed to destroy %s upon failure to populate it: %v", p.si, bck, errDestroy)
+		}
+		p.invalmsghdlrstatusf(w, r, errCode, "failed to populate %s (bucket not created): %v", bck, err)
+		return
+	}
+	glog.Infof("%s: created %s populated by download %q (schedule %q)", p.si, bck, resp.ID, resp.ScheduleID)
+	p.respondWithID(w, resp)
+}
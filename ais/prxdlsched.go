@@ -0,0 +1,337 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// (BMD metasync action)
+const metactionDlJobs = "download-jobs"
+
+type (
+	// dlSchedule re-runs a cloud download job (see downloader.DlBase.Schedule) -
+	// each run is a new job. Schedules are run by the primary and kept in BMD:
+	// upon restart of the primary (or its change) the primary resumes them
+	// (see restoreDlJobs).
+	dlSchedule struct {
+		p                *proxyrunner
+		id               string
+		sched            *downloader.DlSchedule
+		body             downloader.DlCloudBody
+		path             string
+		query            url.Values
+		progressInterval time.Duration
+
+		mu      sync.Mutex
+		lastID  string    // most recent run
+		nextRun time.Time // zero - none
+	}
+	dlSchedules struct {
+		sync.Mutex
+		m map[string]*dlSchedule
+	}
+)
+
+///////////////////
+// dlSchedules   //
+///////////////////
+
+func (ss *dlSchedules) add(s *dlSchedule) {
+	ss.Lock()
+	if ss.m == nil {
+		ss.m = make(map[string]*dlSchedule, 4)
+	}
+	ss.m[s.id] = s
+	ss.Unlock()
+	hk.Reg(s.hkName(), s.housekeep, time.Until(s.nextRun))
+}
+
+// cancel stops running the schedule (see also remove)
+func (ss *dlSchedules) cancel(id string) (s *dlSchedule, ok bool) {
+	ss.Lock()
+	if s, ok = ss.m[id]; ok {
+		delete(ss.m, id)
+	}
+	ss.Unlock()
+	if ok {
+		hk.Unreg(s.hkName())
+	}
+	return
+}
+
+// remove cancels the schedule and removes it from BMD
+func (ss *dlSchedules) remove(p *proxyrunner, id string) (s *dlSchedule, ok bool) {
+	s, ok = ss.cancel(id)
+	p.modifyDlJobs(func(clone *bucketMD) bool { return clone.delDlSched(id) })
+	return
+}
+
+func (ss *dlSchedules) exists(id string) (ok bool) {
+	ss.Lock()
+	_, ok = ss.m[id]
+	ss.Unlock()
+	return
+}
+
+////////////////
+// dlSchedule //
+////////////////
+
+// newDlSchedule restores the schedule from BMD
+func newDlSchedule(p *proxyrunner, id string, md *cluster.DlSched) (s *dlSchedule, err error) {
+	s = &dlSchedule{
+		p:                p,
+		id:               id,
+		path:             md.Path,
+		query:            md.Query,
+		progressInterval: time.Duration(md.ProgressInterval),
+		lastID:           md.LastID,
+	}
+	if err = jsoniter.Unmarshal(md.Body, &s.body); err != nil {
+		return
+	}
+	if s.sched, err = downloader.ParseDlSchedule(s.body.Schedule); err != nil {
+		return
+	}
+	s.nextRun = s.sched.Next(time.Now())
+	return
+}
+
+func (s *dlSchedule) hkName() string { return "download." + s.id }
+
+// (caller must hold s.mu)
+func (s *dlSchedule) md() *cluster.DlSched {
+	return &cluster.DlSched{
+		Body:             cmn.MustMarshal(s.body),
+		Path:             s.path,
+		Query:            s.query,
+		ProgressInterval: int64(s.progressInterval),
+		LastID:           s.lastID,
+	}
+}
+
+func (s *dlSchedule) last() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastID
+}
+
+// start starts the next run
+func (s *dlSchedule) start() (id string, err error, errCode int) {
+	s.mu.Lock()
+	body := s.body
+	body.ScheduleID, body.NextRun = s.id, s.nextRun
+	s.mu.Unlock()
+
+	dlb := downloader.DlBody{Type: downloader.DlTypeCloud, RawMessage: cmn.MustMarshal(body)}
+	query := make(url.Values, len(s.query)+1)
+	for k, v := range s.query {
+		query[k] = v
+	}
+	if id, err, errCode = s.p.startDownload(s.path, query, dlb.Type, cmn.MustMarshal(dlb), body.Limits,
+		body.NotifyURL, s.progressInterval); err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.lastID = id
+	md := s.md()
+	s.mu.Unlock()
+	s.p.modifyDlJobs(func(clone *bucketMD) bool {
+		clone.setDlSched(s.id, md)
+		return true
+	})
+	return
+}
+
+func (s *dlSchedule) housekeep() time.Duration {
+	s.mu.Lock()
+	s.nextRun = s.sched.Next(time.Now())
+	nextRun := s.nextRun
+	s.mu.Unlock()
+
+	go s.run() // not to block housekeeper on broadcasting to targets
+	if nextRun.IsZero() {
+		return time.Hour // (canceled by run)
+	}
+	return time.Until(nextRun)
+}
+
+func (s *dlSchedule) run() {
+	p := s.p
+	if smap := p.owner.smap.get(); !smap.isPrimary(p.si) {
+		glog.Warningf("%s: not primary - canceling download schedule %s", p.si, s.id)
+		p.dlScheds.cancel(s.id)
+		return
+	}
+	s.mu.Lock()
+	lastID, nextRun := s.lastID, s.nextRun
+	s.mu.Unlock()
+
+	if nl, ok := p.notifs.entry(lastID); ok && !nl.Finished() {
+		glog.Warningf("%s: download schedule %s: previous run %q is still running - skipping (next run at %s)",
+			p.si, s.id, lastID, nextRun.Format(time.RFC3339))
+		return
+	}
+	if id, err, _ := s.start(); err != nil {
+		glog.Errorf("%s: download schedule %s: failed to start: %v", p.si, s.id, err)
+	} else {
+		glog.Infof("%s: download schedule %s: started %q", p.si, s.id, id)
+	}
+	if nextRun.IsZero() {
+		glog.Warningf("%s: download schedule %s (%q) does not fire anymore - canceling", p.si, s.id, s.body.Schedule)
+		p.dlScheds.remove(p, s.id)
+	}
+}
+
+/////////////////
+// proxyrunner //
+/////////////////
+
+// scheduleDownload creates the schedule and starts the first run right away.
+func (p *proxyrunner) scheduleDownload(w http.ResponseWriter, r *http.Request, dlb downloader.DlBody, body []byte,
+	progressInterval time.Duration) {
+	if dlb.Type != downloader.DlTypeCloud {
+		p.invalmsghdlrf(w, r, "schedule is supported only for %q downloads (got %q)", downloader.DlTypeCloud, dlb.Type)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+	if p.forwardCP(w, r, nil, "schedule download") {
+		return
+	}
+	var cloudBody downloader.DlCloudBody
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &cloudBody); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	resp, err, errCode := p.startDlSchedule(&cloudBody, r.URL.Path, r.URL.Query(), progressInterval)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	p.respondWithID(w, resp)
+}
+
+// startDlSchedule (primary only) creates the schedule of the cloud download
+// and starts the first run.
+func (p *proxyrunner) startDlSchedule(body *downloader.DlCloudBody, path string, query url.Values,
+	progressInterval time.Duration) (resp downloader.DlPostResp, err error, errCode int) {
+	s := &dlSchedule{
+		p:                p,
+		id:               downloader.DlSchedulePrefix + cmn.GenUUID(),
+		body:             *body,
+		path:             path,
+		query:            query,
+		progressInterval: progressInterval,
+	}
+	errCode = http.StatusBadRequest
+	if s.sched, err = downloader.ParseDlSchedule(s.body.Schedule); err != nil {
+		return
+	}
+	if s.nextRun = s.sched.Next(time.Now()); s.nextRun.IsZero() {
+		err = fmt.Errorf("schedule %q never fires", s.body.Schedule)
+		return
+	}
+	if resp.ID, err, errCode = s.start(); err != nil {
+		err = fmt.Errorf("failed to start download: %v", err)
+		return
+	}
+	p.dlScheds.add(s)
+	glog.Infof("%s: download schedule %s (%q): started %q, next run at %s",
+		p.si, s.id, s.body.Schedule, resp.ID, s.nextRun.Format(time.RFC3339))
+	resp.ScheduleID = s.id
+	return
+}
+
+// unscheduleDownload cancels the schedule; "abort" also aborts its most recent run.
+func (p *proxyrunner) unscheduleDownload(w http.ResponseWriter, r *http.Request, payload *downloader.DlAdminBody,
+	action string) {
+	body := cmn.MustMarshal(payload)
+	r.Body, r.ContentLength = ioutil.NopCloser(bytes.NewBuffer(body)), int64(len(body))
+	if p.forwardCP(w, r, nil, "unschedule download") {
+		return
+	}
+	s, ok := p.dlScheds.remove(p, payload.ID)
+	if !ok {
+		p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "download schedule %q not found", payload.ID)
+		return
+	}
+	lastID := s.last()
+	glog.Infof("%s: canceled download schedule %s (last run %q)", p.si, s.id, lastID)
+
+	// clear the `next_run` of the most recent run
+	msg := &downloader.DlAdminBody{ID: lastID}
+	path := cmn.JoinWords(cmn.Version, cmn.Download, cmn.Unschedule)
+	if _, status, err := p.broadcastDownloadAdminRequest(http.MethodDelete, path, msg); err != nil &&
+		status != http.StatusNotFound {
+		glog.Errorf("%s: failed to unschedule %q: %v", p.si, lastID, err)
+	}
+	if action != cmn.Abort {
+		return
+	}
+	path = cmn.JoinWords(cmn.Version, cmn.Download, cmn.Abort)
+	if _, status, err := p.broadcastDownloadAdminRequest(http.MethodDelete, path, msg); err != nil &&
+		status != http.StatusNotFound {
+		p.invalmsghdlr(w, r, err.Error(), status)
+	}
+}
+
+// modifyDlJobs (primary) updates and metasyncs the download schedules and
+// shared limits kept in BMD
+func (p *proxyrunner) modifyDlJobs(update func(clone *bucketMD) bool) {
+	_ = p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
+		return update(clone), nil
+	}, func(clone *bucketMD) {
+		msg := p.newAisMsgStr(metactionDlJobs, nil, clone)
+		_ = p.metasyncer.sync(revsPair{clone, msg})
+	})
+}
+
+// restoreDlJobs (new primary) resumes the download schedules and the
+// rebalancing of shared limits kept in BMD
+func (p *proxyrunner) restoreDlJobs() {
+	bmd := p.owner.bmd.get()
+	for id, md := range bmd.DlScheds {
+		if p.dlScheds.exists(id) {
+			continue
+		}
+		s, err := newDlSchedule(p, id, md)
+		if err != nil {
+			glog.Errorf("%s: failed to restore download schedule %s: %v", p.si, id, err)
+			continue
+		}
+		if s.nextRun.IsZero() {
+			glog.Warningf("%s: download schedule %s (%q) does not fire anymore - removing", p.si, id, s.body.Schedule)
+			p.dlScheds.remove(p, id)
+			continue
+		}
+		p.dlScheds.add(s)
+		glog.Infof("%s: restored download schedule %s (%q), next run at %s",
+			p.si, id, s.body.Schedule, s.nextRun.Format(time.RFC3339))
+	}
+	for id, raw := range bmd.DlShares {
+		var limits downloader.DlLimits
+		if err := jsoniter.Unmarshal(raw, &limits); err != nil {
+			glog.Errorf("%s: failed to restore shared limits of download job %q: %v", p.si, id, err)
+			continue
+		}
+		if p.dlShares.restore(p, id, limits) {
+			glog.Infof("%s: restored shared limits of download job %q: %+v", p.si, id, limits)
+		}
+	}
+}
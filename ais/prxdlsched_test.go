@@ -0,0 +1,76 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// download schedules and shared limits survive (via BMD) restart and change of the primary
+func TestDlJobsBMD(t *testing.T) {
+	var (
+		bmd = newBucketMD()
+		s   = &dlSchedule{
+			id: downloader.DlSchedulePrefix + "sched",
+			body: downloader.DlCloudBody{
+				DlBase: downloader.DlBase{Bck: cmn.Bck{Name: "bck", Provider: cmn.ProviderAmazon}, Schedule: "0 2 * * *"},
+				Sync:   true,
+				Prefix: "images/",
+			},
+			path:             cmn.JoinWords(cmn.Version, cmn.Download),
+			query:            url.Values{cmn.URLParamProvider: []string{cmn.ProviderAmazon}},
+			progressInterval: 10 * time.Second,
+			lastID:           "last",
+		}
+		limits = downloader.DlLimits{BytesPerHour: cmn.GiB, Shared: true}
+	)
+	bmd.setDlSched(s.id, s.md())
+	bmd.setDlShare("job", &limits)
+	if bmd.Version != 2 {
+		t.Fatalf("expected BMD v2, got v%d", bmd.Version)
+	}
+
+	// (as in: persisted and loaded by the new primary)
+	var loaded bucketMD
+	if err := jsoniter.Unmarshal(bmd.marshal(), &loaded); err != nil {
+		t.Fatal(err)
+	}
+	clone := loaded.clone()
+	rs, err := newDlSchedule(nil, s.id, clone.DlScheds[s.id])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.body.Schedule != s.body.Schedule || rs.body.Prefix != s.body.Prefix || !rs.body.Sync || rs.body.Bck.Name != "bck" {
+		t.Errorf("expected %+v, got %+v", s.body, rs.body)
+	}
+	if rs.path != s.path || rs.query.Get(cmn.URLParamProvider) != cmn.ProviderAmazon ||
+		rs.progressInterval != s.progressInterval || rs.lastID != s.lastID {
+		t.Errorf("expected %+v, got %+v", s, rs)
+	}
+	if now := time.Now(); !rs.nextRun.After(now) || rs.nextRun.Sub(now) > 24*time.Hour {
+		t.Errorf("unexpected next run %v", rs.nextRun)
+	}
+	var rlimits downloader.DlLimits
+	if err := jsoniter.Unmarshal(clone.DlShares["job"], &rlimits); err != nil {
+		t.Fatal(err)
+	}
+	if rlimits.BytesPerHour != limits.BytesPerHour || !rlimits.Shared {
+		t.Errorf("expected %+v, got %+v", limits, rlimits)
+	}
+
+	// removed upon cancellation (and completion)
+	if !clone.delDlSched(s.id) || !clone.delDlShare("job") || clone.delDlShare("job") {
+		t.Error("failed to remove")
+	}
+	if len(loaded.DlScheds) != 1 || len(loaded.DlShares) != 1 {
+		t.Error("clone must not share download jobs with the original")
+	}
+}
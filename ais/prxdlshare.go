@@ -0,0 +1,230 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xaction"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// no reason to rebalance more often - targets' throughput allowance is per minute
+const dlShareInterval = time.Minute
+
+type (
+	// dlShare periodically (re)allocates the shared budget of a download job
+	// (see downloader.DlLimits.Shared) between the targets. Run by the primary;
+	// the budget is kept in BMD and upon restart of the primary (or its change)
+	// the primary resumes the rebalancing (see restoreDlJobs) - in the meantime
+	// the targets keep their last allocation.
+	dlShare struct {
+		p  *proxyrunner
+		id string
+
+		mu     sync.Mutex
+		limits downloader.DlLimits // the budget
+		shares map[string]int      // current allocation (nil - equal parts)
+	}
+	dlShares struct {
+		sync.Mutex
+		m map[string]*dlShare
+	}
+)
+
+///////////////
+// dlShares  //
+///////////////
+
+// add starts rebalancing the job or, if already started, updates its budget
+func (ss *dlShares) add(p *proxyrunner, id string, limits downloader.DlLimits) *dlShare {
+	s, _ := ss._add(p, id, limits)
+	p.modifyDlJobs(func(clone *bucketMD) bool {
+		clone.setDlShare(id, &limits)
+		return true
+	})
+	return s
+}
+
+// restore resumes rebalancing the job (budget from BMD) unless already running
+func (ss *dlShares) restore(p *proxyrunner, id string, limits downloader.DlLimits) (restored bool) {
+	ss.Lock()
+	_, ok := ss.m[id]
+	ss.Unlock()
+	if ok {
+		return false
+	}
+	_, restored = ss._add(p, id, limits)
+	return
+}
+
+func (ss *dlShares) _add(p *proxyrunner, id string, limits downloader.DlLimits) (*dlShare, bool) {
+	ss.Lock()
+	if ss.m == nil {
+		ss.m = make(map[string]*dlShare, 4)
+	}
+	s, ok := ss.m[id]
+	if !ok {
+		s = &dlShare{p: p, id: id}
+		ss.m[id] = s
+	}
+	ss.Unlock()
+
+	s.mu.Lock()
+	s.limits = limits
+	s.limits.Shares = nil
+	s.mu.Unlock()
+	if !ok {
+		hk.Reg(s.hkName(), s.housekeep, dlShareInterval)
+	}
+	return s, !ok
+}
+
+// remove stops rebalancing the job and removes its budget from BMD
+func (ss *dlShares) remove(p *proxyrunner, id string) {
+	ss.cancel(id)
+	p.modifyDlJobs(func(clone *bucketMD) bool { return clone.delDlShare(id) })
+}
+
+// cancel stops rebalancing the job (see also remove)
+func (ss *dlShares) cancel(id string) {
+	ss.Lock()
+	s, ok := ss.m[id]
+	if ok {
+		delete(ss.m, id)
+	}
+	ss.Unlock()
+	if ok {
+		hk.Unreg(s.hkName())
+	}
+}
+
+/////////////
+// dlShare //
+/////////////
+
+func (s *dlShare) hkName() string { return "download-share." + s.id }
+
+func (s *dlShare) housekeep() time.Duration {
+	go func() { // not to block housekeeper on broadcasting to targets
+		if err, _ := s.rebalance(); err != nil {
+			glog.Errorf("%s: download job %q: failed to rebalance shared limits: %v", s.p.si, s.id, err)
+		}
+	}()
+	return dlShareInterval
+}
+
+// rebalance queries the targets and sends each its share of the budget
+func (s *dlShare) rebalance() (err error, status int) {
+	p := s.p
+	if smap := p.owner.smap.get(); !smap.isPrimary(p.si) {
+		glog.Warningf("%s: not primary - stopping to rebalance shared limits of %q", p.si, s.id)
+		p.dlShares.cancel(s.id)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		msg       = &downloader.DlAdminBody{ID: s.id, OnlyActiveTasks: true}
+		path      = cmn.JoinWords(cmn.Version, cmn.Download)
+		responses = p.broadcastDownloadRequest(http.MethodGet, path, cmn.MustMarshal(msg), url.Values{})
+		stats     = make(map[string]downloader.DlShareStats, len(responses))
+		finished  = cmn.NewStringSet() // (not to update the limits of)
+		found     bool
+	)
+	for res := range responses {
+		if res.status == http.StatusNotFound {
+			finished.Add(res.si.ID())
+			continue
+		}
+		if res.err != nil {
+			return res.err, res.status
+		}
+		resp := &downloader.DlStatusResp{}
+		if err := jsoniter.Unmarshal(res.bytes, resp); err != nil {
+			return err, http.StatusInternalServerError
+		}
+		found = true
+		if resp.JobFinished() {
+			finished.Add(res.si.ID())
+			continue
+		}
+		stats[res.si.ID()] = downloader.NewDlShareStats(resp)
+	}
+	if len(stats) == 0 {
+		p.dlShares.remove(p, s.id)
+		if !found {
+			return fmt.Errorf("download job %q not found", s.id), http.StatusNotFound
+		}
+		return
+	}
+
+	limits := s.limits
+	limits.Shares = downloader.DlShares(limits.BytesPerHour, stats, s.shares)
+	limitsMsg := cmn.ActionMsg{
+		Action: cmn.ActXactLimits,
+		Value:  xaction.XactReqMsg{ID: s.id, Limits: limits.JobLimits()},
+	}
+	responses = p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodPut,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Xactions),
+			Body:   cmn.MustMarshal(limitsMsg),
+		},
+		timeout:   cmn.GCO.Get().Timeout.MaxHostBusy,
+		skipNodes: finished,
+	})
+	for res := range responses {
+		// (the job may have finished in the meantime)
+		if res.err != nil && res.status != http.StatusNotFound && err == nil {
+			err, status = res.err, res.status
+		}
+	}
+	s.shares = limits.Shares
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: download job %q: shared limits %+v", p.si, s.id, limits)
+	}
+	return
+}
+
+/////////////////
+// proxyrunner //
+/////////////////
+
+// setJobLimits handles cmn.ActXactLimits: the shared limits of a download job
+// (see downloader.DlLimits.Shared) are rebalanced by the primary; returns false
+// if the limits are to be broadcast as usual.
+func (p *proxyrunner) setJobLimits(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, xactMsg *xaction.XactReqMsg) bool {
+	if len(xactMsg.Limits.Shares) > 0 {
+		p.invalmsghdlr(w, r, "'limits.shares' are set by the cluster and cannot be specified")
+		return true
+	}
+	limits := downloader.NewDlLimits(xactMsg.Limits)
+	if err := limits.Validate(); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return true
+	}
+	if p.forwardCP(w, r, msg, "job limits") {
+		return true
+	}
+	if !limits.Shared {
+		p.dlShares.remove(p, xactMsg.ID)
+		return false
+	}
+	s := p.dlShares.add(p, xactMsg.ID, limits)
+	if err, status := s.rebalance(); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), status)
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort"
+)
+
+// Ephemeral (temporary) buckets are ais buckets with `ephemeral` props set - see
+// cmn.EphemeralConf. The primary proxy periodically checks all such buckets and
+// destroys the ones whose owning job has finished or whose TTL has expired.
+//
+// NOTE: the status of the owner is determined via notification listeners (see
+// notifications.go) or, for dSort (see cmn.EphemeralConf.OwnerKind), by querying
+// the targets. An xaction or download job that is not (or no longer) tracked by
+// the listeners is considered finished - the bucket must be created after its
+// owner has started.
+
+const (
+	ephemeralName       = "ephemeral-buckets"
+	ephemeralHousekeepT = time.Minute
+)
+
+func (p *proxyrunner) housekeepEphemeral() time.Duration {
+	if !p.ClusterStarted() {
+		return ephemeralHousekeepT
+	}
+	if smap := p.owner.smap.get(); !smap.isPrimary(p.si) {
+		return ephemeralHousekeepT
+	}
+	var (
+		now      = time.Now()
+		provider = cmn.ProviderAIS
+		bmd      = p.owner.bmd.get()
+		expired  = make(map[*cluster.Bck]string)
+	)
+	bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
+		if reason := p.ephemeralExpired(bck, now); reason != "" {
+			expired[bck] = reason
+		}
+		return false
+	})
+	for bck, reason := range expired {
+		msg := &cmn.ActionMsg{Action: cmn.ActDestroyLB, Name: bck.Name}
+		if err := p.destroyBucket(msg, bck); err != nil {
+			if _, ok := err.(*cmn.ErrorBucketDoesNotExist); !ok {
+				glog.Errorf("%s: failed to destroy ephemeral bucket %s (%s): %v", p.si, bck, reason, err)
+			}
+			continue
+		}
+		glog.Infof("%s: destroyed ephemeral bucket %s (%s)", p.si, bck, reason)
+	}
+	return ephemeralHousekeepT
+}
+
+// returns non-empty reason if the ephemeral bucket must be destroyed
+func (p *proxyrunner) ephemeralExpired(bck *cluster.Bck, now time.Time) string {
+	conf := &bck.Props.Ephemeral
+	if !conf.Enabled() {
+		return ""
+	}
+	if conf.Expired(bck.Props.Created, now) {
+		return "ttl " + conf.TTLStr + " expired"
+	}
+	if conf.OwnerID == "" {
+		return ""
+	}
+	if p.ephemeralOwnerFinished(conf) {
+		return "owner " + conf.OwnerID + " finished"
+	}
+	return ""
+}
+
+// the owner is either a dSort job or an xaction or download job - the latter
+// two are tracked by notification listeners (including copy and ETL
+// transformations of the buckets)
+func (p *proxyrunner) ephemeralOwnerFinished(conf *cmn.EphemeralConf) bool {
+	if conf.OwnedByDSort() {
+		finished, _ := dsort.JobFinished(conf.OwnerID)
+		return finished
+	}
+	nl, exists := p.notifs.entry(conf.OwnerID)
+	return !exists || nl.Finished()
+}
@@ -0,0 +1,43 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+func TestEphemeralOwnerFinished(t *testing.T) {
+	var (
+		srcs     = cluster.NodeMap{"target": &cluster.Snode{DaemonID: "target"}}
+		running  = xaction.NewXactNL("running", &cluster.Smap{}, srcs, cmn.ActCopyBucket)
+		finished = xaction.NewXactNL("finished", &cluster.Smap{}, srcs, cmn.ActCopyBucket)
+		p        = &proxyrunner{}
+	)
+	finished.Callback(finished, time.Now().UnixNano())
+	p.notifs.m = map[string]nl.NotifListener{running.UUID(): running}
+	p.notifs.fin = map[string]nl.NotifListener{finished.UUID(): finished}
+
+	tests := []struct {
+		ownerID  string
+		finished bool
+	}{
+		{ownerID: running.UUID(), finished: false},
+		{ownerID: finished.UUID(), finished: true},
+		// not (or no longer) tracked by the listeners
+		{ownerID: "unknown", finished: true},
+	}
+	for _, test := range tests {
+		conf := &cmn.EphemeralConf{OwnerID: test.ownerID}
+		if p.ephemeralOwnerFinished(conf) != test.finished {
+			t.Errorf("owner %q: expected finished=%t", test.ownerID, test.finished)
+		}
+	}
+}
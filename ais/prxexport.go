@@ -0,0 +1,244 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/export"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// bucket export to WebDataset shards - see package export
+
+// POST {action: exportbck, value: cmn.ExportMsg} /v1/buckets/bucket-name
+//
+// The proxy pages through the source listing, makes the plan as it goes, and
+// sends each target (in batches) the shards the target is HRW-responsible for
+// in the destination bucket; the export is tracked by IC under the returned
+// UUID. If sending fails, the export gets stopped on all targets.
+func (p *proxyrunner) exportBucket(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessGET); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := bck.Allow(cmn.AccessGET); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+		return
+	}
+	if !bck.IsAIS() {
+		p.invalmsghdlrf(w, r, fmtUnsupProv, msg.Action, bck.Provider)
+		return
+	}
+	emsg := &cmn.ExportMsg{}
+	if err := cmn.MorphMarshal(msg.Value, emsg); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := export.Validate(emsg); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	bckTo := cluster.NewBckEmbed(emsg.BckTo)
+	if bckTo.Provider == "" {
+		bckTo.Provider = cmn.ProviderAIS
+	}
+	if !bckTo.IsAIS() {
+		p.invalmsghdlrf(w, r, "%q: destination must be an ais bucket (have %s)", msg.Action, bckTo)
+		return
+	}
+	if bck.Equal(bckTo, false, true) {
+		p.invalmsghdlrf(w, r, "cannot %s bucket %q onto itself", msg.Action, bck)
+		return
+	}
+	if err := cmn.ValidateBckName(bckTo.Name); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bckTo.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := p.initExportDst(bckTo); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := bckTo.Allow(cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+		return
+	}
+
+	// list the first page to make sure there's something to export
+	smsg := cmn.SelectMsg{Prefix: emsg.Prefix, PageSize: listStreamPageSize}
+	smsg.AddProps(cmn.GetPropsSize)
+	list, err := p.listObjectsAISStream(bck, smsg)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if len(list.Entries) == 0 {
+		p.invalmsghdlrf(w, r, "%q: no objects to export in %s (prefix %q)", msg.Action, bck, emsg.Prefix)
+		return
+	}
+
+	// start the (initially empty) export on all targets
+	var (
+		smap = p.owner.smap.get()
+		ej   = &exportJob{
+			p:       p,
+			path:    r.URL.Path,
+			action:  msg.Action,
+			bck:     bck,
+			bckTo:   bckTo,
+			uuid:    cmn.GenUUID(),
+			smap:    smap,
+			pending: make(map[string][]*export.Shard, smap.CountTargets()),
+		}
+	)
+	nl := xaction.NewXactNL(ej.uuid, &smap.Smap, smap.Tmap.Clone(), cmn.ActExportBck, bck.Bck, bckTo.Bck)
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: smap, query: r.URL.Query()})
+	if err := ej.start(); err != nil {
+		ej.abort(err)
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	// page through the listing, sending the shards as they get planned
+	if err := ej.run(list, smsg, emsg); err != nil {
+		ej.abort(err)
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	glog.Infof("%s %s => %s: %d object(s), %d shard(s), %d target(s) (%s)",
+		msg.Action, bck, bckTo, ej.numObjs, ej.numShards, len(smap.Tmap), ej.uuid)
+	w.Write([]byte(ej.uuid))
+}
+
+// exportJob distributes the plan, batch by batch, to the shards' HRW targets
+type exportJob struct {
+	p          *proxyrunner
+	path       string
+	action     string
+	bck, bckTo *cluster.Bck
+	uuid       string
+	smap       *smapX
+	pending    map[string][]*export.Shard // per target ID
+	numObjs    int
+	numShards  int
+}
+
+// max number of shards per batch sent to a target
+const exportBatchSize = 64
+
+func (ej *exportJob) start() error {
+	for _, si := range ej.smap.Tmap {
+		if err := ej.send(si, &export.Job{UUID: ej.uuid, BckTo: ej.bckTo.Bck}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ej *exportJob) run(list *cmn.BucketList, smsg cmn.SelectMsg, emsg *cmn.ExportMsg) (err error) {
+	pl := export.NewPlanner(emsg)
+	for {
+		for _, e := range list.Entries {
+			ej.numObjs++
+			if shard := pl.Add(e.Name, e.Size); shard != nil {
+				if err = ej.add(shard); err != nil {
+					return
+				}
+			}
+		}
+		if list.ContinuationToken == "" {
+			break
+		}
+		smsg.ContinuationToken = list.ContinuationToken
+		if list, err = ej.p.listObjectsAISStream(ej.bck, smsg); err != nil {
+			return
+		}
+	}
+	if shard := pl.Flush(); shard != nil {
+		ej.queue(shard)
+	}
+	// the last batch to all targets
+	for id, si := range ej.smap.Tmap {
+		job := &export.Job{UUID: ej.uuid, BckTo: ej.bckTo.Bck, Shards: ej.pending[id], Cont: true, Last: true}
+		if err = ej.send(si, job); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// queues the shard for its HRW target
+func (ej *exportJob) queue(shard *export.Shard) (id string) {
+	si, err := cluster.HrwTarget(ej.bckTo.MakeUname(shard.Name), &ej.smap.Smap)
+	cmn.AssertNoErr(err) // (the targets were all started)
+	id = si.ID()
+	ej.pending[id] = append(ej.pending[id], shard)
+	ej.numShards++
+	return
+}
+
+// queues the shard and sends the target's batch once full
+func (ej *exportJob) add(shard *export.Shard) error {
+	id := ej.queue(shard)
+	if len(ej.pending[id]) < exportBatchSize {
+		return nil
+	}
+	job := &export.Job{UUID: ej.uuid, BckTo: ej.bckTo.Bck, Shards: ej.pending[id], Cont: true}
+	delete(ej.pending, id)
+	return ej.send(ej.smap.Tmap[id], job)
+}
+
+func (ej *exportJob) send(si *cluster.Snode, job *export.Job) error {
+	res := ej.p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   ej.path,
+			Query:  cmn.AddBckToQuery(nil, ej.bck.Bck),
+			Body:   cmn.MustMarshal(cmn.ActionMsg{Action: ej.action, Value: job}),
+		},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+	if res.err != nil {
+		return fmt.Errorf("%s: failed to send %d shard(s) to %s: %v", ej.uuid, len(job.Shards), si, res.err)
+	}
+	return nil
+}
+
+// stops the export on all targets (the ones that have started it notify IC)
+func (ej *exportJob) abort(err error) {
+	glog.Errorf("%s: aborting %s %s => %s: %v", ej.p.si, ej.action, ej.bck, ej.bckTo, err)
+	xactMsg := xaction.XactReqMsg{ID: ej.uuid, Kind: cmn.ActExportBck, Bck: ej.bck.Bck}
+	body := cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActXactStop, Value: xactMsg})
+	results := ej.p.callTargets(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Xactions), body)
+	for res := range results {
+		if res.err != nil && res.status != http.StatusNotFound {
+			glog.Errorf("%s: failed to stop %s on %s: %v", ej.p.si, ej.uuid, res.si, res.err)
+		}
+	}
+}
+
+// creates the destination bucket unless it exists
+func (p *proxyrunner) initExportDst(bckTo *cluster.Bck) error {
+	if err := bckTo.Init(p.owner.bmd, p.si); err == nil {
+		return nil
+	} else if _, ok := err.(*cmn.ErrorBucketDoesNotExist); !ok {
+		return err
+	}
+	err := p.createBucket(&cmn.ActionMsg{Action: cmn.ActCreateLB}, bckTo)
+	if _, ok := err.(*cmn.ErrorBucketAlreadyExists); err != nil && !ok {
+		return fmt.Errorf("failed to create %s: %v", bckTo, err)
+	}
+	return bckTo.Init(p.owner.bmd, p.si)
+}
@@ -0,0 +1,87 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Batched existence check: instead of one HEAD(object) per object, the proxy
+// groups the requested names by their (HRW) targets, and each target looks up
+// its own share of the objects in a single intra-cluster call.
+
+// POST {action: headobjs, value: {objnames: [...]}} /v1/buckets/bucket-name
+func (p *proxyrunner) headObjects(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	listMsg := &cmn.ListMsg{}
+	if err := cmn.MorphMarshal(msg.Value, listMsg); err != nil {
+		p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	if len(listMsg.ObjNames) == 0 {
+		p.invalmsghdlrf(w, r, "%q: list of object names is empty", msg.Action)
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		tnames  = make(map[*cluster.Snode][]string, smap.CountTargets())
+		results chan callResult
+		wg      = &sync.WaitGroup{}
+		timeout = cmn.GCO.Get().Timeout.MaxHostBusy
+	)
+	for _, name := range listMsg.ObjNames {
+		si, err := cluster.HrwTarget(bck.MakeUname(name), &smap.Smap)
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		tnames[si] = append(tnames[si], name)
+	}
+	results = make(chan callResult, len(tnames))
+	for si, names := range tnames {
+		wg.Add(1)
+		go func(si *cluster.Snode, names []string) {
+			tmsg := &cmn.ActionMsg{Action: msg.Action, Value: cmn.ListMsg{ObjNames: names}}
+			results <- p.call(callArgs{
+				si: si,
+				req: cmn.ReqArgs{
+					Method: http.MethodPost,
+					Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+					Query:  cmn.AddBckToQuery(nil, bck.Bck),
+					Body:   cmn.MustMarshal(p.newAisMsg(tmsg, smap, nil)),
+				},
+				timeout: timeout,
+				v:       &[]*cmn.ObjHeadEntry{},
+			})
+			wg.Done()
+		}(si, names)
+	}
+	wg.Wait()
+	close(results)
+
+	found := make(map[string]*cmn.ObjHeadEntry, len(listMsg.ObjNames))
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.err.Error(), res.status)
+			return
+		}
+		for _, entry := range *res.v.(*[]*cmn.ObjHeadEntry) {
+			found[entry.Name] = entry
+		}
+	}
+	// same order as requested
+	entries := make([]*cmn.ObjHeadEntry, 0, len(listMsg.ObjNames))
+	for _, name := range listMsg.ObjNames {
+		entry, ok := found[name]
+		if !ok {
+			entry = &cmn.ObjHeadEntry{Name: name}
+		}
+		entries = append(entries, entry)
+	}
+	p.writeJSON(w, r, entries, "headobjs")
+}
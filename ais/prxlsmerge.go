@@ -0,0 +1,244 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Streaming merge of huge list-objects pages.
+//
+// Regular listing (see `listObjectsAIS`) decodes all target responses - up to
+// `PageSize` entries each - and keeps the excess in the query buffer. For huge
+// pages that's #targets times the page. Instead, the proxy reads target
+// responses entry by entry and k-way merges them until the page is complete.
+// The rest of each response is simply not read (TCP provides the
+// backpressure): targets keep the last sent page anyway, so the next page
+// request (starting from the last returned entry) is served from their memory.
+// The proxy holds the resulting page plus a single entry per target.
+
+// pages of (at least) this size are stream-merged
+const listStreamPageSize = 4 * cmn.DefaultListPageSizeAIS
+
+type (
+	// target's response being read entry by entry
+	listStream struct {
+		si        *cluster.Snode
+		resp      *http.Response
+		r         *msgp.Reader
+		entry     *cmn.BucketEntry // current (smallest not yet merged) entry
+		remaining uint32           // entries not yet read
+		done      bool             // target has no more entries beyond this response
+	}
+	listStreams []*listStream // min-heap by the current entry
+)
+
+// interface guard
+var _ heap.Interface = (*listStreams)(nil)
+
+func (s listStreams) Len() int { return len(s) }
+func (s listStreams) Less(i, j int) bool {
+	ei, ej := s[i].entry, s[j].entry
+	if ei.Name == ej.Name {
+		return ei.Flags&cmn.EntryStatusMask < ej.Flags&cmn.EntryStatusMask // same as cmn.SortBckEntries
+	}
+	return ei.Name < ej.Name
+}
+func (s listStreams) Swap(i, j int)       { s[i], s[j] = s[j], s[i] }
+func (s *listStreams) Push(x interface{}) { *s = append(*s, x.(*listStream)) }
+func (s *listStreams) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	*s = old[:n-1]
+	return x
+}
+
+// listObjectsAISStream is `listObjectsAIS` for huge pages (see above); it
+// bypasses the query buffer and cache.
+func (p *proxyrunner) listObjectsAISStream(bck *cluster.Bck, smsg cmn.SelectMsg) (*cmn.BucketList, error) {
+	var (
+		smap     = p.owner.smap.get()
+		pageSize = smsg.PageSize
+		aisMsg   = p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActListObjects, Value: &smsg}, smap, nil)
+		req      = cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+			Body:   cmn.MustMarshal(aisMsg),
+		}
+		streams = make(listStreams, 0, smap.CountTargets())
+		ch      = make(chan *listStream, smap.CountTargets())
+		errCh   = make(chan error, smap.CountTargets())
+		cnt     int
+	)
+	for _, si := range smap.Tmap {
+		if si.InMaintenance() {
+			continue
+		}
+		cnt++
+		go func(si *cluster.Snode) {
+			s, err := p.openListStream(si, req, pageSize)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			ch <- s
+		}(si)
+	}
+	var err error
+	for i := 0; i < cnt; i++ {
+		select {
+		case s := <-ch:
+			if s.entry == nil {
+				s.close()
+				continue
+			}
+			streams = append(streams, s)
+		case err = <-errCh:
+		}
+	}
+	defer func() {
+		for _, s := range streams {
+			s.close()
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, partial, err := streams.merge(pageSize)
+	if err != nil {
+		return nil, err
+	}
+	bckList := &cmn.BucketList{UUID: smsg.UUID, Entries: entries}
+	if len(entries) > 0 && (uint(len(entries)) >= pageSize || partial) {
+		bckList.ContinuationToken = entries[len(entries)-1].Name
+	}
+	return bckList, nil
+}
+
+// merge returns (up to) `pageSize` sorted and deduplicated entries; `partial`
+// is set when the merge had to stop early as the entries following the last
+// one sent by some target are unknown.
+func (s *listStreams) merge(pageSize uint) (entries []*cmn.BucketEntry, partial bool, err error) {
+	entries = make([]*cmn.BucketEntry, 0, pageSize)
+	heap.Init(s)
+	for s.Len() > 0 && uint(len(entries)) < pageSize {
+		ls := (*s)[0]
+		if e := ls.entry; len(entries) == 0 || entries[len(entries)-1].Name != e.Name {
+			entries = append(entries, e)
+		}
+		if err = ls.next(); err != nil {
+			return
+		}
+		if ls.entry != nil {
+			heap.Fix(s, 0)
+			continue
+		}
+		heap.Pop(s)
+		ls.close()
+		if !ls.done {
+			partial = true
+			break
+		}
+	}
+	return
+}
+
+// openListStream makes the list-objects request to the target (see `call`)
+// and reads the response up to its first entry.
+func (p *proxyrunner) openListStream(si *cluster.Snode, args cmn.ReqArgs, pageSize uint) (s *listStream, err error) {
+	args.Base = si.URL(cmn.NetworkIntraControl)
+	req, err := args.Req()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(cmn.HeaderCallerID, p.si.ID())
+	req.Header.Set(cmn.HeaderCallerName, p.si.Name())
+	if smap := p.owner.smap.get(); smap.isValid() {
+		req.Header.Set(cmn.HeaderCallerSmapVersion, strconv.FormatInt(smap.version(), 10))
+	}
+	resp, err := p.httpclientGetPut.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to HTTP-call %s (%s %s): %v", si, args.Method, args.URL(), err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var b bytes.Buffer
+		b.ReadFrom(resp.Body)
+		resp.Body.Close()
+		return nil, errors.New(b.String())
+	}
+	s = &listStream{si: si, resp: resp, r: msgp.NewReaderSize(resp.Body, 10*cmn.KiB)}
+	if err = s.open(pageSize); err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to read list-objects response from %s: %v", si, err)
+	}
+	p.keepalive.heardFrom(si.ID(), false /*reset*/)
+	return s, nil
+}
+
+// open skips `cmn.BucketList` fields preceding the entries and reads the first entry
+func (s *listStream) open(pageSize uint) error {
+	sz, err := s.r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for ; sz > 0; sz-- {
+		field, err := s.r.ReadMapKeyPtr()
+		if err != nil {
+			return err
+		}
+		if msgp.UnsafeString(field) != "Entries" {
+			if err := s.r.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if s.remaining, err = s.r.ReadArrayHeader(); err != nil {
+			return err
+		}
+		s.done = uint(s.remaining) < pageSize
+		return s.next()
+	}
+	s.done = true // no entries
+	return nil
+}
+
+func (s *listStream) next() error {
+	s.entry = nil
+	for s.remaining > 0 {
+		s.remaining--
+		if s.r.IsNil() {
+			if err := s.r.ReadNil(); err != nil {
+				return err
+			}
+			continue
+		}
+		e := &cmn.BucketEntry{}
+		if err := e.DecodeMsg(s.r); err != nil {
+			return fmt.Errorf("failed to read list-objects response from %s: %v", s.si, err)
+		}
+		s.entry = e
+		return nil
+	}
+	return nil
+}
+
+func (s *listStream) close() {
+	if s.resp != nil {
+		s.resp.Body.Close()
+		s.resp = nil
+	}
+}
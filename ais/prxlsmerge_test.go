@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tinylib/msgp/msgp"
+)
+
+var _ = Describe("ListStreamMerge", func() {
+	const pageSize = 4
+
+	makeStream := func(entries ...*cmn.BucketEntry) *listStream {
+		var (
+			buf = &bytes.Buffer{}
+			w   = msgp.NewWriter(buf)
+		)
+		err := (&cmn.BucketList{UUID: "uuid", Entries: entries}).EncodeMsg(w)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Flush()).NotTo(HaveOccurred())
+		s := &listStream{si: &cluster.Snode{DaemonID: "t"}, r: msgp.NewReader(buf)}
+		Expect(s.open(pageSize)).NotTo(HaveOccurred())
+		return s
+	}
+	makeEntries := func(xs ...string) (entries []*cmn.BucketEntry) {
+		for _, x := range xs {
+			entries = append(entries, &cmn.BucketEntry{Name: x})
+		}
+		return
+	}
+	extractNames := func(entries []*cmn.BucketEntry) (xs []string) {
+		for _, entry := range entries {
+			xs = append(xs, entry.Name)
+		}
+		return
+	}
+
+	It("should merge and deduplicate target streams", func() {
+		moved := &cmn.BucketEntry{Name: "b", Flags: cmn.ObjStatusMoved}
+		streams := listStreams{
+			makeStream(makeEntries("a", "c")...),
+			makeStream(moved, &cmn.BucketEntry{Name: "d"}),
+			makeStream(makeEntries("b")...),
+		}
+		entries, partial, err := streams.merge(pageSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(partial).To(BeFalse())
+		Expect(extractNames(entries)).To(Equal([]string{"a", "b", "c", "d"}))
+		Expect(entries[1].IsStatusOK()).To(BeTrue())
+	})
+
+	It("should stop at the end of the page", func() {
+		streams := listStreams{
+			makeStream(makeEntries("a", "c", "e", "g")...),
+			makeStream(makeEntries("b", "d", "f")...),
+		}
+		entries, partial, err := streams.merge(pageSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(partial).To(BeFalse())
+		Expect(extractNames(entries)).To(Equal([]string{"a", "b", "c", "d"}))
+	})
+
+	It("should not go beyond the last entry of incomplete target stream", func() {
+		streams := listStreams{
+			makeStream(&cmn.BucketEntry{Name: "a"}, nil, nil, &cmn.BucketEntry{Name: "c"}),
+			makeStream(makeEntries("b", "d")...),
+		}
+		entries, partial, err := streams.merge(pageSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(partial).To(BeTrue())
+		Expect(extractNames(entries)).To(Equal([]string{"a", "b", "c"}))
+	})
+})
@@ -0,0 +1,73 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// custom metadata index - see package mdindex
+
+// GET /v1/buckets/bucket-name?what=md_query&md=key=value[&md=...][&limit=N]
+// (each target answers from its own index; the proxy merges the results)
+func (p *proxyrunner) mdQuery(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	bck, err := newBckFromQuery(bucket, query)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = bck.Init(p.owner.bmd, p.si); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessObjLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !bck.Props.MDIndex.Enabled {
+		p.invalmsghdlrf(w, r, "%s: custom metadata index is disabled (see md_index bucket property)", bck)
+		return
+	}
+	_, limit, err := parseMDQuery(query)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bucket),
+			Query:  query,
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxKeepalive,
+		fv:      func() interface{} { return &[]string{} },
+	})
+	var (
+		names = make([]string, 0, 64)
+		seen  = make(map[string]struct{}, 64)
+	)
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		for _, name := range *res.v.(*[]string) {
+			if _, ok := seen[name]; ok {
+				continue // (e.g., not yet cleaned up after rebalance)
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+	_ = p.writeJSON(w, r, names, cmn.GetWhatMDQuery)
+}
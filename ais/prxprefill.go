@@ -0,0 +1,187 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// Prefill (see cmn.RebalanceConf.Prefill): instead of triggering global
+// rebalance, a new target joins the cluster flagged as cluster.SnodePrefill:
+//   * the target immediately starts serving PUTs and GETs of the objects
+//     that hash to it; the objects that are not there yet get restored from
+//     neighbors (GFN);
+//   * all targets run the prefill xaction that trickles the existing objects
+//     onto the prefilling target(s) at a configured rate;
+//   * upon successful completion, the primary clears the flag.
+// Global rebalance (started manually or triggered by another membership
+// change) supersedes prefill: the flags get cleared and the targets abort
+// their prefill xactions.
+
+// (under smap lock) called when a new target joins
+func (p *proxyrunner) _prefillPre(ctx *smapModifier, clone *smapX) {
+	rebalance := ctx.exists || p.requiresRebalance(ctx.smap, clone)
+	if !rebalance {
+		return
+	}
+	if !ctx.exists && cmn.GCO.Get().Rebalance.Prefill {
+		clone.setNodeFlags(ctx.nsi.ID(), cluster.SnodePrefill)
+		ctx.prefill = true
+		return
+	}
+	clearPrefill(clone)
+}
+
+func clearPrefill(clone *smapX) {
+	for _, tid := range clone.PrefillTargets() {
+		clone.clearNodeFlags(tid, cluster.SnodePrefill)
+	}
+}
+
+func (p *proxyrunner) startPrefill(smap *smapX) {
+	var (
+		tids    = smap.PrefillTargets()
+		xactMsg = xaction.XactReqMsg{ID: cmn.GenUUID(), Kind: cmn.ActPrefill}
+		body    = cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActXactStart, Value: xactMsg})
+	)
+	glog.Infof("%s: starting prefill[%s] of %v", p.si, xactMsg.ID, tids)
+	xnl := xaction.NewXactNL(xactMsg.ID, &smap.Smap, smap.Tmap.Clone(), cmn.ActPrefill)
+	xnl.SetOwner(equalIC)
+	xnl.F = func(n nl.NotifListener) { p.prefillDone(n, tids) }
+	p.ic.registerEqual(regIC{smap: smap, nl: xnl})
+
+	var (
+		results = p.callTargets(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Xactions), body)
+		failed  bool
+	)
+	for res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to start prefill[%s] on %s: %v", p.si, xactMsg.ID, res.si, res.err)
+			failed = true
+		}
+	}
+	if failed {
+		p.abortPrefill(&xactMsg, tids)
+	}
+}
+
+// prefill that didn't start on all targets is replaced with global rebalance:
+// stop the xaction everywhere, clear the flags, and rebalance
+func (p *proxyrunner) abortPrefill(xactMsg *xaction.XactReqMsg, tids []string) {
+	body := cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActXactStop, Value: xactMsg})
+	results := p.callTargets(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Xactions), body)
+	for res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to stop prefill[%s] on %s: %v", p.si, xactMsg.ID, res.si, res.err)
+		}
+	}
+	if err := p.endPrefill(); err != nil {
+		glog.Errorf("%s: failed to clear prefill[%s] of %v: %v", p.si, xactMsg.ID, tids, err)
+		return
+	}
+	if err := p.canStartRebalance(); err != nil {
+		glog.Errorf("%s: prefill[%s] of %v aborted, cannot rebalance: %v", p.si, xactMsg.ID, tids, err)
+		return
+	}
+	var (
+		smap     = p.owner.smap.get()
+		rmdClone = p.owner.rmd.modify(func(clone *rebMD) { clone.inc() })
+		msg      = &cmn.ActionMsg{Action: cmn.ActRebalance}
+	)
+	_ = p.metasyncer.sync(revsPair{rmdClone, p.newAisMsg(msg, nil, nil)})
+	rebnl := xaction.NewXactNL(xaction.RebID(rmdClone.Version).String(), &smap.Smap, smap.Tmap.Clone(),
+		cmn.ActRebalance)
+	rebnl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{smap: smap, nl: rebnl})
+	glog.Warningf("%s: prefill[%s] of %v aborted, rebalancing instead", p.si, xactMsg.ID, tids)
+}
+
+// upon successful completion, clear the flags of the targets that were
+// prefilling when the xaction started (those that joined later are
+// prefilled by the next one)
+func (p *proxyrunner) prefillDone(n nl.NotifListener, tids []string) {
+	if err := n.Err(false); err != nil || n.Aborted() {
+		glog.Errorf("prefill[%s] of %v didn't finish successfully, err: %v, aborted: %v (run global rebalance to complete)",
+			n.UUID(), tids, err, n.Aborted())
+		return
+	}
+	if !p.owner.smap.get().isPrimary(p.si) {
+		return
+	}
+	ctx := &smapModifier{
+		pre: func(_ *smapModifier, clone *smapX) error {
+			for _, tid := range tids {
+				if tsi := clone.GetTarget(tid); tsi != nil && tsi.InPrefill() {
+					clone.clearNodeFlags(tid, cluster.SnodePrefill)
+				}
+			}
+			return nil
+		},
+		post: p._syncPost,
+		msg:  &cmn.ActionMsg{Action: cmn.ActPrefill},
+	}
+	if err := p.owner.smap.modify(ctx); err != nil {
+		glog.Errorf("%s: failed to complete prefill[%s]: %v", p.si, n.UUID(), err)
+		return
+	}
+	glog.Infof("%s: prefill[%s] of %v done", p.si, n.UUID(), tids)
+}
+
+// global rebalance supersedes prefill (the targets abort their prefill
+// xactions upon starting rebalance)
+func (p *proxyrunner) endPrefill() error {
+	if len(p.owner.smap.get().PrefillTargets()) == 0 {
+		return nil
+	}
+	ctx := &smapModifier{
+		pre: func(_ *smapModifier, clone *smapX) error {
+			clearPrefill(clone)
+			return nil
+		},
+		post: p._syncPost,
+		msg:  &cmn.ActionMsg{Action: cmn.ActPrefill},
+	}
+	return p.owner.smap.modify(ctx)
+}
+
+// GET /v1/cluster?what=prefill
+func (p *proxyrunner) queryPrefill(w http.ResponseWriter, r *http.Request, what string) {
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+			Query:  r.URL.Query(),
+		},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+		fv:      func() interface{} { return &cmn.PrefillStatus{} },
+	})
+	status := &cmn.ClusterPrefillStatus{
+		Prefilling: p.owner.smap.get().PrefillTargets(),
+		Targets:    make(map[string]*cmn.PrefillStatus, len(results)),
+	}
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		ts := res.v.(*cmn.PrefillStatus)
+		status.Targets[res.si.ID()] = ts
+		status.Cluster.Scanned += ts.Scanned
+		status.Cluster.Moved += ts.Moved
+		status.Cluster.Bytes += ts.Bytes
+		status.Cluster.Running = status.Cluster.Running || ts.Running
+		status.Cluster.Aborted = status.Cluster.Aborted || ts.Aborted
+		if status.Cluster.ID == "" {
+			status.Cluster.ID = ts.ID
+		}
+	}
+	_ = p.writeJSON(w, r, status, what)
+}
@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestPrefillPre(t *testing.T) {
+	var (
+		p      = newPrimary()
+		newTsi = func(id string) *cluster.Snode {
+			return newSnode(id, httpProto, cmn.Target, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{})
+		}
+		setPrefill = func(enabled bool) {
+			config := cmn.GCO.BeginUpdate()
+			config.Rebalance.Enabled = true
+			config.Rebalance.DontRunTime = 0
+			config.Rebalance.Prefill = enabled
+			cmn.GCO.CommitUpdate(config)
+		}
+		join = func(prev *smapX, tid string) (*smapModifier, *smapX) {
+			clone := prev.clone()
+			ctx := &smapModifier{smap: prev, nsi: newTsi(tid)}
+			ctx.exists = clone.putNode(ctx.nsi, 0)
+			p._prefillPre(ctx, clone)
+			return ctx, clone
+		}
+	)
+	smap := p.owner.smap.get().clone()
+	smap.putNode(newTsi("t1"), 0)
+
+	// new target joins in prefill mode
+	setPrefill(true)
+	ctx, clone := join(smap, "t2")
+	if !ctx.prefill || !clone.GetTarget("t2").InPrefill() || clone.GetTarget("t1").InPrefill() {
+		t.Fatalf("expected t2 (only) to be prefilling: %v", clone.PrefillTargets())
+	}
+
+	// another one joins while t2 is still prefilling
+	ctx, clone = join(clone, "t3")
+	if !ctx.prefill || len(clone.PrefillTargets()) != 2 {
+		t.Fatalf("expected t2 and t3 to be prefilling: %v", clone.PrefillTargets())
+	}
+
+	// same ID rejoins: global rebalance supersedes prefill
+	ctx, rejoined := join(clone, "t3")
+	if ctx.prefill || len(rejoined.PrefillTargets()) != 0 {
+		t.Fatalf("expected no prefilling targets upon rejoin: %v", rejoined.PrefillTargets())
+	}
+
+	// prefill disabled: global rebalance that clears the flags
+	setPrefill(false)
+	ctx, clone = join(clone, "t4")
+	if ctx.prefill || len(clone.PrefillTargets()) != 0 {
+		t.Fatalf("expected no prefilling targets with prefill disabled: %v", clone.PrefillTargets())
+	}
+}
+
+func TestClearPrefill(t *testing.T) {
+	smap := newSmap()
+	for _, tid := range []string{"t1", "t2", "t3"} {
+		smap.putNode(newSnode(tid, httpProto, cmn.Target, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{}), 0)
+	}
+	smap.setNodeFlags("t1", cluster.SnodePrefill)
+	smap.setNodeFlags("t3", cluster.SnodePrefill|cluster.SnodeMaintenance)
+	clearPrefill(smap)
+	if tids := smap.PrefillTargets(); len(tids) != 0 {
+		t.Fatalf("expected no prefilling targets, got %v", tids)
+	}
+	if !smap.GetTarget("t3").InMaintenance() {
+		t.Fatal("expected other flags to remain set")
+	}
+}
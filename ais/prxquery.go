@@ -6,6 +6,7 @@ package ais
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -190,5 +191,9 @@ func (p *proxyrunner) httpquerygetnext(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	if strings.Contains(r.Header.Get(cmn.HeaderAccept), cmn.ContentProtobuf) {
+		p.writeProtobuf(w, r, &cmn.BucketList{Entries: result.Entries}, "query_objects")
+		return
+	}
 	p.writeJSON(w, r, result.Entries, "query_objects")
 }
@@ -0,0 +1,71 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/replication"
+)
+
+// bucket replication to remote AIS cluster - see package replication
+
+// GET /v1/buckets/bucket-name?what=replication
+// (the targets' replication status plus the cluster-wide one)
+func (p *proxyrunner) replicationStatus(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	bck, err := newBckFromQuery(bucket, query)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = bck.Init(p.owner.bmd, p.si); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	results := p.bcastToGroup(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: r.Method,
+			Path:   cmn.JoinWords(cmn.Version, cmn.Buckets, bucket),
+			Query:  query,
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxKeepalive,
+		fv:      func() interface{} { return &cmn.ReplStatus{} },
+	})
+	targets := make(map[string]*cmn.ReplStatus, len(results))
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		targets[res.si.ID()] = res.v.(*cmn.ReplStatus)
+	}
+	status := replication.Aggregate(bck.Bck, bck.Props.Replication, targets)
+	_ = p.writeJSON(w, r, status, cmn.GetWhatReplication)
+}
+
+// replication destination must be an attached remote AIS cluster
+func checkReplication(bck *cluster.Bck, nprops *cmn.BucketProps, config *cmn.Config) error {
+	conf := &nprops.Replication
+	if !conf.Enabled() || conf.TargetCluster == bck.Props.Replication.TargetCluster {
+		return nil
+	}
+	if aisConf, ok := config.Cloud.ProviderConf(cmn.ProviderAIS); ok {
+		if clusterConf, ok := aisConf.(cmn.CloudConfAIS); ok {
+			if _, ok := clusterConf[conf.TargetCluster]; ok {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: cannot replicate to %q - remote AIS cluster is not attached (see 'ais attach')",
+		bck, conf.TargetCluster)
+}
@@ -159,7 +159,7 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		return
 	}
 	if err := bck.Allow(cmn.AccessBckDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	if p.forwardCP(w, r, &msg, bucket) {
@@ -185,7 +185,7 @@ func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bu
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	decoder := xml.NewDecoder(r.Body)
@@ -224,7 +224,7 @@ func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket s
 		return
 	}
 	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	// From AWS docs:
@@ -280,7 +280,7 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		return
 	}
 	if err := bckSrc.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	bckDst := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
@@ -298,7 +298,7 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		err  error
 	)
 	if err = bckDst.Allow(cmn.AccessPUT); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	objName := strings.Trim(parts[1], "/")
@@ -341,7 +341,7 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessPUT); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	objName := path.Join(items[1:]...)
@@ -384,7 +384,7 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	objName := path.Join(items[1:]...)
@@ -414,7 +414,7 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	smap := p.owner.smap.get()
@@ -448,7 +448,7 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
 		return
 	}
 	objName := path.Join(items[1:]...)
@@ -0,0 +1,184 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// OpenAPI (v3) spec of the native API is generated on the fly from:
+//   * the routes actually registered with the public network (see registerNetworkHandlers);
+//   * the API constants (actions, query parameters, headers) - see cmn.APIConstGroups
+//     generated from cmn/api_const.go.
+// The result is served at GET /v1/spec - to be consumed by client SDK generators
+// and tools like Postman. See also: openapi/README.md
+
+const specVersion = "3.0.0"
+
+type (
+	specResource struct {
+		tag     string
+		methods []string
+		action  bool // request body (if any) is cmn.ActionMsg
+	}
+	specObj = map[string]interface{}
+)
+
+// supported methods per (l2) resource; must be kept in sync with the respective handlers
+// (TestGenSpec fails on any registered route that is neither described here nor internal)
+var specResources = map[string]specResource{
+	cmn.Buckets: {tag: "Bucket", action: true, methods: []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}},
+	cmn.Objects: {tag: "Object", action: true, methods: []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodDelete}},
+	cmn.Download: {tag: "Download", methods: []string{http.MethodGet, http.MethodPost, http.MethodDelete}},
+	cmn.Query:    {tag: "Query", methods: []string{http.MethodGet, http.MethodPost}},
+	cmn.Cluster: {tag: "Cluster", action: true, methods: []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}},
+	cmn.Daemon: {tag: "Daemon", action: true, methods: []string{
+		http.MethodGet, http.MethodPut, http.MethodDelete}},
+	cmn.Sort:    {tag: "Sort", methods: []string{http.MethodGet, http.MethodPost, http.MethodDelete}},
+	cmn.Tokens:  {tag: "Tokens", methods: []string{http.MethodDelete}},
+	cmn.ETL:     {tag: "ETL", methods: []string{http.MethodGet, http.MethodPost}},
+	cmn.Reverse: {tag: "Reverse", methods: []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete}},
+	cmn.S3:      {tag: "S3", methods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}},
+	cmn.Spec:    {tag: "Spec", methods: []string{http.MethodGet}},
+	cmn.Health:  {tag: "Health", methods: []string{http.MethodGet}},
+	cmn.Metrics: {tag: "Metrics", methods: []string{http.MethodGet}},
+	"":          {tag: "Cloud", methods: []string{http.MethodGet, http.MethodHead}}, // "/" (see httpCloudHandler)
+}
+
+// intra-cluster resources that are not included in the spec
+var specInternal = cmn.StringSet{
+	cmn.IC:       {},
+	cmn.Metasync: {},
+	cmn.Vote:     {},
+	cmn.Notifs:   {},
+}
+
+// GET /v1/spec
+func (p *proxyrunner) specHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /spec path")
+		return
+	}
+	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Spec); err != nil {
+		return
+	}
+	p.writeJSON(w, r, p.genSpec(), "spec")
+}
+
+func (p *proxyrunner) genSpec() specObj {
+	paths := make(specObj)
+	for _, path := range p.publicServer.registeredRoutes() {
+		name := specResourceName(path)
+		if specInternal.Contains(name) {
+			continue
+		}
+		res, ok := specResources[name]
+		if !ok {
+			glog.Errorf("%s: route %q is not described by the spec", p.si, path)
+			continue
+		}
+		paths[strings.TrimSuffix(path, "/")+"/{items}"] = specPathItem(&res, true)
+		if path != "/" { // cloud bucket and object names are required
+			paths[path] = specPathItem(&res, false)
+		}
+	}
+	return specObj{
+		"openapi": specVersion,
+		"info": specObj{
+			"title":       "AIStore native API",
+			"version":     cmn.Version,
+			"description": "Generated by " + p.si.Name() + " from the registered routes and API constants",
+		},
+		"paths":      paths,
+		"components": specComponents(),
+	}
+}
+
+// "/v1/buckets" => "buckets"; "/s3" => "s3"
+func specResourceName(path string) string {
+	items := strings.Split(strings.Trim(path, "/"), "/")
+	if len(items) > 1 && items[0] == cmn.Version {
+		return items[1]
+	}
+	return items[0]
+}
+
+func specPathItem(res *specResource, withItems bool) specObj {
+	item := make(specObj, len(res.methods))
+	for _, method := range res.methods {
+		op := specObj{
+			"tags":      []string{res.tag},
+			"responses": specObj{"200": specObj{"description": "OK"}, "default": specObj{"description": "Error"}},
+		}
+		if withItems {
+			op["parameters"] = []specObj{{
+				"name":        "items",
+				"in":          "path",
+				"required":    true,
+				"description": "URL path items (e.g. bucket and object names)",
+				"schema":      specObj{"type": "string"},
+			}}
+		}
+		if res.action && method != http.MethodGet && method != http.MethodHead {
+			op["requestBody"] = specObj{
+				"content": specObj{
+					cmn.ContentJSON: specObj{"schema": specObj{"$ref": "#/components/schemas/ActionMsg"}},
+				},
+			}
+		}
+		item[strings.ToLower(method)] = op
+	}
+	return item
+}
+
+func specComponents() specObj {
+	var (
+		parameters = make(specObj)
+		headers    = make(specObj)
+		actions    = specEnum("Act")
+	)
+	for _, c := range cmn.APIConstsByPrefix("URLParam") {
+		parameters[c.Value] = specObj{
+			"name":        c.Value,
+			"in":          "query",
+			"description": c.Doc,
+			"schema":      specObj{"type": "string"},
+		}
+	}
+	for _, c := range cmn.APIConstsByPrefix("Header") {
+		headers[c.Value] = specObj{"description": c.Doc, "schema": specObj{"type": "string"}}
+	}
+	return specObj{
+		"parameters": parameters,
+		"headers":    headers,
+		"schemas": specObj{
+			"ActionMsg": specObj{
+				"type": "object",
+				"properties": specObj{
+					"action": specObj{"type": "string", "enum": actions},
+					"name":   specObj{"type": "string"},
+					"value":  specObj{},
+				},
+				"required": []string{"action"},
+			},
+		},
+	}
+}
+
+func specEnum(prefix string) (enum []string) {
+	for _, c := range cmn.APIConstsByPrefix(prefix) {
+		enum = append(enum, c.Value)
+	}
+	sort.Strings(enum)
+	return
+}
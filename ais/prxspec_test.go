@@ -0,0 +1,94 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestSpecResourceName(t *testing.T) {
+	tests := map[string]string{
+		"/v1/buckets": cmn.Buckets,
+		"/v1/objects": cmn.Objects,
+		"/s3":         cmn.S3,
+		"/":           "",
+	}
+	for path, expected := range tests {
+		if name := specResourceName(path); name != expected {
+			t.Errorf("%q: expected %q, got %q", path, expected, name)
+		}
+	}
+}
+
+func TestGenSpec(t *testing.T) {
+	p := &proxyrunner{}
+	p.si = &cluster.Snode{DaemonID: "primary", DaemonType: cmn.Proxy}
+	p.si.SetName()
+	p.publicServer = &netServer{mux: mux.NewServeMux()}
+	p.intraControlServer = p.publicServer
+	p.intraDataServer = p.publicServer
+	p.registerNetworkHandlers(p.networkHandlers())
+	p.registerNetworkHandlers(p.clusterStartedHandlers())
+
+	spec := p.genSpec()
+	if spec["openapi"] != specVersion {
+		t.Fatalf("expected openapi %q, got %v", specVersion, spec["openapi"])
+	}
+	paths := spec["paths"].(specObj)
+	for _, path := range p.publicServer.registeredRoutes() {
+		name := specResourceName(path)
+		if specInternal.Contains(name) {
+			if _, ok := paths[path]; ok {
+				t.Errorf("unexpected internal path %q", path)
+			}
+			continue
+		}
+		if _, ok := specResources[name]; !ok {
+			t.Errorf("route %q is missing in the spec (see specResources)", path)
+		}
+		if _, ok := paths[strings.TrimSuffix(path, "/")+"/{items}"]; !ok {
+			t.Errorf("missing path %q", path)
+		}
+	}
+	for _, path := range []string{"/v1/buckets", "/v1/health", "/metrics", "/s3/{items}", "/{items}"} {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("missing path %q", path)
+		}
+	}
+
+	// methods and request bodies follow specResources
+	buckets := paths["/v1/buckets/{items}"].(specObj)
+	if len(buckets) != len(specResources[cmn.Buckets].methods) {
+		t.Errorf("expected %d methods, got %d", len(specResources[cmn.Buckets].methods), len(buckets))
+	}
+	if _, ok := buckets["post"].(specObj)["requestBody"]; !ok {
+		t.Error("expected ActionMsg request body for POST")
+	}
+	if _, ok := buckets["get"].(specObj)["requestBody"]; ok {
+		t.Error("unexpected request body for GET")
+	}
+	if _, ok := paths["/v1/spec"].(specObj)["post"]; ok {
+		t.Error("unexpected POST for /v1/spec")
+	}
+
+	// components are generated from the API constants
+	components := spec["components"].(specObj)
+	if _, ok := components["parameters"].(specObj)[cmn.URLParamProvider]; !ok {
+		t.Errorf("missing query parameter %q", cmn.URLParamProvider)
+	}
+	if _, ok := components["headers"].(specObj)[cmn.HeaderObjCksumType]; !ok {
+		t.Errorf("missing header %q", cmn.HeaderObjCksumType)
+	}
+	props := components["schemas"].(specObj)["ActionMsg"].(specObj)["properties"].(specObj)
+	enum := props["action"].(specObj)["enum"].([]string)
+	if !cmn.StringInSlice(cmn.ActCopyBucket, enum) {
+		t.Errorf("missing action %q", cmn.ActCopyBucket)
+	}
+}
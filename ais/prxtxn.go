@@ -10,12 +10,14 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/xaction"
 	jsoniter "github.com/json-iterator/go"
@@ -144,10 +146,17 @@ func (p *proxyrunner) destroyBucket(msg *cmn.ActionMsg, bck *cluster.Bck) error
 
 // make-n-copies: { confirm existence -- begin -- update locally -- metasync -- commit }
 func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) (xactID string, err error) {
-	copies, err := p.parseNCopies(msg.Value)
+	mncMsg, err := p.parseMNCMsg(msg.Value)
 	if err != nil {
 		return
 	}
+	if _, err = mirror.NewScopeFilter(mncMsg); err != nil {
+		return
+	}
+	var (
+		copies = int64(mncMsg.Copies)
+		scoped = mncMsg.Prefix != "" || mncMsg.Template != ""
+	)
 
 	// 1. confirm existence
 	bmd := p.owner.bmd.get()
@@ -171,27 +180,30 @@ func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) (xactID
 		}
 	}
 
-	// 3. update BMD locally
+	// 3. update BMD locally (unless scoped - a subset of objects does not
+	// change the bucket's mirroring props)
 	var (
 		wg      *sync.WaitGroup
 		present bool
 		bprops  *cmn.BucketProps
 	)
-	_ = p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
-		bprops, present = clone.Get(bck) // TODO: Bucket could be deleted during begin.
-		cmn.Assert(present)
-		nprops := bprops.Clone()
-		nprops.Mirror.Enabled = copies > 1
-		nprops.Mirror.Copies = copies
-
-		clone.set(bck, nprops)
-		return true, nil
-	}, func(clone *bucketMD) {
-		// 4. metasync updated BMD
-		c.msg.BMDVersion = clone.version()
-		wg = p.metasyncer.sync(revsPair{clone, c.msg})
-	})
-	wg.Wait()
+	if !scoped {
+		_ = p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
+			bprops, present = clone.Get(bck) // TODO: Bucket could be deleted during begin.
+			cmn.Assert(present)
+			nprops := bprops.Clone()
+			nprops.Mirror.Enabled = copies > 1
+			nprops.Mirror.Copies = copies
+
+			clone.set(bck, nprops)
+			return true, nil
+		}, func(clone *bucketMD) {
+			// 4. metasync updated BMD
+			c.msg.BMDVersion = clone.version()
+			wg = p.metasyncer.sync(revsPair{clone, c.msg})
+		})
+		wg.Wait()
+	}
 
 	// 5. IC
 	nl := xaction.NewXactNL(c.uuid, &c.smap.Smap, c.smap.Tmap.Clone(), msg.Action, bck.Bck)
@@ -204,7 +216,9 @@ func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) (xactID
 	for res := range results {
 		if res.err != nil {
 			glog.Error(res.err) // commit must go thru
-			p.undoUpdateCopies(msg, bck, bprops.Mirror.Copies, bprops.Mirror.Enabled)
+			if !scoped {
+				p.undoUpdateCopies(msg, bck, bprops.Mirror.Copies, bprops.Mirror.Enabled)
+			}
 			err = res.err
 			return
 		}
@@ -217,16 +231,28 @@ func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) (xactID
 func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck,
 	propsToUpdate cmn.BucketPropsToUpdate) (xactID string, err error) {
 	var (
-		nprops *cmn.BucketProps   // complete version of bucket props containing propsToUpdate changes
-		nmsg   = &cmn.ActionMsg{} // with nprops
+		nprops      *cmn.BucketProps   // complete version of bucket props containing propsToUpdate changes
+		nmsg        = &cmn.ActionMsg{} // with nprops
+		propsVer    int64              // test-and-set: expected props version (if specified)
+		propsVerStr = r.URL.Query().Get(cmn.URLParamBpropsVersion)
 	)
+	if propsVerStr != "" {
+		if propsVer, err = strconv.ParseInt(propsVerStr, 10, 64); err != nil {
+			err = fmt.Errorf("invalid %s=%q: %v", cmn.URLParamBpropsVersion, propsVerStr, err)
+			return
+		}
+	}
 
-	// 1. confirm existence
+	// 1. confirm existence (and, optionally, the props version)
 	bprops, present := p.owner.bmd.get().Get(bck)
 	if !present {
 		err = cmn.NewErrorBucketDoesNotExist(bck.Bck, p.si.String())
 		return
 	}
+	if propsVerStr != "" && bprops.PropsVersion != propsVer {
+		err = cmn.NewErrBpropsChanged(bck.Bck, propsVer, bprops.PropsVersion)
+		return
+	}
 	bck.Props = bprops
 
 	// 2. begin
@@ -252,6 +278,17 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 		if err = p.checkBackendBck(nprops); err != nil {
 			return
 		}
+
+		// heavy changes (EC, mirroring, checksum) require enough capacity unless forced
+		if !cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamForce)) {
+			var plan *cmn.BpropsPlan
+			if plan, err = p.gatherBpropsPlan(bck, nprops); err != nil {
+				return
+			}
+			if err = plan.Insufficient(); err != nil {
+				return
+			}
+		}
 	case cmn.ActResetBprops:
 		if bck.IsCloud() {
 			if bck.HasBackendBck() {
@@ -292,10 +329,14 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 		wg           *sync.WaitGroup
 		needReMirror bool
 		needReEC     bool
+		needReIndex  bool
 	)
 	err = p.owner.bmd.modify(func(clone *bucketMD) (bool, error) {
 		bprops, present = clone.Get(bck) // TODO: Bucket could be deleted during begin.
 		cmn.Assert(present)
+		if propsVerStr != "" && bprops.PropsVersion != propsVer {
+			return false, cmn.NewErrBpropsChanged(bck.Bck, propsVer, bprops.PropsVersion)
+		}
 
 		if msg.Action == cmn.ActSetBprops {
 			bck.Props = bprops
@@ -312,6 +353,7 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 
 		needReMirror = reMirror(bprops, nprops)
 		needReEC = reEC(bprops, nprops, bck)
+		needReIndex = reIndexMD(bprops, nprops)
 		clone.set(bck, nprops)
 		return true, nil
 	}, func(clone *bucketMD) {
@@ -320,15 +362,20 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 		wg = p.metasyncer.sync(revsPair{clone, c.msg})
 	})
 	if err != nil {
+		// abort (e.g., the props have changed since begin)
+		c.req.Path = cmn.JoinWords(c.path, cmn.ActAbort)
+		_ = p.bcastToGroup(bcastArgs{req: c.req, smap: c.smap})
 		return "", err
 	}
 	wg.Wait()
 
-	// 5. if remirror|re-EC|TBD-storage-svc
-	if needReMirror || needReEC {
+	// 5. if remirror|re-EC|re-index|TBD-storage-svc
+	if needReMirror || needReEC || needReIndex {
 		action := cmn.ActMakeNCopies
 		if needReEC {
 			action = cmn.ActECEncode
+		} else if !needReMirror {
+			action = cmn.ActMDIndex
 		}
 		nl := xaction.NewXactNL(c.uuid, &c.smap.Smap, c.smap.Tmap.Clone(), action, bck.Bck)
 		nl.SetOwner(equalIC)
@@ -392,6 +439,7 @@ func (p *proxyrunner) renameBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionM
 		cmn.Assert(added)
 		bckFrom.Props.Renamed = cmn.ActRenameLB
 		clone.set(bckFrom, bckFrom.Props)
+		clone.renameAliases(bckFrom.Bck, bckTo.Bck)
 		return true, nil
 	}, func(clone *bucketMD) {
 		// 4. metasync updated BMD
@@ -435,7 +483,8 @@ func (p *proxyrunner) renameBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionM
 
 // copy-bucket/offline ETL:
 // { confirm existence -- begin -- conditional metasync -- start waiting for operation done -- commit }
-func (p *proxyrunner) bucketToBucketTxn(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg, dryRun bool) (xactID string, err error) {
+func (p *proxyrunner) bucketToBucketTxn(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg,
+	b2bMsg *cmn.Bck2BckMsg) (xactID string, err error) {
 	cmn.Assert(!bckTo.IsHTTP())
 	cmn.Assert(msg.Value != nil)
 
@@ -468,13 +517,18 @@ func (p *proxyrunner) bucketToBucketTxn(bckFrom, bckTo *cluster.Bck, msg *cmn.Ac
 		cmn.Assert(present)
 
 		// Skip destination bucket creation if it's dry run or it's already present.
-		if _, present = clone.Get(bckTo); dryRun || present {
+		if _, present = clone.Get(bckTo); b2bMsg.DryRun || present {
 			return false, nil
 		}
 
 		cmn.Assert(!bckTo.IsRemote())
 		bckFrom.Props = bprops.Clone()
 		bckTo.Props = bprops.Clone()
+		// the destination is ephemeral only if requested (never inherited from the source)
+		bckTo.Props.Ephemeral = cmn.EphemeralConf{}
+		if b2bMsg.Ephemeral != nil {
+			bckTo.Props.Ephemeral = *b2bMsg.Ephemeral
+		}
 		added := clone.add(bckTo, bckTo.Props)
 		cmn.Assert(added)
 		return true, nil
@@ -734,8 +788,12 @@ func (p *proxyrunner) makeNprops(bck *cluster.Bck, propsToUpdate cmn.BucketProps
 		}
 	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
-		if !reflect.DeepEqual(bprops.EC, nprops.EC) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
+		// changing the number of slices is allowed - objects get re-encoded (see reEC)
+		ecConf := nprops.EC
+		ecConf.DataSlices, ecConf.ParitySlices = bprops.EC.DataSlices, bprops.EC.ParitySlices
+		if !reflect.DeepEqual(bprops.EC, ecConf) {
+			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled "+
+				"or have its number of data and parity slices changed", p.si)
 			return
 		}
 	} else if nprops.EC.Enabled {
@@ -762,6 +820,9 @@ func (p *proxyrunner) makeNprops(bck *cluster.Bck, propsToUpdate cmn.BucketProps
 		return
 	}
 
+	if err = checkReplication(bck, nprops, cfg); err != nil {
+		return
+	}
 	targetCnt := p.owner.smap.Get().CountTargets()
 	err = nprops.Validate(targetCnt)
 	return
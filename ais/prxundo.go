@@ -0,0 +1,127 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// see also: tgtundo.go
+
+func (p *proxyrunner) validateUndoWindow(msg *cmn.ActionMsg, bck *cluster.Bck, window string) error {
+	if msg.Action != cmn.ActDelete {
+		return fmt.Errorf("%s is not supported with %q", cmn.URLParamUndoWindow, msg.Action)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return fmt.Errorf("invalid %s=%q: %v", cmn.URLParamUndoWindow, window, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("invalid %s=%q: must be positive", cmn.URLParamUndoWindow, window)
+	}
+	if !bck.IsAIS() {
+		return fmt.Errorf("%s: %s is supported only for ais buckets", bck, cmn.URLParamUndoWindow)
+	}
+	if bck.Props.EC.Enabled {
+		return fmt.Errorf("%s: %s is not supported with erasure coding", bck, cmn.URLParamUndoWindow)
+	}
+	return nil
+}
+
+// PUT {action: undo delete} /v1/cluster
+func (p *proxyrunner) undoDelete(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	token, ok := msg.Value.(string)
+	if !ok || token == "" {
+		p.invalmsghdlrf(w, r, "%s: invalid undo token (%v, %T)", msg.Action, msg.Value, msg.Value)
+		return
+	}
+	// restoring is writing: same checks as upon PUT
+	bck, err, errCode := p.undoBck(token)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := bck.Allow(cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), accessErrCode(err))
+		return
+	}
+	var (
+		restored      int64
+		cnt, notFound int
+		results       = p.callTargets(http.MethodPut, cmn.JoinWords(cmn.Version, cmn.Daemon), cmn.MustMarshal(msg))
+	)
+	for res := range results {
+		cnt++
+		if res.status == http.StatusNotFound {
+			notFound++ // none of the deleted objects were stored on this target
+			continue
+		}
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.err.Error(), res.status)
+			return
+		}
+		var n int64
+		if err := jsoniter.Unmarshal(res.bytes, &n); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		restored += n
+	}
+	if notFound == cnt {
+		p.invalmsghdlr(w, r, fmt.Sprintf("undo token %q not found (or already purged)", msg.Value),
+			http.StatusNotFound)
+		return
+	}
+	w.Write([]byte(strconv.FormatInt(restored, 10)))
+}
+
+// undoBck returns the bucket the objects of a given deletion set were deleted from
+func (p *proxyrunner) undoBck(token string) (bck *cluster.Bck, err error, errCode int) {
+	var (
+		found         cmn.Bck
+		cnt, notFound int
+		query         = url.Values{cmn.URLParamWhat: []string{cmn.GetWhatUndoBck}, cmn.URLParamUUID: []string{token}}
+		results       = p.callTargets(http.MethodGet, cmn.JoinWords(cmn.Version, cmn.Daemon), nil, query)
+	)
+	for res := range results {
+		cnt++
+		if res.status == http.StatusNotFound {
+			notFound++
+			continue
+		}
+		if res.err != nil {
+			err, errCode = res.err, res.status
+			continue
+		}
+		if found.IsEmpty() {
+			if errUnm := jsoniter.Unmarshal(res.bytes, &found); errUnm != nil {
+				err, errCode = errUnm, http.StatusInternalServerError
+			}
+		}
+	}
+	if err != nil {
+		return
+	}
+	if notFound == cnt || found.IsEmpty() {
+		return nil, fmt.Errorf("undo token %q not found (or already purged)", token), http.StatusNotFound
+	}
+	bck = cluster.NewBckEmbed(found)
+	if err = bck.Init(p.owner.bmd, p.si); err != nil {
+		return nil, err, http.StatusNotFound
+	}
+	return bck, nil, 0
+}
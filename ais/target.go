@@ -27,11 +27,13 @@ import (
 	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/evsink"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/replication"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction"
@@ -90,6 +92,9 @@ type (
 		regstate regstate     // the state of being registered with the primary, can be (en/dis)abled via API
 		gmm      *memsys.MMSA // system pagesize-based memory manager and slab allocator
 		smm      *memsys.MMSA // system MMSA for small-size allocations
+		evsink   *evsink.Manager
+		// being replaced by its shadow (see tgtshadow.go) - stopping without unregistering
+		switchedOver atomic.Bool
 	}
 )
 
@@ -198,19 +203,26 @@ func (t *targetrunner) Run() error {
 	cluster.Init()
 
 	t.statsT.RegisterAll()
-	t.httprunner.keepalive = gettargetkeepalive()
-
-	t.checkRestarted()
 
-	// register object type and workfile type
+	// register object, workfile, and content-addressed storage types
 	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.RegisterContentType(fs.CASType, &fs.CASContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if daemon.cli.shadow {
+		return t.runShadow(config)
+	}
+
+	t.httprunner.keepalive = gettargetkeepalive()
+	t.checkRestarted()
 
 	dryRunInit()
+	cmn.GCO.Reg("cold-get-queue", coldGets)
 	t.gfn.local.tag, t.gfn.global.tag = "local GFN", "global GFN"
 
 	// init meta-owners and load local instances
@@ -230,6 +242,10 @@ func (t *targetrunner) Run() error {
 
 	cluster.InitTarget()
 
+	// remove workfiles left behind by the previous run (in background - not to delay
+	// joining the cluster)
+	go t.gcOrphans(time.Now())
+
 	//
 	// join cluster
 	//
@@ -281,6 +297,16 @@ func (t *targetrunner) Run() error {
 	// transactions
 	t.transactions.init(t)
 
+	// object lifecycle events
+	t.evsink = evsink.NewManager(filepath.Join(config.Confdir, evsink.DirName))
+
+	// bulk delete with undo window
+	t.initUndo()
+
+	// bucket lifecycle
+	t.initLifecycle()
+	t.initMpt()
+
 	//
 	// REST API: register storage target's handler(s) and start listening
 	//
@@ -357,7 +383,7 @@ func (t *targetrunner) initRecvHandlers() {
 		{r: cmn.Objects, h: t.objectHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraData}},
 		{r: cmn.Daemon, h: t.daemonHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 		{r: cmn.Metasync, h: t.metasyncHandler, net: []string{cmn.NetworkIntraControl}},
-		{r: cmn.Health, h: t.healthHandler, net: []string{cmn.NetworkIntraControl}},
+		{r: cmn.Health, h: t.healthHandler, net: []string{cmn.NetworkIntraControl, cmn.NetworkIntraData}},
 		{r: cmn.Xactions, h: t.xactHandler, net: []string{cmn.NetworkIntraControl}},
 		{r: cmn.Rebalance, h: t.rebManager.RespHandler, net: []string{cmn.NetworkIntraData}},
 		{r: cmn.EC, h: t.ecHandler, net: []string{cmn.NetworkIntraData}},
@@ -378,6 +404,7 @@ func (t *targetrunner) initRecvHandlers() {
 
 		{r: cmn.Query, h: t.queryHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 
+		{r: "/" + cmn.Metrics, h: t.metricsHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 		{r: "/" + cmn.S3, h: t.s3Handler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraData}},
 		{
 			r: "/", h: cmn.InvalidHandler,
@@ -391,12 +418,27 @@ func (t *targetrunner) initRecvHandlers() {
 func (t *targetrunner) Stop(err error) {
 	glog.Infof("Stopping %s, err: %v", t.GetRunName(), err)
 	registry.Registry.AbortAll()
-	if t.publicServer.s != nil {
+	if t.publicServer.s != nil && !daemon.cli.shadow && !t.switchedOver.Load() {
 		t.unregister() // ignore errors
 	}
 	t.httprunner.stop(err)
 }
 
+// checkVMD validates volume metadata to make sure that none of the mountpaths
+// got swapped, renamed, or borrowed from another target; remapped mountpaths
+// (disk.vmd_remap) are subsequently resilvered - see Run()
+func (t *targetrunner) checkVMD(config *cmn.Config) {
+	remapped, err := fs.CheckVMD(t.si.ID(), config.Disk.VMDRemap)
+	if err != nil {
+		cmn.ExitLogf("%s: refusing to start: %v", t.si, err)
+	}
+	if len(remapped) > 0 {
+		if err := fs.PutMarker(registry.GetMarkerName(cmn.ActResilver)); err != nil {
+			glog.Errorf("%s: failed to mark resilver upon remapping mountpaths: %v", t.si, err)
+		}
+	}
+}
+
 func (t *targetrunner) checkRestarted() {
 	if fs.MarkerExists(nodeRestartedMarker) {
 		t.statsT.Add(stats.RestartCount, 1)
@@ -481,6 +523,14 @@ func (t *targetrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 			t.listBuckets(w, r, cmn.QueryBcks(bck.Bck))
 		}
 	default:
+		switch r.URL.Query().Get(cmn.URLParamWhat) {
+		case cmn.GetWhatReplication:
+			t.replicationStatus(w, r, apiItems[0])
+			return
+		case cmn.GetWhatMDQuery:
+			t.mdQuery(w, r, apiItems[0])
+			return
+		}
 		t.invalmsghdlrf(w, r, "Invalid route /buckets/%s", apiItems[0])
 	}
 }
@@ -542,6 +592,12 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			UUID:  msg.UUID,
 			Evict: msg.Action == cmn.ActEvictObjects,
 		}
+		if window := r.URL.Query().Get(cmn.URLParamUndoWindow); window != "" && !args.Evict {
+			if args.UndoWindow, err = time.ParseDuration(window); err != nil {
+				t.invalmsghdlrf(w, r, "invalid %s=%q: %v", cmn.URLParamUndoWindow, window, err)
+				return
+			}
+		}
 		if err := cmn.MorphMarshal(msg.Value, &rangeMsg); err == nil {
 			args.RangeMsg = rangeMsg
 		} else if err := cmn.MorphMarshal(msg.Value, &listMsg); err == nil {
@@ -662,6 +718,14 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		if !t.bucketSummary(w, r, bck, msg) {
 			return
 		}
+	case cmn.ActAnalyzeBucket:
+		t.bucketAnalysis(w, r, bck, msg)
+	case cmn.ActHeadObjects:
+		t.headObjects(w, r, msg, bck)
+	case cmn.ActPlanBprops:
+		t.planBprops(w, r, msg, bck)
+	case cmn.ActExportBck:
+		t.exportBucket(w, r, bck, msg)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -818,14 +882,16 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		return
 	}
 	goi := &getObjInfo{
-		started: started,
-		t:       t,
-		lom:     lom,
-		w:       w,
-		ctx:     context.Background(),
-		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
-		isGFN:   isGFNRequest,
-		chunked: config.Net.HTTP.Chunked,
+		started:   started,
+		t:         t,
+		lom:       lom,
+		w:         w,
+		ctx:       context.Background(),
+		ranges:    cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
+		isGFN:     isGFNRequest,
+		chunked:   config.Net.HTTP.Chunked,
+		condHdr:   condGetHdr(r),
+		ecRestore: cmn.IsParseBool(query.Get(cmn.URLParamECRestore)),
 	}
 	if bck.IsHTTP() {
 		originalURL := query.Get(cmn.URLParamOrigURL)
@@ -864,12 +930,12 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 			t.statsT.Add(stats.PutRedirLatency, redelta)
 		}
 	}
-	if cs := fs.GetCapStatus(); cs.Err != nil {
+	cs := fs.GetCapStatus()
+	if cs.Err != nil {
 		go t.RunLRU("" /*uuid*/, false)
-		if cs.OOS {
-			t.invalmsghdlr(w, r, cs.Err.Error())
-			return
-		}
+	}
+	if !t.admitPut(w, r, cs) {
+		return
 	}
 	bck, err := newBckFromQuery(bucket, query)
 	if err != nil {
@@ -905,12 +971,17 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	}
 	lom.SetAtimeUnix(started.UnixNano())
 	appendTy := query.Get(cmn.URLParamAppendType)
-	if appendTy == "" {
+	switch appendTy {
+	case "":
 		if err, errCode := t.doPut(r, lom, started); err != nil {
 			t.fshc(err, lom.FQN)
 			t.invalmsghdlr(w, r, err.Error(), errCode)
 		}
-	} else {
+	case cmn.MptCreateOp, cmn.MptPartOp, cmn.MptCompleteOp, cmn.MptAbortOp:
+		if err, errCode := t.doMultipart(w, r, lom, started, appendTy); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), errCode)
+		}
+	default:
 		if handle, err, errCode := t.doAppend(r, lom, started); err != nil {
 			t.invalmsghdlr(w, r, err.Error(), errCode)
 		} else {
@@ -982,6 +1053,24 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.renameObject(w, r, &msg)
+	case cmn.ActMoveObject:
+		if !isIntraCall(r.Header) {
+			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be intra-called", t.si, r.Method, msg.Action)
+			return
+		}
+		t.moveObject(w, r, &msg)
+	case cmn.ActPinObject:
+		if isRedirect(query) == "" {
+			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.pinObject(w, r, &msg)
+	case cmn.ActSetCustomMD:
+		if isRedirect(query) == "" {
+			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.setCustomMD(w, r, &msg)
 	case cmn.ActPromote:
 		if isRedirect(query) == "" && !isIntraCall(r.Header) {
 			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be redirected or intra-called",
@@ -1078,6 +1167,11 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		}
 		return
 	}
+	if !exists && lom.Bck().IsAIS() {
+		if smap := t.owner.smap.get(); t.inPrefill(smap) && t.headPrefill(w, lom, smap) {
+			return
+		}
+	}
 	if lom.Bck().IsAIS() || exists { // && !lom.VerConf().Enabled) {
 		if !exists {
 			invalidHandler(w, r, fmt.Sprintf("%s/%s %s", bucket, objName, cmn.DoesNotExist),
@@ -1085,6 +1179,9 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 			return
 		}
 		lom.ToHTTPHdr(hdr)
+		if etag := cmn.ObjETag(lom.Cksum(), lom.Version()); etag != "" {
+			hdr.Set(cmn.HeaderETag, etag)
+		}
 	} else {
 		objMeta, err, errCode := t.Cloud(lom.Bck()).HeadObj(context.Background(), lom)
 		if err != nil {
@@ -1288,6 +1385,9 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 		handle        = query.Get(cmn.URLParamAppendHandle)
 	)
 
+	if err = lom.Bprops().Naming.ValidateObjName(lom.ObjName); err != nil {
+		return "", err, http.StatusBadRequest
+	}
 	hi, err := parseAppendHandle(handle)
 	if err != nil {
 		return "", err, http.StatusBadRequest
@@ -1382,15 +1482,20 @@ func (t *targetrunner) putMirror(lom *cluster.LOM) {
 }
 
 func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict bool) (error, int) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	return t.deleteObject(ctx, lom, evict, nil)
+}
+
+// PRECONDITION: `lom` is write-locked; non-nil `undo` moves the object into
+// trash instead of removing it (see TrashObject)
+func (t *targetrunner) deleteObject(ctx context.Context, lom *cluster.LOM, evict bool, undo *undoArgs) (error, int) {
 	var (
 		cloudErr     error
 		cloudErrCode int
 		errRet       error
 		delFromAIS   bool
 	)
-	lom.Lock(true)
-	defer lom.Unlock(true)
-
 	delFromCloud := lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false); err == nil {
 		delFromAIS = true
@@ -1408,7 +1513,11 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 		}
 	}
 	if delFromAIS {
-		errRet = lom.Remove()
+		if undo != nil {
+			errRet = t.trash(lom, undo)
+		} else {
+			errRet = lom.Remove()
+		}
 		if errRet != nil {
 			if !os.IsNotExist(errRet) {
 				if cloudErr != nil {
@@ -1417,6 +1526,7 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 				return errRet, 0
 			}
 		}
+		t.unindexMD(lom)
 		if evict {
 			cmn.Assert(lom.Bck().IsRemote())
 			t.statsT.AddMany(
@@ -1428,9 +1538,19 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	if cloudErr != nil {
 		return cloudErr, cloudErrCode
 	}
+	if errRet == nil && !evict {
+		t.publishEvent(lom, evsink.OpDelete)
+		errRet = t.replicate(lom, replication.OpDelete)
+	}
 	return errRet, 0
 }
 
+func (t *targetrunner) publishEvent(lom *cluster.LOM, op string) {
+	if conf := &lom.Bprops().Events; conf.Enabled() {
+		t.evsink.Publish(conf, evsink.NewEvent(op, lom, t.si.ID()))
+	}
+}
+
 ///////////////////
 // RENAME OBJECT //
 ///////////////////
@@ -1476,15 +1596,125 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	if copied {
-		lom.Lock(true)
-		if err = lom.Remove(); err != nil {
-			glog.Warningf("%s: failed to delete renamed object source %s: %v", t.si, lom, err)
+	if !copied {
+		return
+	}
+	lom.Lock(true)
+	if err = lom.Remove(); err != nil {
+		glog.Warningf("%s: failed to delete renamed object source %s: %v", t.si, lom, err)
+	}
+	lom.Unlock(true)
+	if err = t.replicate(lom, replication.OpDelete); err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// (the destination object is replicated by its target unless it's this one)
+	dst := &cluster.LOM{T: t, ObjName: msg.Name}
+	if err = dst.Init(bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if si, err := cluster.HrwTarget(dst.Uname(), t.owner.smap.Get()); err == nil && si.ID() == t.si.ID() {
+		if err = t.replicate(dst, replication.OpPut); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
 		}
-		lom.Unlock(true)
 	}
 }
 
+// POST {action: pinobj, value: true|false} /v1/objects/bucket-name/object-name
+// (the pin is stored in the object's custom metadata; overwriting the object unpins it)
+func (t *targetrunner) pinObject(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	pin, ok := msg.Value.(bool)
+	if !ok {
+		t.invalmsghdlrf(w, r, "%s: invalid %s value %v (expecting bool)", t.si, msg.Action, msg.Value)
+		return
+	}
+	t.updateCustomMD(w, r, func(md cmn.SimpleKVs) bool {
+		if cmn.IsParseBool(md[cluster.PinnedObjMD]) == pin {
+			return false
+		}
+		if pin {
+			md[cluster.PinnedObjMD] = "true"
+		} else {
+			delete(md, cluster.PinnedObjMD)
+		}
+		return true
+	})
+}
+
+// POST {action: setcustommd, value: {key: value, ...}} /v1/objects/bucket-name/object-name
+// (updates the object's custom metadata in place; empty value deletes the key)
+func (t *targetrunner) setCustomMD(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	kvs := cmn.SimpleKVs{}
+	if err := cmn.MorphMarshal(msg.Value, &kvs); err != nil {
+		t.invalmsghdlrf(w, r, "%s: invalid %s value %v: %v", t.si, msg.Action, msg.Value, err)
+		return
+	}
+	for k, v := range kvs {
+		if err := cluster.ValidateObjMD(k, v); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	t.updateCustomMD(w, r, func(md cmn.SimpleKVs) bool {
+		for k, v := range kvs {
+			if v == "" {
+				delete(md, k)
+			} else {
+				md[k] = v
+			}
+		}
+		return true
+	})
+}
+
+// update custom metadata of the object (and its copies) under write lock;
+// the callback returns false if there's nothing to update
+func (t *targetrunner) updateCustomMD(w http.ResponseWriter, r *http.Request, update func(md cmn.SimpleKVs) bool) {
+	apiItems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objName := apiItems[0], apiItems[1]
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil {
+		errCode := http.StatusInternalServerError
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	md := make(cmn.SimpleKVs, len(lom.CustomMD())+1)
+	for k, v := range lom.CustomMD() {
+		md[k] = v
+	}
+	if !update(md) {
+		return
+	}
+	lom.SetCustomMD(md)
+	if err = lom.Persist(); err == nil {
+		err = lom.PersistCopies()
+	}
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.ReCache()
+	t.indexMD(lom)
+}
+
 ///////////////////////////////////////
 // PROMOTE local file(s) => objects  //
 ///////////////////////////////////////
@@ -1595,6 +1825,16 @@ func (t *targetrunner) runResilver(id string, skipGlobMisplaced bool, notifs ...
 	t.rebManager.RunResilver(id, skipGlobMisplaced, notifs...)
 }
 
+// start time of the running rebalance (zero if none) - see stats/alerts.go
+func (t *targetrunner) rebStarted() (started time.Time) {
+	if entry := registry.Registry.GetRunning(registry.XactFilter{Kind: cmn.ActRebalance}); entry != nil {
+		if xact := entry.Get(); xact != nil && !xact.Finished() {
+			started = xact.StartTime()
+		}
+	}
+	return
+}
+
 func (t *targetrunner) AbortAllXacts(tys ...string) {
 	registry.Registry.AbortAll(tys...)
 }
@@ -7,6 +7,7 @@ package integration
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"reflect"
 	"strconv"
@@ -20,9 +21,13 @@ import (
 	"github.com/NVIDIA/aistore/tutils"
 	"github.com/NVIDIA/aistore/tutils/readers"
 	"github.com/NVIDIA/aistore/tutils/tassert"
+	"github.com/NVIDIA/aistore/tutils/tdownload"
 )
 
-// NOTE: TestDownload* can fail if link, content, version changes - should be super rare!
+// NOTE: most of the TestDownload* tests download from the local mock source (see
+// tutils/tdownload) - the cluster must be deployed on the same host. The few that use
+// external Google Cloud Storage links (to test the versions and checksums reported by
+// the Cloud storage) can fail if link, content, version changes - should be super rare!
 
 const (
 	downloadDescAllPrefix = "downloader-test-integration"
@@ -228,7 +233,26 @@ func verifyProps(t *testing.T, bck cmn.Bck, objName string, size int64, version
 	return objProps
 }
 
+// addDownloadSrcObjects registers objects of the same (random) content with the local source.
+func addDownloadSrcObjects(src *tdownload.Server, size int64, objNames ...string) {
+	data := src.AddObject(objNames[0], size)
+	for _, objName := range objNames[1:] {
+		src.AddContent(objName, data)
+	}
+}
+
+// fmtObjNames returns the names a range template expands to, e.g. ("obj-%d", 1, 3) => obj-1, obj-2, obj-3
+func fmtObjNames(format string, start, end int) []string {
+	objNames := make([]string, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		objNames = append(objNames, fmt.Sprintf(format, i))
+	}
+	return objNames
+}
+
 func TestDownloadSingle(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
@@ -238,12 +262,17 @@ func TestDownloadSingle(t *testing.T) {
 		baseParams    = tutils.BaseAPIParams(proxyURL)
 		objName       = "object"
 		objNameSecond = "object-second"
+		src           = tdownload.NewServer()
 
 		// Links below don't contain protocols to test that no error occurs
 		// in case they are missing.
-		linkLarge = "storage.googleapis.com/nvdata-openimages/openimages-train-000001.tar"
-		linkSmall = "storage.googleapis.com/minikube/iso/minikube-v0.23.0.iso.sha256"
+		linkLarge = strings.TrimPrefix(src.URL("large"), "http://")
+		linkSmall = strings.TrimPrefix(src.URL("small"), "http://")
 	)
+	defer src.Close()
+	src.AddObject("large", cmn.MiB)
+	src.AddObject("small", 65)
+	src.SetThrottle(10 * cmn.KiB) // the large object keeps downloading until aborted
 
 	clearDownloadList(t)
 
@@ -294,6 +323,8 @@ func TestDownloadSingle(t *testing.T) {
 }
 
 func TestDownloadRange(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
@@ -301,16 +332,13 @@ func TestDownloadRange(t *testing.T) {
 		}
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
+		src        = tdownload.NewServer()
 
-		template        = "storage.googleapis.com/minikube/iso/minikube-v0.23.{0..4}.iso.sha256"
-		expectedObjects = []string{
-			"minikube-v0.23.0.iso.sha256",
-			"minikube-v0.23.1.iso.sha256",
-			"minikube-v0.23.2.iso.sha256",
-			"minikube-v0.23.3.iso.sha256",
-			"minikube-v0.23.4.iso.sha256",
-		}
+		template        = src.URL("object-{0..4}")
+		expectedObjects = []string{"object-0", "object-1", "object-2", "object-3", "object-4"}
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.KiB, expectedObjects...)
 
 	clearDownloadList(t)
 
@@ -327,7 +355,9 @@ func TestDownloadRange(t *testing.T) {
 	checkDownloadList(t)
 }
 
-func TestDownloadMultiRange(t *testing.T) {
+func TestDownloadCreateBucketFrom(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
@@ -335,15 +365,52 @@ func TestDownloadMultiRange(t *testing.T) {
 		}
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
+		src        = tdownload.NewServer()
 
-		template        = "storage.googleapis.com/minikube/iso/minikube-v0.{23..25..2}.{0..1}.iso.sha256"
-		expectedObjects = []string{
-			"minikube-v0.23.0.iso.sha256",
-			"minikube-v0.23.1.iso.sha256",
-			"minikube-v0.25.0.iso.sha256",
-			"minikube-v0.25.1.iso.sha256",
+		template        = src.URL("object-{0..1}")
+		expectedObjects = []string{"object-0", "object-1"}
+	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.KiB, expectedObjects...)
+
+	clearDownloadList(t)
+
+	// Invalid source: the bucket must not get created
+	_, err := api.CreateBucketFrom(baseParams, bck, downloader.DlBucketSource{Template: template, Sync: true})
+	tassert.Fatalf(t, err != nil, "expected error (sync requires Cloud source)")
+	exists, err := api.DoesBucketExist(baseParams, cmn.QueryBcks(bck))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, !exists, "bucket %s must not exist", bck)
+
+	resp, err := api.CreateBucketFrom(baseParams, bck, downloader.DlBucketSource{
+		Template:    template,
+		Description: generateDownloadDesc(),
+	})
+	tassert.CheckFatal(t, err)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	waitForDownload(t, resp.ID, 10*time.Second)
+	checkDownloadedObjects(t, resp.ID, bck, expectedObjects)
+}
+
+func TestDownloadMultiRange(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName,
+			Provider: cmn.ProviderAIS,
 		}
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		src        = tdownload.NewServer()
+
+		template        = src.URL("object-{23..25..2}.{0..1}")
+		expectedObjects = []string{"object-23.0", "object-23.1", "object-25.0", "object-25.1"}
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.KiB, expectedObjects...)
+	src.AddObject("object-24.0", cmn.KiB) // not in the range
 
 	clearDownloadList(t)
 
@@ -361,18 +428,24 @@ func TestDownloadMultiRange(t *testing.T) {
 }
 
 func TestDownloadMultiMap(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
 			Provider: cmn.ProviderAIS,
 		}
-		m = map[string]string{
-			"ais": "https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md",
-			"k8s": "https://raw.githubusercontent.com/kubernetes/kubernetes/master/README.md",
+		src = tdownload.NewServer()
+		m   = map[string]string{
+			"ais": src.URL("ais/README.md"),
+			"k8s": src.URL("k8s/README.md"),
 		}
 		expectedObjects = []string{"ais", "k8s"}
 		proxyURL        = tutils.RandomProxyURL(t)
 	)
+	defer src.Close()
+	src.AddObject("ais/README.md", 10*cmn.KiB)
+	src.AddObject("k8s/README.md", 5*cmn.KiB)
 
 	clearDownloadList(t)
 
@@ -389,19 +462,22 @@ func TestDownloadMultiMap(t *testing.T) {
 }
 
 func TestDownloadMultiList(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
 			Provider: cmn.ProviderAIS,
 		}
-		l = []string{
-			"https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md",
-			"https://raw.githubusercontent.com/kubernetes/kubernetes/master/LICENSE?query=values",
-		}
+		src          = tdownload.NewServer()
 		expectedObjs = []string{"LICENSE", "README.md"}
 		proxyURL     = tutils.RandomProxyURL(t)
 		baseParams   = tutils.BaseAPIParams(proxyURL)
 	)
+	defer src.Close()
+	src.AddObject("README.md", 10*cmn.KiB)
+	src.AddObject("LICENSE", cmn.KiB)
+	l := []string{src.URL("README.md"), src.URL("LICENSE") + "?query=values"}
 
 	clearDownloadList(t)
 
@@ -418,16 +494,21 @@ func TestDownloadMultiList(t *testing.T) {
 }
 
 func TestDownloadTimeout(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
 			Provider: cmn.ProviderAIS,
 		}
 		objName    = "object"
-		link       = "https://storage.googleapis.com/nvdata-openimages/openimages-train-000001.tar"
+		src        = tdownload.NewServer()
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
 	)
+	defer src.Close()
+	src.AddObject(objName, cmn.MiB)
+	src.SetLatency(time.Second)
 
 	clearDownloadList(t)
 
@@ -437,7 +518,7 @@ func TestDownloadTimeout(t *testing.T) {
 	body := downloader.DlSingleBody{
 		DlSingleObj: downloader.DlSingleObj{
 			ObjName: objName,
-			Link:    link,
+			Link:    src.URL(objName),
 		},
 	}
 	body.Bck = bck
@@ -468,6 +549,47 @@ func TestDownloadTimeout(t *testing.T) {
 	checkDownloadList(t)
 }
 
+func TestDownloadRetry(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName,
+			Provider: cmn.ProviderAIS,
+		}
+		objFailed  = "failed"
+		objAborted = "aborted"
+		objSize    = int64(cmn.MiB)
+		src        = tdownload.NewServer()
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+	)
+	defer src.Close()
+	src.AddObject(objFailed, objSize)
+	src.FailFirst(objFailed, 3, http.StatusServiceUnavailable)
+	src.AddObject(objAborted, objSize)
+	src.AbortAfter(objAborted, 2, objSize/2)
+
+	clearDownloadList(t)
+
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	id, err := api.DownloadMulti(baseParams, generateDownloadDesc(), bck, []string{src.URL(objFailed), src.URL(objAborted)})
+	tassert.CheckFatal(t, err)
+
+	waitForDownload(t, id, 30*time.Second)
+	checkDownloadedObjects(t, id, bck, []string{objAborted, objFailed})
+
+	for _, objName := range []string{objFailed, objAborted} {
+		verifyProps(t, bck, objName, objSize, "1")
+	}
+	tassert.Errorf(t, src.Requests(objFailed) >= 4, "expected at least 4 requests, got %d", src.Requests(objFailed))
+	tassert.Errorf(t, src.Requests(objAborted) >= 3, "expected at least 3 requests, got %d", src.Requests(objAborted))
+
+	checkDownloadList(t)
+}
+
 func TestDownloadCloud(t *testing.T) {
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
@@ -596,6 +718,8 @@ func TestDownloadCloud(t *testing.T) {
 }
 
 func TestDownloadStatus(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
@@ -603,7 +727,9 @@ func TestDownloadStatus(t *testing.T) {
 		}
 		baseParams = tutils.BaseAPIParams()
 		m          = ioContext{t: t}
+		src        = tdownload.NewServer()
 	)
+	defer src.Close()
 
 	m.saveClusterState()
 	if m.originalTargetCount < 2 {
@@ -616,9 +742,12 @@ func TestDownloadStatus(t *testing.T) {
 		longFileName  = tutils.GenerateNotConflictingObjectName(shortFileName, "longFile", bck, m.smap)
 	)
 
+	src.AddObject(shortFileName, cmn.KiB)
+	src.AddObject(longFileName, cmn.MiB)
+	src.SetThrottle(10 * cmn.KiB) // the long file keeps downloading until aborted
 	files := map[string]string{
-		shortFileName: "https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md",
-		longFileName:  "https://storage.googleapis.com/nvdata-openimages/openimages-train-000001.tar",
+		shortFileName: src.URL(shortFileName),
+		longFileName:  src.URL(longFileName),
 	}
 
 	clearDownloadList(t)
@@ -650,21 +779,23 @@ func TestDownloadStatus(t *testing.T) {
 }
 
 func TestDownloadStatusError(t *testing.T) {
-	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true, Local: true})
 
 	var (
 		bck = cmn.Bck{
 			Name:     TestBucketName,
 			Provider: cmn.ProviderAIS,
 		}
+		src   = tdownload.NewServer()
 		files = map[string]string{
-			"invalidURL":   "http://some.invalid.url",
-			"notFoundFile": "https://google.com/404.tar",
+			"invalidURL":   "http://some.invalid", // reserved top-level domain (RFC 2606)
+			"notFoundFile": src.URL("404.tar"),
 		}
 
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
 	)
+	defer src.Close()
 
 	clearDownloadList(t)
 
@@ -700,6 +831,8 @@ func TestDownloadStatusError(t *testing.T) {
 }
 
 func TestDownloadSingleValidExternalAndInternalChecksum(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -710,12 +843,16 @@ func TestDownloadSingleValidExternalAndInternalChecksum(t *testing.T) {
 		}
 		objNameFirst  = "object-first"
 		objNameSecond = "object-second"
+		src           = tdownload.NewServer()
 
-		linkFirst  = "https://storage.googleapis.com/minikube/iso/minikube-v0.23.2.iso.sha256"
-		linkSecond = "https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md"
+		linkFirst  = src.URL("first")
+		linkSecond = src.URL("README.md")
 
 		expectedObjects = []string{objNameFirst, objNameSecond}
 	)
+	defer src.Close()
+	src.AddObject("first", 65)
+	src.AddObject("README.md", 10*cmn.KiB)
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
@@ -733,13 +870,14 @@ func TestDownloadSingleValidExternalAndInternalChecksum(t *testing.T) {
 	waitForDownload(t, id, 10*time.Second)
 	waitForDownload(t, id2, 10*time.Second)
 
-	// If the file was successfully downloaded, it means that the external checksum was correct. Also because of the
-	// ValidateWarmGet property being set to True, if it was downloaded without errors then the internal checksum was
-	// also set properly
+	// Because of the ValidateWarmGet property being set to True, if the files were downloaded
+	// without errors then the internal checksum was set properly
 	tutils.EnsureObjectsExist(t, baseParams, bck, expectedObjects...)
 }
 
 func TestDownloadMultiValidExternalAndInternalChecksum(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -750,14 +888,18 @@ func TestDownloadMultiValidExternalAndInternalChecksum(t *testing.T) {
 		}
 		objNameFirst  = "linkFirst"
 		objNameSecond = "linkSecond"
+		src           = tdownload.NewServer()
 
 		m = map[string]string{
-			"linkFirst":  "https://storage.googleapis.com/minikube/iso/minikube-v0.23.2.iso.sha256",
-			"linkSecond": "https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md",
+			"linkFirst":  src.URL("first"),
+			"linkSecond": src.URL("README.md"),
 		}
 
 		expectedObjects = []string{objNameFirst, objNameSecond}
 	)
+	defer src.Close()
+	src.AddObject("first", 65)
+	src.AddObject("README.md", 10*cmn.KiB)
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
@@ -777,7 +919,7 @@ func TestDownloadMultiValidExternalAndInternalChecksum(t *testing.T) {
 }
 
 func TestDownloadRangeValidExternalAndInternalChecksum(t *testing.T) {
-	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true, Local: true})
 
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
@@ -787,14 +929,13 @@ func TestDownloadRangeValidExternalAndInternalChecksum(t *testing.T) {
 			Name:     TestBucketName,
 			Provider: cmn.ProviderAIS,
 		}
+		src = tdownload.NewServer()
 
-		template        = "storage.googleapis.com/minikube/iso/minikube-v0.{21..25..2}.0.iso.sha256"
-		expectedObjects = []string{
-			"minikube-v0.21.0.iso.sha256",
-			"minikube-v0.23.0.iso.sha256",
-			"minikube-v0.25.0.iso.sha256",
-		}
+		template        = src.URL("object-{21..25..2}.0")
+		expectedObjects = []string{"object-21.0", "object-23.0", "object-25.0"}
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.KiB, expectedObjects...)
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
@@ -817,7 +958,7 @@ func TestDownloadIntoNonexistentBucket(t *testing.T) {
 	var (
 		baseParams = tutils.BaseAPIParams()
 		objName    = "object"
-		obj        = "storage.googleapis.com/nvdata-openimages/openimages-train-000001.tar"
+		obj        = "localhost/object" // never requested: the bucket does not exist
 	)
 
 	bucket, err := tutils.GenerateNonexistentBucketName("download", baseParams)
@@ -834,6 +975,8 @@ func TestDownloadIntoNonexistentBucket(t *testing.T) {
 }
 
 func TestDownloadMpathEvents(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -842,17 +985,22 @@ func TestDownloadMpathEvents(t *testing.T) {
 			Provider: cmn.ProviderAIS,
 		}
 		objsCnt = 100
+		src     = tdownload.NewServer()
 
-		template = "storage.googleapis.com/nvdata-openimages/openimages-train-{000000..000050}.tar"
+		template = src.URL("large-{000000..000050}")
 		m        = make(map[string]string, objsCnt)
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.MiB, fmtObjNames("large-%06d", 0, 50)...)
+	src.AddObject("README.md", cmn.KiB)
+	src.SetThrottle(10 * cmn.KiB) // the large objects keep downloading until aborted
 
 	clearDownloadList(t)
 
 	// Prepare objects to be downloaded to targets. Multiple objects to make
 	// sure that at least one of them gets into target with disabled mountpath.
 	for i := 0; i < objsCnt; i++ {
-		m[strconv.FormatInt(int64(i), 10)] = "https://raw.githubusercontent.com/NVIDIA/aistore/master/README.md"
+		m[strconv.FormatInt(int64(i), 10)] = src.URL("README.md")
 	}
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
@@ -950,6 +1098,8 @@ func TestDownloadOverrideObject(t *testing.T) {
 }
 
 func TestDownloadOverrideObjectWeb(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -960,11 +1110,14 @@ func TestDownloadOverrideObjectWeb(t *testing.T) {
 		p = cmn.DefaultAISBckProps()
 
 		objName = cmn.RandString(10)
-		link    = "https://raw.githubusercontent.com/NVIDIA/aistore/master/LICENSE"
+		src     = tdownload.NewServer()
+		link    = src.URL("LICENSE")
 
 		expectedSize int64 = 1075
 		newSize      int64 = 10
 	)
+	defer src.Close()
+	src.AddObject("LICENSE", expectedSize)
 
 	clearDownloadList(t)
 
@@ -1168,7 +1321,7 @@ func TestDownloadSync(t *testing.T) {
 }
 
 func TestDownloadJobLimitConnections(t *testing.T) {
-	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true, Local: true})
 
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
@@ -1177,9 +1330,13 @@ func TestDownloadJobLimitConnections(t *testing.T) {
 			Name:     cmn.RandString(10),
 			Provider: cmn.ProviderAIS,
 		}
+		src = tdownload.NewServer()
 
-		template = "https://storage.googleapis.com/minikube/iso/minikube-v0.{18..35}.0.iso"
+		template = src.URL("object-{18..35}")
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.MiB, fmtObjNames("object-%d", 18, 35)...)
+	src.SetThrottle(10 * cmn.KiB) // the objects keep downloading until aborted
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
@@ -1218,6 +1375,8 @@ func TestDownloadJobLimitConnections(t *testing.T) {
 }
 
 func TestDownloadJobConcurrency(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Local: true})
+
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -1225,9 +1384,13 @@ func TestDownloadJobConcurrency(t *testing.T) {
 			Name:     cmn.RandString(10),
 			Provider: cmn.ProviderAIS,
 		}
+		src = tdownload.NewServer()
 
-		template = "https://storage.googleapis.com/minikube/iso/minikube-v0.{18..35}.0.iso"
+		template = src.URL("object-{18..35}")
 	)
+	defer src.Close()
+	addDownloadSrcObjects(src, cmn.MiB, fmtObjNames("object-%d", 18, 35)...)
+	src.SetThrottle(10 * cmn.KiB) // the objects keep downloading until aborted
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
@@ -1282,13 +1445,10 @@ func TestDownloadJobConcurrency(t *testing.T) {
 	tutils.Logln("done waiting")
 }
 
-// NOTE: Test may fail if the network is SUPER slow!!
 func TestDownloadJobBytesThrottling(t *testing.T) {
-	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true, Local: true})
 
 	const (
-		link = "https://storage.googleapis.com/minikube/iso/minikube-v0.35.0.iso"
-
 		// Bytes per hour limit.
 		softLimit = 5 * cmn.KiB
 		// Downloader could potentially download a little bit more but should
@@ -1303,7 +1463,11 @@ func TestDownloadJobBytesThrottling(t *testing.T) {
 			Name:     cmn.RandString(10),
 			Provider: cmn.ProviderAIS,
 		}
+		src  = tdownload.NewServer()
+		link = src.URL("object")
 	)
+	defer src.Close()
+	src.AddObject("object", cmn.MiB)
 
 	tutils.CreateFreshBucket(t, proxyURL, bck)
 	defer tutils.DestroyBucket(t, proxyURL, bck)
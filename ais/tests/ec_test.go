@@ -1885,6 +1885,54 @@ func TestECEmergencyTargetForReplica(t *testing.T) {
 	clearAllECObjects(t, bck, false, o)
 }
 
+// Kills (as opposed to unregisters) the main target of an erasure coded object
+// and reads the object with EC restore enabled - before the cluster map gets updated.
+func TestECGetKilledTarget(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName + "-get-killed",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+	)
+
+	o := ecOptions{
+		minTargets:  4,
+		dataCnt:     -1,
+		objCount:    1,
+		concurrency: 1,
+		pattern:     "obj-get-killed-%04d",
+	}.init(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	objName := fmt.Sprintf(o.pattern, 1) // not a small one (see randObjectSize)
+	createECObject(t, baseParams, bck, objName, 1, o)
+
+	objPath := ecTestDir + objName
+	cbck := cluster.NewBck(bck.Name, bck.Provider, cmn.NsGlobal)
+	mainTarget, err := cluster.HrwTarget(cbck.MakeUname(objPath), o.smap)
+	tassert.CheckFatal(t, err)
+
+	tutils.Logf("Killing %s (main target of %s)\n", mainTarget, objPath)
+	cmd, err := kill(mainTarget)
+	tassert.CheckFatal(t, err)
+	defer func() {
+		tassert.CheckError(t, restore(cmd, false, "target"))
+		tutils.WaitNodeRestored(t, proxyURL, "to restore", mainTarget.ID(), o.smap.Version,
+			testing.Verbose(), o.smap.CountProxies(), o.smap.CountTargets())
+		tutils.WaitForRebalanceToComplete(t, baseParams, rebalanceTimeout)
+	}()
+
+	n, err := api.GetObject(baseParams, bck, objPath, api.GetObjectInput{AllowECRestore: true})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n > 0, "expected non-empty %s", objPath)
+}
+
 // Lost mountpah test:
 // - puts some objects
 // - disable a random mountpath
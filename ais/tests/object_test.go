@@ -226,6 +226,63 @@ func TestAppendObject(t *testing.T) {
 	}
 }
 
+func TestMultipartUpload(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{
+			Name:     TestBucketName,
+			Provider: cmn.ProviderAIS,
+		}
+		objName = "test/mpt"
+		content = bytes.Repeat([]byte("0123456789"), 100*cmn.KiB)
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	err := api.PutObjectMultipart(api.MptPutArgs{
+		BaseParams: baseParams,
+		Bck:        bck,
+		Object:     objName,
+		Reader:     bytes.NewReader(content),
+		Size:       int64(len(content)),
+		PartSize:   100 * cmn.KiB,
+		Workers:    3,
+	})
+	tassert.CheckFatal(t, err)
+
+	writer := bytes.NewBuffer(nil)
+	_, err = api.GetObjectWithValidation(baseParams, bck, objName, api.GetObjectInput{Writer: writer})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(writer.Bytes(), content), "invalid object content (size %d, expected %d)",
+		writer.Len(), len(content))
+
+	// the part with the wrong checksum is rejected; an aborted upload is gone
+	uploadID, err := api.CreateMultipartUpload(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	_, err = api.UploadPart(api.UploadPartArgs{
+		BaseParams: baseParams,
+		Bck:        bck,
+		Object:     objName,
+		UploadID:   uploadID,
+		PartNum:    1,
+		Reader:     cmn.NewByteHandle(content[:100]),
+		Size:       100,
+		Cksum:      cmn.NewCksum(cmn.ChecksumXXHash, "0123456789abcdef"),
+	})
+	tassert.Errorf(t, err != nil, "expected checksum mismatch")
+	err = api.AbortMultipartUpload(baseParams, bck, objName, uploadID)
+	tassert.CheckFatal(t, err)
+	err = api.CompleteMultipartUpload(api.CompleteMptArgs{
+		BaseParams: baseParams,
+		Bck:        bck,
+		Object:     objName,
+		UploadID:   uploadID,
+		Parts:      []cmn.MptPart{{Num: 1}},
+	})
+	tassert.Errorf(t, err != nil, "expected completing aborted upload to fail")
+}
+
 // PUT, then delete
 func Test_putdelete(t *testing.T) {
 	const fileSize = 512 * cmn.KiB
@@ -910,6 +967,36 @@ func TestHeadBucket(t *testing.T) {
 	validateBucketProps(t, bckPropsToUpdate, p)
 }
 
+func TestHeadObjects(t *testing.T) {
+	m := ioContext{
+		t:        t,
+		num:      100,
+		fileSize: cmn.KiB,
+	}
+	m.init()
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+	m.puts()
+
+	var (
+		baseParams = tutils.BaseAPIParams(m.proxyURL)
+		names      = append([]string{"nonexistent"}, m.objNames...)
+	)
+	entries, err := api.HeadObjects(baseParams, m.bck, names)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == len(names), "expected %d entries, got %d", len(names), len(entries))
+	for i, entry := range entries {
+		tassert.Errorf(t, entry.Name == names[i], "expected %q, got %q", names[i], entry.Name)
+		if i == 0 {
+			tassert.Errorf(t, !entry.Exists && !entry.Present, "%q must not exist", entry.Name)
+			continue
+		}
+		tassert.Errorf(t, entry.Exists && entry.Present, "%q must exist", entry.Name)
+		tassert.Errorf(t, entry.Size == int64(m.fileSize), "%q: expected size %d, got %d",
+			entry.Name, m.fileSize, entry.Size)
+	}
+}
+
 func TestHeadCloudBucket(t *testing.T) {
 	var (
 		proxyURL   = tutils.RandomProxyURL(t)
@@ -605,6 +605,73 @@ func TestGetClusterStats(t *testing.T) {
 	}
 }
 
+func TestCloudHealth(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Cloud: true, Bck: cliBck})
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		smap       = tutils.GetClusterMap(t, proxyURL)
+	)
+	results, err := api.GetCloudHealth(baseParams, cliBck)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(results) == smap.CountTargets(), "expected results from %d targets, got %d",
+		smap.CountTargets(), len(results))
+	for tid, health := range results {
+		var probed bool
+		for _, res := range health {
+			tassert.Errorf(t, res.Err == "", "%s: %s: %s", tid, res.Provider, res.Err)
+			if res.Provider == cliBck.Provider {
+				probed = true
+				tassert.Errorf(t, res.Bck == cliBck.String(), "%s: expected %s to be probed, got %q", tid, cliBck, res.Bck)
+			}
+		}
+		tassert.Errorf(t, probed, "%s: provider %q not probed", tid, cliBck.Provider)
+	}
+}
+
+func TestCapacityForecast(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		smap       = tutils.GetClusterMap(t, proxyURL)
+	)
+	forecast, err := api.GetCapacityForecast(baseParams)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(forecast.Targets) == smap.CountTargets(), "expected forecasts from %d targets, got %d",
+		smap.CountTargets(), len(forecast.Targets))
+	var used, total uint64
+	for tid, f := range forecast.Targets {
+		// every target takes a sample at startup
+		tassert.Errorf(t, f.Samples > 0, "%s: no capacity samples", tid)
+		tassert.Errorf(t, f.Total > 0 && f.Used <= f.Total, "%s: invalid capacity %d/%d", tid, f.Used, f.Total)
+		used += f.Used
+		total += f.Total
+	}
+	tassert.Errorf(t, forecast.Cluster.Used == used && forecast.Cluster.Total == total,
+		"cluster capacity %d/%d differs from the sum over targets %d/%d",
+		forecast.Cluster.Used, forecast.Cluster.Total, used, total)
+}
+
+func TestPingMatrix(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		smap       = tutils.GetClusterMap(t, proxyURL)
+		cnt        = smap.CountTargets() + smap.CountProxies()
+	)
+	matrix, err := api.GetPingMatrix(baseParams)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(matrix) == cnt, "expected results from %d nodes, got %d", cnt, len(matrix))
+	for from, pings := range matrix {
+		tassert.Errorf(t, len(pings) == cnt-1, "%s: expected %d pings, got %d", from, cnt-1, len(pings))
+		for to, ping := range pings {
+			tassert.Errorf(t, from != to, "%s: unexpected self-ping", from)
+			tassert.Errorf(t, ping.Err == "", "%s => %s: %s", from, to, ping.Err)
+			tassert.Errorf(t, ping.Control > 0, "%s => %s: invalid latency %v", from, to, ping.Control)
+		}
+	}
+}
+
 func TestConfig(t *testing.T) {
 	oconfig := tutils.GetClusterConfig(t)
 	olruconfig := oconfig.LRU
@@ -0,0 +1,58 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Admission control: target rejects new user PUT and append requests early -
+// prior to reading the request body - when it is out of space (see fs.CapStatus)
+// or swapping (see memsys.MMSA.Swapping). Otherwise, it would accept the work
+// only to fail mid-stream and leave workfiles behind. Rejected requests get
+// 503 Service Unavailable with the Retry-After header and are counted as
+// `put.reject.n`. Intra-cluster PUTs (replication, export, copy, prefill) are
+// not subject to admission - failing those would only stall the respective
+// (already running) xactions.
+
+const (
+	retryAfterOOS      = 30 * time.Second // LRU to free up space
+	retryAfterSwapping = 10 * time.Second // memsys to calm down (see SwappingMax)
+)
+
+func (t *targetrunner) admitPut(w http.ResponseWriter, r *http.Request, cs fs.CapStatus) bool {
+	var (
+		err        error
+		retryAfter time.Duration
+	)
+	if isIntraPut(r.Header) {
+		return true
+	}
+	switch {
+	case cs.OOS:
+		err, retryAfter = cs.Err, retryAfterOOS
+	case t.gmm.Swapping.Load() > 0:
+		err = fmt.Errorf("%s: swapping (memory pressure %q)", t.si, memsys.MemPressureText(memsys.OOM))
+		retryAfter = retryAfterSwapping
+	default:
+		return true
+	}
+	t.statsT.Add(stats.PutRejectCount, 1)
+	if n := t.statsT.Get(stats.PutRejectCount); n%100 == 1 { // throttle logging
+		glog.Errorf("%v - rejecting %s requests (total rejected: %d)", err, r.Method, n)
+	}
+	w.Header().Set(cmn.HeaderRetryAfter, strconv.Itoa(int(retryAfter/time.Second)))
+	t.invalmsghdlrsilent(w, r, err.Error(), http.StatusServiceUnavailable)
+	return false
+}
@@ -0,0 +1,67 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+type admitTrackerMock struct {
+	stats.TrackerMock
+	counters map[string]int64
+}
+
+func (m *admitTrackerMock) Add(name string, val int64) { m.counters[name] += val }
+func (m *admitTrackerMock) Get(name string) int64      { return m.counters[name] }
+
+func TestAdmitPut(t *testing.T) {
+	var (
+		tracker = &admitTrackerMock{counters: make(map[string]int64)}
+		tgt     = &targetrunner{gmm: &memsys.MMSA{}}
+		oos     = fs.CapStatus{OOS: true, Err: errors.New("out of space")}
+	)
+	tgt.si = &cluster.Snode{DaemonID: "target"}
+	tgt.statsT = tracker
+
+	admit := func(cs fs.CapStatus, intra bool) (bool, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/v1/objects/bck/obj", nil)
+		if intra {
+			r.Header.Set(cmn.HeaderPutterID, "another-target")
+		}
+		return tgt.admitPut(w, r, cs), w
+	}
+
+	if ok, _ := admit(fs.CapStatus{}, false); !ok {
+		t.Fatal("expected user PUT to be admitted")
+	}
+	ok, w := admit(oos, false)
+	if ok || w.Code != http.StatusServiceUnavailable || w.Header().Get(cmn.HeaderRetryAfter) == "" {
+		t.Fatalf("expected user PUT to be rejected with 503 and Retry-After, got %t, %d", ok, w.Code)
+	}
+	if ok, _ := admit(oos, true); !ok {
+		t.Fatal("expected intra-cluster PUT to be admitted")
+	}
+
+	tgt.gmm.Swapping.Store(memsys.SwappingMax)
+	if ok, _ := admit(fs.CapStatus{}, false); ok {
+		t.Fatal("expected user PUT to be rejected when swapping")
+	}
+	if ok, _ := admit(fs.CapStatus{}, true); !ok {
+		t.Fatal("expected intra-cluster PUT to be admitted when swapping")
+	}
+	if n := tracker.Get(stats.PutRejectCount); n != 2 {
+		t.Errorf("expected 2 rejected PUTs, got %d", n)
+	}
+}
@@ -95,7 +95,18 @@ func (t *targetrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	ok = t.doAsync(w, r, actionMsg.Action, bck, &msg)
+	ok = t.doAsync(w, r, actionMsg.Action, bck, msg.UUID, &msg)
+	return
+}
+
+func (t *targetrunner) bucketAnalysis(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, actionMsg *aisMsg) (ok bool) {
+	var msg cmn.BucketAnalysisMsg
+	if err := cmn.MorphMarshal(actionMsg.Value, &msg); err != nil {
+		err := fmt.Errorf("unable to unmarshal 'value' in request to a cmn.BucketAnalysisMsg: %v", actionMsg.Value)
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	ok = t.doAsync(w, r, actionMsg.Action, bck, msg.UUID, &msg)
 	return
 }
 
@@ -104,7 +115,7 @@ func (t *targetrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 // - returns status of a running task by its ID
 // - returns the result of a task by its ID
 func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action string,
-	bck *cluster.Bck, msg *cmn.BucketSummaryMsg) bool {
+	bck *cluster.Bck, uuid string, msg interface{}) bool {
 	var (
 		query      = r.URL.Query()
 		taskAction = query.Get(cmn.URLParamTaskAction)
@@ -119,7 +130,9 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 
 		switch action {
 		case cmn.ActSummaryBucket:
-			_, err = registry.Registry.RenewBckSummary(ctx, t, bck, msg)
+			_, err = registry.Registry.RenewBckSummary(ctx, t, bck, msg.(*cmn.BucketSummaryMsg))
+		case cmn.ActAnalyzeBucket:
+			_, err = registry.Registry.RenewBckAnalysis(t, bck, msg.(*cmn.BucketAnalysisMsg))
 		default:
 			t.invalmsghdlrf(w, r, "invalid action: %s", action)
 			return false
@@ -134,10 +147,10 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 		return true
 	}
 
-	xact := registry.Registry.GetXact(msg.UUID)
+	xact := registry.Registry.GetXact(uuid)
 	// task never started
 	if xact == nil {
-		s := fmt.Sprintf("Task %s not found", msg.UUID)
+		s := fmt.Sprintf("Task %s not found", uuid)
 		if silent {
 			t.invalmsghdlrsilent(w, r, s, http.StatusNotFound)
 		} else {
@@ -0,0 +1,109 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+	"github.com/NVIDIA/aistore/xaction/runners"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// backup ais bucket to Cloud (or remote AIS) and restore it from there -
+// see xaction/runners/backup.go
+func (t *targetrunner) startBackup(xactMsg *xaction.XactReqMsg, bck *cluster.Bck) error {
+	if xactMsg.Backup == nil {
+		return fmt.Errorf("%q: backup location not specified", xactMsg)
+	}
+	if !bck.IsAIS() {
+		return fmt.Errorf("%q: %s is not an ais bucket", xactMsg, bck)
+	}
+	cloud, err := t.backupCloud(&xactMsg.Backup.Cloud)
+	if err != nil {
+		return err
+	}
+	args := &registry.BackupArgs{Bck: bck, Cloud: cloud, Prefix: xactMsg.Backup.Prefix, Confdir: cmn.GCO.Get().Confdir}
+	xact, err := registry.Registry.RenewBackup(t, xactMsg.ID, xactMsg.Kind, args)
+	if err != nil {
+		return err
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{
+			When: cluster.UponTerm,
+			Dsts: []string{equalIC},
+			F:    t.callerNotifyFin,
+		},
+	})
+	go xact.Run()
+	return nil
+}
+
+func (t *targetrunner) backupCloud(bck *cmn.Bck) (*cluster.Bck, error) {
+	cloud := cluster.NewBckEmbed(*bck)
+	if err := cloud.Init(t.owner.bmd, t.si); err != nil {
+		return nil, err
+	}
+	if !cloud.IsCloud() && !cloud.IsRemoteAIS() {
+		return nil, fmt.Errorf("backup location %s must be a Cloud or remote AIS bucket", cloud)
+	}
+	return cloud, nil
+}
+
+// backupMeta reads the metadata of the bucket backup stored at a given location
+// (query: cmn.URLParamBackup) - the primary uses it to recreate the bucket
+func (t *targetrunner) backupMeta(query url.Values) (*xaction.BackupMeta, error) {
+	bck, prefix, err := cmn.ParseBckObjectURI(query.Get(cmn.URLParamBackup))
+	if err != nil {
+		return nil, err
+	}
+	cloud, err := t.backupCloud(&bck)
+	if err != nil {
+		return nil, err
+	}
+	lom := &cluster.LOM{T: t, ObjName: runners.BackupMetaName(prefix)}
+	if err := lom.Init(cloud.Bck); err != nil {
+		return nil, err
+	}
+	reader, _, err, _ := t.Cloud(cloud).GetObjReader(context.Background(), lom)
+	if err != nil {
+		return nil, fmt.Errorf("no backup at %s/%s: %v", cloud, prefix, err)
+	}
+	defer reader.Close()
+	meta := &xaction.BackupMeta{}
+	if err := jsoniter.NewDecoder(reader).Decode(meta); err != nil {
+		return nil, fmt.Errorf("invalid backup metadata at %s/%s: %v", cloud, prefix, err)
+	}
+	if meta.Props == nil {
+		return nil, fmt.Errorf("invalid backup metadata at %s/%s: missing bucket props", cloud, prefix)
+	}
+	return meta, nil
+}
+
+// putBackupMeta stores the metadata of the bucket backup that has successfully
+// completed on all targets - upon request from the primary (see backupDone)
+func (t *targetrunner) putBackupMeta(query url.Values, msg *cmn.ActionMsg) error {
+	meta := &xaction.BackupMeta{}
+	if err := cmn.MorphMarshal(msg.Value, meta); err != nil {
+		return err
+	}
+	bck, prefix, err := cmn.ParseBckObjectURI(query.Get(cmn.URLParamBackup))
+	if err != nil {
+		return err
+	}
+	cloud, err := t.backupCloud(&bck)
+	if err != nil {
+		return err
+	}
+	body := cmn.MustMarshal(meta)
+	return runners.BackupUpload(t, cloud, bytes.NewReader(body), int64(len(body)), runners.BackupMetaName(prefix))
+}
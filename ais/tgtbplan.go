@@ -0,0 +1,68 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+// POST {action: planbprops, value: nprops} /v1/buckets/bucket-name (proxy => target)
+// Estimates this target's share of the work to change the bucket's props to
+// the new (complete) ones - see cmn.BpropsTargetStats.Estimate.
+func (t *targetrunner) planBprops(w http.ResponseWriter, r *http.Request, msg *aisMsg, bck *cluster.Bck) {
+	nprops := &cmn.BucketProps{}
+	if err := cmn.MorphMarshal(msg.Value, nprops); err != nil {
+		t.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	ts, err := t.bpropsTargetStats(bck)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	t.writeJSON(w, r, ts.Estimate(bck.Props, nprops), "planbprops")
+}
+
+// the bucket's size is computed the same way as the fast bucket summary does
+func (t *targetrunner) bpropsTargetStats(bck *cluster.Bck) (ts *cmn.BpropsTargetStats, err error) {
+	config := cmn.GCO.Get()
+	cs, err := fs.RefreshCapStatus(config, nil)
+	if err != nil {
+		return nil, err
+	}
+	ts = &cmn.BpropsTargetStats{
+		Used:   cs.TotalUsed,
+		Total:  cs.TotalUsed + cs.TotalAvail,
+		HighWM: config.LRU.HighWM,
+	}
+	availablePaths, _ := fs.Get()
+	for _, mpathInfo := range availablePaths {
+		path := mpathInfo.MakePathCT(bck.Bck, fs.ObjectType)
+		size, err := ios.GetDirSize(path)
+		if err != nil {
+			return nil, err
+		}
+		cnt, err := ios.GetFileCount(path)
+		if err != nil {
+			return nil, err
+		}
+		ts.Size += size
+		ts.ObjCount += uint64(cnt)
+	}
+	if bck.Props.Mirror.Enabled && bck.Props.Mirror.Copies > 1 {
+		copies := uint64(bck.Props.Mirror.Copies)
+		ts.Size /= copies
+		ts.ObjCount /= copies
+	}
+	for _, ds := range fs.GetSelectedDiskStats() {
+		ts.Throughput += uint64(ds.RBps + ds.WBps)
+	}
+	return ts, nil
+}
@@ -170,8 +170,24 @@ func (t *targetrunner) daeputJSON(w http.ResponseWriter, r *http.Request) {
 		if err := t.setConfig(kvs); err != nil {
 			t.invalmsghdlr(w, r, err.Error())
 		}
+	case cmn.ActSetLogLevel:
+		if err := setLogLevel(msg.Value); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+		}
 	case cmn.ActShutdown:
 		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	case cmn.ActSwitchover:
+		// the shadow is taking over: shut down but stay in the Smap, so that the
+		// cluster sees a single (re)join of the same node
+		glog.Infof("%s: switching over to the shadow", t.si)
+		t.switchedOver.Store(true)
+		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	case cmn.ActUndoDelete:
+		t.undoDeleteHandler(w, r, &msg)
+	case cmn.ActBackupBck:
+		if err := t.putBackupMeta(r.URL.Query(), &msg); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+		}
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -327,9 +343,31 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 			TStatus:     &stats.TargetStatus{RebalanceStats: rebStats},
 		}
 		t.writeJSON(w, r, msg, httpdaeWhat)
+	case cmn.GetWhatAlerts:
+		t.writeJSON(w, r, getstorstatsrunner().Alerts(), httpdaeWhat)
+	case cmn.GetWhatPrefill:
+		t.writeJSON(w, r, t.prefillStatus(), httpdaeWhat)
 	case cmn.GetWhatDiskStats:
 		diskStats := fs.GetSelectedDiskStats()
 		t.writeJSON(w, r, diskStats, httpdaeWhat)
+	case cmn.GetWhatCloudHealth:
+		results, err := t.cloudHealth(r.URL.Query())
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		t.writeJSON(w, r, results, httpdaeWhat)
+	case cmn.GetWhatUndoBck:
+		t.undoBckHandler(w, r)
+	case cmn.GetWhatBackupMeta:
+		meta, err := t.backupMeta(r.URL.Query())
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		t.writeJSON(w, r, meta, httpdaeWhat)
+	case cmn.GetWhatCapForecast:
+		t.writeJSON(w, r, getstorstatsrunner().CapForecast(), httpdaeWhat)
 	case cmn.GetWhatRemoteAIS:
 		conf, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAIS)
 		if !ok {
@@ -594,8 +632,9 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 	bmd = t.owner.bmd.get()
 	curVer = bmd.version()
 	var (
-		bcksToDelete = make([]*cluster.Bck, 0, 4)
-		_, psi       = t.getPrimaryURLAndSI()
+		bcksToDelete  = make([]*cluster.Bck, 0, 4)
+		bcksToUnindex []*cluster.Bck // see cmn.MDIndexConf
+		_, psi        = t.getPrimaryURLAndSI()
 	)
 	if err = bmd.validateUUID(newBMD, t.si, psi, ""); err != nil {
 		t.owner.bmd.Unlock()
@@ -649,10 +688,17 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 			if obck.Props.EC.Enabled && !nbck.Props.EC.Enabled {
 				registry.Registry.DoAbort(cmn.ActECEncode, nbck)
 			}
+			if obck.Props.MDIndex.Enabled && (!nbck.Props.MDIndex.Enabled || obck.Props.BID != nbck.Props.BID) {
+				registry.Registry.DoAbort(cmn.ActMDIndex, nbck)
+				bcksToUnindex = append(bcksToUnindex, obck)
+			}
 			return true
 		})
 		if !present {
 			bcksToDelete = append(bcksToDelete, obck)
+			if obck.Props.MDIndex.Enabled {
+				bcksToUnindex = append(bcksToUnindex, obck)
+			}
 			// TODO: revisit error handling
 			if err := fs.DestroyBuckets("recv-bmd-"+msg.Action, obck.Bck); err != nil {
 				destroyErrs = err.Error()
@@ -668,6 +714,14 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 		glog.Errorf("%s: %s - destroy err: %s", t.si, failed, destroyErrs)
 	}
 
+	if len(bcksToUnindex) > 0 {
+		go func(bcks ...*cluster.Bck) {
+			for _, b := range bcks {
+				t.dropMDIndex(b)
+			}
+		}(bcksToUnindex...)
+	}
+
 	// evict LOM cache
 	if len(bcksToDelete) > 0 {
 		registry.Registry.AbortAllBuckets(bcksToDelete...)
@@ -942,9 +996,8 @@ func (t *targetrunner) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 
 // PUT /v1/metasync
 func (t *targetrunner) metasyncHandlerPut(w http.ResponseWriter, r *http.Request) {
-	payload := make(msPayload)
-	if err := jsp.Decode(r.Body, &payload, jspMetasyncOpts, "metasync put"); err != nil {
-		cmn.InvalidHandlerDetailed(w, r, err.Error())
+	payload, body, ok := t.decodeMetasync(w, r)
+	if !ok {
 		return
 	}
 
@@ -996,6 +1049,10 @@ func (t *targetrunner) metasyncHandlerPut(w http.ResponseWriter, r *http.Request
 		t.authn.updateRevokedList(revokedTokens)
 	}
 
+	if body != nil {
+		t.gossipRelay(w, r, body, errs)
+		return
+	}
 	if len(errs) > 0 {
 		t.invalmsghdlrf(w, r, "%v", errs)
 		return
@@ -104,7 +104,11 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		debug.AssertNoErr(payload.Validate(false /*requireID*/))
 
-		if payload.ID != "" {
+		if payload.ID != "" && payload.Logs {
+			response, respErr, statusCode = downloaderXact.JobLogs(payload.ID)
+		} else if payload.ID != "" && payload.ObjName != "" {
+			response, respErr, statusCode = downloaderXact.ObjStatus(payload.ID, payload.ObjName)
+		} else if payload.ID != "" {
 			if glog.FastV(4, glog.SmoduleAIS) {
 				glog.Infof("Getting status of download: %v", payload)
 			}
@@ -143,10 +147,15 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 				glog.Infof("Removing download: %v", payload)
 			}
 			response, respErr, statusCode = downloaderXact.RemoveJob(payload.ID)
+		case cmn.Unschedule:
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("Unscheduling download: %v", payload)
+			}
+			response, respErr, statusCode = downloaderXact.UnscheduleJob(payload.ID)
 		default:
 			cmn.AssertMsg(false,
-				fmt.Sprintf("Invalid action for DELETE request: %s (expected either %s or %s).",
-					items[0], cmn.Abort, cmn.Remove))
+				fmt.Sprintf("Invalid action for DELETE request: %s (expected one of %s, %s, %s).",
+					items[0], cmn.Abort, cmn.Remove, cmn.Unschedule))
 			return
 		}
 	default:
@@ -0,0 +1,78 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// evacuate ais buckets (all of them when none specified) to a Cloud or remote AIS
+// bucket - see xaction/runners/evacuate.go
+func (t *targetrunner) startEvacuate(xactMsg *xaction.XactReqMsg) error {
+	msg := xactMsg.Evacuate
+	if msg == nil {
+		return fmt.Errorf("%q: destination not specified", xactMsg)
+	}
+	var (
+		bmd  = t.owner.bmd.get()
+		dst  = cluster.NewBckEmbed(msg.Dst)
+		bcks = make([]*cluster.Bck, 0, len(xactMsg.Buckets))
+	)
+	if err := dst.Init(t.owner.bmd, t.si); err != nil {
+		return err
+	}
+	if !dst.IsCloud() && !dst.IsRemoteAIS() {
+		return fmt.Errorf("%q: destination %s must be a Cloud or remote AIS bucket", xactMsg, dst)
+	}
+	if len(xactMsg.Buckets) == 0 {
+		provider := cmn.ProviderAIS
+		bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
+			if bck.IsAIS() {
+				bcks = append(bcks, bck)
+			}
+			return false
+		})
+	}
+	for _, b := range xactMsg.Buckets {
+		bck := cluster.NewBckEmbed(b)
+		if err := bck.Init(t.owner.bmd, t.si); err != nil {
+			return err
+		}
+		if !bck.IsAIS() {
+			return fmt.Errorf("%q: cannot evacuate %s (not an ais bucket)", xactMsg, bck)
+		}
+		bcks = append(bcks, bck)
+	}
+	if len(bcks) == 0 {
+		return fmt.Errorf("%q: no ais buckets to evacuate", xactMsg)
+	}
+
+	// cluster-wide rate => this target's share
+	args := &registry.EvacuateArgs{Bcks: bcks, Dst: dst, Msg: msg, Confdir: cmn.GCO.Get().Confdir}
+	if msg.BytesPerHour > 0 {
+		perTarget := *msg
+		perTarget.BytesPerHour = cmn.MaxI64(msg.BytesPerHour/int64(t.owner.smap.get().CountTargets()), 1)
+		args.Msg = &perTarget
+	}
+	xact, err := registry.Registry.RenewEvacuate(t, xactMsg.ID, args)
+	if err != nil {
+		return err
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{
+			When: cluster.UponTerm,
+			Dsts: []string{equalIC},
+			F:    t.callerNotifyFin,
+		},
+	})
+	go xact.Run()
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/export"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// bucket export to WebDataset shards - see package export
+
+// exportStorage reads the shards' members from and writes the shards to their
+// HRW targets (which for the shards is, normally, this target).
+type exportStorage struct {
+	t *targetrunner
+}
+
+// interface guard
+var _ export.Storage = &exportStorage{}
+
+// POST {action: exportbck, value: export.Job} /v1/buckets/bucket-name
+// (the first batch of shards starts the export, the subsequent ones get added to it)
+func (t *targetrunner) exportBucket(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	if !isIntraCall(r.Header) {
+		t.invalmsghdlrf(w, r, "%s: %s-%s(bck) is expected to be intra-called", t.si, r.Method, msg.Action)
+		return
+	}
+	job := &export.Job{}
+	if err := cmn.MorphMarshal(msg.Value, job); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if job.Cont {
+		xact, ok := registry.Registry.GetXact(job.UUID).(*export.Xaction)
+		if !ok {
+			t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: export %q not found", t.si, job.UUID)
+			return
+		}
+		if err := xact.Add(job); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	bckTo := cluster.NewBckEmbed(job.BckTo)
+	if err := bckTo.Init(t.owner.bmd, t.si); err != nil {
+		// the destination may have been created just now
+		t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
+		if err = bckTo.Init(t.owner.bmd, t.si); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	args := &export.Args{Job: job, Storage: &exportStorage{t: t}}
+	xact, err := registry.Registry.RenewBucketXact(cmn.ActExportBck, bck,
+		registry.XactArgs{T: t, UUID: job.UUID, Custom: args})
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+	})
+	go xact.(*export.Xaction).Run()
+}
+
+func (s *exportStorage) OpenObj(bck cmn.Bck, objName string) (io.ReadCloser, error) {
+	t := s.t
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err := lom.Init(bck); err != nil {
+		return nil, err
+	}
+	tsi, err := cluster.HrwTarget(lom.Uname(), t.owner.smap.Get())
+	if err != nil {
+		return nil, err
+	}
+	if tsi.ID() == t.si.ID() {
+		lom.Lock(false)
+		defer lom.Unlock(false)
+		if err := lom.Load(false); err != nil {
+			return nil, err
+		}
+		return os.Open(lom.FQN)
+	}
+	hdr := make(http.Header)
+	hdr.Set(cmn.HeaderCallerID, t.si.ID())
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodGet,
+		Base:   tsi.URL(cmn.NetworkIntraData),
+		Path:   cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Query:  cmn.AddBckToQuery(nil, bck),
+		Header: hdr,
+	}
+	req, err := reqArgs.Req()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.httpclientGetPut.Do(req) // nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET %s, err: %w", reqArgs.URL(), err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to GET %s/%s from %s: %s (status %d)", bck, objName, tsi, string(b), resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *exportStorage) PutObj(bck cmn.Bck, objName string, r io.ReadCloser, size int64) error {
+	t := s.t
+	dst := &cluster.LOM{T: t, ObjName: objName}
+	if err := dst.Init(bck); err != nil {
+		cmn.Close(r)
+		return err
+	}
+	tsi, err := cluster.HrwTarget(dst.Uname(), t.owner.smap.Get())
+	if err != nil {
+		cmn.Close(r)
+		return err
+	}
+	if tsi.ID() == t.si.ID() {
+		return t.PutObject(dst, cluster.PutObjectParams{
+			Reader:       r,
+			WorkFQN:      fs.CSM.GenContentParsedFQN(dst.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+			RecvType:     cluster.WarmGet,
+			Started:      time.Now(),
+			WithFinalize: true,
+		})
+	}
+	hdr := make(http.Header)
+	hdr.Set(cmn.HeaderPutterID, t.si.ID())
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPut,
+		Base:   tsi.URL(cmn.NetworkIntraData),
+		Path:   cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Query:  cmn.AddBckToQuery(nil, bck),
+		Header: hdr,
+		BodyR:  r,
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile)
+	if err != nil {
+		cmn.Close(r)
+		return fmt.Errorf("unexpected failure to create request, err: %w", err)
+	}
+	defer cancel()
+	req.ContentLength = size
+	resp, err := t.httpclientGetPut.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to PUT to %s, err: %w", reqArgs.URL(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to PUT to %s: %s (status %d)", tsi, string(b), resp.StatusCode)
+	}
+	return nil
+}
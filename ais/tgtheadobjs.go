@@ -0,0 +1,71 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// POST {action: headobjs, value: {objnames: [...]}} /v1/buckets/bucket-name (proxy => target)
+// Looks up the objects (that the proxy has determined to be owned by this target);
+// objects that are not present locally are, for Cloud buckets, HEAD-ed in the Cloud.
+func (t *targetrunner) headObjects(w http.ResponseWriter, r *http.Request, msg *aisMsg, bck *cluster.Bck) {
+	listMsg := &cmn.ListMsg{}
+	if err := cmn.MorphMarshal(msg.Value, listMsg); err != nil {
+		t.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	entries := make([]*cmn.ObjHeadEntry, 0, len(listMsg.ObjNames))
+	for _, name := range listMsg.ObjNames {
+		entry, err, errCode := t.headObj(bck, name)
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error(), errCode)
+			return
+		}
+		entries = append(entries, entry)
+	}
+	t.writeJSON(w, r, entries, "headobjs")
+}
+
+func (t *targetrunner) headObj(bck *cluster.Bck, objName string) (entry *cmn.ObjHeadEntry, err error, errCode int) {
+	entry = &cmn.ObjHeadEntry{Name: objName}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		return nil, err, http.StatusBadRequest
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err == nil {
+		entry.Exists, entry.Present = true, true
+		entry.Size, entry.Version = lom.Size(), lom.Version()
+		return
+	}
+	if !cmn.IsObjNotExist(err) {
+		return nil, err, http.StatusInternalServerError
+	}
+	if bck.IsAIS() {
+		return entry, nil, 0
+	}
+	objMeta, err, errCode := t.Cloud(bck).HeadObj(context.Background(), lom)
+	if err != nil {
+		if errCode == http.StatusNotFound {
+			return entry, nil, 0
+		}
+		return nil, fmt.Errorf("%s: HEAD request failed, err: %v", lom, err), errCode
+	}
+	entry.Exists = true
+	entry.Version = objMeta[cmn.HeaderObjVersion]
+	if size, err := strconv.ParseInt(objMeta[cmn.HeaderObjSize], 10, 64); err == nil {
+		entry.Size = size
+	}
+	return entry, nil, 0
+}
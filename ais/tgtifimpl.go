@@ -23,11 +23,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/evsink"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/lru"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/replication"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction"
@@ -46,6 +48,14 @@ func (t *targetrunner) SmallMMSA() *memsys.MMSA     { return t.smm }
 func (t *targetrunner) DB() dbdriver.Driver         { return t.dbDriver }
 
 func (t *targetrunner) Cloud(bck *cluster.Bck) cluster.CloudProvider {
+	c := t.cloudProvider(bck)
+	if bck.Props != nil && bck.Props.Residency.Enabled() {
+		return cloud.WithResidency(c)
+	}
+	return c
+}
+
+func (t *targetrunner) cloudProvider(bck *cluster.Bck) cluster.CloudProvider {
 	if bck.Bck.IsRemoteAIS() {
 		return t.cloud[cmn.ProviderAIS]
 	}
@@ -78,6 +88,9 @@ func (t *targetrunner) GFN(gfnType cluster.GFNType) cluster.GFN {
 // gets triggered by the stats evaluation of a remaining capacity
 // and then runs in a goroutine - see stats package, target_stats.go
 func (t *targetrunner) RunLRU(id string, force bool, bcks ...cmn.Bck) {
+	if daemon.cli.shadow {
+		return // read-only
+	}
 	regToIC := id == ""
 	if regToIC {
 		id = cmn.GenUUID()
@@ -314,6 +327,11 @@ func (t *targetrunner) CopyObject(lom *cluster.LOM, params cluster.CopyObjectPar
 
 // FIXME: recomputes checksum if called with a bad one (optimize)
 func (t *targetrunner) GetCold(ctx context.Context, lom *cluster.LOM, prefetch bool) (err error, errCode int) {
+	release, err, errCode := t.acquireColdGet(ctx, lom)
+	if err != nil {
+		return
+	}
+	defer release()
 	if prefetch {
 		if !lom.TryLock(true) {
 			glog.Infof("prefetch: cold GET race: %s - skipping", lom)
@@ -346,6 +364,7 @@ func (t *targetrunner) GetCold(ctx context.Context, lom *cluster.LOM, prefetch b
 		return
 	}
 	lom.ReCache()
+	t.publishEvent(lom, evsink.OpColdGet)
 
 	// NOTE: GET - downgrade and keep the lock, PREFETCH - unlock
 	if prefetch {
@@ -366,6 +385,9 @@ func (t *targetrunner) PromoteFile(params cluster.PromoteFileParams) (nlom *clus
 	if err = lom.Init(params.Bck.Bck); err != nil {
 		return
 	}
+	if err = lom.Bprops().Naming.ValidateObjName(lom.ObjName); err != nil {
+		return
+	}
 	// local or remote?
 	var (
 		si   *cluster.Snode
@@ -449,8 +471,14 @@ func (t *targetrunner) PromoteFile(params cluster.PromoteFileParams) (nlom *clus
 	cmn.Assert(workFQN != "")
 	poi.workFQN = workFQN
 	lom.SetSize(written)
-	err, _ = poi.finalize()
-	if err == nil {
+	if params.CustomMD != nil {
+		lom.SetCustomMD(params.CustomMD)
+	}
+	if err, _ = poi.finalize(); err != nil {
+		return
+	}
+	// promote, multipart upload, and append
+	if err = t.replicate(lom, replication.OpPut); err == nil {
 		nlom = lom
 	}
 	return
@@ -0,0 +1,60 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/lifecycle"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// bucket lifecycle (age-based expiration of objects) - see package lifecycle
+
+func (t *targetrunner) initLifecycle() {
+	hk.Reg(cmn.ActLifecycle, func() time.Duration {
+		if t.lifecycleEnabled() {
+			go t.runLifecycle("" /*uuid*/)
+		}
+		return lifecycle.Interval
+	}, lifecycle.Interval)
+}
+
+// returns true if at least one bucket has lifecycle rules
+func (t *targetrunner) lifecycleEnabled() (enabled bool) {
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		enabled = bck.Props.Lifecycle.Enabled()
+		return enabled
+	})
+	return
+}
+
+func (t *targetrunner) runLifecycle(id string, bcks ...cmn.Bck) {
+	if daemon.cli.shadow {
+		return // read-only
+	}
+	regToIC := id == ""
+	if regToIC {
+		id = cmn.GenUUID()
+	}
+	xlc := registry.Registry.RenewLifecycle(id)
+	if xlc == nil {
+		return // still running
+	}
+	if regToIC && xlc.ID().String() == id {
+		regMsg := xactRegMsg{UUID: id, Kind: cmn.ActLifecycle, Srcs: []string{t.si.ID()}}
+		msg := t.newAisMsg(&cmn.ActionMsg{Action: cmn.ActRegGlobalXaction, Value: regMsg}, nil, nil)
+		t.bcastToIC(msg, false /*wait*/)
+	}
+	xlc.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+	})
+	lifecycle.Run(&lifecycle.Init{T: t, Xaction: xlc.(*lifecycle.Xaction), Buckets: bcks}) // blocking
+}
@@ -0,0 +1,100 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/mdindex"
+)
+
+// custom metadata index - see package mdindex
+
+// (re)index custom metadata of a new (or updated) object (no-op unless enabled)
+func (t *targetrunner) indexMD(lom *cluster.LOM) {
+	if !lom.Bprops().MDIndex.Enabled {
+		return
+	}
+	if err := mdindex.New(t.dbDriver, lom.Bck()).Update(lom.ObjName, lom.CustomMD()); err != nil {
+		glog.Errorf("%s: failed to index %s: %v", t.si, lom, err)
+	}
+}
+
+func (t *targetrunner) unindexMD(lom *cluster.LOM) {
+	if !lom.Bprops().MDIndex.Enabled {
+		return
+	}
+	if err := mdindex.New(t.dbDriver, lom.Bck()).Remove(lom.ObjName); err != nil {
+		glog.Errorf("%s: failed to remove %s from the index: %v", t.si, lom, err)
+	}
+}
+
+// drop the index of a bucket that's been destroyed (or no longer indexed)
+func (t *targetrunner) dropMDIndex(bck *cluster.Bck) {
+	if err := mdindex.New(t.dbDriver, bck).Drop(); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Errorf("%s: failed to drop %s index: %v", t.si, bck, err)
+	}
+}
+
+// GET /v1/buckets/bucket-name?what=md_query&md=key=value[&md=...][&limit=N]
+func (t *targetrunner) mdQuery(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	bck, err := newBckFromQuery(bucket, query)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = bck.Init(t.owner.bmd, t.si); err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	conds, limit, err := parseMDQuery(query)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	config := cmn.GCO.Get()
+	exists := func(objName string) bool {
+		lom := &cluster.LOM{T: t, ObjName: objName}
+		if err := lom.Init(bck.Bck, config); err != nil {
+			return false
+		}
+		return lom.Load() == nil
+	}
+	names, err := mdindex.New(t.dbDriver, bck).Query(conds, limit, exists)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	t.writeJSON(w, r, names, "get-what-md-query")
+}
+
+// parse (and validate) metadata conditions and the limit
+func parseMDQuery(query url.Values) (conds []cmn.MDCond, limit int, err error) {
+	for _, s := range query[cmn.URLParamMDCond] {
+		cond, errP := cmn.ParseMDCond(s)
+		if errP != nil {
+			return nil, 0, errP
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) == 0 {
+		return nil, 0, errors.New("metadata query requires at least one condition")
+	}
+	if s := query.Get(cmn.URLParamLimit); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			return nil, 0, fmt.Errorf("invalid metadata query limit %q", s)
+		}
+	}
+	return
+}
@@ -0,0 +1,206 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+/////////////////
+// MOVE OBJECT //
+/////////////////
+
+// Moving an object across buckets (and providers) is executed by the source
+// object's target as a task xaction (see registry.RenewObjMove):
+// copy => verify => delete source. The copy is a regular (non-migrated) PUT,
+// so that remote destinations receive the object as well.
+
+type objMover struct {
+	t *targetrunner
+}
+
+// interface guard
+var _ registry.ObjMover = &objMover{}
+
+// POST {action: moveobj, value: {bck, objname, uuid}} /v1/objects/bucket-name/object-name
+func (t *targetrunner) moveObject(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	apiItems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objName := apiItems[0], apiItems[1]
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mv := &cmn.ActValMoveObject{}
+	if err := cmn.MorphMarshal(msg.Value, mv); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err := lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	bckTo := cluster.NewBckEmbed(mv.Bck)
+	if err := bckTo.Init(t.owner.bmd, t.si); err != nil {
+		// remote destination may have been added to BMD just now
+		t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
+		if err = bckTo.Init(t.owner.bmd, t.si); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	args := &registry.ObjMoveArgs{LOM: lom, BckTo: bckTo, ObjNameTo: mv.ObjName, Mover: &objMover{t: t}}
+	xact, err := registry.Registry.RenewObjMove(t, mv.UUID, args)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+	})
+	go xact.Run()
+}
+
+// CopyObj sends the (read-locked) source to the destination's target; it is
+// the destination target that PUTs the object to the Cloud (if applicable).
+func (m *objMover) CopyObj(lom *cluster.LOM, bckTo *cluster.Bck, objNameTo string, progress func(n int64)) error {
+	t := m.t
+	tsi, err := cluster.HrwTarget(bckTo.MakeUname(objNameTo), t.owner.smap.Get())
+	if err != nil {
+		return err
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false); err != nil {
+		return err
+	}
+	file, err := os.Open(lom.FQN)
+	if err != nil {
+		return err
+	}
+	reader := cmn.NewCallbackReadCloser(file, func(n int, _ error) { progress(int64(n)) })
+	if tsi.ID() == t.si.ID() {
+		dst := &cluster.LOM{T: t, ObjName: objNameTo}
+		if err := dst.Init(bckTo.Bck); err != nil {
+			cmn.Close(file)
+			return err
+		}
+		return t.PutObject(dst, cluster.PutObjectParams{
+			Reader:       reader,
+			WorkFQN:      fs.CSM.GenContentParsedFQN(dst.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+			RecvType:     cluster.WarmGet,
+			Started:      time.Now(),
+			WithFinalize: true,
+		})
+	}
+	return m.putTo(tsi, lom, reader, bckTo, objNameTo)
+}
+
+// same as t._sendPUT() except that the PUT is regular (not migration) and the
+// response status is checked
+func (m *objMover) putTo(tsi *cluster.Snode, lom *cluster.LOM, reader *cmn.CallbackRC, bckTo *cluster.Bck,
+	objNameTo string) error {
+	var (
+		t   = m.t
+		hdr = cmn.ToHTTPHdr(lom)
+	)
+	hdr.Set(cmn.HeaderPutterID, t.si.ID())
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPut,
+		Base:   tsi.URL(cmn.NetworkIntraData),
+		Path:   cmn.JoinWords(cmn.Version, cmn.Objects, bckTo.Name, objNameTo),
+		Query:  cmn.AddBckToQuery(nil, bckTo.Bck),
+		Header: hdr,
+		BodyR:  reader,
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile)
+	if err != nil {
+		cmn.Close(reader)
+		return fmt.Errorf("unexpected failure to create request, err: %w", err)
+	}
+	defer cancel()
+	req.ContentLength = lom.Size()
+	resp, err := t.httpclientGetPut.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to PUT to %s, err: %w", reqArgs.URL(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to PUT to %s: %s (status %d)", tsi, string(b), resp.StatusCode)
+	}
+	return nil
+}
+
+// DeleteObj deletes the source (PRECONDITION: write-locked)
+func (m *objMover) DeleteObj(lom *cluster.LOM) error {
+	err, _ := m.t.deleteObject(context.Background(), lom, false /*evict*/, nil)
+	return err
+}
+
+// StatObj returns size and checksum of the destination object as stored by its target
+func (m *objMover) StatObj(bckTo *cluster.Bck, objNameTo string) (size int64, cksum *cmn.Cksum, err error) {
+	t := m.t
+	tsi, err := cluster.HrwTarget(bckTo.MakeUname(objNameTo), t.owner.smap.Get())
+	if err != nil {
+		return
+	}
+	if tsi.ID() == t.si.ID() {
+		dst := &cluster.LOM{T: t, ObjName: objNameTo}
+		if err = dst.Init(bckTo.Bck); err != nil {
+			return
+		}
+		dst.Lock(false)
+		err = dst.Load(false)
+		dst.Unlock(false)
+		if err != nil {
+			return
+		}
+		return dst.Size(), dst.Cksum(), nil
+	}
+	query := cmn.AddBckToQuery(nil, bckTo.Bck)
+	query.Set(cmn.URLParamSilent, "true")
+	res := t.call(callArgs{
+		si: tsi,
+		req: cmn.ReqArgs{
+			Method: http.MethodHead,
+			Base:   tsi.URL(cmn.NetworkIntraControl),
+			Path:   cmn.JoinWords(cmn.Version, cmn.Objects, bckTo.Name, objNameTo),
+			Query:  query,
+		},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+	if res.err != nil {
+		return 0, nil, res.err
+	}
+	if size, err = strconv.ParseInt(res.header.Get(cmn.HeaderObjSize), 10, 64); err != nil {
+		return 0, nil, fmt.Errorf("%s: invalid size of %s/%s: %v", tsi, bckTo, objNameTo, err)
+	}
+	if ty := res.header.Get(cmn.HeaderObjCksumType); ty != "" {
+		cksum = cmn.NewCksum(ty, res.header.Get(cmn.HeaderObjCksumVal))
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %s/%s at %s: size %d, %s", t.si, bckTo, objNameTo, tsi, size, cksum)
+	}
+	return
+}
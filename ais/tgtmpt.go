@@ -0,0 +1,380 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Multipart upload is built on top of APPEND: the upload has its (initially
+// empty) workfile that, upon completion, gets assembled from the parts and
+// promoted to become the object. Unlike APPEND, the parts can be uploaded in
+// parallel and in any order - each part is a separate workfile next to the
+// upload's one (see mptPartFQN).
+// The upload ID returned to the client is opaque: the workfile is always
+// derived from the object (see mptUploadFQN), so that the client can't refer
+// to any other file. Uploads survive target restarts; the ones that see no
+// activity for mptUploadTTL get removed (see mptHousekeep).
+
+const (
+	mptUploadTTL  = 7 * 24 * time.Hour // abandoned (incomplete) uploads expire
+	mptGCInterval = time.Hour
+
+	// the upload's workfile suffix - not a valid PID (see fs.WorkfileContentResolver)
+	// so that neither LRU nor the startup orphan GC take the upload for an old workfile
+	mptSuffix = "upload"
+
+	mptMaxIDLen = 32
+)
+
+func combineMptHandle(nodeID, uploadID string) string { return nodeID + "|" + uploadID }
+
+func validMptID(uploadID string) bool {
+	if uploadID == "" || len(uploadID) > mptMaxIDLen {
+		return false
+	}
+	for _, c := range uploadID {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// the upload's workfile
+func mptUploadFQN(lom *cluster.LOM, uploadID string) string {
+	dir, fname := filepath.Split(lom.ObjName)
+	base := fs.WorkfileMpt + "." + fname + "." + uploadID + "." + mptSuffix
+	return fs.CSM.FQN(lom.ParsedFQN.MpathInfo, lom.Bck().Bck, fs.WorkfileType, filepath.Join(dir, base))
+}
+
+// the upload's workfile or one of its parts
+func isMptWorkfile(base string) bool {
+	return strings.HasSuffix(base, "."+mptSuffix) && strings.Contains(base, fs.WorkfileMpt+".")
+}
+
+// the part's workfile: the upload's workfile prefixed with the part number
+func mptPartFQN(uploadFQN string, num int) string {
+	dir, fname := filepath.Split(uploadFQN)
+	return filepath.Join(dir, strconv.Itoa(num)+"-"+fname)
+}
+
+func (t *targetrunner) doMultipart(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, started time.Time,
+	op string) (err error, errCode int) {
+	hi, err := parseAppendHandle(r.URL.Query().Get(cmn.URLParamAppendHandle))
+	if err != nil {
+		return err, http.StatusBadRequest
+	}
+	if op == cmn.MptCreateOp {
+		uploadID := cmn.GenUUID()
+		uploadFQN := mptUploadFQN(lom, uploadID)
+		f, err := lom.CreateFile(uploadFQN)
+		if err != nil {
+			return err, http.StatusInternalServerError
+		}
+		cmn.Close(f)
+		w.Header().Set(cmn.HeaderAppendHandle, combineMptHandle(t.si.ID(), uploadID))
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("multipart upload %s: created %s", lom, uploadFQN)
+		}
+		return nil, 0
+	}
+	if hi.filePath == "" || hi.partialCksum != nil {
+		return errors.New("multipart upload ID not provided"), http.StatusBadRequest
+	}
+	if !validMptID(hi.filePath) {
+		return fmt.Errorf("invalid multipart upload ID %q", hi.filePath), http.StatusBadRequest
+	}
+	uploadFQN := mptUploadFQN(lom, hi.filePath)
+	if _, err := os.Stat(uploadFQN); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("multipart upload of %s not found (completed or aborted?)", lom), http.StatusNotFound
+		}
+		return err, http.StatusInternalServerError
+	}
+	switch op {
+	case cmn.MptPartOp:
+		err, errCode = t.mptPart(w, r, lom, uploadFQN)
+	case cmn.MptCompleteOp:
+		err, errCode = t.mptComplete(r, lom, uploadFQN)
+	case cmn.MptAbortOp:
+		t.mptCleanup(uploadFQN)
+		if err := os.Remove(uploadFQN); err != nil && !os.IsNotExist(err) {
+			return err, http.StatusInternalServerError
+		}
+	}
+	if err == nil && op != cmn.MptAbortOp {
+		delta := time.Since(started)
+		t.statsT.AddMany(
+			stats.NamedVal64{Name: stats.AppendCount, Value: 1},
+			stats.NamedVal64{Name: stats.AppendLatency, Value: int64(delta)},
+		)
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("PUT %s (%s): %s", lom, op, delta)
+		}
+	}
+	return
+}
+
+// receives the part and returns its checksum (in the response header)
+func (t *targetrunner) mptPart(w http.ResponseWriter, r *http.Request, lom *cluster.LOM,
+	uploadFQN string) (err error, errCode int) {
+	var (
+		numStr    = r.URL.Query().Get(cmn.URLParamPartNum)
+		cksumType = r.Header.Get(cmn.HeaderObjCksumType)
+		cksumVal  = r.Header.Get(cmn.HeaderObjCksumVal)
+	)
+	num, err := strconv.Atoi(numStr)
+	if err != nil || num < 1 || num > cmn.MaxMptParts {
+		return fmt.Errorf("invalid part number %q (expecting 1 to %d)", numStr, cmn.MaxMptParts), http.StatusBadRequest
+	}
+	if cksumType == "" {
+		cksumType = lom.CksumConf().Type
+	} else if err := cmn.ValidateCksumType(cksumType); err != nil {
+		return err, http.StatusBadRequest
+	}
+	partFQN := mptPartFQN(uploadFQN, num)
+	f, err := lom.CreateFile(partFQN)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	var (
+		buf   []byte
+		slab  *memsys.Slab
+		cksum = cmn.NewCksumHash(cksumType)
+	)
+	if r.ContentLength > 0 {
+		buf, slab = t.gmm.Alloc(r.ContentLength)
+	} else {
+		buf, slab = t.gmm.Alloc()
+	}
+	_, err = io.CopyBuffer(cmn.NewWriterMulti(f, cksum.H), r.Body, buf)
+	slab.Free(buf)
+	cmn.Close(f)
+	if err != nil {
+		os.Remove(partFQN)
+		return err, http.StatusInternalServerError
+	}
+	cksum.Finalize()
+	if cksumVal != "" {
+		if expected := cmn.NewCksum(cksumType, cksumVal); !cksum.Equal(expected) {
+			os.Remove(partFQN)
+			return cmn.NewBadDataCksumError(cksum.Clone(), expected, fmt.Sprintf("%s part #%d", lom, num)),
+				http.StatusBadRequest
+		}
+	}
+	// activity - postpones the expiration of the upload
+	now := time.Now()
+	os.Chtimes(uploadFQN, now, now)
+
+	w.Header().Set(cmn.HeaderObjCksumType, cksum.Type())
+	w.Header().Set(cmn.HeaderObjCksumVal, cksum.Value())
+	return
+}
+
+// assembles the object from the parts, verifying the checksums (of the parts
+// and, optionally, of the entire object) on the way
+func (t *targetrunner) mptComplete(r *http.Request, lom *cluster.LOM, uploadFQN string) (err error, errCode int) {
+	var (
+		msg       cmn.MptCompleteMsg
+		cksumType = r.Header.Get(cmn.HeaderObjCksumType)
+		cksumVal  = r.Header.Get(cmn.HeaderObjCksumVal)
+	)
+	if err := jsoniter.NewDecoder(r.Body).Decode(&msg); err != nil {
+		return fmt.Errorf("invalid multipart upload completion request: %v", err), http.StatusBadRequest
+	}
+	if len(msg.Parts) == 0 {
+		return errors.New("multipart upload: no parts to complete"), http.StatusBadRequest
+	}
+	for i, part := range msg.Parts {
+		if part.Num < 1 || part.Num > cmn.MaxMptParts || (i > 0 && part.Num <= msg.Parts[i-1].Num) {
+			return fmt.Errorf("invalid part number %d: must be in ascending order, from 1 to %d",
+				part.Num, cmn.MaxMptParts), http.StatusBadRequest
+		}
+		if part.CksumVal != "" {
+			if err := cmn.ValidateCksumType(part.CksumType); err != nil {
+				return err, http.StatusBadRequest
+			}
+		}
+	}
+	if cksumVal != "" {
+		if err := cmn.ValidateCksumType(cksumType); err != nil {
+			return err, http.StatusBadRequest
+		}
+	}
+
+	f, err := os.OpenFile(uploadFQN, os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	var (
+		cksum     = cmn.NewCksumHash(lom.CksumConf().Type)
+		xcksum    = cksum // to verify the entire object against
+		w         = io.Writer(f)
+		buf, slab = t.gmm.Alloc()
+	)
+	if cksumVal != "" && cksumType != cksum.Type() {
+		xcksum = cmn.NewCksumHash(cksumType)
+		w = cmn.NewWriterMulti(f, xcksum.H)
+	}
+	for _, part := range msg.Parts {
+		if err, errCode = mptCopyPart(w, cksum, uploadFQN, part, buf); err != nil {
+			break
+		}
+	}
+	slab.Free(buf)
+	cmn.Close(f)
+	if err != nil {
+		return
+	}
+	cksum.Finalize()
+	if cksumVal != "" {
+		if xcksum != cksum {
+			xcksum.Finalize()
+		}
+		if expected := cmn.NewCksum(cksumType, cksumVal); !xcksum.Equal(expected) {
+			return cmn.NewBadDataCksumError(xcksum.Clone(), expected, lom.String()), http.StatusBadRequest
+		}
+	}
+	params := cluster.PromoteFileParams{
+		SrcFQN:    uploadFQN,
+		Bck:       lom.Bck(),
+		ObjName:   lom.ObjName,
+		Cksum:     cksum.Clone(),
+		Overwrite: true,
+	}
+	if _, err = t.PromoteFile(params); err != nil {
+		return err, http.StatusInternalServerError
+	}
+	t.mptCleanup(uploadFQN)
+	return
+}
+
+func mptCopyPart(w io.Writer, cksum *cmn.CksumHash, uploadFQN string, part cmn.MptPart, buf []byte) (error, int) {
+	f, err := os.Open(mptPartFQN(uploadFQN, part.Num))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("multipart upload: part #%d not uploaded", part.Num), http.StatusBadRequest
+		}
+		return err, http.StatusInternalServerError
+	}
+	defer cmn.Close(f)
+	if part.CksumVal == "" {
+		if _, err = io.CopyBuffer(cmn.NewWriterMulti(w, cksum.H), f, buf); err != nil {
+			return err, http.StatusInternalServerError
+		}
+		return nil, 0
+	}
+	partCksum := cmn.NewCksumHash(part.CksumType)
+	if _, err = io.CopyBuffer(cmn.NewWriterMulti(w, cksum.H, partCksum.H), f, buf); err != nil {
+		return err, http.StatusInternalServerError
+	}
+	partCksum.Finalize()
+	if expected := cmn.NewCksum(part.CksumType, part.CksumVal); !partCksum.Equal(expected) {
+		return cmn.NewBadDataCksumError(partCksum.Clone(), expected, fmt.Sprintf("part #%d", part.Num)),
+			http.StatusBadRequest
+	}
+	return nil, 0
+}
+
+// removes the parts of the upload
+func (t *targetrunner) mptCleanup(uploadFQN string) {
+	dir, fname := filepath.Split(uploadFQN)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		glog.Errorf("%s: failed to clean up multipart upload %s: %v", t.si, uploadFQN, err)
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		i := strings.IndexByte(name, '-')
+		if i <= 0 || name[i+1:] != fname {
+			continue
+		}
+		if _, err := strconv.Atoi(name[:i]); err != nil {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			glog.Errorf("%s: %v", t.si, err)
+		}
+	}
+}
+
+func (t *targetrunner) initMpt() { hk.Reg("mpt.gc", t.mptHousekeep, mptGCInterval) }
+
+// removes the uploads (and their parts) that have seen no activity for mptUploadTTL
+func (t *targetrunner) mptHousekeep() time.Duration {
+	var (
+		bcks     = make([]cmn.Bck, 0, 16)
+		avail, _ = fs.Get()
+		expired  = time.Now().Add(-mptUploadTTL)
+	)
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		bcks = append(bcks, bck.Bck)
+		return false
+	})
+	go func() {
+		for _, mi := range avail {
+			for _, bck := range bcks {
+				t.mptExpire(mi, bck, expired)
+			}
+		}
+	}()
+	return mptGCInterval
+}
+
+func (t *targetrunner) mptExpire(mi *fs.MountpathInfo, bck cmn.Bck, expired time.Time) {
+	dir := mi.MakePathCT(bck, fs.WorkfileType)
+	if err := fs.Access(dir); err != nil {
+		return
+	}
+	opts := &fs.Options{
+		Dir: dir,
+		Callback: func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() || !isMptWorkfile(filepath.Base(fqn)) {
+				return nil
+			}
+			finfo, err := os.Lstat(fqn)
+			if err != nil || finfo.ModTime().After(expired) {
+				return nil
+			}
+			base := filepath.Base(fqn)
+			if strings.HasPrefix(base, fs.WorkfileMpt+".") {
+				glog.Infof("%s: multipart upload %s expired", t.si, fqn)
+				t.mptCleanup(fqn)
+			} else if i := strings.IndexByte(base, '-'); i > 0 {
+				// the part of an upload that's gone
+				if _, err := os.Stat(filepath.Join(filepath.Dir(fqn), base[i+1:])); err == nil {
+					return nil
+				}
+			}
+			if err := os.Remove(fqn); err != nil && !os.IsNotExist(err) {
+				glog.Errorf("%s: %v", t.si, err)
+			}
+			return nil
+		},
+	}
+	if err := fs.Walk(opts); err != nil {
+		glog.Errorf("%s: failed to traverse %q: %v", mi, dir, err)
+	}
+}
@@ -0,0 +1,38 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestMptUploadID(t *testing.T) {
+	for _, id := range []string{"Fx2_a-9", "abc"} {
+		if !validMptID(id) {
+			t.Errorf("expected %q to be valid", id)
+		}
+	}
+	for _, id := range []string{"", "../../etc/passwd", "/tmp/x", "a.b", "0123456789012345678901234567890123"} {
+		if validMptID(id) {
+			t.Errorf("expected %q to be invalid", id)
+		}
+	}
+
+	// neither LRU nor the orphan GC may take a live upload for an old workfile
+	resolver := fs.WorkfileContentResolver{}
+	for _, base := range []string{"mpt.obj.tar.abc123.upload", "12-mpt.obj.tar.abc123.upload"} {
+		if !isMptWorkfile(base) {
+			t.Errorf("expected %q to be a multipart upload workfile", base)
+		}
+		if _, old, ok := resolver.ParseUniqueFQN(base); ok && old {
+			t.Errorf("%q parsed as an old workfile", base)
+		}
+	}
+	if isMptWorkfile("put.obj.tar.aBc.1f2e") {
+		t.Error("expected PUT workfile not to be a multipart upload")
+	}
+}
@@ -0,0 +1,45 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// copy and rename validate the destination name against the destination bucket
+func TestCopyValidateObjName(t *testing.T) {
+	var (
+		props = &cmn.BucketProps{Naming: cmn.NamingConf{ForbiddenChars: ":", PrefixPattern: "train/"}}
+		coi   = &copyObjInfo{CopyObjectParams: cluster.CopyObjectParams{
+			BckTo: cluster.NewBck("dst", cmn.ProviderAIS, cmn.NsGlobal, props),
+		}}
+	)
+	tests := []struct {
+		objName string
+		valid   bool
+	}{
+		{"train/a", true},
+		{"train/a:b", false},
+		{"val/a", false},
+	}
+	for _, test := range tests {
+		err := coi.validateObjName(test.objName)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid=%t, got err: %v", test.objName, test.valid, err)
+		}
+		if _, ok := err.(*cmn.ObjNameError); err != nil && !ok {
+			t.Errorf("%q: unexpected error type %T", test.objName, err)
+		}
+	}
+
+	// destination bucket without props (not initialized) - nothing to validate
+	coi.BckTo = cluster.NewBck("dst", cmn.ProviderAIS, cmn.NsGlobal)
+	if err := coi.validateObjName("val/a:b"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/evsink"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/replication"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xaction/registry"
 )
@@ -75,6 +77,10 @@ type (
 		isGFN bool
 		// true: chunked transfer (en)coding as per https://tools.ietf.org/html/rfc7230#page-36
 		chunked bool
+		// request headers of conditional GET (nil - unconditional), see cmn.NotModified
+		condHdr http.Header
+		// true: the client allows restoring the object from EC slices (see cmn.URLParamECRestore)
+		ecRestore bool
 	}
 
 	// Contains information packed in append handle.
@@ -109,6 +115,7 @@ type (
 	}
 
 	writerOnly struct{ io.Writer }
+	readerOnly struct{ io.Reader }
 )
 
 ////////////////
@@ -117,6 +124,13 @@ type (
 
 func (poi *putObjInfo) putObject() (err error, errCode int) {
 	lom := poi.lom
+	// new (user-named) objects only - migrated and cold-GET objects are named already
+	if !poi.migrated && !poi.cold {
+		if err := lom.Bprops().Naming.ValidateObjName(lom.ObjName); err != nil {
+			cmn.DrainReader(poi.r)
+			return err, http.StatusBadRequest
+		}
+	}
 	// optimize out if the checksums do match
 	if poi.cksumToCheck != nil {
 		if lom.Cksum().Equal(poi.cksumToCheck) {
@@ -129,12 +143,24 @@ func (poi *putObjInfo) putObject() (err error, errCode int) {
 	}
 
 	if !daemon.dryRun.disk {
+		if !poi.migrated {
+			fs.Throttle(lom.ParsedFQN.MpathInfo, fs.QoSUser) // foreground activity (see fs/qos.go)
+		}
 		if err := poi.writeToFile(); err != nil {
 			return err, http.StatusInternalServerError
 		}
 		if err, errCode := poi.finalize(); err != nil {
 			return err, errCode
 		}
+		if !poi.migrated {
+			poi.t.publishEvent(lom, evsink.OpPut)
+			// user PUT, S3 PUT, download, dSort shard, object move - but not cold GET
+			if !poi.cold || lom.Bck().IsAIS() {
+				if err := poi.t.replicate(lom, replication.OpPut); err != nil {
+					return err, http.StatusInternalServerError
+				}
+			}
+		}
 	}
 	if !poi.migrated && !poi.cold {
 		delta := time.Since(poi.started)
@@ -171,6 +197,7 @@ func (poi *putObjInfo) finalize() (err error, errCode int) {
 	}
 
 	poi.t.putMirror(poi.lom)
+	poi.t.indexMD(poi.lom)
 	return
 }
 
@@ -215,6 +242,7 @@ func (poi *putObjInfo) tryFinalize() (err error, errCode int) {
 			return
 		}
 	}
+	lom.DedupRelease()
 	if err := cmn.Rename(poi.workFQN, lom.FQN); err != nil {
 		return fmt.Errorf("rename failed => %s: %w", lom, err), 0
 	}
@@ -226,6 +254,11 @@ func (poi *putObjInfo) tryFinalize() (err error, errCode int) {
 	if err = lom.Persist(); err != nil {
 		return
 	}
+	if lom.Bprops().Dedup.Enabled {
+		if _, err := lom.Dedup(); err != nil {
+			glog.Errorf("%s: failed to dedup: %v", lom, err) // the object is stored nonetheless
+		}
+	}
 	lom.ReCache()
 	return
 }
@@ -465,6 +498,10 @@ do:
 	// 3. coldget
 	if coldGet {
 		goi.lom.Unlock(false) // `GetCold` will lock again and return with object locked
+		if goi.ranges.Range != "" && !goi.isGFN {
+			// read the range directly from the cloud - not fetching the entire object
+			return goi.getColdRange()
+		}
 		if !capRead {
 			capRead = true
 			cs = fs.GetCapStatus()
@@ -475,6 +512,9 @@ do:
 		}
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
 		if err, errCode := goi.t.GetCold(goi.ctx, goi.lom, false /*prefetch*/); err != nil {
+			if errCode == http.StatusServiceUnavailable {
+				goi.setRetryAfter(retryAfterColdGet)
+			}
 			return err, errCode
 		}
 		goi.t.putMirror(goi.lom)
@@ -482,6 +522,9 @@ do:
 
 	// 4. get locally and stream back
 get:
+	if !daemon.dryRun.disk {
+		fs.Throttle(goi.lom.ParsedFQN.MpathInfo, fs.QoSUser) // foreground activity (see fs/qos.go)
+	}
 	retry, err, errCode = goi.finalize(coldGet)
 	if retry && !retried {
 		glog.Warningf("GET %s: uncaching and retrying...", goi.lom)
@@ -609,14 +652,19 @@ func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, err error, errCode
 	if running {
 		doubleCheck = true
 	}
-	gfnActive = goi.t.gfn.global.active()
-	if running && tsi.ID() != goi.t.si.ID() {
+	// (the target that joined in prefill mode gets the objects that haven't
+	// trickled yet from neighbors - see ais/prxprefill.go)
+	gfnActive = goi.t.gfn.global.active() || goi.t.inPrefill(smap)
+	// the client allowed EC restore and the (EC-enabled) GET got redirected
+	// here because the object's main target is down - don't look it up there
+	ecFallback := goi.ecRestore && ecEnabled && tsi.ID() != goi.t.si.ID()
+	if running && tsi.ID() != goi.t.si.ID() && !ecFallback {
 		if goi.t.LookupRemoteSingle(goi.lom, tsi) {
 			gfnNode = tsi
 			goto gfn
 		}
 	}
-	if running || !enoughECRestoreTargets || ((interrupted || gfnActive) && !ecEnabled) {
+	if !ecFallback && (running || !enoughECRestoreTargets || ((interrupted || gfnActive) && !ecEnabled)) {
 		gfnNode = goi.t.lookupRemoteAll(goi.lom, smap)
 	}
 
@@ -632,7 +680,9 @@ gfn:
 
 	// restore from existing EC slices if possible
 	if ecErr := ec.ECM.RestoreObject(goi.lom); ecErr == nil {
-		if glog.FastV(4, glog.SmoduleAIS) {
+		if ecFallback {
+			glog.Warningf("%s: EC-recovered %s (main target %s is down)", tname, goi.lom, tsi)
+		} else if glog.FastV(4, glog.SmoduleAIS) {
 			glog.Infof("%s: EC-recovered %s", tname, goi.lom)
 		}
 		return
@@ -692,15 +742,35 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok
 	return
 }
 
+// setCondHdr sets the object's validators (ETag, Last-Modified) and evaluates
+// the conditional GET, if requested; ETag that's already set (e.g., by S3
+// compatibility API) takes precedence.
+func (goi *getObjInfo) setCondHdr(hdr http.Header, file *os.File) (notModified bool) {
+	finfo, err := file.Stat()
+	if err != nil {
+		return
+	}
+	etag := hdr.Get(cmn.HeaderETag)
+	if etag == "" {
+		if etag = cmn.ObjETag(goi.lom.Cksum(), goi.lom.Version()); etag != "" {
+			hdr.Set(cmn.HeaderETag, etag)
+		}
+	}
+	hdr.Set(cmn.HeaderLastModified, finfo.ModTime().UTC().Format(http.TimeFormat))
+	return goi.condHdr != nil && cmn.NotModified(goi.condHdr, etag, finfo.ModTime())
+}
+
 func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode int) {
 	var (
 		file    *os.File
 		sgl     *memsys.SGL
 		slab    *memsys.Slab
 		buf     []byte
+		iobuf   []byte // aligned (sub)buffer for O_DIRECT reads
 		reader  io.Reader
 		hdr     http.Header // if it is http request we will write also header
 		written int64
+		direct  bool // O_DIRECT read (see cmn.DirectReadConf)
 	)
 	defer func() {
 		if file != nil {
@@ -758,6 +828,16 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 		return
 	}
 
+	if hdr != nil {
+		if notModified := goi.setCondHdr(hdr, file); notModified {
+			goi.w.(http.ResponseWriter).WriteHeader(http.StatusNotModified)
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("GET: %s not modified", goi.lom)
+			}
+			return
+		}
+	}
+
 	var (
 		r    *cmn.HTTPRange
 		size = goi.lom.Size()
@@ -799,6 +879,15 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 				hdr.Set(cmn.HeaderObjCksumVal, cksumValue)
 			}
 		}
+		if r == nil {
+			// other checksums of the entire object (e.g., provided by the Cloud)
+			// for the client to validate as well
+			for _, ty := range cmn.SupportedChecksums() {
+				if v, ok := goi.lom.GetCustomMD(ty); ok && ty != cmn.ChecksumNone {
+					hdr.Add(cmn.HeaderObjCustomMD, ty+"="+v)
+				}
+			}
+		}
 		if goi.lom.Version() != "" {
 			hdr.Set(cmn.HeaderObjVersion, goi.lom.Version())
 		}
@@ -811,8 +900,22 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 		}
 	}
 
+	if r == nil && goi.lom.Bprops().DirectRead.Use(size) {
+		if dfile, err := fs.OpenDirect(fqn); err == nil {
+			cmn.Close(file)
+			file, direct = dfile, true
+		} else if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("%s: failed to open with O_DIRECT (%v), using buffered read", goi.lom, err)
+		}
+	}
+
 	w := goi.w
-	if r == nil {
+	if direct {
+		// hide ReadFrom and WriteTo (sendfile) - must read via aligned buffer
+		w, reader = writerOnly{goi.w}, readerOnly{file}
+		buf, slab = goi.t.gmm.Alloc(memsys.MaxPageSlabSize)
+		iobuf = memsys.AlignBuf(buf)
+	} else if r == nil {
 		reader = file
 		if goi.chunked {
 			w = writerOnly{goi.w} // hide ReadFrom; CopyBuffer will use the buffer instead
@@ -832,7 +935,13 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 			reader = io.NewSectionReader(file, r.Start, r.Length)
 		}
 	}
-	written, err = io.CopyBuffer(w, reader, buf)
+	if !direct {
+		iobuf = buf
+	}
+	if r != nil {
+		goi.w.(http.ResponseWriter).WriteHeader(http.StatusPartialContent)
+	}
+	written, err = io.CopyBuffer(w, reader, iobuf)
 
 	if err != nil {
 		if cmn.IsErrConnectionReset(err) {
@@ -871,6 +980,92 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 		stats.NamedVal64{Name: stats.GetLatency, Value: int64(delta)},
 		stats.NamedVal64{Name: stats.GetCount, Value: 1},
 	)
+	if direct {
+		goi.t.statsT.AddMany(
+			stats.NamedVal64{Name: stats.GetDirectLatency, Value: int64(delta)},
+			stats.NamedVal64{Name: stats.GetDirectCount, Value: 1},
+			stats.NamedVal64{Name: stats.GetDirectSize, Value: written},
+		)
+	}
+	return
+}
+
+// getColdRange serves ranged GET of the object that is not present locally:
+// the range is read from the cloud as is, while the entire object gets cached
+// in background.
+func (goi *getObjInfo) setRetryAfter(d time.Duration) {
+	if w, ok := goi.w.(http.ResponseWriter); ok {
+		w.Header().Set(cmn.HeaderRetryAfter, strconv.Itoa(int(d/time.Second)))
+	}
+}
+
+func (goi *getObjInfo) getColdRange() (err error, errCode int) {
+	var (
+		objMeta cmn.SimpleKVs
+		size    int64
+		hdr     = goi.w.(http.ResponseWriter).Header()
+		cloud   = goi.t.Cloud(goi.lom.Bck())
+	)
+	if objMeta, err, errCode = cloud.HeadObj(goi.ctx, goi.lom); err != nil {
+		return
+	}
+	if size, err = strconv.ParseInt(objMeta[cmn.HeaderObjSize], 10, 64); err != nil {
+		return fmt.Errorf("%s: invalid size %q", goi.lom, objMeta[cmn.HeaderObjSize]), http.StatusInternalServerError
+	}
+	ranges, err := cmn.ParseMultiRange(goi.ranges.Range, size)
+	if err != nil {
+		if err == cmn.ErrNoOverlap {
+			hdr.Set(cmn.HeaderContentRange, fmt.Sprintf("%s*/%d", cmn.HeaderContentRangeValPrefix, size))
+		}
+		return err, http.StatusRequestedRangeNotSatisfiable
+	}
+	if len(ranges) != 1 {
+		return fmt.Errorf("multi-range is not supported"), http.StatusRequestedRangeNotSatisfiable
+	}
+	r := &ranges[0]
+	release, err, errCode := goi.t.acquireColdGet(goi.ctx, goi.lom)
+	if err != nil {
+		goi.setRetryAfter(retryAfterColdGet)
+		return
+	}
+	defer release()
+	reader, _, err, errCode := cloud.GetObjReader(context.WithValue(goi.ctx, cmn.CtxReadRange, r), goi.lom)
+	if err != nil {
+		return
+	}
+	defer cmn.Close(reader)
+
+	hdr.Set(cmn.HeaderAcceptRanges, "bytes")
+	hdr.Set(cmn.HeaderContentRange, r.ContentRange(size))
+	hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(r.Length, 10))
+	hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(size, 10))
+	if v, ok := objMeta[cmn.HeaderObjVersion]; ok {
+		hdr.Set(cmn.HeaderObjVersion, v)
+	}
+	goi.w.(http.ResponseWriter).WriteHeader(http.StatusPartialContent)
+
+	buf, slab := goi.t.gmm.Alloc(r.Length)
+	written, err := io.CopyBuffer(goi.w, io.LimitReader(reader, r.Length), buf)
+	slab.Free(buf)
+	if err != nil {
+		if cmn.IsErrConnectionReset(err) {
+			return
+		}
+		goi.t.statsT.Add(stats.ErrGetCount, 1)
+		return fmt.Errorf("failed to GET %s range %s: %w", goi.lom, r.ContentRange(size), err),
+			http.StatusInternalServerError
+	}
+	delta := time.Since(goi.started)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("GET: %s(%s), %s (cold, range)", goi.lom, cmn.B2S(written, 1), delta)
+	}
+	goi.t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.GetThroughput, Value: written},
+		stats.NamedVal64{Name: stats.GetLatency, Value: int64(delta)},
+		stats.NamedVal64{Name: stats.GetCount, Value: 1},
+		stats.NamedVal64{Name: stats.GetColdCount, Value: 1},
+	)
+	goi.t.coldGetBackground(goi.lom)
 	return
 }
 
@@ -880,6 +1075,9 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 
 func (aoi *appendObjInfo) appendObject() (newHandle string, err error, errCode int) {
 	filePath := aoi.hi.filePath
+	if filePath != "" && aoi.hi.partialCksum == nil { // (multipart upload ID)
+		return "", errors.New("invalid append handle"), http.StatusBadRequest
+	}
 	switch aoi.op {
 	case cmn.AppendOp:
 		var f *os.File
@@ -967,6 +1165,10 @@ func parseAppendHandle(handle string) (hi handleInfo, err error) {
 		return
 	}
 	p := strings.SplitN(handle, "|", 4)
+	if len(p) == 2 { // multipart upload ID (see combineMptHandle)
+		hi.nodeID, hi.filePath = p[0], p[1]
+		return
+	}
 	if len(p) != 4 {
 		return hi, fmt.Errorf("invalid handle provided: %q", handle)
 	}
@@ -996,6 +1198,15 @@ func combineAppendHandle(nodeID, filePath string, partialCksum *cmn.CksumHash) s
 // COPY OBJECT //
 /////////////////
 
+// the destination (name) must comply with the destination bucket's naming rules
+// (copies get migrated to their targets - see putRemote - and are not re-validated)
+func (coi *copyObjInfo) validateObjName(objNameTo string) error {
+	if coi.BckTo.Props == nil {
+		return nil
+	}
+	return coi.BckTo.Props.Naming.ValidateObjName(objNameTo)
+}
+
 func (coi *copyObjInfo) copyObject(srcLOM *cluster.LOM, objNameTo string) (copied bool, err error) {
 	cmn.Assert(coi.DP == nil)
 
@@ -1005,6 +1216,9 @@ func (coi *copyObjInfo) copyObject(srcLOM *cluster.LOM, objNameTo string) (copie
 		copied, _, err = coi.copyReader(srcLOM, objNameTo)
 		return copied, err
 	}
+	if err = coi.validateObjName(objNameTo); err != nil {
+		return
+	}
 
 	// Local bucket to local bucket copying.
 
@@ -1109,6 +1323,9 @@ func (coi *copyObjInfo) copyObject(srcLOM *cluster.LOM, objNameTo string) (copie
 // TODO: make it possible to skip caching an object from a cloud bucket.
 func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (copied bool, size int64, err error) {
 	cmn.Assert(coi.DP != nil)
+	if err = coi.validateObjName(objNameTo); err != nil {
+		return
+	}
 
 	var (
 		si = coi.t.si
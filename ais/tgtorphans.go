@@ -0,0 +1,188 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/filetype"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Workfiles (partial PUTs, cold GETs, downloads, EC slices in progress, etc.) and
+// dSort's intermediate files are owned by the xactions that create them - the
+// xactions remove them when done. When a target crashes (or gets killed) the
+// files stay behind, invisible to users and to capacity accounting, until LRU
+// gets to them. Hence, at startup target removes (in background, concurrently
+// with the xactions of the current process - the files of which are never
+// considered orphaned):
+//   * workfiles created by a different (previous) process;
+//   * workfiles and dSort files last modified before the current process started
+//     (the process ID may get reused - e.g., when running in a container).
+// Workfiles do not record the xaction that owns them - the liveness of the owner
+// is inferred instead: xactions do not survive the process, so the files of a
+// previous process have no live owner, while the files of the current process
+// may belong to running xactions.
+// Multipart uploads in progress are an exception - they expire on their own; so
+// are partially downloaded objects (see downloader.dlPart) that are kept to be
+// resumed, unless LRU evicts them when running out of space.
+// Reclaimed space is logged per owner (workfile prefix) and reported via
+// `orphan.n` and `orphan.size` stats.
+
+type orphanStats struct {
+	cnt  int64
+	size int64
+}
+
+// orphan-owner => stats
+type orphans map[string]*orphanStats
+
+func (o orphans) add(owner string, size int64) {
+	s, ok := o[owner]
+	if !ok {
+		s = &orphanStats{}
+		o[owner] = s
+	}
+	s.cnt++
+	s.size += size
+}
+
+func (o orphans) merge(other orphans) {
+	for owner, s := range other {
+		to, ok := o[owner]
+		if !ok {
+			o[owner] = s
+			continue
+		}
+		to.cnt += s.cnt
+		to.size += s.size
+	}
+}
+
+// `started` is the time the target process started
+func (t *targetrunner) gcOrphans(started time.Time) {
+	var (
+		wg       = &sync.WaitGroup{}
+		mu       = &sync.Mutex{}
+		total    = make(orphans)
+		bcks     = make([]cmn.Bck, 0, 16)
+		avail, _ = fs.Get()
+	)
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		bcks = append(bcks, bck.Bck)
+		return false
+	})
+	if len(bcks) == 0 {
+		return
+	}
+	for _, mpathInfo := range avail {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			found := gcMpathOrphans(mi, bcks, started)
+			mu.Lock()
+			total.merge(found)
+			mu.Unlock()
+			wg.Done()
+		}(mpathInfo)
+	}
+	wg.Wait()
+	t.reportOrphans(total, started)
+}
+
+func (t *targetrunner) reportOrphans(total orphans, started time.Time) {
+	if len(total) == 0 {
+		return
+	}
+	var (
+		cnt, size int64
+		owners    = make([]string, 0, len(total))
+	)
+	for owner, s := range total {
+		cnt += s.cnt
+		size += s.size
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		s := total[owner]
+		glog.Infof("%s: removed %d orphaned %q file(s), %s", t.si, s.cnt, owner, cmn.B2S(s.size, 2))
+	}
+	glog.Infof("%s: reclaimed %s (%d orphaned file(s)) in %v", t.si, cmn.B2S(size, 2), cnt, time.Since(started))
+	t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.OrphanCount, Value: cnt},
+		stats.NamedVal64{Name: stats.OrphanSize, Value: size},
+	)
+}
+
+func gcMpathOrphans(mi *fs.MountpathInfo, bcks []cmn.Bck, started time.Time) orphans {
+	var (
+		found    = make(orphans)
+		resolver = fs.WorkfileContentResolver{}
+		cts      = []string{fs.WorkfileType, filetype.DSortWorkfileType, filetype.DSortFileType}
+	)
+	for _, bck := range bcks {
+		for _, ct := range cts {
+			dir := mi.MakePathCT(bck, ct)
+			if err := fs.Access(dir); err != nil {
+				continue
+			}
+			opts := &fs.Options{
+				Dir: dir,
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					finfo, err := os.Lstat(fqn)
+					if err != nil {
+						return nil
+					}
+					var (
+						base  = filepath.Base(fqn)
+						owner = ct
+						stale = finfo.ModTime().Before(started)
+					)
+					if ct == fs.WorkfileType && isMptWorkfile(base) {
+						return nil // multipart uploads outlive the process (see mptHousekeep)
+					}
+					if ct == fs.WorkfileType && strings.HasPrefix(base, fs.WorkfileDlPart+".") {
+						return nil // as do partial downloads (see fs.GenResumableFQN)
+					}
+					if ct == fs.WorkfileType {
+						if i := strings.IndexByte(base, '.'); i > 0 {
+							owner = base[:i]
+						}
+						if _, old, ok := resolver.ParseUniqueFQN(base); ok && old {
+							stale = true
+						}
+					}
+					if !stale {
+						return nil
+					}
+					if err := os.Remove(fqn); err != nil {
+						if !os.IsNotExist(err) {
+							glog.Errorf("failed to remove orphaned %q: %v", fqn, err)
+						}
+						return nil
+					}
+					found.add(owner, finfo.Size())
+					return nil
+				},
+			}
+			if err := fs.Walk(opts); err != nil {
+				glog.Errorf("%s: failed to traverse %q: %v", mi, dir, err)
+			}
+		}
+	}
+	return found
+}
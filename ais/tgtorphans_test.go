@@ -0,0 +1,118 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/filetype"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+type orphansTrackerMock struct {
+	stats.TrackerMock
+	counters map[string]int64
+}
+
+func (m *orphansTrackerMock) AddMany(nvs ...stats.NamedVal64) {
+	for _, nv := range nvs {
+		m.counters[nv.Name] += nv.Value
+	}
+}
+
+func TestGCMpathOrphans(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "orphans")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mpath)
+
+	var (
+		mi       = &fs.MountpathInfo{Path: mpath}
+		bck      = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		resolver = fs.WorkfileContentResolver{}
+		started  = time.Now()
+		before   = started.Add(-time.Hour)
+		wkDir    = mi.MakePathCT(bck, fs.WorkfileType)
+		dsortDir = mi.MakePathCT(bck, filetype.DSortFileType)
+		otherPID = strconv.FormatInt(int64(os.Getpid()+1), 16)
+	)
+	create := func(fqn string, size int, mtime time.Time) string {
+		if err := cmn.CreateDir(filepath.Dir(fqn)); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fqn, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(fqn, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return fqn
+	}
+
+	var (
+		removed = []string{
+			// created by another process
+			create(filepath.Join(wkDir, fs.WorkfilePut+".obj1.tie."+otherPID), 10, started.Add(time.Minute)),
+			// the PID may have been reused but the file is older than the process
+			create(resolver.GenUniqueFQN(filepath.Join(wkDir, "obj2"), fs.WorkfileColdget), 20, before),
+			create(filepath.Join(dsortDir, "shard-1.tar"), 30, before),
+		}
+		kept = []string{
+			// may belong to running xactions
+			create(resolver.GenUniqueFQN(filepath.Join(wkDir, "obj3"), fs.WorkfilePut), 40, started.Add(time.Second)),
+			create(filepath.Join(dsortDir, "shard-2.tar"), 50, started.Add(time.Second)),
+			// expire on their own
+			create(filepath.Join(wkDir, fs.WorkfileMpt+".obj4.upload-id."+mptSuffix), 60, before),
+			create(filepath.Join(wkDir, fs.WorkfileDlPart+".obj5.tie."+otherPID), 70, before),
+		}
+	)
+
+	found := gcMpathOrphans(mi, []cmn.Bck{bck}, started)
+
+	for _, fqn := range removed {
+		if _, err := os.Stat(fqn); !os.IsNotExist(err) {
+			t.Errorf("expected orphaned %q to be removed (err: %v)", fqn, err)
+		}
+	}
+	for _, fqn := range kept {
+		if _, err := os.Stat(fqn); err != nil {
+			t.Errorf("expected %q to be kept, err: %v", fqn, err)
+		}
+	}
+	expected := orphans{
+		fs.WorkfilePut:         {cnt: 1, size: 10},
+		fs.WorkfileColdget:     {cnt: 1, size: 20},
+		filetype.DSortFileType: {cnt: 1, size: 30},
+	}
+	if len(found) != len(expected) {
+		t.Fatalf("expected orphans of %d owners, got %d", len(expected), len(found))
+	}
+	for owner, s := range expected {
+		if f, ok := found[owner]; !ok || *f != *s {
+			t.Errorf("%q: expected %+v, got %+v", owner, s, f)
+		}
+	}
+
+	// reported as stats
+	var (
+		tracker = &orphansTrackerMock{counters: make(map[string]int64)}
+		tgt     = &targetrunner{}
+	)
+	tgt.si = &cluster.Snode{DaemonID: "target"}
+	tgt.statsT = tracker
+	tgt.reportOrphans(found, started)
+	if tracker.counters[stats.OrphanCount] != 3 || tracker.counters[stats.OrphanSize] != 60 {
+		t.Errorf("expected 3 orphans (60B) reported, got %v", tracker.counters)
+	}
+}
@@ -0,0 +1,112 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+	"github.com/NVIDIA/aistore/xaction/runners"
+)
+
+// move existing objects onto the target(s) that joined in prefill mode -
+// see xaction/runners/prefill.go and ais/prxprefill.go
+func (t *targetrunner) startPrefill(xactMsg *xaction.XactReqMsg) error {
+	args := &registry.PrefillArgs{Move: t.prefillMove}
+	xact, err := registry.Registry.RenewPrefill(t, xactMsg.ID, args)
+	if err != nil {
+		return err
+	}
+	xact.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{
+			When: cluster.UponTerm,
+			Dsts: []string{equalIC},
+			F:    t.callerNotifyFin,
+		},
+	})
+	go xact.Run()
+	return nil
+}
+
+// prefillMove sends the object to its new home (unless already there) and
+// removes the local replica
+func (t *targetrunner) prefillMove(lom *cluster.LOM, tsi *cluster.Snode) (sent bool, err error) {
+	if !t.LookupRemoteSingle(lom, tsi) {
+		lom.Lock(false)
+		if err = lom.Load(false); err != nil {
+			lom.Unlock(false)
+			return
+		}
+		fh, errO := cmn.NewFileHandle(lom.FQN)
+		if errO != nil {
+			lom.Unlock(false)
+			return false, errO
+		}
+		params := cluster.SendToParams{
+			Reader:    fh,
+			BckTo:     lom.Bck(),
+			ObjNameTo: lom.ObjName,
+			Tsi:       tsi,
+			Locked:    true,
+			HdrMeta:   lom,
+		}
+		if err = t.sendTo(lom, params); err != nil { // (unlocks)
+			return
+		}
+		sent = true
+	}
+	lom.Lock(true)
+	err = lom.Remove()
+	lom.Unlock(true)
+	return
+}
+
+// headPrefill responds to HEAD(object) that hasn't trickled onto this (prefilling)
+// target yet - with the props reported by the neighbor that has it
+func (t *targetrunner) headPrefill(w http.ResponseWriter, lom *cluster.LOM, smap *smapX) bool {
+	tsi := t.lookupRemoteAll(lom, smap)
+	if tsi == nil {
+		return false
+	}
+	query := cmn.AddBckToQuery(nil, lom.Bck().Bck)
+	query.Set(cmn.URLParamSilent, "true")
+	res := t.call(callArgs{
+		si: tsi,
+		req: cmn.ReqArgs{
+			Method: http.MethodHead,
+			Base:   tsi.URL(cmn.NetworkIntraControl),
+			Path:   cmn.JoinWords(cmn.Version, cmn.Objects, lom.BckName(), lom.ObjName),
+			Query:  query,
+		},
+		timeout: lom.Config().Timeout.CplaneOperation,
+	})
+	if res.err != nil {
+		return false
+	}
+	hdr := w.Header()
+	for k, v := range res.header {
+		hdr[k] = v
+	}
+	return true
+}
+
+// (the flag is set and cleared by the primary - in the cluster map)
+func (t *targetrunner) inPrefill(smap *smapX) bool {
+	si := smap.GetTarget(t.si.ID())
+	return si != nil && si.InPrefill()
+}
+
+func (t *targetrunner) prefillStatus() *cmn.PrefillStatus {
+	if entry := registry.Registry.GetLatest(registry.XactFilter{Kind: cmn.ActPrefill}); entry != nil {
+		if xact := entry.Get(); xact != nil {
+			return xact.(*runners.Prefill).Status()
+		}
+	}
+	return &cmn.PrefillStatus{}
+}
@@ -0,0 +1,35 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/cloud"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/replication"
+)
+
+// bucket replication to remote AIS cluster - see package replication
+
+// replicate user PUT or DELETE of a given object (no-op unless enabled)
+func (t *targetrunner) replicate(lom *cluster.LOM, op string) error {
+	if !lom.Bprops().Replication.Enabled() {
+		return nil
+	}
+	remote := t.cloud[cmn.ProviderAIS].(*cloud.AisCloudProvider)
+	return replication.Replicate(t, remote, lom, op)
+}
+
+// GET /v1/buckets/bucket-name?what=replication
+func (t *targetrunner) replicationStatus(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t.writeJSON(w, r, replication.Status(bck.Bck), "get-what-replication")
+}
@@ -103,8 +103,7 @@ func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items [
 func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	started := time.Now()
 	config := cmn.GCO.Get()
-	if cs := fs.GetCapStatus(); cs.OOS {
-		t.invalmsghdlr(w, r, cs.Err.Error())
+	if !t.admitPut(w, r, fs.GetCapStatus()) {
 		return
 	}
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
@@ -201,6 +200,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		w:       w,
 		ctx:     context.Background(),
 		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: objSize},
+		condHdr: condGetHdr(r),
 	}
 	s3compat.SetHeaderFromLOM(w.Header(), lom, objSize)
 	if err, errCode := goi.getObject(); err != nil {
@@ -0,0 +1,289 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Shadow target (aisnode -role=target -shadow -shadow_port=PORT) is a new
+// aisnode version started alongside the running target on the same host - with
+// the same config and mountpaths - for blue-green upgrades:
+// - it assumes the identity (Snode) of the target it shadows but does not join
+//   the cluster, listening instead on its own public port;
+// - it serves object reads (GET and HEAD) directly from the mountpaths and
+//   rejects everything else - no cold GETs, no metadata updates, no xactions;
+// - cmn.ActSwitchover shuts down the shadowed target - without unregistering
+//   it - and re-executes the shadow as the regular target (same PID, same
+//   command line minus -shadow) that rejoins the cluster under the same ID;
+// - switchover requires an admin token or, with authentication disabled, a
+//   request from the local host.
+
+const (
+	shadowReloadInterval = 10 * time.Second
+	switchoverTimeout    = time.Minute
+)
+
+type shadowStatus struct {
+	Snode       *cluster.Snode `json:"snode"` // shadowed target
+	Port        int            `json:"port"`
+	SmapVersion int64          `json:"smap_version"`
+	BMDVersion  int64          `json:"bmd_version"`
+}
+
+func (t *targetrunner) runShadow(config *cmn.Config) error {
+	// load (and keep reloading) local metadata of the shadowed target
+	t.owner.bmd.init()
+	smap := newSmap()
+	if err := t.owner.smap.load(smap, config); err != nil {
+		return fmt.Errorf("%s (shadow): failed to load Smap of the shadowed target: %v", t.si, err)
+	}
+	if smap.GetTarget(t.si.ID()) == nil {
+		return fmt.Errorf("%s (shadow): target not present in the local %s", t.si, smap)
+	}
+	t.owner.smap.put(smap)
+	cluster.InitTarget()
+	hk.Reg("shadow", func() time.Duration {
+		t.shadowReload(config)
+		return shadowReloadInterval
+	}, shadowReloadInterval)
+
+	t.markNodeStarted()
+	t.markClusterStarted()
+
+	t.registerNetworkHandlers([]networkHandler{
+		{r: cmn.Objects, h: t.shadowObjHandler, net: []string{cmn.NetworkPublic}},
+		{r: cmn.Daemon, h: t.shadowDaemonHandler, net: []string{cmn.NetworkPublic}},
+		{r: cmn.Health, h: t.shadowHealthHandler, net: []string{cmn.NetworkPublic}},
+		{r: "/", h: cmn.InvalidHandler, net: []string{cmn.NetworkPublic}},
+	})
+	glog.Infof("%s: running read-only shadow on port %d", t.si, daemon.cli.shadowPort)
+	return t.publicServer.listenAndServe(":"+strconv.Itoa(daemon.cli.shadowPort), t.logger)
+}
+
+func (t *targetrunner) shadowReload(config *cmn.Config) {
+	t.owner.bmd.init()
+	smap := newSmap()
+	if err := t.owner.smap.load(smap, config); err != nil {
+		glog.Errorf("%s (shadow): failed to reload Smap: %v", t.si, err)
+		return
+	}
+	t.owner.smap.put(smap)
+}
+
+// [METHOD] /v1/objects
+func (t *targetrunner) shadowObjHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		t.invalmsghdlrf(w, r, "%s: read-only shadow does not support %s(obj)", t.si, r.Method)
+		return
+	}
+	apiItems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bck, err := newBckFromQuery(apiItems[0], r.URL.Query())
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: apiItems[1]}
+	if err := lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	// NOTE: no locking - the shadowed target writes objects via workfiles
+	// and renames them in place, so that an open file never changes
+	if err := lom.Load(false); err != nil {
+		status := http.StatusInternalServerError
+		if cmn.IsObjNotExist(err) {
+			status = http.StatusNotFound // never cold GET or restore - see above
+		}
+		t.invalmsghdlr(w, r, err.Error(), status)
+		return
+	}
+	file, err := os.Open(lom.FQN)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+	finfo, err := file.Stat()
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	hdr := w.Header()
+	lom.ToHTTPHdr(hdr)
+	hdr.Set(cmn.HeaderContentType, cmn.ContentBinary) // no sniffing
+	http.ServeContent(w, r, lom.ObjName, finfo.ModTime(), file)
+}
+
+// [METHOD] /v1/daemon
+func (t *targetrunner) shadowDaemonHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		status := &shadowStatus{
+			Snode:       t.si,
+			Port:        daemon.cli.shadowPort,
+			SmapVersion: t.owner.smap.get().version(),
+			BMDVersion:  t.owner.bmd.get().version(),
+		}
+		t.writeJSON(w, r, status, "shadow")
+	case http.MethodPut:
+		var msg cmn.ActionMsg
+		if cmn.ReadJSON(w, r, &msg) != nil {
+			return
+		}
+		if msg.Action != cmn.ActSwitchover {
+			t.invalmsghdlrf(w, r, "%s: read-only shadow does not support %q", t.si, msg.Action)
+			return
+		}
+		if err := checkSwitchoverAuth(r, cmn.GCO.Get()); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err := t.stopShadowed(); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		go t.switchover()
+	default:
+		t.invalmsghdlrf(w, r, "%s: read-only shadow does not support %s(daemon)", t.si, r.Method)
+	}
+}
+
+// GET /v1/health
+func (t *targetrunner) shadowHealthHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		t.invalmsghdlrf(w, r, "%s: invalid %s(health)", t.si, r.Method)
+	}
+}
+
+// checkSwitchoverAuth requires a valid admin token or, with authentication
+// disabled, a request from the local host
+func checkSwitchoverAuth(r *http.Request, config *cmn.Config) error {
+	if !config.Auth.Enabled {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			return fmt.Errorf("switchover from %s is not allowed (local host only)", r.RemoteAddr)
+		}
+		return nil
+	}
+	// NOTE: not knowing the revoked tokens (see authManager), the shadow relies
+	// on the token's expiration time
+	authToken := r.Header.Get(cmn.HeaderAuthorization)
+	idx := strings.Index(authToken, " ")
+	if idx == -1 || authToken[:idx] != cmn.HeaderBearer {
+		return errInvalidToken
+	}
+	token, err := cmn.DecryptToken(authToken[idx+1:], config.Auth.Secret)
+	if err != nil || token.Expires.Before(time.Now()) {
+		return errInvalidToken
+	}
+	if !token.IsAdmin {
+		return cmn.ErrNoPermissions
+	}
+	return nil
+}
+
+// stopShadowed shuts down the shadowed target (that stays in the Smap - see
+// cmn.ActSwitchover in tgtcp.go) and waits for it to release all its
+// listening ports
+func (t *targetrunner) stopShadowed() error {
+	var (
+		config = cmn.GCO.Get()
+		addrs  = []string{t.si.PublicNet.NodeIPAddr + ":" + t.si.PublicNet.DaemonPort}
+		args   = callArgs{
+			si: t.si,
+			req: cmn.ReqArgs{
+				Method: http.MethodPut,
+				Path:   cmn.JoinWords(cmn.Version, cmn.Daemon),
+				Body:   cmn.MustMarshal(&cmn.ActionMsg{Action: cmn.ActSwitchover}),
+			},
+			timeout: cmn.DefaultTimeout,
+		}
+	)
+	if config.Net.UseIntraControl {
+		addrs = append(addrs, t.si.IntraControlNet.NodeIPAddr+":"+t.si.IntraControlNet.DaemonPort)
+	}
+	if config.Net.UseIntraData {
+		addrs = append(addrs, t.si.IntraDataNet.NodeIPAddr+":"+t.si.IntraDataNet.DaemonPort)
+	}
+	glog.Infof("%s (shadow): switchover - shutting down the shadowed target", t.si)
+	res := t.call(args)
+	if res.status == http.StatusBadRequest {
+		// older version that does not support switchover - leaves the cluster
+		glog.Warningf("%s (shadow): switchover not supported by the shadowed target (%v) - shutting it down",
+			t.si, res.err)
+		args.req.Body = cmn.MustMarshal(&cmn.ActionMsg{Action: cmn.ActShutdown})
+		res = t.call(args)
+	}
+	if res.err != nil && !cmn.IsErrConnectionRefused(res.err) {
+		return res.err
+	}
+	for _, addr := range addrs {
+		deadline := time.Now().Add(switchoverTimeout)
+		for {
+			conn, err := net.DialTimeout("tcp", addr, time.Second)
+			if err != nil {
+				break
+			}
+			conn.Close()
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out waiting for the shadowed target to release %s", addr)
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
+}
+
+// switchover re-executes aisnode as the regular target
+func (t *targetrunner) switchover() {
+	time.Sleep(time.Second) // to respond to the caller
+	exe, err := os.Executable()
+	if err != nil {
+		cmn.ExitLogf("%s (shadow): switchover failed: %v", t.si, err)
+	}
+	args := append([]string{os.Args[0]}, withoutShadowArgs(os.Args[1:])...)
+	glog.Infof("%s (shadow): switchover - executing %s %v", t.si, exe, args[1:])
+	glog.Flush()
+	if err := syscall.Exec(exe, args, os.Environ()); err != nil {
+		cmn.ExitLogf("%s (shadow): switchover failed: %v", t.si, err)
+	}
+}
+
+func withoutShadowArgs(args []string) (out []string) {
+	out = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name := strings.TrimLeft(args[i], "-")
+		hasValue := strings.Contains(name, "=")
+		if hasValue {
+			name = name[:strings.IndexByte(name, '=')]
+		}
+		switch name {
+		case "shadow":
+		case "shadow_port":
+			if !hasValue {
+				i++ // -shadow_port PORT
+			}
+		default:
+			out = append(out, args[i])
+		}
+	}
+	return
+}
@@ -0,0 +1,75 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/dgrijalva/jwt-go"
+)
+
+func TestWithoutShadowArgs(t *testing.T) {
+	tests := []struct {
+		args, out []string
+	}{
+		{[]string{"-role=target", "-config=/etc/ais.json"}, []string{"-role=target", "-config=/etc/ais.json"}},
+		{[]string{"-role=target", "-shadow", "-shadow_port=9090"}, []string{"-role=target"}},
+		{[]string{"--shadow", "-shadow_port", "9090", "-config", "ais.json"}, []string{"-config", "ais.json"}},
+		{[]string{"-shadow=true", "--shadow_port=9090", "-role=target"}, []string{"-role=target"}},
+	}
+	for _, test := range tests {
+		if out := withoutShadowArgs(test.args); !reflect.DeepEqual(out, test.out) {
+			t.Errorf("%v: expected %v, got %v", test.args, test.out, out)
+		}
+	}
+}
+
+func TestCheckSwitchoverAuth(t *testing.T) {
+	const secret = "shadow-secret"
+	token := func(secret string) string {
+		tk := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"username": "user",
+			"expires":  time.Now().Add(time.Hour),
+			"admin":    true,
+		})
+		s, err := tk.SignedString([]byte(secret))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	request := func(remoteAddr, token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPut, "/v1/daemon", nil)
+		r.RemoteAddr = remoteAddr
+		if token != "" {
+			r.Header.Set(cmn.HeaderAuthorization, cmn.HeaderBearer+" "+token)
+		}
+		return r
+	}
+	config := &cmn.Config{}
+
+	// authentication disabled: local host only
+	for addr, allowed := range map[string]bool{
+		"127.0.0.1:40000": true, "[::1]:40000": true, "10.0.0.5:40000": false, "invalid": false,
+	} {
+		if err := checkSwitchoverAuth(request(addr, ""), config); (err == nil) != allowed {
+			t.Errorf("%s: expected allowed=%t, got err: %v", addr, allowed, err)
+		}
+	}
+
+	// authentication enabled: a valid token required, even from the local host
+	config.Auth.Enabled = true
+	config.Auth.Secret = secret
+	for name, token := range map[string]string{"no token": "", "wrong secret": token("other"), "malformed": "xyz"} {
+		if err := checkSwitchoverAuth(request("127.0.0.1:40000", token), config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
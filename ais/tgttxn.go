@@ -179,21 +179,23 @@ func (t *targetrunner) makeNCopies(c *txnServerCtx) error {
 	case cmn.ActAbort:
 		t.transactions.find(c.uuid, cmn.ActAbort)
 	case cmn.ActCommit:
-		copies, _ := t.parseNCopies(c.msg.Value)
+		mncMsg, _ := t.parseMNCMsg(c.msg.Value)
 		txn, err := t.transactions.find(c.uuid, "")
 		if err != nil {
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
 		txnMnc := txn.(*txnMakeNCopies)
-		cmn.Assert(txnMnc.newCopies == copies)
+		cmn.Assert(txnMnc.newCopies == int64(mncMsg.Copies))
 
-		// wait for newBMD w/timeout
-		if err = t.transactions.wait(txn, c.timeout); err != nil {
+		if mncMsg.Prefix != "" || mncMsg.Template != "" {
+			// scoped: BMD does not change
+			t.transactions.find(c.uuid, cmn.ActCommit)
+		} else if err = t.transactions.wait(txn, c.timeout); err != nil { // wait for newBMD w/timeout
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
 
 		// do the work in xaction
-		xact, err := registry.Registry.RenewBckMakeNCopies(t, c.bck, c.uuid, int(copies))
+		xact, err := registry.Registry.RenewBckMakeNCopies(t, c.bck, c.uuid, mncMsg)
 		if err != nil {
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
@@ -210,9 +212,13 @@ func (t *targetrunner) makeNCopies(c *txnServerCtx) error {
 
 func (t *targetrunner) validateMakeNCopies(bck *cluster.Bck, msg *aisMsg) (curCopies, newCopies int64, err error) {
 	curCopies = bck.Props.Mirror.Copies
-	newCopies, err = t.parseNCopies(msg.Value)
+	mncMsg, err := t.parseMNCMsg(msg.Value)
+	if err == nil {
+		newCopies = int64(mncMsg.Copies)
+		err = mirror.ValidateNCopies(t.si.Name(), mncMsg.Copies)
+	}
 	if err == nil {
-		err = mirror.ValidateNCopies(t.si.Name(), int(newCopies))
+		_, err = mirror.NewScopeFilter(mncMsg)
 	}
 	// NOTE: #791 "limited coexistence" here and elsewhere
 	if err == nil {
@@ -268,9 +274,14 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 		if err = t.transactions.wait(txn, c.timeout); err != nil {
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
-		if reMirror(txnSetBprops.bprops, txnSetBprops.nprops) {
-			n := int(txnSetBprops.nprops.Mirror.Copies)
-			xact, err := registry.Registry.RenewBckMakeNCopies(t, c.bck, c.uuid, n)
+		var (
+			bprops, nprops = txnSetBprops.bprops, txnSetBprops.nprops
+			needReMirror   = reMirror(bprops, nprops)
+			needReEC       = reEC(bprops, nprops, c.bck)
+		)
+		if needReMirror {
+			mncMsg := &cmn.MNCMsg{Copies: int(txnSetBprops.nprops.Mirror.Copies)}
+			xact, err := registry.Registry.RenewBckMakeNCopies(t, c.bck, c.uuid, mncMsg)
 			if err != nil {
 				return fmt.Errorf("%s %s: %v", t.si, txn, err)
 			}
@@ -279,7 +290,7 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 			c.addNotif(xact) // notify upon completion
 			go xact.Run()
 		}
-		if reEC(txnSetBprops.bprops, txnSetBprops.nprops, c.bck) {
+		if needReEC {
 			registry.Registry.DoAbort(cmn.ActECEncode, c.bck)
 			xact, err := registry.Registry.RenewECEncode(t, c.bck, c.uuid, cmn.ActCommit)
 			if err != nil {
@@ -289,6 +300,21 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 			c.addNotif(xact) // ditto
 			go xact.Run()
 		}
+		if reIndexMD(bprops, nprops) {
+			// (the ID and the notification go to re-mirror or re-EC, if any - see proxy)
+			uuid := c.uuid
+			if needReMirror || needReEC {
+				uuid = cmn.GenUUID()
+			}
+			xact, err := registry.Registry.RenewBckMDIndex(t, c.bck, uuid)
+			if err != nil {
+				return fmt.Errorf("%s %s: %v", t.si, txn, err)
+			}
+			if uuid == c.uuid {
+				c.addNotif(xact)
+			}
+			go xact.Run()
+		}
 	default:
 		cmn.Assert(false)
 	}
@@ -315,8 +341,12 @@ func (t *targetrunner) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cm
 			return nprops, cs.Err
 		}
 	}
-	if nprops.EC.Enabled && !bck.Props.EC.Enabled {
-		err = cs.Err
+	if nprops.EC.Enabled {
+		// enabling EC or re-encoding with more redundancy (parity/data)
+		bconf, nconf := &bck.Props.EC, &nprops.EC
+		if !bconf.Enabled || nconf.ParitySlices*bconf.DataSlices > bconf.ParitySlices*nconf.DataSlices {
+			err = cs.Err
+		}
 	}
 	return
 }
@@ -0,0 +1,157 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/evsink"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/replication"
+)
+
+// Bulk delete with undo window (cmn.URLParamUndoWindow): objects get moved
+// to trash (see evictDelete xaction and fs.MoveToUndo) and can be restored
+// via cmn.ActUndoDelete with the xaction's UUID as the undo token.
+
+const undoPurgeInterval = time.Minute
+
+type undoArgs struct {
+	token    string
+	deadline time.Time
+}
+
+func (t *targetrunner) initUndo() {
+	hk.Reg("undo-delete", func() time.Duration {
+		fs.PurgeUndo()
+		return undoPurgeInterval
+	}, undoPurgeInterval)
+}
+
+// TrashObject deletes the object (see DeleteObject) moving it into the
+// mountpath's trash, to be restored by the token or purged when the undo
+// window expires.
+func (t *targetrunner) TrashObject(ctx context.Context, lom *cluster.LOM, token string, deadline time.Time) error {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	err, _ := t.deleteObject(ctx, lom, false /*evict*/, &undoArgs{token: token, deadline: deadline})
+	return err
+}
+
+// trash is the counterpart of lom.Remove: the copies, if any, get removed and
+// a deduplicated object releases the CAS file (see cluster.LOM.DedupBreak) -
+// the trash must not hold on to it
+func (t *targetrunner) trash(lom *cluster.LOM, undo *undoArgs) error {
+	if err := lom.DelAllCopies(); err != nil {
+		return err
+	}
+	if err := lom.DedupBreak(); err != nil {
+		return err
+	}
+	lom.Uncache()
+	return lom.ParsedFQN.MpathInfo.MoveToUndo(lom.FQN, undo.token, undo.deadline)
+}
+
+// undoDelete restores the objects deleted by a given (bulk delete) xaction;
+// objects that were created again in the meantime are not overwritten
+func (t *targetrunner) undoDelete(token string) (restored int64, err error) {
+	err = fs.UndoWalk(token, func(trashFQN, fqn string) error {
+		lom := &cluster.LOM{T: t, FQN: fqn}
+		if err := lom.Init(cmn.Bck{}); err != nil {
+			glog.Errorf("%s: cannot restore %s: %v", t.si, fqn, err)
+			return nil
+		}
+		// the bucket may have become read-only (or lost PUT access) since
+		if err := lom.Bck().Allow(cmn.AccessPUT); err != nil {
+			return err
+		}
+		ok, err := t.restore(lom, trashFQN)
+		if err != nil {
+			return err
+		}
+		if ok {
+			restored++
+			// same as upon PUT
+			t.putMirror(lom)
+			t.indexMD(lom)
+			t.publishEvent(lom, evsink.OpPut)
+			if err := t.replicate(lom, replication.OpPut); err != nil {
+				glog.Errorf("%s: failed to replicate restored %s: %v", t.si, lom, err)
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		fs.RemoveUndo(token) // leftovers, if any
+		glog.Infof("%s: undo %q: restored %d object(s)", t.si, token, restored)
+	}
+	return
+}
+
+func (t *targetrunner) restore(lom *cluster.LOM, trashFQN string) (ok bool, err error) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err == nil {
+		glog.Warningf("%s: %s already exists - not restoring", t.si, lom)
+		return false, nil
+	}
+	// the (virtual) directory may have been removed along with its last object
+	if err = cmn.CreateDir(filepath.Dir(lom.FQN)); err != nil {
+		return
+	}
+	if err = os.Rename(trashFQN, lom.FQN); err != nil {
+		return
+	}
+	lom.Uncache()
+	err = lom.Load(false)
+	return err == nil, err
+}
+
+func (t *targetrunner) undoDeleteHandler(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	token, ok := msg.Value.(string)
+	if !ok || token == "" {
+		t.invalmsghdlrf(w, r, "%s: invalid undo token (%v, %T)", msg.Action, msg.Value, msg.Value)
+		return
+	}
+	restored, err := t.undoDelete(token)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), undoErrCode(err))
+		return
+	}
+	t.writeJSON(w, r, restored, msg.Action)
+}
+
+// GET /v1/daemon?what=undo_bck&uuid=token - the proxy uses it to check access
+// to the bucket before undoing
+func (t *targetrunner) undoBckHandler(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get(cmn.URLParamUUID)
+	bck, err := fs.UndoBck(token)
+	if err != nil {
+		t.invalmsghdlrsilent(w, r, err.Error(), undoErrCode(err))
+		return
+	}
+	t.writeJSON(w, r, bck, "undo-bck")
+}
+
+func undoErrCode(err error) int {
+	switch err.(type) {
+	case *cmn.NotFoundError:
+		return http.StatusNotFound
+	case *cmn.BucketAccessDenied, *cmn.BucketReadOnlyError:
+		return accessErrCode(err)
+	}
+	if err == fs.ErrUndoExpired {
+		return http.StatusGone
+	}
+	return http.StatusInternalServerError
+}
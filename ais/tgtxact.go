@@ -12,6 +12,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/registry"
@@ -83,6 +84,10 @@ func (t *targetrunner) xactHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			registry.Registry.DoAbort(xactMsg.Kind, bck)
 			return
+		case cmn.ActXactLimits:
+			if err, status := t.cmdXactLimits(&xactMsg); err != nil {
+				t.invalmsghdlr(w, r, err.Error(), status)
+			}
 		default:
 			t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 		}
@@ -123,6 +128,34 @@ func (t *targetrunner) queryMatchingXact(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// updates limits of a running xaction or download job (the latter is not an
+// xaction - download jobs are executed by the downloader xaction)
+func (t *targetrunner) cmdXactLimits(xactMsg *xaction.XactReqMsg) (error, int) {
+	if xactMsg.ID == "" || xactMsg.Limits == nil {
+		return fmt.Errorf("%q requires job ID and limits", cmn.ActXactLimits), http.StatusBadRequest
+	}
+	if xact := registry.Registry.GetXact(xactMsg.ID); xact != nil {
+		limiter, ok := xact.(xaction.Limiter)
+		if !ok {
+			return fmt.Errorf("%s does not support updating limits at runtime", xact), http.StatusBadRequest
+		}
+		perTarget := *xactMsg.Limits
+		if perTarget.BytesPerHour > 0 {
+			perTarget.BytesPerHour = cmn.Max(perTarget.BytesPerHour/t.owner.smap.get().CountTargets(), 1)
+		}
+		if err := limiter.SetLimits(&perTarget); err != nil {
+			return err, http.StatusBadRequest
+		}
+		return nil, http.StatusOK
+	}
+	xact, err := registry.Registry.RenewDownloader(t, t.statsT)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	_, err, status := xact.(*downloader.Downloader).SetJobLimits(xactMsg.ID, xactMsg.Limits)
+	return err, status
+}
+
 func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bck) error {
 	const erfmb = "global xaction %q does not require bucket (%s) - ignoring it and proceeding to start"
 	const erfmn = "xaction %q requires a bucket to start"
@@ -133,6 +166,12 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
 		}
 		go t.RunLRU(xactMsg.ID, xactMsg.Force != nil && *xactMsg.Force, xactMsg.Buckets...)
+	case cmn.ActLifecycle:
+		bcks := xactMsg.Buckets
+		if bck != nil { // (optional) enforce the rules of a given bucket only
+			bcks = append(bcks, bck.Bck)
+		}
+		go t.runLifecycle(xactMsg.ID, bcks...)
 	case cmn.ActResilver:
 		if bck != nil {
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
@@ -145,6 +184,21 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			},
 		}
 		go t.runResilver(xactMsg.ID, false /*skipGlobMisplaced*/, notif)
+	case cmn.ActEvacuate:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		return t.startEvacuate(xactMsg)
+	case cmn.ActPrefill:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		return t.startPrefill(xactMsg)
+	case cmn.ActBackupBck, cmn.ActRestoreBck:
+		if bck == nil {
+			return fmt.Errorf(erfmn, xactMsg.Kind)
+		}
+		return t.startBackup(xactMsg, bck)
 	// 2. with bucket
 	case cmn.ActPrefetch:
 		if bck == nil {
@@ -163,6 +217,22 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			},
 		})
 		go xact.Run()
+	case cmn.ActRehash:
+		if bck == nil {
+			return fmt.Errorf(erfmn, xactMsg.Kind)
+		}
+		xact, err := registry.Registry.RenewBckRehash(t, bck, xactMsg.ID)
+		if err != nil {
+			return err
+		}
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+		})
+		go xact.Run()
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
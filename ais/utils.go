@@ -31,6 +31,14 @@ func isRedirect(q url.Values) (delta string) {
 	return q.Get(cmn.URLParamUnixTime)
 }
 
+// returns the request headers if the GET is conditional (see cmn.NotModified)
+func condGetHdr(r *http.Request) http.Header {
+	if r.Header.Get(cmn.HeaderIfNoneMatch) == "" && r.Header.Get(cmn.HeaderIfModifiedSince) == "" {
+		return nil
+	}
+	return r.Header
+}
+
 func redirectLatency(started time.Time, ptime string) (redelta int64) {
 	pts, err := cmn.S2UnixNano(ptime)
 	if err != nil {
@@ -185,6 +193,14 @@ func reMirror(bprops, nprops *cmn.BucketProps) bool {
 	return false
 }
 
+// (re)index the objects that are already stored - see mirror/mdindex
+func reIndexMD(bprops, nprops *cmn.BucketProps) bool {
+	if !nprops.MDIndex.Enabled {
+		return false
+	}
+	return !bprops.MDIndex.Enabled || bprops.MDIndex.Keys != nprops.MDIndex.Keys
+}
+
 func reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck) bool {
 	// TODO: xaction to remove all data generated by EC encoder.
 	// For now, do nothing if EC is disabled.
@@ -202,6 +218,14 @@ func reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck) bool {
 		bprops.EC.ParitySlices != nprops.EC.ParitySlices
 }
 
+// bucket access denied: 403, unless the bucket is read-only (423)
+func accessErrCode(err error) int {
+	if _, ok := err.(*cmn.BucketReadOnlyError); ok {
+		return http.StatusLocked
+	}
+	return http.StatusForbidden
+}
+
 func withRetry(cond func() bool) (ok bool) {
 	if ok = cond(); !ok {
 		time.Sleep(time.Second)
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"crypto/hmac"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -22,6 +23,8 @@ const (
 	VoteNo  Vote = "NO"
 )
 
+const witnessID = "witness" // see cmn.ProxyConf.WitnessURL
+
 type (
 	Vote string
 
@@ -69,6 +72,8 @@ func (p *proxyrunner) voteHandler(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case r.Method == http.MethodGet && apiItems[0] == cmn.Proxy:
 		p.httpproxyvote(w, r)
+	case r.Method == http.MethodGet && apiItems[0] == cmn.VotePre:
+		p.httpprevote(w, r)
 	case r.Method == http.MethodPut && apiItems[0] == cmn.Voteres:
 		p.httpsetprimaryproxy(w, r)
 	case r.Method == http.MethodPut && apiItems[0] == cmn.VoteInit:
@@ -161,11 +166,21 @@ func (p *proxyrunner) doProxyElection(vr *VoteRecord, curPrimary *cluster.Snode,
 	if err == nil {
 		// move back to idle
 		glog.Infof("%s: the current primary %s is up, moving back to idle", p.si, curPrimary)
+		p.elections.add(cmn.ElectionEvent{Event: cmn.ElectionPrimaryAlive, Candidate: vr.Candidate, Primary: vr.Primary})
 		return
 	}
 	glog.Infof("%s: primary %s is confirmed down(%v)", p.si, curPrimary, err)
 
-	// 2. election phase 1
+	// 2. pre-vote: the others must not be able to reach the primary either -
+	//    not to disrupt the cluster upon a transient partition that affects only this proxy
+	glog.Info("Moving to election state phase 0 (pre-vote)")
+	if !p.preVote(vr) {
+		glog.Errorf("%s: election phase 0 (pre-vote) failed: primary remains %s, moving back to idle",
+			p.si, curPrimary)
+		return
+	}
+
+	// 3. election phase 1
 	glog.Info("Moving to election state phase 1 (prepare)")
 	elected, votingErrors := p.electAmongProxies(vr, xact)
 	if !elected {
@@ -174,7 +189,7 @@ func (p *proxyrunner) doProxyElection(vr *VoteRecord, curPrimary *cluster.Snode,
 		return
 	}
 
-	// 3. election phase 2
+	// 4. election phase 2
 	glog.Info("Moving to election state phase 2 (commit)")
 	confirmationErrors := p.confirmElectionVictory(vr)
 	for sid := range confirmationErrors {
@@ -184,18 +199,60 @@ func (p *proxyrunner) doProxyElection(vr *VoteRecord, curPrimary *cluster.Snode,
 		}
 	}
 
-	// 4. become!
+	// 5. become!
 	glog.Infof("%s: moving (self) to primary state", p.si)
+	ev := cmn.ElectionEvent{Event: cmn.ElectionElected, Candidate: vr.Candidate, Primary: vr.Primary}
+	if len(confirmationErrors) > 0 {
+		ev.Msg = fmt.Sprintf("failed to confirm with %d node(s)", len(confirmationErrors))
+	}
+	p.elections.add(ev)
 	p.becomeNewPrimary(vr.Primary /* proxyIDToRemove */)
 }
 
+// preVote asks all nodes (and the witness, if configured) whether they'd support
+// the election - see httpprevote
+func (p *proxyrunner) preVote(vr *VoteRecord) (winner bool) {
+	var (
+		y, n    int
+		witness Vote
+	)
+	for res := range p.requestVotes(vr, cmn.VotePre) {
+		switch {
+		case res.daemonID == witnessID:
+			witness = res.witnessVote()
+		case res.err != nil:
+			if res.daemonID != vr.Primary {
+				glog.Warningf("Pre-vote: error response from %s, err: %v", res.daemonID, res.err)
+			}
+			n++
+		case res.yes:
+			y++
+		default:
+			n++
+		}
+	}
+	winner = electionWon(y, n, witness)
+	glog.Infof("Pre-vote results: Y: %d, N: %d, witness: %q, continue: %v", y, n, witness, winner)
+	if !winner {
+		p.elections.add(cmn.ElectionEvent{
+			Event: cmn.ElectionPreVoteLost, Candidate: vr.Candidate, Primary: vr.Primary, Yes: y, No: n,
+		})
+	}
+	return
+}
+
 func (p *proxyrunner) electAmongProxies(vr *VoteRecord, xact cluster.Xact) (winner bool, errors map[string]bool) {
-	// Simple Majority Vote
-	resch := p.requestVotes(vr)
+	var (
+		resch   = p.requestVotes(vr, cmn.Proxy)
+		y, n    int
+		witness Vote
+	)
 	errors = make(map[string]bool)
-	y, n := 0, 0
-
 	for res := range resch {
+		if res.daemonID == witnessID {
+			witness = res.witnessVote()
+			continue
+		}
 		if res.err != nil {
 			if cmn.IsErrConnectionRefused(res.err) {
 				if res.daemonID == vr.Primary {
@@ -222,19 +279,52 @@ func (p *proxyrunner) electAmongProxies(vr *VoteRecord, xact cluster.Xact) (winn
 	}
 
 	xact.ObjectsAdd(int64(y + n))
-	winner = y > n || (y+n == 0) // No Votes: Default Winner
-	glog.Infof("Vote Results:\n Y: %v, N:%v\n Victory: %v\n", y, n, winner)
+	winner = electionWon(y, n, witness)
+	glog.Infof("Vote Results:\n Y: %v, N:%v, witness: %q\n Victory: %v\n", y, n, witness, winner)
+	if !winner {
+		p.elections.add(cmn.ElectionEvent{
+			Event: cmn.ElectionVoteLost, Candidate: vr.Candidate, Primary: vr.Primary, Yes: y, No: n,
+		})
+	}
 	return
 }
 
-func (p *proxyrunner) requestVotes(vr *VoteRecord) chan voteResult {
+// electionWon requires a strict majority of the voting nodes - the candidate
+// included - with the witness (if configured) breaking a tie. The configured
+// quorum (cmn.ProxyConf.ElectionQuorum), if any, is an additional minimum
+// number of Yes votes that includes the candidate's and the witness's.
+func electionWon(y, n int, witness Vote) bool {
+	y++ // the candidate's own
+	won := y > n || (y == n && witness == VoteYes)
+	if witness == VoteYes {
+		y++
+	}
+	if quorum := cmn.GCO.Get().Proxy.ElectionQuorum; quorum > 0 && y < quorum {
+		return false
+	}
+	return won
+}
+
+// requestVotes requests (pre-)votes from all nodes and the witness, if configured
+func (p *proxyrunner) requestVotes(vr *VoteRecord, what string) chan voteResult {
 	var (
-		msg = VoteMessage{Record: *vr}
-		q   = url.Values{}
+		msg     = VoteMessage{Record: *vr}
+		q       = url.Values{}
+		body    = cmn.MustMarshal(&msg)
+		path    = cmn.JoinWords(cmn.Version, cmn.Vote, what)
+		config  = cmn.GCO.Get()
+		timeout = config.Timeout.CplaneOperation
 	)
 	q.Set(cmn.URLParamPrimaryCandidate, p.si.ID())
-	results := p.callAll(http.MethodGet, cmn.JoinWords(cmn.Version, cmn.Vote, cmn.Proxy), cmn.MustMarshal(&msg), q)
-	resCh := make(chan voteResult, len(results))
+	if what == cmn.VotePre {
+		timeout *= 2 // (the voters ping the primary)
+	}
+	results := p.bcastToGroup(bcastArgs{
+		req:     cmn.ReqArgs{Method: http.MethodGet, Path: path, Body: body, Query: q},
+		timeout: timeout,
+		to:      cluster.AllNodes,
+	})
+	resCh := make(chan voteResult, len(results)+1)
 	for res := range results {
 		if res.err != nil {
 			resCh <- voteResult{
@@ -250,11 +340,50 @@ func (p *proxyrunner) requestVotes(vr *VoteRecord) chan voteResult {
 			}
 		}
 	}
+	if config.Proxy.WitnessURL != "" {
+		resCh <- p.callWitness(&config.Proxy, path, body, q, timeout)
+	}
 
 	close(resCh)
 	return resCh
 }
 
+// callWitness requests the witness's (pre-)vote; both the request and the vote
+// are signed with the shared secret (see cmn.WitnessSig).
+func (p *proxyrunner) callWitness(conf *cmn.ProxyConf, path string, body []byte, q url.Values,
+	timeout time.Duration) voteResult {
+	hdr := http.Header{}
+	hdr.Set(cmn.HeaderWitnessSig, cmn.WitnessSig(conf.WitnessSecret, body))
+	res := p.call(callArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodGet, Base: conf.WitnessURL, Path: path, Body: body, Query: q, Header: hdr,
+		},
+		timeout: timeout,
+	})
+	vr := voteResult{daemonID: witnessID, err: res.err}
+	if res.err != nil {
+		return vr
+	}
+	sig := cmn.WitnessSig(conf.WitnessSecret, body, res.bytes)
+	if !hmac.Equal([]byte(sig), []byte(res.header.Get(cmn.HeaderWitnessSig))) {
+		vr.err = fmt.Errorf("%s: invalid vote signature", witnessID)
+		return vr
+	}
+	vr.yes = VoteYes == Vote(res.bytes)
+	return vr
+}
+
+func (res *voteResult) witnessVote() Vote {
+	if res.err != nil {
+		glog.Warningf("Error response from %s, err: %v", witnessID, res.err)
+		return VoteNo
+	}
+	if res.yes {
+		return VoteYes
+	}
+	return VoteNo
+}
+
 func (p *proxyrunner) confirmElectionVictory(vr *VoteRecord) map[string]bool {
 	msg := &VoteResultMessage{
 		VoteResult{
@@ -350,6 +479,8 @@ func (t *targetrunner) voteHandler(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case r.Method == http.MethodGet && apiItems[0] == cmn.Proxy:
 		t.httpproxyvote(w, r)
+	case r.Method == http.MethodGet && apiItems[0] == cmn.VotePre:
+		t.httpprevote(w, r)
 	case r.Method == http.MethodPut && apiItems[0] == cmn.Voteres:
 		t.httpsetprimaryproxy(w, r)
 	default:
@@ -459,6 +590,13 @@ func (h *httprunner) httpproxyvote(w http.ResponseWriter, r *http.Request) {
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("Proxy voted '%v' for %s", vote, psi)
 	}
+	ev := cmn.ElectionEvent{Event: cmn.ElectionVoted, Candidate: candidate, Primary: currPrimaryID}
+	if vote {
+		ev.Yes = 1
+	} else {
+		ev.No = 1
+	}
+	h.elections.add(ev)
 
 	if vote {
 		_, err = w.Write([]byte(VoteYes))
@@ -473,6 +611,57 @@ func (h *httprunner) httpproxyvote(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GET /v1/vote/prevote
+// Unlike the vote (above), pre-vote neither changes the local state (Smap) nor
+// relies on the candidate's view: the node supports the election only if it
+// cannot reach the primary on its own.
+func (h *httprunner) httpprevote(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Vote, cmn.VotePre); err != nil {
+		return
+	}
+	msg := VoteMessage{}
+	if err := cmn.ReadJSON(w, r, &msg); err != nil {
+		return
+	}
+	if msg.Record.Candidate == "" {
+		h.invalmsghdlr(w, r, "Cannot request pre-vote without Candidate field")
+		return
+	}
+	vote, reason := h.preVoteOnProxy(msg.Record.Primary)
+	ev := cmn.ElectionEvent{
+		Event: cmn.ElectionPreVoted, Candidate: msg.Record.Candidate, Primary: msg.Record.Primary, Msg: reason,
+	}
+	if vote == VoteYes {
+		ev.Yes = 1
+	} else {
+		ev.No = 1
+	}
+	h.elections.add(ev)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: pre-voted '%s' for %s: %s", h.si, vote, msg.Record.Candidate, reason)
+	}
+	if _, err := w.Write([]byte(vote)); err != nil {
+		glog.Errorf("Error writing pre-vote: %v", err)
+	}
+}
+
+func (h *httprunner) preVoteOnProxy(primaryID string) (Vote, string) {
+	smap := h.owner.smap.get()
+	if smap.Primary == nil {
+		return VoteNo, "primary undefined"
+	}
+	if smap.Primary.ID() != primaryID {
+		return VoteNo, fmt.Sprintf("the primary is %s", smap.Primary.ID())
+	}
+	if !h.keepalive.isTimeToPing(primaryID) {
+		return VoteNo, "the primary is alive (keepalive)"
+	}
+	if _, err, _ := h.Health(smap.Primary, cmn.GCO.Get().Timeout.CplaneOperation, nil); err == nil {
+		return VoteNo, "the primary is alive (health)"
+	}
+	return VoteYes, "the primary is unreachable"
+}
+
 // PUT /v1/vote/result
 func (h *httprunner) httpsetprimaryproxy(w http.ResponseWriter, r *http.Request) {
 	if _, err := h.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Vote, cmn.Voteres); err != nil {
@@ -491,6 +680,11 @@ func (h *httprunner) httpsetprimaryproxy(w http.ResponseWriter, r *http.Request)
 		sid: vr.Primary,
 	}
 	err := h.owner.smap.modify(ctx)
+	ev := cmn.ElectionEvent{Event: cmn.ElectionNewPrimary, Candidate: vr.Candidate, Primary: vr.Primary}
+	if err != nil {
+		ev.Msg = err.Error()
+	}
+	h.elections.add(ev)
 	if err != nil {
 		h.invalmsghdlr(w, r, err.Error())
 	}
@@ -0,0 +1,47 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const electionHistoryLen = 64
+
+// electionHistory keeps the most recent election events (see cmn.ElectionEvent).
+type electionHistory struct {
+	mu     sync.Mutex
+	events []cmn.ElectionEvent // ring buffer
+	next   int
+}
+
+func (eh *electionHistory) add(ev cmn.ElectionEvent) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	eh.mu.Lock()
+	if len(eh.events) < electionHistoryLen {
+		eh.events = append(eh.events, ev)
+	} else {
+		eh.events[eh.next] = ev
+	}
+	eh.next = (eh.next + 1) % electionHistoryLen
+	eh.mu.Unlock()
+}
+
+// get returns the events, oldest first
+func (eh *electionHistory) get() []cmn.ElectionEvent {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	events := make([]cmn.ElectionEvent, 0, len(eh.events))
+	if len(eh.events) == electionHistoryLen {
+		events = append(events, eh.events[eh.next:]...)
+		return append(events, eh.events[:eh.next]...)
+	}
+	return append(events, eh.events...)
+}
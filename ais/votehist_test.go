@@ -0,0 +1,73 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestElectionHistory(t *testing.T) {
+	eh := &electionHistory{}
+	if events := eh.get(); len(events) != 0 {
+		t.Fatalf("expected empty history, got %d events", len(events))
+	}
+	for i := 0; i < electionHistoryLen+5; i++ {
+		eh.add(cmn.ElectionEvent{Event: cmn.ElectionVoted, Candidate: strconv.Itoa(i)})
+	}
+	events := eh.get()
+	if len(events) != electionHistoryLen {
+		t.Fatalf("expected %d events, got %d", electionHistoryLen, len(events))
+	}
+	for i, ev := range events {
+		if ev.Candidate != strconv.Itoa(i+5) {
+			t.Errorf("event %d: expected candidate %d, got %s", i, i+5, ev.Candidate)
+		}
+		if ev.Time.IsZero() {
+			t.Errorf("event %d: time not set", i)
+		}
+	}
+}
+
+func TestElectionWon(t *testing.T) {
+	setQuorum := func(quorum int) {
+		config := cmn.GCO.BeginUpdate()
+		config.Proxy.ElectionQuorum = quorum
+		cmn.GCO.CommitUpdate(config)
+	}
+	defer setQuorum(0)
+
+	tests := []struct {
+		y, n, quorum int
+		witness      Vote
+		won          bool
+	}{
+		{0, 0, 0, "", true}, // no voters
+		{2, 1, 0, "", true},
+		{1, 1, 0, "", true},
+		{1, 2, 0, "", false},
+		{0, 1, 0, "", false},     // two proxies: a tie...
+		{0, 1, 0, VoteYes, true}, // ...broken by the witness
+		{0, 1, 0, VoteNo, false},
+		{0, 1, 2, VoteYes, true}, // two proxies and the witness, with quorum
+		{0, 1, 2, VoteNo, false},
+		{2, 1, 0, VoteNo, true}, // the witness breaks ties only
+		{1, 3, 0, VoteYes, false},
+		{2, 3, 3, "", false}, // quorum never overrides the majority
+		{2, 4, 3, VoteYes, false},
+		{4, 3, 3, "", true},
+		{1, 0, 3, "", false},
+		{1, 0, 3, VoteYes, true},
+	}
+	for _, test := range tests {
+		setQuorum(test.quorum)
+		if won := electionWon(test.y, test.n, test.witness); won != test.won {
+			t.Errorf("Y: %d, N: %d, witness: %q, quorum: %d: expected %t, got %t",
+				test.y, test.n, test.witness, test.quorum, test.won, won)
+		}
+	}
+}
@@ -11,14 +11,18 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
 )
 
 const (
 	initialPollInterval = 50 * time.Millisecond
 	maxPollInterval     = 10 * time.Second
+
+	maxListRestarts = 3 // see cmn.IsErrListingInvalidated
 )
 
 type (
@@ -46,6 +50,32 @@ func SetBucketProps(baseParams BaseParams, bck cmn.Bck, props cmn.BucketPropsToU
 	return patchBucketProps(baseParams, bck, b, query...)
 }
 
+// SetBucketPropsIfVersion is the test-and-set variant of SetBucketProps: it
+// fails with http.StatusConflict (see cmn.IsErrBpropsChanged) when the
+// properties of the bucket have changed since the given version - the one
+// returned by HeadBucket as BucketProps.PropsVersion.
+func SetBucketPropsIfVersion(baseParams BaseParams, bck cmn.Bck, props cmn.BucketPropsToUpdate,
+	version int64) (string, error) {
+	q := url.Values{cmn.URLParamBpropsVersion: []string{strconv.FormatInt(version, 10)}}
+	return SetBucketProps(baseParams, bck, props, q)
+}
+
+// PlanBucketProps estimates the cost of setting the properties of a bucket:
+// bytes to read and write, time, and additional capacity needed. Heavy changes
+// (EC, mirroring, checksum) that lack capacity headroom are refused by
+// SetBucketProps unless forced via `cmn.URLParamForce` query parameter.
+func PlanBucketProps(baseParams BaseParams, bck cmn.Bck, props cmn.BucketPropsToUpdate) (plan *cmn.BpropsPlan, err error) {
+	baseParams.Method = http.MethodPost
+	plan = &cmn.BpropsPlan{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActPlanBprops, Value: props}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, plan)
+	return
+}
+
 // ResetBucketProps resets the properties of a bucket to the global configuration.
 func ResetBucketProps(baseParams BaseParams, bck cmn.Bck, query ...url.Values) (string, error) {
 	b := cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActResetBprops})
@@ -123,13 +153,79 @@ func GetBucketsSummaries(baseParams BaseParams, query cmn.QueryBcks, msg *cmn.Bu
 		Query:      cmn.AddBckToQuery(nil, cmn.Bck(query)),
 	}
 	var summaries cmn.BucketsSummaries
-	if err := waitForAsyncReqComplete(reqParams, cmn.ActSummaryBucket, msg, &summaries); err != nil {
+	if err := waitForAsyncReqComplete(reqParams, cmn.ActSummaryBucket, msg, &msg.UUID, &summaries); err != nil {
 		return nil, err
 	}
 	sort.Sort(summaries)
 	return summaries, nil
 }
 
+// AnalyzeBucket walks all the objects of the bucket (that are stored in the cluster)
+// and returns the resulting report that is also stored in the bucket as a JSON object
+// named `msg.Report` (default: "analysis/<task-UUID>.json").
+func AnalyzeBucket(baseParams BaseParams, bck cmn.Bck, msg *cmn.BucketAnalysisMsg) (*cmn.BucketAnalysis, error) {
+	if msg == nil {
+		msg = &cmn.BucketAnalysisMsg{}
+	}
+	baseParams.Method = http.MethodPost
+	reqParams := ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Header:     http.Header{cmn.HeaderContentType: []string{cmn.ContentJSON}},
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}
+	analysis := &cmn.BucketAnalysis{}
+	if err := waitForAsyncReqComplete(reqParams, cmn.ActAnalyzeBucket, msg, &msg.UUID, analysis); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
+
+// GetReplicationStatus returns the status of the bucket's replication to remote
+// AIS cluster: per target and cluster-wide (see cmn.RemoteReplConf).
+func GetReplicationStatus(baseParams BaseParams, bck cmn.Bck) (*cmn.ClusterReplStatus, error) {
+	var (
+		status = &cmn.ClusterReplStatus{}
+		query  = url.Values{cmn.URLParamWhat: []string{cmn.GetWhatReplication}}
+	)
+	query = cmn.AddBckToQuery(query, bck)
+	baseParams.Method = http.MethodGet
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Query:      query,
+	}, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// QueryObjectsMD returns (sorted) names of the bucket's objects whose custom
+// metadata satisfies all the conditions, e.g. "label=cat" and "width>=640"
+// (see cmn.ParseMDCond). The query is answered by the bucket's metadata index
+// (see cmn.MDIndexConf) without listing the bucket. Zero limit - no limit.
+func QueryObjectsMD(baseParams BaseParams, bck cmn.Bck, conds []string, limit int) ([]string, error) {
+	var (
+		names = []string{}
+		query = url.Values{cmn.URLParamWhat: []string{cmn.GetWhatMDQuery}, cmn.URLParamMDCond: conds}
+	)
+	if limit > 0 {
+		query.Set(cmn.URLParamLimit, strconv.Itoa(limit))
+	}
+	query = cmn.AddBckToQuery(query, bck)
+	baseParams.Method = http.MethodGet
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Query:      query,
+	}, &names)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
 // CreateBucket sends a HTTP request to a proxy to create an AIS bucket with the given name.
 func CreateBucket(baseParams BaseParams, bck cmn.Bck, ops ...cmn.BucketPropsToUpdate) error {
 	if len(ops) > 1 {
@@ -148,6 +244,23 @@ func CreateBucket(baseParams BaseParams, bck cmn.Bck, ops ...cmn.BucketPropsToUp
 	})
 }
 
+// CreateBucketFrom creates AIS bucket and populates it from the source: Cloud
+// bucket (that also becomes the bucket's backend) or range template of web
+// links - see downloader.DlBucketSource. Returns the ID of the download job
+// and, if `src.Schedule` is specified, of the schedule that keeps the bucket
+// in sync with the Cloud bucket.
+func CreateBucketFrom(baseParams BaseParams, bck cmn.Bck, src downloader.DlBucketSource) (resp downloader.DlPostResp,
+	err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActCreateLBFrom, Value: src}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &resp)
+	return
+}
+
 // DestroyBucket sends a HTTP request to a proxy to remove an AIS bucket with the given name.
 func DestroyBucket(baseParams BaseParams, bck cmn.Bck) error {
 	baseParams.Method = http.MethodDelete
@@ -159,9 +272,18 @@ func DestroyBucket(baseParams BaseParams, bck cmn.Bck) error {
 	})
 }
 
-// DoesBucketExist queries a proxy or target to get a list of all AIS buckets,
-// returns true if the bucket is present in the list.
+// DoesBucketExist returns true if there's a bucket that matches the query.
+// A fully specified bucket (name and provider) is checked by the proxy, which
+// caches the existence of remote buckets for a short while (see DoBucketsExist);
+// otherwise, the function lists the buckets.
 func DoesBucketExist(baseParams BaseParams, query cmn.QueryBcks) (bool, error) {
+	if query.Name != "" && query.Provider != "" {
+		exist, err := DoBucketsExist(baseParams, []cmn.Bck{cmn.Bck(query)})
+		if err != nil {
+			return false, err
+		}
+		return exist[0], nil
+	}
 	bcks, err := ListBuckets(baseParams, query)
 	if err != nil {
 		return false, err
@@ -169,6 +291,28 @@ func DoesBucketExist(baseParams BaseParams, query cmn.QueryBcks) (bool, error) {
 	return bcks.Contains(query), nil
 }
 
+// DoBucketsExist checks existence of the buckets, in a single request.
+// Each bucket must have both name and provider specified. Existence of remote
+// (Cloud or remote AIS) buckets that are not yet known to the cluster is
+// checked with the remote and cached by the proxy - so that validating lists
+// of buckets doesn't hit the Cloud provider on every call.
+func DoBucketsExist(baseParams BaseParams, bcks []cmn.Bck) ([]bool, error) {
+	exist := make([]bool, 0, len(bcks))
+	baseParams.Method = http.MethodPost
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActBcksExist, Value: bcks}),
+	}, &exist)
+	if err != nil {
+		return nil, err
+	}
+	if len(exist) != len(bcks) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(bcks), len(exist))
+	}
+	return exist, nil
+}
+
 // CopyBucket copies existing `fromBck` bucket to the destination `toBck` thus,
 // effectively, creating a copy of the `fromBck`.
 // * AIS will create `toBck` on the fly but only if the destination bucket does not
@@ -193,6 +337,20 @@ func CopyBucket(baseParams BaseParams, fromBck, toBck cmn.Bck, msgs ...*cmn.Copy
 	return
 }
 
+// ExportBucket packs the bucket (or its objects with the given prefix) into
+// WebDataset-style tar shards and their JSON indexes in the destination
+// bucket (see cmn.ExportMsg); returns the ID of the export xaction.
+func ExportBucket(baseParams BaseParams, bck cmn.Bck, msg *cmn.ExportMsg) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActExportBck, Value: msg}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
 // RenameBucket changes the name of a bucket from `oldBck` to `newBck`.
 func RenameBucket(baseParams BaseParams, oldBck, newBck cmn.Bck) (xactID string, err error) {
 	baseParams.Method = http.MethodPost
@@ -204,6 +362,27 @@ func RenameBucket(baseParams BaseParams, oldBck, newBck cmn.Bck) (xactID string,
 	return
 }
 
+// AliasBucket creates (or re-points) cluster-wide alias that resolves to a given bucket.
+func AliasBucket(baseParams BaseParams, bck cmn.Bck, alias string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActAliasBck, Name: alias}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// UnaliasBucket removes bucket alias (the aliased bucket is not affected).
+func UnaliasBucket(baseParams BaseParams, alias string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, alias),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActUnaliasBck}),
+	})
+}
+
 // DeleteList sends a HTTP request to remove a list of objects from a bucket.
 func DeleteList(baseParams BaseParams, bck cmn.Bck, filesList []string) (string, error) {
 	deleteMsg := cmn.ListMsg{ObjNames: filesList}
@@ -216,6 +395,36 @@ func DeleteRange(baseParams BaseParams, bck cmn.Bck, rng string) (string, error)
 	return doListRangeRequest(baseParams, bck, cmn.ActDelete, deleteMsg)
 }
 
+// DeleteListUndo sends a HTTP request to remove a list of objects from a bucket
+// while keeping them in trash for the duration of the undo window.
+// The returned job ID is also the undo token (see UndoDelete).
+func DeleteListUndo(baseParams BaseParams, bck cmn.Bck, filesList []string, undoWindow time.Duration) (string, error) {
+	deleteMsg := cmn.ListMsg{ObjNames: filesList}
+	query := url.Values{cmn.URLParamUndoWindow: []string{undoWindow.String()}}
+	return doListRangeRequest(baseParams, bck, cmn.ActDelete, deleteMsg, query)
+}
+
+// DeleteRangeUndo sends a HTTP request to remove a range of objects from a bucket
+// while keeping them in trash for the duration of the undo window.
+// The returned job ID is also the undo token (see UndoDelete).
+func DeleteRangeUndo(baseParams BaseParams, bck cmn.Bck, rng string, undoWindow time.Duration) (string, error) {
+	deleteMsg := cmn.RangeMsg{Template: rng}
+	query := url.Values{cmn.URLParamUndoWindow: []string{undoWindow.String()}}
+	return doListRangeRequest(baseParams, bck, cmn.ActDelete, deleteMsg, query)
+}
+
+// UndoDelete restores all the objects deleted by DeleteListUndo or DeleteRangeUndo
+// provided the undo window has not expired yet. Returns the number of restored objects.
+func UndoDelete(baseParams BaseParams, token string) (restored int64, err error) {
+	baseParams.Method = http.MethodPut
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActUndoDelete, Value: token}),
+	}, &restored)
+	return
+}
+
 // PrefetchList sends a HTTP request to prefetch a list of objects from a cloud bucket.
 func PrefetchList(baseParams BaseParams, bck cmn.Bck, fileslist []string) (string, error) {
 	prefetchMsg := cmn.ListMsg{ObjNames: fileslist}
@@ -267,8 +476,8 @@ func EvictCloudBucket(baseParams BaseParams, bck cmn.Bck, query ...url.Values) e
 // 3. Breaks loop on error
 // 4. If the destination returns status code StatusOK, it means the response
 //    contains the real data and the function returns the response to the caller
-func waitForAsyncReqComplete(reqParams ReqParams, action string, msg *cmn.BucketSummaryMsg, v interface{}) error {
-	cmn.Assert(action == cmn.ActSummaryBucket)
+func waitForAsyncReqComplete(reqParams ReqParams, action string, msg interface{}, msgUUID *string, v interface{}) error {
+	cmn.Assert(action == cmn.ActSummaryBucket || action == cmn.ActAnalyzeBucket)
 	var (
 		uuid   string
 		sleep  = initialPollInterval
@@ -288,8 +497,8 @@ func waitForAsyncReqComplete(reqParams ReqParams, action string, msg *cmn.Bucket
 		}
 		return fmt.Errorf("invalid response code: %d", resp.StatusCode)
 	}
-	if msg.UUID == "" {
-		msg.UUID = uuid
+	if *msgUUID == "" {
+		*msgUUID = uuid
 	}
 
 	// Poll async task for http.StatusOK completion
@@ -336,6 +545,7 @@ func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObj
 	bckList = &cmn.BucketList{}
 	smsg.UUID = ""
 	smsg.ContinuationToken = ""
+	smsg.SmapVersion, smsg.BMDVersion = 0, 0
 	if len(args) != 0 {
 		ctx = args[0]
 	}
@@ -344,7 +554,7 @@ func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObj
 	// the entire bucket). Each iteration lists a page of objects and reduces the `rem`
 	// counter accordingly. When the latter gets below page size, we perform the final
 	// iteration for the reduced page.
-	for pageNum := 1; listAll || toRead > 0; pageNum++ {
+	for pageNum, restarts := 1, 0; listAll || toRead > 0; pageNum++ {
 		if !listAll {
 			smsg.PageSize = toRead
 		}
@@ -369,11 +579,22 @@ func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObj
 					reqParams.BaseParams.Client = &client
 					continue
 				}
-				return nil, err
+				break
 			}
 			break
 		}
 		if err != nil {
+			// the cluster has changed in a way that does not allow to continue
+			// the listing - start over
+			if cmn.IsErrListingInvalidated(err) && pageNum > 1 && restarts < maxListRestarts {
+				restarts++
+				bckList = &cmn.BucketList{}
+				smsg.UUID, smsg.ContinuationToken = "", ""
+				smsg.SmapVersion, smsg.BMDVersion = 0, 0
+				toRead, pageNum = numObjects, 0
+				time.Sleep(time.Second * time.Duration(restarts))
+				continue
+			}
 			return nil, err
 		}
 
@@ -400,6 +621,7 @@ func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObj
 		cmn.Assert(page.UUID != "")
 		smsg.UUID = page.UUID
 		smsg.ContinuationToken = page.ContinuationToken
+		smsg.SmapVersion, smsg.BMDVersion = page.SmapVersion, page.BMDVersion
 	}
 
 	return bckList, err
@@ -408,6 +630,8 @@ func ListObjects(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, numObj
 // ListObjectsPage returns the first page of bucket objects.
 // On success the function updates `smsg.ContinuationToken`, so a client can reuse
 // the message to fetch the next page.
+// The error that satisfies `cmn.IsErrListingInvalidated` means that the listing
+// cannot be continued (e.g., the cluster is rebalancing) and must start over.
 func ListObjectsPage(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg) (*cmn.BucketList, error) {
 	baseParams.Method = http.MethodPost
 	if smsg == nil {
@@ -432,6 +656,7 @@ func ListObjectsPage(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg) (*
 	}
 	smsg.UUID = page.UUID
 	smsg.ContinuationToken = page.ContinuationToken
+	smsg.SmapVersion, smsg.BMDVersion = page.SmapVersion, page.BMDVersion
 	return page, nil
 }
 
@@ -451,7 +676,12 @@ func ListObjectsInvalidateCache(params BaseParams, bck cmn.Bck) error {
 }
 
 // Handles the List/Range operations (delete, prefetch)
-func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action string, listRangeMsg interface{}) (xactID string, err error) {
+func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action string, listRangeMsg interface{},
+	query ...url.Values) (xactID string, err error) {
+	var q url.Values
+	if len(query) > 0 {
+		q = query[0]
+	}
 	switch action {
 	case cmn.ActDelete, cmn.ActEvictObjects:
 		baseParams.Method = http.MethodDelete
@@ -468,7 +698,7 @@ func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action string, listR
 		Header: http.Header{
 			cmn.HeaderContentType: []string{cmn.ContentJSON},
 		},
-		Query: cmn.AddBckToQuery(nil, bck),
+		Query: cmn.AddBckToQuery(q, bck),
 	}, &xactID)
 	return
 }
@@ -86,6 +86,66 @@ func GetRemoteAIS(baseParams BaseParams) (aisInfo cmn.CloudInfoAIS, err error) {
 	return
 }
 
+// GetCloudHealth has every target probe every configured Cloud provider (and
+// attached remote AIS clusters) and returns the per-target results: probing
+// latency and error, if any. Each provider is probed by listing the given probe
+// bucket of this provider or else some Cloud bucket of this provider that
+// the cluster has (or, if none, by listing the provider's buckets).
+func GetCloudHealth(baseParams BaseParams, probes ...cmn.Bck) (results cmn.CloudHealthResults, err error) {
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatCloudHealth}}
+	for _, bck := range probes {
+		query.Add(cmn.URLParamProbe, bck.String())
+	}
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Query:      query,
+	}, &results)
+	return
+}
+
+// GetCapacityForecast returns, for each target and for the cluster as a whole,
+// the ingest rate estimated from the recent capacity history, and the
+// projected time to reach the high watermark and OOS (see cmn.LRUConf).
+func GetCapacityForecast(baseParams BaseParams) (forecast *cmn.ClusterCapForecast, err error) {
+	baseParams.Method = http.MethodGet
+	forecast = &cmn.ClusterCapForecast{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatCapForecast}},
+	}, forecast)
+	return
+}
+
+// GetPrefillStatus returns the targets that joined the cluster in prefill mode
+// (and are still being prefilled), and the progress of moving the existing
+// objects onto them - for each target and for the cluster as a whole.
+func GetPrefillStatus(baseParams BaseParams) (status *cmn.ClusterPrefillStatus, err error) {
+	baseParams.Method = http.MethodGet
+	status = &cmn.ClusterPrefillStatus{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatPrefill}},
+	}, status)
+	return
+}
+
+// GetPingMatrix has every node in the cluster measure round-trip latency to every
+// other node - separately over the intra-cluster control and data networks - and
+// returns the resulting N x N matrix indexed by the (from, to) node IDs.
+func GetPingMatrix(baseParams BaseParams) (matrix cmn.PingMatrix, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatPingMatrix}},
+	}, &matrix)
+	return
+}
+
 // RegisterNode registers an existing node to the cluster map.
 func RegisterNode(baseParams BaseParams, nodeInfo *cluster.Snode) error {
 	baseParams.Method = http.MethodPost
@@ -131,6 +191,18 @@ func SetClusterConfig(baseParams BaseParams, nvs cmn.SimpleKVs) error {
 	})
 }
 
+// SetClusterLogLevel sets log verbosity levels (global and/or per module) on
+// all nodes in the cluster; with non-zero msg.TTL, the nodes revert to their
+// previous levels once the TTL expires.
+func SetClusterLogLevel(baseParams BaseParams, msg *cmn.LogLevelMsg) error {
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSetLogLevel, Value: msg}),
+	})
+}
+
 func AttachRemoteAIS(baseParams BaseParams, alias, u string) error {
 	q := make(url.Values)
 	q.Set(cmn.URLParamWhat, cmn.GetWhatRemoteAIS)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
 )
 
@@ -94,6 +95,58 @@ func GetDaemonConfig(baseParams BaseParams, nodeID string) (config *cmn.Config,
 	return config, err
 }
 
+// GetDaemonClientStats returns connection pool utilization of the HTTP clients
+// of a specific node in the cluster.
+func GetDaemonClientStats(baseParams BaseParams, nodeID string) (clientStats map[string]cmn.ClientStatsSnap,
+	err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatClientStats}},
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &clientStats)
+	return
+}
+
+// GetDaemonAlerts returns the currently firing alerts of a specific target in the cluster.
+func GetDaemonAlerts(baseParams BaseParams, nodeID string) (alerts []*stats.Alert, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatAlerts}},
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &alerts)
+	return
+}
+
+// GetDaemonMemsysStats returns memory manager stats (by memory manager name)
+// of a specific node in the cluster.
+func GetDaemonMemsysStats(baseParams BaseParams, nodeID string) (mstats map[string]*memsys.Stats, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatMemsys}},
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &mstats)
+	return
+}
+
+// GetDaemonElections returns the history of primary elections (oldest first) as
+// seen by a specific node in the cluster.
+func GetDaemonElections(baseParams BaseParams, nodeID string) (events []cmn.ElectionEvent, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatElections}},
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &events)
+	return
+}
+
 // GetDaemonStatus returns the info of a specific node in the cluster.
 func GetDaemonStatus(baseParams BaseParams, node *cluster.Snode) (daeInfo *stats.DaemonStatus, err error) {
 	baseParams.Method = http.MethodGet
@@ -131,3 +184,25 @@ func SetDaemonConfig(baseParams BaseParams, nodeID string, nvs cmn.SimpleKVs) er
 		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
 	})
 }
+
+// SetDaemonLogLevel sets log verbosity levels for a specific node - see SetClusterLogLevel.
+func SetDaemonLogLevel(baseParams BaseParams, nodeID string, msg *cmn.LogLevelMsg) error {
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSetLogLevel, Value: msg}),
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	})
+}
+
+// Switchover given the direct public URL of a shadow target (aisnode -shadow),
+// shuts down the shadowed target and restarts the shadow as the regular one.
+func Switchover(baseParams BaseParams) error {
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Daemon),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSwitchover}),
+	})
+}
@@ -82,6 +82,36 @@ func DownloadCloud(baseParams BaseParams, description string, bck cmn.Bck, prefi
 	return DownloadWithParam(baseParams, downloader.DlTypeCloud, dlBody)
 }
 
+// PrefetchObjects starts cold-GETting (in the background, by the downloader's
+// joggers) Cloud objects named either by `objNames` or by a bash-style range
+// `template` (e.g. "shard-{00..99}-{0..9}.tar") into the cluster; returns the
+// ID of the job. The job reports (see DownloadStatus) the numbers of prefetched,
+// skipped (already present) and failed objects; use `limits` to cap
+// concurrency and bandwidth.
+func PrefetchObjects(baseParams BaseParams, bck cmn.Bck, objNames []string, template string,
+	limits ...downloader.DlLimits) (string, error) {
+	body := downloader.DlPrefetchBody{ObjNames: objNames, Template: template}
+	body.Bck = bck
+	if len(limits) > 0 {
+		body.Limits = limits[0]
+	}
+	return DownloadWithParam(baseParams, downloader.DlTypePrefetch, body)
+}
+
+// DownloadSchedule starts the cloud download job that then gets re-run on
+// `body.Schedule` (see downloader.DlBase.Schedule); returns the IDs of the first
+// run and of the schedule. To cancel the schedule, call RemoveDownload (or
+// AbortDownload - to also abort the run in progress) with the schedule ID.
+func DownloadSchedule(baseParams BaseParams, body downloader.DlCloudBody) (resp downloader.DlPostResp, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Download),
+		Body:       cmn.MustMarshal(downloader.DlBody{Type: downloader.DlTypeCloud, RawMessage: cmn.MustMarshal(body)}),
+	}, &resp)
+	return
+}
+
 func DownloadStatus(baseParams BaseParams, id string, onlyActiveTasks ...bool) (downloader.DlStatusResp, error) {
 	dlBody := downloader.DlAdminBody{
 		ID: id,
@@ -100,6 +130,39 @@ func DownloadStatus(baseParams BaseParams, id string, onlyActiveTasks ...bool) (
 	})
 }
 
+// DownloadLogs returns the log of the download job (scheduling decisions,
+// retries, errors) collected from all targets and sorted by time.
+func DownloadLogs(baseParams BaseParams, id string) ([]downloader.DlLogEntry, error) {
+	dlBody := downloader.DlAdminBody{
+		ID:   id,
+		Logs: true,
+	}
+	baseParams.Method = http.MethodGet
+	resp, err := doDlStatusRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Download),
+		Body:       cmn.MustMarshal(dlBody),
+	})
+	return resp.Logs, err
+}
+
+// DownloadObjectStatus returns the scheduling state of a given object of the
+// download job: not dispatched yet, waiting for the job's limits, queued (at
+// which position), being downloaded (attempts so far), or done.
+func DownloadObjectStatus(baseParams BaseParams, id, objName string) (*downloader.DlObjStatus, error) {
+	dlBody := downloader.DlAdminBody{
+		ID:      id,
+		ObjName: objName,
+	}
+	baseParams.Method = http.MethodGet
+	resp, err := doDlStatusRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Download),
+		Body:       cmn.MustMarshal(dlBody),
+	})
+	return resp.Object, err
+}
+
 func DownloadGetList(baseParams BaseParams, regex string) (dlList downloader.DlJobInfos, err error) {
 	dlBody := downloader.DlAdminBody{
 		Regex: regex,
@@ -138,6 +201,12 @@ func RemoveDownload(baseParams BaseParams, id string) error {
 	})
 }
 
+// DownloadSetLimits changes the limits of a running download job; zero value
+// of a given limit removes the limit (same as SetJobLimits).
+func DownloadSetLimits(baseParams BaseParams, id string, limits downloader.DlLimits) error {
+	return SetJobLimits(baseParams, id, *limits.JobLimits())
+}
+
 func doDlDownloadRequest(reqParams ReqParams) (string, error) {
 	var resp downloader.DlPostResp
 	err := DoHTTPRequest(reqParams, &resp)
@@ -23,6 +23,35 @@ func StartDSort(baseParams BaseParams, rs dsort.RequestSpec) (string, error) {
 	return id, err
 }
 
+// PlanDSort starts a planning (dry-run) dSort job: the job extracts and sorts
+// the records but does not create any shards. Once the job finishes, use
+// DSortPlan to retrieve the plan.
+func PlanDSort(baseParams BaseParams, rs dsort.RequestSpec) (string, error) {
+	baseParams.Method = http.MethodPost
+	var id string
+	err := DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Plan),
+		Body:       cmn.MustMarshal(rs),
+	}, &id)
+	return id, err
+}
+
+// DSortPlan returns the plan of a given planning job, or nil if the plan is
+// not ready yet.
+func DSortPlan(baseParams BaseParams, managerUUID string) (*dsort.Plan, error) {
+	metrics, err := MetricsDSort(baseParams, managerUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range metrics {
+		if m.Plan != nil {
+			return m.Plan, nil
+		}
+	}
+	return nil, nil
+}
+
 func AbortDSort(baseParams BaseParams, managerUUID string) error {
 	baseParams.Method = http.MethodDelete
 	return DoHTTPRequest(ReqParams{
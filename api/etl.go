@@ -33,6 +33,18 @@ func ETLBuild(baseParams BaseParams, msg etl.BuildMsg) (id string, err error) {
 	return id, err
 }
 
+// ETLValidate validates ETL pod spec or build message (see etl.ValidateMsg)
+// without initializing the ETL. Returns all the problems found (none if valid).
+func ETLValidate(baseParams BaseParams, msg etl.ValidateMsg) (errs etl.ValidationErrs, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.ETL, cmn.ETLValidate),
+		Body:       cmn.MustMarshal(msg),
+	}, &errs)
+	return errs, err
+}
+
 func ETLList(baseParams BaseParams) (list []etl.Info, err error) {
 	baseParams.Method = http.MethodGet
 	err = DoHTTPRequest(ReqParams{
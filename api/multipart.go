@@ -0,0 +1,250 @@
+// Package api provides RESTful API to AIS object storage
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	mptDefaultPartSize = 64 * cmn.MiB
+	mptDefaultWorkers  = 4
+)
+
+type (
+	UploadPartArgs struct {
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		Object     string
+		UploadID   string
+		PartNum    int // starting from 1
+		Reader     cmn.ReadOpenCloser
+		Size       int64
+		Cksum      *cmn.Cksum // optional; verified upon receiving the part
+	}
+	CompleteMptArgs struct {
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		Object     string
+		UploadID   string
+		Parts      []cmn.MptPart
+		Cksum      *cmn.Cksum // optional checksum of the entire object
+	}
+	// MptPutArgs is PutObjectMultipart's input: the object of the given size is
+	// read (in parts of PartSize bytes, by Workers goroutines) from the Reader.
+	MptPutArgs struct {
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		Object     string
+		Reader     io.ReaderAt
+		Size       int64
+		PartSize   int64  // default: 64MiB
+		Workers    int    // default: 4
+		CksumType  string // checksum of the parts (default: xxhash; "none" - not to checksum)
+	}
+)
+
+func mptQuery(bck cmn.Bck, op, uploadID string) url.Values {
+	query := make(url.Values)
+	query.Add(cmn.URLParamAppendType, op)
+	query.Add(cmn.URLParamAppendHandle, uploadID)
+	return cmn.AddBckToQuery(query, bck)
+}
+
+// CreateMultipartUpload starts multipart upload of the object and returns the
+// upload ID to be used with UploadPart, CompleteMultipartUpload, and
+// AbortMultipartUpload. The object gets created (or overwritten) only upon
+// successful completion of the upload.
+func CreateMultipartUpload(baseParams BaseParams, bck cmn.Bck, object string) (uploadID string, err error) {
+	baseParams.Method = http.MethodPut
+	resp, err := doHTTPRequestGetResp(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      mptQuery(bck, cmn.MptCreateOp, ""),
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	return resp.Header.Get(cmn.HeaderAppendHandle), nil
+}
+
+// UploadPart uploads a single part of the multipart upload and returns its
+// checksum, as computed by the target. Parts can be uploaded in any order and
+// in parallel; uploading the part with the same number again replaces it.
+func UploadPart(args UploadPartArgs) (*cmn.Cksum, error) {
+	query := mptQuery(args.Bck, cmn.MptPartOp, args.UploadID)
+	query.Add(cmn.URLParamPartNum, fmt.Sprintf("%d", args.PartNum))
+	var header http.Header
+	if args.Cksum != nil {
+		header = make(http.Header)
+		header.Set(cmn.HeaderObjCksumType, args.Cksum.Type())
+		header.Set(cmn.HeaderObjCksumVal, args.Cksum.Value())
+	}
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPut,
+		Base:   args.BaseParams.URL,
+		Path:   cmn.JoinWords(cmn.Version, cmn.Objects, args.Bck.Name, args.Object),
+		Query:  query,
+		Header: header,
+		BodyR:  args.Reader,
+	}
+	newRequest := func(reqArgs cmn.ReqArgs) (*http.Request, error) {
+		req, err := reqArgs.Req()
+		if err != nil {
+			return nil, cmn.NewFailedToCreateHTTPRequest(err)
+		}
+		req.GetBody = args.Reader.Open
+		if args.Size != 0 {
+			req.ContentLength = args.Size
+		}
+		setAuthToken(req, args.BaseParams)
+		if err := editRequest(req, args.BaseParams); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+	resp, err := DoReqWithRetry(args.BaseParams.Client, newRequest, reqArgs) // nolint:bodyclose // it's closed inside
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload part #%d, err: %v", args.PartNum, err)
+	}
+	return cmn.NewCksum(resp.Header.Get(cmn.HeaderObjCksumType), resp.Header.Get(cmn.HeaderObjCksumVal)), nil
+}
+
+// CompleteMultipartUpload assembles the object from the given parts (in the
+// given order), verifying the checksums of the parts and, if specified, of
+// the entire object.
+func CompleteMultipartUpload(args CompleteMptArgs) error {
+	var header http.Header
+	if args.Cksum != nil {
+		header = make(http.Header)
+		header.Set(cmn.HeaderObjCksumType, args.Cksum.Type())
+		header.Set(cmn.HeaderObjCksumVal, args.Cksum.Value())
+	}
+	args.BaseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: args.BaseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, args.Bck.Name, args.Object),
+		Query:      mptQuery(args.Bck, cmn.MptCompleteOp, args.UploadID),
+		Header:     header,
+		Body:       cmn.MustMarshal(cmn.MptCompleteMsg{Parts: args.Parts}),
+	})
+}
+
+// AbortMultipartUpload removes the upload along with all its parts.
+func AbortMultipartUpload(baseParams BaseParams, bck cmn.Bck, object, uploadID string) error {
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      mptQuery(bck, cmn.MptAbortOp, uploadID),
+	})
+}
+
+// PutObjectMultipart PUTs the object using multipart upload: the parts are
+// checksummed and uploaded in parallel. Upon failure the upload is aborted.
+func PutObjectMultipart(args MptPutArgs) error {
+	if args.PartSize <= 0 {
+		args.PartSize = mptDefaultPartSize
+	}
+	if args.Workers <= 0 {
+		args.Workers = mptDefaultWorkers
+	}
+	if args.CksumType == "" {
+		args.CksumType = cmn.ChecksumXXHash
+	}
+	if err := cmn.ValidateCksumType(args.CksumType); err != nil {
+		return err
+	}
+	numParts := int((args.Size + args.PartSize - 1) / args.PartSize)
+	if numParts == 0 {
+		numParts = 1 // (empty object)
+	}
+	if numParts > cmn.MaxMptParts {
+		return fmt.Errorf("%s: too many parts (%d > %d) - increase the part size", args.Object,
+			numParts, cmn.MaxMptParts)
+	}
+	uploadID, err := CreateMultipartUpload(args.BaseParams, args.Bck, args.Object)
+	if err != nil {
+		return err
+	}
+
+	var (
+		parts = make([]cmn.MptPart, numParts)
+		errCh = make(chan error, numParts)
+		numCh = make(chan int, numParts)
+		wg    = &sync.WaitGroup{}
+	)
+	for i := 0; i < numParts; i++ {
+		numCh <- i + 1
+	}
+	close(numCh)
+	for i := 0; i < args.Workers && i < numParts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for num := range numCh {
+				cksum, err := mptPutPart(args, uploadID, num)
+				if err != nil {
+					errCh <- err
+					return
+				}
+				parts[num-1] = cmn.MptPart{Num: num, CksumType: cksum.Type(), CksumVal: cksum.Value()}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	if err, ok := <-errCh; ok {
+		if errAbort := AbortMultipartUpload(args.BaseParams, args.Bck, args.Object, uploadID); errAbort != nil {
+			return fmt.Errorf("%v (failed to abort the upload: %v)", err, errAbort)
+		}
+		return err
+	}
+	return CompleteMultipartUpload(CompleteMptArgs{
+		BaseParams: args.BaseParams,
+		Bck:        args.Bck,
+		Object:     args.Object,
+		UploadID:   uploadID,
+		Parts:      parts,
+	})
+}
+
+func mptPutPart(args MptPutArgs, uploadID string, num int) (*cmn.Cksum, error) {
+	var (
+		off  = int64(num-1) * args.PartSize
+		size = cmn.MinI64(args.PartSize, args.Size-off)
+	)
+	fh, err := cmn.NewFileSectionHandle(args.Reader, off, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	partArgs := UploadPartArgs{
+		BaseParams: args.BaseParams,
+		Bck:        args.Bck,
+		Object:     args.Object,
+		UploadID:   uploadID,
+		PartNum:    num,
+		Reader:     fh,
+		Size:       size,
+	}
+	if args.CksumType != cmn.ChecksumNone {
+		_, cksum, err := cmn.CopyAndChecksum(ioutil.Discard, fh, nil, args.CksumType)
+		if err != nil {
+			return nil, err
+		}
+		if partArgs.Reader, err = cmn.NewFileSectionHandle(args.Reader, off, size, 0); err != nil {
+			return nil, err
+		}
+		partArgs.Cksum = cksum.Clone()
+	}
+	return UploadPart(partArgs)
+}
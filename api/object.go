@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -35,6 +36,19 @@ type GetObjectInput struct {
 	Query url.Values
 	// Custom header values passed with GET request
 	Header http.Header
+	// Conditional GET: when either is set and the object has not changed - its
+	// ETag matches IfNoneMatch or it hasn't been modified since IfModifiedSince -
+	// the object is not returned, and the GET fails with `cmn.ErrNotModified`.
+	IfNoneMatch     string
+	IfModifiedSince time.Time
+	// Ranged GET: only `Range.Length` bytes starting at `Range.Start` are
+	// returned (zero length: through the end of the object). GetObject then
+	// returns the length of the range.
+	Range *cmn.HTTPRange
+	// EC-protected objects only: if the target that stores the object is down
+	// (or the object is missing there), restore the object from the surviving
+	// data and parity slices within the same request, instead of failing.
+	AllowECRestore bool
 }
 
 // ReplicateObjectInput is used to hold optional parameters for PutObject when it is used for replication
@@ -49,7 +63,8 @@ type PutObjectArgs struct {
 	Object     string
 	Cksum      *cmn.Cksum
 	Reader     cmn.ReadOpenCloser
-	Size       uint64 // optional
+	Size       uint64        // optional
+	CustomMD   cmn.SimpleKVs // optional: user-defined metadata (see also SetObjectCustomMD)
 }
 
 type PromoteArgs struct {
@@ -123,6 +138,20 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 	return objProps, nil
 }
 
+// HeadObjects checks existence of many objects at once - in a single request
+// that each target, in turn, executes for the objects it stores. Returns
+// per-object existence, size, and version in the order of the given names.
+func HeadObjects(baseParams BaseParams, bck cmn.Bck, names []string) (entries []*cmn.ObjHeadEntry, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActHeadObjects, Value: cmn.ListMsg{ObjNames: names}}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &entries)
+	return
+}
+
 // DeleteObject deletes an object specified by bucket/object.
 func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -182,9 +211,8 @@ func GetObjectReader(baseParams BaseParams, bck cmn.Bck, object string, options
 		hdr http.Header
 	)
 	if len(options) != 0 {
-		var w io.Writer
-		w, q, hdr = getObjectOptParams(options[0])
-		cmn.Assert(w == nil)
+		cmn.Assert(options[0].Writer == nil)
+		_, q, hdr = getObjectOptParams(options[0])
 	}
 
 	q = cmn.AddBckToQuery(q, bck)
@@ -205,6 +233,13 @@ func GetObjectReader(baseParams BaseParams, bck cmn.Bck, object string, options
 // temporary buffer is allocated when reading from the response body to compute
 // the object checksum.
 //
+// In addition to the object's checksum (of the type the object is stored
+// with, e.g. xxhash, crc32c, or sha256), a GET of the entire object validates
+// all other checksums the target provides - e.g., crc32c and md5 of the object
+// in the Cloud. A range read gets validated with the checksum of the range -
+// provided the bucket has `checksum.enable_read_range` (otherwise, the response
+// carries the checksum of the entire object and the validation fails).
+//
 // Returns `cmn.InvalidCksumError` when the expected and actual checksum values
 // are different.
 func GetObjectWithValidation(baseParams BaseParams, bck cmn.Bck, object string, options ...GetObjectInput) (n int64, err error) {
@@ -229,9 +264,20 @@ func GetObjectWithValidation(baseParams BaseParams, bck cmn.Bck, object string,
 		return 0, err
 	}
 
-	hdrCksumValue := resp.Header.Get(cmn.HeaderObjCksumVal)
-	if resp.cksumValue != hdrCksumValue {
-		return 0, cmn.NewInvalidCksumError(hdrCksumValue, resp.cksumValue)
+	var (
+		hdrCksumValue = resp.Header.Get(cmn.HeaderObjCksumVal)
+		cksumValue    string
+	)
+	if cksum := resp.cksums[0]; cksum.Type() != cmn.ChecksumNone {
+		cksumValue = cksum.Value()
+	}
+	if cksumValue != hdrCksumValue {
+		return 0, cmn.NewInvalidCksumError(hdrCksumValue, cksumValue)
+	}
+	for i, expected := range respCksums(resp.Response) {
+		if cksum := resp.cksums[i+1]; !cksum.Equal(expected) {
+			return 0, cmn.NewInvalidCksumError(expected.Value(), cksum.Value())
+		}
 	}
 	return resp.n, nil
 }
@@ -304,8 +350,14 @@ func PutObject(args PutObjectArgs) (err error) {
 		if args.Size != 0 {
 			req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 		}
+		for k, v := range args.CustomMD {
+			req.Header.Add(cmn.HeaderObjCustomMD, k+"="+v)
+		}
 
 		setAuthToken(req, args.BaseParams)
+		if err := editRequest(req, args.BaseParams); err != nil {
+			return nil, err
+		}
 		return req, nil
 	}
 	_, err = DoReqWithRetry(args.BaseParams.Client, newRequest, reqArgs) // nolint:bodyclose // is closed inside
@@ -346,6 +398,9 @@ func AppendObject(args AppendArgs) (handle string, err error) {
 		}
 
 		setAuthToken(req, args.BaseParams)
+		if err := editRequest(req, args.BaseParams); err != nil {
+			return nil, err
+		}
 		return req, nil
 	}
 
@@ -393,6 +448,74 @@ func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) e
 	})
 }
 
+// MoveObject moves object `objName` from bucket `bck` to `toBck` (and name
+// `toName`, if specified). Unlike RenameObject, the buckets can be different and
+// of any provider (e.g., ais => aws, gcp => ais). The operation is asynchronous:
+// the object is copied, the copy is verified, and only then the source is deleted.
+// Returns ID of the operation that can be tracked with WaitForXaction and
+// GetXactionStatus; upon failure the source object remains intact.
+func MoveObject(baseParams BaseParams, bck cmn.Bck, objName string, toBck cmn.Bck, toName ...string) (xactID string, err error) {
+	mv := &cmn.ActValMoveObject{Bck: toBck}
+	if len(toName) > 0 {
+		mv.ObjName = toName[0]
+	}
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMoveObject, Value: mv}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
+// PinObject pins (or unpins, if `pin` is false) a given object: LRU never evicts
+// pinned objects. The pin is stored in the object's custom metadata and is lost
+// when the object gets overwritten.
+func PinObject(baseParams BaseParams, bck cmn.Bck, objName string, pin bool) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActPinObject, Value: pin}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// GetObjectCustomMD returns custom metadata of a given object (see also
+// SetObjectCustomMD).
+func GetObjectCustomMD(baseParams BaseParams, bck cmn.Bck, objName string) (md cmn.SimpleKVs, err error) {
+	baseParams.Method = http.MethodHead
+	resp, err := doHTTPRequestGetResp(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	md = make(cmn.SimpleKVs, 4)
+	for _, kv := range resp.Header[http.CanonicalHeaderKey(cmn.HeaderObjCustomMD)] {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			md[kv[:i]] = kv[i+1:]
+		}
+	}
+	return md, nil
+}
+
+// SetObjectCustomMD updates custom metadata of a given object in place: sets
+// the specified keys and deletes those with empty values. If enabled, the
+// bucket's metadata index gets updated as well (see QueryObjectsMD).
+func SetObjectCustomMD(baseParams BaseParams, bck cmn.Bck, objName string, md cmn.SimpleKVs) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSetCustomMD, Value: md}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
 // PromoteFileOrDir promotes AIS-colocated files and directories to objects.
 //
 // NOTE: Advanced usage only.
@@ -6,11 +6,15 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -24,8 +28,14 @@ type (
 		URL    string
 		Method string
 		Token  string
+		// Optional; modifies every request before it is sent - see WithRequestEditor.
+		RequestEditor RequestEditor
 	}
 
+	// RequestEditor modifies the request (e.g., adds custom headers or signs it)
+	// right before it is sent; non-nil error fails the API call.
+	RequestEditor func(req *http.Request) error
+
 	// ReqParams is used in constructing client-side API requests to the AIStore.
 	// Stores Query and Headers for providing arguments that are not used commonly in API requests
 	ReqParams struct {
@@ -34,6 +44,7 @@ type (
 		Body       []byte
 		Query      url.Values
 		Header     http.Header
+		Ctx        context.Context // Optional: cancels the request
 
 		// Authentication
 		User     string
@@ -45,11 +56,28 @@ type (
 
 	wrappedResp struct {
 		*http.Response
-		n          int64  // number bytes read from `resp.Body`
-		cksumValue string // checksum value of the response
+		n      int64            // number bytes read from `resp.Body`
+		cksums []*cmn.CksumHash // checksums of the response (see respCksumTypes)
 	}
 )
 
+// WithRequestEditor returns a copy of the params that apply `editor` to every
+// outgoing request - after the editor that is already set, if any.
+func (bp BaseParams) WithRequestEditor(editor RequestEditor) BaseParams {
+	prev := bp.RequestEditor
+	if prev == nil {
+		bp.RequestEditor = editor
+		return bp
+	}
+	bp.RequestEditor = func(req *http.Request) error {
+		if err := prev(req); err != nil {
+			return err
+		}
+		return editor(req)
+	}
+	return bp
+}
+
 // HTTPStatus returns HTTP status or (-1) for non-HTTP error.
 func HTTPStatus(err error) int {
 	if err == nil {
@@ -116,8 +144,14 @@ func doHTTPRequestGetHTTPResp(reqParams ReqParams) (*http.Response, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request, err: %v", err)
 	}
+	if reqParams.Ctx != nil {
+		req = req.WithContext(reqParams.Ctx)
+	}
 	setRequestOptParams(req, reqParams)
 	setAuthToken(req, reqParams.BaseParams)
+	if err := editRequest(req, reqParams.BaseParams); err != nil {
+		return nil, err
+	}
 
 	resp, err := reqParams.BaseParams.Client.Do(req)
 	if err != nil {
@@ -155,15 +189,27 @@ func readResp(reqParams ReqParams, resp *http.Response, v interface{}) (*wrapped
 			}
 			wresp.n = n
 		} else {
-			hdrCksumType := resp.Header.Get(cmn.HeaderObjCksumType)
+			var (
+				types   = respCksumTypes(resp)
+				writers = make([]io.Writer, 0, len(types)+1)
+			)
+			wresp.cksums = make([]*cmn.CksumHash, 0, len(types))
+			for _, ty := range types {
+				cksum := cmn.NewCksumHash(ty)
+				wresp.cksums = append(wresp.cksums, cksum)
+				writers = append(writers, cksum.H)
+			}
+			if w != ioutil.Discard {
+				writers = append(writers, w)
+			}
 			// TODO: use MMSA
-			n, cksum, err := cmn.CopyAndChecksum(w, resp.Body, nil, hdrCksumType)
+			n, err := io.Copy(cmn.NewWriterMulti(writers...), resp.Body)
 			if err != nil {
 				return nil, err
 			}
 			wresp.n = n
-			if cksum != nil {
-				wresp.cksumValue = cksum.Value()
+			for _, cksum := range wresp.cksums {
+				cksum.Finalize()
 			}
 		}
 	} else {
@@ -191,7 +237,51 @@ func readResp(reqParams ReqParams, resp *http.Response, v interface{}) (*wrapped
 	return wresp, nil
 }
 
+// respCksumTypes returns the types of checksums to compute over the response
+// body: the object's checksum (the first, possibly "none") followed by the
+// other ones the target has provided, if any (see respCksums)
+func respCksumTypes(resp *http.Response) (types []string) {
+	types = append(types, resp.Header.Get(cmn.HeaderObjCksumType))
+	for _, cksum := range respCksums(resp) {
+		types = append(types, cksum.Type())
+	}
+	return
+}
+
+// respCksums returns additional checksums of the entire object (e.g., crc32c
+// and md5 provided by the Cloud) that come in the object's custom metadata
+func respCksums(resp *http.Response) (cksums []*cmn.Cksum) {
+	objType := resp.Header.Get(cmn.HeaderObjCksumType)
+	for _, kv := range resp.Header[http.CanonicalHeaderKey(cmn.HeaderObjCustomMD)] {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			continue
+		}
+		ty, val := kv[:i], kv[i+1:]
+		if ty == objType || ty == cmn.ChecksumNone || cmn.ValidateCksumType(ty) != nil || val == "" {
+			continue
+		}
+		cksums = append(cksums, cmn.NewCksum(ty, hexCksumValue(val)))
+	}
+	return
+}
+
+// Cloud providers may return checksums in base64 (e.g., GCP crc32c), while
+// aistore uses hex encoding
+func hexCksumValue(val string) string {
+	if _, err := hex.DecodeString(val); err == nil {
+		return val
+	}
+	if b, err := base64.StdEncoding.DecodeString(val); err == nil {
+		return hex.EncodeToString(b)
+	}
+	return val
+}
+
 func checkResp(reqParams ReqParams, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotModified { // conditional GET (see GetObjectInput)
+		return cmn.ErrNotModified
+	}
 	if resp.StatusCode < http.StatusBadRequest {
 		return nil
 	}
@@ -244,6 +334,28 @@ func getObjectOptParams(options GetObjectInput) (w io.Writer, q url.Values, hdr
 	if len(options.Header) != 0 {
 		hdr = options.Header
 	}
+	if options.AllowECRestore {
+		if q == nil {
+			q = make(url.Values, 1)
+		}
+		q.Set(cmn.URLParamECRestore, "true")
+	}
+	if options.IfNoneMatch != "" || !options.IfModifiedSince.IsZero() || options.Range != nil {
+		if hdr == nil {
+			hdr = make(http.Header, 3)
+		} else {
+			hdr = hdr.Clone()
+		}
+		if options.Range != nil {
+			hdr.Set(cmn.HeaderRange, options.Range.HdrVal())
+		}
+		if options.IfNoneMatch != "" {
+			hdr.Set(cmn.HeaderIfNoneMatch, options.IfNoneMatch)
+		}
+		if !options.IfModifiedSince.IsZero() {
+			hdr.Set(cmn.HeaderIfModifiedSince, options.IfModifiedSince.UTC().Format(http.TimeFormat))
+		}
+	}
 	return
 }
 
@@ -253,6 +365,16 @@ func setAuthToken(r *http.Request, baseParams BaseParams) {
 	}
 }
 
+func editRequest(r *http.Request, baseParams BaseParams) error {
+	if baseParams.RequestEditor == nil {
+		return nil
+	}
+	if err := baseParams.RequestEditor(r); err != nil {
+		return fmt.Errorf("failed to edit request, err: %v", err)
+	}
+	return nil
+}
+
 func GetWhatRawQuery(getWhat, getProps string) string {
 	q := url.Values{}
 	q.Add(cmn.URLParamWhat, getWhat)
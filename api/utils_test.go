@@ -0,0 +1,116 @@
+// Package api provides RESTful API to AIS object storage
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+const (
+	helloMD5    = "5d41402abc4b2a76b9719d911017c592"
+	helloCRC32C = "9a71bb4c"
+	helloB64CRC = "mnG7TA==" // GCP-style (base64) crc32c of "hello"
+)
+
+func TestHexCksumValue(t *testing.T) {
+	tests := []struct {
+		val, hex string
+	}{
+		{val: helloMD5, hex: helloMD5},       // hex is kept as is
+		{val: helloB64CRC, hex: helloCRC32C}, // base64 gets converted
+		{val: "XUFAKrxLKna5cZ2REBfFkg==", hex: helloMD5},
+		{val: "not-a-checksum!", hex: "not-a-checksum!"}, // neither: kept as is
+	}
+	for _, test := range tests {
+		hex := hexCksumValue(test.val)
+		tassert.Errorf(t, hex == test.hex, "%q: expected %q, got %q", test.val, test.hex, hex)
+	}
+}
+
+func TestRespCksums(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+	for _, kv := range []string{
+		"crc32c=" + helloB64CRC,
+		"md5=" + helloMD5,
+		cmn.ChecksumXXHash + "=1234", // the object's own checksum: already in the header
+		cmn.ChecksumNone + "=",
+		"label=cat", // not a checksum
+		"sha256=",   // empty
+		"no-separator",
+	} {
+		resp.Header.Add(cmn.HeaderObjCustomMD, kv)
+	}
+
+	cksums := respCksums(resp)
+	tassert.Fatalf(t, len(cksums) == 2, "expected 2 checksums, got %v", cksums)
+	tassert.Errorf(t, cksums[0].Equal(cmn.NewCksum(cmn.ChecksumCRC32C, helloCRC32C)), "crc32c: got %s", cksums[0])
+	tassert.Errorf(t, cksums[1].Equal(cmn.NewCksum(cmn.ChecksumMD5, helloMD5)), "md5: got %s", cksums[1])
+
+	types := respCksumTypes(resp)
+	expected := []string{cmn.ChecksumXXHash, cmn.ChecksumCRC32C, cmn.ChecksumMD5}
+	tassert.Errorf(t, strings.Join(types, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, types)
+
+	// no checksum of the object: all the others get validated
+	resp.Header.Set(cmn.HeaderObjCksumType, cmn.ChecksumNone)
+	types = respCksumTypes(resp)
+	expected = []string{cmn.ChecksumNone, cmn.ChecksumCRC32C, cmn.ChecksumMD5, cmn.ChecksumXXHash}
+	tassert.Errorf(t, strings.Join(types, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, types)
+}
+
+func TestGetObjectWithValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		customMD []string
+		mismatch string // the expected value of the first mismatching checksum, if any
+	}{
+		{name: "object checksum only"},
+		{name: "all match", customMD: []string{"crc32c=" + helloB64CRC, "md5=" + helloMD5}},
+		{
+			name:     "md5 mismatch",
+			customMD: []string{"crc32c=" + helloB64CRC, "md5=" + strings.Repeat("0", 32)},
+			mismatch: strings.Repeat("0", 32),
+		},
+		{
+			name:     "first mismatch",
+			customMD: []string{"crc32c=AAAAAA==", "md5=" + strings.Repeat("0", 32)},
+			mismatch: "00000000",
+		},
+	}
+	xxhash := cmn.NewCksumHash(cmn.ChecksumXXHash)
+	xxhash.H.Write([]byte("hello"))
+	xxhash.Finalize()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+				w.Header().Set(cmn.HeaderObjCksumVal, xxhash.Value())
+				for _, kv := range test.customMD {
+					w.Header().Add(cmn.HeaderObjCustomMD, kv)
+				}
+				w.Write([]byte("hello"))
+			}))
+			defer srv.Close()
+
+			baseParams := BaseParams{Client: srv.Client(), URL: srv.URL}
+			n, err := GetObjectWithValidation(baseParams, cmn.Bck{Name: "bck"}, "obj")
+			if test.mismatch != "" {
+				cksumErr, ok := err.(cmn.InvalidCksumError)
+				tassert.Fatalf(t, ok, "expected checksum error, got %v", err)
+				tassert.Errorf(t, cksumErr.Expected() == test.mismatch, "expected mismatch of %q, got %v",
+					test.mismatch, err)
+				return
+			}
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, n == int64(len("hello")), "expected %d bytes, got %d", len("hello"), n)
+		})
+	}
+}
@@ -17,7 +17,9 @@ import (
 )
 
 const (
-	xactRetryInterval = time.Second
+	xactRetryInterval    = time.Second
+	xactLongPollWait     = 30 * time.Second // max time the proxy holds a single status request
+	xactProgressInterval = 5 * time.Second  // default XactReqArgs.ProgressInterval
 )
 
 type (
@@ -39,6 +41,13 @@ type (
 		Timeout time.Duration
 		Force   bool // Optional: force LRU
 		Latest  bool // Determines if we should get latest or all xactions
+
+		// Optional: cancels the request(s) - in particular, the wait (see WaitForXaction)
+		Ctx context.Context
+		// Optional (WaitForXaction): called with the xaction's intermediate stats
+		// every ProgressInterval (default: 5s) while waiting
+		ProgressFn       func(stats NodesXactMultiStats)
+		ProgressInterval time.Duration
 	}
 )
 
@@ -109,6 +118,15 @@ func (xs NodesXactMultiStats) ObjCount() (count int64) {
 	return
 }
 
+func (xs NodesXactMultiStats) BytesCount() (count int64) {
+	for _, targetStats := range xs {
+		for _, xaction := range targetStats {
+			count += xaction.BytesCount()
+		}
+	}
+	return
+}
+
 func (xs NodesXactMultiStats) GetNodesXactStat(id string) (xactStat NodesXactStat) {
 	xactStat = make(NodesXactStat)
 	for target, stats := range xs {
@@ -172,6 +190,62 @@ func AbortXaction(baseParams BaseParams, args XactReqArgs) error {
 	})
 }
 
+// SetJobLimits updates limits of a running job (e.g. download job) given its ID.
+func SetJobLimits(baseParams BaseParams, id string, limits xaction.JobLimits) error {
+	msg := cmn.ActionMsg{
+		Action: cmn.ActXactLimits,
+		Value:  xaction.XactReqMsg{ID: id, Limits: &limits},
+	}
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+	})
+}
+
+// EvacuateBuckets uploads all objects of the given ais buckets (all ais buckets
+// if none specified) to a Cloud or remote AIS bucket. Returns xaction ID.
+func EvacuateBuckets(baseParams BaseParams, bcks []cmn.Bck, evacMsg xaction.EvacuateMsg) (id string, err error) {
+	msg := cmn.ActionMsg{
+		Action: cmn.ActXactStart,
+		Value:  xaction.XactReqMsg{Kind: cmn.ActEvacuate, Buckets: bcks, Evacuate: &evacMsg},
+	}
+	baseParams.Method = http.MethodPut
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+	}, &id)
+	return id, err
+}
+
+// BackupBucket uploads all objects of a given ais bucket, along with the bucket's
+// props and the objects' manifests, to `cloud` under `prefix`. Returns xaction ID.
+func BackupBucket(baseParams BaseParams, bck, cloud cmn.Bck, prefix string) (id string, err error) {
+	return startBackupXact(baseParams, cmn.ActBackupBck, bck, cloud, prefix)
+}
+
+// RestoreBucket recreates ais bucket `bck` (that must not exist) from its backup
+// (see BackupBucket) with the backed-up props. Returns xaction ID.
+func RestoreBucket(baseParams BaseParams, cloud cmn.Bck, prefix string, bck cmn.Bck) (id string, err error) {
+	return startBackupXact(baseParams, cmn.ActRestoreBck, bck, cloud, prefix)
+}
+
+func startBackupXact(baseParams BaseParams, kind string, bck, cloud cmn.Bck, prefix string) (id string, err error) {
+	msg := cmn.ActionMsg{
+		Action: cmn.ActXactStart,
+		Value:  xaction.XactReqMsg{Kind: kind, Bck: bck, Backup: &xaction.BackupMsg{Cloud: cloud, Prefix: prefix}},
+	}
+	baseParams.Method = http.MethodPut
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+	}, &id)
+	return id, err
+}
+
 // GetXactionStatsByID gets all xaction stats for given id.
 func GetXactionStatsByID(baseParams BaseParams, id string) (xactStat NodesXactStat, err error) {
 	xactStats, err := QueryXactionStats(baseParams, XactReqArgs{ID: id})
@@ -198,12 +272,19 @@ func QueryXactionStats(baseParams BaseParams, args XactReqArgs) (xactStats Nodes
 		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
 		Body:       cmn.MustMarshal(msg),
 		Query:      url.Values{cmn.URLParamWhat: []string{cmn.QueryXactStats}},
+		Ctx:        args.Ctx,
 	}, &xactStats)
 	return xactStats, err
 }
 
 // GetXactionStatus retrieves the status of the xaction.
 func GetXactionStatus(baseParams BaseParams, args XactReqArgs) (status *nl.NotifStatus, err error) {
+	return getXactionStatus(baseParams, args, 0)
+}
+
+// getXactionStatus with non-zero `wait` long-polls: the (IC) proxy responds once
+// the xaction finishes or when `wait` expires, whichever comes first.
+func getXactionStatus(baseParams BaseParams, args XactReqArgs, wait time.Duration) (status *nl.NotifStatus, err error) {
 	baseParams.Method = http.MethodGet
 	msg := xaction.XactReqMsg{
 		ID:   args.ID,
@@ -213,61 +294,118 @@ func GetXactionStatus(baseParams BaseParams, args XactReqArgs) (status *nl.Notif
 	if args.Latest {
 		msg.OnlyRunning = Bool(true)
 	}
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatStatus}}
+	if wait > 0 {
+		query.Set(cmn.URLParamNotifWait, wait.String())
+		if client := baseParams.Client; client.Timeout != 0 && client.Timeout < 2*wait {
+			extended := *client
+			extended.Timeout = client.Timeout + wait
+			baseParams.Client = &extended
+		}
+	}
 
 	status = &nl.NotifStatus{}
 	err = DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.JoinWords(cmn.Version, cmn.Cluster),
 		Body:       cmn.MustMarshal(msg),
-		Query: url.Values{
-			cmn.URLParamWhat: []string{cmn.GetWhatStatus},
-		},
+		Query:      query,
+		Ctx:        args.Ctx,
 	}, status)
 	return
 }
 
 // WaitForXaction waits for a given xaction to complete.
+// Instead of polling, it subscribes to the xaction's completion notification
+// (long-poll); `refreshIntervals` (if specified) is the minimum time between
+// consecutive requests. The wait ends upon `args.Timeout` expiration or when
+// `args.Ctx` gets canceled, whichever comes first - the error then being the
+// context's error. When `args.ProgressFn` is specified, it is called with the
+// xaction's stats every `args.ProgressInterval` until the xaction finishes.
 func WaitForXaction(baseParams BaseParams, args XactReqArgs,
 	refreshIntervals ...time.Duration) (status *nl.NotifStatus, err error) {
 	var (
-		ctx           = context.Background()
-		retryInterval = xactRetryInterval
+		ctx              = args.Ctx
+		retryInterval    = xactRetryInterval
+		progressInterval = args.ProgressInterval
+		lastProgress     = time.Now()
 	)
-
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if len(refreshIntervals) > 0 {
 		retryInterval = refreshIntervals[0]
 	}
-
+	if progressInterval <= 0 {
+		progressInterval = xactProgressInterval
+	}
 	if args.Timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, args.Timeout)
 		defer cancel()
 	}
+	args.Ctx = ctx
 
 	for {
-		status, err = GetXactionStatus(baseParams, args)
+		var (
+			wait    = xactLongPollWait
+			started = time.Now()
+		)
+		if args.ProgressFn != nil {
+			wait = cmn.MaxDuration(cmn.MinDuration(wait, progressInterval-time.Since(lastProgress)), time.Millisecond)
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < wait {
+				wait = cmn.MaxDuration(remaining, time.Millisecond)
+			}
+		}
+		status, err = getXactionStatus(baseParams, args, wait)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if err != nil || status.Finished() {
 			return
 		}
+		if args.ProgressFn != nil && time.Since(lastProgress) >= progressInterval {
+			lastProgress = time.Now()
+			// (failing to get intermediate stats is not fatal)
+			if xactStats, err := QueryXactionStats(baseParams, args); err == nil {
+				args.ProgressFn(xactStats)
+			}
+		}
 
-		time.Sleep(retryInterval)
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			break
+		// (older proxies don't long-poll)
+		if elapsed := time.Since(started); elapsed < retryInterval {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryInterval - elapsed):
+			}
 		}
 	}
 }
 
+// SubscribeXaction registers interest in a given xaction and asynchronously
+// calls back once the xaction finishes (or gets aborted), or upon failure -
+// including `args.Timeout` expiration.
+func SubscribeXaction(baseParams BaseParams, args XactReqArgs, cb func(status *nl.NotifStatus, err error)) {
+	go func() {
+		cb(WaitForXaction(baseParams, args))
+	}()
+}
+
 // WaitForXactionToStart waits for a given xaction to start.
 func WaitForXactionToStart(baseParams BaseParams, args XactReqArgs) error {
-	ctx := context.Background()
+	ctx := args.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if args.Timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, args.Timeout)
 		defer cancel()
 	}
+	args.Ctx = ctx
 
 	for {
 		xactStats, err := QueryXactionStats(baseParams, args)
@@ -281,12 +419,10 @@ func WaitForXactionToStart(baseParams BaseParams, args XactReqArgs) error {
 			break
 		}
 
-		time.Sleep(xactRetryInterval)
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		default:
-			break
+		case <-time.After(xactRetryInterval):
 		}
 	}
 	return nil
@@ -303,3 +439,17 @@ func MakeNCopies(baseParams BaseParams, bck cmn.Bck, copies int) (xactID string,
 	}, &xactID)
 	return
 }
+
+// MakeNCopiesExt is MakeNCopies restricted to a subset of the bucket's objects
+// (msg.Prefix and/or msg.Template) and optionally followed by the verification
+// (msg.Verify) - see cmn.MNCMsg. The objects that could not reach the requested
+// number of copies are reported in the xaction's (extended) stats.
+func MakeNCopiesExt(baseParams BaseParams, bck cmn.Bck, msg *cmn.MNCMsg) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMakeNCopies, Value: msg}),
+	}, &xactID)
+	return
+}
@@ -112,6 +112,9 @@ func (b *Bck) MaskBID(i int64) uint64 {
 	return uint64(i)
 }
 
+// BMD version in which the bucket was created (added)
+func (b *Bck) CreatedBMDVersion() int64 { return int64(b.unmaskBID()) }
+
 func (b *Bck) unmaskBID() uint64 {
 	if b.Props == nil || b.Props.BID == 0 {
 		return 0
@@ -187,6 +190,27 @@ func (b *Bck) Init(bowner Bowner, si *Snode) (err error) {
 
 func (b *Bck) InitNoBackend(bowner Bowner, si *Snode) (err error) {
 	bmd := bowner.Get()
+	b.init(bmd)
+	if b.Props == nil && b.Bck.IsAIS() && b.Ns.IsGlobal() {
+		if bck, ok := bmd.Alias(b.Name); ok {
+			b.Bck = bck
+			b.init(bmd)
+		}
+	}
+	if b.Props != nil {
+		return
+	}
+	var name string
+	if si != nil {
+		name = si.Name()
+	}
+	if b.Bck.IsAIS() {
+		return cmn.NewErrorBucketDoesNotExist(b.Bck, name)
+	}
+	return cmn.NewErrorRemoteBucketDoesNotExist(b.Bck, name)
+}
+
+func (b *Bck) init(bmd *BMD) {
 	if b.Provider == "" {
 		bmd.initBckAnyProvider(b)
 	} else if b.Bck.IsCloud() {
@@ -205,17 +229,6 @@ func (b *Bck) InitNoBackend(bowner Bowner, si *Snode) (err error) {
 	} else {
 		b.Props, _ = bmd.Get(b)
 	}
-	if b.Props != nil {
-		return
-	}
-	var name string
-	if si != nil {
-		name = si.Name()
-	}
-	if b.Bck.IsAIS() {
-		return cmn.NewErrorBucketDoesNotExist(b.Bck, name)
-	}
-	return cmn.NewErrorRemoteBucketDoesNotExist(b.Bck, name)
 }
 
 func (b *Bck) CksumConf() (conf *cmn.CksumConf) { return &b.Props.Cksum }
@@ -235,6 +248,9 @@ func (b *Bck) VersionConf() cmn.VersionConf {
 func (b *Bck) Allow(bit int) error { return b.checkAccess(bit) }
 
 func (b *Bck) checkAccess(bit int) (err error) {
+	if b.Props.ReadOnly && bit&cmn.AccessMutate != 0 {
+		return cmn.NewBucketReadOnlyError(b.String(), cmn.AccessOp(bit))
+	}
 	if b.Props.Access.Has(cmn.AccessAttrs(bit)) {
 		return
 	}
@@ -135,4 +135,62 @@ var _ = Describe("Bck", func() {
 			),
 		)
 	})
+
+	Describe("Init", func() {
+		var (
+			props  = &cmn.BucketProps{}
+			target = NewBck("dataset-v2", cmn.ProviderAIS, cmn.NsGlobal, props)
+			other  = NewBck("other", cmn.ProviderAIS, cmn.NsGlobal, props)
+			bowner = NewBaseBownerMock(target, other)
+		)
+		bowner.SetAlias("dataset", target.Bck)
+		bowner.SetAlias("other", target.Bck) // shadowed by the existing bucket
+		bowner.SetAlias("chained", cmn.Bck{Name: "dataset", Provider: cmn.ProviderAIS})
+
+		DescribeTable("should resolve bucket aliases",
+			func(name, provider, expected string) {
+				bck := NewBck(name, provider, cmn.NsGlobal)
+				Expect(bck.Init(bowner, nil)).NotTo(HaveOccurred())
+				Expect(bck.Name).To(Equal(expected))
+				Expect(bck.Provider).To(Equal(cmn.ProviderAIS))
+			},
+			Entry("alias", "dataset", cmn.ProviderAIS, "dataset-v2"),
+			Entry("alias, any provider", "dataset", "", "dataset-v2"),
+			Entry("existing bucket takes precedence", "other", cmn.ProviderAIS, "other"),
+		)
+
+		It("should not chain aliases", func() {
+			bck := NewBck("chained", cmn.ProviderAIS, cmn.NsGlobal)
+			Expect(bck.Init(bowner, nil)).To(HaveOccurred())
+		})
+	})
+
+	Describe("Allow", func() {
+		bck := NewBck("frozen", cmn.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{Access: cmn.AllAccess(), ReadOnly: true})
+
+		DescribeTable("should reject modifications of a read-only bucket",
+			func(bit int) {
+				err := bck.Allow(bit)
+				Expect(err).To(HaveOccurred())
+				_, ok := err.(*cmn.BucketReadOnlyError)
+				Expect(ok).To(BeTrue())
+			},
+			Entry("PUT", cmn.AccessPUT),
+			Entry("APPEND", cmn.AccessAPPEND),
+			Entry("DELETE", cmn.AccessObjDELETE),
+			Entry("rename", cmn.AccessObjRENAME),
+			Entry("bucket rename", cmn.AccessBckRENAME),
+		)
+
+		DescribeTable("should allow reads and props updates",
+			func(bit int) {
+				Expect(bck.Allow(bit)).NotTo(HaveOccurred())
+			},
+			Entry("GET", cmn.AccessGET),
+			Entry("HEAD", cmn.AccessObjHEAD),
+			Entry("list", cmn.AccessObjLIST),
+			Entry("PATCH", cmn.AccessPATCH),
+		)
+	})
 })
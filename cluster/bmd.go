@@ -6,9 +6,11 @@ package cluster
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
 )
 
 // interface to Get current BMD instance
@@ -21,15 +23,32 @@ type (
 	Buckets    map[string]*cmn.BucketProps
 	Namespaces map[string]Buckets
 	Providers  map[string]Namespaces
+	Aliases    map[string]cmn.Bck // alias => bucket
+
+	// state of the download jobs that are run (schedules) and throttled (shared
+	// limits) by the primary proxy - kept in BMD to survive restart of the
+	// primary and its change (see ais/prxdlsched.go)
+	DlScheds map[string]*DlSched            // schedule ID => schedule
+	DlShares map[string]jsoniter.RawMessage // job ID => downloader.DlLimits
+	DlSched  struct {
+		Body             jsoniter.RawMessage `json:"body"` // downloader.DlCloudBody
+		Path             string              `json:"path"`
+		Query            url.Values          `json:"query,omitempty"`
+		ProgressInterval int64               `json:"progress_interval,string"`
+		LastID           string              `json:"last_id,omitempty"` // most recent run
+	}
 
 	// - BMD is the root of the (providers, namespaces, buckets) hierarchy
 	// - BMD (instance) can be obtained via Bowner.Get()
 	// - BMD is immutable and versioned
 	// - BMD versioning is monotonic and incremental
 	BMD struct {
-		Version   int64     `json:"version,string"` // version - gets incremented on every update
-		UUID      string    `json:"uuid"`           // uuid stays the same for the lifetime
-		Providers Providers `json:"providers"`      // (provider, namespace, bucket) hierarchy
+		Version   int64     `json:"version,string"`    // version - gets incremented on every update
+		UUID      string    `json:"uuid"`              // uuid stays the same for the lifetime
+		Providers Providers `json:"providers"`         // (provider, namespace, bucket) hierarchy
+		Aliases   Aliases   `json:"aliases,omitempty"` // alias => bucket (see `Alias`)
+		DlScheds  DlScheds  `json:"dl_schedules,omitempty"`
+		DlShares  DlShares  `json:"dl_shares,omitempty"`
 	}
 )
 
@@ -133,6 +152,28 @@ func (m *BMD) Add(bck *Bck) {
 	buckets[bck.Name] = bck.Props
 }
 
+// Aliases are names of (non-existing) ais buckets that resolve to existing buckets
+// of any provider; existing buckets always take precedence. Aliases do not chain.
+func (m *BMD) Alias(name string) (bck cmn.Bck, ok bool) {
+	bck, ok = m.Aliases[name]
+	return
+}
+
+func (m *BMD) SetAlias(name string, bck cmn.Bck) {
+	if m.Aliases == nil {
+		m.Aliases = make(Aliases, 4)
+	}
+	bck.Props = nil
+	m.Aliases[name] = bck
+}
+
+func (m *BMD) DelAlias(name string) (deleted bool) {
+	if _, deleted = m.Aliases[name]; deleted {
+		delete(m.Aliases, name)
+	}
+	return
+}
+
 func (m *BMD) IsECUsed() (yes bool) {
 	m.Range(nil, nil, func(bck *Bck) (stop bool) {
 		if bck.Props.EC.Enabled {
@@ -180,6 +221,25 @@ func (m *BMD) DeepCopy(dst *BMD) {
 		}
 		dst.Providers[provider] = dstNamespaces
 	}
+	if len(m.Aliases) > 0 {
+		dst.Aliases = make(Aliases, len(m.Aliases))
+		for alias, bck := range m.Aliases {
+			dst.Aliases[alias] = bck
+		}
+	}
+	// (schedules and limits are replaced rather than modified in place)
+	if len(m.DlScheds) > 0 {
+		dst.DlScheds = make(DlScheds, len(m.DlScheds))
+		for id, sched := range m.DlScheds {
+			dst.DlScheds[id] = sched
+		}
+	}
+	if len(m.DlShares) > 0 {
+		dst.DlShares = make(DlShares, len(m.DlShares))
+		for id, limits := range m.DlShares {
+			dst.DlShares[id] = limits
+		}
+	}
 }
 
 /////////////////////
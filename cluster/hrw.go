@@ -7,6 +7,7 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
@@ -216,3 +217,91 @@ func HrwMpath(uname string) (mi *fs.MountpathInfo, digest uint64, err error) {
 	}
 	return
 }
+
+///////////////
+// HrwOwners //
+///////////////
+
+// HrwOwners determines object ownership for long-running jobs (e.g., download,
+// prefetch) that were started with a given cluster map. The owners are the job's
+// initial targets minus those that have since entered maintenance, are being
+// decommissioned, or have left the cluster. Targets that join later are not
+// considered - they never received the job. Once dropped, a target remains
+// dropped for the rest of the job.
+//
+// The job is processed in one or more passes: the first pass covers the objects
+// owned by this target when the job started; each subsequent pass (see Requeue)
+// picks up the objects of the targets dropped in the meantime.
+// NOTE: Owns and Requeue must not be called concurrently.
+type HrwOwners struct {
+	sowner  Sowner
+	sid     string
+	mu      sync.Mutex
+	view    *Smap   // initial targets that are still active
+	version int64   // version of the Smap the `view` was refreshed from
+	curr    *Smap   // owners in the current pass
+	prev    []*Smap // owners in the previous passes
+}
+
+func NewHrwOwners(sowner Sowner, sid string) *HrwOwners {
+	smap := sowner.Get()
+	view := &Smap{Tmap: smap.Tmap.ActiveMap(), Version: smap.Version, UUID: smap.UUID}
+	return &HrwOwners{sowner: sowner, sid: sid, view: view, version: smap.Version, curr: view}
+}
+
+// View returns the current owners (refreshed upon Smap change).
+func (o *HrwOwners) View() *Smap {
+	smap := o.sowner.Get()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if smap.Version == o.version {
+		return o.view
+	}
+	o.version = smap.Version
+	var tmap NodeMap
+	for id := range o.view.Tmap {
+		if tsi := smap.GetTarget(id); tsi != nil && !tsi.InMaintenance() {
+			continue
+		}
+		if tmap == nil {
+			tmap = o.view.Tmap.Clone()
+		}
+		delete(tmap, id)
+	}
+	if tmap != nil {
+		o.view = &Smap{Tmap: tmap, Version: smap.Version, UUID: smap.UUID}
+	}
+	return o.view
+}
+
+// Active returns false once this target itself is no longer an owner.
+func (o *HrwOwners) Active() bool {
+	_, ok := o.View().Tmap[o.sid]
+	return ok
+}
+
+// Owns returns true if the object must be processed by this target in the current pass.
+func (o *HrwOwners) Owns(uname string) (bool, error) {
+	si, err := HrwTarget(uname, o.curr)
+	if err != nil || si.ID() != o.sid {
+		return false, err
+	}
+	for _, smap := range o.prev {
+		if si, err = HrwTarget(uname, smap); err == nil && si.ID() == o.sid {
+			return false, nil // covered by one of the previous passes
+		}
+	}
+	return o.Active(), nil
+}
+
+// Requeue starts a new pass if any of the owners was dropped since the current
+// pass had started; returns false otherwise or when this target is no longer an owner.
+func (o *HrwOwners) Requeue() bool {
+	view := o.View()
+	if _, ok := view.Tmap[o.sid]; !ok || view.CountTargets() == o.curr.CountTargets() {
+		return false
+	}
+	o.prev = append(o.prev, o.curr)
+	o.curr = view
+	return true
+}
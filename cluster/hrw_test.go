@@ -0,0 +1,116 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type smapOwnerMock struct {
+	smap *Smap
+}
+
+func (o *smapOwnerMock) Get() *Smap               { return o.smap }
+func (o *smapOwnerMock) Listeners() SmapListeners { return nil }
+
+var _ = Describe("HrwOwners", func() {
+	const numObjs = 1000
+
+	var (
+		bck    = NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal)
+		sowner *smapOwnerMock
+	)
+
+	newSmap := func(version int64, nodes ...*Snode) *Smap {
+		smap := &Smap{Tmap: make(NodeMap, len(nodes)), Version: version}
+		for _, si := range nodes {
+			smap.Tmap.Add(si)
+		}
+		smap.InitDigests()
+		return smap
+	}
+
+	// uname => target ID, per pass
+	owned := func(owners map[string]*HrwOwners) map[string]string {
+		res := make(map[string]string, numObjs)
+		for i := 0; i < numObjs; i++ {
+			uname := bck.MakeUname(fmt.Sprintf("obj-%d", i))
+			for sid, o := range owners {
+				ok, err := o.Owns(uname)
+				Expect(err).NotTo(HaveOccurred())
+				if ok {
+					Expect(res).NotTo(HaveKey(uname))
+					res[uname] = sid
+				}
+			}
+		}
+		return res
+	}
+
+	BeforeEach(func() {
+		sowner = &smapOwnerMock{smap: newSmap(1,
+			&Snode{DaemonID: "t1"}, &Snode{DaemonID: "t2"}, &Snode{DaemonID: "t3"}, &Snode{DaemonID: "t4"},
+			&Snode{DaemonID: "t5", Flags: SnodeMaintenance},
+		)}
+	})
+
+	It("should requeue objects of the target that entered maintenance", func() {
+		owners := make(map[string]*HrwOwners, 4)
+		for _, sid := range []string{"t1", "t2", "t3", "t4"} {
+			owners[sid] = NewHrwOwners(sowner, sid)
+		}
+		first := owned(owners)
+		Expect(first).To(HaveLen(numObjs))
+		for _, sid := range first {
+			Expect(sid).NotTo(Equal("t5"))
+		}
+
+		// t2 enters maintenance, t6 joins
+		sowner.smap = newSmap(2,
+			&Snode{DaemonID: "t1"}, &Snode{DaemonID: "t2", Flags: SnodeMaintenance}, &Snode{DaemonID: "t3"},
+			&Snode{DaemonID: "t4"}, &Snode{DaemonID: "t5", Flags: SnodeMaintenance}, &Snode{DaemonID: "t6"},
+		)
+		Expect(owners["t2"].Active()).To(BeFalse())
+		Expect(owners["t2"].Requeue()).To(BeFalse())
+		Expect(owners["t1"].Active()).To(BeTrue())
+		for uname, sid := range owned(owners) {
+			Expect(sid).NotTo(Equal("t2"))
+			Expect(first[uname]).To(Equal(sid))
+		}
+
+		for _, sid := range []string{"t1", "t3", "t4"} {
+			Expect(owners[sid].Requeue()).To(BeTrue())
+		}
+		second := owned(owners)
+		for uname, sid := range first {
+			if sid == "t2" {
+				Expect(second).To(HaveKey(uname))
+				Expect(second[uname]).NotTo(Equal("t2"))
+			} else {
+				Expect(second).NotTo(HaveKey(uname))
+			}
+		}
+		for _, sid := range []string{"t1", "t3", "t4"} {
+			Expect(owners[sid].Requeue()).To(BeFalse())
+		}
+	})
+
+	It("should not bring back the target that left maintenance", func() {
+		owners := NewHrwOwners(sowner, "t1")
+		sowner.smap = newSmap(2, &Snode{DaemonID: "t1"}, &Snode{DaemonID: "t3"}, &Snode{DaemonID: "t4"})
+		Expect(owners.View().CountTargets()).To(Equal(3))
+		sowner.smap = newSmap(3,
+			&Snode{DaemonID: "t1"}, &Snode{DaemonID: "t2"}, &Snode{DaemonID: "t3"}, &Snode{DaemonID: "t4"},
+			&Snode{DaemonID: "t5"},
+		)
+		Expect(owners.View().CountTargets()).To(Equal(3))
+		Expect(owners.Requeue()).To(BeTrue())
+		Expect(owners.Requeue()).To(BeFalse())
+	})
+})
@@ -102,6 +102,10 @@ func (lom *LOM) GetCustomMD(key string) (string, bool) {
 	value, exists := lom.md.customMD[key]
 	return value, exists
 }
+func (lom *LOM) IsPinned() bool {
+	value, exists := lom.md.customMD[PinnedObjMD]
+	return exists && cmn.IsParseBool(value)
+}
 func (lom *LOM) ECEnabled() bool            { return lom.Bprops().EC.Enabled }
 func (lom *LOM) IsHRW() bool                { return lom.HrwFQN == lom.FQN } // subj to resilvering
 func (lom *LOM) Bck() *Bck                  { return lom.bck }
@@ -258,6 +262,9 @@ func (lom *LOM) AddCopy(copyFQN string, mpi *fs.MountpathInfo) error {
 	return lom.syncMetaWithCopies()
 }
 
+// PersistCopies stores (in-memory) metadata with all the copies of the object.
+func (lom *LOM) PersistCopies() error { return lom.syncMetaWithCopies() }
+
 func (lom *LOM) DelCopies(copiesFQN ...string) (err error) {
 	numCopies := lom.NumCopies()
 	// 1. Delete all copies from metadata
@@ -494,7 +501,7 @@ func (lom *LOM) LoadBalanceGET() (fqn string) {
 	if !lom.HasCopies() {
 		return lom.FQN
 	}
-	return fs.LoadBalanceGET(lom.FQN, lom.ParsedFQN.MpathInfo.Path, lom.GetCopies())
+	return fs.LoadBalanceGET(lom.FQN, lom.ParsedFQN.MpathInfo.Path, lom.GetCopies(), lom.MirrorConf().HotLabel)
 }
 
 // Returns stored checksum (if present) and computed checksum (if requested)
@@ -845,6 +852,7 @@ beg:
 
 func (lom *LOM) Remove() (err error) {
 	lom.Uncache()
+	lom.DedupRelease()
 	err = cmn.RemoveFile(lom.FQN)
 	for copyFQN := range lom.md.copies {
 		if err := cmn.RemoveFile(copyFQN); err != nil {
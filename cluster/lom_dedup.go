@@ -0,0 +1,197 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+// Content-addressed storage (CAS) mode (see cmn.DedupConf)
+//
+// Each distinct content of the bucket's objects is stored once per mountpath:
+// as a file named by the content checksum and size under the CAS content type
+// (fs.CASType). Objects are hard links to their CAS files; respectively, the
+// filesystem counts the references, and a CAS file that has no other links
+// (i.e., is not referenced by any object) is removed together with the last
+// object (see DedupRelease). On-disk object metadata (xattr) is shared as well
+// which is why objects with custom metadata (e.g., downloaded or cached from
+// Cloud) are never deduplicated; an object that gets such metadata later on
+// (or gets mirrored) stops sharing its content first (see DedupBreak).
+
+// Dedup makes the (just stored) object share its content with identical
+// objects on the same mountpath: the object gets replaced with a link to the
+// existing CAS file or, if there's none, becomes one. The caller must hold
+// the write lock and the object's metadata must be already persisted.
+func (lom *LOM) Dedup() (deduped bool, err error) {
+	casFQN := lom.casFQN(lom.md.cksum, lom.md.size)
+	if casFQN == "" || lom.md.version != "" || len(lom.md.customMD) > 0 || len(lom.md.copies) > 0 {
+		return
+	}
+	if err = cmn.CreateDir(filepath.Dir(casFQN)); err != nil {
+		return
+	}
+	if err = os.Link(lom.FQN, casFQN); err == nil || !os.IsExist(err) {
+		return // new content (or error)
+	}
+	// same checksum and size - make sure it's not a collision
+	same, err := lom.sameContent(casFQN)
+	if err != nil || !same {
+		if !same && err == nil {
+			glog.Warningf("%s: checksum collision with %s - not deduplicating", lom, casFQN)
+		}
+		return
+	}
+	// identical content is already stored - replace the object with another link to it
+	workFQN := fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, "dedup")
+	if err = cmn.CreateDir(filepath.Dir(workFQN)); err != nil {
+		return
+	}
+	if err = os.Link(casFQN, workFQN); err != nil {
+		return
+	}
+	if err = os.Rename(workFQN, lom.FQN); err != nil {
+		if errRemove := os.Remove(workFQN); errRemove != nil {
+			glog.Errorf("nested error: %v", errRemove)
+		}
+		return
+	}
+	return true, nil
+}
+
+// compares the object's content with a given file, byte by byte
+func (lom *LOM) sameContent(fqn string) (same bool, err error) {
+	var f1, f2 *os.File
+	if f1, err = os.Open(lom.FQN); err != nil {
+		return
+	}
+	defer f1.Close()
+	if f2, err = os.Open(fqn); err != nil {
+		return
+	}
+	defer f2.Close()
+	var (
+		buf1, slab1 = lom.T.MMSA().Alloc()
+		buf2, slab2 = lom.T.MMSA().Alloc()
+	)
+	defer func() {
+		slab1.Free(buf1)
+		slab2.Free(buf2)
+	}()
+	for {
+		n1, err1 := io.ReadFull(f1, buf1)
+		n2, err2 := io.ReadFull(f2, buf2)
+		if n1 != n2 || !bytes.Equal(buf1[:n1], buf2[:n2]) {
+			return false, nil
+		}
+		eof1 := err1 == io.EOF || err1 == io.ErrUnexpectedEOF
+		eof2 := err2 == io.EOF || err2 == io.ErrUnexpectedEOF
+		switch {
+		case err1 != nil && !eof1:
+			return false, err1
+		case err2 != nil && !eof2:
+			return false, err2
+		case eof1 || eof2:
+			return eof1 && eof2, nil
+		}
+	}
+}
+
+// dedupable returns true if the object's metadata allows sharing its content
+func (lom *LOM) dedupable() bool {
+	return lom.md.version == "" && len(lom.md.customMD) == 0 && len(lom.md.copies) == 0
+}
+
+// DedupBreak makes the object stop sharing its content (copy-on-write): the
+// object gets its own copy of the content and on-disk metadata (unless it
+// is the last reference - the CAS file is then simply released). Must be
+// done (under write lock) prior to updating on-disk metadata that's not to
+// be shared, and prior to moving the object elsewhere.
+func (lom *LOM) DedupBreak() (err error) {
+	if lom.Bprops() == nil || !lom.Bprops().Dedup.Enabled {
+		return
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if ios.GetNlink(finfo) < 2 {
+		return
+	}
+	lom.DedupRelease()
+	if finfo, err = os.Stat(lom.FQN); err != nil || ios.GetNlink(finfo) < 2 {
+		return
+	}
+	var (
+		md        []byte
+		workFQN   = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, "dedup")
+		buf, slab = lom.T.MMSA().Alloc()
+	)
+	_, _, err = cmn.CopyFile(lom.FQN, workFQN, buf, cmn.ChecksumNone)
+	slab.Free(buf)
+	if err == nil {
+		if md, err = fs.GetXattr(lom.FQN, XattrLOM); err == nil {
+			if err = fs.SetXattr(workFQN, XattrLOM, md); err == nil {
+				err = os.Rename(workFQN, lom.FQN)
+			}
+		}
+	}
+	if err != nil {
+		if errRemove := os.Remove(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			glog.Errorf("nested error: %v", errRemove)
+		}
+	}
+	return
+}
+
+// DedupRelease removes the object's CAS file if the object (about to be deleted
+// or overwritten) is its last reference. The object's metadata is read from
+// the filesystem as the in-memory one may already describe the new content.
+func (lom *LOM) DedupRelease() {
+	if lom.Bprops() == nil || !lom.Bprops().Dedup.Enabled {
+		return
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil || ios.GetNlink(finfo) != 2 { // the object and its CAS file
+		return
+	}
+	md, err := lom.lmfs(false)
+	if err != nil {
+		return
+	}
+	casFQN := lom.casFQN(md.cksum, md.size)
+	if casFQN == "" {
+		return
+	}
+	if casInfo, err := os.Stat(casFQN); err == nil && os.SameFile(finfo, casInfo) {
+		if err := cmn.RemoveFile(casFQN); err != nil {
+			glog.Error(err)
+		}
+	}
+}
+
+// e.g. <mpath>/@ais/<bucket>/%ca/3f/xxhash-3f9a0d5c7b1e2a44-1048576
+func (lom *LOM) casFQN(cksum *cmn.Cksum, size int64) string {
+	if cksum == nil {
+		return ""
+	}
+	ty, value := cksum.Get()
+	if ty == cmn.ChecksumNone || len(value) < 2 {
+		return ""
+	}
+	name := value[:2] + "/" + ty + "-" + value + "-" + strconv.FormatInt(size, 10)
+	return fs.CSM.FQN(lom.ParsedFQN.MpathInfo, lom.bck.Bck, fs.CASType, name)
+}
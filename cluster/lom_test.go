@@ -29,6 +29,7 @@ var _ = Describe("LOM", func() {
 		bucketLocalA = "LOM_TEST_Local_A"
 		bucketLocalB = "LOM_TEST_Local_B"
 		bucketLocalC = "LOM_TEST_Local_C"
+		bucketDedup  = "LOM_TEST_Local_Dedup"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
@@ -68,6 +69,7 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.CASType, &fs.CASContentResolver{})
 
 	var (
 		bmd = cluster.NewBaseBownerMock(
@@ -87,6 +89,10 @@ var _ = Describe("LOM", func() {
 					Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
 				},
 			),
+			cluster.NewBck(
+				bucketDedup, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}, Dedup: cmn.DedupConf{Enabled: true}},
+			),
 			cluster.NewBck(sameBucketName, cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{}),
 			cluster.NewBck(bucketCloudA, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{}),
 			cluster.NewBck(bucketCloudB, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{}),
@@ -578,6 +584,15 @@ var _ = Describe("LOM", func() {
 				_, exists = lom.GetCustomMD("unknown")
 				Expect(exists).To(BeFalse())
 			})
+
+			It("should parse the pin", func() {
+				lom := filePut(localFQN, 0, tMock)
+				Expect(lom.IsPinned()).To(BeFalse())
+				lom.SetCustomMD(cmn.SimpleKVs{cluster.PinnedObjMD: "true"})
+				Expect(lom.IsPinned()).To(BeTrue())
+				lom.SetCustomMD(cmn.SimpleKVs{cluster.PinnedObjMD: "false"})
+				Expect(lom.IsPinned()).To(BeFalse())
+			})
 		})
 	})
 
@@ -961,6 +976,116 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("Dedup", func() {
+		var (
+			dedupBck = cmn.Bck{Name: bucketDedup, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+			content  = []byte("identical content")
+		)
+
+		putDedup := func(objName string, data []byte) *cluster.LOM {
+			fqn := mis[0].MakePathFQN(dedupBck, fs.ObjectType, objName)
+			Expect(cmn.CreateDir(filepath.Dir(fqn))).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(fqn, data, 0o644)).NotTo(HaveOccurred())
+			lom := NewBasicLom(fqn, tMock)
+			lom.SetSize(int64(len(data)))
+			_, err := lom.ComputeCksumIfMissing()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			return lom
+		}
+		sameFile := func(fqn1, fqn2 string) bool {
+			fi1, err := os.Stat(fqn1)
+			Expect(err).NotTo(HaveOccurred())
+			fi2, err := os.Stat(fqn2)
+			Expect(err).NotTo(HaveOccurred())
+			return os.SameFile(fi1, fi2)
+		}
+
+		It("should share identical content and remove it with the last object", func() {
+			lom1 := putDedup("dedup/obj1", content)
+			deduped, err := lom1.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deduped).To(BeFalse())
+
+			lom2 := putDedup("dedup/obj2", content)
+			deduped, err = lom2.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deduped).To(BeTrue())
+			Expect(sameFile(lom1.FQN, lom2.FQN)).To(BeTrue())
+
+			lom3 := putDedup("dedup/obj3", []byte("different content"))
+			deduped, err = lom3.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deduped).To(BeFalse())
+			Expect(sameFile(lom1.FQN, lom3.FQN)).To(BeFalse())
+
+			// metadata is shared and remains valid
+			lom := NewBasicLom(lom2.FQN, tMock)
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.Cksum().Equal(lom1.Cksum())).To(BeTrue())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			casDir := mis[0].MakePathCT(dedupBck, fs.CASType)
+			countCAS := func() (cnt int) {
+				filepath.Walk(casDir, func(_ string, fi os.FileInfo, _ error) error {
+					if fi != nil && !fi.IsDir() {
+						cnt++
+					}
+					return nil
+				})
+				return
+			}
+			Expect(countCAS()).To(Equal(2))
+
+			Expect(lom1.Remove()).NotTo(HaveOccurred())
+			Expect(countCAS()).To(Equal(2))
+			Expect(lom2.Remove()).NotTo(HaveOccurred())
+			Expect(countCAS()).To(Equal(1))
+			Expect(lom3.Remove()).NotTo(HaveOccurred())
+			Expect(countCAS()).To(Equal(0))
+		})
+
+		It("should not share content upon checksum collision", func() {
+			lom1 := putDedup("collision/obj1", []byte("content 1"))
+			_, err := lom1.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+
+			// same size and (forged) checksum, different bytes
+			lom2 := putDedup("collision/obj2", []byte("content 2"))
+			lom2.SetCksum(lom1.Cksum().Clone())
+			Expect(lom2.Persist()).NotTo(HaveOccurred())
+			deduped, err := lom2.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deduped).To(BeFalse())
+			Expect(sameFile(lom1.FQN, lom2.FQN)).To(BeFalse())
+			data, err := ioutil.ReadFile(lom2.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("content 2"))
+		})
+
+		It("should stop sharing content prior to updating metadata", func() {
+			lom1 := putDedup("cow/obj1", content)
+			_, err := lom1.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			lom2 := putDedup("cow/obj2", content)
+			deduped, err := lom2.Dedup()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deduped).To(BeTrue())
+
+			lom2.SetCustomMD(cmn.SimpleKVs{"pinned": "true"})
+			Expect(lom2.Persist()).NotTo(HaveOccurred())
+			Expect(sameFile(lom1.FQN, lom2.FQN)).To(BeFalse())
+
+			lom := NewBasicLom(lom1.FQN, tMock)
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.CustomMD()).To(BeEmpty())
+			lom = NewBasicLom(lom2.FQN, tMock)
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.CustomMD()).To(HaveKeyWithValue("pinned", "true"))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
@@ -77,9 +77,46 @@ const (
 	CRC32CObjMD  = cmn.ChecksumCRC32C
 	MD5ObjMD     = cmn.ChecksumMD5
 
+	// validators of the web (HTTP) source, see RFC 7232
+	ETagObjMD         = "etag"
+	LastModifiedObjMD = "last_modified"
+
 	OrigURLObjMD = "orig_url"
+
+	PinnedObjMD = "pinned" // LRU never evicts pinned objects
 )
 
+var internalObjMD = map[string]struct{}{
+	SourceObjMD:       {},
+	VersionObjMD:      {},
+	CRC32CObjMD:       {},
+	MD5ObjMD:          {},
+	ETagObjMD:         {},
+	LastModifiedObjMD: {},
+	OrigURLObjMD:      {},
+	PinnedObjMD:       {},
+}
+
+// IsInternalObjMD returns true if the custom metadata key is maintained by
+// aistore itself (and is not to be set by users).
+func IsInternalObjMD(key string) bool {
+	_, ok := internalObjMD[key]
+	return ok
+}
+
+// ValidateObjMD checks custom metadata key-value pair set by user.
+func ValidateObjMD(key, value string) error {
+	switch {
+	case key == "" || strings.ContainsAny(key, "="+customMDSepa):
+		return fmt.Errorf("invalid custom metadata key %q", key)
+	case IsInternalObjMD(key):
+		return fmt.Errorf("custom metadata key %q is reserved", key)
+	case strings.Contains(value, customMDSepa):
+		return fmt.Errorf("invalid custom metadata value %q (key %q)", value, key)
+	}
+	return nil
+}
+
 func (lom *LOM) LoadMetaFromFS() error { _, err := lom.lmfs(true); return err }
 
 // TODO -- FIXME: xattrMaxSize == MaxSmallSlabSize is the hard limit
@@ -127,6 +164,12 @@ func (lom *LOM) lmfs(populate bool) (md *lmeta, err error) {
 }
 
 func (lom *LOM) Persist() (err error) {
+	if !lom.dedupable() {
+		// on-disk metadata of deduplicated objects is shared (see Dedup)
+		if err = lom.DedupBreak(); err != nil {
+			return
+		}
+	}
 	buf, mm := lom._persist()
 	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		lom.T.FSHC(err, lom.FQN)
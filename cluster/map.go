@@ -28,6 +28,7 @@ const (
 	SnodeIC
 	SnodeMaintenance
 	SnodeDecomission
+	SnodePrefill // joined without rebalance - being prefilled (see cmn.RebalanceConf.Prefill)
 )
 
 const (
@@ -200,6 +201,7 @@ func (d *Snode) IsTarget() bool      { return d.DaemonType == cmn.Target }
 func (d *Snode) InMaintenance() bool { return d.Flags.IsAnySet(SnodeMaintenanceMask) }
 func (d *Snode) NonElectable() bool  { return d.Flags.IsSet(SnodeNonElectable) }
 func (d *Snode) IsIC() bool          { return d.Flags.IsSet(SnodeIC) }
+func (d *Snode) InPrefill() bool     { return d.Flags.IsSet(SnodePrefill) }
 
 //===============================================================
 //
@@ -264,6 +266,16 @@ func (m *Smap) NewTmap(tids []string) (tmap NodeMap, err error) {
 	return
 }
 
+// IDs of the targets in prefill mode
+func (m *Smap) PrefillTargets() (tids []string) {
+	for tid, tsi := range m.Tmap {
+		if tsi.InPrefill() {
+			tids = append(tids, tid)
+		}
+	}
+	return
+}
+
 func (m *Smap) GetNode(id string) *Snode {
 	if node := m.GetTarget(id); node != nil {
 		return node
@@ -395,6 +407,19 @@ func (m NodeMap) Clone() (clone NodeMap) {
 	return
 }
 
+// ActiveMap returns a copy of the map that excludes nodes in maintenance
+// (or being decommissioned).
+func (m NodeMap) ActiveMap() (clone NodeMap) {
+	clone = make(NodeMap, len(m))
+	for id, node := range m {
+		if node.InMaintenance() {
+			continue
+		}
+		clone[id] = node
+	}
+	return
+}
+
 func (m NodeMap) Nodes() []*Snode {
 	snodes := make([]*Snode, 0, len(m))
 	for _, t := range m {
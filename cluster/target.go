@@ -97,6 +97,7 @@ type (
 		Bck       *Bck
 		ObjName   string
 		Cksum     *cmn.Cksum
+		CustomMD  cmn.SimpleKVs // optional
 		Overwrite bool
 		KeepOrig  bool
 		Verbose   bool
@@ -120,6 +121,7 @@ type Target interface {
 	PutObject(lom *LOM, params PutObjectParams) error
 	EvictObject(lom *LOM) error
 	DeleteObject(ctx context.Context, lom *LOM, evict bool) (error, int)
+	TrashObject(ctx context.Context, lom *LOM, token string, deadline time.Time) error
 	CopyObject(lom *LOM, params CopyObjectParams, localOnly bool) (bool, int64, error)
 	GetCold(ctx context.Context, lom *LOM, prefetch bool) (error, int)
 	PromoteFile(params PromoteFileParams) (lom *LOM, err error)
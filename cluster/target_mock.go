@@ -44,6 +44,9 @@ func (*TargetMock) PutObject(_ *LOM, _ PutObjectParams) error
 func (*TargetMock) GetObject(_ io.Writer, _ *LOM, _ time.Time) error            { return nil }
 func (*TargetMock) EvictObject(_ *LOM) error                                    { return nil }
 func (*TargetMock) DeleteObject(_ context.Context, _ *LOM, _ bool) (error, int) { return nil, 0 }
+func (*TargetMock) TrashObject(_ context.Context, _ *LOM, _ string, _ time.Time) error {
+	return nil
+}
 func (*TargetMock) CopyObject(_ *LOM, _ CopyObjectParams, _ bool) (bool, int64, error) {
 	return false, 0, nil
 }
@@ -0,0 +1,168 @@
+// Package main implements a lightweight witness that votes in primary elections
+// of an AIS cluster (see cmn.ProxyConf.WitnessURL) - e.g., to break ties in
+// two-proxy deployments.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	voteYes = "YES"
+	voteNo  = "NO"
+
+	helpMsg = `Build:
+	go install ./cmd/aiswitness
+
+Examples:
+	aiswitness -h                        - show usage
+	aiswitness -listen=:9090 -secret=<secret>            - run the witness; then, set proxy.witness_url=http://<host>:9090
+	                                                       and proxy.witness_secret=<secret>
+	aiswitness -listen=:9090 -secret=<secret> -grant=5m  - do not vote for another candidate within 5 minutes
+`
+)
+
+type (
+	// (same as ais.VoteMessage)
+	voteMsg struct {
+		Record struct {
+			Candidate string       `json:"candidate"`
+			Primary   string       `json:"primary"`
+			Smap      cluster.Smap `json:"smap"`
+		} `json:"vote_record"`
+	}
+
+	// witness votes "yes" if and only if it cannot reach the primary either and,
+	// in addition, it does not vote for two different candidates (e.g., on the
+	// two sides of a partition) within the `grant` interval.
+	witness struct {
+		client  *http.Client
+		secret  string // shared with the proxies (see cmn.WitnessSig)
+		timeout time.Duration
+		grant   time.Duration
+
+		mu        sync.Mutex
+		candidate string // granted the vote to
+		granted   time.Time
+	}
+)
+
+var flags struct {
+	listen  string
+	secret  string
+	timeout time.Duration
+	grant   time.Duration
+	help    bool
+}
+
+func main() {
+	newFlag := flag.NewFlagSet(os.Args[0], flag.ExitOnError) // discard flags of imported packages
+	newFlag.StringVar(&flags.listen, "listen", ":9090", "address to listen on")
+	newFlag.StringVar(&flags.secret, "secret", "", "secret shared with the proxies (proxy.witness_secret)")
+	newFlag.DurationVar(&flags.timeout, "timeout", 2*time.Second, "timeout to ping the primary")
+	newFlag.DurationVar(&flags.grant, "grant", time.Minute, "minimum interval between votes for different candidates")
+	newFlag.BoolVar(&flags.help, "h", false, "print usage and exit")
+	newFlag.Parse(os.Args[1:])
+	if flags.help {
+		fmt.Print(helpMsg)
+		os.Exit(0)
+	}
+	if flags.secret == "" {
+		fmt.Fprintln(os.Stderr, "missing -secret (see proxy.witness_secret)")
+		os.Exit(2)
+	}
+
+	w := &witness{client: &http.Client{}, secret: flags.secret, timeout: flags.timeout, grant: flags.grant}
+	mux := http.NewServeMux()
+	mux.HandleFunc(cmn.JoinWords(cmn.Version, cmn.Health), func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc(cmn.JoinWords(cmn.Version, cmn.Vote, cmn.VotePre), w.handler(false /*grant*/))
+	mux.HandleFunc(cmn.JoinWords(cmn.Version, cmn.Vote, cmn.Proxy), w.handler(true /*grant*/))
+	log.Printf("witness listening on %s", flags.listen)
+	log.Fatal(http.ListenAndServe(flags.listen, mux))
+}
+
+func (w *witness) handler(grant bool) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(rw, "invalid method "+r.Method, http.StatusBadRequest)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig := cmn.WitnessSig(w.secret, body)
+		if !hmac.Equal([]byte(sig), []byte(r.Header.Get(cmn.HeaderWitnessSig))) {
+			log.Printf("%s: invalid signature (from %s)", r.URL.Path, r.RemoteAddr)
+			http.Error(rw, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		msg := &voteMsg{}
+		if err := jsoniter.Unmarshal(body, msg); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		vote, reason := w.vote(msg, grant)
+		log.Printf("%s (grant: %t): candidate %s, primary %s: %s - %s",
+			r.URL.Path, grant, msg.Record.Candidate, msg.Record.Primary, vote, reason)
+		rw.Header().Set(cmn.HeaderWitnessSig, cmn.WitnessSig(w.secret, body, []byte(vote)))
+		rw.Write([]byte(vote))
+	}
+}
+
+func (w *witness) vote(msg *voteMsg, grant bool) (string, string) {
+	rec := &msg.Record
+	if rec.Candidate == "" || rec.Candidate == rec.Primary {
+		return voteNo, "invalid candidate"
+	}
+	primary := rec.Smap.GetProxy(rec.Primary)
+	if primary == nil {
+		return voteNo, "primary not present in the Smap"
+	}
+	if w.ping(primary) {
+		return voteNo, "the primary is alive"
+	}
+	if !grant {
+		return voteYes, "the primary is unreachable"
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.candidate != "" && w.candidate != rec.Candidate && time.Since(w.granted) < w.grant {
+		return voteNo, fmt.Sprintf("voted for %s %v ago", w.candidate, time.Since(w.granted))
+	}
+	w.candidate, w.granted = rec.Candidate, time.Now()
+	return voteYes, "the primary is unreachable"
+}
+
+func (w *witness) ping(si *cluster.Snode) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+	url := si.URL(cmn.NetworkIntraControl) + cmn.JoinWords(cmn.Version, cmn.Health)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
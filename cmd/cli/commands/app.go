@@ -161,15 +161,19 @@ func (aisCLI *AISCLI) setupCommands() {
 	app.Commands = append(app.Commands, renameCmds...)
 	app.Commands = append(app.Commands, removeCmds...)
 	app.Commands = append(app.Commands, copyCmds...)
+	app.Commands = append(app.Commands, exportCmds...)
 	app.Commands = append(app.Commands, setCmds...)
 	app.Commands = append(app.Commands, attachCmds...)
 	app.Commands = append(app.Commands, detachCmds...)
+	app.Commands = append(app.Commands, remoteCmds...)
 	app.Commands = append(app.Commands, controlCmds...)
 	app.Commands = append(app.Commands, cluSpecificCmds...)
 	app.Commands = append(app.Commands, showCmds...)
+	app.Commands = append(app.Commands, storageCmds...)
 	app.Commands = append(app.Commands, waitCmds...)
 	app.Commands = append(app.Commands, objectSpecificCmds...)
 	app.Commands = append(app.Commands, etlCmds...)
+	app.Commands = append(app.Commands, undoCmds...)
 	sort.Sort(cli.CommandsByName(app.Commands))
 
 	setupCommandHelp(app.Commands)
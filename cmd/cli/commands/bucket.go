@@ -8,16 +8,20 @@ package commands
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
 
@@ -283,6 +287,74 @@ func fetchSummaries(query cmn.QueryBcks, fast, cached bool) (summaries cmn.Bucke
 	return
 }
 
+// newAnalysisView prepares the bucket analysis for display: histogram bins get
+// human-readable names while extensions and prefixes get sorted by size (desc)
+// and, unless `top` is zero, truncated.
+func newAnalysisView(analysis *cmn.BucketAnalysis, top int) *templates.BucketAnalysisView {
+	view := &templates.BucketAnalysisView{BucketAnalysis: analysis}
+	pct := func(cnt int64) float64 {
+		if analysis.ObjCount == 0 {
+			return 0
+		}
+		return float64(cnt) * 100 / float64(analysis.ObjCount)
+	}
+	for i, cnt := range analysis.SizeHist {
+		var name string
+		switch {
+		case i == 0:
+			name = "< " + cmn.B2S(cmn.AnalysisSizeBounds[0], 0)
+		case i == len(cmn.AnalysisSizeBounds):
+			name = ">= " + cmn.B2S(cmn.AnalysisSizeBounds[i-1], 0)
+		default:
+			name = cmn.B2S(cmn.AnalysisSizeBounds[i-1], 0) + " - " + cmn.B2S(cmn.AnalysisSizeBounds[i], 0)
+		}
+		view.SizeRows = append(view.SizeRows, templates.AnalysisRow{Name: name, Count: cnt, Pct: pct(cnt)})
+	}
+	for i, cnt := range analysis.AtimeHist {
+		var name string
+		switch {
+		case i == 0:
+			name = "< " + duration.HumanDuration(cmn.AnalysisAgeBounds[0]) + " ago"
+		case i == len(cmn.AnalysisAgeBounds):
+			name = ">= " + duration.HumanDuration(cmn.AnalysisAgeBounds[i-1]) + " ago"
+		default:
+			name = duration.HumanDuration(cmn.AnalysisAgeBounds[i-1]) + " - " +
+				duration.HumanDuration(cmn.AnalysisAgeBounds[i]) + " ago"
+		}
+		view.AtimeRows = append(view.AtimeRows, templates.AnalysisRow{Name: name, Count: cnt, Pct: pct(cnt)})
+	}
+	view.ExtRows = analysisRows(analysis.Extensions, "(none)", top, pct)
+	view.PrefixRows = analysisRows(analysis.Prefixes, "/", top, pct)
+	if analysis.Dedup != nil {
+		view.DupGroups = analysis.Dedup.Groups
+		if top > 0 && len(view.DupGroups) > top {
+			view.DupGroups = view.DupGroups[:top]
+		}
+	}
+	return view
+}
+
+func analysisRows(counts map[string]*cmn.AnalysisCount, emptyName string, top int,
+	pct func(int64) float64) []templates.AnalysisRow {
+	rows := make([]templates.AnalysisRow, 0, len(counts))
+	for name, cnt := range counts {
+		if name == "" {
+			name = emptyName
+		}
+		rows = append(rows, templates.AnalysisRow{Name: name, Count: cnt.Count, Size: cnt.Size, Pct: pct(cnt.Count)})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Size != rows[j].Size {
+			return rows[i].Size > rows[j].Size
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+	return rows
+}
+
 // Replace user-friendly properties like:
 //  * `access=ro` with real values `access = GET | HEAD` (all numbers are
 //     passed to API as is).
@@ -356,13 +428,26 @@ validate:
 
 // Sets bucket properties
 func setBucketProps(c *cli.Context, bck cmn.Bck, props cmn.BucketPropsToUpdate) (err error) {
-	if _, err = api.SetBucketProps(defaultAPIParams, bck, props); err != nil {
+	query := make(url.Values)
+	if flagIsSet(c, forceFlag) {
+		query.Set(cmn.URLParamForce, "true")
+	}
+	if _, err = api.SetBucketProps(defaultAPIParams, bck, props, query); err != nil {
 		return
 	}
 	fmt.Fprintln(c.App.Writer, "Bucket props successfully updated")
 	return
 }
 
+// Shows the cost estimate of setting bucket properties
+func planBucketProps(c *cli.Context, bck cmn.Bck, props cmn.BucketPropsToUpdate) error {
+	plan, err := api.PlanBucketProps(defaultAPIParams, bck, props)
+	if err != nil {
+		return err
+	}
+	return templates.DisplayOutput(plan, c.App.Writer, templates.BucketPropsPlanTmpl, flagIsSet(c, jsonFlag))
+}
+
 // Resets bucket props
 func resetBucketProps(c *cli.Context, bck cmn.Bck) (err error) {
 	if _, err = api.ResetBucketProps(defaultAPIParams, bck); err != nil {
@@ -395,12 +480,59 @@ func showBucketProps(c *cli.Context) (err error) {
 	if _, p, err = validateBucket(c, bck, "", false); err != nil {
 		return
 	}
+	if flagIsSet(c, propsDiffFlag) {
+		return showBucketPropsDiff(c, p, parseStrFlag(c, propsDiffFlag))
+	}
+	if flagIsSet(c, propsYAMLFlag) {
+		out, err := yaml.Marshal(bckPropsKVs(p))
+		if err != nil {
+			return err
+		}
+		_, err = c.App.Writer.Write(out)
+		return err
+	}
 	if flagIsSet(c, jsonFlag) {
 		return templates.DisplayOutput(p, c.App.Writer, "", true)
 	}
 	return printBckHeadTable(c, p, section)
 }
 
+// compares bucket properties with those of another bucket or a file (when `other` is an existing file)
+func showBucketPropsDiff(c *cli.Context, props *cmn.BucketProps, other string) error {
+	var otherProps cmn.SimpleKVs
+	if finfo, err := os.Stat(other); other == fileStdIO || (err == nil && !finfo.IsDir()) {
+		if otherProps, err = readBckPropsFile(other); err != nil {
+			return err
+		}
+	} else {
+		bck, err := parseBckURI(c, other)
+		if err != nil {
+			return err
+		}
+		_, p, err := validateBucket(c, bck, "", false)
+		if err != nil {
+			return err
+		}
+		otherProps = make(cmn.SimpleKVs, 64)
+		for _, item := range bckPropsKVs(p) {
+			otherProps[item.Key.(string)] = fmt.Sprintf("%v", item.Value)
+		}
+	}
+	diffs := diffBckProps(props, otherProps)
+	if len(diffs) == 0 {
+		fmt.Fprintln(c.App.Writer, "No differences")
+		return nil
+	}
+	tw := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintf(tw, "PROPERTY\t%s\t%s\n", c.Args().First(), other)
+	}
+	for _, diff := range diffs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", diff.Name, diff.Value, diff.Other)
+	}
+	return tw.Flush()
+}
+
 func printBckHeadTable(c *cli.Context, props *cmn.BucketProps, section string) error {
 	// List instead of map to keep properties in the same order always.
 	// All names are one word ones - for easier parsing.
@@ -436,6 +568,20 @@ func configureNCopies(c *cli.Context, bck cmn.Bck, copies int) (err error) {
 	return
 }
 
+// Make N copies of a subset of bucket's objects and/or verify the result
+func configureNCopiesExt(c *cli.Context, bck cmn.Bck, msg *cmn.MNCMsg) (err error) {
+	var xactID string
+	if xactID, err = api.MakeNCopiesExt(defaultAPIParams, bck, msg); err != nil {
+		return
+	}
+	scope := "all objects"
+	if msg.Prefix != "" || msg.Template != "" {
+		scope = fmt.Sprintf("objects matching prefix %q, template %q", msg.Prefix, msg.Template)
+	}
+	fmt.Fprintf(c.App.Writer, "Making %d copies of %s in %q, %s\n", msg.Copies, scope, bck, xactProgressMsg(xactID))
+	return
+}
+
 // erasure code the entire bucket
 func ecEncode(c *cli.Context, bck cmn.Bck, data, parity int) (err error) {
 	var xactID string
@@ -7,19 +7,37 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
 )
 
+// asymmetric: round-trip latencies A => B and B => A differ by more than
+// the factor (and by more than the min difference, to ignore the noise)
+const (
+	pingAsymFactor  = 3
+	pingAsymMinDiff = time.Millisecond
+)
+
 var (
 	joinCmdsFlags = map[string][]cli.Flag{
 		subcmdJoinProxy:  {},
 		subcmdJoinTarget: {},
 	}
+	clusterCmdsFlags = map[string][]cli.Flag{
+		subcmdClusterPing: {
+			dataNetFlag,
+			noHeaderFlag,
+			jsonFlag,
+		},
+	}
 
 	cluSpecificCmds = []cli.Command{
 		{
@@ -42,6 +60,20 @@ var (
 				},
 			},
 		},
+		{
+			Name:  commandCluster,
+			Usage: "cluster-wide diagnostics",
+			Subcommands: []cli.Command{
+				{
+					Name: subcmdClusterPing,
+					Usage: "measure round-trip latency between each pair of nodes " +
+						"over the intra-cluster control (or data) network",
+					ArgsUsage: noArguments,
+					Flags:     clusterCmdsFlags[subcmdClusterPing],
+					Action:    clusterPingHandler,
+				},
+			},
+		},
 	}
 )
 
@@ -92,3 +124,82 @@ func joinNodeHandler(c *cli.Context) (err error) {
 	fmt.Fprintf(c.App.Writer, "%s with ID %q successfully joined the cluster\n", cmn.CapitalizeString(daemonType), daemonID)
 	return
 }
+
+func clusterPingHandler(c *cli.Context) (err error) {
+	matrix, err := api.GetPingMatrix(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(matrix, c.App.Writer, "", true)
+	}
+	var (
+		dataNet = flagIsSet(c, dataNetFlag)
+		ids     = make([]string, 0, len(matrix))
+		errs    = make([]string, 0, 4)
+		tw      = &tabwriter.Writer{}
+	)
+	for id := range matrix {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	latency := func(from, to string) (time.Duration, bool) {
+		ping, ok := matrix[from][to]
+		if !ok || ping.Err != "" {
+			return 0, false
+		}
+		if dataNet {
+			return ping.Data, ping.Data != 0
+		}
+		return ping.Control, true
+	}
+
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintf(tw, "FROM \\ TO\t%s\n", strings.Join(ids, "\t"))
+	}
+	for _, from := range ids {
+		row := make([]string, 0, len(ids)+1)
+		row = append(row, from)
+		for _, to := range ids {
+			if from == to {
+				row = append(row, "-")
+				continue
+			}
+			if ping, ok := matrix[from][to]; ok && ping.Err != "" {
+				row = append(row, "error")
+				errs = append(errs, fmt.Sprintf("%s => %s: %s", from, to, ping.Err))
+				continue
+			}
+			if l, ok := latency(from, to); ok {
+				row = append(row, l.Round(time.Microsecond).String())
+			} else {
+				row = append(row, "n/a")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	// asymmetric pairs
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			ab, ok1 := latency(a, b)
+			ba, ok2 := latency(b, a)
+			if !ok1 || !ok2 {
+				continue
+			}
+			lo, hi := cmn.MinDuration(ab, ba), cmn.MaxDuration(ab, ba)
+			if hi-lo > pingAsymMinDiff && hi > lo*pingAsymFactor {
+				fmt.Fprintf(c.App.Writer, "asymmetric: %s => %s: %v, %s => %s: %v\n", a, b, ab, b, a, ba)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(c.App.Writer, e)
+		}
+		return fmt.Errorf("%d ping(s) failed", len(errs))
+	}
+	return nil
+}
@@ -19,28 +19,36 @@ const (
 	commandAttach    = "attach"
 	commandAuth      = "auth"
 	commandCat       = "cat"
+	commandCluster   = "cluster"
 	commandConcat    = "concat"
 	commandCopy      = "cp"
 	commandCreate    = "create"
 	commandDetach    = "detach"
 	commandECEncode  = "ec-encode"
+	commandEditProps = "edit-props"
 	commandEvict     = "evict"
+	commandExport    = "export"
 	commandGenShards = "gen-shards"
 	commandGet       = "get"
 	commandJoin      = "join"
 	commandList      = "ls"
+	commandPin       = "pin"
 	commandPrefetch  = cmn.ActPrefetch
 	commandPromote   = "promote"
 	commandPut       = "put"
+	commandRemote    = "remote"
 	commandRemove    = "rm"
 	commandRename    = "rename"
 	commandSet       = "set"
 	commandSetCopies = "set-copies"
 	commandShow      = "show"
+	commandStorage   = "storage"
 	commandStart     = cmn.ActXactStart
 	commandStop      = cmn.ActXactStop
+	commandUnpin     = "unpin"
 	commandWait      = "wait"
 	commandSearch    = "search"
+	commandUndo      = "undo"
 	commandETL       = cmn.ETL
 
 	// Subcommands - preferably nouns
@@ -63,6 +71,7 @@ const (
 	subcmdPrimary   = "primary"
 	subcmdInit      = "init"
 	subcmdBuild     = "build"
+	subcmdValidate  = "validate"
 	subcmdList      = commandList
 	subcmdLogs      = "logs"
 	subcmdStop      = "stop"
@@ -81,6 +90,10 @@ const (
 	subcmdShowRemoteAIS = subcmdRemoteAIS
 	subcmdShowCluster   = subcmdCluster
 	subcmdShowMpath     = subcmdMountpath
+	subcmdShowAnalysis  = "analysis"
+	subcmdShowAlerts    = "alerts"
+	subcmdShowMemsys    = "memsys"
+	subcmdShowRepl      = "replication"
 
 	// Create subcommands
 	subcmdCreateBucket = subcmdBucket
@@ -99,6 +112,9 @@ const (
 	// Copy subcommands
 	subcmdCopyBucket = subcmdBucket
 
+	// Export subcommands
+	subcmdExportBucket = subcmdBucket
+
 	// Start subcommands
 	subcmdStartXaction  = subcmdXaction
 	subcmdStartDsort    = subcmdDsort
@@ -110,9 +126,11 @@ const (
 	subcmdStopDownload = subcmdDownload
 
 	// Set subcommand
-	subcmdSetConfig  = subcmdConfig
-	subcmdSetProps   = subcmdProps
-	subcmdSetPrimary = subcmdPrimary
+	subcmdSetConfig   = subcmdConfig
+	subcmdSetProps    = subcmdProps
+	subcmdSetPrimary  = subcmdPrimary
+	subcmdSetDownload = subcmdDownload
+	subcmdSetLogLevel = "loglevel"
 
 	// Attach/Detach subcommand
 	subcmdAttachRemoteAIS = subcmdRemoteAIS
@@ -120,10 +138,33 @@ const (
 	subcmdDetachRemoteAIS = subcmdRemoteAIS
 	subcmdDetachMountpath = subcmdMountpath
 
+	// Remote subcommands
+	subcmdRemoteAttach = commandAttach
+	subcmdRemoteDetach = commandDetach
+	subcmdRemoteShow   = commandShow
+	subcmdRemoteCheck  = "check"
+
+	// Storage subcommands
+	subcmdStorageMpath    = subcmdMountpath
+	subcmdStorageCapacity = "capacity"
+	subcmdStorageDisk     = subcmdDisk
+	subcmdStorageHealth   = "health"
+	subcmdStorageForecast = "forecast"
+	subcmdStorageAttach   = commandAttach
+	subcmdStorageDetach   = commandDetach
+	subcmdStorageEnable   = "enable"
+	subcmdStorageDisable  = "disable"
+
+	// Undo subcommands
+	subcmdUndoDelete = "delete"
+
 	// Join subcommands
 	subcmdJoinProxy  = subcmdProxy
 	subcmdJoinTarget = subcmdTarget
 
+	// Cluster subcommands
+	subcmdClusterPing = "ping"
+
 	// Wait subcommands
 	subcmdWaitXaction  = subcmdXaction
 	subcmdWaitDownload = subcmdDownload
@@ -157,6 +198,7 @@ const (
 
 	// Job IDs (download, dsort)
 	jobIDArgument                 = "JOB_ID"
+	undoTokenArgument             = "UNDO_TOKEN"
 	optionalJobIDArgument         = "[JOB_ID]"
 	optionalJobIDDaemonIDArgument = "[JOB_ID [DAEMON_ID]]"
 
@@ -182,8 +224,10 @@ const (
 	optionalTargetIDArgument = "[TARGET_ID]"
 	showConfigArgument       = "DAEMON_ID [CONFIG_SECTION]"
 	setConfigArgument        = optionalDaemonIDArgument + " " + keyValuePairsArgument
+	setLogLevelArgument      = optionalDaemonIDArgument + " [LEVEL] [MODULE=LEVEL...]"
 	attachRemoteAISArgument  = aliasURLPairArgument
 	detachRemoteAISArgument  = aliasArgument
+	remoteCheckArgument      = "[PROVIDER://PROBE_BUCKET...]"
 	attachMountpathArgument  = daemonMountpathPairArgument
 	detachMountpathArgument  = daemonMountpathPairArgument
 	joinNodeArgument         = "IP:PORT " + optionalDaemonIDArgument
@@ -224,6 +268,7 @@ var (
 	regexFlag       = cli.StringFlag{Name: "regex", Usage: "regex pattern for matching"}
 	jsonFlag        = cli.BoolFlag{Name: "json,j", Usage: "json input/output"}
 	noHeaderFlag    = cli.BoolFlag{Name: "no-headers,H", Usage: "display tables without headers"}
+	dataNetFlag     = cli.BoolFlag{Name: "data", Usage: "intra-cluster data network (default: control network)"}
 	progressBarFlag = cli.BoolFlag{Name: "progress", Usage: "display progress bar"}
 	resetFlag       = cli.BoolFlag{Name: "reset", Usage: "reset to original state"}
 	dryRunFlag      = cli.BoolFlag{Name: "dry-run", Usage: "preview the action without really doing it"}
@@ -234,6 +279,11 @@ var (
 	}
 	bucketPropsFlag = cli.StringFlag{Name: "bucket-props", Usage: "value represents custom properties of a bucket"}
 	forceFlag       = cli.BoolFlag{Name: "force,f", Usage: "force an action"}
+	toBucketFlag    = cli.StringFlag{Name: "to-bucket", Usage: "move object to another bucket (of any provider)"}
+	sourceFlag      = cli.StringFlag{
+		Name:  "source",
+		Usage: "populate the bucket from: Cloud bucket (that becomes its backend), eg. 'gs://bucket/prefix', or range template of links",
+	}
 
 	allFlag         = cli.BoolFlag{Name: "all", Usage: "list all properties"}
 	allXactionsFlag = cli.BoolTFlag{Name: "all", Usage: "show all xactions including finished"}
@@ -245,10 +295,22 @@ var (
 		Name:  "start-after",
 		Usage: "list objects alphabetically starting from the object after given provided key",
 	}
-	objLimitFlag = cli.IntFlag{Name: "limit", Usage: "limit object count", Value: 0}
-	pageSizeFlag = cli.IntFlag{Name: "page-size", Usage: "maximum number of entries by list objects call", Value: 1000}
-	templateFlag = cli.StringFlag{Name: "template", Usage: "template for matching object names"}
-	copiesFlag   = cli.IntFlag{Name: "copies", Usage: "number of object replicas", Value: 1, Required: true}
+	objLimitFlag  = cli.IntFlag{Name: "limit", Usage: "limit object count", Value: 0}
+	pageSizeFlag  = cli.IntFlag{Name: "page-size", Usage: "maximum number of entries by list objects call", Value: 1000}
+	templateFlag  = cli.StringFlag{Name: "template", Usage: "template for matching object names"}
+	copiesFlag    = cli.IntFlag{Name: "copies", Usage: "number of object replicas", Value: 1, Required: true}
+	propsYAMLFlag = cli.BoolFlag{
+		Name:  "yaml",
+		Usage: "show all properties as YAML (that can be applied with 'set props --file')",
+	}
+	propsDiffFlag = cli.StringFlag{
+		Name:  "diff",
+		Usage: "compare with properties of another bucket or the properties in a file (YAML or JSON)",
+	}
+	propsFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "path to file ('-' for stdin) with properties to set (YAML or JSON); only the changed properties get updated",
+	}
 	maxPagesFlag = cli.IntFlag{Name: "max-pages", Usage: "display up to this number pages of bucket objects"}
 	fastFlag     = cli.BoolTFlag{
 		Name:  "fast",
@@ -260,6 +322,11 @@ var (
 	dataSlicesFlag    = cli.IntFlag{Name: "data-slices,data,d", Usage: "number of data slices", Required: true}
 	paritySlicesFlag  = cli.IntFlag{Name: "parity-slices,parity,p", Usage: "number of parity slices", Required: true}
 	listBucketsFlag   = cli.StringFlag{Name: "buckets", Usage: "comma-separated list of bucket names, eg. 'b1,b2,b3'"}
+	depthFlag         = cli.IntFlag{Name: "depth", Usage: "number of leading directories to group objects by", Value: cmn.AnalysisDefaultDepth}
+	reportFlag        = cli.StringFlag{Name: "report", Usage: "name of the object to store the report (default: 'analysis/JOB_ID.json')"}
+	topFlag           = cli.IntFlag{Name: "top", Usage: "show only this number of the largest extensions and prefixes (0 - all)", Value: 10}
+	duplicatesFlag    = cli.BoolFlag{Name: "duplicates", Usage: "find objects with identical content and show the largest groups of duplicates"}
+	verifyCopiesFlag  = cli.BoolFlag{Name: "verify", Usage: "verify the resulting number of copies and report under-replicated objects"}
 
 	// Daeclu
 	countFlag = cli.IntFlag{Name: "count", Usage: "total number of generated reports", Value: countDefault}
@@ -269,7 +336,22 @@ var (
 		Name:  "description,desc",
 		Usage: "description of the job (useful when listing all downloads)",
 	}
-	timeoutFlag          = cli.StringFlag{Name: "timeout", Usage: "timeout for request to external resource, eg. '30m'"}
+	timeoutFlag  = cli.StringFlag{Name: "timeout", Usage: "timeout for request to external resource, eg. '30m'"}
+	deadlineFlag = cli.StringFlag{Name: "deadline", Usage: "abort the entire job if not finished within the given time, eg. '2h'"}
+	rollbackFlag = cli.BoolFlag{Name: "rollback", Usage: "remove objects downloaded by the job upon exceeding its deadline"}
+	resumeFlag   = cli.BoolFlag{Name: "resume", Usage: "keep partially downloaded objects and resume them with range requests"}
+	headerFlag   = cli.StringSliceFlag{
+		Name:  "header",
+		Usage: "HTTP header to add to each request to the source, eg. 'Authorization: Bearer TOKEN' (can be repeated)",
+	}
+	nameTemplateFlag = cli.StringFlag{
+		Name:  "name-template",
+		Usage: "(range download) Go template to generate object names from links, eg. '{{index .Groups 0}}/{{.Base}}'",
+	}
+	scheduleFlag = cli.StringFlag{
+		Name:  "schedule",
+		Usage: "re-run the (cloud bucket) download on schedule: interval, eg. '6h', or cron expression, eg. '0 2 * * *'",
+	}
 	limitConnectionsFlag = cli.IntFlag{
 		Name:  "limit-connections,conns",
 		Usage: "number of connections each target can make concurrently (each target can handle at most #mountpaths connections)",
@@ -278,12 +360,32 @@ var (
 		Name:  "limit-bytes-per-hour,limit-bph,bph",
 		Usage: "number of bytes (can end with suffix (k, MB, GiB, ...)) that all targets can maximally download in hour",
 	}
+	limitSharedFlag = cli.BoolFlag{
+		Name:  "limit-shared",
+		Usage: "allocate bytes per hour limit to targets proportionally to their pending downloads and throughput (instead of equal parts)",
+	}
 	objectsListFlag = cli.StringFlag{
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of strings with object names to download",
 	}
 	syncFlag             = cli.BoolFlag{Name: "sync", Usage: "sync bucket with cloud"}
 	progressIntervalFlag = cli.StringFlag{Name: "progress-interval", Value: downloader.DownloadProgressInterval.String(), Usage: "interval(in secs) at which progress will be monitored, e.g. '10s'"}
+	notifyURLFlag        = cli.StringFlag{Name: "notify-url", Usage: "URL to POST a JSON notification to upon finishing each object and the entire download job"}
+	downloadLogFlag      = cli.BoolFlag{Name: "log", Usage: "show the job's log (scheduling decisions, retries and errors across all targets)"}
+	downloadObjFlag      = cli.StringFlag{Name: "object", Usage: "show the state of a given object of the job (queue position, target and mountpath, attempts)"}
+	dlManifestFlag       = cli.StringFlag{
+		Name:  "manifest",
+		Usage: "path to JSON or YAML file ('-' for stdin) listing objects to download: links, (optional) names and checksums",
+	}
+	dlFromFileFlag = cli.StringFlag{
+		Name:  "from-file",
+		Usage: "path to text file ('-' for stdin) with URLs to download, one per line, each optionally followed by TAB and object name",
+	}
+	dlBatchSizeFlag = cli.IntFlag{
+		Name:  "batch-size",
+		Value: dlManifestBatchSize,
+		Usage: "maximum number of objects in a single download job (larger manifests and lists of URLs get split into multiple jobs)",
+	}
 
 	// dSort
 	dsortBucketFlag = cli.StringFlag{
@@ -304,6 +406,10 @@ var (
 	}
 	fileCountFlag = cli.IntFlag{Name: "fcount", Value: 5, Usage: "number of files inside single shard"}
 	specFileFlag  = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file with dSort specification"}
+	dsortPlanFlag = cli.BoolFlag{
+		Name:  "plan",
+		Usage: "only extract and sort the records, and report the expected output shards (no shards get created)",
+	}
 
 	// Object
 	listFlag      = cli.StringFlag{Name: "list", Usage: "comma separated list of object names, eg. 'o1,o2,o3'"}
@@ -312,6 +418,7 @@ var (
 	isCachedFlag  = cli.BoolFlag{Name: "is-cached", Usage: "check if an object is cached"}
 	cachedFlag    = cli.BoolFlag{Name: "cached", Usage: "list only cached objects"}
 	checksumFlag  = cli.BoolFlag{Name: "checksum", Usage: "validate checksum"}
+	etagFlag      = cli.StringFlag{Name: "etag", Usage: "get the object only if its ETag differs from the given one (conditional GET); print the object's ETag"}
 	recursiveFlag = cli.BoolFlag{Name: "recursive,r", Usage: "recursive operation"}
 	overwriteFlag = cli.BoolFlag{Name: "overwrite,o", Usage: "overwrite destination if exists"}
 	keepOrigFlag  = cli.BoolFlag{Name: "keep", Usage: "keep original file", Required: true}
@@ -336,8 +443,14 @@ var (
 	}
 	cpBckPrefixFlag = cli.StringFlag{Name: "prefix", Usage: "prefix added to every new object's name"}
 
+	// Export Bucket
+	exportPrefixFlag      = cli.StringFlag{Name: "prefix", Usage: "export only the objects with names starting with the prefix"}
+	exportShardSizeFlag   = cli.StringFlag{Name: "shard-size", Usage: "approximate size of a shard, e.g. 512MiB (default 1GiB)"}
+	exportShardPrefixFlag = cli.StringFlag{Name: "shard-prefix", Usage: "shard names: <shard-prefix>NNNNNN.tar (default \"shard-\")"}
+
 	// ETL
-	etlExtFlag = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
+	etlExtFlag       = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
+	etlSrcPrefixFlag = cli.StringFlag{Name: "src-prefix", Usage: "transform only the objects with names starting with the prefix"}
 
 	fromFileFlag = cli.StringFlag{Name: "from-file", Usage: "absolute path to the file with the code for ETL", Required: true}
 	depsFileFlag = cli.StringFlag{
@@ -348,6 +461,10 @@ var (
 		Name:  "runtime",
 		Usage: "runtime which should be used when running the provided code", Required: true,
 	}
+	checkImageFlag = cli.BoolFlag{
+		Name:  "check-image",
+		Usage: "additionally check that the container image can be pulled from its registry",
+	}
 	waitTimeoutFlag = cli.DurationFlag{
 		Name:  "wait-timeout",
 		Usage: "determines how long ais target should wait for pod to become ready",
@@ -360,6 +477,14 @@ var (
 		Name:  "no-rebalance",
 		Usage: "do not run rebalance after putting a node under maintenance",
 	}
+	undoWindowFlag = cli.DurationFlag{
+		Name:  "undo-window",
+		Usage: "keep removed objects in trash for the specified duration (e.g. 10m) to be able to undo the removal",
+	}
+	logLevelTTLFlag = cli.DurationFlag{
+		Name:  "ttl",
+		Usage: "revert to the previous log levels after the specified duration (e.g. 30m); 0 - keep",
+	}
 
 	longRunFlags = []cli.Flag{refreshFlag, countFlag}
 
@@ -29,13 +29,28 @@ var (
 		subcmdStartXaction: {},
 		subcmdStartDownload: {
 			timeoutFlag,
+			deadlineFlag,
+			rollbackFlag,
+			resumeFlag,
+			headerFlag,
+			nameTemplateFlag,
+			scheduleFlag,
 			descriptionFlag,
 			limitConnectionsFlag,
+			limitBytesPerHourFlag,
+			limitSharedFlag,
 			objectsListFlag,
 			progressIntervalFlag,
+			notifyURLFlag,
+			dlManifestFlag,
+			dlFromFileFlag,
+			dlBatchSizeFlag,
+			syncFlag,
+			dryRunFlag,
 		},
 		subcmdStartDsort: {
 			specFileFlag,
+			dsortPlanFlag,
 		},
 		commandPrefetch: append(
 			baseLstRngFlags,
@@ -211,13 +226,22 @@ func stopXactionHandler(c *cli.Context) (err error) {
 
 func startDownloadHandler(c *cli.Context) error {
 	var (
-		description      = parseStrFlag(c, descriptionFlag)
-		timeout          = parseStrFlag(c, timeoutFlag)
-		objectsListPath  = parseStrFlag(c, objectsListFlag)
-		progressInterval = parseStrFlag(c, progressIntervalFlag)
-		id               string
+		objectsListPath = parseStrFlag(c, objectsListFlag)
+		schedule        = parseStrFlag(c, scheduleFlag)
+		id              string
 	)
 
+	if flagIsSet(c, dlManifestFlag) || flagIsSet(c, dlFromFileFlag) {
+		return startManifestDownload(c)
+	}
+	if flagIsSet(c, dlBatchSizeFlag) {
+		return incorrectUsageMsg(c, "%q flag requires %q or %q", dlBatchSizeFlag.Name,
+			dlManifestFlag.Name, dlFromFileFlag.Name)
+	}
+	if flagIsSet(c, dryRunFlag) && !flagIsSet(c, syncFlag) {
+		return incorrectUsageMsg(c, "%q flag requires %q, %q, or %q", dryRunFlag.Name, syncFlag.Name,
+			dlManifestFlag.Name, dlFromFileFlag.Name)
+	}
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, "source", "destination")
 	}
@@ -244,31 +268,11 @@ func startDownloadHandler(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
-	limitBPH, err := parseByteFlagToInt(c, limitBytesPerHourFlag)
+	basePayload, err := parseDlBase(c, bucket)
 	if err != nil {
 		return err
 	}
 
-	if _, err := time.ParseDuration(progressInterval); err != nil {
-		return err
-	}
-
-	basePayload := downloader.DlBase{
-		Bck: cmn.Bck{
-			Name:     bucket,
-			Provider: cmn.ProviderAIS,
-			Ns:       cmn.NsGlobal,
-		},
-		Timeout:          timeout,
-		Description:      description,
-		ProgressInterval: progressInterval,
-		Limits: downloader.DlLimits{
-			Connections:  parseIntFlag(c, limitConnectionsFlag),
-			BytesPerHour: int(limitBPH),
-		},
-	}
-
 	// Heuristics to determine the download type.
 	var dlType downloader.DlType
 	if objectsListPath != "" {
@@ -315,6 +319,16 @@ func startDownloadHandler(c *cli.Context) error {
 		}
 	}
 
+	if schedule != "" && dlType != downloader.DlTypeCloud {
+		return incorrectUsageMsg(c, "%q flag is supported only when downloading cloud bucket (or its prefix)", scheduleFlag.Name)
+	}
+	if flagIsSet(c, syncFlag) && dlType != downloader.DlTypeCloud {
+		return incorrectUsageMsg(c, "%q flag is supported only when downloading cloud bucket (or its prefix)", syncFlag.Name)
+	}
+	if flagIsSet(c, nameTemplateFlag) && dlType != downloader.DlTypeRange {
+		return incorrectUsageMsg(c, "%q flag is supported only for range downloads", nameTemplateFlag.Name)
+	}
+
 	switch dlType {
 	case downloader.DlTypeSingle:
 		payload := downloader.DlSingleBody{
@@ -349,13 +363,19 @@ func startDownloadHandler(c *cli.Context) error {
 			DlBase:   basePayload,
 			Subdir:   pathSuffix, // in this case pathSuffix is a subdirectory in which the objects are to be saved
 			Template: source.link,
+
+			NameTemplate: parseStrFlag(c, nameTemplateFlag),
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	case downloader.DlTypeCloud:
 		payload := downloader.DlCloudBody{
-			DlBase: basePayload,
-			Sync:   flagIsSet(c, syncFlag),
-			Prefix: source.cloud.prefix,
+			DlBase:     basePayload,
+			Sync:       flagIsSet(c, syncFlag),
+			DryRunSync: flagIsSet(c, dryRunFlag),
+			Prefix:     source.cloud.prefix,
+		}
+		if schedule != "" {
+			return startScheduledDownload(c, payload)
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	default:
@@ -371,6 +391,56 @@ func startDownloadHandler(c *cli.Context) error {
 	return nil
 }
 
+// parseDlBase returns the download job's parameters common for all download types.
+func parseDlBase(c *cli.Context, bucket string) (base downloader.DlBase, err error) {
+	progressInterval := parseStrFlag(c, progressIntervalFlag)
+	limitBPH, err := parseByteFlagToInt(c, limitBytesPerHourFlag)
+	if err != nil {
+		return
+	}
+	if _, err = time.ParseDuration(progressInterval); err != nil {
+		return
+	}
+	headers, err := parseHeaders(c.StringSlice(headerFlag.Name))
+	if err != nil {
+		return
+	}
+	base = downloader.DlBase{
+		Bck: cmn.Bck{
+			Name:     bucket,
+			Provider: cmn.ProviderAIS,
+			Ns:       cmn.NsGlobal,
+		},
+		Timeout:          parseStrFlag(c, timeoutFlag),
+		Deadline:         parseStrFlag(c, deadlineFlag),
+		Rollback:         flagIsSet(c, rollbackFlag),
+		Resume:           flagIsSet(c, resumeFlag),
+		Headers:          headers,
+		Schedule:         parseStrFlag(c, scheduleFlag),
+		Description:      parseStrFlag(c, descriptionFlag),
+		ProgressInterval: progressInterval,
+		NotifyURL:        parseStrFlag(c, notifyURLFlag),
+		Limits: downloader.DlLimits{
+			Connections:  parseIntFlag(c, limitConnectionsFlag),
+			BytesPerHour: int(limitBPH),
+			Shared:       flagIsSet(c, limitSharedFlag),
+		},
+	}
+	return
+}
+
+func startScheduledDownload(c *cli.Context, payload downloader.DlCloudBody) error {
+	resp, err := api.DownloadSchedule(defaultAPIParams, payload)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, resp.ID)
+	fmt.Fprintf(c.App.Writer, "Scheduled %q: the download will be re-run as %s.\n", payload.Schedule, resp.ScheduleID)
+	fmt.Fprintf(c.App.Writer, "Run `ais show download %s --progress` to monitor the progress of downloading, "+
+		"`ais rm download %s` to cancel the schedule.\n", resp.ID, resp.ScheduleID)
+	return nil
+}
+
 func stopDownloadHandler(c *cli.Context) (err error) {
 	id := c.Args().First()
 
@@ -440,6 +510,16 @@ func startDsortHandler(c *cli.Context) (err error) {
 		}
 	}
 
+	if flagIsSet(c, dsortPlanFlag) {
+		if id, err = api.PlanDSort(defaultAPIParams, rs); err != nil {
+			return
+		}
+		fmt.Fprintln(c.App.Writer, id)
+		fmt.Fprintf(c.App.Writer, "use '%s %s %s %s' to see the plan once the job finishes\n",
+			cliName, commandShow, subcmdShowDsort, id)
+		return
+	}
+
 	if id, err = api.StartDSort(defaultAPIParams, rs); err != nil {
 		return
 	}
@@ -6,7 +6,12 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/urfave/cli"
 )
 
@@ -15,6 +20,10 @@ var (
 		subcmdCreateBucket: {
 			ignoreErrorFlag,
 			bucketPropsFlag,
+			sourceFlag,
+			syncFlag,
+			scheduleFlag,
+			descriptionFlag,
 		},
 	}
 
@@ -52,6 +61,12 @@ func createBucketHandler(c *cli.Context) (err error) {
 	if err := validateLocalBuckets(buckets, "creating"); err != nil {
 		return err
 	}
+	if flagIsSet(c, sourceFlag) {
+		if len(buckets) != 1 {
+			return errors.New("populating from the source requires a single bucket")
+		}
+		return createBucketFrom(c, buckets[0], props)
+	}
 	for _, bck := range buckets {
 		if props != nil {
 			err = createBucket(c, bck, *props)
@@ -64,3 +79,34 @@ func createBucketHandler(c *cli.Context) (err error) {
 	}
 	return nil
 }
+
+// createBucketFrom creates the bucket, populated from (and, optionally, synced
+// with) the source - see downloader.DlBucketSource
+func createBucketFrom(c *cli.Context, bck cmn.Bck, props *cmn.BucketPropsToUpdate) error {
+	source, err := parseSource(parseStrFlag(c, sourceFlag))
+	if err != nil {
+		return err
+	}
+	src := downloader.DlBucketSource{
+		Prefix:      source.cloud.prefix,
+		Sync:        flagIsSet(c, syncFlag),
+		Schedule:    parseStrFlag(c, scheduleFlag),
+		Description: parseStrFlag(c, descriptionFlag),
+		Props:       props,
+	}
+	if source.cloud.bck.IsEmpty() {
+		src.Template = source.link
+	} else {
+		src.Cloud = source.cloud.bck
+	}
+	resp, err := api.CreateBucketFrom(defaultAPIParams, bck, src)
+	if err != nil {
+		return fmt.Errorf("create bucket %q failed: %v", bck, err)
+	}
+	fmt.Fprintf(c.App.Writer, "%q bucket created, populating (download job %s)\n", bck, resp.ID)
+	if resp.ScheduleID != "" {
+		fmt.Fprintf(c.App.Writer, "Syncing on schedule %q (%s)\n", src.Schedule, resp.ScheduleID)
+	}
+	fmt.Fprintf(c.App.Writer, "Run `ais show download %s` to monitor the progress.\n", resp.ID)
+	return nil
+}
@@ -6,19 +6,29 @@
 package commands
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/downloader"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
 	"github.com/vbauerster/mpb/v4/decor"
+	"gopkg.in/yaml.v2"
 )
 
 type (
@@ -29,6 +39,7 @@ type (
 		downloadingErrors map[string]string
 		finished          bool
 		aborted           bool
+		deadlineExceeded  bool
 	}
 
 	fileDownloadingState struct {
@@ -53,8 +64,9 @@ type (
 		scheduledFiles int
 		errFiles       int
 
-		aborted       bool
-		allDispatched bool
+		aborted          bool
+		deadlineExceeded bool
+		allDispatched    bool
 	}
 )
 
@@ -66,11 +78,14 @@ const (
 )
 
 func (d downloadingResult) String() string {
+	if d.deadlineExceeded {
+		return "Download was aborted: deadline exceeded."
+	}
 	if d.aborted {
 		return "Download was aborted."
 	}
 
-	if d.finished {
+	if d.finished && d.errFiles == 0 {
 		return "All files successfully downloaded."
 	}
 
@@ -110,7 +125,7 @@ func (b *downloaderPB) run() (downloadingResult, error) {
 		return b.result(), nil
 	}
 
-	// All files = finished ones + ones that had downloading errors
+	// refresh until all files are done (finished or failed) or the job is aborted
 	for !b.jobFinished() {
 		time.Sleep(b.refreshTime)
 
@@ -119,12 +134,8 @@ func (b *downloaderPB) run() (downloadingResult, error) {
 			b.cleanBars()
 			return downloadingResult{}, err
 		}
-		if resp.Aborted {
-			b.aborted = true
-			break
-		}
-
 		b.updateBars(resp)
+		b.updateJob(resp)
 	}
 
 	b.cleanBars()
@@ -137,41 +148,29 @@ func (b *downloaderPB) start() (bool, error) {
 		return false, err
 	}
 
-	b.finishedFiles = resp.FinishedCnt
-	b.totalFiles = resp.Total
-	b.scheduledFiles = resp.ScheduledCnt
-	b.errFiles = resp.ErrorCnt
-
-	b.allDispatched = resp.AllDispatched
-	b.aborted = resp.Aborted
-
+	b.updateJob(resp)
 	if b.jobFinished() {
 		return true, err
 	}
 
-	barText := totalBarText
-	if b.totalFilesCnt() != b.totalFiles {
-		barText += " (total estimated)"
-	}
-
-	if b.totalFilesCnt() > 1 {
-		b.totalBar = b.p.AddBar(
-			int64(b.totalFilesCnt()),
-			mpb.BarRemoveOnComplete(),
-			mpb.PrependDecorators(
-				decor.Name(barText, decor.WC{W: len(totalBarText) + 2, C: decor.DSyncWidthR}),
-				decor.CountersNoUnit("%d/%d", decor.WCSyncWidth),
-			),
-			mpb.AppendDecorators(decor.Percentage(decor.WCSyncWidth)),
-		)
-		b.totalBar.IncrBy(b.finishedFiles + b.errFiles)
-	}
-
 	b.updateBars(resp)
 
 	return false, nil
 }
 
+// updateJob records the job-level state (counters, dispatching, abort) of the
+// status response; must be called after updateBars
+func (b *downloaderPB) updateJob(resp downloader.DlStatusResp) {
+	b.finishedFiles = resp.FinishedCnt
+	b.totalFiles = resp.Total
+	b.scheduledFiles = resp.ScheduledCnt
+	b.errFiles = resp.ErrorCnt
+
+	b.allDispatched = resp.AllDispatched
+	b.aborted = resp.Aborted
+	b.deadlineExceeded = resp.DeadlineExceeded
+}
+
 func (b *downloaderPB) updateBars(downloadStatus downloader.DlStatusResp) {
 	fileStates := downloadStatus.CurrentTasks
 
@@ -187,10 +186,26 @@ func (b *downloaderPB) updateBars(downloadStatus downloader.DlStatusResp) {
 		b.updateFileBar(newState, oldState)
 	}
 	if downloadStatus.TotalCnt() > 1 {
-		b.updateTotalBar(downloadStatus.FinishedCnt+downloadStatus.ErrorCnt, downloadStatus.TotalCnt())
-		b.finishedFiles = downloadStatus.FinishedCnt
-		b.errFiles = downloadStatus.ErrorCnt
+		b.updateTotalBar(downloadStatus)
+	}
+}
+
+// the total bar gets added once the job has more than a single file to download
+// (which may happen only after some of the files are scheduled)
+func (b *downloaderPB) addTotalBar(resp downloader.DlStatusResp) {
+	barText := totalBarText
+	if resp.Total <= 0 {
+		barText += " (total estimated)"
 	}
+	b.totalBar = b.p.AddBar(
+		int64(resp.TotalCnt()),
+		mpb.BarRemoveOnComplete(),
+		mpb.PrependDecorators(
+			decor.Name(barText, decor.WC{W: len(totalBarText) + 2, C: decor.DSyncWidthR}),
+			decor.CountersNoUnit("%d/%d", decor.WCSyncWidth),
+		),
+		mpb.AppendDecorators(decor.Percentage(decor.WCSyncWidth)),
+	)
 }
 
 func (b *downloaderPB) updateFinishedFiles(fileStates []downloader.TaskDlInfo) {
@@ -263,13 +278,18 @@ func (b *downloaderPB) updateFileBar(newState downloader.TaskDlInfo, state *file
 	state.lastTime = time.Now()
 }
 
-func (b *downloaderPB) updateTotalBar(newFinished, total int) {
-	progress := newFinished - (b.finishedFiles + b.errFiles)
+func (b *downloaderPB) updateTotalBar(resp downloader.DlStatusResp) {
+	if b.totalBar == nil {
+		b.addTotalBar(resp)
+		b.totalBar.IncrBy(resp.DoneCnt())
+		return
+	}
+	progress := resp.DoneCnt() - (b.finishedFiles + b.errFiles)
 	if progress > 0 {
 		b.totalBar.IncrBy(progress)
 	}
 
-	b.totalBar.SetTotal(int64(total), false)
+	b.totalBar.SetTotal(int64(resp.TotalCnt()), false)
 }
 
 func (b *downloaderPB) cleanBars() {
@@ -278,19 +298,32 @@ func (b *downloaderPB) cleanBars() {
 		state.bar.SetTotal(state.total, true)
 	}
 
-	b.totalBar.SetTotal(int64(b.totalFiles), true)
+	if b.totalBar != nil {
+		b.totalBar.SetTotal(int64(b.totalFilesCnt()), true)
+	}
 
 	b.p.Wait()
 }
 
 func (b *downloaderPB) result() downloadingResult {
-	return downloadingResult{
-		totalFiles:    b.totalFiles,
-		finishedFiles: b.finishedFiles,
-		errFiles:      b.errFiles,
-		finished:      b.jobFinished(),
-		aborted:       b.aborted,
+	res := downloadingResult{
+		totalFiles:       b.totalFiles,
+		finishedFiles:    b.finishedFiles,
+		errFiles:         b.errFiles,
+		finished:         b.jobFinished(),
+		aborted:          b.aborted,
+		deadlineExceeded: b.deadlineExceeded,
+	}
+	if b.errFiles > 0 {
+		// (best effort) the names of the files that failed to download
+		if resp, err := api.DownloadStatus(b.params, b.id, false); err == nil {
+			res.downloadingErrors = make(map[string]string, len(resp.Errs))
+			for _, e := range resp.Errs {
+				res.downloadingErrors[e.Name] = e.Err
+			}
+		}
 	}
+	return res
 }
 
 func (b *downloaderPB) jobFinished() bool {
@@ -316,6 +349,23 @@ func downloadJobsList(c *cli.Context, regex string) error {
 }
 
 func downloadJobStatus(c *cli.Context, id string) error {
+	if flagIsSet(c, downloadLogFlag) {
+		logs, err := api.DownloadLogs(defaultAPIParams, id)
+		if err != nil {
+			return err
+		}
+		printDownloadLogs(c.App.Writer, logs)
+		return nil
+	}
+	if objName := parseStrFlag(c, downloadObjFlag); objName != "" {
+		obj, err := api.DownloadObjectStatus(defaultAPIParams, id, objName)
+		if err != nil {
+			return err
+		}
+		printDownloadObjStatus(c.App.Writer, obj)
+		return nil
+	}
+
 	// with progress bar
 	if flagIsSet(c, progressBarFlag) {
 		refreshRate := calcRefreshRate(c)
@@ -339,20 +389,124 @@ func downloadJobStatus(c *cli.Context, id string) error {
 	return nil
 }
 
+func printDownloadSchedule(w io.Writer, d *downloader.DlJobInfo) {
+	if d.ScheduleID == "" {
+		return
+	}
+	if cmn.IsTimeZero(d.NextRun) {
+		fmt.Fprintf(w, "Schedule %s: canceled\n", d.ScheduleID)
+	} else {
+		fmt.Fprintf(w, "Schedule %s: next run at %s\n", d.ScheduleID, d.NextRun.Format(time.RFC3339))
+	}
+}
+
+func printDownloadLogs(w io.Writer, logs []downloader.DlLogEntry) {
+	if len(logs) == 0 {
+		fmt.Fprintln(w, "No log entries")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tTARGET\tMESSAGE")
+	for _, entry := range logs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.Time.Format("01-02 15:04:05.000"), entry.Target, entry.Msg)
+	}
+	tw.Flush()
+}
+
+func printDownloadObjStatus(w io.Writer, obj *downloader.DlObjStatus) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Object:\t%s\n", obj.Name)
+	fmt.Fprintf(tw, "State:\t%s\n", obj.State)
+	fmt.Fprintf(tw, "Target:\t%s\n", obj.Target)
+	if obj.Mpath != "" {
+		fmt.Fprintf(tw, "Mountpath:\t%s\n", obj.Mpath)
+	}
+	switch obj.State {
+	case downloader.DlObjQueued:
+		fmt.Fprintf(tw, "Position:\t%d/%d\n", obj.Position, obj.QueueLen)
+	case downloader.DlObjRunning, downloader.DlObjFinished, downloader.DlObjFailed:
+		if obj.Attempts > 0 {
+			fmt.Fprintf(tw, "Attempts:\t%d\n", obj.Attempts)
+		}
+		if obj.Total > 0 {
+			fmt.Fprintf(tw, "Downloaded:\t%s/%s\n", cmn.B2S(obj.Downloaded, 2), cmn.B2S(obj.Total, 2))
+		} else if obj.Downloaded > 0 {
+			fmt.Fprintf(tw, "Downloaded:\t%s\n", cmn.B2S(obj.Downloaded, 2))
+		}
+	}
+	if obj.Err != "" {
+		fmt.Fprintf(tw, "Error:\t%s\n", obj.Err)
+	}
+	tw.Flush()
+}
+
+// objects deleted by sync and, in case of dry run, objects to be downloaded
+func printDownloadSync(w io.Writer, d *downloader.DlStatusResp, verbose bool) {
+	if d.DryRun {
+		fmt.Fprintf(w, "Dry run: %d file%s to download, %d file%s to delete\n",
+			d.ToDownloadCnt, cmn.NounEnding(d.ToDownloadCnt), d.DeletedCnt, cmn.NounEnding(d.DeletedCnt))
+	} else if d.DeletedCnt > 0 {
+		fmt.Fprintf(w, "Deleted: %d file%s no longer present in the source\n", d.DeletedCnt, cmn.NounEnding(d.DeletedCnt))
+	}
+	if !verbose {
+		return
+	}
+	deletedHdr := "Deleted:"
+	if d.DryRun {
+		deletedHdr = "To delete:"
+	}
+	for _, list := range []struct {
+		hdr   string
+		names []string
+		cnt   int
+	}{
+		{"To download:", d.ToDownload, d.ToDownloadCnt},
+		{deletedHdr, d.Deleted, d.DeletedCnt},
+	} {
+		if len(list.names) == 0 {
+			continue
+		}
+		sort.Strings(list.names)
+		fmt.Fprintln(w, list.hdr)
+		for _, name := range list.names {
+			fmt.Fprintf(w, "\t%s\n", name)
+		}
+		if list.cnt > len(list.names) {
+			fmt.Fprintf(w, "\t... and %d more\n", list.cnt-len(list.names))
+		}
+	}
+}
+
 func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
+	defer printDownloadSchedule(w, &d.DlJobInfo)
+	if d.DeadlineExceeded {
+		fmt.Fprintln(w, "Download aborted: deadline exceeded")
+		return
+	}
 	if d.Aborted {
 		fmt.Fprintln(w, "Download aborted")
 		return
 	}
 
 	if d.JobFinished() {
-		if d.SkippedCnt > 0 {
+		// (objects deleted by sync and, in case of dry run, the ones to be downloaded
+		// are counted as finished as well - see printDownloadSync)
+		downloaded := d.FinishedCnt - d.DeletedCnt - d.ToDownloadCnt
+		switch {
+		case d.DryRun:
+			fmt.Fprintf(w, "Dry run done: nothing downloaded or deleted, %d error%s\n",
+				d.ErrorCnt, cmn.NounEnding(d.ErrorCnt))
+		case d.SkippedCnt > 0:
 			fmt.Fprintf(w, "Done: %d file%s downloaded (skipped: %d), %d error%s\n",
-				d.FinishedCnt, cmn.NounEnding(d.FinishedCnt), d.SkippedCnt, d.ErrorCnt, cmn.NounEnding(d.ErrorCnt))
-		} else {
+				downloaded, cmn.NounEnding(downloaded), d.SkippedCnt, d.ErrorCnt, cmn.NounEnding(d.ErrorCnt))
+		default:
 			fmt.Fprintf(w, "Done: %d file%s downloaded, %d error%s\n",
-				d.FinishedCnt, cmn.NounEnding(d.FinishedCnt), d.ErrorCnt, cmn.NounEnding(d.ErrorCnt))
+				downloaded, cmn.NounEnding(downloaded), d.ErrorCnt, cmn.NounEnding(d.ErrorCnt))
+		}
+		if d.ResumedSize > 0 {
+			fmt.Fprintf(w, "Resumed: %s not downloaded again\n", cmn.B2S(d.ResumedSize, 2))
 		}
+		printDownloadSync(w, &d, verbose)
 
 		if verbose && len(d.Errs) > 0 {
 			fmt.Fprintln(w, "Errors:")
@@ -380,6 +534,9 @@ func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
 		}
 		fmt.Fprintln(w, progressMsg)
 	}
+	if d.ResumedSize > 0 {
+		fmt.Fprintf(w, "Resumed: %s not downloaded again\n", cmn.B2S(d.ResumedSize, 2))
+	}
 	if verbose {
 		if len(d.CurrentTasks) > 0 {
 			sort.Slice(d.CurrentTasks, func(i, j int) bool {
@@ -403,7 +560,305 @@ func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
 				fmt.Fprintf(w, "\t%s: %s\n", e.Name, e.Err)
 			}
 		}
+		if len(d.Mpaths) > 0 {
+			printDownloadMpaths(w, d.Mpaths)
+		}
 	} else if d.ErrorCnt > 0 {
 		fmt.Fprintf(w, "Errors (%d) occurred during the download. To see detailed info run `ais show download %s -v`\n", d.ErrorCnt, d.ID)
 	}
 }
+
+func printDownloadMpaths(w io.Writer, mpaths []downloader.DlMpathInfo) {
+	sort.Slice(mpaths, func(i, j int) bool {
+		if mpaths[i].Target != mpaths[j].Target {
+			return mpaths[i].Target < mpaths[j].Target
+		}
+		return mpaths[i].Mpath < mpaths[j].Mpath
+	})
+	fmt.Fprintln(w, "Mountpaths:")
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tMOUNTPATH\tMEDIA\tUTIL\tCONCURRENCY\tRUNNING")
+	for _, mp := range mpaths {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d%%\t%d\t%d\n", mp.Target, mp.Mpath, mp.Media, mp.Util, mp.Concurrency, mp.Running)
+	}
+	tw.Flush()
+}
+
+////////////////////////////////////////////////
+// multi-object download from a manifest file //
+////////////////////////////////////////////////
+
+const (
+	dlManifestBatchSize = 10000
+	dlGroupPrefix       = "dlgroup-" // group ID of the jobs started from a single manifest (list of URLs)
+)
+
+type (
+	// dlManifest lists objects to download (`ais start download --manifest`);
+	// the file contains either this structure or the plain list of objects.
+	dlManifest struct {
+		Objects []dlManifestObj `json:"objects" yaml:"objects"`
+	}
+	dlManifestObj struct {
+		Link  string                 `json:"link" yaml:"link"`
+		Name  string                 `json:"name,omitempty" yaml:"name,omitempty"` // default: basename of the link
+		Cksum *downloader.DlObjCksum `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	}
+)
+
+// startManifestDownload starts downloading objects listed in either a manifest
+// (`--manifest`) or a plain list of URLs (`--from-file`), in batches of up to
+// `--batch-size` objects per job; all the jobs share the same group ID.
+func startManifestDownload(c *cli.Context) error {
+	var (
+		srcFlag   = dlManifestFlag
+		srcKind   = "manifest"
+		load      = loadDlManifest
+		batchSize = parseIntFlag(c, dlBatchSizeFlag)
+	)
+	if flagIsSet(c, dlFromFileFlag) {
+		if flagIsSet(c, dlManifestFlag) {
+			return incorrectUsageMsg(c, "%q flag cannot be used together with %q", dlFromFileFlag.Name, dlManifestFlag.Name)
+		}
+		srcFlag, srcKind, load = dlFromFileFlag, "urls", loadDlURLs
+	}
+	srcPath := parseStrFlag(c, srcFlag)
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "destination")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "with %q, expecting a single argument - destination, got %d", srcFlag.Name, c.NArg())
+	}
+	for _, flag := range []cli.Flag{objectsListFlag, scheduleFlag, nameTemplateFlag, syncFlag} {
+		if flagIsSet(c, flag) {
+			return incorrectUsageMsg(c, "%q flag cannot be used together with %q", flag.GetName(), srcFlag.Name)
+		}
+	}
+	if batchSize <= 0 {
+		return incorrectUsageMsg(c, "%q must be positive, got %d", dlBatchSizeFlag.Name, batchSize)
+	}
+	bucket, subdir, err := parseDest(c.Args().First())
+	if err != nil {
+		return err
+	}
+	objs, err := load(c.App.Writer, srcPath, subdir)
+	if err != nil {
+		return err
+	}
+	base, err := parseDlBase(c, bucket)
+	if err != nil {
+		return err
+	}
+	if base.Description == "" {
+		base.Description = srcKind + " " + filepath.Base(srcPath)
+	}
+
+	batches := dlManifestBatches(objs, batchSize)
+	if flagIsSet(c, dryRunFlag) {
+		printDlManifestPlan(c.App.Writer, base, objs, batches)
+		return nil
+	}
+	groupID := dlGroupPrefix + cmn.RandString(8)
+	for i, batch := range batches {
+		payload := downloader.DlMultiBody{DlBase: base, ObjectsPayload: dlManifestPayload(batch)}
+		payload.Description = fmt.Sprintf("%s [%s %d/%d]", base.Description, groupID, i+1, len(batches))
+		id, err := api.DownloadWithParam(defaultAPIParams, downloader.DlTypeMulti, payload)
+		if err != nil {
+			if i > 0 {
+				return fmt.Errorf("failed to start download job %d/%d (the first %d jobs of group %s have been started): %v",
+					i+1, len(batches), i, groupID, err)
+			}
+			return err
+		}
+		fmt.Fprintln(c.App.Writer, id)
+	}
+	fmt.Fprintf(c.App.Writer, "Job group: %s (%d job(s)). Run `ais show download --regex=%q` to see the jobs.\n",
+		groupID, len(batches), groupID)
+	return nil
+}
+
+func loadDlManifest(_ io.Writer, manifestPath, subdir string) ([]dlManifestObj, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if manifestPath == fileStdIO {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(manifestPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	objs, err := parseDlManifest(b)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %v", manifestPath, err)
+	}
+	if err := validateDlManifest(objs, subdir); err != nil {
+		return nil, fmt.Errorf("%q: %v", manifestPath, err)
+	}
+	return objs, nil
+}
+
+func parseDlManifest(b []byte) ([]dlManifestObj, error) {
+	var (
+		manifest dlManifest
+		errs     = make([]string, 0, 2)
+	)
+	for _, unmarshal := range []func([]byte, interface{}) error{jsoniter.Unmarshal, yaml.Unmarshal} {
+		errStruct := unmarshal(b, &manifest)
+		if errStruct == nil {
+			return manifest.Objects, nil
+		}
+		if err := unmarshal(b, &manifest.Objects); err == nil {
+			return manifest.Objects, nil
+		}
+		errs = append(errs, errStruct.Error())
+	}
+	return nil, fmt.Errorf("failed to parse the manifest as JSON or YAML, errs: (%s)", strings.Join(errs, ", "))
+}
+
+// validateDlManifest fills in (and prefixes with subdir) object names, and
+// validates the links, names and checksums.
+func validateDlManifest(objs []dlManifestObj, subdir string) error {
+	if len(objs) == 0 {
+		return errors.New("no objects to download")
+	}
+	names := make(cmn.StringSet, len(objs))
+	for i := range objs {
+		obj := &objs[i]
+		if obj.Link == "" {
+			return fmt.Errorf("object #%d: missing 'link'", i)
+		}
+		u, err := url.Parse(obj.Link)
+		if err != nil {
+			return fmt.Errorf("object #%d: invalid link %q: %v", i, obj.Link, err)
+		}
+		if obj.Name == "" {
+			obj.Name = path.Base(u.Path)
+			if obj.Name == "." || obj.Name == "/" {
+				return fmt.Errorf("object #%d: cannot extract object name from link %q", i, obj.Link)
+			}
+		}
+		if subdir != "" {
+			obj.Name = path.Join(subdir, obj.Name)
+		}
+		if names.Contains(obj.Name) {
+			return fmt.Errorf("object #%d: duplicate name %q", i, obj.Name)
+		}
+		names.Add(obj.Name)
+		if ck := obj.Cksum; ck != nil {
+			if err := cmn.ValidateCksumType(ck.Type); err != nil {
+				return fmt.Errorf("object #%d (%q): %v", i, obj.Name, err)
+			}
+			if ck.Type == "" || ck.Type == cmn.ChecksumNone || ck.Value == "" {
+				return fmt.Errorf("object #%d (%q): checksum requires both type and value", i, obj.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// loadDlURLs reads a list of URLs, one per line, each optionally followed by
+// TAB and the object name; empty lines and lines starting with '#' are skipped,
+// and repeated entries are downloaded once.
+func loadDlURLs(w io.Writer, urlsPath, subdir string) ([]dlManifestObj, error) {
+	var r io.Reader = os.Stdin
+	if urlsPath != fileStdIO {
+		fh, err := os.Open(urlsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer fh.Close()
+		r = fh
+	}
+	objs, dups, err := parseDlURLs(r)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %v", urlsPath, err)
+	}
+	if dups > 0 {
+		fmt.Fprintf(w, "Skipping %d duplicate entries\n", dups)
+	}
+	if err := validateDlManifest(objs, subdir); err != nil {
+		return nil, fmt.Errorf("%q: %v", urlsPath, err)
+	}
+	return objs, nil
+}
+
+func parseDlURLs(r io.Reader) (objs []dlManifestObj, dups int, err error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		seen    = make(map[dlManifestObj]struct{})
+		lineNum int
+	)
+	scanner.Buffer(make([]byte, 0, 64*cmn.KiB), cmn.MiB)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) > 2 {
+			return nil, 0, fmt.Errorf("line %d: expecting URL and (optional) TAB-separated object name, got %d fields",
+				lineNum, len(fields))
+		}
+		source, err := parseSource(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+		if source.link == "" {
+			return nil, 0, fmt.Errorf("line %d: cannot download %q: not a link", lineNum, fields[0])
+		}
+		obj := dlManifestObj{Link: source.link}
+		if len(fields) == 2 {
+			obj.Name = strings.TrimSpace(fields[1])
+		}
+		if _, ok := seen[obj]; ok {
+			dups++
+			continue
+		}
+		seen[obj] = struct{}{}
+		objs = append(objs, obj)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return objs, dups, nil
+}
+
+func dlManifestBatches(objs []dlManifestObj, batchSize int) [][]dlManifestObj {
+	batches := make([][]dlManifestObj, 0, (len(objs)+batchSize-1)/batchSize)
+	for len(objs) > batchSize {
+		batches = append(batches, objs[:batchSize])
+		objs = objs[batchSize:]
+	}
+	return append(batches, objs)
+}
+
+// object name => link or, when the checksum is provided, downloader.DlMultiObj
+func dlManifestPayload(objs []dlManifestObj) map[string]interface{} {
+	payload := make(map[string]interface{}, len(objs))
+	for _, obj := range objs {
+		if obj.Cksum == nil {
+			payload[obj.Name] = obj.Link
+		} else {
+			payload[obj.Name] = downloader.DlMultiObj{Link: obj.Link, Cksum: obj.Cksum}
+		}
+	}
+	return payload
+}
+
+func printDlManifestPlan(w io.Writer, base downloader.DlBase, objs []dlManifestObj, batches [][]dlManifestObj) {
+	var cksums int
+	for _, obj := range objs {
+		if obj.Cksum != nil {
+			cksums++
+		}
+	}
+	fmt.Fprintf(w, "%d objects (%d with checksums) -> %s in %d download job(s):\n",
+		len(objs), cksums, base.Bck, len(batches))
+	for i, batch := range batches {
+		fmt.Fprintf(w, "  %d: %d objects: %s ... %s\n", i+1, len(batch), batch[0].Name, batch[len(batch)-1].Name)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
@@ -522,6 +523,12 @@ func printCondensedStats(w io.Writer, id string) error {
 			"DSort job has successfully finished in %v:\n  Longest extraction:\t%v\n  Longest sorting:\t%v\n  Longest creation:\t%v\n",
 			elapsedTime, extractionTime, sortingTime, creationTime,
 		)
+		for _, tm := range resp {
+			if tm.Plan != nil {
+				printPlan(w, tm.Plan)
+				break
+			}
+		}
 		return nil
 	}
 
@@ -536,6 +543,30 @@ func printCondensedStats(w io.Writer, id string) error {
 	return nil
 }
 
+func printPlan(w io.Writer, plan *dsort.Plan) {
+	_, _ = fmt.Fprintf(
+		w,
+		"Plan (no shards were created):\n  Records:\t%d (%s)\n  Output shards:\t%d (min %s, avg %s, max %s)\n"+
+			"  Sorting memory:\t%s\n  Record skew:\t%.2f\n  Shard skew:\t%.2f\n",
+		plan.RecordCnt, cmn.B2S(plan.RecordsSize, 2), plan.ShardCnt, cmn.B2S(plan.MinShardSize, 2),
+		cmn.B2S(plan.AvgShardSize, 2), cmn.B2S(plan.MaxShardSize, 2), cmn.B2S(plan.SortMemory, 2),
+		plan.RecordSkew, plan.ShardSkew,
+	)
+	ids := make([]string, 0, len(plan.Targets))
+	for id := range plan.Targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  TARGET\tRECORDS\tRECORDS SIZE\tSHARDS\tSHARDS SIZE (DISK)")
+	for _, id := range ids {
+		tplan := plan.Targets[id]
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%s\t%d\t%s\n", id, tplan.RecordCnt, cmn.B2S(tplan.RecordsSize, 2),
+			tplan.ShardCnt, cmn.B2S(tplan.ShardsSize, 2))
+	}
+	_ = tw.Flush()
+}
+
 func dsortJobsList(c *cli.Context, regex string) error {
 	list, err := api.ListDSort(defaultAPIParams, regex)
 	if err != nil {
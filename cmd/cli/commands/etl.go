@@ -32,6 +32,15 @@ var etlCmds = []cli.Command{
 				ArgsUsage: "SPEC_FILE",
 				Action:    etlInitHandler,
 			},
+			{
+				Name:      subcmdValidate,
+				Usage:     "validate ETL yaml spec without initializing ETL",
+				ArgsUsage: "SPEC_FILE",
+				Flags: []cli.Flag{
+					checkImageFlag,
+				},
+				Action: etlValidateHandler,
+			},
 			{
 				Name:  subcmdBuild,
 				Usage: "build",
@@ -75,6 +84,10 @@ var etlCmds = []cli.Command{
 					etlExtFlag,
 					cpBckPrefixFlag,
 					cpBckDryRunFlag,
+					etlSrcPrefixFlag,
+					templateFlag,
+					regexFlag,
+					listFlag,
 				},
 				BashComplete: oldAndNewBucketCompletions([]cli.BashCompleteFunc{}, false /* separator */),
 			},
@@ -90,6 +103,10 @@ func etlInitHandler(c *cli.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if errs := etl.LintSpec(spec); len(errs) > 0 {
+		printETLValidationErrs(c.App.Writer, errs)
+		return fmt.Errorf("invalid ETL spec %q", c.Args()[0])
+	}
 
 	id, err := api.ETLInit(defaultAPIParams, spec)
 	if err != nil {
@@ -99,6 +116,39 @@ func etlInitHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func etlValidateHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "SPEC_FILE")
+	}
+	spec, err := ioutil.ReadFile(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	if errs := etl.LintSpec(spec); len(errs) > 0 {
+		printETLValidationErrs(c.App.Writer, errs)
+		return fmt.Errorf("invalid ETL spec %q", c.Args()[0])
+	}
+	errs, err := api.ETLValidate(defaultAPIParams, etl.ValidateMsg{
+		Spec:       spec,
+		CheckImage: flagIsSet(c, checkImageFlag),
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		printETLValidationErrs(c.App.Writer, errs)
+		return fmt.Errorf("invalid ETL spec %q", c.Args()[0])
+	}
+	fmt.Fprintf(c.App.Writer, "ETL spec %q is valid\n", c.Args()[0])
+	return nil
+}
+
+func printETLValidationErrs(w io.Writer, errs etl.ValidationErrs) {
+	for _, e := range errs {
+		fmt.Fprintf(w, "  - %s\n", e.Error())
+	}
+}
+
 func etlBuildHandler(c *cli.Context) (err error) {
 	var msg etl.BuildMsg
 
@@ -250,12 +300,19 @@ func etlOfflineHandler(c *cli.Context) (err error) {
 		}
 	}
 
-	xactID, err := api.ETLBucket(defaultAPIParams, fromBck, toBck, &cmn.Bck2BckMsg{
-		ID:     id,
-		Ext:    extMap,
-		Prefix: parseStrFlag(c, cpBckPrefixFlag),
-		DryRun: flagIsSet(c, cpBckDryRunFlag),
-	})
+	msg := &cmn.Bck2BckMsg{
+		ID:        id,
+		Ext:       extMap,
+		Prefix:    parseStrFlag(c, cpBckPrefixFlag),
+		DryRun:    flagIsSet(c, cpBckDryRunFlag),
+		SrcPrefix: parseStrFlag(c, etlSrcPrefixFlag),
+		Template:  parseStrFlag(c, templateFlag),
+		Regex:     parseStrFlag(c, regexFlag),
+	}
+	if flagIsSet(c, listFlag) {
+		msg.ObjNames = makeList(parseStrFlag(c, listFlag), ",")
+	}
+	xactID, err := api.ETLBucket(defaultAPIParams, fromBck, toBck, msg)
 
 	if err := handleETLHTTPError(err, id); err != nil {
 		return err
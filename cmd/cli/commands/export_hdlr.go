@@ -0,0 +1,77 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that export buckets to WebDataset shards.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/urfave/cli"
+)
+
+var (
+	exportCmdsFlags = map[string][]cli.Flag{
+		subcmdExportBucket: {
+			exportPrefixFlag,
+			exportShardSizeFlag,
+			exportShardPrefixFlag,
+		},
+	}
+
+	exportCmds = []cli.Command{
+		{
+			Name:  commandExport,
+			Usage: "export buckets to WebDataset-style tar shards with JSON index sidecars",
+			Subcommands: []cli.Command{
+				{
+					Name:         subcmdExportBucket,
+					Usage:        "pack ais bucket (or its objects with a given prefix) into shards in another ais bucket",
+					ArgsUsage:    bucketOldNewArgument,
+					Flags:        exportCmdsFlags[subcmdExportBucket],
+					Action:       exportBucketHandler,
+					BashComplete: oldAndNewBucketCompletions([]cli.BashCompleteFunc{}, false /* separator */),
+				},
+			},
+		},
+	}
+)
+
+func exportBucketHandler(c *cli.Context) (err error) {
+	bucketName, newBucketName, err := getOldNewBucketName(c)
+	if err != nil {
+		return err
+	}
+	fromBck, err := parseBckURI(c, bucketName)
+	if err != nil {
+		return err
+	}
+	toBck, err := parseBckURI(c, newBucketName)
+	if err != nil {
+		return err
+	}
+	if fromBck.Equal(toBck) {
+		return fmt.Errorf("cannot export bucket %q onto itself", fromBck)
+	}
+	fromBck.Provider, toBck.Provider = cmn.ProviderAIS, cmn.ProviderAIS
+	msg := &cmn.ExportMsg{
+		BckTo:       toBck,
+		Prefix:      parseStrFlag(c, exportPrefixFlag),
+		ShardPrefix: parseStrFlag(c, exportShardPrefixFlag),
+	}
+	if flagIsSet(c, exportShardSizeFlag) {
+		if msg.ShardSize, err = parseByteFlagToInt(c, exportShardSizeFlag); err != nil {
+			return err
+		}
+	}
+	xactID, err := api.ExportBucket(defaultAPIParams, fromBck, msg)
+	if err != nil {
+		return err
+	}
+	msgFmt := "Exporting bucket %q to %q in progress.\nTo check the status, run: ais show xaction %s\n"
+	fmt.Fprintf(c.App.Writer, msgFmt, fromBck.Name, toBck.Name, xactID)
+	return nil
+}
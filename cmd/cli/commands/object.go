@@ -10,14 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -27,6 +30,7 @@ import (
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
 	"github.com/vbauerster/mpb/v4/decor"
+	"gopkg.in/yaml.v2"
 )
 
 type uploadParams struct {
@@ -91,17 +95,39 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 	if length, err = parseByteFlagToInt(c, lengthFlag); err != nil {
 		return
 	}
-	hdr := cmn.RangeHdr(offset, length)
+	etagSet := flagIsSet(c, etagFlag)
+	if etagSet && flagIsSet(c, checksumFlag) {
+		return incorrectUsageMsg(c, "%q and %q flags cannot be used together", etagFlag.Name, checksumFlag.Name)
+	}
+	objArgs = api.GetObjectInput{Header: cmn.RangeHdr(offset, length), IfNoneMatch: parseStrFlag(c, etagFlag)}
 
-	if outFile == fileStdIO {
-		objArgs = api.GetObjectInput{Writer: os.Stdout, Header: hdr}
-	} else {
+	var notModified bool
+	switch {
+	case outFile == fileStdIO:
+		objArgs.Writer = os.Stdout
+	case objArgs.IfNoneMatch != "":
+		// conditional GET: keep the existing file intact unless the object has changed
+		var file *os.File
+		if file, err = ioutil.TempFile(filepath.Dir(outFile), "."+filepath.Base(outFile)+".*"); err != nil {
+			return
+		}
+		defer func() {
+			file.Close()
+			if err == nil && !notModified {
+				err = os.Rename(file.Name(), outFile)
+			}
+			if err != nil || notModified {
+				os.Remove(file.Name())
+			}
+		}()
+		objArgs.Writer = file
+	default:
 		var file *os.File
 		if file, err = os.Create(outFile); err != nil {
 			return
 		}
 		defer file.Close()
-		objArgs = api.GetObjectInput{Writer: file, Header: hdr}
+		objArgs.Writer = file
 	}
 
 	if origURL != "" {
@@ -109,12 +135,23 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 		objArgs.Query.Set(cmn.URLParamOrigURL, origURL)
 	}
 
-	if flagIsSet(c, checksumFlag) {
+	var resp *http.Response
+	switch {
+	case flagIsSet(c, checksumFlag):
 		objLen, err = api.GetObjectWithValidation(defaultAPIParams, bck, object, objArgs)
-	} else {
+	case etagSet:
+		resp, objLen, err = api.GetObjectWithResp(defaultAPIParams, bck, object, objArgs)
+	default:
 		objLen, err = api.GetObject(defaultAPIParams, bck, object, objArgs)
 	}
 	if err != nil {
+		if errors.Is(err, cmn.ErrNotModified) {
+			notModified, err = true, nil
+			if !silent {
+				fmt.Fprintf(c.App.ErrWriter, "%q not modified (ETag %s)\n", object, objArgs.IfNoneMatch)
+			}
+			return
+		}
 		if httpErr, ok := err.(*cmn.HTTPError); ok {
 			if httpErr.Status == http.StatusNotFound {
 				return fmt.Errorf("object \"%s/%s\" does not exist", bck, object)
@@ -122,6 +159,9 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 		}
 		return
 	}
+	if resp != nil && !silent {
+		fmt.Fprintf(c.App.ErrWriter, "ETag: %s\n", resp.Header.Get(cmn.HeaderETag))
+	}
 
 	if flagIsSet(c, lengthFlag) {
 		fmt.Fprintf(c.App.ErrWriter, "Read %s (%d B)\n", cmn.B2S(objLen, 2), objLen)
@@ -744,7 +784,11 @@ func listOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 
 	switch command {
 	case commandRemove:
-		xactID, err = api.DeleteList(defaultAPIParams, bck, fileList)
+		if flagIsSet(c, undoWindowFlag) {
+			xactID, err = api.DeleteListUndo(defaultAPIParams, bck, fileList, parseDurationFlag(c, undoWindowFlag))
+		} else {
+			xactID, err = api.DeleteList(defaultAPIParams, bck, fileList)
+		}
 		command = "removed"
 	case commandPrefetch:
 		if err = ensureHasProvider(bck, command); err != nil {
@@ -770,6 +814,9 @@ func listOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 		basemsg += ", " + xactProgressMsg(xactID)
 	}
 	fmt.Fprintln(c.App.Writer, basemsg)
+	if xactID != "" && flagIsSet(c, undoWindowFlag) {
+		fmt.Fprintln(c.App.Writer, undoMsg(c, xactID))
+	}
 	return
 }
 
@@ -797,7 +844,11 @@ func rangeOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 
 	switch command {
 	case commandRemove:
-		xactID, err = api.DeleteRange(defaultAPIParams, bck, rangeStr)
+		if flagIsSet(c, undoWindowFlag) {
+			xactID, err = api.DeleteRangeUndo(defaultAPIParams, bck, rangeStr, parseDurationFlag(c, undoWindowFlag))
+		} else {
+			xactID, err = api.DeleteRange(defaultAPIParams, bck, rangeStr)
+		}
 		command = "removed"
 	case commandPrefetch:
 		if err = ensureHasProvider(bck, command); err != nil {
@@ -825,6 +876,9 @@ func rangeOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 		baseMsg += ", " + xactProgressMsg(xactID)
 	}
 	fmt.Fprintln(c.App.Writer, baseMsg)
+	if xactID != "" && flagIsSet(c, undoWindowFlag) {
+		fmt.Fprintln(c.App.Writer, undoMsg(c, xactID))
+	}
 	return
 }
 
@@ -865,3 +919,127 @@ func multiObjOp(c *cli.Context, command string) error {
 	}
 	return nil
 }
+
+//
+// object's custom metadata (see `ais edit-props`)
+//
+
+func readCustomMDFile(path string) (cmn.SimpleKVs, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if path == fileStdIO {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	md, err := parseCustomMDFile(b)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %v", path, err)
+	}
+	return md, nil
+}
+
+// parseCustomMDFile parses YAML (or JSON) `key: value` pairs; an empty (or null)
+// value deletes the key. The values are kept verbatim (e.g., `yes` and `0123`
+// are not converted to bool and octal number, respectively).
+func parseCustomMDFile(b []byte) (md cmn.SimpleKVs, err error) {
+	var kvs map[string]*string
+	if err = yaml.Unmarshal(b, &kvs); err != nil {
+		return nil, err
+	}
+	md = make(cmn.SimpleKVs, len(kvs))
+	for k, v := range kvs {
+		if v == nil {
+			md[k] = ""
+		} else {
+			md[k] = *v
+		}
+	}
+	return md, nil
+}
+
+// diffCustomMD returns the changes that bring the current custom metadata to the
+// desired one, and the corresponding update (see api.SetObjectCustomMD). With
+// `replace`, the keys that are not in `desired` get deleted.
+func diffCustomMD(cur, desired cmn.SimpleKVs, replace bool) (diffs []propDiff, update cmn.SimpleKVs) {
+	update = make(cmn.SimpleKVs, len(desired))
+	for k, v := range desired {
+		if curV, ok := cur[k]; (ok && curV == v) || (!ok && v == "") {
+			continue
+		}
+		update[k] = v
+		diffs = append(diffs, propDiff{Name: k, Value: cur[k], Other: v})
+	}
+	if replace {
+		for k, v := range cur {
+			if _, ok := desired[k]; !ok {
+				update[k] = ""
+				diffs = append(diffs, propDiff{Name: k, Value: v})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return
+}
+
+// editCustomMD opens the current custom metadata in $EDITOR (YAML) and returns the result
+func editCustomMD(bck cmn.Bck, objName string, cur cmn.SimpleKVs) (cmn.SimpleKVs, error) {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	kvs := make(yaml.MapSlice, 0, len(cur))
+	for k, v := range cur {
+		kvs = append(kvs, yaml.MapItem{Key: k, Value: v})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key.(string) < kvs[j].Key.(string) })
+	b, err := yaml.Marshal(kvs)
+	if err != nil {
+		return nil, err
+	}
+	if len(kvs) == 0 {
+		b = nil
+	}
+	f, err := ioutil.TempFile("", "ais-custom-md-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	fmt.Fprintf(f, "# custom metadata of %s/%s: `key: value` per line (removed keys get deleted)\n", bck, objName)
+	if _, err = f.Write(b); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err = f.Close(); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %v", editor[0], err)
+	}
+	return readCustomMDFile(f.Name())
+}
+
+func printCustomMDDiff(c *cli.Context, diffs []propDiff) error {
+	tw := tabwriter.NewWriter(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "KEY\tCURRENT\tNEW")
+	}
+	for _, diff := range diffs {
+		cur, other := diff.Value, diff.Other
+		if cur == "" {
+			cur = "-"
+		}
+		if other == "" {
+			other = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", diff.Name, cur, other)
+	}
+	return tw.Flush()
+}
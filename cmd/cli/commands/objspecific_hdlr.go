@@ -6,10 +6,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
 )
@@ -24,6 +26,7 @@ var (
 			offsetFlag,
 			lengthFlag,
 			checksumFlag,
+			etagFlag,
 			isCachedFlag,
 			forceFlag,
 		},
@@ -56,6 +59,12 @@ var (
 			checksumFlag,
 			forceFlag,
 		},
+		commandEditProps: {
+			propsFileFlag,
+			dryRunFlag,
+			yesFlag,
+			noHeaderFlag,
+		},
 	}
 
 	objectSpecificCmds = []cli.Command{
@@ -106,6 +115,28 @@ var (
 			Action:       catHandler,
 			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
 		},
+		{
+			Name:         commandPin,
+			Usage:        "pin the object: LRU never evicts pinned objects",
+			ArgsUsage:    objectArgument,
+			Action:       pinHandler,
+			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+		},
+		{
+			Name:         commandUnpin,
+			Usage:        "unpin the object, making it eligible for LRU eviction",
+			ArgsUsage:    objectArgument,
+			Action:       pinHandler,
+			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+		},
+		{
+			Name:         commandEditProps,
+			Usage:        "edit object's custom metadata in $EDITOR, or patch it from a file (YAML or JSON)",
+			ArgsUsage:    objectArgument,
+			Flags:        objectSpecificCmdsFlags[commandEditProps],
+			Action:       editPropsHandler,
+			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+		},
 	}
 )
 
@@ -294,3 +325,84 @@ func promoteHandler(c *cli.Context) (err error) {
 func catHandler(c *cli.Context) (err error) {
 	return getObject(c, fileStdIO, true /*silent*/)
 }
+
+func pinHandler(c *cli.Context) (err error) {
+	var (
+		bck         cmn.Bck
+		objName     string
+		fullObjName = c.Args().First()
+		pin         = c.Command.Name == commandPin
+	)
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	if bck, objName, err = parseBckObjectURI(c, fullObjName); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "object name is required")
+	}
+	if bck, _, err = validateBucket(c, bck, fullObjName, false); err != nil {
+		return
+	}
+	if err = api.PinObject(defaultAPIParams, bck, objName, pin); err != nil {
+		return
+	}
+	if pin {
+		fmt.Fprintf(c.App.Writer, "%s/%s pinned\n", bck, objName)
+	} else {
+		fmt.Fprintf(c.App.Writer, "%s/%s unpinned\n", bck, objName)
+	}
+	return
+}
+
+func editPropsHandler(c *cli.Context) (err error) {
+	var (
+		bck         cmn.Bck
+		objName     string
+		desired     cmn.SimpleKVs
+		replace     bool
+		fullObjName = c.Args().First()
+	)
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	if bck, objName, err = parseBckObjectURI(c, fullObjName); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "object name is required")
+	}
+	if bck, _, err = validateBucket(c, bck, fullObjName, false); err != nil {
+		return
+	}
+	cur, err := api.GetObjectCustomMD(defaultAPIParams, bck, objName)
+	if err != nil {
+		return handleObjHeadError(err, bck, objName)
+	}
+	if flagIsSet(c, propsFileFlag) {
+		desired, err = readCustomMDFile(parseStrFlag(c, propsFileFlag))
+	} else {
+		desired, err = editCustomMD(bck, objName, cur)
+		replace = true
+	}
+	if err != nil {
+		return
+	}
+	diffs, update := diffCustomMD(cur, desired, replace)
+	if len(diffs) == 0 {
+		fmt.Fprintf(c.App.Writer, "Object %s/%s already has the set props, nothing to do\n", bck, objName)
+		return
+	}
+	if err = printCustomMDDiff(c, diffs); err != nil || flagIsSet(c, dryRunFlag) {
+		return
+	}
+	if !flagIsSet(c, yesFlag) && !confirm(c, fmt.Sprintf("Update custom metadata of %s/%s?", bck, objName)) {
+		return errors.New("operation canceled")
+	}
+	if err = api.SetObjectCustomMD(defaultAPIParams, bck, objName, update); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%s/%s custom metadata updated\n", bck, objName)
+	return
+}
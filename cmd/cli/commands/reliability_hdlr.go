@@ -16,6 +16,9 @@ var (
 	bucketSpecificCmdsFlags = map[string][]cli.Flag{
 		commandSetCopies: {
 			copiesFlag,
+			prefixFlag,
+			templateFlag,
+			verifyCopiesFlag,
 		},
 		commandECEncode: {
 			dataSlicesFlag,
@@ -56,6 +59,14 @@ func setCopiesHandler(c *cli.Context) (err error) {
 		return
 	}
 	copies := c.Int(copiesFlag.Name)
+	if flagIsSet(c, prefixFlag) || flagIsSet(c, templateFlag) || flagIsSet(c, verifyCopiesFlag) {
+		return configureNCopiesExt(c, bck, &cmn.MNCMsg{
+			Copies:   copies,
+			Prefix:   parseStrFlag(c, prefixFlag),
+			Template: parseStrFlag(c, templateFlag),
+			Verify:   flagIsSet(c, verifyCopiesFlag),
+		})
+	}
 	if p.Mirror.Copies == int64(copies) {
 		if copies > 1 && p.Mirror.Enabled {
 			fmt.Fprintf(c.App.Writer, "Bucket %q is already %d-way mirror, nothing to do\n", bck, copies)
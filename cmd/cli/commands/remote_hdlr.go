@@ -0,0 +1,126 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that manage remote backends: Cloud providers and remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/urfave/cli"
+)
+
+var (
+	remoteCmdsFlags = map[string][]cli.Flag{
+		subcmdRemoteAttach: {},
+		subcmdRemoteDetach: {},
+		subcmdRemoteShow: {
+			noHeaderFlag,
+		},
+		subcmdRemoteCheck: {
+			noHeaderFlag,
+			jsonFlag,
+		},
+	}
+
+	remoteCmds = []cli.Command{
+		{
+			Name:  commandRemote,
+			Usage: "manage remote backends: attach, detach, and show remote AIS clusters; check Cloud credentials",
+			Subcommands: []cli.Command{
+				{
+					Name:      subcmdRemoteAttach,
+					Usage:     "attach remote cluster",
+					ArgsUsage: attachRemoteAISArgument,
+					Flags:     remoteCmdsFlags[subcmdRemoteAttach],
+					Action:    attachRemoteAISHandler,
+				},
+				{
+					Name:      subcmdRemoteDetach,
+					Usage:     "detach remote cluster",
+					ArgsUsage: detachRemoteAISArgument,
+					Flags:     remoteCmdsFlags[subcmdRemoteDetach],
+					Action:    detachRemoteAISHandler,
+				},
+				{
+					Name:      subcmdRemoteShow,
+					Usage:     "show attached AIS clusters",
+					ArgsUsage: noArguments,
+					Flags:     remoteCmdsFlags[subcmdRemoteShow],
+					Action:    showRemoteAISHandler,
+				},
+				{
+					Name: subcmdRemoteCheck,
+					Usage: "verify connectivity and credentials of each configured Cloud provider (and remote AIS clusters) " +
+						"from every target",
+					ArgsUsage: remoteCheckArgument,
+					Flags:     remoteCmdsFlags[subcmdRemoteCheck],
+					Action:    remoteCheckHandler,
+				},
+			},
+		},
+	}
+)
+
+func remoteCheckHandler(c *cli.Context) (err error) {
+	probes := make([]cmn.Bck, 0, c.NArg())
+	for _, arg := range c.Args() {
+		bck, err := parseBckURI(c, arg)
+		if err != nil {
+			return err
+		}
+		probes = append(probes, bck)
+	}
+	results, err := api.GetCloudHealth(defaultAPIParams, probes...)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(results, c.App.Writer, "", true)
+	}
+
+	tids := make([]string, 0, len(results))
+	for tid := range results {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+
+	var (
+		numFailed int
+		tw        = &tabwriter.Writer{}
+	)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TARGET\tPROVIDER\tPROBE\tLATENCY\tSTATUS")
+	}
+	for _, tid := range tids {
+		for _, res := range results[tid] {
+			var (
+				provider, probe = res.Provider, res.Bck
+				latency, status = res.Latency.String(), "ok"
+			)
+			if provider == "" {
+				provider, latency = "-", "-" // the target itself failed to respond
+			}
+			if probe == "" {
+				probe = "-"
+			}
+			if res.Err != "" {
+				status = res.Err
+				numFailed++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", tid, provider, probe, latency, status)
+		}
+	}
+	tw.Flush()
+	if numFailed > 0 {
+		return fmt.Errorf("%d check(s) failed", numFailed)
+	}
+	return nil
+}
@@ -20,7 +20,10 @@ var (
 		subcmdRemoveBucket: {
 			ignoreErrorFlag,
 		},
-		subcmdRemoveObject: baseLstRngFlags,
+		subcmdRemoveObject: append(
+			baseLstRngFlags,
+			undoWindowFlag,
+		),
 		subcmdRemoveNode: {
 			maintenanceModeFlag,
 			noRebalanceFlag,
@@ -126,6 +129,10 @@ func removeObjectHandler(c *cli.Context) error {
 	}
 
 	// list and range flags are invalid with object argument(s)
+	if flagIsSet(c, undoWindowFlag) {
+		return incorrectUsageMsg(c, "flag %q requires %q or %q",
+			undoWindowFlag.Name, listFlag.Name, templateFlag.Name)
+	}
 	if flagIsSet(c, listFlag) || flagIsSet(c, templateFlag) {
 		return incorrectUsageMsg(c, "flags %q are cannot be used together with object name arguments",
 			strings.Join([]string{listFlag.Name, templateFlag.Name}, ", "))
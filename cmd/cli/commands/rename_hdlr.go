@@ -16,7 +16,7 @@ import (
 var (
 	renameCmdsFlags = map[string][]cli.Flag{
 		subcmdRenameBucket: {},
-		subcmdRenameObject: {},
+		subcmdRenameObject: {toBucketFlag},
 	}
 
 	renameCmds = []cli.Command{
@@ -34,7 +34,7 @@ var (
 				},
 				{
 					Name:         subcmdRenameObject,
-					Usage:        "rename object in ais bucket",
+					Usage:        "rename object in ais bucket or move it to another bucket",
 					ArgsUsage:    objectOldNewArgument,
 					Flags:        renameCmdsFlags[subcmdRenameObject],
 					Action:       renameObjectHandler,
@@ -89,6 +89,9 @@ func renameObjectHandler(c *cli.Context) (err error) {
 	if bck.Name == "" {
 		return incorrectUsageMsg(c, "no bucket specified for object %q", oldObj)
 	}
+	if flagIsSet(c, toBucketFlag) {
+		return moveObject(c, bck, oldObj, newObj)
+	}
 	if bck.Provider != "" && !bck.IsAIS() {
 		return incorrectUsageMsg(c, "provider %q not supported", bck.Provider)
 	}
@@ -101,3 +104,18 @@ func renameObjectHandler(c *cli.Context) (err error) {
 	fmt.Fprintf(c.App.Writer, "%q renamed to %q\n", oldObj, newObj)
 	return
 }
+
+// move object across buckets (and providers)
+func moveObject(c *cli.Context, bck cmn.Bck, objName, newObj string) error {
+	toBck, err := parseBckURI(c, parseStrFlag(c, toBucketFlag))
+	if err != nil {
+		return err
+	}
+	xactID, err := api.MoveObject(defaultAPIParams, bck, objName, toBck, newObj)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Moving %s/%s to %s/%s in progress.\nTo wait for it to finish, run: %s %s %s %s\n",
+		bck, objName, toBck, newObj, cliName, commandWait, subcmdWaitXaction, xactID)
+	return nil
+}
@@ -6,10 +6,14 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/urfave/cli"
 )
 
@@ -18,8 +22,21 @@ var (
 		subcmdSetConfig: {},
 		subcmdSetProps: {
 			resetFlag,
+			dryRunFlag,
+			forceFlag,
+			jsonFlag,
+			propsFileFlag,
+			yesFlag,
 		},
 		subcmdSetPrimary: {},
+		subcmdSetDownload: {
+			limitConnectionsFlag,
+			limitBytesPerHourFlag,
+			limitSharedFlag,
+		},
+		subcmdSetLogLevel: {
+			logLevelTTLFlag,
+		},
 	}
 
 	setCmds = []cli.Command{
@@ -51,6 +68,22 @@ var (
 					Action:       setPrimaryHandler,
 					BashComplete: daemonCompletions(completeProxies),
 				},
+				{
+					Name:         subcmdSetDownload,
+					Usage:        "change limits of a running download job (omitted limit is removed)",
+					ArgsUsage:    jobIDArgument,
+					Flags:        setCmdsFlags[subcmdSetDownload],
+					Action:       setDownloadHandler,
+					BashComplete: downloadIDRunningCompletions,
+				},
+				{
+					Name:         subcmdSetLogLevel,
+					Usage:        "change log verbosity (global and/or per module) of a single node or the cluster at runtime",
+					ArgsUsage:    setLogLevelArgument,
+					Flags:        setCmdsFlags[subcmdSetLogLevel],
+					Action:       setLogLevelHandler,
+					BashComplete: daemonCompletions(completeAllDaemons),
+				},
 			},
 		},
 	}
@@ -63,8 +96,70 @@ func setConfigHandler(c *cli.Context) (err error) {
 	return setConfig(c)
 }
 
+func setLogLevelHandler(c *cli.Context) (err error) {
+	var (
+		daemonID string
+		args     = c.Args()
+		msg      = &cmn.LogLevelMsg{TTL: parseDurationFlag(c, logLevelTTLFlag)}
+	)
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "log level and/or module=level pairs")
+	}
+	// the first argument is either a node ID, or a level, or a module=level pair
+	if _, err := strconv.Atoi(args.First()); err != nil && !strings.Contains(args.First(), keyAndValueSeparator) {
+		daemonID, args = args.First(), args.Tail()
+		smap, err := api.GetClusterMap(defaultAPIParams)
+		if err != nil {
+			return err
+		}
+		if smap.GetNode(daemonID) == nil {
+			return fmt.Errorf("node ID %q does not exist (hint: run 'show cluster' to show nodes)", daemonID)
+		}
+	}
+	for _, arg := range args {
+		if !strings.Contains(arg, keyAndValueSeparator) {
+			if msg.Level != "" {
+				return incorrectUsageMsg(c, "log level specified more than once (%s, %s)", msg.Level, arg)
+			}
+			msg.Level = arg
+			continue
+		}
+		kv := strings.SplitN(arg, keyAndValueSeparator, 2)
+		level, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return incorrectUsageMsg(c, "invalid level %q for module %q", kv[1], kv[0])
+		}
+		if msg.Modules == nil {
+			msg.Modules = make(map[string]int, len(args))
+		}
+		msg.Modules[kv[0]] = level
+	}
+	if msg.Level == "" && len(msg.Modules) == 0 {
+		return missingArgumentsError(c, "log level and/or module=level pairs")
+	}
+
+	var what string
+	if daemonID == "" {
+		err, what = api.SetClusterLogLevel(defaultAPIParams, msg), "cluster"
+	} else {
+		err, what = api.SetDaemonLogLevel(defaultAPIParams, daemonID, msg), fmt.Sprintf("node %q", daemonID)
+	}
+	if err != nil {
+		return
+	}
+	if msg.TTL != 0 {
+		fmt.Fprintf(c.App.Writer, "log levels of %s updated, reverting in %v\n", what, msg.TTL)
+	} else {
+		fmt.Fprintf(c.App.Writer, "log levels of %s updated\n", what)
+	}
+	return
+}
+
 func setPropsHandler(c *cli.Context) (err error) {
 	var origProps *cmn.BucketProps
+	if flagIsSet(c, propsFileFlag) {
+		return setPropsFromFile(c)
+	}
 	bck, err := parseBckURI(c, c.Args().First())
 	if err != nil {
 		return
@@ -77,7 +172,6 @@ func setPropsHandler(c *cli.Context) (err error) {
 	if flagIsSet(c, resetFlag) { // ignores all arguments, just resets bucket origProps
 		return resetBucketProps(c, bck)
 	}
-
 	updateProps, err := parseBckPropsFromContext(c)
 	if err != nil {
 		return
@@ -90,6 +184,9 @@ func setPropsHandler(c *cli.Context) (err error) {
 		return
 	}
 
+	if flagIsSet(c, dryRunFlag) {
+		return planBucketProps(c, bck, updateProps)
+	}
 	if err = setBucketProps(c, bck, updateProps); err != nil {
 		helpMsg := fmt.Sprintf("To show bucket properties, run \"%s %s %s BUCKET_NAME -v\"",
 			cliName, commandShow, subcmdShowBckProps)
@@ -100,6 +197,79 @@ func setPropsHandler(c *cli.Context) (err error) {
 	return
 }
 
+// applies the properties from a file to one or more buckets - only those that
+// differ from the current ones; with multiple buckets, the changes are shown
+// and confirmed first
+func setPropsFromFile(c *cli.Context) error {
+	type bckUpdate struct {
+		bck       cmn.Bck
+		origProps *cmn.BucketProps
+		update    cmn.BucketPropsToUpdate
+	}
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	desired, err := readBckPropsFile(parseStrFlag(c, propsFileFlag))
+	if err != nil {
+		return err
+	}
+	var (
+		updates = make([]bckUpdate, 0, c.NArg())
+		preview = c.NArg() > 1 || flagIsSet(c, dryRunFlag)
+	)
+	for _, arg := range c.Args() {
+		if isJSON(arg) || strings.Contains(arg, keyAndValueSeparator) {
+			return incorrectUsageMsg(c, "%q flag cannot be used together with properties in the command line",
+				propsFileFlag.Name)
+		}
+		bck, err := parseBckURI(c, arg)
+		if err != nil {
+			return err
+		}
+		bck, origProps, err := validateBucket(c, bck, "", false)
+		if err != nil {
+			return err
+		}
+		updateProps, changed, skipped, err := bckPropsUpdate(origProps, desired)
+		if err != nil {
+			return err
+		}
+		for _, name := range skipped {
+			fmt.Fprintf(c.App.Writer, "%q cannot be updated, skipping\n", name)
+		}
+		if len(changed) == 0 {
+			fmt.Fprintf(c.App.Writer, "Bucket %q already has the set props, nothing to do\n", bck)
+			continue
+		}
+		if preview {
+			if c.NArg() > 1 {
+				fmt.Fprintf(c.App.Writer, "Bucket %q:\n", bck)
+			}
+			for _, diff := range changed {
+				fmt.Fprintf(c.App.Writer, "%q to be set to:%q (now:%q)\n", diff.Name, diff.Other, diff.Value)
+			}
+		}
+		updates = append(updates, bckUpdate{bck: bck, origProps: origProps, update: updateProps})
+	}
+	if len(updates) == 0 || flagIsSet(c, dryRunFlag) {
+		return nil
+	}
+	if c.NArg() > 1 && !flagIsSet(c, yesFlag) {
+		if !confirm(c, fmt.Sprintf("Update properties of %d bucket(s)?", len(updates))) {
+			return errors.New("operation canceled")
+		}
+	}
+	for _, u := range updates {
+		if err := setBucketProps(c, u.bck, u.update); err != nil {
+			return fmt.Errorf("%q: %v", u.bck, err)
+		}
+		newProps := u.origProps.Clone()
+		newProps.Apply(u.update)
+		showDiff(c, u.origProps, newProps)
+	}
+	return nil
+}
+
 func displayPropsEqMsg(c *cli.Context, bck cmn.Bck) {
 	args := c.Args().Tail()
 	if len(args) == 1 && !isJSON(args[0]) {
@@ -137,3 +307,24 @@ func setPrimaryHandler(c *cli.Context) (err error) {
 	}
 	return err
 }
+
+func setDownloadHandler(c *cli.Context) (err error) {
+	id := c.Args().First()
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "download job ID")
+	}
+	limitBPH, err := parseByteFlagToInt(c, limitBytesPerHourFlag)
+	if err != nil {
+		return err
+	}
+	limits := downloader.DlLimits{
+		Connections:  parseIntFlag(c, limitConnectionsFlag),
+		BytesPerHour: int(limitBPH),
+		Shared:       flagIsSet(c, limitSharedFlag),
+	}
+	if err = api.DownloadSetLimits(defaultAPIParams, id, limits); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "download job %q limits changed\n", id)
+	return
+}
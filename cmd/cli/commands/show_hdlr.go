@@ -11,11 +11,14 @@ import (
 	"strings"
 	"sync"
 	"text/tabwriter"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/urfave/cli"
 )
@@ -55,6 +58,8 @@ var (
 			progressBarFlag,
 			refreshFlag,
 			verboseFlag,
+			downloadLogFlag,
+			downloadObjFlag,
 		},
 		subcmdShowDsort: {
 			regexFlag,
@@ -88,6 +93,8 @@ var (
 		subcmdShowBckProps: {
 			jsonFlag,
 			verboseFlag,
+			propsYAMLFlag,
+			propsDiffFlag,
 		},
 		subcmdShowConfig: {
 			jsonFlag,
@@ -98,6 +105,26 @@ var (
 		subcmdShowMpath: {
 			jsonFlag,
 		},
+		subcmdShowAnalysis: {
+			prefixFlag,
+			depthFlag,
+			reportFlag,
+			topFlag,
+			duplicatesFlag,
+			jsonFlag,
+		},
+		subcmdShowAlerts: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+		subcmdShowMemsys: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+		subcmdShowRepl: {
+			jsonFlag,
+			noHeaderFlag,
+		},
 	}
 
 	showCmds = []cli.Command{
@@ -223,6 +250,38 @@ var (
 					Action:       showMpathHandler,
 					BashComplete: daemonCompletions(completeTargets),
 				},
+				{
+					Name:         subcmdShowAnalysis,
+					Usage:        "analyze bucket objects (size and access time histograms, counts by extension and prefix)",
+					ArgsUsage:    bucketArgument,
+					Flags:        showCmdsFlags[subcmdShowAnalysis],
+					Action:       showAnalysisHandler,
+					BashComplete: bucketCompletions(),
+				},
+				{
+					Name:         subcmdShowAlerts,
+					Usage:        "show firing alerts (see 'alerts' config section)",
+					ArgsUsage:    optionalTargetIDArgument,
+					Flags:        showCmdsFlags[subcmdShowAlerts],
+					Action:       showAlertsHandler,
+					BashComplete: daemonCompletions(completeTargets),
+				},
+				{
+					Name:         subcmdShowMemsys,
+					Usage:        "show memory manager stats: slab hits and ring depths, memory pressure, and swapping",
+					ArgsUsage:    optionalDaemonIDArgument,
+					Flags:        showCmdsFlags[subcmdShowMemsys],
+					Action:       showMemsysHandler,
+					BashComplete: daemonCompletions(completeAllDaemons),
+				},
+				{
+					Name:         subcmdShowRepl,
+					Usage:        "show bucket replication status: pending objects, lag, and failures",
+					ArgsUsage:    bucketArgument,
+					Flags:        showCmdsFlags[subcmdShowRepl],
+					Action:       showReplicationHandler,
+					BashComplete: bucketCompletions(),
+				},
 			},
 		},
 	}
@@ -413,6 +472,31 @@ func showRebalanceHandler(c *cli.Context) (err error) {
 	return showRebalance(c, flagIsSet(c, refreshFlag), calcRefreshRate(c))
 }
 
+func showAnalysisHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	bck, err := parseBckURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	msg := &cmn.BucketAnalysisMsg{
+		Prefix:     parseStrFlag(c, prefixFlag),
+		Depth:      parseIntFlag(c, depthFlag),
+		Report:     parseStrFlag(c, reportFlag),
+		Duplicates: flagIsSet(c, duplicatesFlag),
+	}
+	analysis, err := api.AnalyzeBucket(defaultAPIParams, bck, msg)
+	if err != nil {
+		return
+	}
+	return templates.DisplayOutput(newAnalysisView(analysis, parseIntFlag(c, topFlag)),
+		c.App.Writer, templates.BucketAnalysisTmpl, flagIsSet(c, jsonFlag))
+}
+
 func showBckPropsHandler(c *cli.Context) (err error) {
 	return showBucketProps(c)
 }
@@ -470,24 +554,10 @@ func showRemoteAISHandler(c *cli.Context) (err error) {
 }
 
 func showMpathHandler(c *cli.Context) (err error) {
-	daemonID := c.Args().First()
-	smap, err := api.GetClusterMap(defaultAPIParams)
+	nodes, err := selectTargets(c.Args().First())
 	if err != nil {
 		return err
 	}
-	var nodes []*cluster.Snode
-	if daemonID != "" {
-		tgt := smap.GetTarget(daemonID)
-		if tgt == nil {
-			return fmt.Errorf("target ID %q invalid - no such target", daemonID)
-		}
-		nodes = []*cluster.Snode{tgt}
-	} else {
-		nodes = make([]*cluster.Snode, 0, len(smap.Tmap))
-		for _, tgt := range smap.Tmap {
-			nodes = append(nodes, tgt)
-		}
-	}
 	wg := &sync.WaitGroup{}
 	mpCh := make(chan *targetMpath, len(nodes))
 	erCh := make(chan error, len(nodes))
@@ -519,3 +589,172 @@ func showMpathHandler(c *cli.Context) (err error) {
 	useJSON := flagIsSet(c, jsonFlag)
 	return templates.DisplayOutput(mpls, c.App.Writer, templates.TargetMpathListTmpl, useJSON)
 }
+
+func showAlertsHandler(c *cli.Context) (err error) {
+	nodes, err := selectTargets(c.Args().First())
+	if err != nil {
+		return err
+	}
+	res, err := forEachTarget(nodes, func(node *cluster.Snode) (interface{}, error) {
+		return api.GetDaemonAlerts(defaultAPIParams, node.ID())
+	})
+	if err != nil {
+		return err
+	}
+	alerts := make([]*stats.Alert, 0, len(res))
+	for _, v := range res {
+		alerts = append(alerts, v.([]*stats.Alert)...)
+	}
+	useJSON := flagIsSet(c, jsonFlag)
+	if len(alerts) == 0 && !useJSON {
+		fmt.Fprintln(c.App.Writer, "No alerts firing")
+		return nil
+	}
+	tmpl := templates.AlertsTmpl
+	if flagIsSet(c, noHeaderFlag) {
+		tmpl = templates.AlertsBodyTmpl
+	}
+	return templates.DisplayOutput(alerts, c.App.Writer, tmpl, useJSON)
+}
+
+func showMemsysHandler(c *cli.Context) (err error) {
+	smap, err := api.GetClusterMap(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	var nodes []*cluster.Snode
+	if daemonID := c.Args().First(); daemonID != "" {
+		node := smap.GetNode(daemonID)
+		if node == nil {
+			return fmt.Errorf("daemon ID %q invalid - no such node", daemonID)
+		}
+		nodes = append(nodes, node)
+	} else {
+		for _, nodeMap := range []cluster.NodeMap{smap.Pmap, smap.Tmap} {
+			for _, node := range nodeMap {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	res, err := forEachTarget(nodes, func(node *cluster.Snode) (interface{}, error) {
+		return api.GetDaemonMemsysStats(defaultAPIParams, node.ID())
+	})
+	if err != nil {
+		return err
+	}
+	all := make(map[string]map[string]*memsys.Stats, len(nodes))
+	for i, node := range nodes { // (sorted by forEachTarget)
+		all[node.ID()] = res[i].(map[string]*memsys.Stats)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(all, c.App.Writer, "", true)
+	}
+
+	var (
+		tw       = &tabwriter.Writer{}
+		noHeader = flagIsSet(c, noHeaderFlag)
+	)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !noHeader {
+		fmt.Fprintln(tw, "NODE\t MMSA\t PRESSURE\t SWAPPING\t SWAP USED\t TO GC\t MIN FREE\t LOW WM")
+	}
+	for _, node := range nodes {
+		for _, name := range memsysNames(all[node.ID()]) {
+			stats := all[node.ID()][name]
+			fmt.Fprintf(tw, "%s\t %s\t %s\t %d/%d\t %s\t %s\t %s\t %s\n", node.ID(), name,
+				memsys.MemPressureText(stats.Pressure), stats.Swapping, memsys.SwappingMax,
+				cmn.UnsignedB2S(stats.SwapUsed, 2), cmn.B2S(stats.ToGC, 2),
+				cmn.UnsignedB2S(stats.MinFree, 2), cmn.UnsignedB2S(stats.LowWM, 2))
+		}
+	}
+	tw.Flush()
+
+	// per-slab (only those in use)
+	fmt.Fprintln(c.App.Writer)
+	if !noHeader {
+		fmt.Fprintln(tw, "NODE\t MMSA\t SLAB\t HITS\t DEPTH\t IDLE")
+	}
+	for _, node := range nodes {
+		for _, name := range memsysNames(all[node.ID()]) {
+			stats := all[node.ID()][name]
+			for i := range stats.Hits {
+				if stats.Hits[i] == 0 && stats.Depth[i] == 0 {
+					continue
+				}
+				idle := "-"
+				if stats.Idle[i] > 0 {
+					idle = stats.Idle[i].Round(time.Second).String()
+				}
+				fmt.Fprintf(tw, "%s\t %s\t %s\t %d\t %d\t %s\n", node.ID(), name,
+					cmn.B2S(stats.SlabSize(i), 0), stats.Hits[i], stats.Depth[i], idle)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func memsysNames(m map[string]*memsys.Stats) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func showReplicationHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	bck, err := parseBckURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	status, err := api.GetReplicationStatus(defaultAPIParams, bck)
+	if err != nil {
+		return
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(status, c.App.Writer, "", true)
+	}
+	fmt.Fprintf(c.App.Writer, "Replication: %s\n\n", status.Conf.String())
+
+	var (
+		tw       = &tabwriter.Writer{}
+		noHeader = flagIsSet(c, noHeaderFlag)
+		ids      = make([]string, 0, len(status.Targets))
+	)
+	for id := range status.Targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !noHeader {
+		fmt.Fprintln(tw, "TARGET\t PENDING\t LAG\t REPLICATED\t SIZE\t FAILED")
+	}
+	line := func(name string, rs *cmn.ReplStatus) {
+		fmt.Fprintf(tw, "%s\t %d\t %s\t %d\t %s\t %d\n", name, rs.Pending, rs.Lag.Round(time.Millisecond),
+			rs.Replicated, cmn.B2S(rs.Bytes, 2), len(rs.Failed))
+	}
+	for _, id := range ids {
+		line(id, status.Targets[id])
+	}
+	line("(cluster)", &status.Cluster)
+	tw.Flush()
+
+	if len(status.Cluster.Failed) == 0 {
+		return
+	}
+	fmt.Fprintln(c.App.Writer)
+	if !noHeader {
+		fmt.Fprintln(tw, "FAILED OBJECT\t OP\t TIME\t ERROR")
+	}
+	for _, f := range status.Cluster.Failed {
+		fmt.Fprintf(tw, "%s\t %s\t %s\t %s\n", f.ObjName, f.Op,
+			time.Unix(0, f.Time).Format(time.RFC3339), f.Err)
+	}
+	return tw.Flush()
+}
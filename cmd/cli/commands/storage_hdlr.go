@@ -0,0 +1,444 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file contains implementation of the top-level `storage` command.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/urfave/cli"
+)
+
+type (
+	// capacity of a given target: per available mountpath and total
+	targetCapacity struct {
+		DaemonID string       `json:"daemon_id"`
+		Mpaths   fs.MPCap     `json:"mountpaths"`
+		Disabled []string     `json:"disabled"`
+		Status   fs.CapStatus `json:"status"`
+		Alert    string       `json:"alert,omitempty"` // capacity exceeded (see fs.CapStatus.Err)
+	}
+	// mountpath health of a given target (FSHC disables faulty mountpaths)
+	targetHealth struct {
+		DaemonID string   `json:"daemon_id"`
+		FSHC     bool     `json:"fshc_enabled"`
+		Avail    []string `json:"available"`
+		Disabled []string `json:"disabled"`
+		Status   string   `json:"status"`
+	}
+	// mountpath operation (attach, detach, enable, disable) on a given target
+	mpathOp struct {
+		si    *cluster.Snode
+		mpath string
+	}
+)
+
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthFailed   = "failed"
+)
+
+var (
+	mpathOpDone = map[string]string{
+		subcmdStorageAttach:  "attached",
+		subcmdStorageDetach:  "detached",
+		subcmdStorageEnable:  "enabled",
+		subcmdStorageDisable: "disabled",
+	}
+
+	storageCmdsFlags = map[string][]cli.Flag{
+		subcmdStorageMpath:    {jsonFlag},
+		subcmdStorageCapacity: {jsonFlag},
+		subcmdStorageDisk: append(
+			longRunFlags,
+			jsonFlag,
+			noHeaderFlag,
+		),
+		subcmdStorageHealth:   {jsonFlag},
+		subcmdStorageForecast: {jsonFlag, noHeaderFlag},
+		subcmdStorageAttach:   {yesFlag},
+		subcmdStorageDetach:   {yesFlag},
+		subcmdStorageEnable:   {yesFlag},
+		subcmdStorageDisable:  {yesFlag},
+	}
+
+	storageCmds = []cli.Command{
+		{
+			Name:  commandStorage,
+			Usage: "show and manage mountpaths, capacity, and disks of storage targets",
+			Subcommands: []cli.Command{
+				{
+					Name:         subcmdStorageMpath,
+					Usage:        "show mountpath list for targets",
+					ArgsUsage:    optionalTargetIDArgument,
+					Flags:        storageCmdsFlags[subcmdStorageMpath],
+					Action:       showMpathHandler,
+					BashComplete: daemonCompletions(completeTargets),
+				},
+				{
+					Name:         subcmdStorageCapacity,
+					Usage:        "show used and available capacity of target mountpaths",
+					ArgsUsage:    optionalTargetIDArgument,
+					Flags:        storageCmdsFlags[subcmdStorageCapacity],
+					Action:       showCapacityHandler,
+					BashComplete: daemonCompletions(completeTargets),
+				},
+				{
+					Name:         subcmdStorageDisk,
+					Usage:        "show disk stats for targets",
+					ArgsUsage:    optionalTargetIDArgument,
+					Flags:        storageCmdsFlags[subcmdStorageDisk],
+					Action:       showDisksHandler,
+					BashComplete: daemonCompletions(completeTargets),
+				},
+				{
+					Name:         subcmdStorageHealth,
+					Usage:        "show mountpath health summary for targets",
+					ArgsUsage:    optionalTargetIDArgument,
+					Flags:        storageCmdsFlags[subcmdStorageHealth],
+					Action:       showHealthHandler,
+					BashComplete: daemonCompletions(completeTargets),
+				},
+				{
+					Name:      subcmdStorageForecast,
+					Usage:     "show ingest rate and projected time to reach high watermark and OOS, per target and cluster-wide",
+					ArgsUsage: noArguments,
+					Flags:     storageCmdsFlags[subcmdStorageForecast],
+					Action:    showCapForecastHandler,
+				},
+				{
+					Name:      subcmdStorageAttach,
+					Usage:     "attach mountpath",
+					ArgsUsage: attachMountpathArgument,
+					Flags:     storageCmdsFlags[subcmdStorageAttach],
+					Action:    storageMpathHandler,
+				},
+				{
+					Name:      subcmdStorageDetach,
+					Usage:     "detach mountpath",
+					ArgsUsage: detachMountpathArgument,
+					Flags:     storageCmdsFlags[subcmdStorageDetach],
+					Action:    storageMpathHandler,
+				},
+				{
+					Name:      subcmdStorageEnable,
+					Usage:     "(re)enable disabled mountpath",
+					ArgsUsage: daemonMountpathPairArgument,
+					Flags:     storageCmdsFlags[subcmdStorageEnable],
+					Action:    storageMpathHandler,
+				},
+				{
+					Name:      subcmdStorageDisable,
+					Usage:     "disable mountpath",
+					ArgsUsage: daemonMountpathPairArgument,
+					Flags:     storageCmdsFlags[subcmdStorageDisable],
+					Action:    storageMpathHandler,
+				},
+			},
+		},
+	}
+)
+
+// selectTargets returns the target with a given ID or, if the ID is empty, all targets
+func selectTargets(daemonID string) ([]*cluster.Snode, error) {
+	smap, err := api.GetClusterMap(defaultAPIParams)
+	if err != nil {
+		return nil, err
+	}
+	if daemonID != "" {
+		tgt := smap.GetTarget(daemonID)
+		if tgt == nil {
+			return nil, fmt.Errorf("target ID %q invalid - no such target", daemonID)
+		}
+		return []*cluster.Snode{tgt}, nil
+	}
+	nodes := make([]*cluster.Snode, 0, len(smap.Tmap))
+	for _, tgt := range smap.Tmap {
+		nodes = append(nodes, tgt)
+	}
+	return nodes, nil
+}
+
+func getTargetCapacity(node *cluster.Snode) (*targetCapacity, error) {
+	status, err := api.GetDaemonStatus(defaultAPIParams, node)
+	if err != nil {
+		return nil, err
+	}
+	mpl, err := api.GetMountpaths(defaultAPIParams, node)
+	if err != nil {
+		return nil, err
+	}
+	config, err := api.GetDaemonConfig(defaultAPIParams, node.ID())
+	if err != nil {
+		return nil, err
+	}
+	tcap := &targetCapacity{
+		DaemonID: node.ID(),
+		Mpaths:   status.Capacity,
+		Disabled: mpl.Disabled,
+		Status:   status.Capacity.CapStatus(config),
+	}
+	if tcap.Mpaths == nil {
+		tcap.Mpaths = fs.MPCap{}
+	}
+	if tcap.Status.Err != nil {
+		tcap.Alert = tcap.Status.Err.Error()
+	}
+	return tcap, nil
+}
+
+func getTargetHealth(node *cluster.Snode) (*targetHealth, error) {
+	mpl, err := api.GetMountpaths(defaultAPIParams, node)
+	if err != nil {
+		return nil, err
+	}
+	config, err := api.GetDaemonConfig(defaultAPIParams, node.ID())
+	if err != nil {
+		return nil, err
+	}
+	health := &targetHealth{
+		DaemonID: node.ID(),
+		FSHC:     config.FSHC.Enabled,
+		Avail:    mpl.Available,
+		Disabled: mpl.Disabled,
+		Status:   healthOK,
+	}
+	if len(mpl.Available) == 0 {
+		health.Status = healthFailed
+	} else if len(mpl.Disabled) > 0 {
+		health.Status = healthDegraded
+	}
+	return health, nil
+}
+
+// forEachTarget calls `get` for each target in parallel; the results are sorted by target ID
+func forEachTarget(nodes []*cluster.Snode, get func(*cluster.Snode) (interface{}, error)) ([]interface{}, error) {
+	var (
+		wg   = &sync.WaitGroup{}
+		res  = make([]interface{}, len(nodes))
+		erCh = make(chan error, len(nodes))
+	)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID() < nodes[j].ID() // ascending by node id/name
+	})
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *cluster.Snode) {
+			defer wg.Done()
+			v, err := get(node)
+			if err != nil {
+				erCh <- err
+				return
+			}
+			res[i] = v
+		}(i, node)
+	}
+	wg.Wait()
+	close(erCh)
+	for err := range erCh {
+		return nil, err
+	}
+	return res, nil
+}
+
+func showCapacityHandler(c *cli.Context) (err error) {
+	nodes, err := selectTargets(c.Args().First())
+	if err != nil {
+		return err
+	}
+	res, err := forEachTarget(nodes, func(node *cluster.Snode) (interface{}, error) {
+		return getTargetCapacity(node)
+	})
+	if err != nil {
+		return err
+	}
+	caps := make([]*targetCapacity, 0, len(res))
+	for _, v := range res {
+		caps = append(caps, v.(*targetCapacity))
+	}
+	return templates.DisplayOutput(caps, c.App.Writer, templates.TargetCapacityTmpl, flagIsSet(c, jsonFlag))
+}
+
+func showHealthHandler(c *cli.Context) (err error) {
+	nodes, err := selectTargets(c.Args().First())
+	if err != nil {
+		return err
+	}
+	res, err := forEachTarget(nodes, func(node *cluster.Snode) (interface{}, error) {
+		return getTargetHealth(node)
+	})
+	if err != nil {
+		return err
+	}
+	health := make([]*targetHealth, 0, len(res))
+	for _, v := range res {
+		health = append(health, v.(*targetHealth))
+	}
+	return templates.DisplayOutput(health, c.App.Writer, templates.TargetHealthTmpl, flagIsSet(c, jsonFlag))
+}
+
+func showCapForecastHandler(c *cli.Context) (err error) {
+	forecast, err := api.GetCapacityForecast(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(forecast, c.App.Writer, "", true)
+	}
+	tids := make([]string, 0, len(forecast.Targets))
+	for tid := range forecast.Targets {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TARGET\tUSED\tTOTAL\tINGEST RATE\tHIGH WM IN\tOOS IN\tSAMPLES (WINDOW)")
+	}
+	line := func(name string, f *cmn.CapForecast) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s/s\t%s\t%s\t%d (%v)\n", name,
+			cmn.UnsignedB2S(f.Used, 2), cmn.UnsignedB2S(f.Total, 2), cmn.B2S(int64(f.IngestRate), 2),
+			fmtTimeTo(f.TimeToHighWM), fmtTimeTo(f.TimeToOOS), f.Samples, f.Window.Truncate(time.Minute))
+	}
+	for _, tid := range tids {
+		line(tid, forecast.Targets[tid])
+	}
+	line("CLUSTER", &forecast.Cluster)
+	return tw.Flush()
+}
+
+func fmtTimeTo(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "never"
+	case d == 0:
+		return "now"
+	default:
+		return d.Truncate(time.Minute).String()
+	}
+}
+
+// storageMpathHandler attaches, detaches, enables, or disables mountpaths -
+// depending on the subcommand - upon showing the impact and asking for confirmation
+func storageMpathHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, daemonMountpathPairArgument)
+	}
+	kvs, err := makePairs(c.Args())
+	if err != nil {
+		return err
+	}
+	smap, err := fillMap()
+	if err != nil {
+		return err
+	}
+	ops := make([]mpathOp, 0, len(kvs))
+	for nodeID, mountpath := range kvs {
+		si := smap.GetTarget(nodeID)
+		if si == nil {
+			return fmt.Errorf("daemon with ID (%s) does not exist", nodeID)
+		}
+		ops = append(ops, mpathOp{si: si, mpath: mountpath})
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].si.ID() < ops[j].si.ID() })
+
+	action := c.Command.Name
+	if !flagIsSet(c, yesFlag) {
+		var warnings []string
+		for _, op := range ops {
+			warning, err := previewMpathOp(c, action, op)
+			if err != nil {
+				return err
+			}
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+		}
+		prompt := fmt.Sprintf("Proceed to %s %d mountpath(s)?", action, len(ops))
+		if len(warnings) > 0 {
+			warnings = []string{strings.Join(warnings, "; ")}
+		}
+		if ok := confirm(c, prompt, warnings...); !ok {
+			return nil
+		}
+	}
+
+	for _, op := range ops {
+		switch action {
+		case subcmdStorageAttach:
+			err = api.AddMountpath(defaultAPIParams, op.si, op.mpath)
+		case subcmdStorageDetach:
+			err = api.RemoveMountpath(defaultAPIParams, op.si.ID(), op.mpath)
+		case subcmdStorageEnable:
+			err = api.EnableMountpath(defaultAPIParams, op.si, op.mpath)
+		case subcmdStorageDisable:
+			err = api.DisableMountpath(defaultAPIParams, op.si.ID(), op.mpath)
+		default:
+			cmn.AssertMsg(false, action)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.App.Writer, "Node %q: %s mountpath %q\n", op.si.ID(), mpathOpDone[action], op.mpath)
+	}
+	return nil
+}
+
+// previewMpathOp prints the current state of the target and the state it is
+// going to be in upon the operation; returns a warning if the latter is not healthy
+func previewMpathOp(c *cli.Context, action string, op mpathOp) (warning string, err error) {
+	tcap, err := getTargetCapacity(op.si)
+	if err != nil {
+		return "", err
+	}
+	var (
+		avail          = len(tcap.Mpaths)
+		used, total    = tcap.Status.TotalUsed, tcap.Status.TotalUsed + tcap.Status.TotalAvail
+		mpCap, isAvail = tcap.Mpaths[op.mpath]
+		isDisabled     = cmn.StringInSlice(op.mpath, tcap.Disabled)
+	)
+	fmt.Fprintf(c.App.Writer, "Node %q: %d available and %d disabled mountpath(s), used %s of %s\n",
+		op.si.ID(), avail, len(tcap.Disabled), cmn.UnsignedB2S(used, 2), cmn.UnsignedB2S(total, 2))
+
+	switch action {
+	case subcmdStorageAttach, subcmdStorageEnable:
+		if action == subcmdStorageEnable && !isDisabled {
+			return "", fmt.Errorf("node %q: mountpath %q is not disabled", op.si.ID(), op.mpath)
+		}
+		fmt.Fprintf(c.App.Writer, "\t%s %q: %d available mountpath(s) after, rebalance/resilver may start\n",
+			action, op.mpath, avail+1)
+	case subcmdStorageDetach, subcmdStorageDisable:
+		if !isAvail {
+			if action == subcmdStorageDisable || !isDisabled {
+				return "", fmt.Errorf("node %q: mountpath %q is not available", op.si.ID(), op.mpath)
+			}
+			fmt.Fprintf(c.App.Writer, "\t%s %q: disabled mountpath, no capacity change\n", action, op.mpath)
+			return
+		}
+		used -= mpCap.Used
+		total -= mpCap.Used + mpCap.Avail
+		fmt.Fprintf(c.App.Writer, "\t%s %q: %d available mountpath(s) after, used %s of %s\n",
+			action, op.mpath, avail-1, cmn.UnsignedB2S(used, 2), cmn.UnsignedB2S(total, 2))
+		if avail == 1 {
+			warning = fmt.Sprintf("node %q will have no available mountpaths", op.si.ID())
+		} else {
+			warning = fmt.Sprintf("data stored on %q will be unavailable until it is rebalanced/resilvered",
+				op.mpath)
+		}
+	}
+	return
+}
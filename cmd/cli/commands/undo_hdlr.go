@@ -0,0 +1,53 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file contains implementation of the top-level `undo` command.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/urfave/cli"
+)
+
+var (
+	undoCmdsFlags = map[string][]cli.Flag{
+		subcmdUndoDelete: {},
+	}
+
+	undoCmds = []cli.Command{
+		{
+			Name:  commandUndo,
+			Usage: "undo operations",
+			Subcommands: []cli.Command{
+				{
+					Name:      subcmdUndoDelete,
+					Usage:     "restore objects removed with '--undo-window' (provided the window has not expired yet)",
+					ArgsUsage: undoTokenArgument,
+					Flags:     undoCmdsFlags[subcmdUndoDelete],
+					Action:    undoDeleteHandler,
+				},
+			},
+		},
+	}
+)
+
+func undoMsg(c *cli.Context, token string) string {
+	return fmt.Sprintf("use '%s %s %s %s' within %v to undo", cliName, commandUndo, subcmdUndoDelete, token,
+		parseDurationFlag(c, undoWindowFlag))
+}
+
+func undoDeleteHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, undoTokenArgument)
+	}
+	token := c.Args().First()
+	restored, err := api.UndoDelete(defaultAPIParams, token)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Restored %d object(s)\n", restored)
+	return nil
+}
@@ -8,8 +8,10 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"net/http"
 	"net/url"
@@ -34,6 +36,7 @@ import (
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
 	"github.com/vbauerster/mpb/v4/decor"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -366,6 +369,23 @@ func parseSource(rawURL string) (source dlSource, err error) {
 	}, err
 }
 
+// parseHeaders parses "name: value" pairs (see downloader.DlBase.Headers)
+func parseHeaders(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, ":", 2)
+		name := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || name == "" {
+			return nil, fmt.Errorf("invalid header %q (expecting 'name: value')", pair)
+		}
+		headers[name] = strings.TrimSpace(kv[1])
+	}
+	return headers, nil
+}
+
 func parseDest(rawURL string) (bucket, pathSuffix string, err error) {
 	destScheme, destBucket, destPathSuffix, err := parseURI(rawURL)
 	if err != nil {
@@ -938,6 +958,42 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop, err err
 			{"lru", props.LRU.String()},
 			{"versioning", props.Versioning.String()},
 		}
+		if props.Ephemeral.Enabled() {
+			propList = append(propList, prop{Name: "ephemeral", Value: props.Ephemeral.String()})
+		}
+		if props.DirectRead.Enabled {
+			propList = append(propList, prop{Name: "direct_read", Value: props.DirectRead.String()})
+		}
+		if props.Events.Enabled() {
+			propList = append(propList, prop{Name: "events", Value: props.Events.String()})
+		}
+		if props.ReadOnly {
+			propList = append(propList, prop{Name: "read_only", Value: "true"})
+		}
+		if props.Residency.Enabled() {
+			propList = append(propList, prop{Name: "residency", Value: props.Residency.String()})
+		}
+		if props.Dedup.Enabled {
+			propList = append(propList, prop{Name: "dedup", Value: "true"})
+		}
+		if props.Lifecycle.Enabled() {
+			propList = append(propList, prop{Name: "lifecycle", Value: props.Lifecycle.String()})
+		}
+		if props.Naming.Enabled() {
+			propList = append(propList, prop{Name: "naming", Value: props.Naming.String()})
+		}
+		if props.Replication.Enabled() {
+			propList = append(propList, prop{Name: "replication", Value: props.Replication.String()})
+		}
+		if props.MDIndex.Enabled {
+			propList = append(propList, prop{Name: "md-index", Value: props.MDIndex.String()})
+		}
+		if !props.ColdGet.IsDefault() {
+			propList = append(propList, prop{Name: "cold-get", Value: props.ColdGet.String()})
+		}
+		if props.Extra.AWS.Endpoint != "" {
+			propList = append(propList, prop{Name: "s3-endpoint", Value: props.Extra.AWS.String()})
+		}
 		if props.Extra.OrigURLBck != "" {
 			propList = append(propList, prop{Name: "original-url", Value: props.Extra.OrigURLBck})
 		}
@@ -1024,3 +1080,142 @@ func maintenanceModeToAction(c *cli.Context, mode string) (string, error) {
 	return "", incorrectUsageMsg(c, "'mode' is one of %s, %s, and %s",
 		maintenanceModeStart, maintenanceModeStop, maintenanceModeDecommission)
 }
+
+// bckPropsKVs returns all bucket properties as flat `name => value` pairs,
+// the names being the same as in `ais set props BUCKET NAME=VALUE`.
+func bckPropsKVs(props *cmn.BucketProps) (nvs yaml.MapSlice) {
+	err := cmn.IterFields(props, func(uniqueTag string, field cmn.IterField) (error, bool) {
+		nvs = append(nvs, yaml.MapItem{Key: uniqueTag, Value: field.Value()})
+		return nil, false
+	})
+	cmn.AssertNoErr(err)
+	return
+}
+
+func readBckPropsFile(path string) (cmn.SimpleKVs, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if path == fileStdIO {
+		b, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	nvs, err := parseBckPropsFile(b)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %v", path, err)
+	}
+	return nvs, nil
+}
+
+// parseBckPropsFile parses YAML (or JSON) bucket properties - either flat
+// `name: value` pairs (see bckPropsKVs) or nested sections, e.g. `mirror: {copies: 2}`.
+func parseBckPropsFile(b []byte) (nvs cmn.SimpleKVs, err error) {
+	var v interface{}
+	if err = yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	nvs = make(cmn.SimpleKVs, 32)
+	if err = flattenBckProps("", v, nvs); err != nil {
+		return nil, err
+	}
+	known := make(cmn.StringSet, len(nvs))
+	for _, item := range bckPropsKVs(&cmn.BucketProps{}) {
+		known.Add(item.Key.(string))
+	}
+	for name := range nvs {
+		if !known.Contains(name) {
+			return nil, fmt.Errorf("unknown property %q", name)
+		}
+	}
+	return nvs, nil
+}
+
+func flattenBckProps(prefix string, v interface{}, nvs cmn.SimpleKVs) error {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		for k, sub := range val {
+			name := fmt.Sprintf("%v", k)
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if err := flattenBckProps(name, sub, nvs); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		return fmt.Errorf("property %q: expecting value, got list", prefix)
+	case nil:
+		if prefix == "" {
+			return errors.New("no properties")
+		}
+		nvs[prefix] = ""
+	default:
+		if prefix == "" {
+			return fmt.Errorf("expecting properties, got %v", val)
+		}
+		nvs[prefix] = fmt.Sprintf("%v", val)
+	}
+	return nil
+}
+
+type propDiff struct {
+	Name  string
+	Value string // current
+	Other string // other bucket's or desired
+}
+
+// diffBckProps compares bucket properties with the other ones - all or only some
+// of them (e.g., those listed in a file).
+func diffBckProps(props *cmn.BucketProps, other cmn.SimpleKVs) (diffs []propDiff) {
+	for _, item := range bckPropsKVs(props) {
+		name := item.Key.(string)
+		otherValue, ok := other[name]
+		if !ok {
+			continue
+		}
+		if value := fmt.Sprintf("%v", item.Value); value != otherValue {
+			diffs = append(diffs, propDiff{Name: name, Value: value, Other: otherValue})
+		}
+	}
+	return
+}
+
+// bckPropsUpdatable returns the names of the bucket properties that can be
+// updated (see cmn.BucketPropsToUpdate).
+func bckPropsUpdatable() cmn.StringSet {
+	names := make(cmn.StringSet, 64)
+	err := cmn.IterFields(&cmn.BucketPropsToUpdate{}, func(uniqueTag string, _ cmn.IterField) (error, bool) {
+		names.Add(uniqueTag)
+		return nil, false
+	})
+	cmn.AssertNoErr(err)
+	return names
+}
+
+// bckPropsUpdate returns the update that brings bucket properties to the desired
+// ones - only the changed properties that can be updated (the others are returned
+// as skipped, e.g. `created` or `provider`).
+func bckPropsUpdate(props *cmn.BucketProps, desired cmn.SimpleKVs) (update cmn.BucketPropsToUpdate,
+	changed []propDiff, skipped []string, err error) {
+	var (
+		nvs       = make(cmn.SimpleKVs, len(desired))
+		updatable = bckPropsUpdatable()
+	)
+	for _, diff := range diffBckProps(props, desired) {
+		if !updatable.Contains(diff.Name) {
+			skipped = append(skipped, diff.Name)
+			continue
+		}
+		nvs[diff.Name] = diff.Other
+		changed = append(changed, diff)
+	}
+	if len(nvs) > 0 {
+		update, err = cmn.NewBucketPropsToUpdate(nvs)
+	}
+	return
+}
@@ -6,7 +6,12 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
@@ -18,6 +23,7 @@ var (
 	waitCmdsFlags = map[string][]cli.Flag{
 		subcmdWaitXaction: {
 			refreshFlag,
+			progressBarFlag,
 		},
 		subcmdWaitDownload: {
 			refreshFlag,
@@ -73,9 +79,33 @@ func waitXactionHandler(c *cli.Context) error {
 		return err
 	}
 
-	refreshRate := calcRefreshRate(c)
-	xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck}
+	var (
+		refreshRate = calcRefreshRate(c)
+		xactArgs    = api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck}
+		ctx, cancel = context.WithCancel(context.Background())
+		sigCh       = make(chan os.Signal, 1)
+	)
+	defer cancel()
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	xactArgs.Ctx = ctx
+	if flagIsSet(c, progressBarFlag) {
+		xactArgs.ProgressInterval = refreshRate
+		xactArgs.ProgressFn = func(stats api.NodesXactMultiStats) {
+			fmt.Fprintf(c.App.Writer, "%d objects, %s\n", stats.ObjCount(), cmn.B2S(stats.BytesCount(), 2))
+		}
+	}
 	status, err := api.WaitForXaction(defaultAPIParams, xactArgs, refreshRate)
+	if err == context.Canceled {
+		return errors.New("interrupted (the xaction keeps running)")
+	}
 	if err != nil {
 		return err
 	}
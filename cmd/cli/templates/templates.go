@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -102,6 +103,7 @@ const (
 		" Burst:\t{{$obj.Burst}}\n" +
 		" Utilization Threshold:\t{{$obj.UtilThresh}}\n" +
 		" Optimize PUT:\t{{$obj.OptimizePUT}}\n" +
+		" Hot Label:\t{{$obj.HotLabel}}\n" +
 		" Enabled:\t{{$obj.Enabled}}\n"
 	LogConfTmpl = "\n{{$obj := .Log}}Log Config\n" +
 		" Dir:\t{{$obj.Dir}}\n" +
@@ -121,7 +123,12 @@ const (
 		" Timeout:\t{{$obj.TimeoutStr}}\n" +
 		" Long Timeout:\t{{$obj.TimeoutLongStr}}\n" +
 		" List Time:\t{{$obj.ListObjectsStr}}\n" +
-		" Flags:\t{{FormatFeatureFlags $obj.Features}}\n"
+		" Flags:\t{{FormatFeatureFlags $obj.Features}}\n" +
+		" Max Idle Conns (per host):\t{{$obj.Transport.MaxIdleConns}} ({{$obj.Transport.MaxIdleConnsPerHost}})\n" +
+		" Idle Conn Timeout:\t{{$obj.Transport.IdleConnTimeoutStr}}\n" +
+		" Dial Timeout:\t{{$obj.Transport.DialTimeoutStr}}\n" +
+		" TCP Keepalive:\t{{$obj.Transport.KeepAliveStr}}\n" +
+		" HTTP/2:\t{{$obj.Transport.HTTP2}}\n"
 	ProxyConfTmpl = "\n{{$obj := .Proxy}}Proxy Config\n" +
 		" Non Electable:\t{{$obj.NonElectable}}\n" +
 		" Primary URL:\t{{$obj.PrimaryURL}}\n" +
@@ -139,7 +146,8 @@ const (
 		" Disk Utilization High WM:\t{{$obj.DiskUtilHighWM}}\n" +
 		" Disk Utilization Max WM:\t{{$obj.DiskUtilMaxWM}}\n" +
 		" IO Stats Time Long:\t{{$obj.IostatTimeLongStr}}\n" +
-		" IO Stats Time Short:\t{{$obj.IostatTimeShortStr}}\n"
+		" IO Stats Time Short:\t{{$obj.IostatTimeShortStr}}\n" +
+		" VMD Remap:\t{{$obj.VMDRemap}}\n"
 	RebalanceConfTmpl = "\n{{$obj := .Rebalance}}Rebalance Config\n" +
 		" Destination Retry Time:\t{{$obj.DestRetryTimeStr}}\n" +
 		" Enabled:\t{{$obj.Enabled}}\n" +
@@ -192,7 +200,12 @@ const (
 		" Name: \t{{$obj.Proxy.Name}}\t \t{{$obj.Target.Name}}\n" +
 		" Factor: \t{{$obj.Proxy.Factor}}\t \t{{$obj.Target.Factor}}\n"
 	DownloaderConfTmpl = "\n{{$obj := .Downloader}}Downloader Config\n" +
-		" Timeout: {{$obj.TimeoutStr}}\n"
+		" Timeout: {{$obj.TimeoutStr}}\n" +
+		" Max Idle Conns (per host): {{$obj.Transport.MaxIdleConns}} ({{$obj.Transport.MaxIdleConnsPerHost}})\n" +
+		" Idle Conn Timeout: {{$obj.Transport.IdleConnTimeoutStr}}\n" +
+		" Dial Timeout: {{$obj.Transport.DialTimeoutStr}}\n" +
+		" TCP Keepalive: {{$obj.Transport.KeepAliveStr}}\n" +
+		" HTTP/2: {{$obj.Transport.HTTP2}}\n"
 	DSortConfTmpl = "\n{{$obj := .DSort}}Distributed Sort Config\n" +
 		" Duplicated Records:\t{{$obj.DuplicatedRecords}}\n" +
 		" Missing Shards:\t{{$obj.MissingShards}}\n" +
@@ -210,7 +223,26 @@ const (
 		" Number of parity slices:\t{{$obj.ParitySlices}}\n" +
 		" Rebalance batch size:\t{{$obj.BatchSize}}\n" +
 		" Compression options:\t{{$obj.Compression}}\n"
-	GlobalConfTmpl = "Config Directory: {{.Confdir}}\nCloud Providers: {{ range $key := .Cloud.Providers}} {{$key}} {{end}}\n"
+	XactionConfTmpl = "\n{{$obj := .Xaction}}Xaction Config\n" +
+		" Max Heavy per Bucket:\t{{$obj.MaxHeavyPerBucket}}\n" +
+		" Max Heavy per Namespace:\t{{$obj.MaxHeavyPerNs}}\n" +
+		" Max Heavy per Target:\t{{$obj.MaxHeavyPerTarget}}\n"
+	ScrubConfTmpl = "\n{{$obj := .Scrub}}Scrub Config\n" +
+		" Enabled:\t{{$obj.Enabled}}\n" +
+		" Pause:\t{{$obj.PauseStr}}\n"
+	MetasyncConfTmpl = "\n{{$obj := .Metasync}}Metasync Config\n" +
+		" Mode:\t{{$obj.Mode}}\n" +
+		" Fanout:\t{{$obj.Fanout}}\n" +
+		" Min Nodes:\t{{$obj.MinNodes}}\n"
+	AlertsConfTmpl = "\n{{$obj := .Alerts}}Alerts Config\n" +
+		" Enabled:\t{{$obj.Enabled}}\n" +
+		" Rules:\t{{range $r := $obj.Rules}}{{$r.Name}}({{$r.Kind}}) {{end}}\n" +
+		" Webhook:\t{{$obj.Webhook}}\n" +
+		" Email Server:\t{{$obj.Email.Server}}\n"
+	ColdGetConfTmpl = "\n{{$obj := .ColdGet}}Cold GET Config\n" +
+		" Max Concurrent:\t{{$obj.MaxConcurrent}}\n" +
+		" Max Wait:\t{{$obj.MaxWaitStr}}\n"
+	GlobalConfTmpl = "Config Directory: {{.Confdir}}\nProfile: {{.Profile}}\nCloud Providers: {{ range $key := .Cloud.Providers}} {{$key}} {{end}}\n"
 
 	// hidden config sections: replication
 	// Application Config has this sections but /deploy/dev/local/aisnode_config.sh does not expose them
@@ -225,18 +257,21 @@ const (
 		ReplicationConfTmpl + CksumConfTmpl + VerConfTmpl + FSpathsConfTmpl +
 		TestFSPConfTmpl + NetConfTmpl + FSHCConfTmpl + AuthConfTmpl + KeepaliveConfTmpl +
 		DownloaderConfTmpl + DSortConfTmpl +
-		CompressionTmpl + ECTmpl
+		CompressionTmpl + ECTmpl + XactionConfTmpl + ScrubConfTmpl + MetasyncConfTmpl + AlertsConfTmpl +
+		ColdGetConfTmpl
 
 	BucketPropsSimpleTmpl = "PROPERTY\t VALUE\n" +
 		"{{range $p := . }}" +
 		"{{$p.Name}}\t {{$p.Value}}\n" +
 		"{{end}}"
 
-	DownloadListHeader = "JOB ID\t STATUS\t ERRORS\t DESCRIPTION\n"
+	DownloadListHeader = "JOB ID\t STATUS\t ERRORS\t NEXT RUN\t DESCRIPTION\n"
 	DownloadListBody   = "{{$value.ID}}\t " +
-		"{{if $value.Aborted}}Aborted" +
+		"{{if $value.DeadlineExceeded}}Deadline exceeded" +
+		"{{else if $value.Aborted}}Aborted" +
 		"{{else}}{{if $value.JobFinished}}Finished{{else}}{{$value.PendingCnt}} pending{{end}}" +
-		"{{end}}\t {{$value.ErrorCnt}}\t {{$value.Description}}\n"
+		"{{end}}\t {{$value.ErrorCnt}}\t " +
+		"{{if IsUnsetTime $value.NextRun}}-{{else}}{{FormatTime $value.NextRun}}{{end}}\t {{$value.Description}}\n"
 	DownloadListTmpl = DownloadListHeader + "{{ range $key, $value := . }}" + DownloadListBody + "{{end}}"
 
 	DSortListHeader = "JOB ID\t STATUS\t START\t FINISH\t DESCRIPTION\n"
@@ -251,17 +286,19 @@ const (
 	XactionsBodyTmpl     = XactionsBaseBodyTmpl + XactionsExtBodyTmpl
 	XactionsBaseBodyTmpl = XactionStatsHeader +
 		"{{range $daemon := $.Stats }}" + XactionBody + "{{end}}"
-	XactionStatsHeader = "DAEMON ID\t ID\t KIND\t BUCKET\t OBJECTS\t BYTES\t START\t END\t ABORTED\n"
+	XactionStatsHeader = "DAEMON ID\t ID\t KIND\t BUCKET\t OBJECTS\t BYTES\t DISK READ/WRITE\t START\t END\t ABORTED\n"
 	XactionBody        = "{{range $key, $xact := $daemon.Stats}}" + XactionStatsBody + "{{end}}" +
-		"{{if $daemon.Stats}}\t \t \t \t \t \t \t \t{{if $.Verbose}} \t {{end}}\n{{end}}"
+		"{{if $daemon.Stats}}\t \t \t \t \t \t \t \t \t{{if $.Verbose}} \t {{end}}\n{{end}}"
 	XactionStatsBody = "{{ $daemon.DaemonID }}\t " +
 		"{{if $xact.IDX}}{{$xact.IDX}}{{else}}-{{end}}\t " +
 		"{{$xact.KindX}}\t " +
 		"{{if $xact.BckX.Name}}{{$xact.BckX.Name}}{{else}}-{{end}}\t " +
 		"{{if (eq $xact.ObjCountX 0) }}-{{else}}{{$xact.ObjCountX}}{{end}}\t " +
 		"{{if (eq $xact.BytesCountX 0) }}-{{else}}{{FormatBytesSigned $xact.BytesCountX 2}}{{end}}\t " +
+		"{{FormatXactDiskIO $xact}}\t " +
 		"{{FormatTime $xact.StartTimeX}}\t " +
-		"{{if (IsUnsetTime $xact.EndTimeX)}}-{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
+		"{{if $xact.QueuePosX}}queued (#{{$xact.QueuePosX}}){{else if (IsUnsetTime $xact.EndTimeX)}}-" +
+		"{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
 		"{{$xact.AbortedX}}\n"
 	XactionsExtBodyTmpl = "{{if $.Verbose }}" + // if not nil
 		"\n{{range $daemon := $.Stats }}" +
@@ -279,6 +316,29 @@ const (
 		"{{$v.Bck}}\t {{$v.ObjCount}}\t {{FormatBytesUnsigned $v.Size 2}}\t {{FormatFloat $v.UsedPct}}%\n" +
 		"{{end}}"
 
+	BucketAnalysisTmpl = "Bucket:\t {{.Bck}}\nObjects:\t {{.ObjCount}}\nSize:\t {{FormatBytesSigned .Size 2}}\n" +
+		"Report:\t {{.Report}}\n" +
+		"\nSIZE\t OBJECTS\t %\n" +
+		"{{range $r := .SizeRows}}{{$r.Name}}\t {{$r.Count}}\t {{FormatFloat $r.Pct}}%\n{{end}}" +
+		"\nLAST ACCESS\t OBJECTS\t %\n" +
+		"{{range $r := .AtimeRows}}{{$r.Name}}\t {{$r.Count}}\t {{FormatFloat $r.Pct}}%\n{{end}}" +
+		"\nEXTENSION\t OBJECTS\t SIZE\n" +
+		"{{range $r := .ExtRows}}{{$r.Name}}\t {{$r.Count}}\t {{FormatBytesSigned $r.Size 2}}\n{{end}}" +
+		"\nPREFIX\t OBJECTS\t SIZE\n" +
+		"{{range $r := .PrefixRows}}{{$r.Name}}\t {{$r.Count}}\t {{FormatBytesSigned $r.Size 2}}\n{{end}}" +
+		"{{if .Dedup}}\nDuplicates:\t {{.Dedup.DupCnt}} objects in {{.Dedup.GroupCnt}} groups\n" +
+		"Reclaimable:\t {{FormatBytesSigned .Dedup.Reclaimable 2}}\n" +
+		"\nCHECKSUM\t SIZE\t COPIES\t OBJECTS\n" +
+		"{{range $g := .DupGroups}}{{$g.Cksum}}\t {{FormatBytesSigned $g.Size 2}}\t {{$g.Count}}\t {{JoinList $g.Objects}}\n{{end}}{{end}}"
+
+	BucketPropsPlanTmpl = "Changes:\t {{JoinList .Changes}}\n" +
+		"{{if .Changes}}\nTARGET\t OBJECTS\t SIZE\t READ\t WRITE\t CAPACITY\t HEADROOM\t EST. TIME\t SUFFICIENT\n" +
+		"{{range $id, $e := .Targets}}{{$id}}\t {{with $e}}" + bucketPropsPlanRow + "{{end}}{{end}}" +
+		"TOTAL\t {{with .Total}}" + bucketPropsPlanRow + "{{end}}{{end}}"
+	bucketPropsPlanRow = "{{.ObjCount}}\t {{FormatBytesUnsigned .Size 2}}\t {{FormatBytesUnsigned .ReadBytes 2}}\t " +
+		"{{FormatBytesUnsigned .WriteBytes 2}}\t {{FormatBytesUnsigned .Capacity 2}}\t {{FormatBytesUnsigned .Headroom 2}}\t " +
+		"{{if .Time}}{{FormatDur .Time.Nanoseconds}}{{else}}-{{end}}\t {{FormatBool .Sufficient}}\n"
+
 	// For `object put` mass uploader. A caller adds to the template
 	// total count and size. That is why the template ends with \t
 	ExtensionTmpl = "Files to upload:\nEXTENSION\t COUNT\t SIZE\n" +
@@ -342,6 +402,34 @@ const (
 		"\t\t{{ $mp }}\n" +
 		"{{end}}{{end}}" +
 		"{{end}}{{end}}"
+
+	// Command `storage capacity`
+	TargetCapacityTmpl = "TARGET\t MOUNTPATH\t USED\t AVAIL\t USED %\n" +
+		"{{range $t := . }}" +
+		"{{range $mp, $c := $t.Mpaths }}" +
+		"{{ $t.DaemonID }}\t {{ $mp }}\t {{FormatBytesUnsigned $c.Used 2}}\t " +
+		"{{FormatBytesUnsigned $c.Avail 2}}\t {{ $c.PctUsed }}\n" +
+		"{{end}}" +
+		"{{range $mp := $t.Disabled }}" +
+		"{{ $t.DaemonID }}\t {{ $mp }}\t -\t -\t (disabled)\n" +
+		"{{end}}" +
+		"{{ $t.DaemonID }}\t TOTAL\t {{FormatBytesUnsigned $t.Status.TotalUsed 2}}\t " +
+		"{{FormatBytesUnsigned $t.Status.TotalAvail 2}}\t " +
+		"avg {{ $t.Status.PctAvg }}, max {{ $t.Status.PctMax }}{{if $t.Alert}} ({{ $t.Alert }}){{end}}\n" +
+		"{{end}}"
+
+	// Command `storage health`
+	TargetHealthTmpl = "TARGET\t FSHC\t AVAILABLE\t DISABLED\t STATUS\t DISABLED MOUNTPATHS\n" +
+		"{{range $h := . }}" +
+		"{{ $h.DaemonID }}\t {{FormatBool $h.FSHC}}\t {{len $h.Avail}}\t {{len $h.Disabled}}\t " +
+		"{{ $h.Status }}\t {{JoinList $h.Disabled}}\n" +
+		"{{end}}"
+
+	// Alerts
+	AlertsBodyTmpl = "{{range $a := . }}" +
+		"{{ $a.Node }}\t {{ $a.Rule }}\t {{ $a.Kind }}\t {{FormatTime $a.Since}}\t {{ $a.Message }}\n" +
+		"{{end}}"
+	AlertsTmpl = "TARGET\t RULE\t KIND\t SINCE\t MESSAGE\n" + AlertsBodyTmpl
 )
 
 var (
@@ -381,6 +469,7 @@ var (
 		"FormatEC":            fmtEC,
 		"FormatDur":           fmtDuration,
 		"FormatXactStatus":    fmtXactStatus,
+		"FormatXactDiskIO":    fmtXactDiskIO,
 		"FormatObjStatus":     fmtObjStatus,
 		"FormatObjIsCached":   fmtObjIsCached,
 		"FormatDaemonID":      fmtDaemonID,
@@ -429,10 +518,30 @@ type (
 		Smap   *cluster.Smap              `json:"smap"`
 		Status DaemonStatusTemplateHelper `json:"status"`
 	}
+
+	BucketAnalysisView struct {
+		*cmn.BucketAnalysis
+		SizeRows   []AnalysisRow
+		AtimeRows  []AnalysisRow
+		ExtRows    []AnalysisRow
+		PrefixRows []AnalysisRow
+		DupGroups  []*cmn.DupGroup
+	}
+	AnalysisRow struct {
+		Name  string
+		Count int64
+		Size  int64
+		Pct   float64
+	}
 )
 
 // interface guard
-var _ forMarshaler = SmapTemplateHelper{}
+var (
+	_ forMarshaler = SmapTemplateHelper{}
+	_ forMarshaler = &BucketAnalysisView{}
+)
+
+func (v *BucketAnalysisView) forMarshal() interface{} { return v.BucketAnalysis }
 
 func (sth SmapTemplateHelper) forMarshal() interface{} {
 	return sth.Smap
@@ -484,6 +593,15 @@ func fmtXactStatus(tStatus *stats.TargetStatus) string {
 	return fmt.Sprintf("finished; %d moved (%s)", tStats.ObjCount(), cmn.B2S(tStats.BytesCount(), 1))
 }
 
+// disk throughput attributed to the xaction (average over its lifetime)
+func fmtXactDiskIO(xact *xaction.BaseXactStatsExt) string {
+	if xact.DiskReadX == 0 && xact.DiskWriteX == 0 {
+		return "-"
+	}
+	rbps, wbps := xact.DiskThroughput()
+	return fmt.Sprintf("%s/s, %s/s", cmn.B2S(rbps, 1), cmn.B2S(wbps, 1))
+}
+
 func fmtObjStatus(obj *cmn.BucketEntry) string {
 	if obj.IsStatusOK() {
 		return "ok"
@@ -515,6 +633,11 @@ var ConfigSectionTmpl = map[string]string{
 	"compression":          CompressionTmpl,
 	"ec":                   ECTmpl,
 	"replication":          ReplicationConfTmpl,
+	"xaction":              XactionConfTmpl,
+	"scrub":                ScrubConfTmpl,
+	"metasync":             MetasyncConfTmpl,
+	"alerts":               AlertsConfTmpl,
+	"cold_get":             ColdGetConfTmpl,
 }
 
 func fmtObjIsCached(obj *cmn.BucketEntry) string {
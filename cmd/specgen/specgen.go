@@ -0,0 +1,106 @@
+// Package specgen generates the table of native API constants (actions, URL query
+// parameters, headers, URL paths) that is used to build the OpenAPI spec served
+// by AIS proxies at /v1/spec.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+var flags struct {
+	in, out, pkg string
+}
+
+const header = `// Code generated by cmd/specgen from %s; DO NOT EDIT.
+
+package %s
+
+// APIConstGroups lists all string constants of the native API grouped
+// (and documented) as they are defined in the source.
+var APIConstGroups = []APIConstGroup{
+`
+
+func main() {
+	flag.StringVar(&flags.in, "in", "api_const.go", "source file with API constants")
+	flag.StringVar(&flags.out, "out", "api_const_gen.go", "generated file")
+	flag.StringVar(&flags.pkg, "pkg", "cmn", "package name of the generated file")
+	flag.Parse()
+
+	if err := generate(); err != nil {
+		fmt.Fprintf(os.Stderr, "specgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate() error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, flags.in, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, header, flags.in, flags.pkg)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST || gen.Doc == nil {
+			continue
+		}
+		consts := stringConsts(gen)
+		if len(consts) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "\t{\n\t\tDoc: %q,\n\t\tConsts: []APIConst{\n", text(gen.Doc))
+		b.WriteString(strings.Join(consts, ""))
+		b.WriteString("\t\t},\n\t},\n")
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(flags.out, src, 0o644)
+}
+
+// returns string constants of a given const block; the constants are referenced
+// by name (rather than value) so that the generated file won't compile once
+// it gets out of sync with the source
+func stringConsts(gen *ast.GenDecl) (consts []string) {
+	for _, spec := range gen.Specs {
+		vspec := spec.(*ast.ValueSpec)
+		if len(vspec.Values) != len(vspec.Names) {
+			continue
+		}
+		for i, name := range vspec.Names {
+			lit, ok := vspec.Values[i].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING || !name.IsExported() {
+				continue
+			}
+			doc := text(vspec.Comment)
+			if doc == "" {
+				doc = text(vspec.Doc)
+			}
+			consts = append(consts, fmt.Sprintf("\t\t\t{Name: %q, Value: %s, Doc: %q},\n", name.Name, name.Name, doc))
+		}
+	}
+	return
+}
+
+func text(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(cg.Text()), " ")
+}
@@ -0,0 +1,55 @@
+// Package specgen generates the table of native API constants (actions, URL query
+// parameters, headers, URL paths) that is used to build the OpenAPI spec served
+// by AIS proxies at /v1/spec.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// cmn/api_const_gen.go must be regenerated (`go generate ./cmn`) whenever
+// cmn/api_const.go changes
+func TestGeneratedInSync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "specgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the source path is recorded in the generated header - run from where `go generate` does
+	if err := os.Chdir(filepath.Join("..", "..", "cmn")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Chdir(cwd); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	flags.in, flags.out, flags.pkg = "api_const.go", filepath.Join(dir, "api_const_gen.go"), "cmn"
+	if err := generate(); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := ioutil.ReadFile(flags.out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err := ioutil.ReadFile("api_const_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, existing) {
+		t.Fatal("cmn/api_const_gen.go is out of date, run `go generate ./cmn`")
+	}
+}
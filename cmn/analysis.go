@@ -0,0 +1,241 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bucket analysis (ActAnalyzeBucket) is a single pass over the objects stored
+// in the cluster that yields object size and access time (age) histograms, and
+// object counts by extension and by prefix. Each target analyzes its own
+// objects; the proxy merges the results and stores the report as a JSON object.
+//
+// Optionally (BucketAnalysisMsg.Duplicates), the analysis also finds objects
+// with identical content (same checksum and size) across the entire bucket and
+// reports the groups of duplicates along with the number of bytes that could be
+// reclaimed by storing each content once (see DedupConf).
+
+const (
+	AnalysisDefaultDepth = 1
+	AnalysisOther        = "*" // all the keys beyond the limit (below)
+
+	analysisMaxKeys = 1000 // max number of extensions (prefixes) in the report
+	dedupMaxGroups  = 1000 // max number of duplicate groups in the report
+	dedupMaxNames   = 16   // max number of object names per duplicate group
+)
+
+var (
+	// upper bounds (exclusive) of the size histogram bins; the last bin is unbounded
+	AnalysisSizeBounds = []int64{KiB, 64 * KiB, MiB, 16 * MiB, 256 * MiB, 4 * GiB}
+	// upper bounds (exclusive) of the access time histogram bins (age of the
+	// last access at the start of the analysis); the last bin is unbounded
+	AnalysisAgeBounds = []time.Duration{
+		time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour, 365 * 24 * time.Hour,
+	}
+)
+
+type (
+	// BucketAnalysisMsg represents options that can be set when asking for bucket analysis.
+	BucketAnalysisMsg struct {
+		UUID   string `json:"uuid"`
+		Prefix string `json:"prefix"` // analyze only the objects with this prefix
+		Depth  int    `json:"depth"`  // number of leading (virtual) directories to group objects by
+		Report string `json:"report"` // name of the report object (in the same bucket)
+		// find duplicates (objects with identical content); objects without
+		// checksum get their checksums computed which may take a while
+		Duplicates bool `json:"duplicates"`
+	}
+	AnalysisCount struct {
+		Count int64 `json:"count,string"`
+		Size  int64 `json:"size,string"`
+	}
+	BucketAnalysis struct {
+		Bck        Bck                       `json:"bck"`
+		Report     string                    `json:"report"` // name of the stored report object
+		Started    time.Time                 `json:"started"`
+		ObjCount   int64                     `json:"obj_count,string"`
+		Size       int64                     `json:"size,string"`
+		SizeHist   []int64                   `json:"size_hist"`  // see AnalysisSizeBounds
+		AtimeHist  []int64                   `json:"atime_hist"` // see AnalysisAgeBounds
+		Extensions map[string]*AnalysisCount `json:"extensions"` // "" - no extension
+		Prefixes   map[string]*AnalysisCount `json:"prefixes"`   // "" - top level
+		Dedup      *DedupReport              `json:"dedup,omitempty"`
+	}
+	DedupReport struct {
+		GroupCnt    int64       `json:"group_cnt,string"`   // number of distinct contents stored more than once
+		DupCnt      int64       `json:"dup_cnt,string"`     // number of redundant objects (all but one per group)
+		Reclaimable int64       `json:"reclaimable,string"` // size of the redundant objects
+		Groups      []*DupGroup `json:"groups"`             // largest groups (by reclaimable size)
+		// all contents found so far (by checksum and size); only passed between
+		// the targets and the proxy - cleared by Finalize
+		Contents map[string]*DupGroup `json:"contents,omitempty"`
+	}
+	DupGroup struct {
+		Cksum   string   `json:"cksum"` // type:value
+		Size    int64    `json:"size,string"`
+		Count   int64    `json:"count,string"`
+		Objects []string `json:"objects"` // (some of) the names of the objects
+	}
+)
+
+func NewDedupReport() *DedupReport {
+	return &DedupReport{Contents: make(map[string]*DupGroup, 64)}
+}
+
+func NewBucketAnalysis(bck Bck, started time.Time) *BucketAnalysis {
+	return &BucketAnalysis{
+		Bck:        bck,
+		Started:    started,
+		SizeHist:   make([]int64, len(AnalysisSizeBounds)+1),
+		AtimeHist:  make([]int64, len(AnalysisAgeBounds)+1),
+		Extensions: make(map[string]*AnalysisCount, 16),
+		Prefixes:   make(map[string]*AnalysisCount, 16),
+	}
+}
+
+func (a *BucketAnalysis) Add(objName string, size int64, atime time.Time, depth int) {
+	a.ObjCount++
+	a.Size += size
+	a.SizeHist[analysisSizeBin(size)]++
+	a.AtimeHist[analysisAgeBin(a.Started.Sub(atime))]++
+	addAnalysisCount(a.Extensions, path.Ext(path.Base(objName)), 1, size)
+	addAnalysisCount(a.Prefixes, AnalysisPrefix(objName, depth), 1, size)
+}
+
+func (a *BucketAnalysis) Merge(other *BucketAnalysis) {
+	a.ObjCount += other.ObjCount
+	a.Size += other.Size
+	for i := range a.SizeHist {
+		a.SizeHist[i] += other.SizeHist[i]
+	}
+	for i := range a.AtimeHist {
+		a.AtimeHist[i] += other.AtimeHist[i]
+	}
+	for ext, cnt := range other.Extensions {
+		addAnalysisCount(a.Extensions, ext, cnt.Count, cnt.Size)
+	}
+	for prefix, cnt := range other.Prefixes {
+		addAnalysisCount(a.Prefixes, prefix, cnt.Count, cnt.Size)
+	}
+	if other.Dedup != nil {
+		if a.Dedup == nil {
+			a.Dedup = NewDedupReport()
+		}
+		a.Dedup.Merge(other.Dedup)
+	}
+}
+
+//////////////////
+// dedup report //
+//////////////////
+
+func (r *DedupReport) Add(objName string, size int64, cksum *Cksum) {
+	if cksum == nil || cksum.Type() == ChecksumNone || cksum.Value() == "" {
+		return
+	}
+	r.add(&DupGroup{Cksum: cksum.Type() + ":" + cksum.Value(), Size: size, Count: 1, Objects: []string{objName}})
+}
+
+func (r *DedupReport) Merge(other *DedupReport) {
+	for _, g := range other.Contents {
+		r.add(g)
+	}
+}
+
+func (r *DedupReport) add(g *DupGroup) {
+	key := g.Cksum + "/" + strconv.FormatInt(g.Size, 10)
+	group, ok := r.Contents[key]
+	if !ok {
+		group = &DupGroup{Cksum: g.Cksum, Size: g.Size}
+		r.Contents[key] = group
+	}
+	group.Count += g.Count
+	for _, name := range g.Objects {
+		if len(group.Objects) >= dedupMaxNames {
+			break
+		}
+		group.Objects = append(group.Objects, name)
+	}
+}
+
+// Finalize computes the totals, selects the largest groups of duplicates, and
+// drops all the (unique) contents.
+func (r *DedupReport) Finalize() {
+	r.Groups = r.Groups[:0]
+	for _, g := range r.Contents {
+		if g.Count < 2 {
+			continue
+		}
+		r.GroupCnt++
+		r.DupCnt += g.Count - 1
+		r.Reclaimable += (g.Count - 1) * g.Size
+		sort.Strings(g.Objects)
+		r.Groups = append(r.Groups, g)
+	}
+	sort.Slice(r.Groups, func(i, j int) bool {
+		ri, rj := (r.Groups[i].Count-1)*r.Groups[i].Size, (r.Groups[j].Count-1)*r.Groups[j].Size
+		if ri != rj {
+			return ri > rj
+		}
+		return r.Groups[i].Cksum < r.Groups[j].Cksum
+	})
+	if len(r.Groups) > dedupMaxGroups {
+		r.Groups = r.Groups[:dedupMaxGroups]
+	}
+	r.Contents = nil
+}
+
+// AnalysisPrefix returns up to `depth` leading directories of the object name
+// (e.g., "a/b" for "a/b/c/d.jpg" and depth 2).
+func AnalysisPrefix(objName string, depth int) string {
+	if depth <= 0 {
+		depth = AnalysisDefaultDepth
+	}
+	dirs := strings.Split(objName, "/")
+	dirs = dirs[:len(dirs)-1]
+	if len(dirs) > depth {
+		dirs = dirs[:depth]
+	}
+	return strings.Join(dirs, "/")
+}
+
+func addAnalysisCount(m map[string]*AnalysisCount, key string, count, size int64) {
+	cnt, ok := m[key]
+	if !ok {
+		if len(m) >= analysisMaxKeys {
+			key = AnalysisOther
+			cnt = m[key]
+		}
+		if cnt == nil {
+			cnt = &AnalysisCount{}
+			m[key] = cnt
+		}
+	}
+	cnt.Count += count
+	cnt.Size += size
+}
+
+func analysisSizeBin(size int64) int {
+	for i, bound := range AnalysisSizeBounds {
+		if size < bound {
+			return i
+		}
+	}
+	return len(AnalysisSizeBounds)
+}
+
+func analysisAgeBin(age time.Duration) int {
+	for i, bound := range AnalysisAgeBounds {
+		if age < bound {
+			return i
+		}
+	}
+	return len(AnalysisAgeBounds)
+}
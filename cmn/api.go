@@ -5,10 +5,19 @@
 package cmn
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
 )
@@ -35,6 +44,20 @@ type (
 		KeepOrig  bool   `json:"keep_original"`
 		Verbose   bool   `json:"verbose"`
 	}
+	// ActValMoveObject is the value of ActMoveObject: the source (bucket and
+	// object) is given by the request URL, the destination - by the fields below.
+	ActValMoveObject struct {
+		Bck     Bck    `json:"bck"`
+		ObjName string `json:"objname"`
+		UUID    string `json:"uuid,omitempty"` // (internal) xaction ID assigned by the proxy
+	}
+	// LogLevelMsg is the value of ActSetLogLevel: global (-v) and per-module
+	// (see glog.SmoduleNames) log verbosity levels to set at runtime.
+	LogLevelMsg struct {
+		Level   string         `json:"level,omitempty"`   // global verbosity (same as config log.level); "" - unchanged
+		Modules map[string]int `json:"modules,omitempty"` // module name => verbosity, e.g. {"fs": 4}
+		TTL     time.Duration  `json:"ttl,omitempty"`     // revert to the previous levels after TTL (0: keep)
+	}
 	ActValDecommision struct {
 		DaemonID      string `json:"sid"`
 		SkipRebalance bool   `json:"skip_rebalance"`
@@ -52,6 +75,10 @@ type (
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
 		Flags             uint64 `json:"flags,string"`       // advanced filtering (SelectMsg extended flags)
 		UseCache          bool   `json:"use_cache"`          // use proxy cache to speed up listing objects
+		// versions of the cluster map and BMD the (multi-page) listing has started
+		// with, to validate its continuation - see ErrListingInvalidated
+		SmapVersion int64 `json:"smap_version,string,omitempty"` // `BucketList.SmapVersion`
+		BMDVersion  int64 `json:"bmd_version,string,omitempty"`  // `BucketList.BMDVersion`
 	}
 
 	BucketSummary struct {
@@ -78,6 +105,31 @@ type (
 		Template string `json:"template"`
 	}
 
+	// MptPart is an uploaded part of a multipart upload (see api.UploadPart);
+	// part numbers start from 1 (and go up to MaxMptParts);
+	// the checksum (optional) is verified upon completion of the upload.
+	MptPart struct {
+		Num       int    `json:"num"`
+		CksumType string `json:"cksum_type,omitempty"`
+		CksumVal  string `json:"cksum_value,omitempty"`
+	}
+	// MptCompleteMsg lists the parts that, in the given order, constitute the object
+	MptCompleteMsg struct {
+		Parts []MptPart `json:"parts"`
+	}
+
+	// MNCMsg is an extended form of the ActMakeNCopies action value (the number
+	// of copies alone is still accepted). Prefix and Template restrict the
+	// operation to a subset of the bucket's objects - in which case the
+	// bucket's mirroring properties remain unchanged. Verify adds a final pass
+	// that confirms the resulting number of copies of each (in-scope) object.
+	MNCMsg struct {
+		Copies   int    `json:"copies"`
+		Prefix   string `json:"prefix,omitempty"`
+		Template string `json:"template,omitempty"` // e.g. "shard-{0000..0999}.tar"
+		Verify   bool   `json:"verify,omitempty"`
+	}
+
 	// MountpathList contains two lists:
 	// * Available - list of local mountpaths available to the storage target
 	// * Disabled  - list of disabled mountpaths, the mountpaths that generated
@@ -91,6 +143,19 @@ type (
 		BckTo  Bck    `json:"bck_to"`
 		Prefix string `json:"prefix"`  // Prefix added to each resulting object.
 		DryRun bool   `json:"dry_run"` // Don't perform any PUT
+
+		// Ephemeral, if specified, makes the destination bucket temporary
+		// (applies only when the destination gets created by the copy)
+		Ephemeral *EphemeralConf `json:"ephemeral,omitempty"`
+	}
+
+	// ExportMsg packs a bucket (or its objects with a given prefix) into
+	// WebDataset-style tar shards and their JSON indexes - see package export.
+	ExportMsg struct {
+		BckTo       Bck    `json:"bck_to"`
+		Prefix      string `json:"prefix,omitempty"`       // source objects to export
+		ShardSize   int64  `json:"shard_size,omitempty"`   // approximate size of a shard (default 1GiB)
+		ShardPrefix string `json:"shard_prefix,omitempty"` // shard names: <shard_prefix>NNNNNN.tar (default "shard-")
 	}
 
 	Bck2BckMsg struct {
@@ -105,11 +170,24 @@ type (
 		ID string `json:"id,omitempty"` // optional, ETL only
 
 		// The same as CopyBckMsg
-		Prefix string `json:"prefix"`
-		DryRun bool   `json:"dry_run"`
+		Prefix    string         `json:"prefix"`
+		DryRun    bool           `json:"dry_run"`
+		Ephemeral *EphemeralConf `json:"ephemeral,omitempty"`
+
+		// Selective input (ETL only): when any of the following is specified,
+		// only the matching source objects are transformed - all conditions
+		// must hold. Template is a bash-style range (e.g. "shard-{0000..0999}.tar"),
+		// ObjNames - the list of the names of the objects to transform.
+		SrcPrefix string   `json:"src_prefix,omitempty"`
+		Template  string   `json:"template,omitempty"`
+		Regex     string   `json:"regex,omitempty"`
+		ObjNames  []string `json:"objnames,omitempty"`
 	}
 )
 
+// MaxMptParts is the maximum number of parts of a multipart upload.
+const MaxMptParts = 10000
+
 // bucket properties
 type (
 	// BucketProps defines the configuration of the bucket with regard to
@@ -146,6 +224,42 @@ type (
 		// Bucket access attributes - see Allow* above
 		Access AccessAttrs `json:"access,string"`
 
+		// Ephemeral, when set, makes the bucket temporary (eg., staging space of a job)
+		Ephemeral EphemeralConf `json:"ephemeral"`
+
+		// DirectRead defines when to bypass page cache while reading objects
+		DirectRead DirectReadConf `json:"direct_read"`
+
+		// Events defines the external sink for the bucket's object lifecycle events
+		Events EventSinkConf `json:"events"`
+
+		// ReadOnly, when set, rejects all modifications of the bucket's content
+		// (see AccessMutate) while still allowing reads - e.g., for the duration
+		// of a copy, eviction, or migration
+		ReadOnly bool `json:"read_only"`
+
+		// Residency restricts external endpoints and Cloud regions that
+		// the bucket's downloads and Cloud operations may contact
+		Residency ResidencyConf `json:"residency"`
+
+		// Dedup enables content-addressed storage of the bucket's objects
+		Dedup DedupConf `json:"dedup"`
+
+		// Lifecycle defines age-based expiration of the bucket's objects
+		Lifecycle LifecycleConf `json:"lifecycle"`
+
+		// Naming defines the rules that new object names must comply with
+		Naming NamingConf `json:"naming"`
+
+		// Replication defines continuous replication of the bucket to a remote AIS cluster
+		Replication RemoteReplConf `json:"replication"`
+
+		// ColdGet defines the bucket's share of the cluster-wide cold GET budget
+		ColdGet ColdGetShareConf `json:"cold_get"`
+
+		// MDIndex defines indexing of the objects' custom metadata
+		MDIndex MDIndexConf `json:"md_index"`
+
 		// Extra contains additional information which can depend on the provider.
 		Extra struct {
 			// [HTTP provider] Original URL prior to hashing.
@@ -153,6 +267,9 @@ type (
 
 			// [AWS provider] Region where the cloud bucket is located.
 			CloudRegion string `json:"cloud_region,omitempty" list:"readonly"`
+
+			// [AWS provider] S3-compatible endpoint of the bucket (overrides cluster config).
+			AWS ExtraPropsAWS `json:"aws"`
 		} `json:"extra,omitempty" list:"readonly"`
 
 		// unique bucket ID
@@ -161,22 +278,250 @@ type (
 		// Bucket creation time
 		Created int64 `json:"created,string" list:"readonly"`
 
+		// incremented upon each change of the props; allows to test-and-set
+		// the props (see URLParamBpropsVersion)
+		PropsVersion int64 `json:"props_version,string" list:"readonly"`
+
 		// non-empty when the bucket has been renamed (TODO: delayed deletion likewise)
 		Renamed string `list:"omit"`
 	}
 	BucketPropsToUpdate struct {
-		BackendBck *BckToUpdate         `json:"backend_bck"`
-		Versioning *VersionConfToUpdate `json:"versioning"`
-		Cksum      *CksumConfToUpdate   `json:"checksum"`
-		LRU        *LRUConfToUpdate     `json:"lru"`
-		Mirror     *MirrorConfToUpdate  `json:"mirror"`
-		EC         *ECConfToUpdate      `json:"ec"`
-		Access     *AccessAttrs         `json:"access,string"`
+		BackendBck  *BckToUpdate              `json:"backend_bck"`
+		Versioning  *VersionConfToUpdate      `json:"versioning"`
+		Cksum       *CksumConfToUpdate        `json:"checksum"`
+		LRU         *LRUConfToUpdate          `json:"lru"`
+		Mirror      *MirrorConfToUpdate       `json:"mirror"`
+		EC          *ECConfToUpdate           `json:"ec"`
+		Access      *AccessAttrs              `json:"access,string"`
+		Ephemeral   *EphemeralConfToUpdate    `json:"ephemeral"`
+		DirectRead  *DirectReadConfToUpdate   `json:"direct_read"`
+		Events      *EventSinkConfToUpdate    `json:"events"`
+		ReadOnly    *bool                     `json:"read_only"`
+		Residency   *ResidencyConfToUpdate    `json:"residency"`
+		Dedup       *DedupConfToUpdate        `json:"dedup"`
+		Lifecycle   *LifecycleConfToUpdate    `json:"lifecycle"`
+		Naming      *NamingConfToUpdate       `json:"naming"`
+		Replication *RemoteReplConfToUpdate   `json:"replication"`
+		ColdGet     *ColdGetShareConfToUpdate `json:"cold_get"`
+		MDIndex     *MDIndexConfToUpdate      `json:"md_index"`
+		Extra       *ExtraToUpdate            `json:"extra"`
+	}
+	ExtraToUpdate struct {
+		AWS *ExtraPropsAWSToUpdate `json:"aws"`
 	}
 	BckToUpdate struct {
 		Name     *string `json:"name"`
 		Provider *string `json:"provider"`
 	}
+
+	// EphemeralConf defines the lifetime of a temporary ais bucket. The bucket is
+	// destroyed by the primary proxy when either the owning job (download, xaction)
+	// finishes or the TTL (counting from the bucket creation) expires - whichever
+	// comes first.
+	EphemeralConf struct {
+		// OwnerID is the UUID of the owning job (optional)
+		OwnerID string `json:"owner_id"`
+
+		// OwnerKind is the kind of the owning job: DSortNameLowercase for dSort
+		// jobs; empty for xactions and download jobs (the default)
+		OwnerKind string `json:"owner_kind"`
+
+		// TTLStr denotes the maximum lifetime of the bucket, eg. "2h" (optional)
+		TTLStr string `json:"ttl"`
+	}
+	EphemeralConfToUpdate struct {
+		OwnerID   *string `json:"owner_id"`
+		OwnerKind *string `json:"owner_kind"`
+		TTLStr    *string `json:"ttl"`
+	}
+
+	// DirectReadConf enables reading objects with O_DIRECT (bypassing page cache)
+	// to avoid double caching when large objects are streamed sequentially,
+	// e.g. by training workloads that read the whole dataset once per epoch.
+	// Only whole-object (non-range) GETs of objects of at least MinSize
+	// bytes are affected.
+	DirectReadConf struct {
+		Enabled bool  `json:"enabled"`
+		MinSize int64 `json:"min_size"`
+	}
+	DirectReadConfToUpdate struct {
+		Enabled *bool  `json:"enabled"`
+		MinSize *int64 `json:"min_size"`
+	}
+
+	// EventSinkConf defines where to publish object lifecycle events (PUT,
+	// DELETE, cold GET) of the bucket - see package evsink.
+	EventSinkConf struct {
+		Type  string `json:"type"`  // EventSinkKafka | EventSinkNATS ("" - disabled)
+		URL   string `json:"url"`   // Kafka REST Proxy URL or NATS server address
+		Topic string `json:"topic"` // Kafka topic or NATS subject
+	}
+	EventSinkConfToUpdate struct {
+		Type  *string `json:"type"`
+		URL   *string `json:"url"`
+		Topic *string `json:"topic"`
+	}
+
+	// ResidencyConf defines data residency constraints of the bucket: the
+	// allowlists of hosts (downloads and HTTP buckets) and Cloud regions (Cloud
+	// buckets) that may be contacted on behalf of the bucket. Both lists are
+	// comma-separated; an empty list allows all. A host entry that starts with
+	// a dot (e.g. ".example.com") matches all subdomains.
+	ResidencyConf struct {
+		Hosts   string `json:"hosts"`
+		Regions string `json:"regions"`
+	}
+	ResidencyConfToUpdate struct {
+		Hosts   *string `json:"hosts"`
+		Regions *string `json:"regions"`
+	}
+
+	// DedupConf enables content-addressed storage (CAS) mode: objects with
+	// identical content (same checksum and size) share physical data - hard
+	// links to a single file per mountpath. Objects stored prior to enabling
+	// the mode are not affected. Since object metadata is shared as well, the
+	// mode cannot be combined with versioning, mirroring, and erasure coding.
+	DedupConf struct {
+		Enabled bool `json:"enabled"`
+	}
+	DedupConfToUpdate struct {
+		Enabled *bool `json:"enabled"`
+	}
+
+	// LifecycleConf defines age-based (as opposed to LRU's capacity-based)
+	// expiration of the bucket's objects, enforced by the targets periodically
+	// (see package lifecycle). All ages are durations, e.g. "720h"; empty -
+	// disabled. Note that for Cloud buckets TTL and DeleteAfter delete objects
+	// from the Cloud as well.
+	LifecycleConf struct {
+		// TTLStr: delete objects that have not been accessed for longer than TTL
+		TTLStr string `json:"ttl"`
+		// DeleteAfterStr: delete objects created (PUT) longer than DeleteAfter ago
+		DeleteAfterStr string `json:"delete_after"`
+		// EvictCloudAfterStr: evict (remove the cached copies of) Cloud objects
+		// that have not been accessed for longer than EvictCloudAfter
+		EvictCloudAfterStr string `json:"evict_cloud_after"`
+	}
+	LifecycleConfToUpdate struct {
+		TTLStr             *string `json:"ttl"`
+		DeleteAfterStr     *string `json:"delete_after"`
+		EvictCloudAfterStr *string `json:"evict_cloud_after"`
+	}
+
+	// NamingConf defines optional object naming rules of the bucket, enforced
+	// on PUT, promote, and download - so that downstream tools with stricter
+	// naming requirements (e.g., POSIX exports via aisfs) never receive
+	// incompatible names. Objects stored prior to setting the rules are not
+	// affected. Zero values - no restrictions.
+	NamingConf struct {
+		// MaxLen: maximum length of the object name, in bytes
+		MaxLen int `json:"max_len"`
+		// ForbiddenChars: characters that the object name must not contain
+		ForbiddenChars string `json:"forbidden_chars"`
+		// PrefixPattern: regular expression that the object name must start with
+		PrefixPattern string `json:"prefix_pattern"`
+	}
+	NamingConfToUpdate struct {
+		MaxLen         *int    `json:"max_len"`
+		ForbiddenChars *string `json:"forbidden_chars"`
+		PrefixPattern  *string `json:"prefix_pattern"`
+	}
+
+	// RemoteReplConf defines continuous replication of an ais bucket to the
+	// (mirror) bucket of an attached remote AIS cluster (see ActAttach): object
+	// PUTs and DELETEs get propagated by the targets - either as part of the
+	// request (sync) or in the background (async) - see package replication.
+	// Objects stored prior to enabling replication are not replicated.
+	RemoteReplConf struct {
+		// TargetCluster: alias (or UUID) of the attached remote AIS cluster ("" - disabled)
+		TargetCluster string `json:"target_cluster"`
+		// Bucket: name of the mirror bucket in the remote cluster ("" - same name)
+		Bucket string `json:"bucket"`
+		// Mode: ReplicationSync or ReplicationAsync ("" - async)
+		Mode string `json:"mode"`
+	}
+	RemoteReplConfToUpdate struct {
+		TargetCluster *string `json:"target_cluster"`
+		Bucket        *string `json:"bucket"`
+		Mode          *string `json:"mode"`
+	}
+
+	// ColdGetShareConf: when the number of concurrent cold GETs reaches the
+	// cluster-wide limit (see ColdGetConf), the waiting cold GETs of the buckets
+	// get admitted in proportion to the buckets' weights - so that a cache-miss
+	// storm in one bucket does not starve the others.
+	ColdGetShareConf struct {
+		// MaxConcurrent: cluster-wide max number of concurrent cold GETs of the bucket (0 - no limit)
+		MaxConcurrent int `json:"max_concurrent"`
+		// Weight: the bucket's share relative to other buckets (0 - default, same as 1)
+		Weight int `json:"weight"`
+	}
+	ColdGetShareConfToUpdate struct {
+		MaxConcurrent *int `json:"max_concurrent"`
+		Weight        *int `json:"weight"`
+	}
+
+	// MDIndexConf enables (per-target) inverted index over the custom metadata
+	// of the bucket's objects. The index is maintained on PUT and on updating
+	// the object's custom metadata (ActSetCustomMD), and it answers metadata
+	// queries (GetWhatMDQuery) without walking the bucket - see package mdindex.
+	// Objects stored prior to enabling the index get indexed by ActMDIndex.
+	MDIndexConf struct {
+		Enabled bool `json:"enabled"`
+		// Keys: comma-separated custom metadata keys to index ("" - all keys)
+		Keys string `json:"keys"`
+	}
+	MDIndexConfToUpdate struct {
+		Enabled *bool   `json:"enabled"`
+		Keys    *string `json:"keys"`
+	}
+
+	// ExtraPropsAWS allows to front S3-compatible storage (e.g., MinIO, Ceph RGW)
+	// on a per-bucket basis; when Endpoint is empty the cluster-wide
+	// CloudConfAWS (or, the default AWS endpoint resolution) applies.
+	ExtraPropsAWS struct {
+		Endpoint string `json:"endpoint"`
+		// PathStyle: "http(s)://endpoint/bucket" rather than "http(s)://bucket.endpoint"
+		PathStyle bool `json:"path_style"`
+	}
+	ExtraPropsAWSToUpdate struct {
+		Endpoint  *string `json:"endpoint"`
+		PathStyle *bool   `json:"path_style"`
+	}
+
+	// MDCond is a condition on the value of the object's custom metadata key;
+	// other than MDOpEq, the operators compare numerically (so that objects with
+	// non-numeric values never match). Textual form: "key=value", "key>=value", etc.
+	MDCond struct {
+		Key   string `json:"key"`
+		Op    string `json:"op"`
+		Value string `json:"value"`
+	}
+)
+
+// MDCond.Op enum
+const (
+	MDOpEq = "="
+	MDOpLt = "<"
+	MDOpLe = "<="
+	MDOpGt = ">"
+	MDOpGe = ">="
+)
+
+// characters that custom metadata keys must not contain to be indexed
+// (the values - to be found with MDOpEq)
+const mdIndexForbiddenChars = "*?\\<>=,"
+
+// RemoteReplConf.Mode enum
+const (
+	ReplicationSync  = "sync"
+	ReplicationAsync = "async"
+)
+
+// EventSinkConf.Type enum
+const (
+	EventSinkKafka = "kafka"
+	EventSinkNATS  = "nats"
 )
 
 // object properties
@@ -198,8 +543,54 @@ type (
 		Type  string `json:"type"`
 		Value string `json:"value"`
 	}
+	// ObjHeadEntry is a per-object result of the batched existence check (see ActHeadObjects)
+	ObjHeadEntry struct {
+		Name    string `json:"name"`
+		Size    int64  `json:"size,string,omitempty"`
+		Version string `json:"version,omitempty"`
+		Exists  bool   `json:"exists"`  // exists in the bucket (including Cloud)
+		Present bool   `json:"present"` // is stored in the cluster
+	}
 )
 
+// primary election history (see GetWhatElections)
+const (
+	// candidate
+	ElectionPrimaryAlive = "primary-alive" // the primary responded - back to idle
+	ElectionPreVoteLost  = "prevote-lost"  // too few nodes find the primary unreachable
+	ElectionVoteLost     = "vote-lost"
+	ElectionElected      = "elected"
+	// voter
+	ElectionPreVoted = "prevoted"
+	ElectionVoted    = "voted"
+	// all nodes
+	ElectionNewPrimary = "new-primary"
+)
+
+// ElectionEvent is a single entry of the history of primary elections kept by
+// each node - to debug flapping primaries. Yes and No count the votes received
+// (candidate) or the vote given (voter).
+type ElectionEvent struct {
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"`
+	Candidate string    `json:"candidate"`
+	Primary   string    `json:"primary"` // the (failed) primary
+	Yes       int       `json:"yes,omitempty"`
+	No        int       `json:"no,omitempty"`
+	Msg       string    `json:"msg,omitempty"`
+}
+
+// WitnessSig returns HMAC-SHA256 of the given parts keyed with the secret shared
+// between the proxies and the witness (see ProxyConf.WitnessSecret). The proxies
+// sign the vote requests, and the witness - its votes.
+func WitnessSig(secret string, parts ...[]byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // GetPropsDefault is a list of default (most relevant) `GetProps*` options.
 // NOTE: do **NOT** forget update this array when a prop is added/removed.
 var GetPropsDefault = []string{
@@ -348,10 +739,20 @@ func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %s | OOS: %v%%",
+	s := fmt.Sprintf("Watermarks: %d%%/%d%% | Do not evict time: %s | OOS: %v%%",
 		c.LowWM, c.HighWM, c.DontEvictTimeStr, c.OOS)
+	if c.DontEvictPrefixes != "" {
+		s += " | Do not evict: " + c.DontEvictPrefixes
+	}
+	if c.Priority != 0 {
+		s += fmt.Sprintf(" | Priority: %d", c.Priority)
+	}
+	return s
 }
 
+// DontEvictPrefixList returns the prefixes of the objects that LRU never evicts.
+func (c *LRUConf) DontEvictPrefixList() []string { return splitList(c.DontEvictPrefixes) }
+
 func (c *MirrorConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -375,6 +776,512 @@ func (c *ECConf) String() string {
 	return fmt.Sprintf("%d:%d (%s)", c.DataSlices, c.ParitySlices, B2S(objSizeLimit, 0))
 }
 
+func (c *EphemeralConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	text := make([]string, 0, 2)
+	if c.OwnerID != "" && c.OwnerKind != "" {
+		text = append(text, "Owner: "+c.OwnerKind+" "+c.OwnerID)
+	} else if c.OwnerID != "" {
+		text = append(text, "Owner: "+c.OwnerID)
+	}
+	if c.TTLStr != "" {
+		text = append(text, "TTL: "+c.TTLStr)
+	}
+	return strings.Join(text, " | ")
+}
+
+func (c *EphemeralConf) Enabled() bool { return c.OwnerID != "" || c.TTLStr != "" }
+
+// OwnedByDSort returns true if the owner is a dSort job - the other owners are
+// tracked by notification listeners
+func (c *EphemeralConf) OwnedByDSort() bool { return c.OwnerKind == DSortNameLowercase }
+
+func (c *EphemeralConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.OwnerKind != "" {
+		if c.OwnerID == "" {
+			return fmt.Errorf("invalid ephemeral.owner_kind %q: requires ephemeral.owner_id", c.OwnerKind)
+		}
+		if !c.OwnedByDSort() {
+			return fmt.Errorf("invalid ephemeral.owner_kind %q (expected %q or none)", c.OwnerKind,
+				DSortNameLowercase)
+		}
+	}
+	if c.TTLStr == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(c.TTLStr)
+	if err != nil {
+		return fmt.Errorf("invalid ephemeral.ttl format: %v", err)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("invalid ephemeral.ttl: %v (expected positive duration)", ttl)
+	}
+	return nil
+}
+
+// Expired returns true if TTL is set and has passed since `created` (unix nano).
+func (c *EphemeralConf) Expired(created int64, now time.Time) bool {
+	if c.TTLStr == "" {
+		return false
+	}
+	ttl, err := time.ParseDuration(c.TTLStr)
+	if err != nil || ttl <= 0 {
+		return false
+	}
+	return now.Sub(time.Unix(0, created)) > ttl
+}
+
+func (c *LifecycleConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	text := make([]string, 0, 3)
+	if c.TTLStr != "" {
+		text = append(text, "TTL: "+c.TTLStr)
+	}
+	if c.DeleteAfterStr != "" {
+		text = append(text, "Delete after: "+c.DeleteAfterStr)
+	}
+	if c.EvictCloudAfterStr != "" {
+		text = append(text, "Evict Cloud after: "+c.EvictCloudAfterStr)
+	}
+	return strings.Join(text, " | ")
+}
+
+func (c *LifecycleConf) Enabled() bool {
+	return c.TTLStr != "" || c.DeleteAfterStr != "" || c.EvictCloudAfterStr != ""
+}
+
+func (c *LifecycleConf) ValidateAsProps(_ *ValidationArgs) error {
+	_, _, _, err := c.Parse()
+	return err
+}
+
+// Parse returns the configured ages (zero - not set).
+func (c *LifecycleConf) Parse() (ttl, deleteAfter, evictCloudAfter time.Duration, err error) {
+	if ttl, err = parseLifecycleAge("ttl", c.TTLStr); err != nil {
+		return
+	}
+	if deleteAfter, err = parseLifecycleAge("delete_after", c.DeleteAfterStr); err != nil {
+		return
+	}
+	evictCloudAfter, err = parseLifecycleAge("evict_cloud_after", c.EvictCloudAfterStr)
+	return
+}
+
+func parseLifecycleAge(name, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lifecycle.%s format: %v", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid lifecycle.%s: %v (expected positive duration)", name, d)
+	}
+	return d, nil
+}
+
+func (c *NamingConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	text := make([]string, 0, 3)
+	if c.MaxLen > 0 {
+		text = append(text, fmt.Sprintf("Max length: %d", c.MaxLen))
+	}
+	if c.ForbiddenChars != "" {
+		text = append(text, fmt.Sprintf("Forbidden: %q", c.ForbiddenChars))
+	}
+	if c.PrefixPattern != "" {
+		text = append(text, "Prefix: "+c.PrefixPattern)
+	}
+	return strings.Join(text, " | ")
+}
+
+func (c *NamingConf) Enabled() bool {
+	return c.MaxLen > 0 || c.ForbiddenChars != "" || c.PrefixPattern != ""
+}
+
+func (c *NamingConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MaxLen < 0 {
+		return fmt.Errorf("invalid naming.max_len: %d (expected non-negative)", c.MaxLen)
+	}
+	if c.PrefixPattern == "" {
+		return nil
+	}
+	if _, err := namingRegexp(c.PrefixPattern); err != nil {
+		return fmt.Errorf("invalid naming.prefix_pattern %q: %v", c.PrefixPattern, err)
+	}
+	return nil
+}
+
+// ValidateObjName returns an error if the object name violates the bucket's naming rules.
+func (c *NamingConf) ValidateObjName(objName string) error {
+	if c.MaxLen > 0 && len(objName) > c.MaxLen {
+		return NewObjNameError(objName, fmt.Sprintf("length exceeds %d", c.MaxLen))
+	}
+	if c.ForbiddenChars != "" {
+		if i := strings.IndexAny(objName, c.ForbiddenChars); i >= 0 {
+			r, _ := utf8.DecodeRuneInString(objName[i:])
+			return NewObjNameError(objName, fmt.Sprintf("forbidden character %q", r))
+		}
+	}
+	if c.PrefixPattern != "" {
+		re, err := namingRegexp(c.PrefixPattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(objName) {
+			return NewObjNameError(objName, fmt.Sprintf("prefix does not match %q", c.PrefixPattern))
+		}
+	}
+	return nil
+}
+
+// compiled (and anchored) prefix patterns, shared by all buckets
+var namingRegexps sync.Map // pattern => *regexp.Regexp
+
+func namingRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := namingRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")")
+	if err != nil {
+		return nil, err
+	}
+	namingRegexps.Store(pattern, re)
+	return re, nil
+}
+
+func (c *RemoteReplConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%s => %s (%s)", c.Mode, c.TargetCluster, c.Bucket)
+}
+
+func (c *RemoteReplConf) Enabled() bool { return c.TargetCluster != "" }
+func (c *RemoteReplConf) Sync() bool    { return c.Mode == ReplicationSync }
+
+func (c *RemoteReplConf) ValidateAsProps(_ *ValidationArgs) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Mode == "" {
+		c.Mode = ReplicationAsync
+	} else if c.Mode != ReplicationSync && c.Mode != ReplicationAsync {
+		return fmt.Errorf("invalid replication.mode %q (expected one of: %q, %q)",
+			c.Mode, ReplicationSync, ReplicationAsync)
+	}
+	return nil
+}
+
+// RemoteBck returns the mirror bucket of a given (source) ais bucket.
+func (c *RemoteReplConf) RemoteBck(bck Bck) Bck {
+	name := c.Bucket
+	if name == "" {
+		name = bck.Name
+	}
+	return Bck{Name: name, Provider: ProviderAIS, Ns: Ns{UUID: c.TargetCluster}}
+}
+
+func (c *ColdGetShareConf) String() string {
+	if c.IsDefault() {
+		return "Default"
+	}
+	s := fmt.Sprintf("Weight: %d", c.EffectiveWeight())
+	if c.MaxConcurrent > 0 {
+		s += fmt.Sprintf(" | Max concurrent: %d", c.MaxConcurrent)
+	}
+	return s
+}
+
+func (c *ColdGetShareConf) IsDefault() bool { return c.MaxConcurrent == 0 && c.Weight <= 1 }
+
+func (c *ColdGetShareConf) EffectiveWeight() int {
+	if c.Weight == 0 {
+		return 1
+	}
+	return c.Weight
+}
+
+func (c *ColdGetShareConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MaxConcurrent < 0 {
+		return fmt.Errorf("invalid cold_get.max_concurrent: %d (expected non-negative)", c.MaxConcurrent)
+	}
+	if c.Weight < 0 {
+		return fmt.Errorf("invalid cold_get.weight: %d (expected non-negative)", c.Weight)
+	}
+	return nil
+}
+
+func (c *MDIndexConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Keys == "" {
+		return "All keys"
+	}
+	return "Keys: " + c.Keys
+}
+
+func (c *MDIndexConf) ValidateAsProps(_ *ValidationArgs) error {
+	for _, key := range strings.Split(c.Keys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			if c.Keys != "" {
+				return fmt.Errorf("invalid md_index.keys %q: empty key", c.Keys)
+			}
+			continue
+		}
+		if strings.ContainsAny(key, mdIndexForbiddenChars) {
+			return fmt.Errorf("invalid md_index.keys: %q (may not contain any of %q)", key, mdIndexForbiddenChars)
+		}
+	}
+	return nil
+}
+
+// Indexed returns true if a given custom metadata key is to be indexed.
+func (c *MDIndexConf) Indexed(key string) bool {
+	if key == "" || strings.ContainsAny(key, mdIndexForbiddenChars) {
+		return false
+	}
+	if c.Keys == "" {
+		return true
+	}
+	for _, k := range strings.Split(c.Keys, ",") {
+		if strings.TrimSpace(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IndexedValue returns true if the value can be matched by MDOpEq condition.
+func (c *MDIndexConf) IndexedValue(value string) bool {
+	return !strings.ContainsAny(value, mdIndexForbiddenChars)
+}
+
+// ParseMDCond parses the textual form of the condition, e.g. "label=cat" or "width>=640".
+func ParseMDCond(s string) (cond MDCond, err error) {
+	i := strings.IndexAny(s, "<>=")
+	if i <= 0 {
+		return cond, fmt.Errorf("invalid metadata condition %q (expecting key, operator, and value, e.g. \"label=cat\")", s)
+	}
+	cond.Key, cond.Op, cond.Value = s[:i], s[i:i+1], s[i+1:]
+	if cond.Op != MDOpEq && strings.HasPrefix(cond.Value, "=") {
+		cond.Op, cond.Value = cond.Op+"=", cond.Value[1:]
+	}
+	err = cond.Validate()
+	return
+}
+
+func (c *MDCond) String() string { return c.Key + c.Op + c.Value }
+
+func (c *MDCond) Numeric() bool { return c.Op != MDOpEq }
+
+func (c *MDCond) Validate() error {
+	if c.Key == "" || c.Value == "" {
+		return fmt.Errorf("invalid metadata condition %q: empty key or value", c.String())
+	}
+	switch c.Op {
+	case MDOpEq:
+		if strings.ContainsAny(c.Value, mdIndexForbiddenChars) {
+			return fmt.Errorf("invalid metadata condition %q: value may not contain any of %q",
+				c.String(), mdIndexForbiddenChars)
+		}
+	case MDOpLt, MDOpLe, MDOpGt, MDOpGe:
+		if _, err := strconv.ParseFloat(c.Value, 64); err != nil {
+			return fmt.Errorf("invalid metadata condition %q: %q is not a number", c.String(), c.Value)
+		}
+	default:
+		return fmt.Errorf("invalid metadata condition %q: unknown operator %q", c.String(), c.Op)
+	}
+	return nil
+}
+
+// Match returns true if the value satisfies the condition.
+func (c *MDCond) Match(value string) bool {
+	if !c.Numeric() {
+		return value == c.Value
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	bound, _ := strconv.ParseFloat(c.Value, 64)
+	switch c.Op {
+	case MDOpLt:
+		return v < bound
+	case MDOpLe:
+		return v <= bound
+	case MDOpGt:
+		return v > bound
+	default:
+		return v >= bound
+	}
+}
+
+func (c *ExtraPropsAWS) String() string {
+	if c.PathStyle {
+		return c.Endpoint + " (path-style)"
+	}
+	return c.Endpoint
+}
+
+// ValidateS3Endpoint checks S3-compatible endpoint - see ExtraPropsAWS and CloudConfAWS.
+func ValidateS3Endpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid S3 endpoint %q (expecting http(s)://host[:port])", endpoint)
+	}
+	return nil
+}
+
+func (c *DirectReadConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "Objects >= " + B2S(c.MinSize, 0)
+}
+
+func (c *DirectReadConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MinSize < 0 {
+		return fmt.Errorf("invalid direct_read.min_size: %d (expected non-negative)", c.MinSize)
+	}
+	return nil
+}
+
+// Use returns true if an object of a given size is to be read with O_DIRECT.
+func (c *DirectReadConf) Use(size int64) bool { return c.Enabled && size >= c.MinSize }
+
+func (c *EventSinkConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	return c.Type + "://" + strings.TrimPrefix(c.URL, c.Type+"://") + "/" + c.Topic
+}
+
+func (c *EventSinkConf) Enabled() bool { return c.Type != "" }
+
+func (c *EventSinkConf) ValidateAsProps(_ *ValidationArgs) error {
+	switch c.Type {
+	case "":
+		return nil
+	case EventSinkKafka, EventSinkNATS:
+	default:
+		return fmt.Errorf("invalid events.type %q (expected one of: %q, %q)", c.Type, EventSinkKafka, EventSinkNATS)
+	}
+	if c.URL == "" || c.Topic == "" {
+		return fmt.Errorf("events.url and events.topic must be specified for %q sink", c.Type)
+	}
+	return nil
+}
+
+func (c *ResidencyConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	var parts []string
+	if c.Hosts != "" {
+		parts = append(parts, "hosts: "+c.Hosts)
+	}
+	if c.Regions != "" {
+		parts = append(parts, "regions: "+c.Regions)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (c *ResidencyConf) Enabled() bool { return c.Hosts != "" || c.Regions != "" }
+
+func (c *ResidencyConf) ValidateAsProps(_ *ValidationArgs) error {
+	for _, host := range splitList(c.Hosts) {
+		if strings.ContainsAny(host, "/: ") {
+			return fmt.Errorf("invalid residency.hosts entry %q (expected host name)", host)
+		}
+	}
+	for _, region := range splitList(c.Regions) {
+		if strings.ContainsAny(region, "/: ") {
+			return fmt.Errorf("invalid residency.regions entry %q", region)
+		}
+	}
+	return nil
+}
+
+// AllowHost returns an error if the host (of an external endpoint) is not allowlisted.
+func (c *ResidencyConf) AllowHost(host string) error {
+	hosts := splitList(c.Hosts)
+	if len(hosts) == 0 {
+		return nil
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range hosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+	return NewResidencyError("host", host)
+}
+
+// AllowURL is AllowHost for the host of a given URL.
+func (c *ResidencyConf) AllowURL(link string) error {
+	if c.Hosts == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+	return c.AllowHost(u.Hostname())
+}
+
+const maxRedirects = 10 // same as net/http default
+
+// CheckRedirect is http.Client.CheckRedirect that validates every redirect hop
+// against the request's CtxResidency, if any - otherwise, an allowlisted host
+// could redirect (and the data would get fetched from) anywhere.
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if conf, ok := req.Context().Value(CtxResidency).(*ResidencyConf); ok {
+		return conf.AllowURL(req.URL.String())
+	}
+	return nil
+}
+
+// AllowRegion returns an error if the Cloud region is not allowlisted;
+// unknown (empty) region is not allowed either.
+func (c *ResidencyConf) AllowRegion(region string) error {
+	regions := splitList(c.Regions)
+	if len(regions) == 0 {
+		return nil
+	}
+	for _, allowed := range regions {
+		if strings.EqualFold(region, allowed) {
+			return nil
+		}
+	}
+	if region == "" {
+		region = "(unknown)"
+	}
+	return NewResidencyError("region", region)
+}
+
+func splitList(s string) (list []string) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return
+}
+
 func (c *ECConf) RequiredEncodeTargets() int {
 	// data slices + parity slices + 1 target for original object
 	return c.DataSlices + c.ParitySlices + 1
@@ -384,9 +1291,7 @@ func (c *ECConf) RequiredRestoreTargets() int {
 	return c.DataSlices
 }
 
-//
 // by default, bucket props inherit global config
-//
 func DefaultAISBckProps() *BucketProps {
 	c := GCO.Clone()
 	if c.Cksum.Type == "" {
@@ -444,6 +1349,7 @@ func (bp *BucketProps) Equal(other *BucketProps) (eq bool) {
 	src := *bp
 	src.BID = other.BID
 	src.Created = other.Created
+	src.PropsVersion = other.PropsVersion
 	eq = reflect.DeepEqual(&src, other)
 	return
 }
@@ -471,8 +1377,13 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 
+	if bp.Ephemeral.Enabled() && bp.Provider != ProviderAIS {
+		return fmt.Errorf("ephemeral (temporary) bucket must be an ais bucket (provider: %q)", bp.Provider)
+	}
+
 	validationArgs := &ValidationArgs{TargetCnt: targetCnt}
-	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC}
+	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Ephemeral, &bp.DirectRead,
+		&bp.Events, &bp.Residency, &bp.Lifecycle, &bp.Naming, &bp.Replication, &bp.ColdGet, &bp.MDIndex}
 	for _, validator := range validators {
 		if err := validator.ValidateAsProps(validationArgs); err != nil {
 			return err
@@ -482,6 +1393,28 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		return fmt.Errorf("cannot enable mirroring and ec at the same time for the same bucket")
 	}
+	if bp.Extra.AWS.Endpoint != "" {
+		if bp.Provider != ProviderAmazon {
+			return fmt.Errorf("S3 endpoint (extra.aws.endpoint) can only be set for %q buckets", ProviderAmazon)
+		}
+		if err := ValidateS3Endpoint(bp.Extra.AWS.Endpoint); err != nil {
+			return err
+		}
+	}
+	if bp.Replication.Enabled() && (bp.Provider != ProviderAIS || !bp.BackendBck.IsEmpty()) {
+		return fmt.Errorf("replication to a remote AIS cluster is supported only for ais buckets")
+	}
+	if bp.Dedup.Enabled {
+		switch {
+		case bp.Provider != ProviderAIS || !bp.BackendBck.IsEmpty():
+			return fmt.Errorf("dedup (content-addressed storage) is supported only for ais buckets")
+		case bp.Cksum.Type == ChecksumNone:
+			return fmt.Errorf("dedup (content-addressed storage) requires checksums")
+		case bp.Versioning.Enabled || bp.Mirror.Enabled || bp.EC.Enabled:
+			return fmt.Errorf("dedup (content-addressed storage) cannot be enabled together with " +
+				"versioning, mirroring, or ec")
+		}
+	}
 	return nil
 }
 
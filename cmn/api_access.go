@@ -43,6 +43,11 @@ const (
 		AccessPUT | AccessAPPEND | AccessDOWNLOAD | AccessObjDELETE | AccessObjRENAME
 	allowClusterAccess = allowAllAccess & (AccessBckCREATE - 1)
 
+	// operations that modify bucket's content - denied when the bucket is read-only
+	// (see BucketProps.ReadOnly)
+	AccessMutate = AccessPUT | AccessAPPEND | AccessDOWNLOAD | AccessObjDELETE | AccessObjRENAME |
+		AccessPROMOTE | AccessBckRENAME | AccessSYNC | AccessBckDELETE
+
 	// Permission Operations
 	AllowAccess = "allow"
 	DenyAccess  = "deny"
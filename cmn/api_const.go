@@ -27,37 +27,62 @@ const (
 const (
 	AppendOp = "append"
 	FlushOp  = "flush"
+
+	// multipart upload (URLParamAppendType values)
+	MptCreateOp   = "mpt-create"
+	MptPartOp     = "mpt-part"
+	MptCompleteOp = "mpt-complete"
+	MptAbortOp    = "mpt-abort"
 )
 
 // ActionMsg.Action
 // includes Xaction.Kind == ActionMsg.Action (when the action is asynchronous)
 const (
 	ActShutdown       = "shutdown"
+	ActSwitchover     = "switchover" // shadow target takes over (see aisnode -shadow)
 	ActRebalance      = "rebalance"
 	ActResilver       = "resilver"
 	ActLRU            = "lru"
+	ActLifecycle      = "lifecycle"
 	ActSyncLB         = "synclb"
 	ActCreateLB       = "createlb"
+	ActCreateLBFrom   = "createlbfrom" // create ais bucket and populate it from a source (see downloader.DlBucketSource)
 	ActDestroyLB      = "destroylb"
 	ActRenameLB       = "renamelb"
+	ActAliasBck       = "aliasbck"
+	ActUnaliasBck     = "unaliasbck"
 	ActCopyBucket     = "copybck"
 	ActETLBucket      = "etlbck"
+	ActExportBck      = "exportbck" // pack bucket into WebDataset shards
 	ActRegisterCB     = "registercb"
 	ActEvictCB        = "evictcb"
 	ActSetConfig      = "setconfig"
+	ActSetLogLevel    = "setloglevel" // adjust log verbosity at runtime (see LogLevelMsg)
 	ActSetBprops      = "setbprops"
 	ActResetBprops    = "resetbprops"
 	ActResyncBprops   = "resyncbprops"
+	ActPlanBprops     = "planbprops"
 	ActListObjects    = "listobj"
 	ActQueryObjects   = "queryobj"
 	ActInvalListCache = "invallistobjcache"
 	ActSummaryBucket  = "summarybck"
+	ActBcksExist      = "bcksexist" // check existence of (a batch of) buckets - see api.DoBucketsExist
+	ActAnalyzeBucket  = "analyzebck"
 	ActRenameObject   = "renameobj"
+	ActMoveObject     = "moveobj"
+	ActPinObject      = "pinobj"      // pin (unpin) object: LRU never evicts pinned objects
+	ActSetCustomMD    = "setcustommd" // update object's custom metadata (empty value deletes the key)
 	ActPromote        = "promote"
 	ActEvictObjects   = "evictobj"
 	ActDelete         = "delete"
+	ActUndoDelete     = "undodelete"
 	ActPrefetch       = "prefetch"
+	ActHeadObjects    = "headobjs"
 	ActDownload       = "download"
+	ActEvacuate       = "evacuate"
+	ActBackupBck      = "backup-bck"  // full backup of an ais bucket to Cloud (see xaction.BackupMsg)
+	ActRestoreBck     = "restore-bck" // recreate ais bucket from its backup
+	ActPrefill        = "prefill"     // move existing objects onto the targets that joined in prefill mode (see RebalanceConf.Prefill)
 	ActRegTarget      = "regtarget"
 	ActRegProxy       = "regproxy"
 	ActUnregTarget    = "unregtarget"
@@ -72,10 +97,13 @@ const (
 	ActECPut          = "ecput"    // erasure encode objects
 	ActECRespond      = "ecresp"   // respond to other targets' EC requests
 	ActECEncode       = "ecencode" // erasure code a bucket
+	ActRehash         = "rehash"   // recompute checksums of a bucket
+	ActMDIndex        = "mdindex"  // (re)index custom metadata of a bucket (see MDIndexConf)
 	ActStartGFN       = "metasync-start-gfn"
 	ActRecoverBck     = "recoverbck"
 	ActAttach         = "attach"
 	ActDetach         = "detach"
+	ActReplicate      = "replicate" // replicate bucket to remote AIS cluster
 	// Node maintenance
 	ActStartMaintenance = "startmaitenance" // put into maintenance state
 	ActStopMaintenance  = "stopmaintenance" // cancel maintenance state
@@ -95,8 +123,9 @@ const (
 	ActMountpathRemove  = "remove"
 
 	// Actions on xactions
-	ActXactStop  = Stop
-	ActXactStart = Start
+	ActXactStop   = Stop
+	ActXactStart  = Start
+	ActXactLimits = "limits" // adjust limits of a running job (see xaction.JobLimits)
 
 	// auxiliary
 	ActTransient = "transient" // do not save on the disk
@@ -145,6 +174,8 @@ const (
 	HeaderPutterID          = "putter.id"
 	HeaderCallerName        = "caller.name"
 	HeaderCallerSmapVersion = "caller.smap.ver"
+	HeaderGossipNodes       = "gossip.nodes" // metasync: comma-separated IDs of the nodes to relay to
+	HeaderWitnessSig        = "witness.sig"  // signed vote request (response) of the witness (see WitnessSig)
 
 	HeaderNodeID  = "node.id"
 	HeaderNodeURL = "node.url"
@@ -154,7 +185,9 @@ const (
 
 	// intra-cluster: streams
 	HeaderSessID   = "session.id"
-	HeaderCompress = "compress" // LZ4Compression, etc.
+	HeaderSessUUID = "session.uuid" // replay-enabled stream: unique across reconnects
+	HeaderSessSeq  = "session.seq"  // replay-enabled stream: last delivered sequence number
+	HeaderCompress = "compress"     // LZ4Compression, etc.
 
 	HeaderHandle = "handle"
 )
@@ -174,6 +207,10 @@ const (
 	URLParamNamespace   = "namespace"
 	URLParamPrefix      = "prefix" // prefix for list objects in a bucket
 	URLParamRegex       = "regex"  // dsort/downloader regex
+	URLParamProbe       = "probe"  // bucket to probe the Cloud provider with, e.g. "gs://bucket" (GetWhatCloudHealth)
+	URLParamNotifWait   = "wait"   // long-poll: max time to wait for the job (xaction) to finish
+	URLParamMDCond      = "md"     // custom metadata condition, e.g. "label=cat" or "width>=640" (GetWhatMDQuery)
+	URLParamLimit       = "limit"  // max number of results to return
 	// internal use
 	URLParamCheckExistsAny   = "cea" // true: lookup object in all mountpaths (NOTE: compare with URLParamCheckExists)
 	URLParamProxyID          = "pid" // ID of the redirecting proxy
@@ -190,9 +227,11 @@ const (
 	URLParamTaskAction       = "tac" // "start", "status", "result"
 	URLParamClusterInfo      = "cii" // true: Health to return ais.clusterInfo
 	URLParamRecvType         = "rtp" // to tell real PUT from migration PUT
+	URLParamBackup           = "bkp" // location of the bucket backup, e.g. "gs://bucket/prefix" (GetWhatBackupMeta)
 
 	URLParamAppendType   = "appendty"
 	URLParamAppendHandle = "handle"
+	URLParamPartNum      = "partnum" // multipart upload: part number (starting from 1)
 
 	// action (operation, transaction, task) UUID
 	URLParamUUID = "uuid"
@@ -211,6 +250,16 @@ const (
 
 	// HTTP bucket support
 	URLParamOrigURL = "origurl"
+
+	// bulk delete: keep deleted objects in trash for the specified duration (see ActUndoDelete)
+	URLParamUndoWindow = "undo_window"
+
+	// GET: restore EC-protected object from slices if its main target is down (see api.GetObjectInput)
+	URLParamECRestore = "ec_restore"
+
+	// PATCH bucket props: fail with http.StatusConflict unless the current
+	// props version is the one specified (see BucketProps.PropsVersion)
+	URLParamBpropsVersion = "props_version"
 )
 
 // enum: task action (cmn.URLParamTaskAction)
@@ -238,6 +287,18 @@ const (
 	GetWhatStatus       = "status"    // JTX status by uuid
 	GetWhatICBundle     = "ic-bundle"
 	GetWhatTargetIPs    = "target_ips"
+	GetWhatClientStats  = "client_stats" // HTTP clients' connection pool utilization
+	GetWhatAlerts       = "alerts"       // firing alerts (target only)
+	GetWhatMemsys       = "memsys"       // memory manager stats (see memsys.Stats)
+	GetWhatElections    = "elections"    // recent primary elections as seen by the node (see ElectionEvent)
+	GetWhatCloudHealth  = "cloud_health" // connectivity and credentials of the Cloud providers (see CloudHealth)
+	GetWhatCapForecast  = "cap_forecast" // capacity usage forecast (see CapForecast)
+	GetWhatPingMatrix   = "ping_matrix"  // node-to-node round-trip latencies (see PingMatrix)
+	GetWhatReplication  = "replication"  // bucket replication status (see ClusterReplStatus)
+	GetWhatBackupMeta   = "backup_meta"  // metadata of the bucket backup (target only, see xaction.BackupMeta)
+	GetWhatMDQuery      = "md_query"     // names of the objects that match custom metadata conditions (see MDIndexConf)
+	GetWhatPrefill      = "prefill"      // progress of prefilling newly joined targets (see ClusterPrefillStatus)
+	GetWhatUndoBck      = "undo_bck"     // bucket of the objects deleted with a given undo token (target only)
 )
 
 // SelectMsg.TimeFormat enum
@@ -305,7 +366,8 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	Query     = "query"
-	IC        = "ic" // information center
+	IC        = "ic"   // information center
+	Spec      = "spec" // OpenAPI spec of the native API
 
 	// l3
 	SyncSmap     = "syncsmap"
@@ -316,6 +378,7 @@ const (
 	Proxy        = "proxy"
 	Voteres      = "result"
 	VoteInit     = "init"
+	VotePre      = "prevote" // pre-vote: would the node support the election (see cmn.ElectionEvent)
 	Mountpaths   = "mountpaths"
 	AllBuckets   = "*"
 
@@ -328,7 +391,7 @@ const (
 	Finished = "finished"
 	Progress = "progress"
 
-	// dSort, downloader, query
+	// dSort, downloader, query, Prometheus (GET /metrics)
 	Metrics     = "metrics"
 	Records     = "records"
 	Shards      = "shards"
@@ -338,7 +401,9 @@ const (
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
-	WorkerOwner = "worker" // TODO: it should be removed once get-next-bytes endpoint is ready
+	WorkerOwner = "worker"     // TODO: it should be removed once get-next-bytes endpoint is ready
+	Plan        = "plan"       // dSort: planning (dry-run) mode
+	Unschedule  = "unschedule" // downloader: the schedule of the job has been canceled
 
 	// CLI
 	Target = "target"
@@ -347,13 +412,14 @@ const (
 	GetTargetObjects = "objects"
 
 	// ETL
-	ETL       = "etl"
-	ETLInit   = Init
-	ETLBuild  = "build"
-	ETLList   = List
-	ETLLogs   = "logs"
-	ETLObject = "object"
-	ETLStop   = Stop
+	ETL         = "etl"
+	ETLInit     = Init
+	ETLBuild    = "build"
+	ETLValidate = "validate"
+	ETLList     = List
+	ETLLogs     = "logs"
+	ETLObject   = "object"
+	ETLStop     = Stop
 )
 
 // enum: compression
@@ -0,0 +1,336 @@
+// Code generated by cmd/specgen from api_const.go; DO NOT EDIT.
+
+package cmn
+
+// APIConstGroups lists all string constants of the native API grouped
+// (and documented) as they are defined in the source.
+var APIConstGroups = []APIConstGroup{
+	{
+		Doc: "checksums",
+		Consts: []APIConst{
+			{Name: "ChecksumNone", Value: ChecksumNone, Doc: ""},
+			{Name: "ChecksumXXHash", Value: ChecksumXXHash, Doc: ""},
+			{Name: "ChecksumMD5", Value: ChecksumMD5, Doc: ""},
+			{Name: "ChecksumCRC32C", Value: ChecksumCRC32C, Doc: ""},
+			{Name: "ChecksumSHA256", Value: ChecksumSHA256, Doc: "crypto.SHA512_256 (SHA-2)"},
+			{Name: "ChecksumSHA512", Value: ChecksumSHA512, Doc: "crypto.SHA512 (SHA-2)"},
+		},
+	},
+	{
+		Doc: "module names",
+		Consts: []APIConst{
+			{Name: "DSortName", Value: DSortName, Doc: ""},
+			{Name: "DSortNameLowercase", Value: DSortNameLowercase, Doc: ""},
+		},
+	},
+	{
+		Doc: "ActionMsg.Action includes Xaction.Kind == ActionMsg.Action (when the action is asynchronous)",
+		Consts: []APIConst{
+			{Name: "ActShutdown", Value: ActShutdown, Doc: ""},
+			{Name: "ActSwitchover", Value: ActSwitchover, Doc: "shadow target takes over (see aisnode -shadow)"},
+			{Name: "ActRebalance", Value: ActRebalance, Doc: ""},
+			{Name: "ActResilver", Value: ActResilver, Doc: ""},
+			{Name: "ActLRU", Value: ActLRU, Doc: ""},
+			{Name: "ActLifecycle", Value: ActLifecycle, Doc: ""},
+			{Name: "ActSyncLB", Value: ActSyncLB, Doc: ""},
+			{Name: "ActCreateLB", Value: ActCreateLB, Doc: ""},
+			{Name: "ActCreateLBFrom", Value: ActCreateLBFrom, Doc: "create ais bucket and populate it from a source (see downloader.DlBucketSource)"},
+			{Name: "ActDestroyLB", Value: ActDestroyLB, Doc: ""},
+			{Name: "ActRenameLB", Value: ActRenameLB, Doc: ""},
+			{Name: "ActAliasBck", Value: ActAliasBck, Doc: ""},
+			{Name: "ActUnaliasBck", Value: ActUnaliasBck, Doc: ""},
+			{Name: "ActCopyBucket", Value: ActCopyBucket, Doc: ""},
+			{Name: "ActETLBucket", Value: ActETLBucket, Doc: ""},
+			{Name: "ActExportBck", Value: ActExportBck, Doc: "pack bucket into WebDataset shards"},
+			{Name: "ActRegisterCB", Value: ActRegisterCB, Doc: ""},
+			{Name: "ActEvictCB", Value: ActEvictCB, Doc: ""},
+			{Name: "ActSetConfig", Value: ActSetConfig, Doc: ""},
+			{Name: "ActSetLogLevel", Value: ActSetLogLevel, Doc: "adjust log verbosity at runtime (see LogLevelMsg)"},
+			{Name: "ActSetBprops", Value: ActSetBprops, Doc: ""},
+			{Name: "ActResetBprops", Value: ActResetBprops, Doc: ""},
+			{Name: "ActResyncBprops", Value: ActResyncBprops, Doc: ""},
+			{Name: "ActPlanBprops", Value: ActPlanBprops, Doc: ""},
+			{Name: "ActListObjects", Value: ActListObjects, Doc: ""},
+			{Name: "ActQueryObjects", Value: ActQueryObjects, Doc: ""},
+			{Name: "ActInvalListCache", Value: ActInvalListCache, Doc: ""},
+			{Name: "ActSummaryBucket", Value: ActSummaryBucket, Doc: ""},
+			{Name: "ActBcksExist", Value: ActBcksExist, Doc: "check existence of (a batch of) buckets - see api.DoBucketsExist"},
+			{Name: "ActAnalyzeBucket", Value: ActAnalyzeBucket, Doc: ""},
+			{Name: "ActRenameObject", Value: ActRenameObject, Doc: ""},
+			{Name: "ActMoveObject", Value: ActMoveObject, Doc: ""},
+			{Name: "ActPinObject", Value: ActPinObject, Doc: "pin (unpin) object: LRU never evicts pinned objects"},
+			{Name: "ActSetCustomMD", Value: ActSetCustomMD, Doc: "update object's custom metadata (empty value deletes the key)"},
+			{Name: "ActPromote", Value: ActPromote, Doc: ""},
+			{Name: "ActEvictObjects", Value: ActEvictObjects, Doc: ""},
+			{Name: "ActDelete", Value: ActDelete, Doc: ""},
+			{Name: "ActUndoDelete", Value: ActUndoDelete, Doc: ""},
+			{Name: "ActPrefetch", Value: ActPrefetch, Doc: ""},
+			{Name: "ActHeadObjects", Value: ActHeadObjects, Doc: ""},
+			{Name: "ActDownload", Value: ActDownload, Doc: ""},
+			{Name: "ActEvacuate", Value: ActEvacuate, Doc: ""},
+			{Name: "ActBackupBck", Value: ActBackupBck, Doc: "full backup of an ais bucket to Cloud (see xaction.BackupMsg)"},
+			{Name: "ActRestoreBck", Value: ActRestoreBck, Doc: "recreate ais bucket from its backup"},
+			{Name: "ActPrefill", Value: ActPrefill, Doc: "move existing objects onto the targets that joined in prefill mode (see RebalanceConf.Prefill)"},
+			{Name: "ActRegTarget", Value: ActRegTarget, Doc: ""},
+			{Name: "ActRegProxy", Value: ActRegProxy, Doc: ""},
+			{Name: "ActUnregTarget", Value: ActUnregTarget, Doc: ""},
+			{Name: "ActUnregProxy", Value: ActUnregProxy, Doc: ""},
+			{Name: "ActNewPrimary", Value: ActNewPrimary, Doc: ""},
+			{Name: "ActRevokeToken", Value: ActRevokeToken, Doc: ""},
+			{Name: "ActElection", Value: ActElection, Doc: ""},
+			{Name: "ActPutCopies", Value: ActPutCopies, Doc: ""},
+			{Name: "ActMakeNCopies", Value: ActMakeNCopies, Doc: ""},
+			{Name: "ActLoadLomCache", Value: ActLoadLomCache, Doc: ""},
+			{Name: "ActECGet", Value: ActECGet, Doc: "erasure decode objects"},
+			{Name: "ActECPut", Value: ActECPut, Doc: "erasure encode objects"},
+			{Name: "ActECRespond", Value: ActECRespond, Doc: "respond to other targets' EC requests"},
+			{Name: "ActECEncode", Value: ActECEncode, Doc: "erasure code a bucket"},
+			{Name: "ActRehash", Value: ActRehash, Doc: "recompute checksums of a bucket"},
+			{Name: "ActMDIndex", Value: ActMDIndex, Doc: "(re)index custom metadata of a bucket (see MDIndexConf)"},
+			{Name: "ActStartGFN", Value: ActStartGFN, Doc: ""},
+			{Name: "ActRecoverBck", Value: ActRecoverBck, Doc: ""},
+			{Name: "ActAttach", Value: ActAttach, Doc: ""},
+			{Name: "ActDetach", Value: ActDetach, Doc: ""},
+			{Name: "ActReplicate", Value: ActReplicate, Doc: "replicate bucket to remote AIS cluster"},
+			{Name: "ActStartMaintenance", Value: ActStartMaintenance, Doc: "put into maintenance state"},
+			{Name: "ActStopMaintenance", Value: ActStopMaintenance, Doc: "cancel maintenance state"},
+			{Name: "ActDecommission", Value: ActDecommission, Doc: "start rebalance and remove node from Smap when it finishes"},
+			{Name: "ActSendOwnershipTbl", Value: ActSendOwnershipTbl, Doc: "IC"},
+			{Name: "ActListenToNotif", Value: ActListenToNotif, Doc: ""},
+			{Name: "ActMergeOwnershipTbl", Value: ActMergeOwnershipTbl, Doc: ""},
+			{Name: "ActRegGlobalXaction", Value: ActRegGlobalXaction, Doc: ""},
+		},
+	},
+	{
+		Doc: "xaction begin-commit phases",
+		Consts: []APIConst{
+			{Name: "ActBegin", Value: ActBegin, Doc: ""},
+			{Name: "ActCommit", Value: ActCommit, Doc: ""},
+			{Name: "ActAbort", Value: ActAbort, Doc: ""},
+		},
+	},
+	{
+		Doc: "Header Key enum - conventions: - the constant equals the path of a value in BucketProps structure - if a property is a root one, then the constant is just a lowercased property name - if a property is nested, then its value is property's parent and property name separated with a dot",
+		Consts: []APIConst{
+			{Name: "HeaderBackendBck", Value: HeaderBackendBck, Doc: ""},
+			{Name: "HeaderOrigURLBck", Value: HeaderOrigURLBck, Doc: "see BucketProps.OrigURLBck"},
+			{Name: "HeaderCloudRegion", Value: HeaderCloudRegion, Doc: "see BucketProps.CloudRegion"},
+			{Name: "HeaderCloudProvider", Value: HeaderCloudProvider, Doc: "ProviderAmazon et al. - see cmn/bucket.go"},
+			{Name: "HeaderCloudOffline", Value: HeaderCloudOffline, Doc: "when accessing cached cloud bucket with no Cloud connectivity"},
+			{Name: "HeaderRemoteAisOffline", Value: HeaderRemoteAisOffline, Doc: "when accessing cached cloud bucket with no Cloud connectivity"},
+			{Name: "HeaderBucketVerEnabled", Value: HeaderBucketVerEnabled, Doc: "Enable/disable object versioning in a bucket"},
+			{Name: "HeaderBucketVerValidateWarm", Value: HeaderBucketVerValidateWarm, Doc: "Validate version on warm GET"},
+			{Name: "HeaderBucketAccessAttrs", Value: HeaderBucketAccessAttrs, Doc: "Bucket access attributes"},
+			{Name: "HeaderBucketCreated", Value: HeaderBucketCreated, Doc: "Bucket creation time"},
+			{Name: "HeaderObjCksumType", Value: HeaderObjCksumType, Doc: "Checksum Type, one of SupportedChecksums()"},
+			{Name: "HeaderObjCksumVal", Value: HeaderObjCksumVal, Doc: "Checksum Value"},
+			{Name: "HeaderObjAtime", Value: HeaderObjAtime, Doc: "Object access time"},
+			{Name: "HeaderObjCustomMD", Value: HeaderObjCustomMD, Doc: "Object custom metadata"},
+			{Name: "HeaderObjSize", Value: HeaderObjSize, Doc: "Object size (bytes)"},
+			{Name: "HeaderObjVersion", Value: HeaderObjVersion, Doc: "Object version/generation - ais or Cloud"},
+			{Name: "HeaderObjECMeta", Value: HeaderObjECMeta, Doc: "Info about EC object/slice/replica"},
+			{Name: "HeaderCallerID", Value: HeaderCallerID, Doc: "it is a marker of intra-cluster request (see cmn.IsInternalReq)"},
+			{Name: "HeaderPutterID", Value: HeaderPutterID, Doc: ""},
+			{Name: "HeaderCallerName", Value: HeaderCallerName, Doc: ""},
+			{Name: "HeaderCallerSmapVersion", Value: HeaderCallerSmapVersion, Doc: ""},
+			{Name: "HeaderGossipNodes", Value: HeaderGossipNodes, Doc: "metasync: comma-separated IDs of the nodes to relay to"},
+			{Name: "HeaderWitnessSig", Value: HeaderWitnessSig, Doc: "signed vote request (response) of the witness (see WitnessSig)"},
+			{Name: "HeaderNodeID", Value: HeaderNodeID, Doc: ""},
+			{Name: "HeaderNodeURL", Value: HeaderNodeURL, Doc: ""},
+			{Name: "HeaderAppendHandle", Value: HeaderAppendHandle, Doc: "custom"},
+			{Name: "HeaderSessID", Value: HeaderSessID, Doc: "intra-cluster: streams"},
+			{Name: "HeaderSessUUID", Value: HeaderSessUUID, Doc: "replay-enabled stream: unique across reconnects"},
+			{Name: "HeaderSessSeq", Value: HeaderSessSeq, Doc: "replay-enabled stream: last delivered sequence number"},
+			{Name: "HeaderCompress", Value: HeaderCompress, Doc: "LZ4Compression, etc."},
+			{Name: "HeaderHandle", Value: HeaderHandle, Doc: ""},
+		},
+	},
+	{
+		Doc: "supported compressions (alg-s)",
+		Consts: []APIConst{
+			{Name: "LZ4Compression", Value: LZ4Compression, Doc: ""},
+		},
+	},
+	{
+		Doc: "URL Query \"?name1=val1&name2=...\"",
+		Consts: []APIConst{
+			{Name: "URLParamWhat", Value: URLParamWhat, Doc: "\"smap\" | \"bmd\" | \"config\" | \"stats\" | \"xaction\" ..."},
+			{Name: "URLParamProps", Value: URLParamProps, Doc: "e.g. \"checksum, size\"|\"atime, size\"|\"cached\"|\"bucket, size\"| ..."},
+			{Name: "URLParamCheckExists", Value: URLParamCheckExists, Doc: "true: check if object exists"},
+			{Name: "URLParamProvider", Value: URLParamProvider, Doc: "cloud provider"},
+			{Name: "URLParamNamespace", Value: URLParamNamespace, Doc: ""},
+			{Name: "URLParamPrefix", Value: URLParamPrefix, Doc: "prefix for list objects in a bucket"},
+			{Name: "URLParamRegex", Value: URLParamRegex, Doc: "dsort/downloader regex"},
+			{Name: "URLParamProbe", Value: URLParamProbe, Doc: "bucket to probe the Cloud provider with, e.g. \"gs://bucket\" (GetWhatCloudHealth)"},
+			{Name: "URLParamNotifWait", Value: URLParamNotifWait, Doc: "long-poll: max time to wait for the job (xaction) to finish"},
+			{Name: "URLParamMDCond", Value: URLParamMDCond, Doc: "custom metadata condition, e.g. \"label=cat\" or \"width>=640\" (GetWhatMDQuery)"},
+			{Name: "URLParamLimit", Value: URLParamLimit, Doc: "max number of results to return"},
+			{Name: "URLParamCheckExistsAny", Value: URLParamCheckExistsAny, Doc: "true: lookup object in all mountpaths (NOTE: compare with URLParamCheckExists)"},
+			{Name: "URLParamProxyID", Value: URLParamProxyID, Doc: "ID of the redirecting proxy"},
+			{Name: "URLParamTargetID", Value: URLParamTargetID, Doc: "target (daemon) ID"},
+			{Name: "URLParamPrimaryCandidate", Value: URLParamPrimaryCandidate, Doc: "ID of the candidate for the primary proxy"},
+			{Name: "URLParamForce", Value: URLParamForce, Doc: "true: force the operation (e.g., shutdown primary and the entire cluster)"},
+			{Name: "URLParamPrepare", Value: URLParamPrepare, Doc: "true: request belongs to the \"prepare\" phase of the primary proxy election"},
+			{Name: "URLParamNonElectable", Value: URLParamNonElectable, Doc: "true: proxy is non-electable for the primary role"},
+			{Name: "URLParamUnixTime", Value: URLParamUnixTime, Doc: "Unix time: number of nanoseconds elapsed since 01/01/70 UTC"},
+			{Name: "URLParamIsGFNRequest", Value: URLParamIsGFNRequest, Doc: "true if the request is a Get-From-Neighbor"},
+			{Name: "URLParamSilent", Value: URLParamSilent, Doc: "true: destination should not log errors (HEAD request)"},
+			{Name: "URLParamRebStatus", Value: URLParamRebStatus, Doc: "true: get detailed rebalancing status"},
+			{Name: "URLParamRebData", Value: URLParamRebData, Doc: "true: get EC rebalance data (pulling data if push way fails)"},
+			{Name: "URLParamTaskAction", Value: URLParamTaskAction, Doc: "\"start\", \"status\", \"result\""},
+			{Name: "URLParamClusterInfo", Value: URLParamClusterInfo, Doc: "true: Health to return ais.clusterInfo"},
+			{Name: "URLParamRecvType", Value: URLParamRecvType, Doc: "to tell real PUT from migration PUT"},
+			{Name: "URLParamBackup", Value: URLParamBackup, Doc: "location of the bucket backup, e.g. \"gs://bucket/prefix\" (GetWhatBackupMeta)"},
+			{Name: "URLParamAppendType", Value: URLParamAppendType, Doc: ""},
+			{Name: "URLParamAppendHandle", Value: URLParamAppendHandle, Doc: ""},
+			{Name: "URLParamPartNum", Value: URLParamPartNum, Doc: "multipart upload: part number (starting from 1)"},
+			{Name: "URLParamUUID", Value: URLParamUUID, Doc: "action (operation, transaction, task) UUID"},
+			{Name: "URLParamTotalCompressedSize", Value: URLParamTotalCompressedSize, Doc: "dsort"},
+			{Name: "URLParamTotalInputShardsExtracted", Value: URLParamTotalInputShardsExtracted, Doc: ""},
+			{Name: "URLParamTotalUncompressedSize", Value: URLParamTotalUncompressedSize, Doc: ""},
+			{Name: "URLParamTxnTimeout", Value: URLParamTxnTimeout, Doc: "transaction timeout"},
+			{Name: "URLParamWaitMetasync", Value: URLParamWaitMetasync, Doc: "wait for metasync (used only when there's an alternative)"},
+			{Name: "URLParamNotifyMe", Value: URLParamNotifyMe, Doc: "notification target's node ID (usually, the node that initiates the operation)"},
+			{Name: "URLParamOrigURL", Value: URLParamOrigURL, Doc: "HTTP bucket support"},
+			{Name: "URLParamUndoWindow", Value: URLParamUndoWindow, Doc: "bulk delete: keep deleted objects in trash for the specified duration (see ActUndoDelete)"},
+			{Name: "URLParamECRestore", Value: URLParamECRestore, Doc: "GET: restore EC-protected object from slices if its main target is down (see api.GetObjectInput)"},
+			{Name: "URLParamBpropsVersion", Value: URLParamBpropsVersion, Doc: "PATCH bucket props: fail with http.StatusConflict unless the current props version is the one specified (see BucketProps.PropsVersion)"},
+		},
+	},
+	{
+		Doc: "enum: task action (cmn.URLParamTaskAction)",
+		Consts: []APIConst{
+			{Name: "TaskStatus", Value: TaskStatus, Doc: ""},
+			{Name: "TaskResult", Value: TaskResult, Doc: ""},
+		},
+	},
+	{
+		Doc: "URLParamWhat enum",
+		Consts: []APIConst{
+			{Name: "GetWhatConfig", Value: GetWhatConfig, Doc: ""},
+			{Name: "GetWhatSmap", Value: GetWhatSmap, Doc: ""},
+			{Name: "GetWhatBMD", Value: GetWhatBMD, Doc: ""},
+			{Name: "GetWhatStats", Value: GetWhatStats, Doc: ""},
+			{Name: "GetWhatSmapVote", Value: GetWhatSmapVote, Doc: ""},
+			{Name: "GetWhatMountpaths", Value: GetWhatMountpaths, Doc: ""},
+			{Name: "GetWhatSnode", Value: GetWhatSnode, Doc: ""},
+			{Name: "GetWhatSysInfo", Value: GetWhatSysInfo, Doc: ""},
+			{Name: "GetWhatDiskStats", Value: GetWhatDiskStats, Doc: ""},
+			{Name: "GetWhatDaemonStatus", Value: GetWhatDaemonStatus, Doc: ""},
+			{Name: "GetWhatRemoteAIS", Value: GetWhatRemoteAIS, Doc: ""},
+			{Name: "GetWhatXactStats", Value: GetWhatXactStats, Doc: "stats(xaction-by-uuid)"},
+			{Name: "QueryXactStats", Value: QueryXactStats, Doc: "stats(all-matching-xactions)"},
+			{Name: "GetWhatStatus", Value: GetWhatStatus, Doc: "JTX status by uuid"},
+			{Name: "GetWhatICBundle", Value: GetWhatICBundle, Doc: ""},
+			{Name: "GetWhatTargetIPs", Value: GetWhatTargetIPs, Doc: ""},
+			{Name: "GetWhatClientStats", Value: GetWhatClientStats, Doc: "HTTP clients' connection pool utilization"},
+			{Name: "GetWhatAlerts", Value: GetWhatAlerts, Doc: "firing alerts (target only)"},
+			{Name: "GetWhatMemsys", Value: GetWhatMemsys, Doc: "memory manager stats (see memsys.Stats)"},
+			{Name: "GetWhatElections", Value: GetWhatElections, Doc: "recent primary elections as seen by the node (see ElectionEvent)"},
+			{Name: "GetWhatCloudHealth", Value: GetWhatCloudHealth, Doc: "connectivity and credentials of the Cloud providers (see CloudHealth)"},
+			{Name: "GetWhatCapForecast", Value: GetWhatCapForecast, Doc: "capacity usage forecast (see CapForecast)"},
+			{Name: "GetWhatPingMatrix", Value: GetWhatPingMatrix, Doc: "node-to-node round-trip latencies (see PingMatrix)"},
+			{Name: "GetWhatReplication", Value: GetWhatReplication, Doc: "bucket replication status (see ClusterReplStatus)"},
+			{Name: "GetWhatBackupMeta", Value: GetWhatBackupMeta, Doc: "metadata of the bucket backup (target only, see xaction.BackupMeta)"},
+			{Name: "GetWhatMDQuery", Value: GetWhatMDQuery, Doc: "names of the objects that match custom metadata conditions (see MDIndexConf)"},
+			{Name: "GetWhatPrefill", Value: GetWhatPrefill, Doc: "progress of prefilling newly joined targets (see ClusterPrefillStatus)"},
+			{Name: "GetWhatUndoBck", Value: GetWhatUndoBck, Doc: "bucket of the objects deleted with a given undo token (target only)"},
+		},
+	},
+	{
+		Doc: "SelectMsg.Props enum DO NOT forget update `GetPropsAll` constant when a prop is added/removed",
+		Consts: []APIConst{
+			{Name: "GetPropsName", Value: GetPropsName, Doc: ""},
+			{Name: "GetPropsSize", Value: GetPropsSize, Doc: ""},
+			{Name: "GetPropsVersion", Value: GetPropsVersion, Doc: ""},
+			{Name: "GetPropsChecksum", Value: GetPropsChecksum, Doc: ""},
+			{Name: "GetPropsAtime", Value: GetPropsAtime, Doc: ""},
+			{Name: "GetPropsCached", Value: GetPropsCached, Doc: ""},
+			{Name: "GetTargetURL", Value: GetTargetURL, Doc: ""},
+			{Name: "GetPropsStatus", Value: GetPropsStatus, Doc: ""},
+			{Name: "GetPropsCopies", Value: GetPropsCopies, Doc: ""},
+			{Name: "GetPropsEC", Value: GetPropsEC, Doc: ""},
+		},
+	},
+	{
+		Doc: "RESTful URL path: l1/l2/l3",
+		Consts: []APIConst{
+			{Name: "Version", Value: Version, Doc: "l1"},
+			{Name: "Buckets", Value: Buckets, Doc: "l2"},
+			{Name: "Objects", Value: Objects, Doc: ""},
+			{Name: "EC", Value: EC, Doc: ""},
+			{Name: "Download", Value: Download, Doc: ""},
+			{Name: "Daemon", Value: Daemon, Doc: ""},
+			{Name: "Cluster", Value: Cluster, Doc: ""},
+			{Name: "Tokens", Value: Tokens, Doc: ""},
+			{Name: "Metasync", Value: Metasync, Doc: ""},
+			{Name: "Health", Value: Health, Doc: ""},
+			{Name: "Vote", Value: Vote, Doc: ""},
+			{Name: "Transport", Value: Transport, Doc: ""},
+			{Name: "Reverse", Value: Reverse, Doc: ""},
+			{Name: "Rebalance", Value: Rebalance, Doc: ""},
+			{Name: "Xactions", Value: Xactions, Doc: ""},
+			{Name: "S3", Value: S3, Doc: ""},
+			{Name: "Txn", Value: Txn, Doc: "2PC"},
+			{Name: "Notifs", Value: Notifs, Doc: "intra-cluster notifications"},
+			{Name: "Users", Value: Users, Doc: "AuthN"},
+			{Name: "Clusters", Value: Clusters, Doc: "AuthN"},
+			{Name: "Roles", Value: Roles, Doc: "AuthN"},
+			{Name: "Query", Value: Query, Doc: ""},
+			{Name: "IC", Value: IC, Doc: "information center"},
+			{Name: "Spec", Value: Spec, Doc: "OpenAPI spec of the native API"},
+			{Name: "SyncSmap", Value: SyncSmap, Doc: "l3"},
+			{Name: "Keepalive", Value: Keepalive, Doc: ""},
+			{Name: "UserRegister", Value: UserRegister, Doc: "node register by admin (manual)"},
+			{Name: "AutoRegister", Value: AutoRegister, Doc: "node register itself into the primary proxy (automatic)"},
+			{Name: "Unregister", Value: Unregister, Doc: ""},
+			{Name: "Proxy", Value: Proxy, Doc: ""},
+			{Name: "Voteres", Value: Voteres, Doc: ""},
+			{Name: "VoteInit", Value: VoteInit, Doc: ""},
+			{Name: "VotePre", Value: VotePre, Doc: "pre-vote: would the node support the election (see cmn.ElectionEvent)"},
+			{Name: "Mountpaths", Value: Mountpaths, Doc: ""},
+			{Name: "AllBuckets", Value: AllBuckets, Doc: ""},
+			{Name: "Init", Value: Init, Doc: "common"},
+			{Name: "Start", Value: Start, Doc: ""},
+			{Name: "Stop", Value: Stop, Doc: ""},
+			{Name: "Abort", Value: Abort, Doc: ""},
+			{Name: "Sort", Value: Sort, Doc: ""},
+			{Name: "Finished", Value: Finished, Doc: ""},
+			{Name: "Progress", Value: Progress, Doc: ""},
+			{Name: "Metrics", Value: Metrics, Doc: "dSort, downloader, query, Prometheus (GET /metrics)"},
+			{Name: "Records", Value: Records, Doc: ""},
+			{Name: "Shards", Value: Shards, Doc: ""},
+			{Name: "FinishedAck", Value: FinishedAck, Doc: ""},
+			{Name: "List", Value: List, Doc: ""},
+			{Name: "Remove", Value: Remove, Doc: ""},
+			{Name: "Next", Value: Next, Doc: ""},
+			{Name: "Peek", Value: Peek, Doc: ""},
+			{Name: "Discard", Value: Discard, Doc: ""},
+			{Name: "WorkerOwner", Value: WorkerOwner, Doc: "TODO: it should be removed once get-next-bytes endpoint is ready"},
+			{Name: "Plan", Value: Plan, Doc: "dSort: planning (dry-run) mode"},
+			{Name: "Unschedule", Value: Unschedule, Doc: "downloader: the schedule of the job has been canceled"},
+			{Name: "Target", Value: Target, Doc: "CLI"},
+			{Name: "GetTargetObjects", Value: GetTargetObjects, Doc: "tar2tf"},
+			{Name: "ETL", Value: ETL, Doc: "ETL"},
+			{Name: "ETLBuild", Value: ETLBuild, Doc: ""},
+			{Name: "ETLValidate", Value: ETLValidate, Doc: ""},
+			{Name: "ETLLogs", Value: ETLLogs, Doc: ""},
+			{Name: "ETLObject", Value: ETLObject, Doc: ""},
+		},
+	},
+	{
+		Doc: "enum: compression",
+		Consts: []APIConst{
+			{Name: "CompressAlways", Value: CompressAlways, Doc: ""},
+			{Name: "CompressNever", Value: CompressNever, Doc: ""},
+			{Name: "CompressRatio", Value: CompressRatio, Doc: "adaptive: min ratio that warrants compression"},
+		},
+	},
+	{
+		Doc: "AuthN consts",
+		Consts: []APIConst{
+			{Name: "HeaderAuthorization", Value: HeaderAuthorization, Doc: ""},
+			{Name: "HeaderBearer", Value: HeaderBearer, Doc: ""},
+		},
+	},
+}
@@ -0,0 +1,37 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import "strings"
+
+//go:generate go run ../cmd/specgen -in api_const.go -out api_const_gen.go
+
+// APIConst and APIConstGroup describe the constants defined in api_const.go
+// (see APIConstGroups in the generated api_const_gen.go). The latter is used
+// to build the OpenAPI spec of the native API - run `go generate ./cmn`
+// after adding or modifying the constants.
+type (
+	APIConst struct {
+		Name  string
+		Value string
+		Doc   string
+	}
+	APIConstGroup struct {
+		Doc    string
+		Consts []APIConst
+	}
+)
+
+// APIConstsByPrefix returns all generated constants with names starting with a given prefix.
+func APIConstsByPrefix(prefix string) (consts []APIConst) {
+	for _, group := range APIConstGroups {
+		for _, c := range group.Consts {
+			if strings.HasPrefix(c.Name, prefix) {
+				consts = append(consts, c)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,166 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Planning of heavy bucket props changes (ActPlanBprops)
+//
+// Enabling erasure coding, adding mirror copies, and changing checksum type
+// make the cluster read (and possibly write) the entire bucket. Prior to
+// committing such a change, each target estimates its own share of the work -
+// bytes to read and write, time at its current disk throughput, and temporary
+// capacity needed - from the (fast) size of the bucket and its capacity usage.
+// The proxy merges the estimates into a single plan; the change itself is then
+// refused unless all targets have enough headroom below the high watermark
+// (see LRUConf.HighWM) or the caller forces it (URLParamForce).
+
+const (
+	BpropsChangeEC       = "ec"
+	BpropsChangeMirror   = "mirror"
+	BpropsChangeChecksum = "checksum"
+)
+
+type (
+	// BpropsTargetStats is the input of the target's estimate.
+	BpropsTargetStats struct {
+		ObjCount   uint64 // number of objects (not counting copies)
+		Size       uint64 // size of the objects (ditto)
+		Used       uint64 // used capacity of all mountpaths
+		Total      uint64 // total capacity of all mountpaths
+		HighWM     int64  // see LRUConf.HighWM
+		Throughput uint64 // current disk throughput (bytes/s) - reads and writes combined
+	}
+	// BpropsEstimate is the estimated cost of changing bucket props on a given
+	// target or, when merged, in the entire cluster.
+	BpropsEstimate struct {
+		ObjCount   uint64        `json:"obj_count,string"`
+		Size       uint64        `json:"size,string"`
+		ReadBytes  uint64        `json:"read,string"`     // bytes to read
+		WriteBytes uint64        `json:"write,string"`    // bytes to write (incl. sending to other targets)
+		Capacity   uint64        `json:"capacity,string"` // additional capacity needed
+		Headroom   uint64        `json:"headroom,string"` // capacity available below the high watermark
+		Throughput uint64        `json:"throughput,string"`
+		Time       time.Duration `json:"time"` // zero when unknown (no current throughput)
+		Sufficient bool          `json:"sufficient"`
+	}
+	// BpropsPlan is the result of ActPlanBprops.
+	BpropsPlan struct {
+		Bck     Bck                        `json:"bck"`
+		Changes []string                   `json:"changes"` // see BpropsChange* enum; empty when nothing's heavy
+		Total   BpropsEstimate             `json:"total"`
+		Targets map[string]*BpropsEstimate `json:"targets"` // by target ID
+	}
+)
+
+// BpropsChanges returns the heavy changes (if any) between the current and
+// the new bucket props.
+func BpropsChanges(from, to *BucketProps) (changes []string) {
+	if to.EC.Enabled && (!from.EC.Enabled ||
+		from.EC.DataSlices != to.EC.DataSlices || from.EC.ParitySlices != to.EC.ParitySlices) {
+		changes = append(changes, BpropsChangeEC)
+	}
+	if mirrorCopies(to) > mirrorCopies(from) {
+		changes = append(changes, BpropsChangeMirror)
+	}
+	if to.Cksum.Type != from.Cksum.Type && to.Cksum.Type != ChecksumNone {
+		changes = append(changes, BpropsChangeChecksum)
+	}
+	return
+}
+
+func mirrorCopies(p *BucketProps) uint64 {
+	if !p.Mirror.Enabled || p.Mirror.Copies < 1 {
+		return 1
+	}
+	return uint64(p.Mirror.Copies)
+}
+
+// Estimate computes the target's share of the work:
+// - EC: read all objects; write (and send to other targets) P replicas of
+//   small objects or D+P slices of the D-th part of large ones
+// - mirror: read all objects; write the added copies
+// - checksum: read all objects to recompute their checksums
+// Object sizes are not known individually, so the average size decides
+// between replicating and slicing.
+func (ts *BpropsTargetStats) Estimate(from, to *BucketProps) *BpropsEstimate {
+	est := &BpropsEstimate{ObjCount: ts.ObjCount, Size: ts.Size, Throughput: ts.Throughput}
+	for _, change := range BpropsChanges(from, to) {
+		est.ReadBytes = ts.Size
+		switch change {
+		case BpropsChangeEC:
+			var extra uint64
+			if ts.ObjCount > 0 && int64(ts.Size/ts.ObjCount) < to.EC.ObjSizeLimit {
+				extra = ts.Size * uint64(to.EC.ParitySlices)
+			} else if to.EC.DataSlices > 0 {
+				extra = ts.Size * uint64(to.EC.DataSlices+to.EC.ParitySlices) / uint64(to.EC.DataSlices)
+			}
+			est.WriteBytes += extra
+			est.Capacity += extra
+		case BpropsChangeMirror:
+			extra := ts.Size * (mirrorCopies(to) - mirrorCopies(from))
+			est.WriteBytes += extra
+			est.Capacity += extra
+		}
+	}
+	if limit := ts.Total * uint64(ts.HighWM) / 100; limit > ts.Used {
+		est.Headroom = limit - ts.Used
+	}
+	est.Sufficient = est.Capacity <= est.Headroom
+	if ts.Throughput > 0 {
+		est.Time = time.Duration(float64(est.ReadBytes+est.WriteBytes) / float64(ts.Throughput) * float64(time.Second))
+	}
+	return est
+}
+
+// NewBpropsPlan returns an empty plan for the targets' estimates to be merged into.
+func NewBpropsPlan(bck Bck, changes []string) *BpropsPlan {
+	return &BpropsPlan{
+		Bck:     bck,
+		Changes: changes,
+		Total:   BpropsEstimate{Sufficient: true},
+		Targets: make(map[string]*BpropsEstimate),
+	}
+}
+
+// Merge adds the target's estimate to the plan; targets work in parallel, so
+// the total time is the longest of the targets'.
+func (plan *BpropsPlan) Merge(tid string, other *BpropsEstimate) {
+	plan.Targets[tid] = other
+	est := &plan.Total
+	est.ObjCount += other.ObjCount
+	est.Size += other.Size
+	est.ReadBytes += other.ReadBytes
+	est.WriteBytes += other.WriteBytes
+	est.Capacity += other.Capacity
+	est.Headroom += other.Headroom
+	est.Throughput += other.Throughput
+	if other.Time > est.Time {
+		est.Time = other.Time
+	}
+	est.Sufficient = est.Sufficient && other.Sufficient
+}
+
+// Insufficient returns the error to refuse the change with (nil if all
+// targets have enough headroom).
+func (plan *BpropsPlan) Insufficient() error {
+	if len(plan.Changes) == 0 || plan.Total.Sufficient {
+		return nil
+	}
+	var short []string
+	for tid, est := range plan.Targets {
+		if !est.Sufficient {
+			short = append(short, tid)
+		}
+	}
+	sort.Strings(short)
+	return fmt.Errorf("changing %s props (%v) requires %s of additional capacity, targets %v lack headroom "+
+		"(below high watermark) - use force to override", plan.Bck, plan.Changes,
+		B2S(int64(plan.Total.Capacity), 1), short)
+}
@@ -68,4 +68,7 @@ type BucketList struct {
 	Entries []*BucketEntry `json:"entries"`
 	// TODO: merge `UUID` into `ContinuationToken`
 	ContinuationToken string `json:"continuation_token"`
+	// cluster map and BMD versions of the listing (see SelectMsg.SmapVersion)
+	SmapVersion int64 `json:"smap_version,string,omitempty"`
+	BMDVersion  int64 `json:"bmd_version,string,omitempty"`
 }
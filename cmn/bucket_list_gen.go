@@ -292,6 +292,18 @@ func (z *BucketList) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "ContinuationToken")
 				return
 			}
+		case "SmapVersion":
+			z.SmapVersion, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "SmapVersion")
+				return
+			}
+		case "BMDVersion":
+			z.BMDVersion, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "BMDVersion")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -305,9 +317,9 @@ func (z *BucketList) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketList) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
+	// map header, size 5
 	// write "UUID"
-	err = en.Append(0x83, 0xa4, 0x55, 0x55, 0x49, 0x44)
+	err = en.Append(0x85, 0xa4, 0x55, 0x55, 0x49, 0x44)
 	if err != nil {
 		return
 	}
@@ -350,6 +362,26 @@ func (z *BucketList) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "ContinuationToken")
 		return
 	}
+	// write "SmapVersion"
+	err = en.Append(0xab, 0x53, 0x6d, 0x61, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.SmapVersion)
+	if err != nil {
+		err = msgp.WrapError(err, "SmapVersion")
+		return
+	}
+	// write "BMDVersion"
+	err = en.Append(0xaa, 0x42, 0x4d, 0x44, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.BMDVersion)
+	if err != nil {
+		err = msgp.WrapError(err, "BMDVersion")
+		return
+	}
 	return
 }
 
@@ -363,6 +395,6 @@ func (z *BucketList) Msgsize() (s int) {
 			s += z.Entries[za0001].Msgsize()
 		}
 	}
-	s += 18 + msgp.StringPrefixSize + len(z.ContinuationToken)
+	s += 18 + msgp.StringPrefixSize + len(z.ContinuationToken) + 12 + msgp.Int64Size + 11 + msgp.Int64Size
 	return
 }
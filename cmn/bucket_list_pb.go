@@ -0,0 +1,194 @@
+// Package provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Protobuf encoding of the list-objects and query results (see ContentProtobuf)
+// for clients that negotiate it via the Accept header. The encoding is written
+// by hand (no generated code) and corresponds to the following schema:
+//
+//   syntax = "proto3";
+//   message BucketEntry {
+//     string name       = 1;
+//     int64  size       = 2;
+//     string checksum   = 3;
+//     string atime      = 4;
+//     string version    = 5;
+//     string target_url = 6;
+//     int32  copies     = 7;
+//     uint32 flags      = 8;
+//   }
+//   message BucketList {
+//     string               uuid               = 1;
+//     repeated BucketEntry entries            = 2;
+//     string               continuation_token = 3;
+//     int64                smap_version       = 4;
+//     int64                bmd_version        = 5;
+//   }
+
+const (
+	pbEntryName protowire.Number = iota + 1
+	pbEntrySize
+	pbEntryChecksum
+	pbEntryAtime
+	pbEntryVersion
+	pbEntryTargetURL
+	pbEntryCopies
+	pbEntryFlags
+)
+
+const (
+	pbListUUID protowire.Number = iota + 1
+	pbListEntries
+	pbListToken
+	pbListSmapVersion
+	pbListBMDVersion
+)
+
+func appendPBString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendPBVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func (be *BucketEntry) sizePB() int {
+	// upper bound: tags and lengths take (far) less than 4 bytes each
+	return len(be.Name) + len(be.Checksum) + len(be.Atime) + len(be.Version) + len(be.TargetURL) + 8*4 + 3*10
+}
+
+func (be *BucketEntry) appendPB(b []byte) []byte {
+	b = appendPBString(b, pbEntryName, be.Name)
+	b = appendPBVarint(b, pbEntrySize, uint64(be.Size))
+	b = appendPBString(b, pbEntryChecksum, be.Checksum)
+	b = appendPBString(b, pbEntryAtime, be.Atime)
+	b = appendPBString(b, pbEntryVersion, be.Version)
+	b = appendPBString(b, pbEntryTargetURL, be.TargetURL)
+	b = appendPBVarint(b, pbEntryCopies, uint64(int64(be.Copies)))
+	b = appendPBVarint(b, pbEntryFlags, uint64(be.Flags))
+	return b
+}
+
+// MarshalPB returns protobuf-encoded list (see the schema above).
+func (bl *BucketList) MarshalPB() []byte {
+	size := len(bl.UUID) + len(bl.ContinuationToken) + 16 + 2*10
+	for _, be := range bl.Entries {
+		size += be.sizePB() + 8
+	}
+	var (
+		b     = make([]byte, 0, size)
+		entry []byte
+	)
+	b = appendPBString(b, pbListUUID, bl.UUID)
+	for _, be := range bl.Entries {
+		entry = be.appendPB(entry[:0])
+		b = protowire.AppendTag(b, pbListEntries, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	b = appendPBString(b, pbListToken, bl.ContinuationToken)
+	b = appendPBVarint(b, pbListSmapVersion, uint64(bl.SmapVersion))
+	b = appendPBVarint(b, pbListBMDVersion, uint64(bl.BMDVersion))
+	return b
+}
+
+// UnmarshalPB decodes protobuf-encoded list; unknown fields are skipped.
+func (bl *BucketList) UnmarshalPB(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == pbListUUID && typ == protowire.BytesType:
+			bl.UUID, n = protowire.ConsumeString(b)
+		case num == pbListToken && typ == protowire.BytesType:
+			bl.ContinuationToken, n = protowire.ConsumeString(b)
+		case num == pbListSmapVersion && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			bl.SmapVersion = int64(v)
+		case num == pbListBMDVersion && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			bl.BMDVersion = int64(v)
+		case num == pbListEntries && typ == protowire.BytesType:
+			var v []byte
+			if v, n = protowire.ConsumeBytes(b); n >= 0 {
+				be := &BucketEntry{}
+				if err := be.unmarshalPB(v); err != nil {
+					return fmt.Errorf("protobuf: entry #%d: %v", len(bl.Entries), err)
+				}
+				bl.Entries = append(bl.Entries, be)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func (be *BucketEntry) unmarshalPB(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var (
+			s string
+			v uint64
+		)
+		switch typ {
+		case protowire.BytesType:
+			s, n = protowire.ConsumeString(b)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch num {
+		case pbEntryName:
+			be.Name = s
+		case pbEntrySize:
+			be.Size = int64(v)
+		case pbEntryChecksum:
+			be.Checksum = s
+		case pbEntryAtime:
+			be.Atime = s
+		case pbEntryVersion:
+			be.Version = s
+		case pbEntryTargetURL:
+			be.TargetURL = s
+		case pbEntryCopies:
+			be.Copies = int16(v)
+		case pbEntryFlags:
+			be.Flags = uint16(v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+)
+
+type (
+	// ClientStats tracks connection pool utilization of a given HTTP client
+	// (see TransportArgs.Stats); all clients with stats are globally registered
+	// by name and can be queried via ClientStatsAll
+	ClientStats struct {
+		name     string
+		requests atomic.Int64 // total number of requests
+		inflight atomic.Int64 // requests in progress
+		reused   atomic.Int64 // requests served via idle (pooled) connections
+		dials    atomic.Int64 // new connections
+		dialErrs atomic.Int64 // failed dials
+		open     atomic.Int64 // currently open connections (active and idle)
+	}
+	ClientStatsSnap struct {
+		Requests int64 `json:"requests"`
+		InFlight int64 `json:"inflight"`
+		Reused   int64 `json:"reused"`
+		Dials    int64 `json:"dials"`
+		DialErrs int64 `json:"dial_errs"`
+		Open     int64 `json:"open"`
+		// fraction of the requests served via pooled connections
+		ReuseRatio float64 `json:"reuse_ratio"`
+	}
+
+	statsRoundTripper struct {
+		rt    http.RoundTripper
+		stats *ClientStats
+	}
+	statsConn struct {
+		net.Conn
+		stats  *ClientStats
+		closed atomic.Bool
+	}
+)
+
+var clientStats sync.Map // name => *ClientStats
+
+// NewClientStats returns (and registers) the stats of the named client;
+// clients that share the name also share the stats
+func NewClientStats(name string) *ClientStats {
+	cs, _ := clientStats.LoadOrStore(name, &ClientStats{name: name})
+	return cs.(*ClientStats)
+}
+
+// ClientStatsAll returns a snapshot of all registered client stats
+func ClientStatsAll() map[string]ClientStatsSnap {
+	all := make(map[string]ClientStatsSnap, 4)
+	clientStats.Range(func(name, cs interface{}) bool {
+		all[name.(string)] = cs.(*ClientStats).Snap()
+		return true
+	})
+	return all
+}
+
+func (cs *ClientStats) Name() string { return cs.name }
+
+func (cs *ClientStats) Snap() (snap ClientStatsSnap) {
+	snap = ClientStatsSnap{
+		Requests: cs.requests.Load(),
+		InFlight: cs.inflight.Load(),
+		Reused:   cs.reused.Load(),
+		Dials:    cs.dials.Load(),
+		DialErrs: cs.dialErrs.Load(),
+		Open:     cs.open.Load(),
+	}
+	if snap.Requests > 0 {
+		snap.ReuseRatio = float64(snap.Reused) / float64(snap.Requests)
+	}
+	return
+}
+
+func (cs *ClientStats) dialContext(dialer *net.Dialer) func(context.Context, string, string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			cs.dialErrs.Inc()
+			return nil, err
+		}
+		cs.dials.Inc()
+		cs.open.Inc()
+		return &statsConn{Conn: conn, stats: cs}, nil
+	}
+}
+
+func (c *statsConn) Close() error {
+	if c.closed.CAS(false, true) {
+		c.stats.open.Dec()
+	}
+	return c.Conn.Close()
+}
+
+func (t *statsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	cs := t.stats
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				cs.reused.Inc()
+			}
+		},
+	}
+	cs.requests.Inc()
+	cs.inflight.Inc()
+	resp, err := t.rt.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	cs.inflight.Dec() // NOTE: upon receiving response headers (the body may still be in transit)
+	return resp, err
+}
+
+// propagate to the wrapped transport (see http.Client.CloseIdleConnections)
+func (t *statsRoundTripper) CloseIdleConnections() {
+	type closeIdler interface{ CloseIdleConnections() }
+	if tr, ok := t.rt.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
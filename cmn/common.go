@@ -124,6 +124,70 @@ type (
 		Proxy  JSONRawMsgs `json:"proxy"`
 		Target JSONRawMsgs `json:"target"`
 	}
+	// CapForecast is the capacity usage forecast based on the (persisted)
+	// history of capacity samples: the ingest rate is estimated over the last
+	// (up to) 24 hours; the projected times assume that the rate stays the same.
+	CapForecast struct {
+		Samples      int           `json:"samples"`               // number of samples the estimate is based upon
+		Window       time.Duration `json:"window,string"`         // time span of the samples
+		Used         uint64        `json:"used,string"`           // bytes, as of the latest sample
+		Total        uint64        `json:"total,string"`          // ditto
+		IngestRate   float64       `json:"ingest_rate"`           // bytes per second (negative: usage is going down)
+		TimeToHighWM time.Duration `json:"time_to_highwm,string"` // 0: already above; -1: never (at the current rate)
+		TimeToOOS    time.Duration `json:"time_to_oos,string"`    // ditto
+	}
+	ClusterCapForecast struct {
+		Cluster CapForecast             `json:"cluster"` // all targets combined
+		Targets map[string]*CapForecast `json:"targets"`
+	}
+	// round-trip latency from a given node to one of its peers over the intra-cluster
+	// control and data networks (see GetWhatPingMatrix); Data is zero when the cluster
+	// does not use a separate intra-cluster data network
+	NodePing struct {
+		Control time.Duration `json:"control,string"`
+		Data    time.Duration `json:"data,string"`
+		Err     string        `json:"error,omitempty"`
+	}
+	NodePings  map[string]*NodePing // peer node ID -> latencies
+	PingMatrix map[string]NodePings // node ID -> latencies to all the other nodes
+	// status of the bucket's replication to a remote AIS cluster (see
+	// BucketProps.Replication and GetWhatReplication)
+	ReplStatus struct {
+		Pending    int64          `json:"pending,string"`      // PUTs and DELETEs yet to be replicated
+		Lag        time.Duration  `json:"lag,string"`          // age of the oldest pending one
+		Replicated int64          `json:"replicated,string"`   // number of replicated PUTs and DELETEs
+		Bytes      int64          `json:"bytes,string"`        // replicated bytes
+		FailedCnt  int64          `json:"failed_count,string"` // number of failures (total)
+		Failed     []*ReplFailure `json:"failed"`              // objects that failed to replicate (most recent first)
+		LastTime   int64          `json:"last_time,string"`    // last successful replication (Unix time, nanoseconds)
+	}
+	ReplFailure struct {
+		ObjName string `json:"name"`
+		Op      string `json:"op"` // "put" | "delete"
+		Err     string `json:"error"`
+		Time    int64  `json:"time,string"` // Unix time (nanoseconds)
+	}
+	ClusterReplStatus struct {
+		Bck     Bck                    `json:"bucket"`
+		Conf    RemoteReplConf         `json:"conf"`
+		Cluster ReplStatus             `json:"cluster"` // all targets combined
+		Targets map[string]*ReplStatus `json:"targets"`
+	}
+	// progress of moving the existing objects onto the targets that joined the
+	// cluster in prefill mode (see RebalanceConf.Prefill and GetWhatPrefill)
+	PrefillStatus struct {
+		ID      string `json:"id"`             // prefill xaction ID (empty - not started)
+		Scanned int64  `json:"scanned,string"` // local objects checked so far
+		Moved   int64  `json:"moved,string"`   // objects moved to the prefilling targets
+		Bytes   int64  `json:"bytes,string"`   // ditto, bytes
+		Running bool   `json:"running"`
+		Aborted bool   `json:"aborted"`
+	}
+	ClusterPrefillStatus struct {
+		Prefilling []string                  `json:"prefilling"` // IDs of the targets in prefill mode
+		Cluster    PrefillStatus             `json:"cluster"`    // all targets combined
+		Targets    map[string]*PrefillStatus `json:"targets"`
+	}
 	ParsedQuantity struct {
 		Type  string
 		Value uint64
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -36,6 +37,25 @@ const (
 	MaxSliceCount = 32 // maximum number of data or parity slices
 )
 
+const DefaultScrubPause = time.Second
+
+// metasync modes
+const (
+	MetasyncModeDirect = "direct" // primary sends to all nodes (default)
+	MetasyncModeGossip = "gossip" // primary sends to a few nodes that relay to the rest
+
+	DefaultMetasyncFanout   = 8
+	DefaultMetasyncMinNodes = 64
+)
+
+// alert rule kinds (see AlertsConf)
+const (
+	AlertCapacity          = "capacity"           // used capacity (max across mountpaths) above threshold percentage
+	AlertErrorRate         = "error_rate"         // errors per second above threshold
+	AlertMpathDisabled     = "mountpath_disabled" // one or more mountpaths disabled
+	AlertRebalanceDuration = "rebalance_duration" // rebalance running longer than duration
+)
+
 const (
 	IgnoreReaction = "ignore"
 	WarnReaction   = "warn"
@@ -56,6 +76,13 @@ const (
 	FeatureDirectAccess = 1 << iota
 )
 
+// config profiles (environment tiers) - see Config.validateProfile()
+const (
+	ProfileDev     = "dev"
+	ProfileStaging = "staging"
+	ProfileProd    = "prod"
+)
+
 type (
 	ValidationArgs struct {
 		TargetCnt int // for EC
@@ -122,6 +149,7 @@ type (
 	// nolint:maligned // no performance critical code
 	Config struct {
 		Confdir          string          `json:"confdir"`
+		Profile          string          `json:"profile" list:"readonly"` // dev (default), staging, or prod
 		Cloud            CloudConf       `json:"cloud"`
 		Mirror           MirrorConf      `json:"mirror"`
 		EC               ECConf          `json:"ec"`
@@ -145,6 +173,11 @@ type (
 		Downloader       DownloaderConf  `json:"downloader"`
 		DSort            DSortConf       `json:"distributed_sort"`
 		Compression      CompressionConf `json:"compression"`
+		Xaction          XactionConf     `json:"xaction"`
+		Scrub            ScrubConf       `json:"scrub"`
+		Metasync         MetasyncConf    `json:"metasync"`
+		Alerts           AlertsConf      `json:"alerts"`
+		ColdGet          ColdGetConf     `json:"cold_get"`
 	}
 	CloudConf struct {
 		Conf map[string]interface{} `json:"conf,omitempty"` // implementation depends on cloud provider
@@ -162,21 +195,48 @@ type (
 	}
 
 	CloudConfAIS map[string][]string // cluster alias -> [urls...]
+
+	// S3-compatible storage (e.g., MinIO, Ceph RGW) fronted by the aws provider;
+	// can be overridden on a per-bucket basis - see ExtraPropsAWS
+	CloudConfAWS struct {
+		Endpoint  string `json:"endpoint,omitempty"`   // empty - AWS (or, AIS_S3_ENDPOINT env)
+		PathStyle bool   `json:"path_style,omitempty"` // force path-style addressing
+	}
+	// uploads to GCP: objects larger than the chunk size are streamed via
+	// resumable upload sessions, chunk by chunk - see ais/cloud/gcp.go
+	CloudConfGCP struct {
+		ChunkSizeStr string `json:"chunk_size,omitempty"`  // multiple of 256KiB (default 16MiB)
+		MaxRetries   int    `json:"max_retries,omitempty"` // per chunk, upon transient errors (default 5)
+		ChunkSize    int64  `json:"-"`
+	}
 	CloudInfoAIS map[string]*RemoteAISInfo
 
+	// CloudHealth is the result of probing a configured Cloud provider (or
+	// attached remote AIS clusters) by a given target: listing the probe bucket
+	// or, if there's none, listing the buckets (see GetWhatCloudHealth)
+	CloudHealth struct {
+		Provider string        `json:"provider"`
+		Bck      string        `json:"bucket,omitempty"` // the probe bucket
+		Latency  time.Duration `json:"latency,string"`
+		Err      string        `json:"error,omitempty"`
+	}
+	CloudHealthResults map[string][]CloudHealth // target ID -> results (one per provider)
+
 	MirrorConf struct {
-		Copies      int64 `json:"copies"`       // num local copies
-		Burst       int64 `json:"burst_buffer"` // channel buffer size
-		UtilThresh  int64 `json:"util_thresh"`  // considered equivalent when below threshold
-		OptimizePUT bool  `json:"optimize_put"` // optimization objective
-		Enabled     bool  `json:"enabled"`      // will only generate local copies when set to true
+		Copies      int64  `json:"copies"`       // num local copies
+		Burst       int64  `json:"burst_buffer"` // channel buffer size
+		UtilThresh  int64  `json:"util_thresh"`  // considered equivalent when below threshold
+		OptimizePUT bool   `json:"optimize_put"` // optimization objective
+		Enabled     bool   `json:"enabled"`      // will only generate local copies when set to true
+		HotLabel    string `json:"hot_label"`    // when set, one copy goes to mountpaths with this label (see FSPathsConf)
 	}
 	MirrorConfToUpdate struct {
-		Copies      *int64 `json:"copies"`
-		Burst       *int64 `json:"burst_buffer"`
-		UtilThresh  *int64 `json:"util_thresh"`
-		OptimizePUT *bool  `json:"optimize_put"`
-		Enabled     *bool  `json:"enabled"`
+		Copies      *int64  `json:"copies"`
+		Burst       *int64  `json:"burst_buffer"`
+		UtilThresh  *int64  `json:"util_thresh"`
+		OptimizePUT *bool   `json:"optimize_put"`
+		Enabled     *bool   `json:"enabled"`
+		HotLabel    *string `json:"hot_label"`
 	}
 	ECConf struct {
 		ObjSizeLimit int64  `json:"objsize_limit"` // objects below this size are replicated instead of EC'ed
@@ -229,12 +289,35 @@ type (
 		ListObjectsStr string        `json:"list_timeout"`
 		ListObjects    time.Duration `json:"-"`
 		Features       FeatureFlags  `json:"features,string"`
+		Transport      TransportConf `json:"transport"` // intra-cluster clients
+	}
+	// HTTP client transport tuning (see TransportArgs.Tune); zero values
+	// (and empty strings) keep the respective defaults
+	TransportConf struct {
+		MaxIdleConns        int           `json:"max_idle_conns"`          // total; zero: unlimited
+		MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"` // zero: http.DefaultMaxIdleConnsPerHost (2)
+		IdleConnTimeoutStr  string        `json:"idle_conn_timeout"`
+		IdleConnTimeout     time.Duration `json:"-"`
+		DialTimeoutStr      string        `json:"dial_timeout"`
+		DialTimeout         time.Duration `json:"-"`
+		KeepAliveStr        string        `json:"tcp_keepalive"` // negative: disabled
+		KeepAlive           time.Duration `json:"-"`
+		HTTP2               bool          `json:"http2"` // HTTPS only
 	}
 	ProxyConf struct {
 		PrimaryURL   string `json:"primary_url"`
 		OriginalURL  string `json:"original_url"`
 		DiscoveryURL string `json:"discovery_url"`
 		NonElectable bool   `json:"non_electable"`
+		// Minimum number of affirmative votes (including the candidate's own) to
+		// elect the new primary; zero - simple majority of the voters.
+		ElectionQuorum int `json:"election_quorum"`
+		// Optional lightweight witness (see cmd/aiswitness) that votes in primary
+		// elections, e.g. to break ties in two-proxy deployments.
+		WitnessURL string `json:"witness_url"`
+		// Secret shared with the witness to sign the vote requests and the
+		// witness's responses (see cmn.WitnessSig); required with WitnessURL.
+		WitnessSecret string `json:"witness_secret"`
 	}
 	LRUConf struct {
 		// LowWM: used capacity low-watermark (% of total local storage capacity)
@@ -257,6 +340,14 @@ type (
 		// DontEvictTime is the parsed value of DontEvictTimeStr
 		DontEvictTime time.Duration `json:"-"`
 
+		// DontEvictPrefixes: comma-separated object name prefixes (e.g. "manifest/,index/")
+		// that LRU never evicts
+		DontEvictPrefixes string `json:"dont_evict_prefixes"`
+
+		// Priority: buckets with higher priority are evicted last, i.e., only
+		// when evicting lower-priority buckets does not free up enough space
+		Priority int64 `json:"priority"`
+
 		// CapacityUpdTimeStr denotes the frequency at which AIStore updates local capacity utilization
 		CapacityUpdTimeStr string `json:"capacity_upd_time"`
 
@@ -267,10 +358,12 @@ type (
 		Enabled bool `json:"enabled"`
 	}
 	LRUConfToUpdate struct {
-		LowWM   *int64 `json:"lowwm"`
-		HighWM  *int64 `json:"highwm"`
-		OOS     *int64 `json:"out_of_space"`
-		Enabled *bool  `json:"enabled"`
+		LowWM             *int64  `json:"lowwm"`
+		HighWM            *int64  `json:"highwm"`
+		OOS               *int64  `json:"out_of_space"`
+		DontEvictPrefixes *string `json:"dont_evict_prefixes"`
+		Priority          *int64  `json:"priority"`
+		Enabled           *bool   `json:"enabled"`
 	}
 	DiskConf struct {
 		DiskUtilLowWM   int64         `json:"disk_util_low_wm"`  // no throttling below
@@ -281,6 +374,10 @@ type (
 
 		IostatTimeLongStr  string `json:"iostat_time_long"`
 		IostatTimeShortStr string `json:"iostat_time_short"`
+
+		// at startup, accept swapped or renamed mountpaths (and resilver)
+		// rather than refusing to start - see fs.CheckVMD
+		VMDRemap bool `json:"vmd_remap"`
 	}
 	RebalanceConf struct {
 		DontRunTimeStr   string        `json:"dont_run_time"`
@@ -292,6 +389,13 @@ type (
 		Compression      string        `json:"compression"`     // see CompressAlways, etc. enum
 		Multiplier       uint8         `json:"multiplier"`      // stream-bundle-and-jogger multiplier
 		Enabled          bool          `json:"enabled"`         // true=auto-rebalance | manual rebalancing
+		// Prefill: a new target joins without triggering rebalance - instead, it receives
+		// new writes (as per HRW), gets-from-neighbors (and keeps) the objects it does not
+		// have, and the existing objects trickle to it in the background at PrefillRate
+		// (bytes per second, per sending target; zero - unlimited).
+		Prefill        bool   `json:"prefill"`
+		PrefillRateStr string `json:"prefill_rate,omitempty"`
+		PrefillRate    int64  `json:"-"` // (runtime)
 	}
 	ReplicationConf struct {
 		OnColdGet     bool `json:"on_cold_get"`     // object replication on cold GET request
@@ -403,6 +507,7 @@ type (
 	DownloaderConf struct {
 		TimeoutStr string        `json:"timeout"`
 		Timeout    time.Duration `json:"-"`
+		Transport  TransportConf `json:"transport"` // clients to access external sources
 	}
 	DSortConf struct {
 		DuplicatedRecords   string        `json:"duplicated_records"`
@@ -416,13 +521,63 @@ type (
 		CallTimeout         time.Duration `json:"-"` // time to wait for other target
 	}
 	FSPathsConf struct {
-		Paths map[string]struct{} `json:"paths,omitempty"`
+		Paths  map[string]struct{} `json:"paths,omitempty"`
+		Labels map[string]string   `json:"-"` // mountpath => label (media class, e.g. "nvme" or "hdd")
 	}
 	// lz4 block and frame formats: http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
 	CompressionConf struct {
 		BlockMaxSize int  `json:"block_size"` // *uncompressed* block max size
 		Checksum     bool `json:"checksum"`   // true: checksum lz4 frames
 	}
+	// max number of concurrently running heavy xactions (copy, etl, ec-encode,
+	// make-n-copies) - the rest are queued; zero means unlimited
+	XactionConf struct {
+		MaxHeavyPerBucket int `json:"max_heavy_per_bucket"`
+		MaxHeavyPerNs     int `json:"max_heavy_per_ns"`
+		MaxHeavyPerTarget int `json:"max_heavy_per_target"`
+	}
+	// low-priority background verification of object checksums that runs only
+	// when mountpaths are idle - see health/scrub.go
+	ScrubConf struct {
+		// PauseStr: time to pause between objects (i.e., the scrubbing rate)
+		PauseStr string        `json:"pause"`
+		Pause    time.Duration `json:"-"`
+		Enabled  bool          `json:"enabled"`
+	}
+	// dissemination of cluster-level metadata (Smap, BMD, etc.) by the primary;
+	// gossip mode offloads the primary in clusters with hundreds of nodes
+	MetasyncConf struct {
+		Mode     string `json:"mode"`      // one of MetasyncModeDirect, MetasyncModeGossip
+		Fanout   int    `json:"fanout"`    // gossip: number of nodes each sender (primary or relay) sends to
+		MinNodes int    `json:"min_nodes"` // gossip: minimum cluster size for gossip to kick in
+	}
+	// alert rules evaluated by the targets' stats runners with notifications
+	// sent upon state changes (firing, resolved) - see stats/alerts.go
+	AlertsConf struct {
+		Rules   []AlertRule    `json:"rules"`
+		Webhook string         `json:"webhook"` // URL to POST alerts to (JSON)
+		Email   AlertEmailConf `json:"email"`
+		Enabled bool           `json:"enabled"`
+	}
+	AlertRule struct {
+		Name        string        `json:"name"`                // (default: kind)
+		Kind        string        `json:"kind"`                // one of the Alert* kinds (above)
+		Threshold   float64       `json:"threshold,omitempty"` // capacity (percentage) and error_rate (errors per second)
+		DurationStr string        `json:"duration,omitempty"`  // rebalance_duration
+		Duration    time.Duration `json:"-"`
+	}
+	AlertEmailConf struct {
+		Server string   `json:"server"` // SMTP server (host:port)
+		From   string   `json:"from"`
+		To     []string `json:"to"`
+	}
+	// cluster-wide budget of concurrent cold GETs (each target gets its equal
+	// share) - see also bucket's ColdGetShareConf
+	ColdGetConf struct {
+		MaxConcurrent int           `json:"max_concurrent"` // 0 - unlimited
+		MaxWaitStr    string        `json:"max_wait"`       // max time to wait for a slot ("" or 0 - indefinitely)
+		MaxWait       time.Duration `json:"-"`
+	}
 )
 
 var (
@@ -558,6 +713,13 @@ var (
 	_ Validator = &FSPathsConf{}
 	_ Validator = &TestfspathConf{}
 	_ Validator = &CompressionConf{}
+	_ Validator = &XactionConf{}
+	_ Validator = &ScrubConf{}
+	_ Validator = &MetasyncConf{}
+	_ Validator = &AlertsConf{}
+	_ Validator = &ColdGetConf{}
+	_ Validator = &TransportConf{}
+	_ Validator = &ProxyConf{}
 
 	_ PropsValidator = &CksumConf{}
 	_ PropsValidator = &LRUConf{}
@@ -576,7 +738,7 @@ var (
 
 func (c *Config) Validate() error {
 	opts := IterOpts{VisitAll: true}
-	return IterFields(c, func(tag string, field IterField) (err error, b bool) {
+	err := IterFields(c, func(tag string, field IterField) (err error, b bool) {
 		if v, ok := field.Value().(Validator); ok {
 			if err := v.Validate(c); err != nil {
 				return err, false
@@ -584,6 +746,74 @@ func (c *Config) Validate() error {
 		}
 		return nil, false
 	}, opts)
+	if err != nil {
+		return err
+	}
+	return c.validateProfile()
+}
+
+// Settings that are fine for development but dangerous in production are
+// permitted only with the `dev` profile: `prod` refuses to load (or set at
+// runtime) the config that has any, `staging` tolerates (with a warning) those
+// that come with the config file but rejects enabling them at runtime - see
+// ValidateUpdate(). The profile itself is read-only - it can be changed only
+// in the config file.
+func (c *Config) validateProfile() error {
+	switch c.Profile {
+	case "", ProfileDev:
+		return nil
+	case ProfileStaging, ProfileProd:
+	default:
+		return fmt.Errorf("invalid profile %q (expecting one of: %q, %q, %q)",
+			c.Profile, ProfileDev, ProfileStaging, ProfileProd)
+	}
+	dangerous := c.devOnlySettings()
+	if len(dangerous) == 0 {
+		return nil
+	}
+	if c.Profile == ProfileProd {
+		return fmt.Errorf("%s profile: %v not permitted", c.Profile, dangerous)
+	}
+	glog.Warningf("%s profile: %v not recommended", c.Profile, dangerous)
+	return nil
+}
+
+// ValidateUpdate validates the config that results from the runtime update of
+// the `old` one: in addition to Validate(), non-dev profiles reject the update
+// that turns on any of the dev-only settings.
+func (c *Config) ValidateUpdate(old *Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c.Profile == "" || c.Profile == ProfileDev {
+		return nil
+	}
+	var (
+		prev  = old.devOnlySettings()
+		added []string
+	)
+	for _, s := range c.devOnlySettings() {
+		if !StringInSlice(s, prev) {
+			added = append(added, s)
+		}
+	}
+	if len(added) > 0 {
+		return fmt.Errorf("%s profile: %v cannot be set at runtime", c.Profile, added)
+	}
+	return nil
+}
+
+func (c *Config) devOnlySettings() (settings []string) {
+	if !c.FSHC.Enabled {
+		settings = append(settings, "fshc.enabled=false")
+	}
+	if c.Cksum.Type == ChecksumNone {
+		settings = append(settings, "checksum.type="+ChecksumNone)
+	}
+	if !c.Auth.Enabled {
+		settings = append(settings, "auth.enabled=false")
+	}
+	return
 }
 
 // TestingEnv returns true if config is set to a development environment
@@ -622,6 +852,28 @@ func (c *CloudConf) Validate(_ *Config) (err error) {
 				break
 			}
 			c.Conf[provider] = aisConf
+		case ProviderAmazon:
+			var awsConf CloudConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if awsConf.Endpoint != "" {
+				if err := ValidateS3Endpoint(awsConf.Endpoint); err != nil {
+					return err
+				}
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
+		case ProviderGoogle:
+			var gcpConf CloudConfGCP
+			if err := jsoniter.Unmarshal(b, &gcpConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if err := gcpConf.validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = gcpConf
+			c.setProvider(provider)
 		case "":
 			continue
 		default:
@@ -654,6 +906,32 @@ func (c *CloudConf) ProviderConf(provider string, newConf ...interface{}) (conf
 	return
 }
 
+const (
+	gcpChunkAlign        = 256 * KiB
+	GCPDefaultChunkSize  = 16 * MiB
+	GCPDefaultMaxRetries = 5
+)
+
+func (c *CloudConfGCP) validate() (err error) {
+	c.ChunkSize = GCPDefaultChunkSize
+	if c.ChunkSizeStr != "" {
+		if c.ChunkSize, err = S2B(c.ChunkSizeStr); err != nil {
+			return fmt.Errorf("invalid cloud.gcp.chunk_size %q: %v", c.ChunkSizeStr, err)
+		}
+		if c.ChunkSize <= 0 || c.ChunkSize%gcpChunkAlign != 0 {
+			return fmt.Errorf("invalid cloud.gcp.chunk_size %q: expecting positive multiple of %s",
+				c.ChunkSizeStr, B2S(gcpChunkAlign, 0))
+		}
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("invalid cloud.gcp.max_retries %d: expecting non-negative value", c.MaxRetries)
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = GCPDefaultMaxRetries
+	}
+	return nil
+}
+
 func (c *DiskConf) Validate(_ *Config) (err error) {
 	lwm, hwm, maxwm := c.DiskUtilLowWM, c.DiskUtilHighWM, c.DiskUtilMaxWM
 	if lwm <= 0 || hwm <= lwm || maxwm <= hwm || maxwm > 100 {
@@ -807,6 +1085,50 @@ func (c *ClientConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+func (c *ProxyConf) Validate(_ *Config) error {
+	if c.ElectionQuorum < 0 {
+		return fmt.Errorf("invalid proxy.election_quorum=%d (expecting non-negative)", c.ElectionQuorum)
+	}
+	if c.WitnessURL != "" {
+		if u, err := url.Parse(c.WitnessURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid proxy.witness_url %q", c.WitnessURL)
+		}
+		if c.WitnessSecret == "" {
+			return errors.New("proxy.witness_url requires proxy.witness_secret")
+		}
+	}
+	return nil
+}
+
+func (c *TransportConf) Validate(_ *Config) (err error) {
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("invalid transport.max_idle_conns (%d) or transport.max_idle_conns_per_host (%d)",
+			c.MaxIdleConns, c.MaxIdleConnsPerHost)
+	}
+	for _, d := range []struct {
+		name string
+		s    string
+		v    *time.Duration
+	}{
+		{"idle_conn_timeout", c.IdleConnTimeoutStr, &c.IdleConnTimeout},
+		{"dial_timeout", c.DialTimeoutStr, &c.DialTimeout},
+		{"tcp_keepalive", c.KeepAliveStr, &c.KeepAlive},
+	} {
+		if d.s == "" {
+			*d.v = 0
+			continue
+		}
+		if *d.v, err = time.ParseDuration(d.s); err != nil {
+			return fmt.Errorf("invalid transport.%s format %s, err %v", d.name, d.s, err)
+		}
+	}
+	if c.IdleConnTimeout < 0 || c.DialTimeout < 0 {
+		return fmt.Errorf("invalid transport.idle_conn_timeout (%v) or transport.dial_timeout (%v)",
+			c.IdleConnTimeout, c.DialTimeout)
+	}
+	return nil
+}
+
 func (c *RebalanceConf) Validate(_ *Config) (err error) {
 	if c.DontRunTimeStr != "" { // can be missing
 		if c.DontRunTime, err = time.ParseDuration(c.DontRunTimeStr); err != nil {
@@ -820,6 +1142,13 @@ func (c *RebalanceConf) Validate(_ *Config) (err error) {
 	if c.Quiesce, err = time.ParseDuration(c.QuiesceStr); err != nil {
 		return fmt.Errorf("invalid rebalance.quiesce format %s, err %v", c.QuiesceStr, err)
 	}
+	c.PrefillRate = 0
+	if c.PrefillRateStr != "" {
+		if c.PrefillRate, err = S2B(c.PrefillRateStr); err != nil || c.PrefillRate < 0 {
+			return fmt.Errorf("invalid rebalance.prefill_rate %q (expecting size per second, e.g. \"20MB\")",
+				c.PrefillRateStr)
+		}
+	}
 	return nil
 }
 
@@ -952,7 +1281,9 @@ func (c *DSortConf) ValidateWithOpts(_ *Config, allowEmpty bool) (err error) {
 	return nil
 }
 
-// FIXME: change config to accept array of mpaths, not map of mpath -> " "
+// The config maps mountpaths to their (optional) labels, e.g.:
+// "fspaths": {"/ais/nvme0": "nvme", "/ais/hdd0": "hdd", "/ais/hdd1": " "}
+// where blank means no label.
 func (c *FSPathsConf) UnmarshalJSON(data []byte) error {
 	m := make(map[string]string)
 	err := jsoniter.Unmarshal(data, &m)
@@ -961,8 +1292,12 @@ func (c *FSPathsConf) UnmarshalJSON(data []byte) error {
 	}
 
 	c.Paths = make(map[string]struct{})
-	for k := range m {
+	c.Labels = make(map[string]string)
+	for k, label := range m {
 		c.Paths[k] = struct{}{}
+		if label = strings.TrimSpace(label); label != "" {
+			c.Labels[k] = label
+		}
 	}
 
 	return nil
@@ -972,7 +1307,11 @@ func (c *FSPathsConf) MarshalJSON() (data []byte, err error) {
 	m := make(map[string]string)
 
 	for k := range c.Paths {
-		m[k] = " "
+		if label, ok := c.Labels[k]; ok {
+			m[k] = label
+		} else {
+			m[k] = " "
+		}
 	}
 
 	return MustMarshal(m), nil
@@ -988,17 +1327,22 @@ func (c *FSPathsConf) Validate(contextConfig *Config) (err error) {
 		return fmt.Errorf("expected at least one mountpath in fspaths config")
 	}
 
-	cleanMpaths := make(map[string]struct{})
-
+	var (
+		cleanMpaths = make(map[string]struct{})
+		cleanLabels = make(map[string]string)
+	)
 	for k := range c.Paths {
 		cleanMpath, err := ValidateMpath(k)
 		if err != nil {
 			return err
 		}
 		cleanMpaths[cleanMpath] = struct{}{}
+		if label, ok := c.Labels[k]; ok {
+			cleanLabels[cleanMpath] = label
+		}
 	}
 
-	c.Paths = cleanMpaths
+	c.Paths, c.Labels = cleanMpaths, cleanLabels
 	return nil
 }
 
@@ -1038,6 +1382,112 @@ func (c *CompressionConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+func (c *XactionConf) Validate(_ *Config) (err error) {
+	if c.MaxHeavyPerBucket < 0 || c.MaxHeavyPerNs < 0 || c.MaxHeavyPerTarget < 0 {
+		return fmt.Errorf("invalid xaction limits (%d, %d, %d): expecting non-negative values",
+			c.MaxHeavyPerBucket, c.MaxHeavyPerNs, c.MaxHeavyPerTarget)
+	}
+	return nil
+}
+
+func (c *ScrubConf) Validate(_ *Config) (err error) {
+	if c.PauseStr == "" {
+		c.Pause = DefaultScrubPause
+		return nil
+	}
+	if c.Pause, err = time.ParseDuration(c.PauseStr); err != nil {
+		return fmt.Errorf("invalid scrub.pause format: %v", err)
+	}
+	if c.Pause <= 0 {
+		return fmt.Errorf("invalid scrub.pause %v: expecting positive duration", c.Pause)
+	}
+	return nil
+}
+
+func (c *ColdGetConf) Validate(_ *Config) (err error) {
+	if c.MaxConcurrent < 0 {
+		return fmt.Errorf("invalid cold_get.max_concurrent %d: expecting non-negative value", c.MaxConcurrent)
+	}
+	c.MaxWait = 0
+	if c.MaxWaitStr != "" {
+		if c.MaxWait, err = time.ParseDuration(c.MaxWaitStr); err != nil || c.MaxWait < 0 {
+			return fmt.Errorf("invalid cold_get.max_wait %q", c.MaxWaitStr)
+		}
+	}
+	return nil
+}
+
+func (c *MetasyncConf) Validate(_ *Config) (err error) {
+	switch c.Mode {
+	case "":
+		c.Mode = MetasyncModeDirect
+	case MetasyncModeDirect, MetasyncModeGossip:
+	default:
+		return fmt.Errorf("invalid metasync.mode %q (expecting %q or %q)",
+			c.Mode, MetasyncModeDirect, MetasyncModeGossip)
+	}
+	if c.Fanout == 0 {
+		c.Fanout = DefaultMetasyncFanout
+	}
+	if c.MinNodes == 0 {
+		c.MinNodes = DefaultMetasyncMinNodes
+	}
+	if c.Fanout < 2 {
+		return fmt.Errorf("invalid metasync.fanout %d: expecting 2 or greater", c.Fanout)
+	}
+	if c.MinNodes < 0 {
+		return fmt.Errorf("invalid metasync.min_nodes %d: expecting non-negative value", c.MinNodes)
+	}
+	return nil
+}
+
+func (c *AlertsConf) Validate(_ *Config) (err error) {
+	names := make(StringSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" {
+			rule.Name = rule.Kind
+		}
+		if names.Contains(rule.Name) {
+			return fmt.Errorf("duplicate alerts.rules name %q", rule.Name)
+		}
+		names.Add(rule.Name)
+		switch rule.Kind {
+		case AlertCapacity:
+			if rule.Threshold <= 0 || rule.Threshold > 100 {
+				return fmt.Errorf("invalid alerts rule %q threshold %v: expecting percentage in (0, 100]",
+					rule.Name, rule.Threshold)
+			}
+		case AlertErrorRate:
+			if rule.Threshold <= 0 {
+				return fmt.Errorf("invalid alerts rule %q threshold %v: expecting positive number of errors per second",
+					rule.Name, rule.Threshold)
+			}
+		case AlertMpathDisabled:
+		case AlertRebalanceDuration:
+			if rule.Duration, err = time.ParseDuration(rule.DurationStr); err != nil {
+				return fmt.Errorf("invalid alerts rule %q duration: %v", rule.Name, err)
+			}
+			if rule.Duration <= 0 {
+				return fmt.Errorf("invalid alerts rule %q duration %v: expecting positive duration",
+					rule.Name, rule.Duration)
+			}
+		default:
+			return fmt.Errorf("invalid alerts rule %q kind %q (expecting one of: %q, %q, %q, %q)", rule.Name,
+				rule.Kind, AlertCapacity, AlertErrorRate, AlertMpathDisabled, AlertRebalanceDuration)
+		}
+	}
+	if c.Webhook != "" {
+		if _, err := url.ParseRequestURI(c.Webhook); err != nil {
+			return fmt.Errorf("invalid alerts.webhook %q: %v", c.Webhook, err)
+		}
+	}
+	if c.Email.Server != "" && (c.Email.From == "" || len(c.Email.To) == 0) {
+		return errors.New("alerts.email requires both 'from' and 'to'")
+	}
+	return nil
+}
+
 func KeepaliveRetryDuration(cs ...*Config) time.Duration {
 	var c *Config
 	if len(cs) != 0 {
@@ -21,4 +21,6 @@ const (
 	CtxReadWrapper contextID = "readWrapper" // context key for ReadWrapperFunc
 	CtxSetSize     contextID = "setSize"     // context key for SetSizeFunc
 	CtxOriginalURL contextID = "origURL"     // context key for OriginalURL for HTTP cloud
+	CtxReadRange   contextID = "readRange"   // context key for *HTTPRange: read only the range of the cloud object
+	CtxResidency   contextID = "residency"   // context key for *ResidencyConf to validate redirects (see CheckRedirect)
 )
@@ -37,18 +37,7 @@ func fatalMsg(f string, v ...interface{}) {
 // environment variables. It is to help enable asserts that were originally
 // used for testing/initial development and to set the verbosity of glog.
 func loadLogLevel() {
-	var (
-		opts    []string
-		modules = map[string]uint8{
-			"ais":       glog.SmoduleAIS,
-			"cluster":   glog.SmoduleCluster,
-			"fs":        glog.SmoduleFS,
-			"memsys":    glog.SmoduleMemsys,
-			"mirror":    glog.SmoduleMirror,
-			"reb":       glog.SmoduleReb,
-			"transport": glog.SmoduleTransport,
-		}
-	)
+	var opts []string
 
 	// Input will be in the format of AIS_DEBUG=transport=4,memsys=3 (same as GODEBUG).
 	if val := os.Getenv("AIS_DEBUG"); val != "" {
@@ -61,7 +50,7 @@ func loadLogLevel() {
 			fatalMsg("failed to get module=level element: %q", ele)
 		}
 		module, level := pair[0], pair[1]
-		logModule, exists := modules[module]
+		logModule, exists := glog.SmoduleByName(module)
 		if !exists {
 			fatalMsg("unknown module: %s", module)
 		}
@@ -36,6 +36,20 @@ type (
 		oos  bool
 	}
 
+	BucketReadOnlyError struct {
+		bucket    string
+		operation string
+	}
+	// data residency constraint violation (see BucketProps.Residency)
+	ResidencyError struct {
+		what  string // "host" or "region"
+		value string
+	}
+	// object naming rule violation (see BucketProps.Naming)
+	ObjNameError struct {
+		objName string
+		reason  string
+	}
 	BucketAccessDenied struct{ errAccessDenied }
 	ObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied    struct {
@@ -74,6 +88,17 @@ type (
 	NotFoundError struct {
 		what string
 	}
+	// multi-page listing that cannot be continued (see SelectMsg.SmapVersion)
+	ErrListingInvalidated struct {
+		uuid   string
+		reason string
+	}
+	// bucket props changed since a given version (see URLParamBpropsVersion)
+	ErrBpropsChanged struct {
+		bck      Bck
+		expected int64
+		actual   int64
+	}
 	ETLError struct {
 		Reason string
 		ETLErrorContext
@@ -200,6 +225,30 @@ func NewBucketAccessDenied(bucket, oper string, aattrs AccessAttrs) *BucketAcces
 	return &BucketAccessDenied{errAccessDenied{bucket, oper, aattrs}}
 }
 
+func NewBucketReadOnlyError(bucket, oper string) *BucketReadOnlyError {
+	return &BucketReadOnlyError{bucket: bucket, operation: oper}
+}
+
+func (e *BucketReadOnlyError) Error() string {
+	return fmt.Sprintf("bucket %s is read-only: %s access denied", e.bucket, e.operation)
+}
+
+func NewResidencyError(what, value string) *ResidencyError {
+	return &ResidencyError{what: what, value: value}
+}
+
+func (e *ResidencyError) Error() string {
+	return fmt.Sprintf("%s %q is not allowed by the bucket's data residency constraints", e.what, e.value)
+}
+
+func NewObjNameError(objName, reason string) *ObjNameError {
+	return &ObjNameError{objName: objName, reason: reason}
+}
+
+func (e *ObjNameError) Error() string {
+	return fmt.Sprintf("object name %q violates the bucket's naming rules: %s", e.objName, e.reason)
+}
+
 func NewErrorCapacityExceeded(high int64, used int32, oos bool) *ErrorCapacityExceeded {
 	return &ErrorCapacityExceeded{high: high, used: used, oos: oos}
 }
@@ -288,6 +337,22 @@ func NewNotFoundError(format string, a ...interface{}) *NotFoundError {
 
 func (e *NotFoundError) Error() string { return e.what + " not found" }
 
+func NewErrListingInvalidated(uuid, reason string) *ErrListingInvalidated {
+	return &ErrListingInvalidated{uuid: uuid, reason: reason}
+}
+
+func (e *ErrListingInvalidated) Error() string {
+	return fmt.Sprintf("list-objects %q invalidated: %s (restart the listing)", e.uuid, e.reason)
+}
+
+func NewErrBpropsChanged(bck Bck, expected, actual int64) *ErrBpropsChanged {
+	return &ErrBpropsChanged{bck: bck, expected: expected, actual: actual}
+}
+
+func (e *ErrBpropsChanged) Error() string {
+	return fmt.Sprintf("bucket %s: props have changed (version %d, expected %d)", e.bck, e.actual, e.expected)
+}
+
 func NewETLError(ctx *ETLErrorContext, format string, a ...interface{}) *ETLError {
 	e := &ETLError{
 		Reason: fmt.Sprintf(format, a...),
@@ -399,5 +464,23 @@ func IsObjNotExist(err error) bool {
 	_, ok := err.(*NotFoundError)
 	return ok
 }
+// the listing is to be restarted from scratch (is returned with http.StatusPreconditionFailed)
+func IsErrListingInvalidated(err error) bool {
+	if _, ok := err.(*ErrListingInvalidated); ok {
+		return true
+	}
+	httpErr, ok := err.(*HTTPError)
+	return ok && httpErr.Status == http.StatusPreconditionFailed
+}
+
+// the bucket props have been modified by someone else (is returned with http.StatusConflict)
+func IsErrBpropsChanged(err error) bool {
+	if _, ok := err.(*ErrBpropsChanged); ok {
+		return true
+	}
+	httpErr, ok := err.(*HTTPError)
+	return ok && httpErr.Status == http.StatusConflict
+}
+
 func IsErrBucketLevel(err error) bool { return IsErrBucketNought(err) }
 func IsErrObjLevel(err error) bool    { return IsErrObjNought(err) }
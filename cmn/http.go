@@ -31,6 +31,10 @@ var (
 	// ErrNoOverlap is returned by serveContent's parseRange if first-byte-pos of
 	// all of the byte-range-spec values is greater than the content size.
 	ErrNoOverlap = errors.New("invalid range: failed to overlap")
+
+	// ErrNotModified is returned by conditional GET when the object has not
+	// changed (see NotModified)
+	ErrNotModified = errors.New("not modified")
 )
 
 const (
@@ -44,14 +48,23 @@ const (
 	HeaderAccept                = "Accept"
 	HeaderLocation              = "Location"
 	HeaderETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HeaderLastModified          = "Last-Modified"
+	HeaderRetryAfter            = "Retry-After" // Ref: https://tools.ietf.org/html/rfc7231#section-7.1.3
+
+	// conditional GET, Ref: https://tools.ietf.org/html/rfc7232
+	HeaderIfNoneMatch     = "If-None-Match"
+	HeaderIfModifiedSince = "If-Modified-Since"
+	HeaderIfRange         = "If-Range" // Ref: https://tools.ietf.org/html/rfc7233#section-3.2
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
 const (
 	ContentJSON    = "application/json"
 	ContentMsgPack = "application/msgpack"
-	ContentXML     = "application/xml"
-	ContentBinary  = "application/octet-stream"
+	// list-objects and query results only (see cmn.BucketList.MarshalPB)
+	ContentProtobuf = "application/x-protobuf"
+	ContentXML      = "application/xml"
+	ContentBinary   = "application/octet-stream"
 )
 
 type (
@@ -411,6 +424,14 @@ func (r HTTPRange) ContentRange(size int64) string {
 	return fmt.Sprintf("%s%d-%d/%d", HeaderContentRangeValPrefix, r.Start, r.Start+r.Length-1, size)
 }
 
+// HdrVal returns the value of the Range request header (zero length: through the end of the object)
+func (r HTTPRange) HdrVal() string {
+	if r.Length == 0 {
+		return fmt.Sprintf("%s%d-", HeaderRangeValPrefix, r.Start)
+	}
+	return fmt.Sprintf("%s%d-%d", HeaderRangeValPrefix, r.Start, r.Start+r.Length-1)
+}
+
 // TODO: simplify the range logic
 func ParseMultiRange(s string, size int64) (ranges []HTTPRange, err error) {
 	if s == "" {
@@ -523,3 +544,37 @@ func ToHTTPHdr(meta ObjHeaderMetaProvider, hdrs ...http.Header) (hdr http.Header
 	}
 	return hdr
 }
+
+// ObjETag returns the entity tag of the object based on its checksum or, if
+// there's none, on its version; empty string when there's neither.
+func ObjETag(cksum *Cksum, version string) string {
+	if cksum != nil {
+		if ty, val := cksum.Get(); ty != ChecksumNone && val != "" {
+			return strconv.Quote(val)
+		}
+	}
+	if version != "" {
+		return strconv.Quote("v" + version)
+	}
+	return ""
+}
+
+// NotModified evaluates conditional GET request headers against the (existing)
+// object's entity tag and modification time. As per RFC 7232, If-Modified-Since
+// is ignored when If-None-Match is present; the latter uses weak comparison.
+func NotModified(hdr http.Header, etag string, mtime time.Time) bool {
+	if inm := hdr.Get(HeaderIfNoneMatch); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || (etag != "" && tag == etag) {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := hdr.Get(HeaderIfModifiedSince); ims != "" && !mtime.IsZero() {
+		since, err := http.ParseTime(ims)
+		return err == nil && !mtime.Truncate(time.Second).After(since)
+	}
+	return false
+}
@@ -98,7 +98,7 @@ func SetConfigMany(nvmap cmn.SimpleKVs) (err error) {
 	}
 
 	// Validate config after everything is set
-	if err := conf.Validate(); err != nil {
+	if err := conf.ValidateUpdate(cmn.GCO.Get()); err != nil {
 		cmn.GCO.DiscardUpdate()
 		return err
 	}
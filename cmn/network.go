@@ -26,6 +26,8 @@ type (
 	TransportArgs struct {
 		DialTimeout      time.Duration
 		Timeout          time.Duration
+		IdleConnTimeout  time.Duration // zero: http.DefaultTransport's
+		KeepAlive        time.Duration // TCP keep-alive period; zero: 30s, negative: disabled
 		SndRcvBufSize    int
 		IdleConnsPerHost int
 		MaxIdleConns     int
@@ -36,9 +38,34 @@ type (
 		// For HTTPS mode only: if true, the client does not verify server's
 		// certificate. It is useful for clusters with self-signed certificates.
 		SkipVerify bool
+		// For HTTPS mode only: attempt HTTP/2 (note that a transport with
+		// custom dialer and TLS config does not do it otherwise)
+		UseHTTP2 bool
+		// optional; when set, tracks connection pool utilization (see ClientStats)
+		Stats *ClientStats
 	}
 )
 
+// Tune overrides defaults with the non-zero values from the configuration
+func (args *TransportArgs) Tune(c *TransportConf) {
+	if c.MaxIdleConns != 0 {
+		args.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost != 0 {
+		args.IdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout != 0 {
+		args.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.DialTimeout != 0 {
+		args.DialTimeout = c.DialTimeout
+	}
+	if c.KeepAlive != 0 {
+		args.KeepAlive = c.KeepAlive
+	}
+	args.UseHTTP2 = c.HTTP2
+}
+
 func NetworkIsKnown(net string) bool {
 	return net == NetworkPublic || net == NetworkIntraControl || net == NetworkIntraData
 }
@@ -68,17 +95,29 @@ func NewTransport(args TransportArgs) *http.Transport {
 	if dialTimeout == 0 {
 		dialTimeout = 30 * time.Second
 	}
+	keepAlive := args.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = 30 * time.Second
+	}
+	idleConnTimeout := args.IdleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = defaultTransport.IdleConnTimeout
+	}
 	dialer := &net.Dialer{
 		Timeout:   dialTimeout,
-		KeepAlive: 30 * time.Second,
+		KeepAlive: keepAlive,
 	}
 	// setsockopt when non-zero, otherwise use TCP defaults
 	if args.SndRcvBufSize > 0 {
 		dialer.Control = args.setSockOpt
 	}
+	dialContext := dialer.DialContext
+	if args.Stats != nil {
+		dialContext = args.Stats.dialContext(dialer)
+	}
 	transport := &http.Transport{
-		DialContext:           dialer.DialContext,
-		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+		DialContext:           dialContext,
+		IdleConnTimeout:       idleConnTimeout,
 		TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout,
 		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
 		MaxIdleConnsPerHost:   args.IdleConnsPerHost,
@@ -88,6 +127,7 @@ func NewTransport(args TransportArgs) *http.Transport {
 	}
 	if args.UseHTTPS {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: args.SkipVerify}
+		transport.ForceAttemptHTTP2 = args.UseHTTP2
 	}
 	if args.UseHTTPProxyEnv {
 		transport.Proxy = defaultTransport.Proxy
@@ -96,7 +136,10 @@ func NewTransport(args TransportArgs) *http.Transport {
 }
 
 func NewClient(args TransportArgs) *http.Client {
-	transport := NewTransport(args)
+	var transport http.RoundTripper = NewTransport(args)
+	if args.Stats != nil {
+		transport = &statsRoundTripper{rt: transport, stats: args.Stats}
+	}
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   args.Timeout,
@@ -153,6 +153,7 @@ func (s *DynSemaphore) SetSize(n int) {
 	Assert(n >= 1)
 	s.mu.Lock()
 	s.size = n
+	s.c.Broadcast() // the size may have grown
 	s.mu.Unlock()
 }
 
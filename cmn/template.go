@@ -59,19 +59,31 @@ func (pt *ParsedTemplate) ToSlice(maxLen ...int) []string {
 }
 
 func (pt *ParsedTemplate) Iter() func() (string, bool) {
+	next := pt.IterGroups()
+	return func() (string, bool) {
+		name, _, ok := next()
+		return name, ok
+	}
+}
+
+// IterGroups is Iter that also returns the current values of the template's
+// ranges, formatted as in the generated name (e.g., "0042" for "{0000..0099}");
+// the returned slice is reused - valid only until the next call.
+func (pt *ParsedTemplate) IterGroups() func() (string, []string, bool) {
 	rangesCount := len(pt.Ranges)
 	at := make([]int64, rangesCount)
+	groups := make([]string, rangesCount)
 
 	for i, tr := range pt.Ranges {
 		at[i] = tr.Start
 	}
 
 	var buf bytes.Buffer
-	return func() (string, bool) {
+	return func() (string, []string, bool) {
 		for i := rangesCount - 1; i >= 0; i-- {
 			if at[i] > pt.Ranges[i].End {
 				if i == 0 {
-					return "", false
+					return "", nil, false
 				}
 				at[i] = pt.Ranges[i].Start
 				at[i-1] += pt.Ranges[i-1].Step
@@ -81,12 +93,46 @@ func (pt *ParsedTemplate) Iter() func() (string, bool) {
 		buf.Reset()
 		buf.WriteString(pt.Prefix)
 		for i, tr := range pt.Ranges {
-			buf.WriteString(fmt.Sprintf("%0*d%s", tr.DigitCount, at[i], tr.Gap))
+			groups[i] = fmt.Sprintf("%0*d", tr.DigitCount, at[i])
+			buf.WriteString(groups[i])
+			buf.WriteString(tr.Gap)
 		}
 
 		at[rangesCount-1] += pt.Ranges[rangesCount-1].Step
-		return buf.String(), true
+		return buf.String(), groups, true
+	}
+}
+
+// Match returns true if a given name is one of the names generated by the template
+func (pt *ParsedTemplate) Match(name string) bool {
+	if !strings.HasPrefix(name, pt.Prefix) {
+		return false
+	}
+	return pt.matchRanges(name[len(pt.Prefix):], 0)
+}
+
+func (pt *ParsedTemplate) matchRanges(s string, i int) bool {
+	if i == len(pt.Ranges) {
+		return s == ""
+	}
+	tr := &pt.Ranges[i]
+	// try every length of the leading run of digits (the gap may start with a digit)
+	for n := 1; n <= len(s) && s[n-1] >= '0' && s[n-1] <= '9'; n++ {
+		if n < tr.DigitCount || (n > tr.DigitCount && s[0] == '0') { // see `%0*d` in Iter()
+			continue
+		}
+		if !strings.HasPrefix(s[n:], tr.Gap) {
+			continue
+		}
+		v, err := strconv.ParseInt(s[:n], 10, 64)
+		if err != nil || v < tr.Start || v > tr.End || (v-tr.Start)%tr.Step != 0 {
+			continue
+		}
+		if pt.matchRanges(s[n+len(tr.Gap):], i+1) {
+			return true
+		}
 	}
+	return false
 }
 
 func ParseFmtTemplate(template string) (pt ParsedTemplate, err error) {
@@ -0,0 +1,96 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestAnalysisPrefix(t *testing.T) {
+	tests := []struct {
+		objName string
+		depth   int
+		prefix  string
+	}{
+		{"obj.jpg", 1, ""},
+		{"a/obj.jpg", 1, "a"},
+		{"a/b/c/obj.jpg", 1, "a"},
+		{"a/b/c/obj.jpg", 2, "a/b"},
+		{"a/b/c/obj.jpg", 5, "a/b/c"},
+		{"a/b/obj.jpg", 0, "a"},
+	}
+	for _, test := range tests {
+		prefix := cmn.AnalysisPrefix(test.objName, test.depth)
+		tassert.Errorf(t, prefix == test.prefix, "%q (depth %d): expected %q, got %q",
+			test.objName, test.depth, test.prefix, prefix)
+	}
+}
+
+func TestBucketAnalysisMerge(t *testing.T) {
+	var (
+		now = time.Now()
+		bck = cmn.Bck{Name: "analysis", Provider: cmn.ProviderAIS}
+		a1  = cmn.NewBucketAnalysis(bck, now)
+		a2  = cmn.NewBucketAnalysis(bck, now)
+	)
+	a1.Add("train/0001.jpg", 100, now, 1)
+	a1.Add("train/0002.jpg", 100*cmn.KiB, now.Add(-2*time.Hour), 1)
+	a2.Add("val/0001.jpg", 2*cmn.MiB, now.Add(-400*24*time.Hour), 1)
+	a2.Add("README", 10, now, 1)
+	a1.Merge(a2)
+
+	tassert.Errorf(t, a1.ObjCount == 4, "expected 4 objects, got %d", a1.ObjCount)
+	tassert.Errorf(t, a1.Size == 100+100*cmn.KiB+2*cmn.MiB+10, "unexpected size %d", a1.Size)
+	tassert.Errorf(t, a1.SizeHist[0] == 2 && a1.SizeHist[2] == 1 && a1.SizeHist[3] == 1,
+		"unexpected size histogram %v", a1.SizeHist)
+	tassert.Errorf(t, a1.AtimeHist[0] == 2 && a1.AtimeHist[1] == 1 && a1.AtimeHist[len(a1.AtimeHist)-1] == 1,
+		"unexpected atime histogram %v", a1.AtimeHist)
+	tassert.Errorf(t, a1.Extensions[".jpg"].Count == 3 && a1.Extensions[""].Count == 1,
+		"unexpected extensions %v", a1.Extensions)
+	tassert.Errorf(t, a1.Prefixes["train"].Count == 2 && a1.Prefixes["val"].Size == 2*cmn.MiB,
+		"unexpected prefixes %v", a1.Prefixes)
+}
+
+func TestDedupReport(t *testing.T) {
+	var (
+		now = time.Now()
+		bck = cmn.Bck{Name: "analysis", Provider: cmn.ProviderAIS}
+		a1  = cmn.NewBucketAnalysis(bck, now)
+		a2  = cmn.NewBucketAnalysis(bck, now)
+		ck1 = cmn.NewCksum(cmn.ChecksumXXHash, "1111")
+		ck2 = cmn.NewCksum(cmn.ChecksumXXHash, "2222")
+	)
+	a1.Dedup, a2.Dedup = cmn.NewDedupReport(), cmn.NewDedupReport()
+	a1.Dedup.Add("a", cmn.MiB, ck1)
+	a1.Dedup.Add("b", cmn.MiB, ck1)
+	a1.Dedup.Add("c", cmn.KiB, ck2)
+	a1.Dedup.Add("d", 10, nil)
+	a2.Dedup.Add("e", cmn.MiB, ck1)
+	a2.Dedup.Add("f", cmn.KiB, ck2)
+	a2.Dedup.Add("g", 2*cmn.KiB, ck2) // same checksum, different size - not a duplicate
+	a2.Dedup.Add("h", 10, cmn.NewCksum(cmn.ChecksumNone, ""))
+
+	merged := cmn.NewBucketAnalysis(bck, now)
+	merged.Merge(a1)
+	merged.Merge(a2)
+	report := merged.Dedup
+	tassert.Fatalf(t, report != nil, "expected dedup report")
+	report.Finalize()
+
+	tassert.Errorf(t, report.GroupCnt == 2, "expected 2 groups, got %d", report.GroupCnt)
+	tassert.Errorf(t, report.DupCnt == 3, "expected 3 duplicates, got %d", report.DupCnt)
+	tassert.Errorf(t, report.Reclaimable == 2*cmn.MiB+cmn.KiB, "unexpected reclaimable size %d", report.Reclaimable)
+	tassert.Fatalf(t, len(report.Groups) == 2, "expected 2 groups, got %d", len(report.Groups))
+	g := report.Groups[0]
+	tassert.Errorf(t, g.Cksum == cmn.ChecksumXXHash+":1111" && g.Size == cmn.MiB && g.Count == 3,
+		"unexpected largest group %+v", g)
+	tassert.Errorf(t, len(g.Objects) == 3 && g.Objects[0] == "a" && g.Objects[2] == "e",
+		"unexpected objects %v", g.Objects)
+	tassert.Errorf(t, report.Contents == nil, "expected contents to be dropped")
+}
@@ -5,6 +5,8 @@
 package tests
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	. "github.com/onsi/ginkgo"
@@ -179,4 +181,59 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("EphemeralConf", func() {
+		var (
+			now     = time.Now()
+			created = now.Add(-time.Hour).UnixNano()
+		)
+
+		DescribeTable("should be enabled, validated and expired",
+			func(conf cmn.EphemeralConf, enabled, valid, expired bool) {
+				Expect(conf.Enabled()).To(Equal(enabled))
+				if valid {
+					Expect(conf.ValidateAsProps(nil)).NotTo(HaveOccurred())
+				} else {
+					Expect(conf.ValidateAsProps(nil)).To(HaveOccurred())
+				}
+				Expect(conf.Expired(created, now)).To(Equal(expired))
+			},
+			Entry("disabled", cmn.EphemeralConf{}, false, true, false),
+			Entry("owner only", cmn.EphemeralConf{OwnerID: "job"}, true, true, false),
+			Entry("expired TTL", cmn.EphemeralConf{TTLStr: "30m"}, true, true, true),
+			Entry("TTL and owner", cmn.EphemeralConf{TTLStr: "2h", OwnerID: "job"}, true, true, false),
+			Entry("negative TTL", cmn.EphemeralConf{TTLStr: "-1h"}, true, false, false),
+			Entry("invalid TTL", cmn.EphemeralConf{TTLStr: "forever"}, true, false, false),
+			Entry("dSort owner",
+				cmn.EphemeralConf{OwnerID: "job", OwnerKind: cmn.DSortNameLowercase}, true, true, false),
+			Entry("owner kind without owner",
+				cmn.EphemeralConf{OwnerKind: cmn.DSortNameLowercase}, false, false, false),
+			Entry("unsupported owner kind", cmn.EphemeralConf{OwnerID: "job", OwnerKind: "lru"}, true, false, false),
+		)
+
+		It("should be allowed for ais buckets only", func() {
+			props := cmn.DefaultAISBckProps()
+			props.Provider = cmn.ProviderAIS
+			props.Ephemeral = cmn.EphemeralConf{TTLStr: "1h"}
+			Expect(props.Validate(1)).NotTo(HaveOccurred())
+
+			props = cmn.DefaultAISBckProps()
+			props.Provider = cmn.ProviderAmazon
+			props.Ephemeral = cmn.EphemeralConf{TTLStr: "1h"}
+			Expect(props.Validate(1)).To(HaveOccurred())
+		})
+	})
+
+	Describe("DirectReadConf", func() {
+		DescribeTable("should use direct read for objects of at least the minimum size",
+			func(conf cmn.DirectReadConf, size int64, use bool) {
+				Expect(conf.Use(size)).To(Equal(use))
+			},
+			Entry("disabled", cmn.DirectReadConf{MinSize: cmn.MiB}, int64(cmn.GiB), false),
+			Entry("below the minimum", cmn.DirectReadConf{Enabled: true, MinSize: cmn.MiB}, int64(cmn.MiB-1), false),
+			Entry("minimum size", cmn.DirectReadConf{Enabled: true, MinSize: cmn.MiB}, int64(cmn.MiB), true),
+			Entry("above the minimum", cmn.DirectReadConf{Enabled: true, MinSize: cmn.MiB}, int64(cmn.GiB), true),
+			Entry("no minimum", cmn.DirectReadConf{Enabled: true}, int64(0), true),
+		)
+	})
 })
@@ -0,0 +1,74 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestBpropsPlan(t *testing.T) {
+	var (
+		from = cmn.DefaultAISBckProps()
+		ts   = &cmn.BpropsTargetStats{
+			ObjCount:   100,
+			Size:       100 * cmn.MiB,
+			Used:       500 * cmn.MiB,
+			Total:      1000 * cmn.MiB,
+			HighWM:     90,
+			Throughput: 100 * cmn.MiB,
+		}
+	)
+	from.Cksum.Type = cmn.ChecksumXXHash
+
+	// nothing heavy
+	to := from.Clone()
+	to.Versioning.Enabled = !from.Versioning.Enabled
+	to.Cksum.Type = cmn.ChecksumNone
+	est := ts.Estimate(from, to)
+	tassert.Errorf(t, len(cmn.BpropsChanges(from, to)) == 0, "expected no heavy changes")
+	tassert.Errorf(t, est.ReadBytes == 0 && est.Capacity == 0 && est.Sufficient, "unexpected estimate %+v", est)
+	tassert.Errorf(t, est.Headroom == 400*cmn.MiB, "expected 400MiB headroom, got %d", est.Headroom)
+
+	// 3-way mirror
+	to = from.Clone()
+	to.Mirror.Enabled, to.Mirror.Copies = true, 3
+	est = ts.Estimate(from, to)
+	tassert.Errorf(t, est.ReadBytes == 100*cmn.MiB && est.WriteBytes == 200*cmn.MiB && est.Capacity == 200*cmn.MiB,
+		"unexpected mirror estimate %+v", est)
+	tassert.Errorf(t, est.Time == 3*time.Second, "expected 3s, got %v", est.Time)
+
+	// EC: large objects are sliced (D+P)/D, small ones are replicated P times
+	to = from.Clone()
+	to.EC.Enabled, to.EC.DataSlices, to.EC.ParitySlices = true, 2, 2
+	to.EC.ObjSizeLimit = cmn.KiB
+	est = ts.Estimate(from, to)
+	tassert.Errorf(t, est.Capacity == 200*cmn.MiB, "unexpected EC estimate %+v", est)
+	to.EC.ObjSizeLimit = 2 * cmn.MiB
+	to.Cksum.Type = cmn.ChecksumMD5
+	est = ts.Estimate(from, to)
+	tassert.Errorf(t, reflect.DeepEqual(cmn.BpropsChanges(from, to), []string{cmn.BpropsChangeEC, cmn.BpropsChangeChecksum}),
+		"unexpected changes %v", cmn.BpropsChanges(from, to))
+	tassert.Errorf(t, est.ReadBytes == 100*cmn.MiB && est.Capacity == 200*cmn.MiB, "unexpected EC estimate %+v", est)
+
+	// insufficient headroom on one of the targets
+	plan := cmn.NewBpropsPlan(cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}, cmn.BpropsChanges(from, to))
+	plan.Merge("t1", est)
+	tassert.CheckFatal(t, plan.Insufficient())
+	ts.Used = 800 * cmn.MiB
+	plan.Merge("t2", ts.Estimate(from, to))
+	tassert.Errorf(t, plan.Total.Capacity == 400*cmn.MiB && plan.Total.Headroom == 500*cmn.MiB,
+		"unexpected total %+v", plan.Total)
+	tassert.Errorf(t, plan.Insufficient() != nil, "expected insufficient headroom")
+
+	// no current throughput - unknown time
+	ts.Throughput = 0
+	est = ts.Estimate(from, to)
+	tassert.Errorf(t, est.Time == 0, "expected unknown time, got %v", est.Time)
+}
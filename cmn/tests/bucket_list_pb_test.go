@@ -0,0 +1,44 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestBucketListProtobuf(t *testing.T) {
+	list := &cmn.BucketList{
+		UUID: "uuid",
+		Entries: []*cmn.BucketEntry{
+			{Name: "a", Size: 1024, Checksum: "abc", Atime: "now", Version: "2", TargetURL: "http://t1", Copies: 2, Flags: cmn.ObjStatusMoved},
+			{Name: "b"},
+			{Name: "c", Size: -1, Copies: -1},
+		},
+		ContinuationToken: "c",
+		SmapVersion:       12,
+		BMDVersion:        7,
+	}
+	b := list.MarshalPB()
+
+	// unknown fields (added by later versions) must be skipped
+	b = protowire.AppendTag(b, 100, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, 42)
+
+	decoded := &cmn.BucketList{}
+	tassert.CheckFatal(t, decoded.UnmarshalPB(b))
+	tassert.Fatalf(t, reflect.DeepEqual(list, decoded), "expected %+v, got %+v", list, decoded)
+
+	err := decoded.UnmarshalPB(b[:len(b)-3])
+	tassert.Errorf(t, err != nil, "expected error on truncated message")
+
+	empty := &cmn.BucketList{}
+	tassert.CheckFatal(t, empty.UnmarshalPB((&cmn.BucketList{}).MarshalPB()))
+	tassert.Errorf(t, len(empty.Entries) == 0 && empty.UUID == "", "expected empty list, got %+v", empty)
+}
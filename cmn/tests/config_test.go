@@ -36,6 +36,62 @@ func TestConfigFSPaths(t *testing.T) {
 	tassert.CheckFatal(t, jsp.LoadConfig(confPath))
 }
 
+func TestConfigProfile(t *testing.T) {
+	var (
+		config   = &cmn.Config{}
+		confPath = filepath.Join(thisFileDir(t), "configs", "configtest.json")
+	)
+	tassert.CheckFatal(t, jsp.Load(confPath, config, jsp.Options{}))
+	config.Cloud = cmn.CloudConf{} // not relevant
+	config.FSHC.Enabled = false
+	config.Auth.Enabled = true
+	for _, profile := range []string{"", cmn.ProfileDev, cmn.ProfileStaging} {
+		config.Profile = profile
+		tassert.CheckError(t, config.Validate())
+	}
+	config.Profile = cmn.ProfileProd
+	tassert.Errorf(t, config.Validate() != nil, "expected fshc disabled to be rejected in %q profile", config.Profile)
+
+	config.FSHC.Enabled = true
+	tassert.CheckError(t, config.Validate())
+	config.Cksum.Type = cmn.ChecksumNone
+	tassert.Errorf(t, config.Validate() != nil, "expected checksum none to be rejected in %q profile", config.Profile)
+
+	config.Profile = "production"
+	tassert.Errorf(t, config.Validate() != nil, "expected invalid profile to be rejected")
+}
+
+func TestConfigProfileUpdate(t *testing.T) {
+	var (
+		config   = &cmn.Config{}
+		confPath = filepath.Join(thisFileDir(t), "configs", "configtest.json")
+	)
+	tassert.CheckFatal(t, jsp.Load(confPath, config, jsp.Options{}))
+	config.Cloud = cmn.CloudConf{} // not relevant
+	config.FSHC.Enabled = true
+	config.Auth.Enabled = false // comes with the config file
+
+	for _, profile := range []string{cmn.ProfileDev, cmn.ProfileStaging} {
+		old := *config
+		old.Profile = profile
+		updated := old
+		tassert.CheckError(t, updated.ValidateUpdate(&old))
+
+		updated.FSHC.Enabled = false
+		err := updated.ValidateUpdate(&old)
+		if profile == cmn.ProfileDev {
+			tassert.CheckError(t, err)
+		} else {
+			tassert.Errorf(t, err != nil, "expected disabling fshc at runtime to be rejected in %q profile", profile)
+		}
+
+		// turning a dev-only setting off is always allowed
+		updated = old
+		updated.Auth.Enabled = true
+		tassert.CheckError(t, updated.ValidateUpdate(&old))
+	}
+}
+
 func thisFileDir(t *testing.T) string {
 	_, filename, _, ok := runtime.Caller(1)
 	tassert.Fatalf(t, ok, "Taking path of a file failed")
@@ -54,3 +110,17 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestFSPathsLabels(t *testing.T) {
+	var (
+		fspaths cmn.FSPathsConf
+		data    = []byte(`{"/tmp/ais/1": "nvme", "/tmp/ais/2/": "hdd", "/tmp/ais/3": " "}`)
+	)
+	tassert.CheckFatal(t, fspaths.UnmarshalJSON(data))
+	tassert.CheckFatal(t, fspaths.Validate(&cmn.Config{}))
+	tassert.Errorf(t, len(fspaths.Paths) == 3, "expected 3 fspaths, got %v", fspaths.Paths)
+	tassert.Errorf(t, fspaths.Labels["/tmp/ais/1"] == "nvme" && fspaths.Labels["/tmp/ais/2"] == "hdd",
+		"unexpected labels %v", fspaths.Labels)
+	_, ok := fspaths.Labels["/tmp/ais/3"]
+	tassert.Errorf(t, !ok, "expected no label for blank value")
+}
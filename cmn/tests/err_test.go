@@ -7,6 +7,7 @@ package tests
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -23,3 +24,27 @@ func TestAbortedErrorAs(t *testing.T) {
 	mockError := fmt.Errorf("wrapping aborted error %w", abortedError)
 	tassert.Fatalf(t, errors.As(mockError, &cmn.AbortedError{}), "expected errors.As to return true on a wrapped error")
 }
+
+func TestIsErrListingInvalidated(t *testing.T) {
+	err := cmn.NewErrListingInvalidated("uuid", "rebalance is running")
+	tassert.Errorf(t, cmn.IsErrListingInvalidated(err), "expected %v to be listing-invalidated", err)
+
+	// as received by the client
+	httpErr := &cmn.HTTPError{Status: http.StatusPreconditionFailed, Message: err.Error()}
+	tassert.Errorf(t, cmn.IsErrListingInvalidated(httpErr), "expected %v to be listing-invalidated", httpErr)
+
+	httpErr = &cmn.HTTPError{Status: http.StatusNotFound, Message: "not found"}
+	tassert.Errorf(t, !cmn.IsErrListingInvalidated(httpErr), "expected %v not to be listing-invalidated", httpErr)
+}
+
+func TestIsErrBpropsChanged(t *testing.T) {
+	err := cmn.NewErrBpropsChanged(cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}, 3, 4)
+	tassert.Errorf(t, cmn.IsErrBpropsChanged(err), "expected %v to be props-changed", err)
+
+	// as received by the client
+	httpErr := &cmn.HTTPError{Status: http.StatusConflict, Message: err.Error()}
+	tassert.Errorf(t, cmn.IsErrBpropsChanged(httpErr), "expected %v to be props-changed", httpErr)
+
+	httpErr = &cmn.HTTPError{Status: http.StatusBadRequest, Message: "bad request"}
+	tassert.Errorf(t, !cmn.IsErrBpropsChanged(httpErr), "expected %v not to be props-changed", httpErr)
+}
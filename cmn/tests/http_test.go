@@ -5,10 +5,15 @@
 package tests
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
 )
 
 func TestMatchRESTItems(t *testing.T) {
@@ -85,3 +90,96 @@ func TestMatchRESTItems(t *testing.T) {
 		}
 	}
 }
+
+func TestClientStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var (
+		cs     = cmn.NewClientStats("test-client")
+		client = cmn.NewClient(cmn.TransportArgs{IdleConnsPerHost: 4, Stats: cs})
+		cnt    = 10
+	)
+	for i := 0; i < cnt; i++ {
+		resp, err := client.Get(srv.URL)
+		tassert.CheckFatal(t, err)
+		_, err = ioutil.ReadAll(resp.Body)
+		tassert.CheckFatal(t, err)
+		resp.Body.Close()
+	}
+	snap := cs.Snap()
+	tassert.Errorf(t, snap.Requests == int64(cnt), "expected %d requests, got %d", cnt, snap.Requests)
+	tassert.Errorf(t, snap.Dials == 1, "expected a single dial, got %d", snap.Dials)
+	tassert.Errorf(t, snap.Reused == int64(cnt-1), "expected %d reused, got %d", cnt-1, snap.Reused)
+	tassert.Errorf(t, snap.Open == 1 && snap.InFlight == 0, "expected 1 open and 0 in-flight, got %d and %d",
+		snap.Open, snap.InFlight)
+	_, ok := cmn.ClientStatsAll()[cs.Name()]
+	tassert.Errorf(t, ok, "%q is not registered", cs.Name())
+
+	client.CloseIdleConnections()
+	tassert.Errorf(t, cs.Snap().Open == 0, "expected no open connections, got %d", cs.Snap().Open)
+}
+
+func TestNotModified(t *testing.T) {
+	var (
+		mtime = time.Date(2020, 6, 1, 12, 0, 0, 500, time.UTC)
+		etag  = cmn.ObjETag(cmn.NewCksum(cmn.ChecksumXXHash, "abc"), "3")
+	)
+	tassert.Fatalf(t, etag == `"abc"`, "unexpected etag %s", etag)
+	tassert.Errorf(t, cmn.ObjETag(cmn.NewCksum(cmn.ChecksumNone, ""), "3") == `"v3"`, "expected version-based etag")
+	tassert.Errorf(t, cmn.ObjETag(nil, "") == "", "expected no etag")
+
+	tests := []struct {
+		inm, ims    string
+		notModified bool
+	}{
+		{"", "", false},
+		{`"abc"`, "", true},
+		{`W/"abc"`, "", true},
+		{`"xyz", "abc"`, "", true},
+		{`"xyz"`, "", false},
+		{"*", "", true},
+		{"", mtime.Format(http.TimeFormat), true},
+		{"", mtime.Add(time.Hour).Format(http.TimeFormat), true},
+		{"", mtime.Add(-time.Second).Format(http.TimeFormat), false},
+		{"", "garbage", false},
+		{`"xyz"`, mtime.Add(time.Hour).Format(http.TimeFormat), false}, // If-None-Match takes precedence
+	}
+	for _, test := range tests {
+		hdr := make(http.Header)
+		if test.inm != "" {
+			hdr.Set(cmn.HeaderIfNoneMatch, test.inm)
+		}
+		if test.ims != "" {
+			hdr.Set(cmn.HeaderIfModifiedSince, test.ims)
+		}
+		tassert.Errorf(t, cmn.NotModified(hdr, etag, mtime) == test.notModified,
+			"If-None-Match %q, If-Modified-Since %q: expected not-modified=%t", test.inm, test.ims, test.notModified)
+	}
+}
+
+func TestHTTPRangeHdrVal(t *testing.T) {
+	const size = 1000
+	tests := []struct {
+		rng      cmn.HTTPRange
+		hdr      string
+		expected cmn.HTTPRange
+	}{
+		{rng: cmn.HTTPRange{Start: 0, Length: 10}, hdr: "bytes=0-9", expected: cmn.HTTPRange{Start: 0, Length: 10}},
+		{rng: cmn.HTTPRange{Start: 990, Length: 10}, hdr: "bytes=990-999", expected: cmn.HTTPRange{Start: 990, Length: 10}},
+		// zero length: through the end of the object
+		{rng: cmn.HTTPRange{Start: 100}, hdr: "bytes=100-", expected: cmn.HTTPRange{Start: 100, Length: 900}},
+	}
+	for _, test := range tests {
+		hdr := test.rng.HdrVal()
+		tassert.Errorf(t, hdr == test.hdr, "expected %q, got %q", test.hdr, hdr)
+		ranges, err := cmn.ParseMultiRange(hdr, size)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(ranges) == 1, "expected a single range, got %v", ranges)
+		tassert.Errorf(t, ranges[0] == test.expected, "expected %+v, got %+v", test.expected, ranges[0])
+	}
+	cr := cmn.HTTPRange{Start: 990, Length: 10}.ContentRange(size)
+	tassert.Errorf(t, cr == "bytes 990-999/1000", "unexpected content range %q", cr)
+}
@@ -63,6 +63,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.util_thresh":  int64(0),
 					"mirror.burst_buffer": int64(0),
 					"mirror.optimize_put": false,
+					"mirror.hot_label":    "",
 
 					"ec.enabled":       true,
 					"ec.parity_slices": 1024,
@@ -80,18 +81,58 @@ var _ = Describe("IterFields", func() {
 					"checksum.validate_obj_move": false,
 					"checksum.enable_read_range": false,
 
-					"lru.enabled":           false,
-					"lru.lowwm":             int64(0),
-					"lru.highwm":            int64(0),
-					"lru.out_of_space":      int64(0),
-					"lru.dont_evict_time":   "",
-					"lru.capacity_upd_time": "",
+					"lru.enabled":             false,
+					"lru.lowwm":               int64(0),
+					"lru.highwm":              int64(0),
+					"lru.out_of_space":        int64(0),
+					"lru.dont_evict_time":     "",
+					"lru.dont_evict_prefixes": "",
+					"lru.priority":            int64(0),
+					"lru.capacity_upd_time":   "",
 
-					"extra.original_url": "",
-					"extra.cloud_region": "",
+					"extra.original_url":   "",
+					"extra.cloud_region":   "",
+					"extra.aws.endpoint":   "",
+					"extra.aws.path_style": false,
 
-					"access":  cmn.AccessAttrs(0),
-					"created": int64(0),
+					"direct_read.enabled":  false,
+					"direct_read.min_size": int64(0),
+
+					"ephemeral.owner_id":   "",
+					"ephemeral.owner_kind": "",
+					"ephemeral.ttl":        "",
+
+					"events.type":  "",
+					"events.url":   "",
+					"events.topic": "",
+
+					"residency.hosts":   "",
+					"residency.regions": "",
+
+					"dedup.enabled": false,
+
+					"lifecycle.ttl":               "",
+					"lifecycle.delete_after":      "",
+					"lifecycle.evict_cloud_after": "",
+
+					"naming.max_len":         0,
+					"naming.forbidden_chars": "",
+					"naming.prefix_pattern":  "",
+
+					"replication.target_cluster": "",
+					"replication.bucket":         "",
+					"replication.mode":           "",
+
+					"cold_get.max_concurrent": 0,
+					"cold_get.weight":         0,
+
+					"md_index.enabled": false,
+					"md_index.keys":    "",
+
+					"access":        cmn.AccessAttrs(0),
+					"read_only":     false,
+					"created":       int64(0),
+					"props_version": int64(0),
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -115,6 +156,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.util_thresh":  (*int64)(nil),
 					"mirror.burst_buffer": (*int64)(nil),
 					"mirror.optimize_put": (*bool)(nil),
+					"mirror.hot_label":    (*string)(nil),
 
 					"ec.enabled":       api.Bool(true),
 					"ec.parity_slices": api.Int(1024),
@@ -131,12 +173,52 @@ var _ = Describe("IterFields", func() {
 					"checksum.validate_obj_move": (*bool)(nil),
 					"checksum.enable_read_range": (*bool)(nil),
 
-					"lru.enabled":      (*bool)(nil),
-					"lru.lowwm":        (*int64)(nil),
-					"lru.highwm":       (*int64)(nil),
-					"lru.out_of_space": (*int64)(nil),
+					"lru.enabled":             (*bool)(nil),
+					"lru.lowwm":               (*int64)(nil),
+					"lru.highwm":              (*int64)(nil),
+					"lru.out_of_space":        (*int64)(nil),
+					"lru.dont_evict_prefixes": (*string)(nil),
+					"lru.priority":            (*int64)(nil),
+
+					"direct_read.enabled":  (*bool)(nil),
+					"direct_read.min_size": (*int64)(nil),
+
+					"ephemeral.owner_id":   (*string)(nil),
+					"ephemeral.owner_kind": (*string)(nil),
+					"ephemeral.ttl":        (*string)(nil),
 
-					"access": api.AccessAttrs(1024),
+					"events.type":  (*string)(nil),
+					"events.url":   (*string)(nil),
+					"events.topic": (*string)(nil),
+
+					"residency.hosts":   (*string)(nil),
+					"residency.regions": (*string)(nil),
+
+					"dedup.enabled": (*bool)(nil),
+
+					"lifecycle.ttl":               (*string)(nil),
+					"lifecycle.delete_after":      (*string)(nil),
+					"lifecycle.evict_cloud_after": (*string)(nil),
+
+					"naming.max_len":         (*int)(nil),
+					"naming.forbidden_chars": (*string)(nil),
+					"naming.prefix_pattern":  (*string)(nil),
+
+					"replication.target_cluster": (*string)(nil),
+					"replication.bucket":         (*string)(nil),
+					"replication.mode":           (*string)(nil),
+
+					"cold_get.max_concurrent": (*int)(nil),
+					"cold_get.weight":         (*int)(nil),
+
+					"md_index.enabled": (*bool)(nil),
+					"md_index.keys":    (*string)(nil),
+
+					"extra.aws.endpoint":   (*string)(nil),
+					"extra.aws.path_style": (*bool)(nil),
+
+					"access":    api.AccessAttrs(1024),
+					"read_only": (*bool)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -186,6 +268,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.util_thresh":  int64(0),
 					"mirror.burst_buffer": "9560", // type == int64
 					"mirror.optimize_put": false,
+					"mirror.hot_label":    "nvme",
 
 					"ec.enabled":       true,
 					"ec.parity_slices": 1024,
@@ -200,9 +283,10 @@ var _ = Describe("IterFields", func() {
 				},
 				&cmn.BucketProps{
 					Mirror: cmn.MirrorConf{
-						Enabled: true,
-						Copies:  120,
-						Burst:   9560,
+						Enabled:  true,
+						Copies:   120,
+						Burst:    9560,
+						HotLabel: "nvme",
 					},
 					EC: cmn.ECConf{
 						Enabled:      true,
@@ -239,6 +323,8 @@ var _ = Describe("IterFields", func() {
 
 					"checksum.type": cmn.ChecksumXXHash,
 
+					"extra.aws.endpoint": "http://minio:9000",
+
 					"access": "12", // type == uint64
 				},
 				&cmn.BucketPropsToUpdate{
@@ -260,6 +346,9 @@ var _ = Describe("IterFields", func() {
 						Type:            api.String(cmn.ChecksumXXHash),
 						ValidateWarmGet: api.Bool(true),
 					},
+					Extra: &cmn.ExtraToUpdate{
+						AWS: &cmn.ExtraPropsAWSToUpdate{Endpoint: api.String("http://minio:9000")},
+					},
 					Access: api.AccessAttrs(12),
 				},
 			),
@@ -0,0 +1,52 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestNamingRules(t *testing.T) {
+	conf := cmn.NamingConf{MaxLen: 16, ForbiddenChars: ":*?", PrefixPattern: "(train|val)/"}
+	if err := conf.ValidateAsProps(nil); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		objName string
+		valid   bool
+	}{
+		{"train/0001.tar", true},
+		{"val/a", true},
+		{"test/0001.tar", false},
+		{"x/train/0001.tar", false},
+		{"train/a:b", false},
+		{"val/" + strings.Repeat("a", 12), true},
+		{"val/" + strings.Repeat("a", 13), false},
+	}
+	for _, test := range tests {
+		err := conf.ValidateObjName(test.objName)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid=%t, got err: %v", test.objName, test.valid, err)
+		}
+		if _, ok := err.(*cmn.ObjNameError); err != nil && !ok {
+			t.Errorf("%q: unexpected error type %T", test.objName, err)
+		}
+	}
+
+	// zero values - no restrictions
+	empty := cmn.NamingConf{}
+	if empty.Enabled() || empty.ValidateObjName(strings.Repeat("?", 1024)) != nil {
+		t.Error("expected empty naming rules to allow all")
+	}
+	if err := (&cmn.NamingConf{PrefixPattern: "(train"}).ValidateAsProps(nil); err == nil {
+		t.Error("expected invalid regular expression to fail validation")
+	}
+	if err := (&cmn.NamingConf{MaxLen: -1}).ValidateAsProps(nil); err == nil {
+		t.Error("expected negative max_len to fail validation")
+	}
+}
@@ -0,0 +1,48 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestResidency(t *testing.T) {
+	conf := cmn.ResidencyConf{Hosts: "storage.example.com, .eu.example.org", Regions: "eu-west-1,eu-central-1"}
+	if err := conf.ValidateAsProps(nil); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		link    string
+		allowed bool
+	}{
+		{"https://storage.example.com/data/shard-0001.tar", true},
+		{"http://STORAGE.example.com:8080/shard", true},
+		{"https://a.b.eu.example.org/shard", true},
+		{"https://eu.example.org/shard", false},
+		{"https://example.com/shard", false},
+		{"https://storage.example.com.evil.io/shard", false},
+	}
+	for _, test := range tests {
+		if err := conf.AllowURL(test.link); (err == nil) != test.allowed {
+			t.Errorf("%s: expected allowed=%t, got err: %v", test.link, test.allowed, err)
+		}
+	}
+	for region, allowed := range map[string]bool{"eu-west-1": true, "EU-CENTRAL-1": true, "us-east-1": false, "": false} {
+		if err := conf.AllowRegion(region); (err == nil) != allowed {
+			t.Errorf("region %q: expected allowed=%t, got err: %v", region, allowed, err)
+		}
+	}
+
+	// empty lists allow all
+	empty := cmn.ResidencyConf{}
+	if empty.Enabled() || empty.AllowURL("https://example.com/shard") != nil || empty.AllowRegion("") != nil {
+		t.Error("expected empty residency constraints to allow all")
+	}
+	if err := (&cmn.ResidencyConf{Hosts: "https://example.com"}).ValidateAsProps(nil); err == nil {
+		t.Error("expected URL (rather than host name) to fail validation")
+	}
+}
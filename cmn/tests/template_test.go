@@ -0,0 +1,62 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestTemplateMatch(t *testing.T) {
+	tests := []struct {
+		template string
+		matches  []string
+		others   []string
+	}{
+		{
+			template: "shard-{0000..0099}.tar",
+			matches:  []string{"shard-0000.tar", "shard-0042.tar", "shard-0099.tar"},
+			others:   []string{"shard-0100.tar", "shard-42.tar", "shard-00042.tar", "shard-0042.tgz", "xshard-0042.tar"},
+		},
+		{
+			template: "a-{1..10..3}-b-{01..02}",
+			matches:  []string{"a-1-b-01", "a-4-b-02", "a-10-b-01"},
+			others:   []string{"a-2-b-01", "a-13-b-01", "a-1-b-03", "a-1-b-1", "a-1-b-01-"},
+		},
+		{
+			template: "obj{1..3}1{10..12}", // gap starts with a digit
+			matches:  []string{"obj1110", "obj3112"},
+			others:   []string{"obj4110", "obj119", "obj11"},
+		},
+		{
+			template: "{0..5}",
+			matches:  []string{"0", "5"},
+			others:   []string{"", "6", "05"},
+		},
+	}
+	for _, test := range tests {
+		pt, err := cmn.ParseBashTemplate(test.template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range test.matches {
+			if !pt.Match(name) {
+				t.Errorf("%q: expected %q to match", test.template, name)
+			}
+		}
+		for _, name := range test.others {
+			if pt.Match(name) {
+				t.Errorf("%q: expected %q not to match", test.template, name)
+			}
+		}
+		// all generated names must match
+		for it, name, ok := pt.Iter(), "", true; ok; {
+			if name, ok = it(); ok && !pt.Match(name) {
+				t.Errorf("%q: expected generated %q to match", test.template, name)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 cat > $AIS_CONF_FILE <<EOL
 {
 	"confdir": "${AIS_CONF_DIR}",
+	"profile": "${AIS_PROFILE:-dev}",
   "cloud": {
     $(for i in ${AIS_CLD_PROVIDERS};do echo -n "\"${i}\":{}", ;done | sed 's/,$//')
   },
@@ -41,19 +42,32 @@ cat > $AIS_CONF_FILE <<EOL
 		"client_timeout":      "10s",
 		"client_long_timeout": "30m",
 		"allow_direct_access": false,
-		"list_timeout":        "3m"
+		"list_timeout":        "3m",
+		"transport": {
+			"max_idle_conns":          0,
+			"max_idle_conns_per_host": 32,
+			"idle_conn_timeout":       "90s",
+			"dial_timeout":            "30s",
+			"tcp_keepalive":           "30s",
+			"http2":                   false
+		}
 	},
 	"proxy": {
 		"primary_url":   "${AIS_PRIMARY_URL}",
 		"original_url":  "${AIS_PRIMARY_URL}",
 		"discovery_url": "${AIS_DISCOVERY_URL}",
-		"non_electable": ${NON_ELECTABLE:-false}
+		"non_electable":   ${NON_ELECTABLE:-false},
+		"election_quorum": 0,
+		"witness_url":     "",
+		"witness_secret":  ""
 	},
 	"lru": {
 		"lowwm":             75,
 		"highwm":            90,
 		"out_of_space":      95,
 		"dont_evict_time":   "120m",
+		"dont_evict_prefixes": "",
+		"priority":          0,
 		"capacity_upd_time": "10m",
 		"enabled":           true
 	},
@@ -62,7 +76,8 @@ cat > $AIS_CONF_FILE <<EOL
 	    "iostat_time_short": "${IOSTAT_TIME_SHORT:-100ms}",
 	    "disk_util_low_wm":  20,
 	    "disk_util_high_wm": 80,
-	    "disk_util_max_wm":  95
+	    "disk_util_max_wm":  95,
+	    "vmd_remap":         false
 	},
 	"rebalance": {
 		"enabled":         true,
@@ -70,6 +85,7 @@ cat > $AIS_CONF_FILE <<EOL
 		"dest_retry_time": "2m",
 		"quiescent":       "10s",
 		"compression":     "${COMPRESSION:-never}",
+		"prefill":         false,
 		"multiplier":      ${REBALANCE_MULTIPLIER:-4}
 	},
 	"checksum": {
@@ -140,7 +156,15 @@ cat > $AIS_CONF_FILE <<EOL
 		"timeout_factor": 3
 	},
 	"downloader": {
-		"timeout": "1h"
+		"timeout": "1h",
+		"transport": {
+			"max_idle_conns":          0,
+			"max_idle_conns_per_host": 16,
+			"idle_conn_timeout":       "90s",
+			"dial_timeout":            "30s",
+			"tcp_keepalive":           "30s",
+			"http2":                   true
+		}
 	},
 	"distributed_sort": {
 		"duplicated_records":    "ignore",
@@ -151,6 +175,34 @@ cat > $AIS_CONF_FILE <<EOL
 		"dsorter_mem_threshold": "100GB",
 		"compression":           "${COMPRESSION:-never}",
 		"call_timeout":          "10m"
+	},
+	"xaction": {
+		"max_heavy_per_bucket": 0,
+		"max_heavy_per_ns":     0,
+		"max_heavy_per_target": 0
+	},
+	"scrub": {
+		"pause":   "1s",
+		"enabled": ${SCRUB_ENABLED:-false}
+	},
+	"metasync": {
+		"mode":      "${METASYNC_MODE:-direct}",
+		"fanout":    8,
+		"min_nodes": 64
+	},
+	"alerts": {
+		"enabled": false,
+		"rules":   [],
+		"webhook": "",
+		"email": {
+			"server": "",
+			"from":   "",
+			"to":     []
+		}
+	},
+	"cold_get": {
+		"max_concurrent": 0,
+		"max_wait":       "30s"
 	}
 }
 EOL
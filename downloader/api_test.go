@@ -0,0 +1,234 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestDlLimits(t *testing.T) {
+	tests := []struct {
+		limits DlLimits
+		valid  bool
+	}{
+		{DlLimits{Connections: 4, BytesPerHour: 1024}, true},
+		{DlLimits{}, true}, // unlimited
+		{DlLimits{BytesPerHour: 1024, Shared: true}, true},
+		{DlLimits{Connections: -1}, false},
+		{DlLimits{BytesPerHour: -1}, false},
+		{DlLimits{Shared: true}, false},
+	}
+	for _, test := range tests {
+		if err := test.limits.Validate(); (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid=%t, got err=%v", test.limits, test.valid, err)
+		}
+		// (limits of a running job are updated via xaction.JobLimits)
+		if limits := NewDlLimits(test.limits.JobLimits()); fmt.Sprint(limits) != fmt.Sprint(test.limits) {
+			t.Errorf("expected %+v, got %+v", test.limits, limits)
+		}
+	}
+}
+
+func TestDlBaseHeaders(t *testing.T) {
+	tests := []struct {
+		headers map[string]string
+		valid   bool
+	}{
+		{map[string]string{"Authorization": "Bearer token", "X-Api-Key": "key"}, true},
+		{map[string]string{"": "value"}, false},
+		{map[string]string{"X-Bad Name": "value"}, false},
+		{map[string]string{"X-Header": "value\r\nX-Injected: 1"}, false},
+		{map[string]string{"range": "bytes=0-1"}, false}, // set by the downloader
+	}
+	for _, test := range tests {
+		body := DlBase{Bck: cmn.Bck{Name: "bck"}, Headers: test.headers}
+		if err := body.Validate(); (err == nil) != test.valid {
+			t.Errorf("%v: expected valid=%t, got err=%v", test.headers, test.valid, err)
+		}
+	}
+}
+
+func TestDlBucketSource(t *testing.T) {
+	cloudBck := cmn.Bck{Name: "raw", Provider: cmn.ProviderGoogle}
+	tests := []struct {
+		src   DlBucketSource
+		valid bool
+	}{
+		{DlBucketSource{Cloud: cloudBck, Prefix: "train/", Sync: true, Schedule: "0 2 * * *"}, true},
+		{DlBucketSource{Template: "https://host/shard-{0..9}.tar"}, true},
+		{DlBucketSource{}, false},
+		{DlBucketSource{Cloud: cloudBck, Template: "https://host/shard-{0..9}.tar"}, false},
+		{DlBucketSource{Cloud: cmn.Bck{Name: "raw", Provider: cmn.ProviderAIS}}, false},
+		{DlBucketSource{Template: "https://host/shard-{0..9}.tar", Sync: true}, false},
+		{DlBucketSource{Cloud: cloudBck, Schedule: "every day"}, false},
+		{DlBucketSource{Cloud: cloudBck, Props: &cmn.BucketPropsToUpdate{BackendBck: &cmn.BckToUpdate{}}}, false},
+	}
+	for _, test := range tests {
+		if err := test.src.Validate(); (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid=%t, got err=%v", test.src, test.valid, err)
+		}
+	}
+
+	src := DlBucketSource{Cloud: cloudBck, Prefix: "train/", Sync: true, Schedule: "6h"}
+	body := src.CloudBody(cmn.Bck{Name: "imagenet", Provider: cmn.ProviderAIS})
+	if body.Bck.Name != "imagenet" || body.Prefix != "train/" || !body.Sync || body.Schedule != "6h" {
+		t.Errorf("unexpected download body: %+v", body)
+	}
+}
+
+func TestDlMultiBodyCksums(t *testing.T) {
+	var body DlMultiBody
+	err := jsoniter.Unmarshal([]byte(`{"bucket": {"name": "bck"}, "objects": {
+		"a.tar": "https://host/a.tar",
+		"b.tar?v=1": {"link": "https://host/b.tar", "checksum": {"type": "md5", "value": "abc"}},
+		"c.tar": {"link": "https://host/c.tar"}
+	}}`), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := body.ExtractPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 3 || objs["b.tar?v=1"] != "https://host/b.tar" || objs["c.tar"] != "https://host/c.tar" {
+		t.Errorf("unexpected objects: %v", objs)
+	}
+	cksums, err := body.ExtractCksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cksums) != 1 || !cksums["b.tar"].Equal(cmn.NewCksum(cmn.ChecksumMD5, "abc")) {
+		t.Errorf("unexpected checksums: %v", cksums)
+	}
+
+	for _, objects := range []string{
+		`{"a.tar": {"checksum": {"type": "md5", "value": "abc"}}}`,
+		`{"a.tar": {"link": "https://host/a.tar", "checksum": {"type": "sha1", "value": "abc"}}}`,
+		`{"a.tar": {"link": "https://host/a.tar", "checksum": {"type": "md5"}}}`,
+	} {
+		body := DlMultiBody{}
+		if err := jsoniter.Unmarshal([]byte(objects), &body.ObjectsPayload); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := body.ExtractPayload(); err == nil {
+			t.Errorf("%s: expected error", objects)
+		}
+	}
+}
+
+func TestDlSingleBodyCksum(t *testing.T) {
+	body := &DlSingleBody{DlSingleObj: DlSingleObj{
+		ObjName: "a.tar",
+		Link:    "https://host/a.tar",
+		Cksum:   &DlObjCksum{Type: cmn.ChecksumMD5, Value: "abc"},
+	}}
+	body.Bck = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}
+	if err := body.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cksums, err := body.ExtractCksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cksums) != 1 || !cksums["a.tar"].Equal(cmn.NewCksum(cmn.ChecksumMD5, "abc")) {
+		t.Errorf("unexpected checksums: %v", cksums)
+	}
+
+	for _, obj := range []DlSingleObj{
+		{ObjName: "a.tar", Link: "https://host/a.tar", Cksum: &DlObjCksum{Type: "sha1", Value: "abc"}},
+		{ObjName: "a.tar", Link: "https://host/a.tar", Cksum: &DlObjCksum{Type: cmn.ChecksumMD5}},
+		{ObjName: "a.tar", FromCloud: true, Cksum: &DlObjCksum{Type: cmn.ChecksumMD5, Value: "abc"}},
+	} {
+		body := &DlSingleBody{DlSingleObj: obj}
+		body.Bck = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}
+		if err := body.Validate(); err == nil {
+			t.Errorf("%+v: expected error", obj)
+		}
+	}
+	body.SegmentSize = cmn.MiB
+	if err := body.Validate(); err == nil {
+		t.Error("segmented download with checksum: expected error")
+	}
+}
+
+func TestDlPrefetchBody(t *testing.T) {
+	bck := cmn.Bck{Name: "raw", Provider: cmn.ProviderAmazon}
+	tests := []struct {
+		body  DlPrefetchBody
+		valid bool
+	}{
+		{DlPrefetchBody{ObjNames: []string{"a", "b/c"}}, true},
+		{DlPrefetchBody{Template: "shard-{00..99}-{0..9}.tar"}, true},
+		{DlPrefetchBody{}, false},
+		{DlPrefetchBody{ObjNames: []string{"a"}, Template: "shard-{0..9}.tar"}, false},
+		{DlPrefetchBody{ObjNames: []string{"a", ""}}, false},
+		{DlPrefetchBody{Template: "shard-{9..0}.tar"}, false},
+		{DlPrefetchBody{DlBase: DlBase{Headers: map[string]string{"X-Key": "v"}}, Template: "shard-{0..9}.tar"}, false},
+	}
+	for _, test := range tests {
+		test.body.Bck = bck
+		if err := test.body.Validate(); (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid=%t, got err=%v", test.body, test.valid, err)
+		}
+	}
+}
+
+func TestDlStatusRespSync(t *testing.T) {
+	bck := cmn.Bck{Name: "raw", Provider: cmn.ProviderGoogle}
+	if err := (&DlCloudBody{DlBase: DlBase{Bck: bck}, DryRunSync: true}).Validate(); err == nil {
+		t.Error("dry run without sync: expected error")
+	}
+	if err := (&DlCloudBody{DlBase: DlBase{Bck: bck}, Sync: true, DryRunSync: true}).Validate(); err != nil {
+		t.Error(err)
+	}
+
+	names := func(n int) []string {
+		s := make([]string, n)
+		for i := range s {
+			s[i] = fmt.Sprintf("obj-%d", i)
+		}
+		return s
+	}
+	var resp *DlStatusResp
+	for i := 0; i < 3; i++ {
+		resp = resp.Aggregate(DlStatusResp{
+			DlJobInfo: DlJobInfo{DeletedCnt: 600, ToDownloadCnt: 2, DryRun: i == 1},
+			Deleted:   names(600),
+		})
+	}
+	if resp.DeletedCnt != 1800 || resp.ToDownloadCnt != 6 || !resp.DryRun {
+		t.Errorf("unexpected counters: %+v", resp.DlJobInfo)
+	}
+	if len(resp.Deleted) != DlMaxListedNames {
+		t.Errorf("expected %d deleted names, got %d", DlMaxListedNames, len(resp.Deleted))
+	}
+}
+
+func TestDlStatusRespObject(t *testing.T) {
+	for _, body := range []DlAdminBody{{ObjName: "obj"}, {ID: "id", ObjName: "obj", Logs: true}} {
+		if err := body.Validate(false /*requireID*/); err == nil {
+			t.Errorf("%+v: expected error", body)
+		}
+	}
+	var (
+		resp *DlStatusResp
+		objs = []*DlObjStatus{
+			nil,
+			{Name: "obj", State: DlObjPending, Target: "t1"},
+			{Name: "obj", State: DlObjQueued, Target: "t2", Position: 3},
+			nil,
+		}
+	)
+	for _, obj := range objs {
+		resp = resp.Aggregate(DlStatusResp{Object: obj})
+	}
+	if resp.Object == nil || resp.Object.State != DlObjQueued || resp.Object.Target != "t2" || resp.Object.Position != 3 {
+		t.Errorf("unexpected object status: %+v", resp.Object)
+	}
+}
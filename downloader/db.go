@@ -16,6 +16,7 @@ import (
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderLogs       = "logs"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -25,6 +26,13 @@ const (
 	// Number of tasks stored in memory. When the number of tasks exceeds
 	// this number, then all errors will be flushed to disk
 	taskInfoCacheSize = 1000
+
+	// Number of log entries stored in memory (same as errCacheSize).
+	logCacheSize = 100
+
+	// Maximum number of log entries kept per job. When exceeded, the oldest
+	// entries are dropped.
+	logMaxEntries = 1000
 )
 
 var errJobNotFound = errors.New("job not found")
@@ -35,6 +43,7 @@ type downloaderDB struct {
 
 	errCache      map[string][]TaskErrInfo // memory cache for errors, see: errCacheSize
 	taskInfoCache map[string][]TaskDlInfo  // memory cache for tasks, see: taskInfoCacheSize
+	logCache      map[string][]DlLogEntry  // memory cache for logs, see: logCacheSize
 }
 
 func newDownloadDB(driver dbdriver.Driver) *downloaderDB {
@@ -42,6 +51,7 @@ func newDownloadDB(driver dbdriver.Driver) *downloaderDB {
 		driver:        driver,
 		errCache:      make(map[string][]TaskErrInfo, 10),
 		taskInfoCache: make(map[string][]TaskDlInfo, 10),
+		logCache:      make(map[string][]DlLogEntry, 10),
 	}
 }
 
@@ -137,6 +147,56 @@ func (db *downloaderDB) getTasks(id string) (tasks []TaskDlInfo, err error) {
 	return db.tasks(id)
 }
 
+func (db *downloaderDB) logs(id string) (logs []DlLogEntry, err error) {
+	key := path.Join(downloaderLogs, id)
+	if err := db.driver.Get(downloaderCollection, key, &logs); err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+			return nil, err
+		}
+		// If there was nothing in DB, return only values in the cache
+		return db.logCache[id], nil
+	}
+	logs = append(logs, db.logCache[id]...)
+	if len(logs) > logMaxEntries {
+		logs = logs[len(logs)-logMaxEntries:]
+	}
+	return
+}
+
+func (db *downloaderDB) getLogs(id string) (logs []DlLogEntry, err error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	return db.logs(id)
+}
+
+func (db *downloaderDB) persistLog(id string, entry DlLogEntry) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	db.logCache[id] = append(db.logCache[id], entry)
+	if len(db.logCache[id]) < logCacheSize {
+		return
+	}
+	if err := db.flushLogs(id); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) flushLogs(id string) error {
+	logs, err := db.logs(id) // it will also append (and trim) logs from cache
+	if err != nil {
+		return err
+	}
+	key := path.Join(downloaderLogs, id)
+	if err := db.driver.Set(downloaderCollection, key, logs); err != nil {
+		glog.Error(err)
+		return err
+	}
+	db.logCache[id] = db.logCache[id][:0] // clear cache
+	return nil
+}
+
 // flushes caches into the disk
 func (db *downloaderDB) flush(id string) error {
 	db.mtx.Lock()
@@ -171,6 +231,12 @@ func (db *downloaderDB) flush(id string) error {
 
 		db.taskInfoCache[id] = db.taskInfoCache[id][:0] // clear cache
 	}
+
+	if len(db.logCache[id]) > 0 {
+		if err := db.flushLogs(id); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -180,5 +246,8 @@ func (db *downloaderDB) delete(id string) {
 	db.driver.Delete(downloaderCollection, key)
 	key = path.Join(downloaderTasks, id)
 	db.driver.Delete(downloaderCollection, key)
+	key = path.Join(downloaderLogs, id)
+	db.driver.Delete(downloaderCollection, key)
+	delete(db.logCache, id)
 	db.mtx.Unlock()
 }
@@ -0,0 +1,65 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestDownloaderDBLogs(t *testing.T) {
+	const (
+		id  = "job"
+		cnt = logMaxEntries + 3*logCacheSize/2
+	)
+	db := newDownloadDB(dbdriver.NewDBMock())
+	for i := 0; i < cnt; i++ {
+		db.persistLog(id, DlLogEntry{Time: time.Now(), Msg: fmt.Sprintf("entry-%d", i)})
+	}
+
+	logs, err := db.getLogs(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(logs) == logMaxEntries, "expected %d entries, got %d", logMaxEntries, len(logs))
+	tassert.Errorf(t, logs[0].Msg == fmt.Sprintf("entry-%d", cnt-logMaxEntries), "unexpected oldest entry %q", logs[0].Msg)
+	tassert.Errorf(t, logs[len(logs)-1].Msg == fmt.Sprintf("entry-%d", cnt-1), "unexpected newest entry %q", logs[len(logs)-1].Msg)
+
+	tassert.CheckFatal(t, db.flush(id))
+	logs, err = db.getLogs(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(logs) == logMaxEntries, "expected %d entries after flush, got %d", logMaxEntries, len(logs))
+
+	db.delete(id)
+	logs, err = db.getLogs(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(logs) == 0, "expected no entries after delete, got %d", len(logs))
+}
+
+func TestInfoStoreObjDone(t *testing.T) {
+	const id = "job"
+	is := &infoStore{
+		downloaderDB: newDownloadDB(dbdriver.NewDBMock()),
+		jobInfo:      map[string]*downloadJobInfo{id: {ID: id}},
+	}
+	is.setObjDone(id, TaskDlInfo{Name: "ok", Attempts: 1, Downloaded: 10, Total: 10})
+	is.setObjFailed(id, "failed", "timeout")
+	is.setObjDone(id, TaskDlInfo{Name: "failed", Attempts: 3})
+
+	status := &DlObjStatus{Name: "ok"}
+	tassert.Fatalf(t, is.objDone(id, status), "expected %q to be done", status.Name)
+	tassert.Errorf(t, status.State == DlObjFinished && status.Downloaded == 10 && status.Attempts == 1,
+		"unexpected status %+v", status)
+
+	status = &DlObjStatus{Name: "failed"}
+	tassert.Fatalf(t, is.objDone(id, status), "expected %q to be done", status.Name)
+	tassert.Errorf(t, status.State == DlObjFailed && status.Err == "timeout" && status.Attempts == 3,
+		"unexpected status %+v", status)
+
+	tassert.Errorf(t, !is.objDone(id, &DlObjStatus{Name: "unknown"}), "unknown object must not be done")
+	tassert.Errorf(t, !is.objDone("unknown", &DlObjStatus{Name: "ok"}), "unknown job must not have objects")
+}
@@ -5,6 +5,8 @@
 package downloader
 
 import (
+	"net/http"
+
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -46,12 +48,20 @@ type (
 	WebResource struct {
 		ObjName string
 		Link    string
+		Header  http.Header // see DlBase.Headers
+		Offset  int64       // segment of the resource (zero length - entire resource)
+		Length  int64
+		polite  *politeness // nil - no politeness settings
 	}
 
 	DstElement struct {
 		ObjName string
 		Version string
 		Link    string
+		Header  http.Header
+		Offset  int64
+		Length  int64
+		polite  *politeness
 	}
 
 	DiffResolverResult struct {
@@ -167,6 +177,10 @@ func (dr *DiffResolver) PushDst(v interface{}) {
 		d = &DstElement{
 			ObjName: x.ObjName,
 			Link:    x.Link,
+			Header:  x.Header,
+			Offset:  x.Offset,
+			Length:  x.Length,
+			polite:  x.polite,
 		}
 	default:
 		cmn.Assertf(false, "%T", x)
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/fs"
 	"golang.org/x/sync/errgroup"
 )
@@ -29,6 +31,10 @@ type (
 
 		joggers  map[string]*jogger     // mpath -> jogger
 		abortJob map[string]*cmn.StopCh // jobID -> abort job chan
+		jobs     map[string]DlJob       // jobID -> dispatched (running) job
+
+		// jobID -> object being dispatched (see blockingDispatchDownloadSingle)
+		dispatching map[string]*dispatchState
 
 		adminCh            chan *request
 		dispatchDownloadCh chan DlJob
@@ -36,6 +42,14 @@ type (
 		stopCh *cmn.StopCh
 		sync.RWMutex
 	}
+
+	// the object that the job's dispatch is currently waiting on:
+	// for the job's limits or for room in the mountpath's queue
+	dispatchState struct {
+		objName string
+		mpath   string
+		state   string // DlObjThrottled | DlObjBlocked
+	}
 )
 
 func newDispatcher(parent *Downloader) *dispatcher {
@@ -48,7 +62,10 @@ func newDispatcher(parent *Downloader) *dispatcher {
 
 		stopCh:   cmn.NewStopCh(),
 		abortJob: make(map[string]*cmn.StopCh, jobsChSize),
+		jobs:     make(map[string]DlJob, jobsChSize),
 		adminCh:  make(chan *request),
+
+		dispatching: make(map[string]*dispatchState, jobsChSize),
 	}
 }
 
@@ -64,10 +81,14 @@ func (d *dispatcher) run() (err error) {
 	for mpath := range availablePaths {
 		d.addJogger(mpath)
 	}
+	adjustTicker := time.NewTicker(cmn.GCO.Get().Disk.IostatTimeLong)
+	defer adjustTicker.Stop()
 
 Loop:
 	for {
 		select {
+		case <-adjustTicker.C:
+			d.adjustJoggers()
 		case <-d.parent.IdleTimer():
 			glog.Infof("%s has timed out. Exiting...", d.parent.Name())
 			break Loop
@@ -84,6 +105,12 @@ Loop:
 				d.dispatchRemove(req)
 			case actList:
 				d.dispatchList(req)
+			case actLimits:
+				d.dispatchLimits(req)
+			case actLogs:
+				d.dispatchLogs(req)
+			case actObjStatus:
+				d.dispatchObjStatus(req)
 			default:
 				cmn.Assertf(false, "%v; %v", req, req.action)
 			}
@@ -95,6 +122,7 @@ Loop:
 			// may not saturate the full downloader throughput).
 			d.Lock()
 			d.abortJob[job.ID()] = cmn.NewStopCh()
+			d.jobs[job.ID()] = job
 			d.Unlock()
 
 			sema.Acquire()
@@ -130,17 +158,48 @@ func (d *dispatcher) addJogger(mpath string) {
 		glog.Errorf("Attempted to add a mountpath %q with no corresponding filesystem", mpath)
 		return
 	}
-	j := newJogger(d, mpath)
+	j := newJogger(d, mpathInfo)
 	go j.jog()
 	d.joggers[mpath] = j
 }
 
+// adjusts per-mountpath concurrency (see jogger.adjust)
+func (d *dispatcher) adjustJoggers() {
+	var (
+		config = cmn.GCO.Get()
+		utils  = fs.GetAllMpathUtils(mono.NanoTime())
+	)
+	for mpath, j := range d.joggers {
+		if util, ok := utils[mpath]; ok {
+			j.adjust(util, config)
+		}
+	}
+}
+
+func (d *dispatcher) mpathsInfo() []DlMpathInfo {
+	var (
+		tid    = d.parent.t.Snode().ID()
+		mpaths = make([]DlMpathInfo, 0, len(d.joggers))
+	)
+	d.RLock()
+	for _, j := range d.joggers {
+		info := j.mpathInfoResp()
+		info.Target = tid
+		mpaths = append(mpaths, info)
+	}
+	d.RUnlock()
+	sort.Slice(mpaths, func(i, k int) bool { return mpaths[i].Mpath < mpaths[k].Mpath })
+	return mpaths
+}
+
 func (d *dispatcher) cleanUpAborted(jobID string) {
 	d.Lock()
 	if ch, exists := d.abortJob[jobID]; exists {
 		ch.Close()
 		delete(d.abortJob, jobID)
 	}
+	delete(d.jobs, jobID)
+	delete(d.dispatching, jobID)
 	d.Unlock()
 }
 
@@ -149,8 +208,13 @@ func (d *dispatcher) cleanUpAborted(jobID string) {
  */
 
 func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
+	dl := &jobDeadline{}
 	defer func() {
 		d.waitFor(job)
+		dl.finish()
+		if job.Rollback() && dlStore.deadlineExceeded(job.ID()) {
+			d.rollback(job)
+		}
 		d.cleanUpAborted(job.ID())
 		job.cleanup()
 	}()
@@ -159,7 +223,42 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 		return !aborted
 	}
 
-	diffResolver := NewDiffResolver(nil)
+	dlStore.log(job.ID(), "dispatching started: %s", job.Description())
+
+	if deadline := job.Deadline(); deadline > 0 {
+		jInfo, err := dlStore.getJob(job.ID())
+		cmn.AssertNoErr(err)
+		dl.start(deadline-time.Since(jInfo.StartedTime), func() { d.abortDeadline(job) })
+	}
+
+	props := d.bprops(job)
+	for sync := job.Sync(); ; sync = false {
+		if ok, eof := d.dispatchPass(job, sync, props); !eof {
+			return ok
+		}
+		// wait for the pass to complete - targets may be dropped in the meantime
+		d.waitFor(job)
+		if !job.requeue() {
+			break
+		}
+		dlStore.log(job.ID(), "requeueing: target(s) entered maintenance or left the cluster")
+		if n := job.Len(); n > 0 {
+			dlStore.addTotal(job.ID(), n)
+		}
+	}
+	dlStore.log(job.ID(), "all objects dispatched")
+	dlStore.setAllDispatched(job.ID(), true)
+	return true
+}
+
+// dispatches a single pass over the job's objects (see DlJob.requeue);
+// returns eof=true when all objects of the pass were dispatched
+func (d *dispatcher) dispatchPass(job DlJob, sync bool, props *cmn.BucketProps) (ok, eof bool) {
+	var (
+		owners       = job.owners()
+		dropped      bool
+		diffResolver = NewDiffResolver(nil)
+	)
 
 	diffResolver.Start()
 
@@ -167,7 +266,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 	// We just want to download requested objects so we know exactly which
 	// objects must be checked (compared) and which not. Therefore, only traverse
 	// bucket when we need to sync the objects.
-	if job.Sync() {
+	if sync {
 		go func() {
 			defer diffResolver.CloseSrc()
 
@@ -201,7 +300,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 	go func() {
 		defer func() {
 			diffResolver.CloseDst()
-			if !job.Sync() {
+			if !sync {
 				diffResolver.CloseSrc()
 			}
 		}()
@@ -226,7 +325,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					return
 				}
 
-				if !job.Sync() {
+				if !sync {
 					// When it is not a sync job, push LOM for a given object
 					// because we need to check if it exists.
 					lom := &cluster.LOM{T: d.parent.t, ObjName: obj.objName}
@@ -241,6 +340,10 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					diffResolver.PushDst(&WebResource{
 						ObjName: obj.objName,
 						Link:    obj.link,
+						Header:  job.header(),
+						Offset:  obj.offset,
+						Length:  obj.length,
+						polite:  job.politeness(),
 					})
 				} else {
 					diffResolver.PushDst(&CloudResource{
@@ -254,7 +357,8 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 	for {
 		result, err := diffResolver.Next()
 		if err != nil {
-			return false
+			dlStore.log(job.ID(), "dispatching failed: %v", err)
+			return false, false
 		}
 		switch result.Action {
 		case DiffResolverRecv, DiffResolverSkip, DiffResolverErr, DiffResolverDelete:
@@ -264,6 +368,8 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					objName:   dst.ObjName,
 					link:      dst.Link,
 					fromCloud: dst.Link == "",
+					offset:    dst.Offset,
+					length:    dst.Length,
 				}
 			} else {
 				src := result.Src
@@ -275,9 +381,32 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 				}
 			}
 
+			// this target has entered maintenance (or left the cluster) - the remaining
+			// objects are left to the other targets (see DlJob.requeue)
+			if !owners.Active() {
+				if !dropped {
+					dropped = true
+					glog.Warningf("%s: no longer an owner of download job %q objects", d.parent.Name(), job.ID())
+					dlStore.log(job.ID(), "target in maintenance or left the cluster: leaving remaining objects to others")
+				}
+				dlStore.addTotal(job.ID(), -1)
+				continue
+			}
+
 			dlStore.incScheduled(job.ID())
 
 			if result.Action == DiffResolverSkip {
+				dlStore.log(job.ID(), "skipped %q: already present", obj.objName)
+				dlStore.incSkipped(job.ID())
+				continue
+			}
+			if result.Action == DiffResolverDelete && modifiedSince(result.Src, job.snapshot()) {
+				dlStore.log(job.ID(), "skipped %q: modified after the source snapshot", obj.objName)
+				dlStore.incSkipped(job.ID())
+				continue
+			}
+			if result.Action == DiffResolverRecv && d.routedExists(job, obj.objName) {
+				dlStore.log(job.ID(), "skipped %q: already present at its route destination", obj.objName)
 				dlStore.incSkipped(job.ID())
 				continue
 			}
@@ -293,35 +422,101 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 				continue
 			}
 
+			if props != nil && props.Residency.Enabled() && obj.link != "" {
+				if err := props.Residency.AllowURL(obj.link); err != nil {
+					t.markFailed(err.Error())
+					continue
+				}
+			}
+			if props != nil && result.Action != DiffResolverDelete {
+				if err := props.Naming.ValidateObjName(obj.objName); err != nil {
+					t.markFailed(err.Error())
+					continue
+				}
+			}
+
 			if result.Action == DiffResolverDelete {
-				cmn.Assert(job.Sync())
-				if err := d.parent.t.EvictObject(result.Src); err != nil {
+				cmn.Assert(sync)
+				if job.DryRun() {
+					dlStore.incDeleted(job.ID(), obj.objName)
+				} else if err := d.parent.t.EvictObject(result.Src); err != nil {
 					t.markFailed(err.Error())
 				} else {
-					dlStore.incFinished(job.ID())
+					dlStore.log(job.ID(), "evicted %q: no longer present in the source", obj.objName)
+					dlStore.incDeleted(job.ID(), obj.objName)
 				}
 				continue
 			}
+			if job.DryRun() {
+				dlStore.incToDownload(job.ID(), obj.objName)
+				continue
+			}
 
 			err, ok := d.blockingDispatchDownloadSingle(t)
 			if err != nil {
 				glog.Errorf("Download job %q failed, couldn't download object %q, aborting; err: %s", job.ID(), obj.objName, err.Error())
+				dlStore.log(job.ID(), "couldn't download %q, aborting: %v", obj.objName, err)
 				dlStore.setAborted(job.ID())
-				return ok
+				return ok, false
 			}
 			if !ok {
 				dlStore.setAborted(job.ID())
-				return false
+				return false, false
 			}
 		case DiffResolverSend:
-			cmn.Assert(job.Sync())
+			cmn.Assert(sync)
 		case DiffResolverEOF:
-			dlStore.setAllDispatched(job.ID(), true)
-			return true
+			return true, true
 		}
 	}
 }
 
+// whether the object has been modified after a given snapshot of the source
+// (the source may not yet reflect the change); false if there's no snapshot
+func modifiedSince(lom *cluster.LOM, snapshot time.Time) bool {
+	if snapshot.IsZero() {
+		return false
+	}
+	finfo, err := os.Stat(lom.FQN)
+	return err == nil && finfo.ModTime().After(snapshot)
+}
+
+// whether the object that gets routed by its name (see DlRoute) is already
+// present at its destination
+func (d *dispatcher) routedExists(job DlJob, objName string) bool {
+	bck, name, routed := job.Route(objName, "" /*content type*/)
+	if !routed {
+		return false
+	}
+	lom := &cluster.LOM{T: d.parent.t, ObjName: name}
+	if err := lom.Init(bck); err != nil {
+		return false
+	}
+	return lomExists(d.parent.t, lom)
+}
+
+// whether the object exists at its (HRW) target
+func lomExists(t cluster.Target, lom *cluster.LOM) bool {
+	tsi, err := cluster.HrwTarget(lom.Uname(), t.Sowner().Get())
+	if err != nil {
+		return false
+	}
+	if tsi.ID() != t.Snode().ID() {
+		return t.LookupRemoteSingle(lom, tsi)
+	}
+	return lom.Load() == nil
+}
+
+// returns the properties of the job's destination bucket
+// (to enforce data residency constraints and naming rules)
+func (d *dispatcher) bprops(job DlJob) *cmn.BucketProps {
+	bck := cluster.NewBckEmbed(job.Bck())
+	if err := bck.Init(d.parent.t.Bowner(), d.parent.t.Snode()); err != nil {
+		return nil // (will fail later on)
+	}
+	return bck.Props
+}
+
 func (d *dispatcher) jobAbortedCh(jobID string) *cmn.StopCh {
 	d.RLock()
 	defer d.RUnlock()
@@ -370,6 +565,9 @@ func (d *dispatcher) blockingDispatchDownloadSingle(task *singleObjectTask) (err
 		return err, false
 	}
 
+	d.setDispatching(task, mi.Path, DlObjThrottled)
+	defer d.clearDispatching(task.id())
+
 	// NOTE: Throttle job before making jogger busy - we don't want to clog the
 	//  jogger as other tasks from other jobs can be already ready to download.
 	task.job.throttler().acquire()
@@ -381,10 +579,17 @@ func (d *dispatcher) blockingDispatchDownloadSingle(task *singleObjectTask) (err
 	}
 
 	// Secondly, try to push the new task into queue.
+	putCh := jogger.putCh(task)
+	select {
+	case putCh <- task:
+		return nil, true
+	default:
+		d.setDispatching(task, mi.Path, DlObjBlocked)
+	}
 	select {
 	// FIXME: if this particular jogger is full, but others are available, dispatcher
 	//  will wait with dispatching all of the requests anyway
-	case jogger.putCh(task) <- task:
+	case putCh <- task:
 		return nil, true
 	case <-d.jobAbortedCh(task.job.ID()).Listen():
 		task.job.throttler().release()
@@ -395,6 +600,18 @@ func (d *dispatcher) blockingDispatchDownloadSingle(task *singleObjectTask) (err
 	}
 }
 
+func (d *dispatcher) setDispatching(task *singleObjectTask, mpath, state string) {
+	d.Lock()
+	d.dispatching[task.id()] = &dispatchState{objName: task.obj.objName, mpath: mpath, state: state}
+	d.Unlock()
+}
+
+func (d *dispatcher) clearDispatching(jobID string) {
+	d.Lock()
+	delete(d.dispatching, jobID)
+	d.Unlock()
+}
+
 func (d *dispatcher) dispatchRemove(req *request) {
 	jInfo, err := d.parent.checkJob(req)
 	if err != nil {
@@ -419,6 +636,7 @@ func (d *dispatcher) dispatchAbort(req *request) {
 	}
 
 	d.jobAbortedCh(req.id).Close()
+	dlStore.log(req.id, "aborted by user")
 
 	for _, j := range d.joggers {
 		j.abortJob(req.id)
@@ -428,6 +646,174 @@ func (d *dispatcher) dispatchAbort(req *request) {
 	req.writeResp(nil)
 }
 
+// jobDeadline aborts the job upon exceeding its deadline. The timer may fire
+// concurrently with the job's normal completion, hence the (job) lock: once
+// finished, the job can no longer be aborted (and rolled back).
+type jobDeadline struct {
+	mtx      sync.Mutex
+	timer    *time.Timer
+	finished bool
+}
+
+func (dl *jobDeadline) start(d time.Duration, abort func()) {
+	dl.mtx.Lock()
+	dl.timer = time.AfterFunc(d, func() {
+		dl.mtx.Lock()
+		if !dl.finished {
+			abort()
+		}
+		dl.mtx.Unlock()
+	})
+	dl.mtx.Unlock()
+}
+
+func (dl *jobDeadline) finish() {
+	dl.mtx.Lock()
+	dl.finished = true
+	if dl.timer != nil {
+		dl.timer.Stop()
+	}
+	dl.mtx.Unlock()
+}
+
+// aborts the job upon exceeding its deadline - same as dispatchAbort but
+// without going through the admin channel (and without the response)
+func (d *dispatcher) abortDeadline(job DlJob) {
+	glog.Warningf("%s: download job %q exceeded its deadline (%v) - aborting", d.parent.Name(), job.ID(), job.Deadline())
+	dlStore.setDeadlineExceeded(job.ID())
+	dlStore.log(job.ID(), "deadline (%v) exceeded - aborting", job.Deadline())
+	d.jobAbortedCh(job.ID()).Close()
+
+	d.RLock()
+	for _, j := range d.joggers {
+		j.abortJob(job.ID())
+	}
+	d.RUnlock()
+}
+
+// returns the tasks to roll back: the objects successfully downloaded and newly
+// created by the job (objects that existed prior to the job - e.g., updated in
+// place by sync - are kept)
+func rollbackTasks(tasks []TaskDlInfo, dlErrors []TaskErrInfo) []TaskDlInfo {
+	failed := make(cmn.StringSet, len(dlErrors))
+	for _, dlErr := range dlErrors {
+		failed.Add(dlErr.Name)
+	}
+	rb := tasks[:0:0]
+	for _, task := range tasks {
+		if task.Created && !failed.Contains(task.Name) {
+			rb = append(rb, task)
+		}
+	}
+	return rb
+}
+
+// removes (evicts, in case of Cloud bucket) the objects successfully downloaded
+// and newly created by the job that has exceeded its deadline
+func (d *dispatcher) rollback(job DlJob) {
+	tasks, err := dlStore.getTasks(job.ID())
+	if err != nil {
+		glog.Errorf("%s: failed to roll back download job %q: %v", d.parent.Name(), job.ID(), err)
+		dlStore.log(job.ID(), "failed to roll back: %v", err)
+		return
+	}
+	dlErrors, err := dlStore.getErrors(job.ID())
+	if err != nil {
+		glog.Errorf("%s: failed to roll back download job %q: %v", d.parent.Name(), job.ID(), err)
+		dlStore.log(job.ID(), "failed to roll back: %v", err)
+		return
+	}
+	var cnt int
+	for _, task := range rollbackTasks(tasks, dlErrors) {
+		var (
+			lom = &cluster.LOM{T: d.parent.t, ObjName: task.Name}
+			bck = job.Bck()
+		)
+		if task.RoutedBck != nil {
+			lom.ObjName, bck = task.RoutedName, *task.RoutedBck
+		}
+		if err := lom.Init(bck); err != nil {
+			glog.Error(err)
+			continue
+		}
+		if task.RoutedBck != nil {
+			// routed objects get forwarded to their (HRW) targets
+			if err := d.deleteRouted(lom); err != nil {
+				if !cmn.IsObjNotExist(err) {
+					glog.Errorf("%s: failed to roll back %s: %v", d.parent.Name(), lom, err)
+				}
+			} else {
+				cnt++
+			}
+			continue
+		}
+		if err, _ := d.parent.t.DeleteObject(context.Background(), lom, lom.Bck().IsRemote()); err != nil {
+			if !cmn.IsObjNotExist(err) {
+				glog.Errorf("%s: failed to roll back %s: %v", d.parent.Name(), lom, err)
+			}
+			continue
+		}
+		cnt++
+	}
+	glog.Infof("%s: download job %q rolled back (%d objects removed)", d.parent.Name(), job.ID(), cnt)
+	dlStore.log(job.ID(), "rolled back (%d objects removed)", cnt)
+}
+
+func (d *dispatcher) deleteRouted(lom *cluster.LOM) error {
+	tsi, err := cluster.HrwTarget(lom.Uname(), d.parent.t.Sowner().Get())
+	if err != nil {
+		return err
+	}
+	if tsi.ID() == d.parent.t.Snode().ID() {
+		err, _ = d.parent.t.DeleteObject(context.Background(), lom, false /*evict*/)
+		return err
+	}
+	var (
+		query = cmn.AddBckToQuery(nil, lom.Bck().Bck)
+		u     = tsi.URL(cmn.NetworkIntraData) + cmn.JoinWords(cmn.Version, cmn.Objects, lom.BckName(), lom.ObjName)
+	)
+	req, err := http.NewRequest(http.MethodDelete, u+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := clientForURL(u).Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return cmn.NewNotFoundError("%s", lom)
+	default:
+		return fmt.Errorf("%s: DELETE %s failed, status %d", d.parent.Name(), lom, resp.StatusCode)
+	}
+}
+
+func (d *dispatcher) dispatchLimits(req *request) {
+	if _, err := d.parent.checkJob(req); err != nil {
+		return
+	}
+	d.RLock()
+	job, ok := d.jobs[req.id]
+	d.RUnlock()
+	if !ok {
+		req.writeErrResp(fmt.Errorf("download job with id = %s is not running", req.id), http.StatusBadRequest)
+		return
+	}
+	if req.limits.BatchSize != 0 {
+		req.writeErrResp(fmt.Errorf("download job %q: 'batch_size' applies to offline ETL only", req.id),
+			http.StatusBadRequest)
+		return
+	}
+	limits := NewDlLimits(req.limits).perTarget(d.parent.t.Snode().ID(), d.parent.t.Sowner().Get().CountTargets())
+	job.throttler().update(limits)
+	glog.Infof("%s: job %q limits updated: %+v", d.parent.Name(), req.id, limits)
+	dlStore.log(req.id, "limits updated: %+v", limits)
+	req.writeResp(nil)
+}
+
 func (d *dispatcher) dispatchStatus(req *request) {
 	var (
 		finishedTasks []TaskDlInfo
@@ -455,14 +841,120 @@ func (d *dispatcher) dispatchStatus(req *request) {
 		sort.Sort(TaskErrByName(dlErrors))
 	}
 
-	req.writeResp(&DlStatusResp{
+	resp := &DlStatusResp{
 		DlJobInfo:     jInfo.ToDlJobInfo(),
 		CurrentTasks:  currentTasks,
 		FinishedTasks: finishedTasks,
 		Errs:          dlErrors,
+		Mpaths:        d.mpathsInfo(),
+	}
+	if !req.onlyActive {
+		resp.Deleted, resp.ToDownload = jInfo.syncNames()
+	}
+	d.RLock()
+	job, ok := d.jobs[req.id]
+	d.RUnlock()
+	if ok {
+		resp.Throughput = job.throttler().throughput()
+	}
+	req.writeResp(resp)
+}
+
+func (d *dispatcher) dispatchLogs(req *request) {
+	jInfo, err := d.parent.checkJob(req)
+	if err != nil {
+		return
+	}
+	logs, err := dlStore.getLogs(req.id)
+	if err != nil {
+		req.writeErrResp(err, http.StatusInternalServerError)
+		return
+	}
+	tid := d.parent.t.Snode().ID()
+	for i := range logs {
+		logs[i].Target = tid
+	}
+	req.writeResp(&DlStatusResp{
+		DlJobInfo: jInfo.ToDlJobInfo(),
+		Logs:      logs,
 	})
 }
 
+func (d *dispatcher) dispatchObjStatus(req *request) {
+	jInfo, err := d.parent.checkJob(req)
+	if err != nil {
+		return
+	}
+	status, err := d.objStatus(req.id, req.objName)
+	if err != nil {
+		req.writeErrResp(err, http.StatusInternalServerError)
+		return
+	}
+	req.writeResp(&DlStatusResp{
+		DlJobInfo: jInfo.ToDlJobInfo(),
+		Object:    status,
+	})
+}
+
+// objStatus returns the state of the job's object, or nil if this target
+// does not own the object (or has not seen it and the job is no longer running)
+func (d *dispatcher) objStatus(id, objName string) (*DlObjStatus, error) {
+	var (
+		uname  string
+		status = &DlObjStatus{Name: objName, Target: d.parent.t.Snode().ID()}
+	)
+	d.RLock()
+	job, running := d.jobs[id]
+	if running {
+		uname = cluster.NewBckEmbed(job.Bck()).MakeUname(objName)
+		if mi, _, err := cluster.HrwMpath(uname); err == nil {
+			status.Mpath = mi.Path
+		}
+	}
+	// 1. being dispatched
+	if ds, ok := d.dispatching[id]; ok && ds.objName == objName {
+		status.State, status.Mpath = ds.state, ds.mpath
+		d.RUnlock()
+		return status, nil
+	}
+	// 2. running or queued
+	if j, ok := d.joggers[status.Mpath]; ok {
+		for _, task := range j.getTasks(id) {
+			if task.obj.objName != objName {
+				continue
+			}
+			info := task.ToTaskDlInfo()
+			status.State = DlObjRunning
+			status.Attempts = info.Attempts
+			status.Downloaded, status.Total = info.Downloaded, info.Total
+			status.StartTime = info.StartTime
+			d.RUnlock()
+			return status, nil
+		}
+		if pos, length, ok := j.q.position(id, objName); ok {
+			status.State, status.Position, status.QueueLen = DlObjQueued, pos, length
+			d.RUnlock()
+			return status, nil
+		}
+	}
+	d.RUnlock()
+
+	// 3. done
+	if dlStore.objDone(id, status) {
+		return status, nil
+	}
+
+	// 4. not dispatched yet (as long as the object is this target's)
+	if !running {
+		return nil, nil
+	}
+	if owns, err := job.owners().Owns(uname); err != nil || !owns {
+		return nil, err
+	}
+	status.State = DlObjPending
+	return status, nil
+}
+
 func (d *dispatcher) dispatchList(req *request) {
 	records := dlStore.getList(req.regex)
 	respMap := make(map[string]DlJobInfo)
@@ -477,8 +969,7 @@ func (d *dispatcher) activeTasks(reqID string) []TaskDlInfo {
 	d.RLock()
 	currentTasks := make([]TaskDlInfo, 0, len(d.joggers))
 	for _, j := range d.joggers {
-		task := j.getTask()
-		if task != nil && task.id() == reqID {
+		for _, task := range j.getTasks(reqID) {
 			currentTasks = append(currentTasks, task.ToTaskDlInfo())
 		}
 	}
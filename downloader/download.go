@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -38,6 +39,7 @@ import (
 //   * Download    - to download a new object from a URL
 //   * Abort       - to abort a previously requested download (currently queued or currently downloading)
 //   * Status      - to request the status of a previously requested download
+//   * Logs        - to request the (bounded) log of a previously requested download
 // The Download, Abort and Status requests are encapsulated into an internal
 // request object, added to a dispatcher's request queue and then are dispatched by dispatcher
 // to the correct jogger. The remaining operations are private to the Downloader and
@@ -112,6 +114,10 @@ const (
 	actAbort  = "ABORT"
 	actStatus = "STATUS"
 	actList   = "LIST"
+	actLimits = "LIMITS"
+	actLogs   = "LOGS"
+
+	actObjStatus = "OBJ_STATUS"
 
 	jobsChSize = 1000
 )
@@ -120,12 +126,11 @@ var (
 	// Downloader cannot use global HTTP client because it must work with
 	// arbitrary server. The downloader chooses the correct client by
 	// server's URL. Certification check is disabled always for now and
-	// does not depend on cluster settings.
-	httpClient  = cmn.NewClient(cmn.TransportArgs{})
-	httpsClient = cmn.NewClient(cmn.TransportArgs{
-		UseHTTPS:   true,
-		SkipVerify: true,
-	})
+	// does not depend on cluster settings. The clients are created upon
+	// first use and are tuned via `downloader.transport` configuration.
+	httpClient, httpsClient *http.Client
+	clientsOnce             sync.Once
+
 	instance atomic.Int64
 )
 
@@ -155,20 +160,49 @@ type (
 	// objects are used by Downloader to process the request, and are then
 	// dispatched to the correct jogger to be handled.
 	request struct {
-		action     string         // one of: adminAbort, adminList, adminStatus, adminRemove
-		id         string         // id of the job task
-		regex      *regexp.Regexp // regex of descriptions to return if id is empty
-		responseCh chan *response // where the outcome of the request is written
-		onlyActive bool           // request status of only active tasks
+		action     string             // one of: adminAbort, adminList, adminStatus, adminRemove, adminLimits, adminLogs, adminObjStatus
+		id         string             // id of the job task
+		objName    string             // object of the job (actObjStatus)
+		regex      *regexp.Regexp     // regex of descriptions to return if id is empty
+		responseCh chan *response     // where the outcome of the request is written
+		onlyActive bool               // request status of only active tasks
+		limits     *xaction.JobLimits // new limits of the job
 	}
 
 	progressReader struct {
 		r        io.Reader
 		reporter func(n int64)
 	}
+
+	// cksumReader computes the checksum of the object being read and, upon
+	// reaching the end, compares it with the expected one - on mismatch the
+	// read fails and the object does not get stored
+	cksumReader struct {
+		r        io.ReadCloser
+		cksum    *cmn.CksumHash
+		expected *cmn.Cksum
+		objName  string
+	}
 )
 
+func initClients() {
+	var (
+		conf      = &cmn.GCO.Get().Downloader.Transport
+		httpArgs  = cmn.TransportArgs{Stats: cmn.NewClientStats("downloader")}
+		httpsArgs = cmn.TransportArgs{
+			UseHTTPS:   true,
+			SkipVerify: true,
+			Stats:      cmn.NewClientStats("downloader-https"),
+		}
+	)
+	httpArgs.Tune(conf)
+	httpsArgs.Tune(conf)
+	httpClient, httpsClient = cmn.NewClient(httpArgs), cmn.NewClient(httpsArgs)
+	httpClient.CheckRedirect, httpsClient.CheckRedirect = cmn.CheckRedirect, cmn.CheckRedirect
+}
+
 func clientForURL(u string) *http.Client {
+	clientsOnce.Do(initClients)
 	if cmn.IsHTTPS(u) {
 		return httpsClient
 	}
@@ -210,6 +244,28 @@ func (pr *progressReader) Close() error {
 	return nil
 }
 
+// ========================== cksumReader ======================================
+
+var _ io.ReadCloser = &cksumReader{}
+
+func newCksumReader(r io.ReadCloser, expected *cmn.Cksum, objName string) *cksumReader {
+	return &cksumReader{r: r, cksum: cmn.NewCksumHash(expected.Type()), expected: expected, objName: objName}
+}
+
+func (cr *cksumReader) Read(p []byte) (n int, err error) {
+	n, err = cr.r.Read(p)
+	cr.cksum.H.Write(p[:n])
+	if err == io.EOF {
+		cr.cksum.Finalize()
+		if !cr.cksum.Equal(cr.expected) {
+			err = cmn.NewBadDataCksumError(cr.expected, &cr.cksum.Cksum, cr.objName)
+		}
+	}
+	return
+}
+
+func (cr *cksumReader) Close() error { return cr.r.Close() }
+
 // ============================= Downloader ====================================
 
 func init() {
@@ -336,6 +392,39 @@ func (d *Downloader) JobStatus(id string, onlyActive bool) (resp interface{}, er
 	return r.resp, r.err, r.statusCode
 }
 
+// JobLogs returns the log of the job (see DlLogEntry).
+func (d *Downloader) JobLogs(id string) (resp interface{}, err error, statusCode int) {
+	d.IncPending()
+	defer d.DecPending()
+	req := &request{
+		action:     actLogs,
+		id:         id,
+		responseCh: make(chan *response, 1),
+	}
+	d.dispatcher.adminCh <- req
+
+	// await the response
+	r := <-req.responseCh
+	return r.resp, r.err, r.statusCode
+}
+
+// ObjStatus returns the scheduling state of a given object of the job (see DlObjStatus)
+func (d *Downloader) ObjStatus(id, objName string) (resp interface{}, err error, statusCode int) {
+	d.IncPending()
+	defer d.DecPending()
+	req := &request{
+		action:     actObjStatus,
+		id:         id,
+		objName:    objName,
+		responseCh: make(chan *response, 1),
+	}
+	d.dispatcher.adminCh <- req
+
+	// await the response
+	r := <-req.responseCh
+	return r.resp, r.err, r.statusCode
+}
+
 func (d *Downloader) ListJobs(regex *regexp.Regexp) (resp interface{}, err error, statusCode int) {
 	d.IncPending()
 	defer d.DecPending()
@@ -351,6 +440,33 @@ func (d *Downloader) ListJobs(regex *regexp.Regexp) (resp interface{}, err error
 	return r.resp, r.err, r.statusCode
 }
 
+// SetJobLimits updates the limits of a running job.
+func (d *Downloader) SetJobLimits(id string, limits *xaction.JobLimits) (resp interface{}, err error, statusCode int) {
+	d.IncPending()
+	defer d.DecPending()
+	req := &request{
+		action:     actLimits,
+		id:         id,
+		responseCh: make(chan *response, 1),
+		limits:     limits,
+	}
+	d.dispatcher.adminCh <- req
+
+	// await the response
+	r := <-req.responseCh
+	return r.resp, r.err, r.statusCode
+}
+
+// UnscheduleJob clears the next run of the job's schedule - the (primary) proxy
+// has canceled the schedule (see DlBase.Schedule).
+func (d *Downloader) UnscheduleJob(id string) (resp interface{}, err error, statusCode int) {
+	if err := dlStore.unschedule(id); err != nil {
+		cmn.Assert(errors.Is(err, errJobNotFound))
+		return nil, fmt.Errorf("download job %q not found", id), http.StatusNotFound
+	}
+	return nil, nil, http.StatusOK
+}
+
 func (d *Downloader) checkJob(req *request) (*downloadJobInfo, error) {
 	jInfo, err := dlStore.getJob(req.id)
 	if err != nil {
@@ -5,6 +5,7 @@
 package downloader
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -72,18 +73,52 @@ func (is *infoStore) getList(descRegex *regexp.Regexp) []*downloadJobInfo {
 }
 
 func (is *infoStore) setJob(id string, job DlJob) {
+	var nextRun time.Time
 	jInfo := &downloadJobInfo{
 		ID:          job.ID(),
-		Total:       job.Len(),
 		Description: job.Description(),
 		StartedTime: time.Now(),
 	}
+	jInfo.Total.Store(int32(job.Len()))
+	jInfo.ScheduleID, nextRun = job.Schedule()
+	jInfo.DryRun = job.DryRun()
+	jInfo.NextRun.Store(nextRun)
 
 	is.Lock()
 	is.jobInfo[id] = jInfo
 	is.Unlock()
 }
 
+// adds a new entry to the job's log
+func (is *infoStore) log(id, format string, a ...interface{}) {
+	is.persistLog(id, DlLogEntry{Time: time.Now(), Msg: fmt.Sprintf(format, a...)})
+}
+
+// adjusts the total number of tasks unless unknown (negative)
+func (is *infoStore) addTotal(id string, n int) {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	if jInfo.Total.Load() >= 0 {
+		jInfo.Total.Add(int32(n))
+	}
+}
+
+// clears the next run of the job's schedule (the schedule has been canceled)
+func (is *infoStore) unschedule(id string) error {
+	jInfo, err := is.getJob(id)
+	if err != nil {
+		return err
+	}
+	jInfo.NextRun.Store(time.Time{})
+	return nil
+}
+
+func (is *infoStore) addResumed(id string, size int64) {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	jInfo.ResumedSize.Add(size)
+}
+
 func (is *infoStore) incFinished(id string) {
 	jInfo, err := is.getJob(id)
 	cmn.AssertNoErr(err)
@@ -97,6 +132,32 @@ func (is *infoStore) incSkipped(id string) {
 	jInfo.FinishedCnt.Inc()
 }
 
+// object deleted by sync (or to be deleted - in case of dry run)
+func (is *infoStore) incDeleted(id, objName string) {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	jInfo.mtx.Lock()
+	if len(jInfo.deleted) < DlMaxListedNames {
+		jInfo.deleted = append(jInfo.deleted, objName)
+	}
+	jInfo.mtx.Unlock()
+	jInfo.DeletedCnt.Inc()
+	jInfo.FinishedCnt.Inc()
+}
+
+// object to be downloaded (dry run)
+func (is *infoStore) incToDownload(id, objName string) {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	jInfo.mtx.Lock()
+	if len(jInfo.toDownload) < DlMaxListedNames {
+		jInfo.toDownload = append(jInfo.toDownload, objName)
+	}
+	jInfo.mtx.Unlock()
+	jInfo.ToDownloadCnt.Inc()
+	jInfo.FinishedCnt.Inc()
+}
+
 func (is *infoStore) incScheduled(id string) {
 	jInfo, err := is.getJob(id)
 	cmn.AssertNoErr(err)
@@ -109,6 +170,54 @@ func (is *infoStore) incErrorCnt(id string) {
 	jInfo.ErrorCnt.Inc()
 }
 
+// indexes the object's final download info (see objDone)
+func (is *infoStore) setObjDone(id string, info TaskDlInfo) {
+	jInfo, err := is.getJob(id)
+	if err != nil {
+		return
+	}
+	jInfo.mtx.Lock()
+	obj := jInfo.obj(info.Name)
+	obj.attempts = info.Attempts
+	obj.downloaded, obj.total = info.Downloaded, info.Total
+	obj.startTime, obj.endTime = info.StartTime, info.EndTime
+	jInfo.mtx.Unlock()
+}
+
+// indexes the object's failure (see objDone)
+func (is *infoStore) setObjFailed(id, objName, errMsg string) {
+	jInfo, err := is.getJob(id)
+	if err != nil {
+		return
+	}
+	jInfo.mtx.Lock()
+	jInfo.obj(objName).err = errMsg
+	jInfo.mtx.Unlock()
+}
+
+// fills in the state of the job's object that has been either downloaded or
+// failed; returns false if the object is not done (or the job is unknown)
+func (is *infoStore) objDone(id string, status *DlObjStatus) bool {
+	jInfo, err := is.getJob(id)
+	if err != nil {
+		return false
+	}
+	jInfo.mtx.Lock()
+	defer jInfo.mtx.Unlock()
+	obj, ok := jInfo.objs[status.Name]
+	if !ok {
+		return false
+	}
+	status.State = DlObjFinished
+	if obj.err != "" {
+		status.State, status.Err = DlObjFailed, obj.err
+	}
+	status.Attempts = obj.attempts
+	status.Downloaded, status.Total = obj.downloaded, obj.total
+	status.StartTime, status.EndTime = obj.startTime, obj.endTime
+	return true
+}
+
 func (is *infoStore) setAllDispatched(id string, dispatched bool) {
 	jInfo, err := is.getJob(id)
 	cmn.AssertNoErr(err)
@@ -120,6 +229,8 @@ func (is *infoStore) markFinished(id string) {
 	cmn.AssertNoErr(err)
 	jInfo.FinishedTime.Store(time.Now())
 	cmn.Assert(jInfo.valid())
+	is.log(id, "job finished: %d done (skipped: %d, deleted: %d), %d errors",
+		jInfo.FinishedCnt.Load(), jInfo.SkippedCnt.Load(), jInfo.DeletedCnt.Load(), jInfo.ErrorCnt.Load())
 }
 
 func (is *infoStore) setAborted(id string) {
@@ -131,6 +242,19 @@ func (is *infoStore) setAborted(id string) {
 	//       that all tasks have been stopped and all resources were freed.
 }
 
+func (is *infoStore) setDeadlineExceeded(id string) {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	jInfo.DeadlineExceeded.Store(true)
+	jInfo.Aborted.Store(true)
+}
+
+func (is *infoStore) deadlineExceeded(id string) bool {
+	jInfo, err := is.getJob(id)
+	cmn.AssertNoErr(err)
+	return jInfo.DeadlineExceeded.Load()
+}
+
 func (is *infoStore) delJob(id string) {
 	delete(is.jobInfo, id)
 	is.downloaderDB.delete(id)
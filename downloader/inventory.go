@@ -0,0 +1,306 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Provider-native inventories are periodically generated listings of
+// a cloud bucket (S3 Inventory, GCS Storage Insights). For very large buckets
+// reading the inventory is significantly cheaper and faster than listing
+// the bucket page by page. Since inventory is only a snapshot, the objects
+// created after it was generated can be picked up by live listing of
+// a (presumably small) set of recent prefixes.
+//
+// Both formats consist of a manifest (JSON) that references one or more
+// CSV data files, all stored in the same bucket as the manifest:
+//   * S3: `manifest.json` with `files[].key` (gzipped CSV, no header,
+//     `fileSchema` determines the columns, `Key` is URL-encoded);
+//   * GCS: `*_manifest.json` with `report_shards_file_names` (plain CSV with
+//     header, `name` column, shards located next to the manifest).
+//
+// The manifest also carries the time of the snapshot (`creationTimestamp`,
+// `snapshot_time`, respectively): when syncing, the objects that were modified
+// after the snapshot are not deleted even though the inventory lacks them.
+
+const (
+	s3ManifestKeyColumn  = "Key"
+	gcsManifestKeyColumn = "name"
+)
+
+var errInventoryNotFound = errors.New("inventory not found")
+
+type (
+	s3InventoryManifest struct {
+		SourceBucket string `json:"sourceBucket"`
+		FileFormat   string `json:"fileFormat"`
+		FileSchema   string `json:"fileSchema"`
+		Created      string `json:"creationTimestamp"` // milliseconds since epoch
+		Files        []struct {
+			Key string `json:"key"`
+		} `json:"files"`
+	}
+
+	gcsInventoryManifest struct {
+		SnapshotTime time.Time `json:"snapshot_time"`
+		Shards       []string  `json:"report_shards_file_names"`
+	}
+
+	// inventory is a single manifest's worth of object names.
+	inventory struct {
+		t    cluster.Target
+		ctx  context.Context
+		bck  *cluster.Bck // bucket containing manifest and data files
+		spec *DlInventory
+
+		snapshot time.Time // when the inventory was generated (see objNames)
+	}
+)
+
+func newInventory(ctx context.Context, t cluster.Target, jobBck *cluster.Bck, spec *DlInventory) (*inventory, error) {
+	bck := jobBck
+	if !spec.Bck.IsEmpty() {
+		bck = cluster.NewBckEmbed(spec.Bck)
+		if err := bck.Init(t.Bowner(), t.Snode()); err != nil {
+			return nil, err
+		}
+	}
+	return &inventory{t: t, ctx: ctx, bck: bck, spec: spec}, nil
+}
+
+// objNames reads the manifest together with all referenced data files and
+// returns the names for which `filter` returns true.
+func (inv *inventory) objNames(filter func(string) bool) (names []string, err error) {
+	var (
+		files  []string
+		column int
+		header bool
+	)
+	switch inv.spec.Format {
+	case DlInventoryS3:
+		manifest := &s3InventoryManifest{}
+		if err := inv.readJSON(inv.spec.Manifest, manifest); err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(manifest.FileFormat, "CSV") {
+			return nil, fmt.Errorf("s3 inventory %q: unsupported file format %q (expecting CSV)", inv.spec.Manifest, manifest.FileFormat)
+		}
+		column = -1
+		for i, c := range strings.Split(manifest.FileSchema, ",") {
+			if strings.TrimSpace(c) == s3ManifestKeyColumn {
+				column = i
+				break
+			}
+		}
+		if column < 0 {
+			return nil, fmt.Errorf("s3 inventory %q: schema %q does not contain %q", inv.spec.Manifest, manifest.FileSchema, s3ManifestKeyColumn)
+		}
+		ms, err := strconv.ParseInt(manifest.Created, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("s3 inventory %q: invalid creation timestamp %q", inv.spec.Manifest, manifest.Created)
+		}
+		inv.snapshot = time.Unix(0, ms*int64(time.Millisecond))
+		for _, f := range manifest.Files {
+			files = append(files, f.Key)
+		}
+	case DlInventoryGCS:
+		manifest := &gcsInventoryManifest{}
+		if err := inv.readJSON(inv.spec.Manifest, manifest); err != nil {
+			return nil, err
+		}
+		if manifest.SnapshotTime.IsZero() {
+			return nil, fmt.Errorf("gcs inventory %q: missing snapshot time", inv.spec.Manifest)
+		}
+		inv.snapshot = manifest.SnapshotTime
+		dir := path.Dir(inv.spec.Manifest)
+		for _, shard := range manifest.Shards {
+			files = append(files, path.Join(dir, shard))
+		}
+		header = true
+	default:
+		cmn.Assertf(false, "%q", inv.spec.Format)
+	}
+
+	for _, f := range files {
+		if names, err = inv.readCSV(f, column, header, names, filter); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+func (inv *inventory) open(objName string) (io.ReadCloser, error) {
+	lom := &cluster.LOM{T: inv.t, ObjName: objName}
+	if err := lom.Init(inv.bck.Bck); err != nil {
+		return nil, err
+	}
+	r, _, err, errCode := inv.t.Cloud(inv.bck).GetObjReader(inv.ctx, lom)
+	if err != nil {
+		if errCode == http.StatusNotFound {
+			return nil, cmn.NewNotFoundError("inventory file %q", objName)
+		}
+		return nil, fmt.Errorf("failed to read inventory file %q: %v", objName, err)
+	}
+	return r, nil
+}
+
+func (inv *inventory) readJSON(objName string, v interface{}) error {
+	r, err := inv.open(objName)
+	if err != nil {
+		return err
+	}
+	defer cmn.Close(r)
+	if err := jsoniter.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse inventory manifest %q: %v", objName, err)
+	}
+	return nil
+}
+
+func (inv *inventory) readCSV(objName string, column int, header bool, names []string,
+	filter func(string) bool) ([]string, error) {
+	r, err := inv.open(objName)
+	if err != nil {
+		return nil, err
+	}
+	defer cmn.Close(r)
+
+	var reader io.Reader = r
+	if strings.HasSuffix(objName, ".gz") {
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		reader = gzr
+	}
+	return parseInventoryCSV(reader, inv.spec.Format, column, header, names, filter)
+}
+
+func parseInventoryCSV(r io.Reader, format string, column int, header bool, names []string,
+	filter func(string) bool) ([]string, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	if header {
+		hdr, err := cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		column = -1
+		for i, c := range hdr {
+			if c == gcsManifestKeyColumn {
+				column = i
+				break
+			}
+		}
+		if column < 0 {
+			return nil, fmt.Errorf("inventory header %v does not contain %q", hdr, gcsManifestKeyColumn)
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if column >= len(record) {
+			return nil, fmt.Errorf("malformed inventory record %v (expecting at least %d columns)", record, column+1)
+		}
+		name := record[column]
+		if format == DlInventoryS3 {
+			if name, err = url.QueryUnescape(name); err != nil {
+				return nil, err
+			}
+		}
+		if filter(name) {
+			names = append(names, name)
+		}
+	}
+}
+
+// Reads the inventory and merges it with live listing of the recent prefixes.
+// Only the objects that belong to this target are kept in memory; the result
+// is sorted as required by the `DiffResolver`.
+func (j *cloudBucketDlJob) loadInventory() (names []string, err error) {
+	seen := make(map[string]struct{}, 1000)
+	filter := func(objName string) bool {
+		if !j.checkObj(objName) {
+			return false
+		}
+		if _, ok := seen[objName]; ok {
+			return false
+		}
+		if owned, err := j.owns.Owns(j.bck.MakeUname(objName)); err != nil || !owned {
+			return false
+		}
+		seen[objName] = struct{}{}
+		return true
+	}
+
+	inv, err := newInventory(j.ctx, j.t, j.bck, j.inventory)
+	if err != nil {
+		return nil, err
+	}
+	if names, err = inv.objNames(filter); err != nil {
+		if _, ok := err.(*cmn.NotFoundError); !ok {
+			return nil, err
+		}
+		glog.Warningf("%s: inventory %q not found, falling back to live listing", j.id, j.inventory.Manifest)
+		return nil, errInventoryNotFound
+	}
+	j.invSnapshot = inv.snapshot
+	for _, prefix := range j.inventory.RecentPrefixes {
+		if !strings.HasPrefix(prefix, j.prefix) && !strings.HasPrefix(j.prefix, prefix) {
+			continue
+		}
+		if names, err = j.listRecent(prefix, names, filter); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (j *cloudBucketDlJob) listRecent(prefix string, names []string, filter func(string) bool) ([]string, error) {
+	var (
+		cloud = j.t.Cloud(j.bck)
+		msg   = &cmn.SelectMsg{Prefix: prefix, PageSize: cloud.MaxPageSize()}
+	)
+	for {
+		bckList, err, _ := cloud.ListObjects(j.ctx, j.bck, msg)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range bckList.Entries {
+			if filter(entry.Name) {
+				names = append(names, entry.Name)
+			}
+		}
+		if bckList.ContinuationToken == "" {
+			return names, nil
+		}
+		msg.ContinuationToken = bckList.ContinuationToken
+	}
+}
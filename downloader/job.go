@@ -7,11 +7,13 @@ package downloader
 import (
 	"context"
 	"errors"
-	"path"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/nl"
@@ -35,6 +37,11 @@ type (
 		objName   string
 		link      string
 		fromCloud bool
+		// segment of the source (see segment.go); zero length - entire source
+		offset int64
+		length int64
+		// expected checksum of the downloaded object (see DlSingleObj and DlMultiObj)
+		cksum *cmn.Cksum
 	}
 
 	DlJob interface {
@@ -44,14 +51,29 @@ type (
 		Timeout() time.Duration
 		ActiveStats() (*DlStatusResp, error)
 
+		// Deadline of the entire job (zero - no deadline), see DlBase.Deadline.
+		Deadline() time.Duration
+		Rollback() bool
+		Resume() bool
+		// Schedule (see DlBase.Schedule) this job is a run of; empty if none.
+		Schedule() (id string, nextRun time.Time)
+		// Completion webhook URL (see DlBase.NotifyURL); empty if none.
+		NotifyURL() string
+
+		// Destination of a given object, see DlBase.Routes.
+		Route(objName, contentType string) (bck cmn.Bck, dstName string, routed bool)
+
 		Notif() cluster.Notif // notifications
 		AddNotif(n cluster.Notif, job DlJob)
 
-		// If total length (size) of download job is not known, -1 should be returned.
+		// Number of objects to download by this target in the current pass
+		// (see requeue); -1 if not known.
 		Len() int
 
 		// Determines if it requires also syncing.
 		Sync() bool
+		// Sync without downloading or deleting anything, see DlCloudBody.DryRunSync.
+		DryRun() bool
 
 		// Checks if object name matches the request.
 		checkObj(objName string) bool
@@ -60,7 +82,18 @@ type (
 		//  `ok` is set to `true` if there is batch to process, `false` otherwise
 		genNext() (objs []dlObj, ok bool, err error)
 
+		// requeue starts another pass over the job's objects to pick up those
+		// of the targets that have entered maintenance (or left the cluster)
+		// since the current pass had started; returns false if there are none.
+		requeue() bool
+
 		throttler() *throttler
+		politeness() *politeness // nil - no politeness settings
+		header() http.Header     // nil - no headers, see DlBase.Headers
+		owners() *cluster.HrwOwners
+		// Sync: the objects modified after this time (zero - none) are kept
+		// even though they are missing in the source, see DlInventory.
+		snapshot() time.Time
 
 		cleanup()
 	}
@@ -69,8 +102,18 @@ type (
 		id          string
 		bck         *cluster.Bck
 		timeout     time.Duration
+		deadline    time.Duration
+		rollback    bool
+		resume      bool
+		scheduleID  string
+		nextRun     time.Time
+		notifyURL   string
+		routes      dlRoutes
 		description string
 		t           *throttler
+		polite      *politeness
+		hdr         http.Header
+		owns        *cluster.HrwOwners
 		dlXact      *Downloader
 
 		// notif
@@ -79,7 +122,8 @@ type (
 
 	sliceDlJob struct {
 		baseDlJob
-		objs    []dlObj
+		all     []dlObj // all objects of the job
+		objs    []dlObj // objects to download by this target in the current pass
 		current int
 	}
 
@@ -94,11 +138,23 @@ type (
 	rangeDlJob struct {
 		baseDlJob
 		t     cluster.Target
-		objs  []dlObj               // objects' metas which are ready to be downloaded
-		iter  func() (string, bool) // links iterator
-		count int                   // total number object to download by a target
-		dir   string                // objects directory(prefix) from request
-		done  bool                  // true = the iterator is exhausted, nothing left to read
+		objs  []dlObj                         // objects' metas which are ready to be downloaded
+		pt    cmn.ParsedTemplate              // range template (to restart the iterator upon requeue)
+		iter  func() (string, []string, bool) // links iterator
+		idx   int64                           // ordinal number of the next link
+		count int                             // total number object to download by a target
+		namer *rangeNamer                     // generates object names from links
+		done  bool                            // true = the iterator is exhausted, nothing left to read
+	}
+
+	prefetchDlJob struct {
+		baseDlJob
+		objs     []dlObj            // objects' metas which are ready to be downloaded
+		objNames []string           // either the list of object names
+		pt       cmn.ParsedTemplate // or the range template
+		iter     func() (string, bool)
+		count    int  // total number of objects to prefetch by this target
+		done     bool // true = the iterator is exhausted, nothing left to read
 	}
 
 	cloudBucketDlJob struct {
@@ -109,10 +165,26 @@ type (
 		prefix string
 		suffix string
 		sync   bool
+		dryRun bool
 
 		done              bool
 		objs              []dlObj // objects' metas which are ready to be downloaded
 		continuationToken string
+
+		inventory *DlInventory // if set, objects are enumerated from the inventory
+		invNames  []string     // remaining (sorted) names read from the inventory
+		invLoaded bool
+		// time of the inventory snapshot; zero - live listing (see `snapshot`)
+		invSnapshot time.Time
+	}
+
+	dlObjDone struct {
+		attempts   int
+		downloaded int64
+		total      int64
+		startTime  time.Time
+		endTime    time.Time
+		err        string
 	}
 
 	downloadJobInfo struct {
@@ -123,21 +195,50 @@ type (
 		ScheduledCnt atomic.Int32 `json:"scheduled"`
 		SkippedCnt   atomic.Int32 `json:"skipped"`
 		ErrorCnt     atomic.Int32 `json:"errors"`
-		Total        int          `json:"total"`
+		Total        atomic.Int32 `json:"total"`
 
-		Aborted       atomic.Bool `json:"aborted"`
-		AllDispatched atomic.Bool `json:"all_dispatched"`
+		Aborted          atomic.Bool `json:"aborted"`
+		DeadlineExceeded atomic.Bool `json:"deadline_exceeded"`
+		AllDispatched    atomic.Bool `json:"all_dispatched"`
+
+		ResumedSize atomic.Int64 `json:"resumed_size"`
+
+		// sync: counters and (capped) names of the deleted objects and, in
+		// case of dry run, of the objects to be downloaded
+		DeletedCnt    atomic.Int32 `json:"deleted"`
+		ToDownloadCnt atomic.Int32 `json:"to_download"`
+		DryRun        bool         `json:"dry_run"`
+		mtx           sync.Mutex
+		deleted       []string
+		toDownload    []string
+
+		// final state of the job's downloaded (and failed) objects indexed
+		// by name - serves per-object status lookups (see objStatus)
+		objs map[string]*dlObjDone
+
+		ScheduleID string      `json:"schedule_id"`
+		NextRun    atomic.Time `json:"next_run"`
 
 		StartedTime  time.Time   `json:"started_time"`
 		FinishedTime atomic.Time `json:"finished_time"`
 	}
 )
 
-func (j *baseDlJob) ID() string             { return j.id }
-func (j *baseDlJob) Bck() cmn.Bck           { return j.bck.Bck }
-func (j *baseDlJob) Timeout() time.Duration { return j.timeout }
-func (j *baseDlJob) Description() string    { return j.description }
-func (j *baseDlJob) Sync() bool             { return false }
+func (j *baseDlJob) ID() string                    { return j.id }
+func (j *baseDlJob) Bck() cmn.Bck                  { return j.bck.Bck }
+func (j *baseDlJob) Timeout() time.Duration        { return j.timeout }
+func (j *baseDlJob) Deadline() time.Duration       { return j.deadline }
+func (j *baseDlJob) Rollback() bool                { return j.rollback }
+func (j *baseDlJob) Resume() bool                  { return j.resume }
+func (j *baseDlJob) Schedule() (string, time.Time) { return j.scheduleID, j.nextRun }
+func (j *baseDlJob) NotifyURL() string             { return j.notifyURL }
+func (j *baseDlJob) Description() string           { return j.description }
+func (j *baseDlJob) Sync() bool                    { return false }
+func (j *baseDlJob) DryRun() bool                  { return false }
+
+func (j *baseDlJob) Route(objName, contentType string) (cmn.Bck, string, bool) {
+	return j.routes.route(j.bck.Bck, objName, contentType)
+}
 
 // Notifications
 func (j *baseDlJob) Notif() cluster.Notif { return j.notif }
@@ -161,8 +262,12 @@ func (j *baseDlJob) ActiveStats() (*DlStatusResp, error) {
 	}
 	return resp.(*DlStatusResp), nil
 }
-func (j *baseDlJob) checkObj(string) bool  { cmn.Assert(false); return false }
-func (j *baseDlJob) throttler() *throttler { return j.t }
+func (j *baseDlJob) checkObj(string) bool       { cmn.Assert(false); return false }
+func (j *baseDlJob) throttler() *throttler      { return j.t }
+func (j *baseDlJob) politeness() *politeness    { return j.polite }
+func (j *baseDlJob) header() http.Header        { return j.hdr }
+func (j *baseDlJob) owners() *cluster.HrwOwners { return j.owns }
+func (j *baseDlJob) snapshot() time.Time        { return time.Time{} }
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
 	dlStore.markFinished(j.ID())
@@ -170,24 +275,47 @@ func (j *baseDlJob) cleanup() {
 	nl.OnFinished(j.Notif(), nil)
 }
 
-func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits, dlXact *Downloader) *baseDlJob {
+func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlBase, desc string, dlXact *Downloader) *baseDlJob {
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
-	if limits.BytesPerHour > 0 {
-		limits.BytesPerHour /= t.Sowner().Get().CountTargets()
-	}
+	var (
+		numTargets = t.Sowner().Get().CountTargets()
+		limits     = payload.Limits.perTarget(t.Snode().ID(), numTargets)
+	)
 
-	td, _ := time.ParseDuration(timeout)
+	td, _ := time.ParseDuration(payload.Timeout)
+	deadline, _ := time.ParseDuration(payload.Deadline)
 	return &baseDlJob{
 		id:          id,
 		bck:         bck,
 		timeout:     td,
+		deadline:    deadline,
+		rollback:    payload.Rollback,
+		resume:      payload.Resume,
+		scheduleID:  payload.ScheduleID,
+		nextRun:     payload.NextRun,
+		notifyURL:   payload.NotifyURL,
+		routes:      payload.Routes,
 		description: desc,
 		t:           newThrottler(limits),
+		polite:      newPoliteness(payload.Politeness, numTargets),
+		hdr:         newHeader(payload.Headers),
+		owns:        cluster.NewHrwOwners(t.Sowner(), t.Snode().ID()),
 		dlXact:      dlXact,
 	}
 }
 
+func newHeader(headers map[string]string) (header http.Header) {
+	if len(headers) == 0 {
+		return nil
+	}
+	header = make(http.Header, len(headers))
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	return
+}
+
 func (j *sliceDlJob) Len() int { return len(j.objs) }
 func (j *sliceDlJob) genNext() (objs []dlObj, ok bool, err error) {
 	if j.current == len(j.objs) {
@@ -210,14 +338,18 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMul
 		return nil, errAISBckReq
 	}
 	var (
-		objs cmn.SimpleKVs
-		err  error
+		objs   cmn.SimpleKVs
+		cksums map[string]*cmn.Cksum
+		err    error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
-	sliceDlJob, err := newSliceDlJob(t, bck, base, objs)
+	if cksums, err = payload.ExtractCksums(); err != nil {
+		return nil, err
+	}
+	sliceDlJob, err := newSliceDlJob(bck, base, objs, cksums)
 	if err != nil {
 		return nil, err
 	}
@@ -230,37 +362,84 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlSi
 	}
 
 	var (
-		objs cmn.SimpleKVs
-		err  error
+		objs   cmn.SimpleKVs
+		cksums map[string]*cmn.Cksum
+		err    error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
+	if payload.SegmentSize > 0 {
+		sliceDlJob, err := newSegmentedDlJob(t, bck, base, payload)
+		if err != nil {
+			return nil, err
+		}
+		return &singleDlJob{sliceDlJob}, nil
+	}
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
-	sliceDlJob, err := newSliceDlJob(t, bck, base, objs)
+	if cksums, err = payload.ExtractCksums(); err != nil {
+		return nil, err
+	}
+	sliceDlJob, err := newSliceDlJob(bck, base, objs, cksums)
 	if err != nil {
 		return nil, err
 	}
 	return &singleDlJob{sliceDlJob}, nil
 }
 
-func newSliceDlJob(t cluster.Target, bck *cluster.Bck, base *baseDlJob, objects cmn.SimpleKVs) (*sliceDlJob, error) {
-	objs, err := buildDlObjs(t, bck, objects)
+func (j *sliceDlJob) requeue() (ok bool) {
+	if !j.owns.Requeue() {
+		return false
+	}
+	j.objs, j.current = nil, 0
+	objs, err := filterDlObjs(j.owns, j.bck, j.all)
+	if err != nil {
+		glog.Errorf("%s: failed to requeue: %v", j.id, err)
+		return false
+	}
+	j.objs = objs
+	return true
+}
+
+func newSliceDlJob(bck *cluster.Bck, base *baseDlJob, objects cmn.SimpleKVs, cksums map[string]*cmn.Cksum) (*sliceDlJob, error) {
+	all, err := buildDlObjs(objects)
+	if err != nil {
+		return nil, err
+	}
+	if len(cksums) > 0 {
+		for i := range all {
+			all[i].cksum = cksums[all[i].objName]
+		}
+	}
+	objs, err := filterDlObjs(base.owns, bck, all)
 	if err != nil {
 		return nil, err
 	}
 	return &sliceDlJob{
 		baseDlJob: *base,
+		all:       all,
 		objs:      objs,
 	}, nil
 }
 
-func (j *cloudBucketDlJob) Len() int   { return -1 }
-func (j *cloudBucketDlJob) Sync() bool { return j.sync }
+func (j *cloudBucketDlJob) Len() int     { return -1 }
+func (j *cloudBucketDlJob) Sync() bool   { return j.sync }
+func (j *cloudBucketDlJob) DryRun() bool { return j.dryRun }
 func (j *cloudBucketDlJob) checkObj(objName string) bool {
 	return strings.HasPrefix(objName, j.prefix) && strings.HasSuffix(objName, j.suffix)
 }
 
+func (j *cloudBucketDlJob) snapshot() time.Time { return j.invSnapshot }
+
+func (j *cloudBucketDlJob) requeue() bool {
+	if !j.owns.Requeue() {
+		return false
+	}
+	j.done, j.continuationToken = false, ""
+	j.invNames, j.invLoaded = nil, false
+	return true
+}
+
 func (j *cloudBucketDlJob) genNext() (objs []dlObj, ok bool, err error) {
 	if j.done {
 		return nil, false, nil
@@ -274,12 +453,22 @@ func (j *cloudBucketDlJob) genNext() (objs []dlObj, ok bool, err error) {
 // Reads the content of a cloud bucket page by page until any objects to
 // download found or the bucket list is over.
 func (j *cloudBucketDlJob) getNextObjs() error {
-	var (
-		sid   = j.t.Snode().ID()
-		smap  = j.t.Sowner().Get()
-		cloud = j.t.Cloud(j.bck)
-	)
+	cloud := j.t.Cloud(j.bck)
 	j.objs = j.objs[:0]
+	if j.inventory != nil {
+		if !j.invLoaded {
+			names, err := j.loadInventory()
+			if err == nil {
+				j.invNames, j.invLoaded = names, true
+			} else if err == errInventoryNotFound {
+				j.inventory = nil
+				return j.getNextObjs()
+			} else {
+				return err
+			}
+		}
+		return j.nextInventoryObjs()
+	}
 	for len(j.objs) < downloadBatchSize {
 		msg := &cmn.SelectMsg{
 			Prefix:            j.prefix,
@@ -296,7 +485,7 @@ func (j *cloudBucketDlJob) getNextObjs() error {
 			if !j.checkObj(entry.Name) {
 				continue
 			}
-			obj, err := makeDlObj(smap, sid, j.bck, entry.Name, "")
+			obj, err := makeDlObj(j.owns, j.bck, entry.Name, "")
 			if err != nil {
 				if err == errInvalidTarget {
 					continue
@@ -313,6 +502,89 @@ func (j *cloudBucketDlJob) getNextObjs() error {
 	return nil
 }
 
+// NOTE: `invNames` contain only (filtered) objects that belong to this target.
+func (j *cloudBucketDlJob) nextInventoryObjs() error {
+	cnt := cmn.Min(downloadBatchSize, len(j.invNames))
+	for _, name := range j.invNames[:cnt] {
+		j.objs = append(j.objs, dlObj{objName: name, fromCloud: true})
+	}
+	j.invNames = j.invNames[cnt:]
+	if len(j.invNames) == 0 {
+		j.done = true
+	}
+	return nil
+}
+
+func (j *prefetchDlJob) Len() int { return j.count }
+func (j *prefetchDlJob) genNext() ([]dlObj, bool, error) {
+	if j.done {
+		return nil, false, nil
+	}
+	if err := j.getNextObjs(); err != nil {
+		return nil, false, err
+	}
+	return j.objs, true, nil
+}
+
+func (j *prefetchDlJob) requeue() bool {
+	if !j.owns.Requeue() {
+		return false
+	}
+	cnt, err := j.countObjects()
+	if err != nil {
+		glog.Errorf("%s: failed to requeue: %v", j.id, err)
+		return false
+	}
+	j.iter, j.count, j.done = j.newIter(), cnt, false
+	return true
+}
+
+func (j *prefetchDlJob) newIter() func() (string, bool) {
+	if j.objNames == nil {
+		return j.pt.Iter()
+	}
+	var idx int
+	return func() (string, bool) {
+		if idx >= len(j.objNames) {
+			return "", false
+		}
+		idx++
+		return j.objNames[idx-1], true
+	}
+}
+
+func (j *prefetchDlJob) countObjects() (cnt int, err error) {
+	iter := j.newIter()
+	for name, ok := iter(); ok; name, ok = iter() {
+		if _, err = makeDlObj(j.owns, j.bck, name, ""); err == nil {
+			cnt++
+		} else if err != errInvalidTarget {
+			return
+		}
+	}
+	return cnt, nil
+}
+
+func (j *prefetchDlJob) getNextObjs() error {
+	j.objs = j.objs[:0]
+	for len(j.objs) < downloadBatchSize {
+		name, ok := j.iter()
+		if !ok {
+			j.done = true
+			break
+		}
+		obj, err := makeDlObj(j.owns, j.bck, name, "")
+		if err != nil {
+			if err == errInvalidTarget {
+				continue
+			}
+			return err
+		}
+		j.objs = append(j.objs, obj)
+	}
+	return nil
+}
+
 func (j *rangeDlJob) SrcBck() cmn.Bck { return j.bck.Bck }
 func (j *rangeDlJob) Len() int        { return j.count }
 func (j *rangeDlJob) genNext() ([]dlObj, bool, error) {
@@ -325,20 +597,33 @@ func (j *rangeDlJob) genNext() ([]dlObj, bool, error) {
 	return j.objs, true, nil
 }
 
+func (j *rangeDlJob) requeue() bool {
+	if !j.owns.Requeue() {
+		return false
+	}
+	cnt, err := countObjects(j.owns, j.pt, j.namer, j.bck)
+	if err != nil {
+		glog.Errorf("%s: failed to requeue: %v", j.id, err)
+		return false
+	}
+	j.iter, j.idx, j.count, j.done = j.pt.IterGroups(), 0, cnt, false
+	return true
+}
+
 func (j *rangeDlJob) getNextObjs() error {
-	var (
-		smap = j.t.Sowner().Get()
-		sid  = j.t.Snode().ID()
-	)
 	j.objs = j.objs[:0]
 	for len(j.objs) < downloadBatchSize {
-		link, ok := j.iter()
+		link, groups, ok := j.iter()
 		if !ok {
 			j.done = true
 			break
 		}
-		name := path.Join(j.dir, path.Base(link))
-		obj, err := makeDlObj(smap, sid, j.bck, name, link)
+		name, err := j.namer.objName(link, groups, j.idx)
+		if err != nil {
+			return err
+		}
+		j.idx++
+		obj, err := makeDlObj(j.owns, j.bck, name, link)
 		if err != nil {
 			if err == errInvalidTarget {
 				continue
@@ -354,37 +639,62 @@ func newCloudBucketDlJob(ctx context.Context, t cluster.Target, id string, bck *
 	if !bck.IsCloud() {
 		return nil, errors.New("bucket download requires a cloud bucket")
 	}
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
 	job := &cloudBucketDlJob{
 		baseDlJob: *base,
 		t:         t,
 		ctx:       ctx,
 		sync:      payload.Sync,
+		dryRun:    payload.DryRunSync,
 		prefix:    payload.Prefix,
 		suffix:    payload.Suffix,
+		inventory: payload.Inventory,
+	}
+	return job, nil
+}
+
+func newPrefetchDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlPrefetchBody, dlXact *Downloader) (*prefetchDlJob, error) {
+	if !bck.IsCloud() {
+		return nil, errors.New("prefetch requires a cloud bucket")
+	}
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
+	job := &prefetchDlJob{baseDlJob: *base, objNames: payload.ObjNames}
+	if payload.Template != "" {
+		pt, err := cmn.ParseBashTemplate(payload.Template)
+		if err != nil {
+			return nil, err
+		}
+		job.pt = pt
+	}
+	cnt, err := job.countObjects()
+	if err != nil {
+		return nil, err
 	}
+	job.iter, job.count = job.newIter(), cnt
 	return job, nil
 }
 
-func countObjects(t cluster.Target, pt cmn.ParsedTemplate, dir string, bck *cluster.Bck) (cnt int, err error) {
+func countObjects(owners *cluster.HrwOwners, pt cmn.ParsedTemplate, namer *rangeNamer, bck *cluster.Bck) (cnt int, err error) {
 	var (
-		smap = t.Sowner().Get()
-		sid  = t.Snode().ID()
-		iter = pt.Iter()
-		si   *cluster.Snode
+		iter  = pt.IterGroups()
+		idx   int64
+		name  string
+		owned bool
 	)
-
-	for link, ok := iter(); ok; link, ok = iter() {
-		name := path.Join(dir, path.Base(link))
+	for link, groups, ok := iter(); ok; link, groups, ok = iter() {
+		if name, err = namer.objName(link, groups, idx); err != nil {
+			return
+		}
+		idx++
 		name, err = normalizeObjName(name)
 		if err != nil {
 			return
 		}
-		si, err = cluster.HrwTarget(bck.MakeUname(name), smap)
+		owned, err = owners.Owns(bck.MakeUname(name))
 		if err != nil {
 			return
 		}
-		if si.ID() == sid {
+		if owned {
 			cnt++
 		}
 	}
@@ -407,16 +717,21 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 		return nil, err
 	}
 
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
-	cnt, err := countObjects(t, pt, payload.Subdir, base.bck)
+	namer, err := newRangeNamer(payload)
+	if err != nil {
+		return nil, err
+	}
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
+	cnt, err := countObjects(base.owns, pt, namer, base.bck)
 	if err != nil {
 		return nil, err
 	}
 	job := &rangeDlJob{
 		baseDlJob: *base,
 		t:         t,
-		iter:      pt.Iter(),
-		dir:       payload.Subdir,
+		pt:        pt,
+		iter:      pt.IterGroups(),
+		namer:     namer,
 		count:     cnt,
 	}
 	return job, nil
@@ -424,18 +739,47 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 
 func (d *downloadJobInfo) ToDlJobInfo() DlJobInfo {
 	return DlJobInfo{
-		ID:            d.ID,
-		Description:   d.Description,
-		FinishedCnt:   int(d.FinishedCnt.Load()),
-		ScheduledCnt:  int(d.ScheduledCnt.Load()),
-		SkippedCnt:    int(d.SkippedCnt.Load()),
-		ErrorCnt:      int(d.ErrorCnt.Load()),
-		Total:         d.Total,
-		AllDispatched: d.AllDispatched.Load(),
-		Aborted:       d.Aborted.Load(),
-		StartedTime:   d.StartedTime,
-		FinishedTime:  d.FinishedTime.Load(),
+		ID:               d.ID,
+		Description:      d.Description,
+		FinishedCnt:      int(d.FinishedCnt.Load()),
+		ScheduledCnt:     int(d.ScheduledCnt.Load()),
+		SkippedCnt:       int(d.SkippedCnt.Load()),
+		ErrorCnt:         int(d.ErrorCnt.Load()),
+		DeletedCnt:       int(d.DeletedCnt.Load()),
+		ToDownloadCnt:    int(d.ToDownloadCnt.Load()),
+		DryRun:           d.DryRun,
+		Total:            int(d.Total.Load()),
+		AllDispatched:    d.AllDispatched.Load(),
+		Aborted:          d.Aborted.Load(),
+		DeadlineExceeded: d.DeadlineExceeded.Load(),
+		ResumedSize:      d.ResumedSize.Load(),
+		ScheduleID:       d.ScheduleID,
+		NextRun:          d.NextRun.Load(),
+		StartedTime:      d.StartedTime,
+		FinishedTime:     d.FinishedTime.Load(),
+	}
+}
+
+// returns copies of the (capped) lists of deleted and to-be-downloaded objects
+func (d *downloadJobInfo) syncNames() (deleted, toDownload []string) {
+	d.mtx.Lock()
+	deleted = append(deleted, d.deleted...)
+	toDownload = append(toDownload, d.toDownload...)
+	d.mtx.Unlock()
+	return
+}
+
+// returns the object's index entry, creating one if need be; must be called under mtx
+func (d *downloadJobInfo) obj(objName string) *dlObjDone {
+	if d.objs == nil {
+		d.objs = make(map[string]*dlObjDone)
+	}
+	obj, ok := d.objs[objName]
+	if !ok {
+		obj = &dlObjDone{}
+		d.objs[objName] = obj
 	}
+	return obj
 }
 
 // Used for debugging purposes to ensure integrity of the struct.
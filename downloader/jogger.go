@@ -8,19 +8,39 @@ import (
 	"context"
 	"sync"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const queueChSize = 1000
 
+// Per-mountpath concurrency: the number of simultaneous downloads the jogger
+// runs. Starts from the media type's initial value and then gets adjusted
+// (within the media type's bounds) based on the mountpath's utilization -
+// SSDs take more parallel downloads while HDDs avoid seek-thrashing.
+const (
+	hddConcInit = 1
+	hddConcMax  = 2
+	ssdConcInit = 4
+	ssdConcMax  = 16
+)
+
 type (
-	queueEntry = map[string]struct{}
+	queueEntry = map[string]queueItem // request uid -> item
+
+	queueItem struct {
+		objName string
+		seq     int64 // order of arrival (see queue.position)
+	}
 
 	queue struct {
 		sync.RWMutex
-		ch chan *singleObjectTask // for pending downloads
-		m  map[string]queueEntry  // jobID -> set of request uid
+		ch  chan *singleObjectTask // for pending downloads
+		m   map[string]queueEntry  // jobID -> queued requests
+		in  int64                  // number of tasks put so far (lock protected)
+		out atomic.Int64           // number of tasks taken off the channel so far
 	}
 
 	// Each jogger corresponds to an mpath. All types of download requests
@@ -29,36 +49,59 @@ type (
 	// are dlTasks.
 	jogger struct {
 		mpath       string
+		mpathInfo   *fs.MountpathInfo
 		terminateCh *cmn.StopCh // synchronizes termination
 		parent      *dispatcher
 
 		q *queue
 
+		// concurrency (see jogger.adjust)
+		rotational bool
+		maxConc    int
+		conc       atomic.Int32
+		util       atomic.Int64 // utilization at the last adjustment
+		sema       *cmn.DynSemaphore
+		wg         sync.WaitGroup
+
 		mtx sync.RWMutex
 		// lock protected
-		task      *singleObjectTask // currently running download task
+		tasks     map[*singleObjectTask]struct{} // currently running download tasks
 		stopAgent bool
 	}
 )
 
-func newJogger(d *dispatcher, mpath string) *jogger {
-	return &jogger{
-		mpath:       mpath,
+func newJogger(d *dispatcher, mpathInfo *fs.MountpathInfo) *jogger {
+	j := &jogger{
+		mpath:       mpathInfo.Path,
+		mpathInfo:   mpathInfo,
 		parent:      d,
 		q:           newQueue(),
 		terminateCh: cmn.NewStopCh(),
+		rotational:  mpathInfo.IsRotational(),
+		tasks:       make(map[*singleObjectTask]struct{}, ssdConcMax),
 	}
+	conc := ssdConcInit
+	j.maxConc = ssdConcMax
+	if j.rotational {
+		conc, j.maxConc = hddConcInit, hddConcMax
+	}
+	j.conc.Store(int32(conc))
+	j.sema = cmn.NewDynSemaphore(conc)
+	return j
 }
 
 func (j *jogger) jog() {
-	glog.Infof("Starting jogger for mpath %q.", j.mpath)
+	glog.Infof("Starting jogger for mpath %q (%s, concurrency %d).", j.mpath, j.media(), j.conc.Load())
 	for {
+		j.sema.Acquire()
 		t, skip := j.q.get()
 		if t == nil {
+			j.sema.Release()
 			break
 		}
 		if skip {
 			t.job.throttler().release()
+			j.sema.Release()
 			continue
 		}
 
@@ -72,35 +115,86 @@ func (j *jogger) jog() {
 			t.markFailed(internalErrorMsg)
 
 			j.mtx.Unlock()
+			j.sema.Release()
 			continue
 		}
-		j.task = t
+		j.tasks[t] = struct{}{}
 		j.mtx.Unlock()
 
-		t.download()
-		t.job.throttler().release()
-
-		j.mtx.Lock()
-		j.task.persist()
-		j.task = nil
-		j.mtx.Unlock()
-		if exists := j.q.delete(t); exists {
-			j.parent.parent.DecPending()
-		}
+		j.wg.Add(1)
+		go j.run(t)
 	}
 
+	j.wg.Wait()
 	j.q.cleanup()
 	j.terminateCh.Close()
 }
 
+func (j *jogger) run(t *singleObjectTask) {
+	// downloading is a background job - yield to user GETs and PUTs
+	fs.Throttle(j.mpathInfo, fs.QoSLow)
+	t.download()
+	t.job.throttler().release()
+
+	j.mtx.Lock()
+	t.persist()
+	delete(j.tasks, t)
+	j.mtx.Unlock()
+	if exists := j.q.delete(t); exists {
+		j.parent.parent.DecPending()
+	}
+	j.sema.Release()
+	j.wg.Done()
+}
+
+// adjust updates the concurrency based on the mountpath's current utilization:
+// one more download below the low watermark, one less above the high one.
+func (j *jogger) adjust(util int64, config *cmn.Config) {
+	j.util.Store(util)
+	conc := int(j.conc.Load())
+	switch {
+	case util < config.Disk.DiskUtilLowWM:
+		conc++
+	case util > config.Disk.DiskUtilHighWM:
+		conc--
+	default:
+		return
+	}
+	conc = cmn.Min(cmn.Max(conc, 1), j.maxConc)
+	if conc != int(j.conc.Load()) {
+		j.conc.Store(int32(conc))
+		j.sema.SetSize(conc)
+	}
+}
+
+func (j *jogger) media() string {
+	if j.rotational {
+		return DlMediaHDD
+	}
+	return DlMediaSSD
+}
+
+func (j *jogger) mpathInfoResp() DlMpathInfo {
+	j.mtx.RLock()
+	running := len(j.tasks)
+	j.mtx.RUnlock()
+	return DlMpathInfo{
+		Mpath:       j.mpath,
+		Media:       j.media(),
+		Util:        j.util.Load(),
+		Concurrency: int(j.conc.Load()),
+		Running:     running,
+	}
+}
+
 // stop terminates the jogger and waits for it to finish.
 func (j *jogger) stop() {
 	glog.Infof("Stopping jogger for mpath: %s", j.mpath)
 
 	j.mtx.Lock()
 	j.stopAgent = true
-	if j.task != nil {
-		j.task.cancel() // Stops running task (cancels download).
+	for t := range j.tasks {
+		t.cancel() // Stops running task (cancels download).
 	}
 	j.mtx.Unlock()
 	j.q.close()
@@ -117,9 +211,14 @@ func (j *jogger) putCh(t *singleObjectTask) chan<- *singleObjectTask {
 	return ch
 }
 
-func (j *jogger) getTask() (t *singleObjectTask) {
+// returns currently running tasks of a given job
+func (j *jogger) getTasks(id string) (tasks []*singleObjectTask) {
 	j.mtx.RLock()
-	t = j.task
+	for t := range j.tasks {
+		if t.id() == id {
+			tasks = append(tasks, t)
+		}
+	}
 	j.mtx.RUnlock()
 	return
 }
@@ -132,17 +231,18 @@ func (j *jogger) abortJob(id string) {
 	cnt := j.q.removeJob(id)
 	j.parent.parent.SubPending(cnt)
 
-	// Abort currently running task, if belongs to a given job.
-	if j.task != nil && j.task.id() == id {
-		j.task.cancel()
+	// Abort currently running tasks, if belong to a given job.
+	for t := range j.tasks {
+		if t.id() == id {
+			t.cancel()
+		}
 	}
 }
 
 // Returns `true` if there is any pending task for a given job (either running
 // or still in queue), `false` otherwise.
 func (j *jogger) pending(id string) bool {
-	task := j.getTask()
-	return (task != nil && task.id() == id) || j.q.pending(id)
+	return len(j.getTasks(id)) > 0 || j.q.pending(id)
 }
 
 func newQueue() *queue {
@@ -160,7 +260,7 @@ func (q *queue) putCh(t *singleObjectTask) (ok bool, ch chan<- *singleObjectTask
 		q.Unlock()
 		return false, make(chan *singleObjectTask, 1)
 	}
-	q.putToSet(t.id(), t.uid())
+	q.putToSet(t.id(), t.uid(), t.obj.objName)
 	q.Unlock()
 
 	return true, q.ch
@@ -172,6 +272,7 @@ func (q *queue) get() (foundTask *singleObjectTask, skip bool) {
 	if !ok {
 		return nil, false
 	}
+	q.out.Inc()
 
 	q.RLock()
 	defer q.RUnlock()
@@ -229,13 +330,30 @@ func (q *queue) pending(jobID string) bool {
 	return exists
 }
 
+// position returns the 1-based position of the job's object in the queue
+// along with the queue's length. The position is approximate: tasks of the
+// aborted jobs still occupy the channel until taken off by the jogger.
+func (q *queue) position(jobID, objName string) (pos, length int, ok bool) {
+	q.RLock()
+	defer q.RUnlock()
+	out := q.out.Load()
+	length = int(q.in - out)
+	for _, item := range q.m[jobID] {
+		if item.objName == objName && item.seq > out {
+			return int(item.seq - out), length, true
+		}
+	}
+	return 0, length, false
+}
+
 // NOTE: Should be called under `q.Lock()`.
-func (q *queue) putToSet(jobID, requestUID string) {
+func (q *queue) putToSet(jobID, requestUID, objName string) {
 	if _, ok := q.m[jobID]; !ok {
 		q.m[jobID] = make(queueEntry)
 	}
 
-	q.m[jobID][requestUID] = struct{}{}
+	q.in++
+	q.m[jobID][requestUID] = queueItem{objName: objName, seq: q.in}
 }
 
 // NOTE: Should be called under `q.Lock()`.
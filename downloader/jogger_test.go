@@ -0,0 +1,77 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestJoggerAdjust(t *testing.T) {
+	config := &cmn.Config{}
+	config.Disk.DiskUtilLowWM, config.Disk.DiskUtilHighWM = 20, 80
+
+	newTestJogger := func(rotational bool) *jogger {
+		j := &jogger{rotational: rotational, maxConc: ssdConcMax, sema: cmn.NewDynSemaphore(ssdConcInit)}
+		j.conc.Store(ssdConcInit)
+		if rotational {
+			j.maxConc = hddConcMax
+			j.conc.Store(hddConcInit)
+			j.sema.SetSize(hddConcInit)
+		}
+		return j
+	}
+
+	ssd := newTestJogger(false)
+	for i := 0; i < 2*ssdConcMax; i++ {
+		ssd.adjust(5, config)
+	}
+	if c := ssd.conc.Load(); c != ssdConcMax || ssd.sema.Size() != ssdConcMax {
+		t.Errorf("ssd: expected concurrency %d, got %d (sema %d)", ssdConcMax, c, ssd.sema.Size())
+	}
+	ssd.adjust(50, config) // between watermarks: no change
+	if c := ssd.conc.Load(); c != ssdConcMax {
+		t.Errorf("ssd: expected concurrency %d, got %d", ssdConcMax, c)
+	}
+	for i := 0; i < 2*ssdConcMax; i++ {
+		ssd.adjust(95, config)
+	}
+	if c := ssd.conc.Load(); c != 1 || ssd.sema.Size() != 1 {
+		t.Errorf("ssd: expected concurrency 1, got %d (sema %d)", c, ssd.sema.Size())
+	}
+
+	hdd := newTestJogger(true)
+	for i := 0; i < 10; i++ {
+		hdd.adjust(0, config)
+	}
+	if c := hdd.conc.Load(); c != hddConcMax {
+		t.Errorf("hdd: expected concurrency %d, got %d", hddConcMax, c)
+	}
+	if info := hdd.mpathInfoResp(); info.Media != DlMediaHDD || info.Concurrency != hddConcMax || info.Util != 0 {
+		t.Errorf("hdd: unexpected info %+v", info)
+	}
+}
+
+func TestQueuePosition(t *testing.T) {
+	q := newQueue()
+	q.putToSet("job1", "a", "a")
+	q.putToSet("job2", "b", "b")
+	q.putToSet("job1", "c", "c")
+	if pos, length, ok := q.position("job1", "c"); !ok || pos != 3 || length != 3 {
+		t.Errorf("expected position 3 of 3, got %d of %d (%t)", pos, length, ok)
+	}
+	// taken off the channel (running) - no longer in the queue
+	q.out.Inc()
+	if _, _, ok := q.position("job1", "a"); ok {
+		t.Error("expected dequeued object not to have a position")
+	}
+	if pos, length, ok := q.position("job1", "c"); !ok || pos != 2 || length != 2 {
+		t.Errorf("expected position 2 of 2, got %d of %d (%t)", pos, length, ok)
+	}
+	if _, _, ok := q.position("job2", "c"); ok {
+		t.Error("expected object of a different job not to be found")
+	}
+}
@@ -0,0 +1,75 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Object name template of the range download (see DlRangeBody.NameTemplate)
+// is a Go text/template executed for each generated link, e.g.:
+//   `{{trimPrefix .Base "shard-"}}`           - strip prefix of the basename
+//   `train/{{index .Groups 0}}/{{.Base}}`     - insert shard index as a directory
+//   `{{printf "%05d" (atoi (index .Groups 0))}}.tar` - re-pad the index
+
+type dlNameArgs struct {
+	Link   string   // source link, as generated by the range template
+	Base   string   // link basename (the default object name)
+	Groups []string // values of the template's ranges, as they appear in the link
+	Index  int64    // ordinal number of the link in the template (starting from 0)
+}
+
+var nameTemplateFuncs = template.FuncMap{
+	"trimPrefix": strings.TrimPrefix,
+	"trimSuffix": strings.TrimSuffix,
+	"replace":    func(s, old, new string) string { return strings.ReplaceAll(s, old, new) },
+	"ext":        path.Ext,
+	"atoi":       func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) },
+	"add":        func(a, b int64) int64 { return a + b },
+}
+
+func parseNameTemplate(s string) (*template.Template, error) {
+	tmpl, err := template.New("name").Funcs(nameTemplateFuncs).Option("missingkey=error").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template %q: %v", s, err)
+	}
+	return tmpl, nil
+}
+
+// rangeNamer generates object names for the links of a range download
+type rangeNamer struct {
+	dir  string             // subdirectory (see DlRangeBody.Subdir)
+	tmpl *template.Template // nil - link basename
+	sb   strings.Builder
+}
+
+func newRangeNamer(payload *DlRangeBody) (n *rangeNamer, err error) {
+	n = &rangeNamer{dir: payload.Subdir}
+	if payload.NameTemplate != "" {
+		n.tmpl, err = parseNameTemplate(payload.NameTemplate)
+	}
+	return
+}
+
+func (n *rangeNamer) objName(link string, groups []string, idx int64) (string, error) {
+	base := path.Base(link)
+	if n.tmpl == nil {
+		return path.Join(n.dir, base), nil
+	}
+	n.sb.Reset()
+	args := dlNameArgs{Link: link, Base: base, Groups: groups, Index: idx}
+	if err := n.tmpl.Execute(&n.sb, &args); err != nil {
+		return "", fmt.Errorf("failed to generate object name for %q: %v", redactLink(link), err)
+	}
+	name := strings.TrimSpace(n.sb.String())
+	if name == "" {
+		return "", fmt.Errorf("empty object name generated for %q", redactLink(link))
+	}
+	return path.Join(n.dir, name), nil
+}
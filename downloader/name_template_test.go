@@ -0,0 +1,57 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestRangeNameTemplate(t *testing.T) {
+	const template = "https://example.com/data/train/shard-{008..010}-{1..2}.tar"
+	tests := []struct {
+		nameTemplate string
+		subdir       string
+		expected     []string
+	}{
+		{"", "", []string{"shard-008-1.tar", "shard-008-2.tar", "shard-009-1.tar"}},
+		{"", "imagenet", []string{"imagenet/shard-008-1.tar", "imagenet/shard-008-2.tar", "imagenet/shard-009-1.tar"}},
+		{`{{trimPrefix .Base "shard-"}}`, "", []string{"008-1.tar", "008-2.tar", "009-1.tar"}},
+		{`{{index .Groups 1}}/{{index .Groups 0}}{{ext .Base}}`, "x", []string{"x/1/008.tar", "x/2/008.tar", "x/1/009.tar"}},
+		{`{{printf "%05d" (atoi (index .Groups 0))}}-{{.Index}}`, "", []string{"00008-0", "00008-1", "00009-2"}},
+	}
+	for _, test := range tests {
+		pt, err := cmn.ParseBashTemplate(template)
+		tassert.CheckFatal(t, err)
+		namer, err := newRangeNamer(&DlRangeBody{Template: template, Subdir: test.subdir, NameTemplate: test.nameTemplate})
+		tassert.CheckFatal(t, err)
+		var (
+			iter = pt.IterGroups()
+			idx  int64
+		)
+		for _, expected := range test.expected {
+			link, groups, ok := iter()
+			tassert.Fatalf(t, ok, "%q: template exhausted", test.nameTemplate)
+			name, err := namer.objName(link, groups, idx)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, name == expected, "%q: expected %q, got %q", test.nameTemplate, expected, name)
+			idx++
+		}
+	}
+
+	// invalid and failing templates
+	_, err := parseNameTemplate("{{.Base")
+	tassert.Errorf(t, err != nil, "expected parsing error")
+	namer, err := newRangeNamer(&DlRangeBody{NameTemplate: "{{index .Groups 3}}"})
+	tassert.CheckFatal(t, err)
+	_, err = namer.objName("https://example.com/shard-1.tar", []string{"1"}, 0)
+	tassert.Errorf(t, err != nil, "expected execution error (index out of range)")
+	namer, err = newRangeNamer(&DlRangeBody{NameTemplate: "{{if false}}x{{end}}"})
+	tassert.CheckFatal(t, err)
+	_, err = namer.objName("https://example.com/shard-1.tar", []string{"1"}, 0)
+	tassert.Errorf(t, err != nil, "expected error (empty name)")
+}
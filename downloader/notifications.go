@@ -17,6 +17,7 @@ import (
 type (
 	NotifDownloadListerner struct {
 		nl.NotifListenerBase
+		NotifyURL string // job completion webhook, see DlBase.NotifyURL
 	}
 
 	NotifDownload struct {
@@ -48,6 +49,23 @@ func (nd *NotifDownloadListerner) UnmarshalStats(rawMsg []byte) (stats interface
 	return
 }
 
+// JobInfo aggregates the job's summary reported by the targets.
+func (nd *NotifDownloadListerner) JobInfo() *DlJobInfo {
+	var resp *DlStatusResp
+	nd.NodeStats().Range(func(_ string, stats interface{}) bool {
+		if dlStatus, ok := stats.(*DlStatusResp); ok {
+			resp = resp.Aggregate(*dlStatus)
+		}
+		return true
+	})
+	if resp == nil {
+		return &DlJobInfo{ID: nd.UUID(), Aborted: nd.Aborted()}
+	}
+	resp.DlJobInfo.ID = nd.UUID()
+	resp.DlJobInfo.Aborted = resp.DlJobInfo.Aborted || nd.Aborted()
+	return &resp.DlJobInfo
+}
+
 func (nd *NotifDownloadListerner) QueryArgs() cmn.ReqArgs {
 	args := cmn.ReqArgs{Method: http.MethodGet}
 	dlBody := DlAdminBody{
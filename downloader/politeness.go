@@ -0,0 +1,371 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Politeness settings apply to web (link-based) downloads only. Same as
+// `DlLimits`, the cluster-wide per-host limits are split between the targets
+// by each target upon creating the job: every target makes at most
+// `HostConnections / #targets` concurrent connections to a given host and sends
+// requests to the host no more often than every `HostInterval * #targets`.
+// (Hence, the proxy rejects jobs with `HostConnections` less than the number of
+// targets.) The limits apply to all requests to the host including HEAD
+// (e.g., when syncing) and fetching robots.txt.
+
+const (
+	dlDefaultUserAgent = "aistore"
+	robotsTxtPath      = "/robots.txt"
+	robotsTxtMaxSize   = 512 * cmn.KiB // the rest of robots.txt is ignored
+)
+
+var errDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+type (
+	// DlPoliteness limits the load that a download job puts on the source hosts.
+	DlPoliteness struct {
+		RobotsTxt       bool   `json:"robots_txt,omitempty"`       // obey robots.txt of the source hosts
+		UserAgent       string `json:"user_agent,omitempty"`       // sent to the hosts and matched against robots.txt
+		HostConnections int    `json:"host_connections,omitempty"` // max concurrent connections per host (cluster-wide)
+		HostInterval    string `json:"host_interval,omitempty"`    // min interval between requests to a host (cluster-wide)
+	}
+
+	politeness struct {
+		robotsTxt   bool
+		userAgent   string
+		connections int           // per target
+		interval    time.Duration // per target
+		numTargets  int
+
+		mtx   sync.Mutex
+		hosts map[string]*hostState
+	}
+
+	hostState struct {
+		conns chan struct{} // nil - unlimited
+
+		mtx      sync.Mutex
+		next     time.Time // earliest time of the next request
+		robots   *robotsRules
+		fetching chan struct{} // non-nil - robots.txt is being fetched (closed when done)
+	}
+
+	robotsRules struct {
+		rules      []robotsRule
+		crawlDelay time.Duration
+	}
+
+	robotsRule struct {
+		pattern string
+		allow   bool
+	}
+)
+
+func (p *DlPoliteness) Validate() error {
+	if p.HostConnections < 0 {
+		return fmt.Errorf("'politeness.host_connections' must be non-negative (got: %d)", p.HostConnections)
+	}
+	if p.HostInterval != "" {
+		if d, err := time.ParseDuration(p.HostInterval); err != nil {
+			return fmt.Errorf("failed to parse politeness.host_interval field: %v", err)
+		} else if d < 0 {
+			return fmt.Errorf("'politeness.host_interval' must be non-negative (got: %s)", p.HostInterval)
+		}
+	}
+	return nil
+}
+
+// ValidateTargets checks that each of the targets gets at least one connection.
+func (p *DlPoliteness) ValidateTargets(numTargets int) error {
+	if p.HostConnections > 0 && p.HostConnections < numTargets {
+		return fmt.Errorf("'politeness.host_connections' (%d) must be at least the number of targets (%d)",
+			p.HostConnections, numTargets)
+	}
+	return nil
+}
+
+func newPoliteness(p *DlPoliteness, numTargets int) *politeness {
+	if p == nil {
+		return nil
+	}
+	interval, _ := time.ParseDuration(p.HostInterval)
+	pol := &politeness{
+		robotsTxt:  p.RobotsTxt,
+		userAgent:  p.UserAgent,
+		interval:   interval * time.Duration(numTargets),
+		numTargets: numTargets,
+		hosts:      make(map[string]*hostState, 4),
+	}
+	if pol.userAgent == "" {
+		pol.userAgent = dlDefaultUserAgent
+	}
+	if p.HostConnections > 0 {
+		// zero share is possible only if the cluster has grown since the job
+		// was validated - still, not exceeding the cluster-wide limit by much
+		pol.connections = cmn.Max(p.HostConnections/numTargets, 1)
+	}
+	return pol
+}
+
+func (p *politeness) host(u *url.URL) *hostState {
+	p.mtx.Lock()
+	h, ok := p.hosts[u.Host]
+	if !ok {
+		h = &hostState{}
+		if p.connections > 0 {
+			h.conns = make(chan struct{}, p.connections)
+		}
+		p.hosts[u.Host] = h
+	}
+	p.mtx.Unlock()
+	return h
+}
+
+// acquire waits for a vacant connection to the host and for the host's
+// request interval; the returned function must be called once the request
+// (including reading the response) is done.
+func (p *politeness) acquire(ctx context.Context, u *url.URL) (release func(), err error) {
+	h := p.host(u)
+	release = func() {}
+	if h.conns != nil {
+		select {
+		case h.conns <- struct{}{}:
+			release = func() { <-h.conns }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if p.robotsTxt {
+		if err = p.checkRobots(ctx, h, u); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	h.mtx.Lock()
+	interval := p.interval
+	if h.robots != nil && h.robots.crawlDelay*time.Duration(p.numTargets) > interval {
+		interval = h.robots.crawlDelay * time.Duration(p.numTargets)
+	}
+	now := time.Now()
+	wait := h.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	h.next = now.Add(wait + interval)
+	h.mtx.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// checkRobots applies robots.txt of the host.
+func (p *politeness) checkRobots(ctx context.Context, h *hostState, u *url.URL) error {
+	rules, err := p.robots(ctx, h, u)
+	if err != nil {
+		return err
+	}
+	if !rules.allowed(u.EscapedPath()) {
+		return fmt.Errorf("%s: %w", u, errDisallowedByRobots)
+	}
+	return nil
+}
+
+// robots returns the rules of the host fetching robots.txt (once per host and
+// not under the lock) if need be; concurrent callers wait for the fetch to
+// complete. Failures to fetch are not cached so that the next attempt (retry)
+// fetches again.
+func (p *politeness) robots(ctx context.Context, h *hostState, u *url.URL) (*robotsRules, error) {
+	for {
+		h.mtx.Lock()
+		if rules := h.robots; rules != nil {
+			h.mtx.Unlock()
+			return rules, nil
+		}
+		if fetching := h.fetching; fetching != nil {
+			h.mtx.Unlock()
+			select {
+			case <-fetching:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		fetching := make(chan struct{})
+		h.fetching = fetching
+		h.mtx.Unlock()
+
+		rules, err := p.fetchRobots(ctx, u)
+		h.mtx.Lock()
+		if err == nil {
+			h.robots = rules
+		}
+		h.fetching = nil
+		h.mtx.Unlock()
+		close(fetching)
+		return rules, err
+	}
+}
+
+func (p *politeness) fetchRobots(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	robotsURL := url.URL{Scheme: u.Scheme, Host: u.Host, Path: robotsTxtPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	resp, err := clientForURL(robotsURL.String()).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer cmn.Close(resp.Body)
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("failed to fetch %s: status %d", robotsURL.String(), resp.StatusCode)
+	case resp.StatusCode >= http.StatusBadRequest:
+		// no robots.txt - no restrictions
+		return &robotsRules{}, nil
+	}
+	return parseRobots(io.LimitReader(resp.Body, robotsTxtMaxSize), p.userAgent)
+}
+
+////////////////
+// robots.txt //
+////////////////
+
+// parseRobots returns the rules of the group that matches the user agent
+// (the most specific one) or, if there's none, of the `*` group.
+func parseRobots(r io.Reader, userAgent string) (*robotsRules, error) {
+	var (
+		scanner   = bufio.NewScanner(r)
+		ua        = strings.ToLower(userAgent)
+		groups    = make(map[string]*robotsRules, 4)
+		current   []string // user agents of the group being parsed
+		inRules   bool     // parsing the rules (as opposed to user agents) of the group
+		bestAgent string
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:i]))
+		val := strings.TrimSpace(line[i+1:])
+		switch key {
+		case "user-agent":
+			if inRules {
+				current, inRules = nil, false
+			}
+			agent := strings.ToLower(val)
+			current = append(current, agent)
+			if _, ok := groups[agent]; !ok {
+				groups[agent] = &robotsRules{}
+			}
+			if agent != "*" && strings.Contains(ua, agent) && len(agent) > len(bestAgent) {
+				bestAgent = agent
+			}
+		case "allow", "disallow":
+			inRules = true
+			if val == "" { // empty disallow allows everything
+				continue
+			}
+			for _, agent := range current {
+				g := groups[agent]
+				g.rules = append(g.rules, robotsRule{pattern: val, allow: key == "allow"})
+			}
+		case "crawl-delay":
+			inRules = true
+			secs, err := strconv.ParseFloat(val, 64)
+			if err != nil || secs < 0 {
+				continue
+			}
+			for _, agent := range current {
+				groups[agent].crawlDelay = time.Duration(secs * float64(time.Second))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if bestAgent == "" {
+		bestAgent = "*"
+	}
+	if g, ok := groups[bestAgent]; ok {
+		return g, nil
+	}
+	return &robotsRules{}, nil
+}
+
+// allowed applies the longest matching rule; `allow` wins a tie.
+func (rr *robotsRules) allowed(urlPath string) bool {
+	if urlPath == "" {
+		urlPath = "/"
+	}
+	var (
+		allow   = true
+		longest = -1
+	)
+	for _, rule := range rr.rules {
+		if !robotsMatch(rule.pattern, urlPath) {
+			continue
+		}
+		if l := len(rule.pattern); l > longest || (l == longest && rule.allow) {
+			allow, longest = rule.allow, l
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches the path against the pattern which may contain
+// wildcards (`*`) and the end-of-path anchor (`$`).
+func robotsMatch(pattern, urlPath string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = pattern[:len(pattern)-1]
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(urlPath, parts[0]) {
+		return false
+	}
+	rest := urlPath[len(parts[0]):]
+	for i := 1; i < len(parts); i++ {
+		if i == len(parts)-1 && anchored {
+			return strings.HasSuffix(rest, parts[i])
+		}
+		j := strings.Index(rest, parts[i])
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(parts[i]):]
+	}
+	return !anchored || rest == ""
+}
@@ -0,0 +1,154 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+const robotsTxt = `
+# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/public$
+Crawl-delay: 2
+
+User-agent: aistore
+User-agent: other
+Disallow: /*.php$
+Disallow: /tmp
+Allow: /tmp/keep
+Crawl-delay: 0.5
+`
+
+func TestParseRobots(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{"some-crawler", "/private/x", false},
+		{"some-crawler", "/private/public", true},
+		{"some-crawler", "/private/public/x", false},
+		{"some-crawler", "/index.php", true},
+		{"aistore/1.0", "/private/x", true},
+		{"aistore/1.0", "/index.php", false},
+		{"aistore/1.0", "/index.php?q=1", true},
+		{"aistore/1.0", "/tmp/x", false},
+		{"aistore/1.0", "/tmp/keep/x", true},
+		{"aistore/1.0", "", true},
+	}
+	for _, test := range tests {
+		rules, err := parseRobots(strings.NewReader(robotsTxt), test.userAgent)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, rules.allowed(test.path) == test.allowed,
+			"%s %q: expected allowed=%t", test.userAgent, test.path, test.allowed)
+	}
+
+	rules, err := parseRobots(strings.NewReader(robotsTxt), "AIStore")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, rules.crawlDelay == 500*time.Millisecond, "unexpected crawl delay %v", rules.crawlDelay)
+	rules, err = parseRobots(strings.NewReader(robotsTxt), "some-crawler")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, rules.crawlDelay == 2*time.Second, "unexpected crawl delay %v", rules.crawlDelay)
+}
+
+func TestPolitenessHostLimits(t *testing.T) {
+	var (
+		pol = newPoliteness(&DlPoliteness{HostConnections: 4, HostInterval: "50ms"}, 2)
+		u1  = &url.URL{Scheme: "http", Host: "a.com", Path: "/1"}
+		u2  = &url.URL{Scheme: "http", Host: "b.com", Path: "/1"}
+		ctx = context.Background()
+	)
+	tassert.Errorf(t, pol.connections == 2, "expected 2 connections per target, got %d", pol.connections)
+	tassert.Errorf(t, pol.interval == 100*time.Millisecond, "expected 100ms interval per target, got %v", pol.interval)
+
+	started := time.Now()
+	release, err := pol.acquire(ctx, u1)
+	tassert.CheckFatal(t, err)
+	release()
+	release, err = pol.acquire(ctx, u1)
+	tassert.CheckFatal(t, err)
+	release()
+	elapsed := time.Since(started)
+	tassert.Errorf(t, elapsed >= 100*time.Millisecond, "interval not enforced (%v)", elapsed)
+
+	// other hosts are not affected
+	started = time.Now()
+	release, err = pol.acquire(ctx, u2)
+	tassert.CheckFatal(t, err)
+	elapsed = time.Since(started)
+	tassert.Errorf(t, elapsed < 50*time.Millisecond, "unexpected wait (%v)", elapsed)
+
+	// connection limit
+	release2, err := pol.acquire(ctx, u2)
+	tassert.CheckFatal(t, err)
+	ctx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+	_, err = pol.acquire(ctx, u2)
+	tassert.Errorf(t, err == context.DeadlineExceeded, "expected connection limit, got %v", err)
+	release()
+	release2()
+}
+
+func TestPolitenessValidateTargets(t *testing.T) {
+	p := &DlPoliteness{HostConnections: 2}
+	tassert.CheckError(t, p.ValidateTargets(2))
+	tassert.Errorf(t, p.ValidateTargets(3) != nil, "expected error: fewer connections than targets")
+	p.HostConnections = 0 // unlimited
+	tassert.CheckError(t, p.ValidateTargets(3))
+}
+
+func TestPolitenessRobots(t *testing.T) {
+	var (
+		fetched atomic.Int32
+		heads   atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == robotsTxtPath {
+			fetched.Inc()
+			time.Sleep(50 * time.Millisecond)
+			_, err := w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+			tassert.CheckError(t, err)
+			return
+		}
+		heads.Inc()
+	}))
+	defer srv.Close()
+
+	var (
+		pol = newPoliteness(&DlPoliteness{RobotsTxt: true}, 1)
+		wg  = &sync.WaitGroup{}
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := headLink(srv.URL+"/public/obj", nil, pol)
+			tassert.CheckError(t, err)
+			if err == nil {
+				tassert.Errorf(t, resp.StatusCode == http.StatusOK, "expected 200, got %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	tassert.Errorf(t, fetched.Load() == 1, "expected robots.txt to be fetched once, got %d", fetched.Load())
+
+	// HEAD is subject to robots.txt as well
+	_, err := headLink(srv.URL+"/private/obj", nil, pol)
+	tassert.Errorf(t, errors.Is(err, errDisallowedByRobots), "expected %v, got %v", errDisallowedByRobots, err)
+	tassert.Errorf(t, heads.Load() == 4, "expected 4 HEAD requests, got %d", heads.Load())
+}
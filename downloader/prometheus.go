@@ -0,0 +1,60 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"sort"
+
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// WriteProm writes the per-job stats of all download jobs known to this
+// target (see infoStore), running or not, labeled with the job ID. Note that
+// the downloader's byte count and latency are part of the target's core stats.
+func WriteProm(pw *stats.PromWriter) {
+	if dlStore == nil {
+		return // downloader never started
+	}
+	var (
+		records = dlStore.getList(nil)
+		jobs    = make([]DlJobInfo, 0, len(records))
+	)
+	for _, r := range records {
+		jobs = append(jobs, r.ToDlJobInfo())
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+
+	counters := []struct {
+		name, help string
+		get        func(j *DlJobInfo) int
+	}{
+		{"ais_dl_job_finished_total", "number of objects downloaded (or skipped) by the job",
+			func(j *DlJobInfo) int { return j.FinishedCnt }},
+		{"ais_dl_job_skipped_total", "number of objects skipped by the job (e.g., already present)",
+			func(j *DlJobInfo) int { return j.SkippedCnt }},
+		{"ais_dl_job_errors_total", "number of objects the job failed to download",
+			func(j *DlJobInfo) int { return j.ErrorCnt }},
+		{"ais_dl_job_scheduled_total", "number of objects scheduled by the job",
+			func(j *DlJobInfo) int { return j.ScheduledCnt }},
+	}
+	for _, c := range counters {
+		pw.Family(c.name, stats.PromCounter, c.help)
+		for i := range jobs {
+			pw.Sample(c.name, float64(c.get(&jobs[i])), "job", jobs[i].ID)
+		}
+	}
+	pw.Family("ais_dl_job_objects", stats.PromGauge, "total number of objects to download (negative if unknown)")
+	for i := range jobs {
+		pw.Sample("ais_dl_job_objects", float64(jobs[i].Total), "job", jobs[i].ID)
+	}
+	pw.Family("ais_dl_job_running", stats.PromGauge, "1 if the job is running, 0 otherwise")
+	for i := range jobs {
+		var running float64
+		if jobs[i].JobRunning() {
+			running = 1
+		}
+		pw.Sample("ais_dl_job_running", running, "job", jobs[i].ID)
+	}
+}
@@ -0,0 +1,46 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestWriteProm(t *testing.T) {
+	saved := dlStore
+	defer func() { dlStore = saved }()
+
+	var sb strings.Builder
+	dlStore = nil
+	WriteProm(stats.NewPromWriter(&sb, "t1"))
+	tassert.Errorf(t, sb.Len() == 0, "expected no output, got %q", sb.String())
+
+	dlStore = &infoStore{
+		downloaderDB: newDownloadDB(dbdriver.NewDBMock()),
+		jobInfo:      make(map[string]*downloadJobInfo),
+	}
+	ji := &downloadJobInfo{ID: `job"1`}
+	ji.FinishedCnt.Store(7)
+	ji.ErrorCnt.Store(2)
+	ji.Total.Store(10)
+	dlStore.jobInfo[ji.ID] = ji
+
+	WriteProm(stats.NewPromWriter(&sb, "t1"))
+	out := sb.String()
+	for _, line := range []string{
+		"# TYPE ais_dl_job_finished_total counter\n",
+		`ais_dl_job_finished_total{node="t1",job="job\"1"} 7` + "\n",
+		`ais_dl_job_errors_total{node="t1",job="job\"1"} 2` + "\n",
+		`ais_dl_job_objects{node="t1",job="job\"1"} 10` + "\n",
+		`ais_dl_job_running{node="t1",job="job\"1"} 1` + "\n",
+	} {
+		tassert.Errorf(t, strings.Contains(out, line), "expected %q in:\n%s", line, out)
+	}
+}
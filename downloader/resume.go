@@ -0,0 +1,212 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
+)
+
+// xattr of the partial workfile that holds the validator (ETag or Last-Modified)
+// of the source - the download is resumed only if the source has not changed
+const xattrDlPart = "user.ais.dlpart"
+
+// dlPart is a partially downloaded object (see DlBase.Resume). Unlike regular
+// workfiles, it is kept when the download fails, to be resumed (with a range
+// request) by the next attempt or by another job downloading the same object
+// from the same source. Once complete, it gets promoted in place (see
+// putPart).
+type dlPart struct {
+	fqn       string
+	mpath     *fs.MountpathInfo
+	file      *os.File
+	offset    int64 // see dlObj
+	length    int64
+	size      int64  // downloaded so far
+	validator string // ETag or Last-Modified of the source
+}
+
+// partial workfiles in use: concurrent tasks (of the same or different jobs)
+// downloading the same object from the same link do not share the workfile -
+// all but one download without resuming
+var dlPartsInUse = struct {
+	sync.Mutex
+	fqns map[string]struct{}
+}{fqns: make(map[string]struct{})}
+
+// returns nil (and no error) if the partial workfile is in use by another task
+func openDlPart(lom *cluster.LOM, obj dlObj) (p *dlPart, err error) {
+	src := obj.link
+	if obj.length > 0 {
+		src += fmt.Sprintf("#%d-%d", obj.offset, obj.length)
+	}
+	tag := strconv.FormatUint(xxhash.ChecksumString64S(src, cmn.MLCG32), 16)
+	p = &dlPart{
+		fqn:    fs.CSM.GenResumableFQN(lom.ParsedFQN, fs.WorkfileDlPart, tag),
+		mpath:  lom.ParsedFQN.MpathInfo,
+		offset: obj.offset,
+		length: obj.length,
+	}
+	dlPartsInUse.Lock()
+	_, inUse := dlPartsInUse.fqns[p.fqn]
+	if !inUse {
+		dlPartsInUse.fqns[p.fqn] = struct{}{}
+	}
+	dlPartsInUse.Unlock()
+	if inUse {
+		return nil, nil
+	}
+	if err = cmn.CreateDir(filepath.Dir(p.fqn)); err != nil {
+		p.release()
+		return nil, err
+	}
+	if p.file, err = os.OpenFile(p.fqn, os.O_CREATE|os.O_RDWR, 0o644); err != nil {
+		p.release()
+		return nil, err
+	}
+	finfo, err := p.file.Stat()
+	if err != nil {
+		p.close()
+		return nil, err
+	}
+	if p.size = finfo.Size(); p.size == 0 {
+		return p, nil
+	}
+	if b, err := fs.GetXattr(p.fqn, xattrDlPart); err == nil && len(b) > 0 {
+		p.validator = string(b)
+	} else {
+		p.truncate() // cannot tell whether the source has changed
+	}
+	return p, nil
+}
+
+// requests the remaining part if (and only if) the source has not changed
+func (p *dlPart) setRange(req *http.Request) {
+	start := p.offset + p.size
+	if p.length > 0 {
+		req.Header.Set(cmn.HeaderRange, fmt.Sprintf("%s%d-%d", cmn.HeaderRangeValPrefix, start, p.offset+p.length-1))
+	} else {
+		req.Header.Set(cmn.HeaderRange, fmt.Sprintf("%s%d-", cmn.HeaderRangeValPrefix, start))
+	}
+	req.Header.Set(cmn.HeaderIfRange, p.validator)
+}
+
+// resume checks that the response continues the partial download and returns
+// the size the download is resumed at; zero means starting over
+func (p *dlPart) resume(resp *http.Response) (int64, error) {
+	if p.size > 0 {
+		if resp.StatusCode == http.StatusPartialContent {
+			if start, ok := contentRangeStart(resp.Header.Get(cmn.HeaderContentRange)); ok && start == p.offset+p.size {
+				return p.size, nil
+			}
+			p.truncate()
+			return 0, fmt.Errorf("cannot resume %q at %d: unexpected %s %q",
+				p.fqn, p.size, cmn.HeaderContentRange, resp.Header.Get(cmn.HeaderContentRange))
+		}
+		// the source has changed or does not support range requests
+		p.truncate()
+	}
+	p.validator = ""
+	if etag := resp.Header.Get(cmn.HeaderETag); etag != "" && !strings.HasPrefix(etag, "W/") {
+		p.validator = etag
+	} else {
+		p.validator = resp.Header.Get(cmn.HeaderLastModified)
+	}
+	if p.validator != "" {
+		if err := fs.SetXattr(p.fqn, xattrDlPart, []byte(p.validator)); err != nil {
+			glog.Errorf("%s: %v", p.fqn, err)
+		}
+	}
+	return 0, nil
+}
+
+// download appends the rest of the object
+func (p *dlPart) download(r io.Reader) error {
+	if _, err := p.file.Seek(p.size, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := io.Copy(p.file, r)
+	p.size += n
+	return err
+}
+
+// reader of the entire (downloaded) object
+func (p *dlPart) reader() (io.ReadCloser, error) {
+	if _, err := p.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(p.file), nil
+}
+
+// validate checks the entire (downloaded) object against the expected checksum
+func (p *dlPart) validate(expected *cmn.Cksum, objName string) error {
+	r, err := p.reader()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(ioutil.Discard, newCksumReader(r, expected, objName))
+	return err
+}
+
+func (p *dlPart) truncate() {
+	if err := p.file.Truncate(0); err != nil {
+		glog.Errorf("%s: %v", p.fqn, err)
+	}
+	p.size, p.validator = 0, ""
+}
+
+func (p *dlPart) close() {
+	if p.file != nil {
+		cmn.Close(p.file)
+		p.file = nil
+		p.release()
+	}
+}
+
+func (p *dlPart) release() {
+	dlPartsInUse.Lock()
+	delete(dlPartsInUse.fqns, p.fqn)
+	dlPartsInUse.Unlock()
+}
+
+// (removes before releasing - see dlPartsInUse)
+func (p *dlPart) remove() {
+	if p.file == nil {
+		return
+	}
+	cmn.Close(p.file)
+	p.file = nil
+	if err := cmn.RemoveFile(p.fqn); err != nil {
+		glog.Errorf("%s: %v", p.fqn, err)
+	}
+	p.release()
+}
+
+// parses the start of "bytes 100-199/1000"
+func contentRangeStart(s string) (int64, bool) {
+	if !strings.HasPrefix(s, cmn.HeaderContentRangeValPrefix) {
+		return 0, false
+	}
+	s = s[len(cmn.HeaderContentRangeValPrefix):]
+	i := strings.IndexByte(s, '-')
+	if i <= 0 {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(s[:i], 10, 64)
+	return start, err == nil
+}
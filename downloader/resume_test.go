@@ -0,0 +1,157 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestContentRangeStart(t *testing.T) {
+	tests := []struct {
+		s     string
+		start int64
+		ok    bool
+	}{
+		{"bytes 0-99/1000", 0, true},
+		{"bytes 100-199/*", 100, true},
+		{"bytes */1000", 0, false},
+		{"items 100-199/1000", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		start, ok := contentRangeStart(test.s)
+		tassert.Errorf(t, ok == test.ok && start == test.start, "%q: expected (%d, %t), got (%d, %t)",
+			test.s, test.start, test.ok, start, ok)
+	}
+}
+
+func TestDlPartResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dlpart")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	newPart := func(content string) *dlPart {
+		fqn := filepath.Join(dir, "part")
+		err := ioutil.WriteFile(fqn, []byte(content), 0o644)
+		tassert.CheckFatal(t, err)
+		file, err := os.OpenFile(fqn, os.O_RDWR, 0o644)
+		tassert.CheckFatal(t, err)
+		return &dlPart{fqn: fqn, file: file, size: int64(len(content)), validator: `"v1"`}
+	}
+	readAll := func(p *dlPart, body string) string {
+		err := p.download(strings.NewReader(body))
+		tassert.CheckFatal(t, err)
+		r, err := p.reader()
+		tassert.CheckFatal(t, err)
+		b, err := ioutil.ReadAll(r)
+		tassert.CheckFatal(t, err)
+		return string(b)
+	}
+
+	// the source supports range requests and has not changed
+	p := newPart("hello ")
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/obj", nil)
+	p.setRange(req)
+	tassert.Errorf(t, req.Header.Get(cmn.HeaderRange) == "bytes=6-", "range: %q", req.Header.Get(cmn.HeaderRange))
+	tassert.Errorf(t, req.Header.Get(cmn.HeaderIfRange) == `"v1"`, "if-range: %q", req.Header.Get(cmn.HeaderIfRange))
+	resp := &http.Response{StatusCode: http.StatusPartialContent, Header: http.Header{}}
+	resp.Header.Set(cmn.HeaderContentRange, "bytes 6-10/11")
+	resumed, err := p.resume(resp)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resumed == 6, "expected resumed at 6, got %d", resumed)
+	s := readAll(p, "world")
+	tassert.Errorf(t, s == "hello world", "got %q", s)
+	p.close()
+
+	// the source has changed - starting over
+	p = newPart("hello ")
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set(cmn.HeaderETag, `"v2"`)
+	resumed, err = p.resume(resp)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resumed == 0, "expected to start over, got %d", resumed)
+	tassert.Errorf(t, p.validator == `"v2"`, "validator: %q", p.validator)
+	s = readAll(p, "brave new world")
+	tassert.Errorf(t, s == "brave new world", "got %q", s)
+	p.close()
+
+	// unexpected range
+	p = newPart("hello ")
+	resp = &http.Response{StatusCode: http.StatusPartialContent, Header: http.Header{}}
+	resp.Header.Set(cmn.HeaderContentRange, "bytes 0-10/11")
+	_, err = p.resume(resp)
+	tassert.Errorf(t, err != nil, "expected error")
+	tassert.Errorf(t, p.size == 0, "expected truncated, got %d", p.size)
+	p.close()
+}
+
+func TestDlPartValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dlpart")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	fqn := filepath.Join(dir, "part")
+	file, err := os.OpenFile(fqn, os.O_CREATE|os.O_RDWR, 0o644)
+	tassert.CheckFatal(t, err)
+	p := &dlPart{fqn: fqn, file: file}
+	defer p.close()
+	tassert.CheckFatal(t, p.download(strings.NewReader("hello world")))
+
+	good := cmn.NewCksum(cmn.ChecksumMD5, "5eb63bbbe01eeed093cb22bb8f5acdc3")
+	tassert.CheckError(t, p.validate(good, "obj"))
+	bad := cmn.NewCksum(cmn.ChecksumMD5, "00000000000000000000000000000000")
+	err = p.validate(bad, "obj")
+	tassert.Errorf(t, errors.Is(err, &cmn.BadCksumError{}), "expected bad checksum, got %v", err)
+}
+
+func TestOpenDlPartInUse(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "dlpart")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+
+	cluster.InitTarget()
+	fs.Init()
+	fs.DisableFsIDCheck()
+	tassert.CheckFatal(t, fs.Add(mpath))
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+
+	var (
+		bck = cluster.NewBck("dl", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{})
+		lom = &cluster.LOM{T: cluster.NewTargetMock(cluster.NewBaseBownerMock(bck)), ObjName: "obj"}
+		obj = dlObj{objName: "obj", link: "http://localhost/obj"}
+	)
+	tassert.CheckFatal(t, lom.Init(bck.Bck))
+
+	p1, err := openDlPart(lom, obj)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, p1 != nil, "expected partial workfile")
+	p2, err := openDlPart(lom, obj)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, p2 == nil, "expected the partial workfile to be in use")
+
+	// ranges of the same link do not share the workfile
+	p3, err := openDlPart(lom, dlObj{objName: "obj", link: obj.link, offset: 0, length: 10})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, p3 != nil && p3.fqn != p1.fqn, "expected a different partial workfile")
+	p3.remove()
+
+	p1.close()
+	p2, err = openDlPart(lom, obj)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, p2 != nil && p2.fqn == p1.fqn, "expected the released partial workfile")
+	p2.remove()
+}
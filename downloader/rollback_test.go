@@ -0,0 +1,75 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestRollbackTasks(t *testing.T) {
+	var (
+		tasks = []TaskDlInfo{
+			{Name: "created", Created: true},
+			{Name: "synced"}, // existed prior to the job (updated in place)
+			{Name: "failed", Created: true},
+			{Name: "routed", Created: true, RoutedName: "dst/routed"},
+		}
+		dlErrors = []TaskErrInfo{{Name: "failed", Err: "timeout"}}
+	)
+	rb := rollbackTasks(tasks, dlErrors)
+	tassert.Fatalf(t, len(rb) == 2, "expected 2 tasks to roll back, got %d", len(rb))
+	tassert.Errorf(t, rb[0].Name == "created", "expected %q, got %q", "created", rb[0].Name)
+	tassert.Errorf(t, rb[1].Name == "routed", "expected %q, got %q", "routed", rb[1].Name)
+	tassert.Errorf(t, len(tasks) == 4 && tasks[1].Name == "synced", "tasks must not be modified")
+}
+
+func TestTaskCreated(t *testing.T) {
+	task := &singleObjectTask{obj: dlObj{objName: "obj"}}
+	tassert.Errorf(t, task.ToTaskDlInfo().Created, "expected the object to be reported as created")
+	task.existed.Store(true)
+	tassert.Errorf(t, !task.ToTaskDlInfo().Created, "expected the object to be reported as existing")
+}
+
+func TestJobDeadline(t *testing.T) {
+	t.Run("exceeded", func(t *testing.T) {
+		var (
+			aborted atomic.Bool
+			dl      = &jobDeadline{}
+		)
+		dl.start(time.Millisecond, func() { aborted.Store(true) })
+		time.Sleep(50 * time.Millisecond)
+		dl.finish()
+		tassert.Errorf(t, aborted.Load(), "expected the job to be aborted")
+	})
+	t.Run("finished", func(t *testing.T) {
+		var (
+			aborted atomic.Bool
+			dl      = &jobDeadline{}
+		)
+		dl.start(20*time.Millisecond, func() { aborted.Store(true) })
+		dl.finish()
+		time.Sleep(50 * time.Millisecond)
+		tassert.Errorf(t, !aborted.Load(), "finished job must not be aborted")
+	})
+	t.Run("race", func(t *testing.T) {
+		// the timer fires while the job is finishing: either the abort completes
+		// before finish() returns, or it does not happen at all
+		for i := 0; i < 100; i++ {
+			var (
+				aborted atomic.Bool
+				dl      = &jobDeadline{}
+			)
+			dl.start(0, func() { aborted.Store(true) })
+			dl.finish()
+			before := aborted.Load()
+			time.Sleep(time.Millisecond)
+			tassert.Fatalf(t, aborted.Load() == before, "job aborted after it finished")
+		}
+	})
+}
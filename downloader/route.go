@@ -0,0 +1,98 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// DlRoute routes downloaded objects - by extension or by Content-Type
+// returned by the source - into a different object name prefix and/or
+// a different (ais) destination bucket. Routes are evaluated in order
+// and the first match wins; unmatched objects land as usual.
+// A routed object is stored by its HRW target (as per the routed bucket and
+// name), and is not downloaded again if already present there - unless routed
+// by content type (that is known only upon download).
+type DlRoute struct {
+	Ext         string  `json:"ext,omitempty"`          // e.g. ".jpg" (case-insensitive)
+	ContentType string  `json:"content_type,omitempty"` // e.g. "image/jpeg" or "image/*"
+	Prefix      string  `json:"prefix,omitempty"`       // prepended to the object name
+	Bck         cmn.Bck `json:"bucket,omitempty"`       // destination bucket (default: job's bucket)
+}
+
+type dlRoutes []DlRoute
+
+func (r *DlRoute) Validate() error {
+	if (r.Ext == "") == (r.ContentType == "") {
+		return errors.New("route must specify either 'ext' or 'content_type'")
+	}
+	if r.Prefix == "" && r.Bck.Name == "" {
+		return fmt.Errorf("route %q must specify 'prefix' and/or 'bucket'", r.match())
+	}
+	if r.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(r.ContentType); err != nil {
+			return fmt.Errorf("invalid route content type %q: %v", r.ContentType, err)
+		}
+	}
+	if r.Bck.Name != "" {
+		if err := cmn.ValidateBckName(r.Bck.Name); err != nil {
+			return err
+		}
+		if r.Bck.Provider != "" && !r.Bck.IsAIS() {
+			return fmt.Errorf("route %q: destination bucket %s must be ais bucket", r.match(), r.Bck)
+		}
+	}
+	return nil
+}
+
+func (r *DlRoute) match() string {
+	if r.Ext != "" {
+		return r.Ext
+	}
+	return r.ContentType
+}
+
+func (r *DlRoute) matches(objName, contentType string) bool {
+	if r.Ext != "" {
+		return strings.EqualFold(path.Ext(objName), "."+strings.TrimPrefix(r.Ext, "."))
+	}
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasSuffix(r.ContentType, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(r.ContentType, "*"))
+	}
+	routeType, _, _ := mime.ParseMediaType(r.ContentType)
+	return mediaType == routeType
+}
+
+// route returns the destination of a given object (the job's bucket and the
+// unchanged name if none of the routes matches)
+func (routes dlRoutes) route(bck cmn.Bck, objName, contentType string) (cmn.Bck, string, bool) {
+	for i := range routes {
+		r := &routes[i]
+		if !r.matches(objName, contentType) {
+			continue
+		}
+		if r.Bck.Name != "" {
+			bck = r.Bck
+			if bck.Provider == "" {
+				bck.Provider = cmn.ProviderAIS
+			}
+		}
+		return bck, r.Prefix + objName, true
+	}
+	return bck, objName, false
+}
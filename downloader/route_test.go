@@ -0,0 +1,67 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestDlRoutes(t *testing.T) {
+	var (
+		bck    = cmn.Bck{Name: "crawl", Provider: cmn.ProviderAIS}
+		images = cmn.Bck{Name: "images", Provider: cmn.ProviderAIS}
+		routes = dlRoutes{
+			{Ext: "jpg", Bck: cmn.Bck{Name: "images"}},
+			{Ext: ".PDF", Prefix: "docs/"},
+			{ContentType: "text/*", Prefix: "text/"},
+			{ContentType: "application/json", Prefix: "json/", Bck: images},
+		}
+	)
+	tests := []struct {
+		objName     string
+		contentType string
+		bck         cmn.Bck
+		dstName     string
+		routed      bool
+	}{
+		{"a/b.jpg", "", images, "a/b.jpg", true},
+		{"b.JPG", "text/html", images, "b.JPG", true}, // first match wins
+		{"c.pdf", "application/pdf", bck, "docs/c.pdf", true},
+		{"d", "text/html; charset=utf-8", bck, "text/d", true},
+		{"e", "application/json", images, "json/e", true},
+		{"f.tar", "application/x-tar", bck, "f.tar", false},
+		{"g", "", bck, "g", false},
+		{"h", "invalid;;", bck, "h", false},
+	}
+	for _, test := range tests {
+		dstBck, dstName, routed := routes.route(bck, test.objName, test.contentType)
+		if routed != test.routed || dstName != test.dstName || !dstBck.Equal(test.bck) {
+			t.Errorf("route(%q, %q): expected (%s, %q, %t), got (%s, %q, %t)", test.objName, test.contentType,
+				test.bck, test.dstName, test.routed, dstBck, dstName, routed)
+		}
+	}
+}
+
+func TestDlRouteValidate(t *testing.T) {
+	tests := []struct {
+		route DlRoute
+		valid bool
+	}{
+		{DlRoute{Ext: ".jpg", Prefix: "img/"}, true},
+		{DlRoute{ContentType: "image/*", Bck: cmn.Bck{Name: "images"}}, true},
+		{DlRoute{Prefix: "img/"}, false},
+		{DlRoute{Ext: ".jpg", ContentType: "image/jpeg", Prefix: "img/"}, false},
+		{DlRoute{Ext: ".jpg"}, false},
+		{DlRoute{ContentType: "image/", Prefix: "img/"}, false},
+		{DlRoute{Ext: ".jpg", Bck: cmn.Bck{Name: "images", Provider: cmn.ProviderAmazon}}, false},
+	}
+	for _, test := range tests {
+		if err := test.route.Validate(); (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid=%t, got err=%v", test.route, test.valid, err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// IDs of the schedules (see DlBase.Schedule) are distinguished by the prefix.
+	DlSchedulePrefix = "sched-"
+
+	minScheduleInterval = time.Minute
+	maxScheduleLookup   = 5 * 366 * 24 * time.Hour // no match within - the schedule never fires
+)
+
+// DlSchedule determines when a job gets re-run (see DlBase.Schedule). Supported
+// formats: interval (e.g. "6h") or standard 5-field cron expression ("minute hour
+// day-of-month month day-of-week") with `*`, lists ("1,15"), ranges ("1-5"), and
+// steps ("*/6", "0-30/10"). As in cron, when both day-of-month and day-of-week
+// are restricted, the day matches either one.
+type DlSchedule struct {
+	interval time.Duration
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7}, // both 0 and 7 stand for Sunday
+}
+
+func ParseDlSchedule(s string) (*DlSchedule, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < minScheduleInterval {
+			return nil, fmt.Errorf("schedule interval %v is too short (minimum %v)", d, minScheduleInterval)
+		}
+		return &DlSchedule{interval: d}, nil
+	}
+	fields := strings.Fields(s)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expecting interval (e.g. \"6h\") or cron expression with %d fields",
+			s, len(cronFields))
+	}
+	var (
+		sched = &DlSchedule{}
+		masks [5]uint64
+	)
+	for i, f := range fields {
+		mask, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", s, err)
+		}
+		masks[i] = mask
+	}
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1 // Sunday
+	}
+	sched.minute, sched.hour, sched.dom, sched.month, sched.dow = masks[0], masks[1], masks[2], masks[3], masks[4]
+	sched.domStar, sched.dowStar = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+	return sched, nil
+}
+
+func parseCronField(s string, f cronField) (mask uint64, err error) {
+	for _, item := range strings.Split(s, ",") {
+		var (
+			lo, hi = f.min, f.max
+			step   = 1
+			rng    = item
+		)
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, item)
+			}
+			rng = item[:i]
+		}
+		if rng != "*" {
+			if i := strings.IndexByte(rng, '-'); i >= 0 {
+				lo, err = strconv.Atoi(rng[:i])
+				if err == nil {
+					hi, err = strconv.Atoi(rng[i+1:])
+				}
+			} else {
+				lo, err = strconv.Atoi(rng)
+				hi = lo
+				if err == nil && step > 1 {
+					hi = f.max // e.g. "5/15" (same as "5-59/15")
+				}
+			}
+			if err != nil || lo < f.min || hi > f.max || lo > hi {
+				return 0, fmt.Errorf("invalid %s %q (expecting %d-%d)", f.name, item, f.min, f.max)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// Next returns the first time after `after` the schedule fires; zero time if it never does.
+func (s *DlSchedule) Next(after time.Time) time.Time {
+	if s.interval > 0 {
+		return after.Add(s.interval)
+	}
+	var (
+		t     = after.Truncate(time.Minute).Add(time.Minute)
+		until = after.Add(maxScheduleLookup)
+	)
+	for t.Before(until) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = nextHour(t)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			// skip to the next allowed minute within the hour, if any
+			if rest := s.minute >> uint(t.Minute()); rest != 0 {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			} else {
+				t = nextHour(t)
+			}
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// (not using Truncate - time zones with offsets other than whole hours)
+func nextHour(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+}
+
+func (s *DlSchedule) dayMatches(t time.Time) bool {
+	var (
+		dom = s.dom&(1<<uint(t.Day())) != 0
+		dow = s.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
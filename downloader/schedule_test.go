@@ -0,0 +1,60 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestParseDlSchedule(t *testing.T) {
+	valid := []string{"6h", "1m", "* * * * *", "0 2 * * *", "*/15 0-6 1,15 * 1-5", "5/10 * * 1-12/2 7", "0 0 29 2 *"}
+	for _, s := range valid {
+		_, err := ParseDlSchedule(s)
+		tassert.Errorf(t, err == nil, "%q: unexpected error: %v", s, err)
+	}
+	invalid := []string{"", "10s", "-1h", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "1-a * * * *"}
+	for _, s := range invalid {
+		_, err := ParseDlSchedule(s)
+		tassert.Errorf(t, err != nil, "%q: expected error", s)
+	}
+}
+
+func TestDlScheduleNext(t *testing.T) {
+	// Wednesday
+	now := time.Date(2020, time.September, 30, 10, 17, 42, 0, time.UTC)
+	tests := []struct {
+		sched string
+		next  time.Time
+	}{
+		{"6h", now.Add(6 * time.Hour)},
+		{"* * * * *", time.Date(2020, time.September, 30, 10, 18, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2020, time.October, 1, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.September, 30, 10, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, time.October, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, time.October, 4, 0, 0, 0, 0, time.UTC)},
+		{"30 9 1 1 *", time.Date(2021, time.January, 1, 9, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// both day-of-month and day-of-week restricted - either one
+		{"0 12 15 * 5", time.Date(2020, time.October, 2, 12, 0, 0, 0, time.UTC)},
+		{"0 12 1 * 6", time.Date(2020, time.October, 1, 12, 0, 0, 0, time.UTC)},
+		// day-of-week restricted only
+		{"0 12 * * 5", time.Date(2020, time.October, 2, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		sched, err := ParseDlSchedule(test.sched)
+		tassert.CheckFatal(t, err)
+		next := sched.Next(now)
+		tassert.Errorf(t, next.Equal(test.next), "%q: expected %v, got %v", test.sched, test.next, next)
+	}
+
+	// never fires
+	sched, err := ParseDlSchedule("0 0 31 2 *")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, sched.Next(now).IsZero(), "expected schedule that never fires")
+}
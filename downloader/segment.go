@@ -0,0 +1,129 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Segmented download: a single connection caps the download of a (huge) file far
+// below the aggregate bandwidth of the cluster. Instead, the file is split into
+// ranges (segments) of `SegmentSize` bytes and each segment is downloaded - via
+// HTTP range request - by the target that owns (HRW) its name: `<object_name>.segNNNNN`.
+// The target that owns `<object_name>.manifest` stores the DlSegmentManifest.
+
+const (
+	segManifestSuffix = ".manifest"
+	segMinWidth       = 5 // min number of digits in the segment's name
+)
+
+type segment struct {
+	objName string
+	offset  int64
+	length  int64
+}
+
+func splitSegments(objName string, size, segSize int64) []segment {
+	var (
+		cnt   = (size + segSize - 1) / segSize
+		width = cmn.Max(len(strconv.FormatInt(cnt-1, 10)), segMinWidth)
+		segs  = make([]segment, 0, cnt)
+	)
+	for i, off := int64(0), int64(0); off < size; i, off = i+1, off+segSize {
+		segs = append(segs, segment{
+			objName: fmt.Sprintf("%s.seg%0*d", objName, width, i),
+			offset:  off,
+			length:  cmn.MinI64(segSize, size-off),
+		})
+	}
+	return segs
+}
+
+func newSegmentedDlJob(t cluster.Target, bck *cluster.Bck, base *baseDlJob, payload *DlSingleBody) (*sliceDlJob, error) {
+	objName, err := normalizeObjName(payload.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	link := cmn.PrependProtocol(payload.Link)
+	resp, err := headLink(link, base.header(), base.politeness())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("HEAD %q failed with %d status code (%s)", redactLink(link), resp.StatusCode,
+			http.StatusText(resp.StatusCode))
+	}
+	if resp.ContentLength <= 0 {
+		return nil, fmt.Errorf("cannot download %q in segments: size unknown", redactLink(link))
+	}
+	if resp.Header.Get(cmn.HeaderAcceptRanges) == "none" {
+		return nil, fmt.Errorf("cannot download %q in segments: range requests not supported", redactLink(link))
+	}
+
+	var (
+		segs     = splitSegments(objName, resp.ContentLength, payload.SegmentSize)
+		all      = make([]dlObj, 0, len(segs))
+		manifest = &DlSegmentManifest{
+			Link:        redactLink(link),
+			Size:        resp.ContentLength,
+			SegmentSize: payload.SegmentSize,
+			Segments:    make([]string, 0, len(segs)),
+		}
+	)
+	for _, seg := range segs {
+		manifest.Segments = append(manifest.Segments, seg.objName)
+		obj, err := newDlObj(seg.objName, link)
+		if err != nil {
+			return nil, err
+		}
+		obj.offset, obj.length = seg.offset, seg.length
+		all = append(all, obj)
+	}
+	objs, err := filterDlObjs(base.owns, bck, all)
+	if err != nil {
+		return nil, err
+	}
+	if err := putSegManifest(t, bck, objName+segManifestSuffix, manifest); err != nil {
+		return nil, err
+	}
+	return &sliceDlJob{baseDlJob: *base, all: all, objs: objs}, nil
+}
+
+// stores the manifest if this target is the one to store it (noop otherwise)
+func putSegManifest(t cluster.Target, bck *cluster.Bck, name string, manifest *DlSegmentManifest) error {
+	si, err := cluster.HrwTarget(bck.MakeUname(name), t.Sowner().Get())
+	if err != nil {
+		return err
+	}
+	if si.ID() != t.Snode().ID() {
+		return nil
+	}
+	lom := &cluster.LOM{T: t, ObjName: name}
+	if err := lom.Init(bck.Bck); err != nil {
+		return err
+	}
+	b, err := jsoniter.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	lom.SetCustomMD(cmn.SimpleKVs{cluster.SourceObjMD: cluster.SourceWebObjMD})
+	return t.PutObject(lom, cluster.PutObjectParams{
+		Reader:       ioutil.NopCloser(bytes.NewReader(b)),
+		WorkFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		RecvType:     cluster.ColdGet,
+		Started:      time.Now(),
+		WithFinalize: true,
+	})
+}
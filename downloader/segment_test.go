@@ -0,0 +1,39 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestSplitSegments(t *testing.T) {
+	tests := []struct {
+		size, segSize int64
+		cnt           int
+		last          string
+	}{
+		{size: 1, segSize: 10, cnt: 1, last: "obj.seg00000"},
+		{size: 100, segSize: 10, cnt: 10, last: "obj.seg00009"},
+		{size: 101, segSize: 10, cnt: 11, last: "obj.seg00010"},
+		{size: 1_000_001, segSize: 1, cnt: 1_000_001, last: "obj.seg1000000"},
+	}
+	for _, test := range tests {
+		segs := splitSegments("obj", test.size, test.segSize)
+		tassert.Fatalf(t, len(segs) == test.cnt, "expected %d segments, got %d", test.cnt, len(segs))
+		var total int64
+		for i, seg := range segs {
+			tassert.Fatalf(t, seg.offset == total, "segment %d: offset %d != %d", i, seg.offset, total)
+			tassert.Fatalf(t, seg.length > 0 && seg.length <= test.segSize, "segment %d: invalid length %d", i, seg.length)
+			if i > 0 {
+				tassert.Fatalf(t, segs[i-1].objName < seg.objName, "segment names must sort in order")
+			}
+			total += seg.length
+		}
+		tassert.Errorf(t, total == test.size, "expected total %d, got %d", test.size, total)
+		tassert.Errorf(t, segs[len(segs)-1].objName == test.last, "expected %q, got %q", test.last, segs[len(segs)-1].objName)
+	}
+}
@@ -0,0 +1,118 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+const (
+	// Smallest allocation - zero (bytes per minute, see throttler) would mean unlimited.
+	minDlShare = 60
+
+	// A target that has used less than `dlShareUnderused` of its previous
+	// allocation is capped at its throughput (plus `dlShareHeadroom`, to be
+	// able to speed up) and the rest of the budget goes to the others.
+	dlShareUnderused = 0.8
+	dlShareHeadroom  = 1.25
+)
+
+// DlShareStats is what the allocation of the shared budget (see DlLimits.Shared)
+// is based upon - reported by each target in DlStatusResp.
+type DlShareStats struct {
+	Pending    int   // tasks dispatched and not finished yet
+	Done       bool  // all dispatched and nothing pending
+	Throughput int64 // bytes per second
+}
+
+func NewDlShareStats(resp *DlStatusResp) DlShareStats {
+	pending := resp.ScheduledCnt - resp.FinishedCnt - resp.ErrorCnt
+	if pending < 0 {
+		pending = 0
+	}
+	return DlShareStats{
+		Pending:    pending,
+		Done:       resp.AllDispatched && pending == 0,
+		Throughput: resp.Throughput,
+	}
+}
+
+// DlShares divides the cluster-wide `budget` (bytes per hour) between the
+// targets proportionally to the number of their pending tasks. Targets that
+// have not used their previous allocation (`prev`, nil if none) get as much as
+// they can use, and the surplus is divided between the rest.
+func DlShares(budget int, stats map[string]DlShareStats, prev map[string]int) map[string]int {
+	var (
+		shares  = make(map[string]int, len(stats))
+		weights = make(map[string]int, len(stats))
+		caps    = make(map[string]int, len(stats))
+	)
+	for tid, st := range stats {
+		shares[tid] = minDlShare
+		if st.Done {
+			continue
+		}
+		weights[tid] = st.Pending
+		if weights[tid] == 0 {
+			weights[tid] = 1 // still dispatching
+		}
+		if share, ok := prev[tid]; ok {
+			if rate := int(st.Throughput * 3600); float64(rate) < float64(share)*dlShareUnderused {
+				caps[tid] = int(float64(rate) * dlShareHeadroom)
+			}
+		}
+	}
+	if len(weights) == 0 {
+		return shares
+	}
+
+	// water-filling: targets capped below their proportional part get their caps,
+	// the rest of the budget is divided between the others
+	var (
+		active    = make(map[string]int, len(weights))
+		remaining = budget
+	)
+	for tid, w := range weights {
+		active[tid] = w
+	}
+	for len(active) > 0 {
+		var total int
+		for _, w := range active {
+			total += w
+		}
+		var fixed []string
+		for tid, w := range active {
+			if c, ok := caps[tid]; ok && c < proportional(remaining, w, total) {
+				fixed = append(fixed, tid)
+			}
+		}
+		if len(fixed) == 0 {
+			for tid, w := range active {
+				shares[tid] = proportional(remaining, w, total)
+			}
+			remaining = 0
+			break
+		}
+		for _, tid := range fixed {
+			shares[tid] = caps[tid]
+			remaining -= caps[tid]
+			delete(active, tid)
+		}
+	}
+	// all capped - no reason to hold back the rest
+	if remaining > 0 {
+		var total int
+		for _, w := range weights {
+			total += w
+		}
+		for tid, w := range weights {
+			shares[tid] += proportional(remaining, w, total)
+		}
+	}
+	for tid, share := range shares {
+		if share < minDlShare {
+			shares[tid] = minDlShare
+		}
+	}
+	return shares
+}
+
+func proportional(n, w, total int) int { return int(int64(n) * int64(w) / int64(total)) }
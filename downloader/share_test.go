@@ -0,0 +1,66 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestDlShares(t *testing.T) {
+	const budget = 3600 * 1000
+
+	// proportional to pending tasks; done targets get the minimum
+	stats := map[string]DlShareStats{
+		"t1": {Pending: 3},
+		"t2": {Pending: 1},
+		"t3": {Done: true},
+		"t4": {}, // still dispatching
+	}
+	shares := DlShares(budget, stats, nil)
+	tassert.Errorf(t, shares["t1"] == budget*3/5, "t1: %d", shares["t1"])
+	tassert.Errorf(t, shares["t2"] == budget/5, "t2: %d", shares["t2"])
+	tassert.Errorf(t, shares["t3"] == minDlShare, "t3: %d", shares["t3"])
+	tassert.Errorf(t, shares["t4"] == budget/5, "t4: %d", shares["t4"])
+
+	// t1 uses only 100 bytes/s out of its allocation - the surplus goes to the others
+	stats = map[string]DlShareStats{
+		"t1": {Pending: 2, Throughput: 100},
+		"t2": {Pending: 1, Throughput: 1000},
+		"t3": {Pending: 1, Throughput: 1000},
+	}
+	prev := map[string]int{"t1": budget / 2, "t2": budget / 4, "t3": budget / 4}
+	shares = DlShares(budget, stats, prev)
+	capped := int(100 * 3600 * dlShareHeadroom)
+	tassert.Errorf(t, shares["t1"] == capped, "t1: %d", shares["t1"])
+	tassert.Errorf(t, shares["t2"] == (budget-capped)/2, "t2: %d", shares["t2"])
+	tassert.Errorf(t, shares["t3"] == (budget-capped)/2, "t3: %d", shares["t3"])
+
+	// everyone is underusing - the entire budget is allocated anyway
+	stats = map[string]DlShareStats{
+		"t1": {Pending: 1, Throughput: 10},
+		"t2": {Pending: 1, Throughput: 10},
+	}
+	prev = map[string]int{"t1": budget / 2, "t2": budget / 2}
+	shares = DlShares(budget, stats, prev)
+	tassert.Errorf(t, shares["t1"]+shares["t2"] == budget, "expected the entire budget allocated: %v", shares)
+
+	// all done
+	shares = DlShares(budget, map[string]DlShareStats{"t1": {Done: true}}, nil)
+	tassert.Errorf(t, shares["t1"] == minDlShare, "t1: %d", shares["t1"])
+}
+
+func TestDlLimitsPerTarget(t *testing.T) {
+	limits := DlLimits{BytesPerHour: 3000, Shared: true, Shares: map[string]int{"t1": 2500, "t2": 500}}
+	l := limits.perTarget("t1", 2)
+	tassert.Errorf(t, l.BytesPerHour == 2500 && l.Shares == nil, "t1: %+v", l)
+	l = limits.perTarget("t3", 3) // not allocated yet
+	tassert.Errorf(t, l.BytesPerHour == 1000, "t3: %+v", l)
+
+	limits.Shared = false
+	l = limits.perTarget("t1", 2)
+	tassert.Errorf(t, l.BytesPerHour == 1500, "t1: %+v", l)
+}
@@ -53,10 +53,19 @@ type (
 
 		currentSize atomic.Int64 // the current size of the file (updated as the download progresses)
 		totalSize   atomic.Int64 // the total size of the file (nonzero only if Content-Length header was provided by the source of the file)
+		resumed     atomic.Int64 // the size the download was resumed at (see DlBase.Resume)
+		routed      atomic.Value // *dlDst if the object got routed elsewhere (see DlRoute)
+		attempts    atomic.Int32 // download attempts so far (see DlObjStatus)
+		existed     atomic.Bool  // the object existed prior to the download (see DlBase.Rollback)
 
 		downloadCtx context.Context    // context with cancel function
 		cancelFunc  context.CancelFunc // used to cancel the download after the request commences
 	}
+
+	dlDst struct {
+		bck     cmn.Bck
+		objName string
+	}
 )
 
 func (t *singleObjectTask) download() {
@@ -69,6 +78,7 @@ func (t *singleObjectTask) download() {
 		t.markFailed(internalErrorMsg)
 		return
 	}
+	t.existed.Store(err == nil)
 
 	if glog.V(4) {
 		glog.Infof("Starting download for %v", t)
@@ -96,12 +106,27 @@ func (t *singleObjectTask) download() {
 	)
 	t.parent.ObjectsInc()
 	t.parent.BytesAdd(t.currentSize.Load())
+	t.notify("")
 }
 
-func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration) error {
-	workFQN := fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
+func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration) (err error) {
 	ctx, cancel := context.WithTimeout(t.downloadCtx, timeout)
 	defer cancel()
+	if residency := &lom.Bprops().Residency; residency.Enabled() {
+		ctx = context.WithValue(ctx, cmn.CtxResidency, residency)
+	}
+
+	var part *dlPart
+	if t.job.Resume() {
+		if part, err = openDlPart(lom, t.obj); err != nil {
+			return err
+		}
+		if part != nil {
+			defer part.close()
+		} else {
+			t.warnf("%s: being downloaded by another task - not resuming", t)
+		}
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.obj.link, nil)
 	if err != nil {
@@ -112,6 +137,22 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	// This should increase number of connections to GCS.
 	if cmn.IsGoogleStorageURL(req.URL) {
 		req.Header.Add("User-Agent", cmn.GcsUA)
+	} else if pol := t.job.politeness(); pol != nil {
+		req.Header.Set("User-Agent", pol.userAgent)
+	}
+	addHeaders(req, t.job.header())
+	if part != nil && part.size > 0 {
+		part.setRange(req)
+	} else if t.obj.length > 0 {
+		req.Header.Set(cmn.HeaderRange, fmt.Sprintf("bytes=%d-%d", t.obj.offset, t.obj.offset+t.obj.length-1))
+	}
+
+	if pol := t.job.politeness(); pol != nil {
+		release, err := pol.acquire(ctx, req.URL)
+		if err != nil {
+			return err
+		}
+		defer release()
 	}
 
 	resp, err := clientForURL(t.obj.link).Do(req)
@@ -121,60 +162,181 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	defer cmn.Close(resp.Body)
 
 	if resp.StatusCode >= http.StatusBadRequest {
+		if part != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			part.truncate() // start over
+		}
 		return fmt.Errorf("request failed with %d status code (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
+	var resumed int64
+	if part != nil {
+		if resumed, err = part.resume(resp); err != nil {
+			return err
+		}
+		if resumed > 0 {
+			t.resumed.Store(resumed)
+			t.currentSize.Store(resumed)
+			dlStore.addResumed(t.id(), resumed)
+			dlStore.log(t.id(), "resuming %q at %d", t.obj.objName, resumed)
+		}
+	}
+	if bck, objName, routed := t.job.Route(t.obj.objName, resp.Header.Get(cmn.HeaderContentType)); routed {
+		if lom, err = t.routedLOM(bck, objName); err != nil {
+			return err
+		}
+	}
 
 	var (
 		r   = t.wrapReader(ctx, resp.Body)
-		roi = roiFromLink(t.obj.link, resp)
+		roi remoteObjInfo
 	)
+	if t.obj.length > 0 {
+		if resp.StatusCode != http.StatusPartialContent || resumed+resp.ContentLength != t.obj.length {
+			return fmt.Errorf("range request failed: status %d, size %d (expected %d, size %d)",
+				resp.StatusCode, resumed+resp.ContentLength, http.StatusPartialContent, t.obj.length)
+		}
+		// source's checksums and version describe the entire object
+		roi.size, roi.md = t.obj.length, cmn.SimpleKVs{cluster.SourceObjMD: cluster.SourceWebObjMD}
+	} else {
+		roi = roiFromLink(t.obj.link, resp)
+		if resumed > 0 && roi.size > 0 {
+			roi.size += resumed
+		}
+	}
 
 	t.setTotalSize(roi.size)
 
 	lom.SetCustomMD(roi.md)
-	params := cluster.PutObjectParams{
-		Reader:       r,
-		WorkFQN:      workFQN,
-		RecvType:     cluster.ColdGet,
-		Started:      t.started.Load(),
-		WithFinalize: true,
+	// validate the entire object before it gets finalized: against the
+	// user-specified checksum or, if none, the checksum reported by the source
+	expected := t.obj.cksum
+	if expected == nil && t.obj.length == 0 {
+		expected = sourceCksum(roi)
+	}
+	if part != nil {
+		err = t.putPart(lom, part, r, expected)
+	} else {
+		if expected != nil {
+			r = newCksumReader(r, expected, lom.String())
+		}
+		err = t.put(lom, r)
 	}
-	err = t.parent.t.PutObject(lom, params)
 	if err != nil {
+		if part != nil && errors.Is(err, &cmn.BadCksumError{}) {
+			part.remove() // start over next time
+		}
 		return err
 	}
-	if err := lom.Load(); err != nil {
+	if part != nil {
+		part.remove()
+	}
+	if err = lom.Load(); err != nil {
 		return err
 	}
+	if _, ok := t.routed.Load().(*dlDst); ok {
+		return t.forwardRouted(lom)
+	}
 	return nil
 }
 
+// the routed object belongs to (HRW) another target - send it there
+func (t *singleObjectTask) put(lom *cluster.LOM, r io.ReadCloser) error {
+	return t.parent.t.PutObject(lom, cluster.PutObjectParams{
+		Reader:       r,
+		WorkFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		RecvType:     cluster.ColdGet,
+		Started:      t.started.Load(),
+		WithFinalize: true,
+	})
+}
+
+// putPart downloads the rest of the object into the (persistent) partial
+// workfile, validates the entire object, and promotes the workfile in place -
+// or, if the object is routed elsewhere (see routedLOM), copies it
+func (t *singleObjectTask) putPart(lom *cluster.LOM, part *dlPart, r io.Reader, expected *cmn.Cksum) error {
+	if err := part.download(r); err != nil {
+		return err
+	}
+	var cksum *cmn.Cksum
+	if expected != nil {
+		if err := part.validate(expected, lom.String()); err != nil {
+			return err
+		}
+		if expected.Type() == lom.CksumConf().Type {
+			cksum = expected // no need to compute it again
+		}
+	}
+	if _, routed := t.routed.Load().(*dlDst); routed || part.mpath != lom.ParsedFQN.MpathInfo {
+		pr, err := part.reader()
+		if err != nil {
+			return err
+		}
+		return t.put(lom, pr)
+	}
+	_, err := t.parent.t.PromoteFile(cluster.PromoteFileParams{
+		SrcFQN:    part.fqn,
+		Bck:       lom.Bck(),
+		ObjName:   lom.ObjName,
+		Cksum:     cksum,
+		CustomMD:  lom.CustomMD(),
+		Overwrite: true,
+	})
+	return err
+}
+
+func (t *singleObjectTask) forwardRouted(lom *cluster.LOM) (err error) {
+	tsi, err := cluster.HrwTarget(lom.Uname(), t.parent.t.Sowner().Get())
+	if err != nil || tsi.ID() == t.parent.t.Snode().ID() {
+		return
+	}
+	if _, _, err = t.parent.t.CopyObject(lom, cluster.CopyObjectParams{BckTo: lom.Bck()}, false); err != nil {
+		return
+	}
+	lom.Lock(true)
+	err = lom.Remove()
+	lom.Unlock(true)
+	return
+}
+
+func (t *singleObjectTask) routedLOM(bck cmn.Bck, objName string) (*cluster.LOM, error) {
+	lom := &cluster.LOM{T: t.parent.t, ObjName: objName}
+	if err := lom.Init(bck); err != nil {
+		return nil, err
+	}
+	lom.SetAtimeUnix(t.started.Load().UnixNano())
+	t.routed.Store(&dlDst{bck: lom.Bck().Bck, objName: objName})
+	t.existed.Store(lomExists(t.parent.t, lom))
+	return lom, nil
+}
+
 func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 	var (
 		httpErr = &cmn.HTTPError{}
 		timeout = t.initialTimeout()
 	)
 	for i := 0; i < retryCnt; i++ {
+		t.attempts.Inc()
 		err = t.tryDownloadLocal(lom, timeout)
 		if err == nil {
 			return nil
 		} else if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
 			// Download was canceled or stopped, so just return.
 			return err
+		} else if errors.Is(err, errDisallowedByRobots) || errors.Is(err, &cmn.BadCksumError{}) {
+			return err
 		} else if errors.Is(err, context.DeadlineExceeded) {
-			glog.Warningf("%s [retries: %d/%d]: context exceeded with timeout (%v), increasing and retrying...", t, i, retryCnt, timeout)
+			t.warnf("%s [retries: %d/%d]: context exceeded with timeout (%v), increasing and retrying...", t, i, retryCnt, timeout)
 			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
 		} else if errors.As(err, &httpErr) {
-			glog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", t, i, retryCnt, err, httpErr.Status)
+			t.warnf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", t, i, retryCnt, err, httpErr.Status)
 			if _, exists := terminalStatuses[httpErr.Status]; exists {
 				// Nothing we can do...
 				return err
 			}
 			// Otherwise retry...
 		} else if cmn.IsErrConnectionReset(err) || cmn.IsErrConnectionRefused(err) {
-			glog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", t, i, retryCnt, err)
+			t.warnf("%s [retries: %d/%d]: connection failed with (%v), retrying...", t, i, retryCnt, err)
 		} else {
-			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", t, i, retryCnt, err)
+			t.warnf("%s [retries: %d/%d]: unexpected error (%v), retrying...", t, i, retryCnt, err)
 		}
 
 		t.reset()
@@ -205,6 +367,7 @@ func (t *singleObjectTask) setTotalSize(size int64) {
 func (t *singleObjectTask) reset() {
 	t.totalSize.Store(0)
 	t.currentSize.Store(0)
+	t.resumed.Store(0)
 }
 
 func (t *singleObjectTask) downloadCloud(lom *cluster.LOM) error {
@@ -216,6 +379,7 @@ func (t *singleObjectTask) downloadCloud(lom *cluster.LOM) error {
 	ctx = context.WithValue(ctx, cmn.CtxSetSize, cmn.SetSizeFunc(t.setTotalSize))
 
 	// Do final GET (prefetch) request.
+	t.attempts.Inc()
 	err, _ := t.parent.t.GetCold(ctx, lom, true /*prefetch*/)
 	return err
 }
@@ -241,11 +405,47 @@ func (t *singleObjectTask) markFailed(statusMsg string) {
 	t.parent.statsT.Add(stats.ErrDownloadCount, 1)
 
 	dlStore.persistError(t.id(), t.obj.objName, statusMsg)
+	dlStore.setObjFailed(t.id(), t.obj.objName, statusMsg)
 	dlStore.incErrorCnt(t.id())
+	dlStore.log(t.id(), "failed to download %q: %s", t.obj.objName, statusMsg)
+	t.notify(statusMsg)
+}
+
+// posts the object's completion webhook, if requested (see DlBase.NotifyURL)
+func (t *singleObjectTask) notify(errMsg string) {
+	u := t.job.NotifyURL()
+	if u == "" {
+		return
+	}
+	msg := &DlWebhookMsg{
+		Event:   DlWebhookObject,
+		JobID:   t.id(),
+		Time:    time.Now(),
+		ObjName: t.obj.objName,
+		Error:   errMsg,
+		Target:  t.parent.t.Snode().ID(),
+	}
+	bck := t.job.Bck()
+	if dst, ok := t.routed.Load().(*dlDst); ok {
+		bck, msg.ObjName = dst.bck, dst.objName
+	}
+	msg.Bck = &bck
+	if errMsg == "" {
+		msg.Size = t.currentSize.Load()
+	}
+	whs.post(u, msg)
+}
+
+// logs the warning both locally and in the job's log
+func (t *singleObjectTask) warnf(format string, a ...interface{}) {
+	glog.WarningDepth(1, fmt.Sprintf(format, a...))
+	dlStore.log(t.id(), format, a...)
 }
 
 func (t *singleObjectTask) persist() {
-	_ = dlStore.persistTaskInfo(t.id(), t.ToTaskDlInfo())
+	info := t.ToTaskDlInfo()
+	_ = dlStore.persistTaskInfo(t.id(), info)
+	dlStore.setObjDone(t.id(), info)
 }
 
 func (t *singleObjectTask) id() string { return t.job.ID() }
@@ -255,21 +455,28 @@ func (t *singleObjectTask) uid() string {
 
 func (t *singleObjectTask) ToTaskDlInfo() TaskDlInfo {
 	ended := t.ended.Load()
-	return TaskDlInfo{
+	info := TaskDlInfo{
 		Name:       t.obj.objName,
 		Downloaded: t.currentSize.Load(),
 		Total:      t.totalSize.Load(),
+		Resumed:    t.resumed.Load(),
 
 		StartTime: t.started.Load(),
 		EndTime:   ended,
 
-		Running: ended.IsZero(),
+		Running:  ended.IsZero(),
+		Attempts: int(t.attempts.Load()),
+		Created:  !t.existed.Load(),
+	}
+	if dst, ok := t.routed.Load().(*dlDst); ok {
+		info.RoutedBck, info.RoutedName = &dst.bck, dst.objName
 	}
+	return info
 }
 
 func (t *singleObjectTask) String() (str string) {
 	return fmt.Sprintf(
 		"{id: %q, obj_name: %q, link: %q, from_cloud: %v, bucket: %q}",
-		t.id(), t.obj.objName, t.obj.link, t.obj.fromCloud, t.job.Bck(),
+		t.id(), t.obj.objName, redactLink(t.obj.link), t.obj.fromCloud, t.job.Bck(),
 	)
 }
@@ -8,9 +8,24 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math"
+	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Limits can be updated while the job is running (see `update`). The
+// connection limit applies immediately; throughput limit - once the current
+// minute's allowance is used up.
+
+const (
+	unlimitedConns = math.MaxInt32
+
+	// throughput is averaged over at least this long (see `throughput`)
+	minRateWindow = 10 * time.Second
 )
 
 var errThrottlerStopped = errors.New("throttler has been stopped")
@@ -19,15 +34,23 @@ type (
 	throttler struct {
 		sema *cmn.DynSemaphore
 
-		maxBytesPerMinute int
+		maxBytesPerMinute atomic.Int64 // 0 - unlimited
 		capacityCh        chan int
 		giveBackCh        chan int
 		ticker            *time.Ticker
 		stopCh            *cmn.StopCh
+		initOnce          sync.Once // throughput throttling is initialized on demand
+
+		downloaded atomic.Int64 // bytes read so far
+		rateMu     sync.Mutex
+		rateTime   int64 // mono time of the last sample
+		rateBytes  int64 // `downloaded` at the time of the last sample
+		rate       int64 // bytes per second
 	}
 
 	throughputThrottler interface {
 		acquireAllowance(ctx context.Context, n int) error
+		countBytes(n int)
 	}
 
 	throttledReader struct {
@@ -38,28 +61,59 @@ type (
 )
 
 func newThrottler(limits DlLimits) *throttler {
-	t := &throttler{}
+	t := &throttler{
+		sema:     cmn.NewDynSemaphore(unlimitedConns),
+		stopCh:   cmn.NewStopCh(),
+		rateTime: mono.NanoTime(),
+	}
+	t.update(limits)
+	return t
+}
+
+func (t *throttler) update(limits DlLimits) {
 	if limits.Connections > 0 {
-		t.sema = cmn.NewDynSemaphore(limits.Connections)
+		t.sema.SetSize(limits.Connections)
+	} else {
+		t.sema.SetSize(unlimitedConns)
 	}
-	if limits.BytesPerHour > 0 {
-		t.initThroughputThrottling(limits.BytesPerHour / 60)
+	maxBytesPerMinute := limits.BytesPerHour / 60
+	if maxBytesPerMinute > 0 {
+		t.initOnce.Do(func() { t.initThroughputThrottling(maxBytesPerMinute) })
+		if t.capacityCh == nil { // stopped
+			return
+		}
 	}
-	return t
+	// NOTE: must be stored after the initialization (see `acquireAllowance`)
+	t.maxBytesPerMinute.Store(int64(maxBytesPerMinute))
+}
+
+func (t *throttler) limit() int { return int(t.maxBytesPerMinute.Load()) }
+
+// throughput returns the download rate (bytes per second) since the previous
+// sample - i.e., since the previous call but over at least `minRateWindow`.
+func (t *throttler) throughput() int64 {
+	t.rateMu.Lock()
+	defer t.rateMu.Unlock()
+	elapsed := mono.Since(t.rateTime)
+	if elapsed < minRateWindow {
+		return t.rate
+	}
+	downloaded := t.downloaded.Load()
+	t.rate = (downloaded - t.rateBytes) * int64(time.Second) / int64(elapsed)
+	t.rateTime, t.rateBytes = mono.NanoTime(), downloaded
+	return t.rate
 }
 
 func (t *throttler) initThroughputThrottling(maxBytesPerMinute int) {
-	t.maxBytesPerMinute = maxBytesPerMinute
 	t.capacityCh = make(chan int, 1)
 	t.giveBackCh = make(chan int, 1)
 	t.ticker = time.NewTicker(time.Minute)
-	t.stopCh = cmn.NewStopCh()
 	go func() {
 		defer func() {
 			t.ticker.Stop()
 			close(t.capacityCh)
 		}()
-		t.capacityCh <- t.maxBytesPerMinute
+		t.capacityCh <- maxBytesPerMinute
 
 		// LOOP-INVARIANT: `t.capacityCh` has 1 element and `t.giveBackCh` has 0 elements.
 		// LOOP-INVARIANT: `t.capacityCh` and `t.giveBackCh` can't have size > 0 at the same time.
@@ -79,12 +133,12 @@ func (t *throttler) initThroughputThrottling(maxBytesPerMinute int) {
 						break
 					// But if time has passed, put a big chunk.
 					case <-t.ticker.C:
-						t.capacityCh <- t.maxBytesPerMinute
+						t.capacityCh <- t.limit()
 					}
 				} else {
 					// Readers are faster than bandwidth, throttle here.
 					<-t.ticker.C
-					t.capacityCh <- t.maxBytesPerMinute
+					t.capacityCh <- t.limit()
 				}
 				// Regardless of chosen if-branch we put 1 element to `t.capacityCh`.
 			}
@@ -92,24 +146,11 @@ func (t *throttler) initThroughputThrottling(maxBytesPerMinute int) {
 	}()
 }
 
-func (t *throttler) acquire() {
-	if t.sema == nil {
-		return
-	}
-	t.sema.Acquire()
-}
-
-func (t *throttler) release() {
-	if t.sema == nil {
-		return
-	}
-	t.sema.Release()
-}
+func (t *throttler) acquire() { t.sema.Acquire() }
+func (t *throttler) release() { t.sema.Release() }
 
+// NOTE: readers are wrapped regardless of the current limit - it may be set later
 func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
-	if t.maxBytesPerMinute == 0 {
-		return r
-	}
 	return &throttledReader{
 		t:   t,
 		ctx: ctx,
@@ -118,12 +159,11 @@ func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadClos
 }
 
 func (t *throttler) stop() {
+	t.initOnce.Do(func() {}) // no throughput throttling from now on
 	if t.ticker != nil {
 		t.ticker.Stop()
 	}
-	if t.stopCh != nil {
-		t.stopCh.Close()
-	}
+	t.stopCh.Close()
 }
 
 func (t *throttler) giveBack(leftoverSize int) {
@@ -132,7 +172,12 @@ func (t *throttler) giveBack(leftoverSize int) {
 	t.giveBackCh <- leftoverSize
 }
 
+func (t *throttler) countBytes(n int) { t.downloaded.Add(int64(n)) }
+
 func (t *throttler) acquireAllowance(ctx context.Context, n int) error {
+	if t.limit() == 0 {
+		return nil
+	}
 	select {
 	case size, ok := <-t.capacityCh:
 		if !ok {
@@ -149,7 +194,9 @@ func (tr *throttledReader) Read(p []byte) (n int, err error) {
 	if err := tr.t.acquireAllowance(tr.ctx, len(p)); err != nil {
 		return 0, err
 	}
-	return tr.r.Read(p)
+	n, err = tr.r.Read(p)
+	tr.t.countBytes(n)
+	return
 }
 
 func (tr *throttledReader) Close() (err error) {
@@ -0,0 +1,52 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestThrottlerUpdate(t *testing.T) {
+	thr := newThrottler(DlLimits{Connections: 1})
+	defer thr.stop()
+
+	// second connection must wait until the limit gets raised
+	thr.acquire()
+	acquired := make(chan struct{})
+	go func() {
+		thr.acquire()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("connection limit exceeded")
+	case <-time.After(100 * time.Millisecond):
+	}
+	thr.update(DlLimits{Connections: 2})
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("raised connection limit not applied")
+	}
+	thr.release()
+	thr.release()
+
+	// throughput: the first minute's allowance is the new limit, the rest must wait
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	tassert.CheckFatal(t, thr.acquireAllowance(ctx, 1<<20)) // unlimited
+	thr.update(DlLimits{BytesPerHour: 60 * 1024})
+	tassert.CheckFatal(t, thr.acquireAllowance(ctx, 1024))
+	err := thr.acquireAllowance(ctx, 1)
+	tassert.Errorf(t, err == context.Canceled, "expected throttling, got %v", err)
+
+	// removing the limit applies immediately
+	thr.update(DlLimits{})
+	tassert.CheckFatal(t, thr.acquireAllowance(context.Background(), 1<<20))
+}
@@ -5,10 +5,15 @@
 package downloader
 
 import (
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -87,6 +92,83 @@ func TestCompareObject(t *testing.T) {
 	tassert.Errorf(t, equal, "expected the objects to be equal")
 }
 
+func TestCompareWebObject(t *testing.T) {
+	var (
+		content     = "0123456789"
+		etag        = `"v1"`
+		modTime     = time.Now().Add(-time.Hour)
+		notModified int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set(cmn.HeaderETag, etag)
+		http.ServeContent(rec, r, "", modTime, strings.NewReader(content))
+		if rec.Code == http.StatusNotModified {
+			notModified++
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+	}))
+	defer srv.Close()
+
+	// the source's validators are recorded at download time
+	resp, err := headLink(srv.URL, nil, nil)
+	tassert.CheckFatal(t, err)
+	roi := roiFromLink(srv.URL, resp)
+	tassert.Fatalf(t, roi.md[cluster.ETagObjMD] == etag, "expected ETag %s, got %v", etag, roi.md)
+	_, ok := roi.md[cluster.LastModifiedObjMD]
+	tassert.Fatalf(t, ok, "expected Last-Modified, got %v", roi.md)
+
+	src := &cluster.LOM{T: cluster.NewTargetMock(nil)}
+	src.SetSize(roi.size)
+	src.SetCustomMD(roi.md)
+	dst := &DstElement{Link: srv.URL}
+
+	equal, err := compareObjects(src, dst)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, equal, "expected the objects to be equal")
+	tassert.Errorf(t, notModified == 1, "expected conditional request (304), got %d", notModified)
+
+	// the same size, different content
+	content, etag = "9876543210", `"v2"`
+	equal, err = compareObjects(src, dst)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !equal, "expected the objects not to be equal")
+}
+
+func TestHeadLinkAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); ok && user == "user" && pass == "secret" {
+			return
+		}
+		if r.Header.Get("X-Api-Key") == "key" {
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	resp, err := headLink(srv.URL, nil, nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resp.StatusCode == http.StatusUnauthorized, "expected 401, got %d", resp.StatusCode)
+
+	resp, err = headLink(srv.URL, newHeader(map[string]string{"x-api-key": "key"}), nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resp.StatusCode == http.StatusOK, "headers: expected 200, got %d", resp.StatusCode)
+
+	link := strings.Replace(srv.URL, "http://", "http://user:secret@", 1)
+	resp, err = headLink(link, nil, nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resp.StatusCode == http.StatusOK, "basic auth: expected 200, got %d", resp.StatusCode)
+
+	redacted := redactLink(link)
+	tassert.Errorf(t, !strings.Contains(redacted, "secret") && strings.Contains(redacted, "user"),
+		"expected password to be redacted: %q", redacted)
+	tassert.Errorf(t, redactLink(srv.URL) == srv.URL, "expected %q unchanged, got %q", srv.URL, redactLink(srv.URL))
+}
+
 func downloadObject(link string) (string, error) {
 	resp, err := http.Get(link)
 	if err != nil {
@@ -103,3 +185,93 @@ func downloadObject(link string) (string, error) {
 	_, err = io.Copy(f, resp.Body)
 	return f.Name(), err
 }
+
+func TestParseInventoryCSV(t *testing.T) {
+	all := func(string) bool { return true }
+
+	// S3: no header, `Key` column URL-encoded.
+	s3CSV := "\"bck\",\"a%2Fb.tar\",\"10\"\n\"bck\",\"c+d.tar\",\"20\"\n"
+	names, err := parseInventoryCSV(strings.NewReader(s3CSV), DlInventoryS3, 1, false, nil, all)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(names) == 2, "expected 2 names, got %v", names)
+	tassert.Errorf(t, names[0] == "a/b.tar" && names[1] == "c d.tar", "unexpected names: %v", names)
+
+	// GCS: header with `name` column, filter applied.
+	gcsCSV := "bucket,name,size\nbck,x.tgz,1\nbck,y.tar,2\n"
+	names, err = parseInventoryCSV(strings.NewReader(gcsCSV), DlInventoryGCS, 0, true, nil,
+		func(name string) bool { return strings.HasSuffix(name, ".tgz") })
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(names) == 1 && names[0] == "x.tgz", "unexpected names: %v", names)
+
+	_, err = parseInventoryCSV(strings.NewReader("bucket,key\nbck,x\n"), DlInventoryGCS, 0, true, nil, all)
+	tassert.Errorf(t, err != nil, "expected error on missing 'name' column")
+}
+
+func TestModifiedSince(t *testing.T) {
+	f, err := ioutil.TempFile("", "dl-modified")
+	tassert.CheckFatal(t, err)
+	defer os.Remove(f.Name())
+	tassert.CheckFatal(t, f.Close())
+
+	var (
+		lom   = &cluster.LOM{FQN: f.Name()}
+		mtime = time.Now().Add(-time.Hour)
+	)
+	tassert.CheckFatal(t, os.Chtimes(f.Name(), mtime, mtime))
+	tassert.Errorf(t, !modifiedSince(lom, time.Time{}), "expected no snapshot to keep nothing")
+	tassert.Errorf(t, modifiedSince(lom, mtime.Add(-time.Minute)), "expected object modified after the snapshot")
+	tassert.Errorf(t, !modifiedSince(lom, mtime.Add(time.Minute)), "expected object modified before the snapshot")
+	tassert.Errorf(t, !modifiedSince(&cluster.LOM{FQN: f.Name() + ".gone"}, mtime), "expected missing object")
+}
+
+func TestCksumReader(t *testing.T) {
+	const md5Hello = "5d41402abc4b2a76b9719d911017c592"
+	r := newCksumReader(ioutil.NopCloser(strings.NewReader("hello")), cmn.NewCksum(cmn.ChecksumMD5, md5Hello), "obj")
+	_, err := ioutil.ReadAll(r)
+	tassert.CheckFatal(t, err)
+
+	r = newCksumReader(ioutil.NopCloser(strings.NewReader("hellO")), cmn.NewCksum(cmn.ChecksumMD5, md5Hello), "obj")
+	_, err = ioutil.ReadAll(r)
+	tassert.Fatalf(t, errors.Is(err, &cmn.BadCksumError{}), "expected checksum error, got: %v", err)
+}
+
+func TestSourceCksum(t *testing.T) {
+	const md5Hello = "5d41402abc4b2a76b9719d911017c592"
+	tests := []struct {
+		link   string
+		header http.Header
+		cksum  *cmn.Cksum
+	}{
+		{
+			link:   "https://storage.googleapis.com/bck/obj",
+			header: http.Header{"X-Goog-Hash": []string{"crc32c=mnG7TA==", "md5=XUFAKrxLKna5cZ2REBfFkg=="}},
+			cksum:  cmn.NewCksum(cmn.ChecksumMD5, md5Hello),
+		},
+		{
+			link:   "https://storage.googleapis.com/bck/obj",
+			header: http.Header{"X-Goog-Hash": []string{"crc32c=mnG7TA=="}},
+			cksum:  cmn.NewCksum(cmn.ChecksumCRC32C, "9a71bb4c"),
+		},
+		{
+			link:   "https://bck.s3.amazonaws.com/obj",
+			header: http.Header{"Etag": []string{`"` + md5Hello + `"`}},
+			cksum:  cmn.NewCksum(cmn.ChecksumMD5, md5Hello),
+		},
+		{
+			link:   "https://bck.s3.amazonaws.com/obj",
+			header: http.Header{"Etag": []string{`"` + md5Hello + `-2"`}}, // multipart
+		},
+		{
+			link:   "https://example.com/obj",
+			header: http.Header{"Etag": []string{`"` + md5Hello + `"`}},
+		},
+	}
+	for _, test := range tests {
+		cksum := sourceCksum(roiFromLink(test.link, &http.Response{Header: test.header}))
+		if test.cksum == nil {
+			tassert.Errorf(t, cksum == nil, "%s: expected no checksum, got %s", test.link, cksum)
+		} else {
+			tassert.Errorf(t, test.cksum.Equal(cksum), "%s: expected %s, got %s", test.link, test.cksum, cksum)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Completion webhooks (see DlBase.NotifyURL): the targets POST DlWebhookMsg
+// (event "object") upon finishing each object - successfully or with an error,
+// and one of the proxies (event "job") upon finishing (or aborting) the entire job.
+//
+// Delivery is best-effort: each message is retried a few times and then
+// dropped (and logged in the job's log). Object events are queued per job and
+// sent in the background, in order, by the job's own worker - a slow or
+// unreachable receiver never delays downloading (or the other jobs' events):
+// when the job's queue is full, its new events are dropped and counted (see
+// the job's log).
+
+const (
+	DlWebhookObject = "object"
+	DlWebhookJob    = "job"
+)
+
+const (
+	webhookQueueSize  = 256 // per job
+	webhookTimeout    = 10 * time.Second
+	webhookRetries    = 3
+	webhookRetryDelay = time.Second
+)
+
+type (
+	// DlWebhookMsg is the JSON payload POSTed to DlBase.NotifyURL.
+	DlWebhookMsg struct {
+		Event string    `json:"event"` // DlWebhookObject | DlWebhookJob
+		JobID string    `json:"job_id"`
+		Time  time.Time `json:"time"`
+		// object event: the object's destination (see DlRoute), its size, and
+		// the error, if any
+		Bck     *cmn.Bck `json:"bucket,omitempty"`
+		ObjName string   `json:"object_name,omitempty"`
+		Size    int64    `json:"size,string,omitempty"`
+		Error   string   `json:"error,omitempty"`
+		Target  string   `json:"target,omitempty"`
+		// job event: the job's summary aggregated across all targets
+		Job *DlJobInfo `json:"job,omitempty"`
+	}
+
+	// pending object events of a given job
+	webhookQueue struct {
+		jobID   string
+		url     string
+		ch      chan *DlWebhookMsg
+		dropped int64 // protected by webhooks.mtx
+	}
+
+	webhooks struct {
+		mtx    sync.Mutex
+		queues map[string]*webhookQueue // by job ID
+	}
+)
+
+var (
+	whs           webhooks
+	webhookClient = cmn.NewClient(cmn.TransportArgs{Timeout: webhookTimeout})
+)
+
+func validateNotifyURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("invalid 'notify_url' %q: %v", u, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid 'notify_url' %q: expecting http(s)://host[:port]/path", u)
+	}
+	return nil
+}
+
+// queue an object event; never blocks - drops the event if the job's queue is full
+func (wh *webhooks) post(u string, msg *DlWebhookMsg) {
+	wh.mtx.Lock()
+	q, ok := wh.queues[msg.JobID]
+	if !ok {
+		if wh.queues == nil {
+			wh.queues = make(map[string]*webhookQueue)
+		}
+		q = &webhookQueue{jobID: msg.JobID, url: u, ch: make(chan *DlWebhookMsg, webhookQueueSize)}
+		wh.queues[msg.JobID] = q
+		go wh.work(q)
+	}
+	select {
+	case q.ch <- msg:
+	default:
+		if q.dropped == 0 {
+			glog.Warningf("%s: webhook queue is full, dropping object events", msg.JobID)
+		}
+		q.dropped++
+	}
+	wh.mtx.Unlock()
+}
+
+// sends the job's events until the queue is empty, and then goes away
+func (wh *webhooks) work(q *webhookQueue) {
+	for {
+		select {
+		case msg := <-q.ch:
+			if err := PostWebhook(q.url, msg); err != nil {
+				glog.Warning(err)
+				dlStore.log(q.jobID, "%v", err)
+			}
+		default:
+			wh.mtx.Lock()
+			if len(q.ch) == 0 && q.dropped == 0 {
+				delete(wh.queues, q.jobID)
+				wh.mtx.Unlock()
+				return
+			}
+			dropped := q.dropped
+			q.dropped = 0
+			wh.mtx.Unlock()
+			if dropped > 0 {
+				dlStore.log(q.jobID, "dropped %d webhook object event(s): %s is falling behind",
+					dropped, redactLink(q.url))
+			}
+		}
+	}
+}
+
+// PostWebhook POSTs the message to a given URL retrying upon failure.
+func PostWebhook(u string, msg *DlWebhookMsg) (err error) {
+	body := cmn.MustMarshal(msg)
+	for i := 0; i < webhookRetries; i++ {
+		if i > 0 {
+			time.Sleep(webhookRetryDelay * time.Duration(i))
+		}
+		if err = postWebhook(u, body); err == nil {
+			return
+		}
+	}
+	return fmt.Errorf("failed to notify %s of %s %s: %v", redactLink(u), msg.Event, msg.JobID, err)
+}
+
+func postWebhook(u string, body []byte) error {
+	resp, err := webhookClient.Post(u, cmn.ContentJSON, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestValidateNotifyURL(t *testing.T) {
+	for _, u := range []string{"http://airflow:8080/hook", "https://example.com/dl?x=1"} {
+		if err := validateNotifyURL(u); err != nil {
+			t.Errorf("%q: unexpected error: %v", u, err)
+		}
+	}
+	for _, u := range []string{"airflow:8080/hook", "ftp://example.com", "http:///path", "://"} {
+		if err := validateNotifyURL(u); err == nil {
+			t.Errorf("%q: expected error", u)
+		}
+	}
+}
+
+func TestPostWebhook(t *testing.T) {
+	var (
+		calls int
+		got   DlWebhookMsg
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 { // fail the first attempt
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if err := jsoniter.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	bck := cmn.Bck{Name: "dataset", Provider: cmn.ProviderAIS}
+	msg := &DlWebhookMsg{
+		Event:   DlWebhookObject,
+		JobID:   "job-1",
+		Time:    time.Now(),
+		Bck:     &bck,
+		ObjName: "obj-1",
+		Size:    1024,
+	}
+	if err := PostWebhook(srv.URL, msg); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+	if got.Event != msg.Event || got.JobID != msg.JobID || got.ObjName != msg.ObjName || got.Size != msg.Size ||
+		got.Bck == nil || !got.Bck.Equal(bck) {
+		t.Errorf("expected %+v, got %+v", msg, got)
+	}
+}
+
+func TestNotifJobInfo(t *testing.T) {
+	smap := &cluster.Smap{Tmap: cluster.NodeMap{"t1": &cluster.Snode{DaemonID: "t1"}, "t2": &cluster.Snode{DaemonID: "t2"}}}
+	nd := NewDownloadNL("job-1", smap, smap.Tmap, string(DlTypeRange), DownloadProgressInterval)
+	nd.SetStats("t1", &DlStatusResp{DlJobInfo: DlJobInfo{FinishedCnt: 3, ErrorCnt: 1}})
+	nd.SetStats("t2", &DlStatusResp{DlJobInfo: DlJobInfo{FinishedCnt: 2}})
+	info := nd.JobInfo()
+	if info.ID != "job-1" || info.FinishedCnt != 5 || info.ErrorCnt != 1 {
+		t.Errorf("unexpected job info %+v", info)
+	}
+}
+
+func TestWebhookQueuePerJob(t *testing.T) {
+	saved := dlStore
+	defer func() { dlStore = saved }()
+	dlStore = &infoStore{
+		downloaderDB: newDownloadDB(dbdriver.NewDBMock()),
+		jobInfo:      make(map[string]*downloadJobInfo),
+	}
+
+	var (
+		release = make(chan struct{})
+		fast    = make(chan struct{}, 10)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := &DlWebhookMsg{}
+		if err := jsoniter.NewDecoder(r.Body).Decode(msg); err != nil {
+			t.Error(err)
+		}
+		if msg.JobID == "slow" {
+			<-release
+			return
+		}
+		fast <- struct{}{}
+	}))
+	defer srv.Close()
+
+	// the receiver never responds to the "slow" job: posting must not block
+	wh := &webhooks{}
+	started := time.Now()
+	for i := 0; i < webhookQueueSize+10; i++ {
+		wh.post(srv.URL, &DlWebhookMsg{Event: DlWebhookObject, JobID: "slow"})
+	}
+	if d := time.Since(started); d > time.Second {
+		t.Fatalf("posting took %v", d)
+	}
+	wh.mtx.Lock()
+	dropped := wh.queues["slow"].dropped
+	wh.mtx.Unlock()
+	if dropped < 9 || dropped > 10 {
+		t.Errorf("expected 9 or 10 dropped events, got %d", dropped)
+	}
+
+	// ...and must not delay the other jobs' events
+	for i := 0; i < cap(fast); i++ {
+		wh.post(srv.URL, &DlWebhookMsg{Event: DlWebhookObject, JobID: "fast"})
+	}
+	for i := 0; i < cap(fast); i++ {
+		select {
+		case <-fast:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d out of %d events", i, cap(fast))
+		}
+	}
+
+	close(release)
+	for started = time.Now(); time.Since(started) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		wh.mtx.Lock()
+		n := len(wh.queues)
+		wh.mtx.Unlock()
+		if n == 0 {
+			return
+		}
+	}
+	t.Error("idle queues were not removed")
+}
@@ -200,7 +200,11 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 		}
 
 		expectedUncompressedSize := uint64(float64(lom.Size()) / m.avgCompressionRatio())
-		toDisk := m.dsorter.preShardExtraction(expectedUncompressedSize)
+		// In plan mode only the headers are read so there is nothing to reserve.
+		var toDisk bool
+		if !m.rs.PlanOnly {
+			toDisk = m.dsorter.preShardExtraction(expectedUncompressedSize)
+		}
 
 		beforeExtraction := mono.NanoTime()
 		reader := io.NewSectionReader(f, 0, lom.Size())
@@ -215,7 +219,9 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 		phaseInfo.adjuster.releaseSema(lom.ParsedFQN.MpathInfo)
 		lom.Unlock(false)
 
-		m.dsorter.postShardExtraction(expectedUncompressedSize) // schedule unreserving reserved memory on next memory update
+		if !m.rs.PlanOnly {
+			m.dsorter.postShardExtraction(expectedUncompressedSize) // schedule unreserving reserved memory on next memory update
+		}
 		if err != nil {
 			cmn.Close(f)
 			return errors.Errorf("error in ExtractShard, file: %s, err: %v", f.Name(), err)
@@ -403,8 +409,22 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	// If the newly created shard belongs on a different target
 	// according to HRW, send it there. Since it doesn't really matter
 	// if we have an extra copy of the object local to this target, we
-	// optimize for performance by not removing the object now.
+	// optimize for performance by not removing the object now - unless
+	// the shard was deliberately placed here by the output placement
+	// policy (in which case the copy would only take space).
 	if si.DaemonID != m.ctx.node.DaemonID && !m.rs.DryRun {
+		if m.rs.OutputPlacement != OutputPlacementHRW || len(m.rs.ExcludeTargets) > 0 {
+			defer func() {
+				if err != nil {
+					return
+				}
+				lom.Lock(true)
+				if errRm := lom.Remove(); errRm != nil {
+					glog.Errorf("%s: failed to remove local copy of %s, err: %v", m.ctx.t.Snode(), lom, errRm)
+				}
+				lom.Unlock(true)
+			}()
+		}
 		lom.Lock(false)
 		defer lom.Unlock(false)
 
@@ -739,18 +759,8 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shar
 // distributeShardRecords creates Shard structs in the order of
 // dsortManager.Records corresponding to a maximum size maxSize. Each Shard is
 // sent in an HTTP request to the appropriate target to create the actual file
-// itself. The strategy used to determine the appropriate target differs
-// depending on whether compression is used.
-//
-// 1) By HRW (not using compression)
-// 2) By locality (using compression),
-//  using two maps:
-//      i) shardsToTarget - tracks the total number of shards creation requests sent to each target URL
-//      ii) numLocalRecords - tracks the number of records in the current shardMeta each target has locally
-//
-//      The appropriate target is determined firstly by locality (i.e. the target with the most local records)
-//      and secondly (if there is a tie), by least load (i.e. the target with the least number of shard creation requests
-//      sent to it already).
+// itself. The appropriate target is determined by the output placement policy
+// of the request (see shardPlacer).
 func (m *Manager) distributeShardRecords(maxSize int64) error {
 	var (
 		shards []*extract.Shard
@@ -780,34 +790,21 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 		return err
 	}
 
-	// TODO: Following heuristic doesn't seem to be working correctly in
-	// all cases. When there is not much shards at each disk (like 1-5)
-	// then it may happen that some target will have more shards than other
-	// targets and will "win" all output shards what will result in enormous
-	// skew and result in slow creation phase (single target will be
-	// responsible for creating all shards).
-	//
-	// if m.extractCreator.UsingCompression() {
-	// 	daemonID := nodeForShardRequest(shardsToTarget, numLocalRecords)
-	// 	baseURL = m.smap.GetTarget(daemonID).URL(cmn.NetworkIntraData)
-	// } else {
-	// 	// If output shards are not compressed, there will always be less
-	// 	// data sent over the network if the shard is constructed on the
-	// 	// correct HRW target as opposed to constructing it on the target
-	// 	// with optimal file content locality and then sent to the correct
-	// 	// target.
-	// }
-
 	bck := cluster.NewBck(m.rs.OutputBucket, m.rs.OutputProvider, cmn.NsGlobal)
 	if err := bck.Init(m.ctx.bmdOwner, m.ctx.t.Snode()); err != nil {
 		return err
 	}
 
+	placer, err := newShardPlacer(m.rs, m.smap)
+	if err != nil {
+		return err
+	}
 	for _, s := range shards {
-		si, err := cluster.HrwTarget(bck.MakeUname(s.Name), m.smap)
+		si, err := placer.place(s, bck.MakeUname(s.Name))
 		if err != nil {
 			return err
 		}
+		s.DaemonID = si.ID()
 		shardsToTarget[si] = append(shardsToTarget[si], s)
 
 		if m.dsorter.name() == DSorterMemType {
@@ -816,8 +813,9 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 				shard, ok := singleSendOrder[record.DaemonID]
 				if !ok {
 					shard = &extract.Shard{
-						Name:    s.Name,
-						Records: extract.NewRecords(100),
+						Name:     s.Name,
+						Records:  extract.NewRecords(100),
+						DaemonID: s.DaemonID,
 					}
 					singleSendOrder[record.DaemonID] = shard
 				}
@@ -830,6 +828,18 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 		}
 	}
 
+	if m.rs.PlanOnly {
+		m.setPlan(m.newPlan(shards, shardsToTarget))
+		// nothing to create - the (empty) metadata still gets sent to
+		// release the targets that are waiting for it
+		for si := range shardsToTarget {
+			shardsToTarget[si] = nil
+		}
+		for daemonID := range sendOrder {
+			sendOrder[daemonID] = nil
+		}
+	}
+
 	m.recManager.Records.Drain()
 
 	for si, s := range shardsToTarget {
@@ -885,35 +895,6 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 	return nil
 }
 
-// nodeForShardRequest returns the optimal daemon id for a shard
-// creation request. The target chosen is determined based on:
-//  1) Locality of shard source files, and in a tie situation,
-//  2) Number of shard creation requests previously sent to the target.
-//
-// nolint:deadcode,unused // has TODO to fix it
-func nodeForShardRequest(shardsToTarget map[string][]*extract.Shard, numLocalRecords map[string]int) string {
-	var max int
-	var id string
-	var numSentToCur int
-
-	for node, localRecordCount := range numLocalRecords {
-		if localRecordCount > max {
-			numSentToCur = len(shardsToTarget[node])
-			max = localRecordCount
-			id = node
-		} else if localRecordCount == max {
-			// If a shard has equal number of source files in multiple targets,
-			// send request to the target with the least requests sent to it so
-			// far.
-			if len(shardsToTarget[node]) < numSentToCur {
-				numSentToCur = len(shardsToTarget[node])
-				id = node
-			}
-		}
-	}
-	return id
-}
-
 // randomTargetOrder returns a cluster.Snode slice for targets in a pseudorandom order.
 func randomTargetOrder(salt uint64, tmap cluster.NodeMap) []*cluster.Snode {
 	targets := make(map[uint64]*cluster.Snode, len(tmap))
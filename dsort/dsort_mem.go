@@ -385,13 +385,12 @@ func (ds *dsorterMem) createShardsLocally() (err error) {
 					return func() error {
 						defer ds.creationPhase.adjuster.read.releaseGoroutineSema()
 
-						bck := cluster.NewBck(ds.m.rs.OutputBucket, ds.m.rs.OutputProvider, cmn.NsGlobal)
-						if err := bck.Init(ds.m.ctx.bmdOwner, ds.m.ctx.t.Snode()); err != nil {
-							return err
-						}
-						toNode, err := cluster.HrwTarget(bck.MakeUname(shard.Name), ds.m.ctx.smapOwner.Get())
-						if err != nil {
-							return err
+						// records are sent to the target that creates the shard
+						smap := ds.m.ctx.smapOwner.Get()
+						toNode := smap.GetTarget(shard.DaemonID)
+						if toNode == nil {
+							return fmt.Errorf("%s: target %s (creating shard %q) not present in the %s",
+								ds.m.ctx.node, shard.DaemonID, shard.Name, smap)
 						}
 
 						for _, rec := range shard.Records.All() {
@@ -75,6 +75,7 @@ type (
 		keyExtractor    KeyExtractor
 		contents        *sync.Map
 		extractionPaths *sync.Map // Keys correspond to all paths to record contents on disk.
+		headersOnly     bool      // only record headers are collected, contents are never stored
 
 		enqueued struct {
 			mu      sync.Mutex
@@ -102,6 +103,13 @@ func NewRecordManager(t cluster.Target, daemonID, bucket, provider, extension st
 	}
 }
 
+// SetHeadersOnly makes the manager collect only the headers (name, size and
+// location) of the records without storing their contents. Used by the
+// planning mode which never creates any output shard.
+func (rm *RecordManager) SetHeadersOnly() {
+	rm.headersOnly = true
+}
+
 func (rm *RecordManager) ExtractRecordWithBuffer(args extractRecordArgs) (size int64, err error) {
 	var (
 		storeType       string
@@ -131,7 +139,18 @@ func (rm *RecordManager) ExtractRecordWithBuffer(args extractRecordArgs) (size i
 	}
 
 	r, ske, needRead := rm.keyExtractor.PrepareExtractor(args.recordName, args.r, ext)
-	if args.extractMethod.Has(ExtractToMem) {
+	if rm.headersOnly {
+		mdSize, size = int64(len(args.metadata)), r.Size()
+		storeType = OffsetStoreType
+		contentPath, _ = rm.encodeRecordName(storeType, args.shardName, args.recordName)
+
+		// The content is read only when the key is computed from it.
+		if needRead {
+			if _, err := io.CopyBuffer(ioutil.Discard, r, args.buf); err != nil {
+				return 0, errors.WithStack(err)
+			}
+		}
+	} else if args.extractMethod.Has(ExtractToMem) {
 		mdSize = int64(len(args.metadata))
 		storeType = SGLStoreType
 		contentPath, fullContentPath = rm.encodeRecordName(storeType, args.shardName, args.recordName)
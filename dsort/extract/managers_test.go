@@ -0,0 +1,55 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"errors"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// unreadableReader fails whenever its content is read.
+type unreadableReader struct{ size int64 }
+
+func (r *unreadableReader) Read([]byte) (int, error) {
+	return 0, errors.New("content must not be read")
+}
+func (r *unreadableReader) Size() int64 { return r.size }
+
+var _ = Describe("RecordManager", func() {
+	Context("headers only", func() {
+		It("should collect record headers without reading the content", func() {
+			ke, err := NewNameKeyExtractor()
+			Expect(err).NotTo(HaveOccurred())
+			rm := NewRecordManager(nil, "target", "bck", cmn.ProviderAIS, cmn.ExtTar,
+				NewTarExtractCreator(nil), ke, nil)
+			rm.SetHeadersOnly()
+
+			size, err := rm.ExtractRecordWithBuffer(extractRecordArgs{
+				shardName:     "shard-1.tar",
+				recordName:    "record-1.cls",
+				r:             &unreadableReader{size: 1024},
+				metadata:      []byte("header"),
+				extractMethod: ExtractToMem,
+				offset:        512,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(1024)))
+
+			Expect(rm.Records.Len()).To(Equal(1))
+			rec := rm.Records.All()[0]
+			Expect(rec.Objects).To(HaveLen(1))
+			obj := rec.Objects[0]
+			Expect(obj.StoreType).To(Equal(OffsetStoreType))
+			Expect(obj.ContentPath).To(Equal("shard-1.tar"))
+			Expect(obj.Size).To(Equal(int64(1024)))
+			Expect(obj.MetadataSize).To(Equal(int64(len("header"))))
+			Expect(obj.Offset).To(Equal(int64(512)))
+			Expect(rec.TotalSize()).To(Equal(int64(1024)))
+		})
+	})
+})
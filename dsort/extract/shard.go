@@ -23,6 +23,9 @@ type (
 		Records *Records `msg:"r"`
 		// Name determines the output name of the shard.
 		Name string `msg:"n"`
+		// DaemonID is the ID of the target that creates the shard (see
+		// RequestSpec.OutputPlacement); the shard is stored on the HRW target.
+		DaemonID string `msg:"d"`
 	}
 )
 
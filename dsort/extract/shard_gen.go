@@ -58,6 +58,12 @@ func (z *Shard) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Name")
 				return
 			}
+		case "d":
+			z.DaemonID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DaemonID")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -71,9 +77,9 @@ func (z *Shard) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *Shard) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
+	// map header, size 4
 	// write "s"
-	err = en.Append(0x83, 0xa1, 0x73)
+	err = en.Append(0x84, 0xa1, 0x73)
 	if err != nil {
 		return
 	}
@@ -109,6 +115,16 @@ func (z *Shard) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Name")
 		return
 	}
+	// write "d"
+	err = en.Append(0xa1, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DaemonID)
+	if err != nil {
+		err = msgp.WrapError(err, "DaemonID")
+		return
+	}
 	return
 }
 
@@ -120,6 +136,6 @@ func (z *Shard) Msgsize() (s int) {
 	} else {
 		s += z.Records.Msgsize()
 	}
-	s += 2 + msgp.StringPrefixSize + len(z.Name)
+	s += 2 + msgp.StringPrefixSize + len(z.Name) + 2 + msgp.StringPrefixSize + len(z.DaemonID)
 	return
 }
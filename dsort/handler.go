@@ -46,7 +46,13 @@ func ProxySortHandler(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodPost:
-		proxyStartSortHandler(w, r)
+		if len(apiItems) == 1 && apiItems[0] == cmn.Plan {
+			proxyStartSortHandler(w, r, true /*plan only*/)
+		} else if len(apiItems) == 0 {
+			proxyStartSortHandler(w, r, false)
+		} else {
+			cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("invalid request %s", apiItems[0]))
+		}
 	case http.MethodGet:
 		proxyGetHandler(w, r)
 	case http.MethodDelete:
@@ -63,7 +69,8 @@ func ProxySortHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /v1/sort
-func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
+// POST /v1/sort/plan
+func proxyStartSortHandler(w http.ResponseWriter, r *http.Request, planOnly bool) {
 	if !checkHTTPMethod(w, r, http.MethodPost) {
 		return
 	}
@@ -77,6 +84,7 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	parsedRS.TargetOrderSalt = []byte(time.Now().Format("15:04:05.000000"))
+	parsedRS.PlanOnly = planOnly
 
 	// TODO: handle case when bucket was removed during dSort job - this should
 	// stop whole operation. Maybe some listeners as we have on smap change?
@@ -107,6 +115,20 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(parsedRS.ExcludeTargets) > 0 {
+		smap := ctx.smapOwner.Get()
+		for _, tid := range parsedRS.ExcludeTargets {
+			if smap.GetTarget(tid) == nil {
+				cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("cannot exclude target %q: not present in the %s", tid, smap))
+				return
+			}
+		}
+		if len(parsedRS.ExcludeTargets) >= smap.CountTargets() {
+			cmn.InvalidHandlerWithMsg(w, r, "cannot exclude all targets from creating output shards")
+			return
+		}
+	}
+
 	parsedRS.DSorterType, err = determineDSorterType(parsedRS)
 	if err != nil {
 		cmn.InvalidHandlerWithMsg(w, r, err.Error())
@@ -279,6 +301,34 @@ func proxyMetricsSortHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(body)
 }
 
+// JobFinished (proxy only) queries the targets and returns true if a given
+// dSort job has finished (or aborted) on all of them; `exists` is false if
+// none of the targets knows about the job.
+func JobFinished(managerUUID string) (finished, exists bool) {
+	var (
+		path      = cmn.JoinWords(cmn.Version, cmn.Sort, cmn.Metrics, managerUUID)
+		responses = broadcast(http.MethodGet, path, nil, nil, ctx.smapOwner.Get().Tmap)
+	)
+	for _, resp := range responses {
+		if resp.statusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.err != nil {
+			return false, true
+		}
+		metrics := &Metrics{}
+		if err := js.Unmarshal(resp.res, &metrics); err != nil {
+			return false, true
+		}
+		if !metrics.Archived.Load() {
+			return false, true
+		}
+		exists = true
+	}
+	finished = exists
+	return
+}
+
 // DELETE /v1/sort/abort
 func proxyAbortSortHandler(w http.ResponseWriter, r *http.Request) {
 	if !checkHTTPMethod(w, r, http.MethodDelete) {
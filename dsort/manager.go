@@ -467,6 +467,9 @@ func (m *Manager) setExtractCreator() (err error) {
 
 	m.recManager = extract.NewRecordManager(m.ctx.t, m.ctx.node.DaemonID, m.rs.Bucket, m.rs.Provider,
 		m.rs.Extension, m.extractCreator, keyExtractor, onDuplicatedRecords)
+	if m.rs.PlanOnly {
+		m.recManager.SetHeadersOnly()
+	}
 
 	return nil
 }
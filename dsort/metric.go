@@ -187,6 +187,9 @@ type Metrics struct {
 	Sorting    *MetaSorting     `json:"meta_sorting,omitempty"`
 	Creation   *ShardCreation   `json:"shard_creation,omitempty"`
 
+	// Plan is set by the final target of a planning job (see Plan).
+	Plan *Plan `json:"plan,omitempty"`
+
 	// Aborted specifies if the DSort has been aborted or not.
 	Aborted atomic.Bool `json:"aborted,omitempty"`
 	// Archived specifies if the DSort has been archived to persistent storage.
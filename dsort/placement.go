@@ -0,0 +1,109 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package dsort
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
+)
+
+// shardPlacer selects the target that creates a given output shard, as per
+// RequestSpec.OutputPlacement and RequestSpec.ExcludeTargets:
+//
+// * hrw       - the HRW target of the shard; when the latter is excluded,
+//               the least loaded target is used instead;
+// * spread    - the least loaded target (load being the total size of the
+//               shards assigned to the target so far);
+// * collocate - the target that holds (extracted) most of the shard's records
+//               by size, the least loaded one in case of a tie.
+//
+// Excluded targets (and targets in maintenance) never create shards. Note that
+// the shard is always *stored* on its HRW target - when created elsewhere it
+// gets sent over to the HRW target (see createShard).
+type shardPlacer struct {
+	smap     *cluster.Smap
+	policy   string
+	targets  cluster.Nodes    // eligible targets sorted by ID
+	load     map[string]int64 // target ID => total size of the assigned shards
+	excluded cmn.StringSet
+	local    map[string]int64 // (collocate) target ID => size of the shard's records; reused
+}
+
+func newShardPlacer(rs *ParsedRequestSpec, smap *cluster.Smap) (*shardPlacer, error) {
+	p := &shardPlacer{
+		smap:     smap,
+		policy:   rs.OutputPlacement,
+		targets:  make(cluster.Nodes, 0, smap.CountTargets()),
+		load:     make(map[string]int64, smap.CountTargets()),
+		excluded: cmn.NewStringSet(rs.ExcludeTargets...),
+		local:    make(map[string]int64, smap.CountTargets()),
+	}
+	for _, si := range smap.Tmap {
+		if p.excluded.Contains(si.ID()) || si.InMaintenance() {
+			continue
+		}
+		p.targets = append(p.targets, si)
+	}
+	if len(p.targets) == 0 {
+		return nil, fmt.Errorf("no targets to create output shards: all excluded or in maintenance (%s)", smap)
+	}
+	sort.Slice(p.targets, func(i, j int) bool { return p.targets[i].ID() < p.targets[j].ID() })
+	return p, nil
+}
+
+func (p *shardPlacer) place(s *extract.Shard, uname string) (si *cluster.Snode, err error) {
+	switch p.policy {
+	case OutputPlacementSpread:
+		si = p.leastLoaded()
+	case OutputPlacementCollocate:
+		si = p.collocated(s)
+	default:
+		if si, err = cluster.HrwTarget(uname, p.smap); err != nil {
+			return
+		}
+		if p.excluded.Contains(si.ID()) {
+			si = p.leastLoaded()
+		}
+	}
+	p.load[si.ID()] += s.Size
+	return
+}
+
+func (p *shardPlacer) leastLoaded() (si *cluster.Snode) {
+	for _, tsi := range p.targets {
+		if si == nil || p.load[tsi.ID()] < p.load[si.ID()] {
+			si = tsi
+		}
+	}
+	return
+}
+
+func (p *shardPlacer) collocated(s *extract.Shard) (si *cluster.Snode) {
+	for id := range p.local {
+		delete(p.local, id)
+	}
+	for _, rec := range s.Records.All() {
+		p.local[rec.DaemonID] += rec.TotalSize()
+	}
+	var max int64
+	for _, tsi := range p.targets {
+		size := p.local[tsi.ID()]
+		if size == 0 {
+			continue
+		}
+		if si == nil || size > max || (size == max && p.load[tsi.ID()] < p.load[si.ID()]) {
+			si, max = tsi, size
+		}
+	}
+	if si == nil { // none of the eligible targets holds the shard's records
+		si = p.leastLoaded()
+	}
+	return
+}
@@ -0,0 +1,98 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Placement", func() {
+	var smap *cluster.Smap
+
+	newShard := func(name string, sizes map[string]int64) *extract.Shard {
+		s := &extract.Shard{Name: name, Records: extract.NewRecords(len(sizes))}
+		for daemonID, size := range sizes {
+			s.Records.Insert(&extract.Record{
+				Name:     name + "-" + daemonID,
+				DaemonID: daemonID,
+				Objects:  []*extract.RecordObj{{Size: size}},
+			})
+			s.Size += size
+		}
+		return s
+	}
+
+	BeforeEach(func() {
+		smap = &cluster.Smap{Tmap: make(cluster.NodeMap)}
+		for _, id := range []string{"t1", "t2", "t3"} {
+			smap.Tmap[id] = &cluster.Snode{DaemonID: id}
+		}
+		smap.InitDigests()
+	})
+
+	It("should place shards on HRW targets by default", func() {
+		p, err := newShardPlacer(&ParsedRequestSpec{OutputPlacement: OutputPlacementHRW}, smap)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 100; i++ {
+			uname := fmt.Sprintf("shard-%d", i)
+			hrw, err := cluster.HrwTarget(uname, smap)
+			Expect(err).NotTo(HaveOccurred())
+			si, err := p.place(newShard(uname, map[string]int64{"t1": 10}), uname)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(si.ID()).To(Equal(hrw.ID()))
+		}
+	})
+
+	It("should never place shards on excluded targets", func() {
+		rs := &ParsedRequestSpec{ExcludeTargets: []string{"t2"}}
+		for _, policy := range supportedOutputPlacements {
+			rs.OutputPlacement = policy
+			p, err := newShardPlacer(rs, smap)
+			Expect(err).NotTo(HaveOccurred())
+			for i := 0; i < 100; i++ {
+				uname := fmt.Sprintf("shard-%d", i)
+				si, err := p.place(newShard(uname, map[string]int64{"t2": 10, "t3": 5}), uname)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(si.ID()).NotTo(Equal("t2"), policy)
+			}
+		}
+	})
+
+	It("should spread shards evenly by size", func() {
+		p, err := newShardPlacer(&ParsedRequestSpec{OutputPlacement: OutputPlacementSpread}, smap)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 99; i++ {
+			uname := fmt.Sprintf("shard-%d", i)
+			_, err := p.place(newShard(uname, map[string]int64{"t1": 10}), uname)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		for _, id := range []string{"t1", "t2", "t3"} {
+			Expect(p.load[id]).To(BeEquivalentTo(330))
+		}
+	})
+
+	It("should collocate shards with most of their records", func() {
+		p, err := newShardPlacer(&ParsedRequestSpec{OutputPlacement: OutputPlacementCollocate}, smap)
+		Expect(err).NotTo(HaveOccurred())
+		si, err := p.place(newShard("a", map[string]int64{"t1": 10, "t3": 20}), "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(si.ID()).To(Equal("t3"))
+
+		// tie - the least loaded target wins
+		si, err = p.place(newShard("b", map[string]int64{"t1": 10, "t3": 10}), "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(si.ID()).To(Equal("t1"))
+	})
+
+	It("should fail when all targets are excluded", func() {
+		_, err := newShardPlacer(&ParsedRequestSpec{ExcludeTargets: []string{"t1", "t2", "t3"}}, smap)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,120 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package dsort
+
+import (
+	"math"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
+)
+
+type (
+	// Plan is the outcome of a planning (dry-run) job (POST /v1/sort/plan):
+	// the job extracts and sorts the records but, instead of creating output
+	// shards, reports what the full run would produce. Computed by the final
+	// target and included in its Metrics.
+	Plan struct {
+		RecordCnt    int64 `json:"record_count,string"`
+		RecordsSize  int64 `json:"records_size,string"` // total (uncompressed) size of all records
+		ShardCnt     int64 `json:"shard_count,string"`
+		MinShardSize int64 `json:"min_shard_size,string"`
+		MaxShardSize int64 `json:"max_shard_size,string"`
+		AvgShardSize int64 `json:"avg_shard_size,string"`
+		// memory required to hold and sort the metadata of all records
+		// (the final target does it at the end of the sorting phase)
+		SortMemory int64 `json:"sort_memory,string"`
+		// max/avg ratios of per-target numbers (1.0 - perfectly balanced)
+		RecordSkew float64 `json:"record_skew"`
+		ShardSkew  float64 `json:"shard_skew"`
+
+		Targets map[string]*TargetPlan `json:"targets"` // per target (by ID)
+	}
+	TargetPlan struct {
+		// input: records extracted by the target; their content is kept
+		// (in memory or on disk) until shard creation
+		RecordCnt   int64 `json:"record_count,string"`
+		RecordsSize int64 `json:"records_size,string"`
+		// output: shards that the target would create (as per the output placement)
+		ShardCnt int64 `json:"shard_count,string"`
+		// estimated disk space required to store the shards (compressed, if applicable)
+		ShardsSize int64 `json:"shards_size,string"`
+	}
+)
+
+// newPlan must be called by the final target - prior to draining the records
+func (m *Manager) newPlan(shards []*extract.Shard, shardsToTarget map[*cluster.Snode][]*extract.Shard) *Plan {
+	var (
+		ratio = 1.0
+		plan  = &Plan{
+			ShardCnt:     int64(len(shards)),
+			MinShardSize: math.MaxInt64,
+			SortMemory:   int64(m.recManager.Records.Len()) * int64(m.recManager.Records.RecordMemorySize()),
+			Targets:      make(map[string]*TargetPlan, len(shardsToTarget)),
+		}
+	)
+	if m.extractCreator.UsingCompression() {
+		ratio = m.avgCompressionRatio()
+	}
+	for si, tshards := range shardsToTarget {
+		tplan := &TargetPlan{ShardCnt: int64(len(tshards))}
+		for _, s := range tshards {
+			tplan.ShardsSize += int64(float64(s.Size) * ratio)
+		}
+		plan.Targets[si.ID()] = tplan
+	}
+	for _, r := range m.recManager.Records.All() {
+		tplan, ok := plan.Targets[r.DaemonID]
+		if !ok {
+			tplan = &TargetPlan{}
+			plan.Targets[r.DaemonID] = tplan
+		}
+		tplan.RecordCnt++
+		tplan.RecordsSize += r.TotalSize()
+		plan.RecordCnt++
+		plan.RecordsSize += r.TotalSize()
+	}
+	var totalSize int64
+	for _, s := range shards {
+		plan.MinShardSize = cmn.MinI64(plan.MinShardSize, s.Size)
+		plan.MaxShardSize = cmn.MaxI64(plan.MaxShardSize, s.Size)
+		totalSize += s.Size
+	}
+	if plan.ShardCnt == 0 {
+		plan.MinShardSize = 0
+	} else {
+		plan.AvgShardSize = totalSize / plan.ShardCnt
+	}
+
+	var maxRecords, maxShards int64
+	for _, tplan := range plan.Targets {
+		maxRecords = cmn.MaxI64(maxRecords, tplan.RecordCnt)
+		maxShards = cmn.MaxI64(maxShards, tplan.ShardsSize)
+	}
+	if cnt := int64(len(plan.Targets)); cnt > 0 {
+		plan.RecordSkew = skew(maxRecords, plan.RecordCnt, cnt)
+		plan.ShardSkew = skew(maxShards, int64(float64(totalSize)*ratio), cnt)
+	}
+	return plan
+}
+
+func skew(max, total, cnt int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(max) * float64(cnt) / float64(total)
+}
+
+func (m *Manager) setPlan(plan *Plan) {
+	m.Metrics.lock()
+	m.Metrics.Plan = plan
+	m.Metrics.unlock()
+	glog.Infof("%s %s plan: %d records (%s) => %d shards (avg %s), record skew %.2f, shard skew %.2f",
+		cmn.DSortName, m.ManagerUUID, plan.RecordCnt, cmn.B2S(plan.RecordsSize, 2), plan.ShardCnt,
+		cmn.B2S(plan.AvgShardSize, 2), plan.RecordSkew, plan.ShardSkew)
+}
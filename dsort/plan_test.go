@@ -0,0 +1,24 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	DescribeTable("skew",
+		func(max, total, cnt int64, expected float64) {
+			Expect(skew(max, total, cnt)).To(BeNumerically("~", expected, 0.001))
+		},
+		Entry("empty", int64(0), int64(0), int64(3), 0.0),
+		Entry("balanced", int64(10), int64(30), int64(3), 1.0),
+		Entry("single target", int64(30), int64(30), int64(1), 1.0),
+		Entry("all on one target", int64(30), int64(30), int64(3), 3.0),
+		Entry("slightly skewed", int64(12), int64(30), int64(3), 1.2),
+	)
+})
@@ -24,12 +24,20 @@ const (
 	templAt   = "@"
 )
 
+// Output placement policies (see RequestSpec.OutputPlacement)
+const (
+	OutputPlacementHRW       = "hrw"       // shard is created by its HRW target (default)
+	OutputPlacementSpread    = "spread"    // shards are spread evenly (by size) across the targets
+	OutputPlacementCollocate = "collocate" // shard is created by the target holding most of its records
+)
+
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
 	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = fmt.Errorf("concurrency max limit must be 0 (limits will be calculated) or > 0")
+	errInvalidOutputPlacement   = fmt.Errorf("invalid output placement, should be one of: %+v", supportedOutputPlacements)
 
 	errInvalidInputTemplateFormat  = errors.New("could not parse given input format, example of bash format: 'prefix{0001..0010}suffix`, example of at format: 'prefix@00100suffix`")
 	errInvalidOutputTemplateFormat = errors.New("could not parse given output format, example of bash format: 'prefix{0001..0010}suffix`, example of at format: 'prefix@00100suffix`")
@@ -44,6 +52,8 @@ var (
 // supportedExtensions is a list of supported extensions by dSort
 var supportedExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtZip}
 
+var supportedOutputPlacements = []string{OutputPlacementHRW, OutputPlacementSpread, OutputPlacementCollocate}
+
 // TODO: maybe this struct should be composed of `type` and `template` where
 // template is interface and each template has it's own struct. Then we could
 // reflect the interface and based on it start different traverse function.
@@ -100,6 +110,10 @@ type RequestSpec struct {
 	StreamMultiplier int `json:"stream_multiplier" yaml:"stream_multiplier"`
 	// Default: false
 	ExtendedMetrics bool `json:"extended_metrics" yaml:"extended_metrics"`
+	// Default: "hrw"
+	OutputPlacement string `json:"output_placement" yaml:"output_placement"`
+	// Default: [] (IDs of the targets that must not create output shards)
+	ExcludeTargets []string `json:"exclude_targets" yaml:"exclude_targets"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -128,11 +142,17 @@ type ParsedRequestSpec struct {
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	StreamMultiplier    int                   `json:"stream_multiplier"` // TODO: should be removed
 	ExtendedMetrics     bool                  `json:"extended_metrics"`
+	OutputPlacement     string                `json:"output_placement"`
+	ExcludeTargets      []string              `json:"exclude_targets"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
 	DryRun      bool   `json:"dry_run"`
 
+	// PlanOnly is set when the job is started via the planning endpoint:
+	// the job stops after sorting and reports its Plan (no shards get created)
+	PlanOnly bool `json:"plan_only"`
+
 	cmn.DSortConf
 }
 
@@ -246,6 +266,21 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.CreateConcMaxLimit = rs.CreateConcMaxLimit
 	parsedRS.StreamMultiplier = rs.StreamMultiplier
 	parsedRS.ExtendedMetrics = rs.ExtendedMetrics
+
+	parsedRS.OutputPlacement = rs.OutputPlacement
+	if parsedRS.OutputPlacement == "" {
+		parsedRS.OutputPlacement = OutputPlacementHRW
+	} else if !cmn.StringInSlice(parsedRS.OutputPlacement, supportedOutputPlacements) {
+		return nil, errInvalidOutputPlacement
+	}
+	for _, tid := range rs.ExcludeTargets {
+		if tid == "" {
+			return nil, errors.New("invalid (empty) target ID in the exclusion list")
+		}
+		if !cmn.StringInSlice(tid, parsedRS.ExcludeTargets) {
+			parsedRS.ExcludeTargets = append(parsedRS.ExcludeTargets, tid)
+		}
+	}
 	parsedRS.DSorterType = rs.DSorterType
 	parsedRS.DryRun = rs.DryRun
 
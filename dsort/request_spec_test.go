@@ -290,6 +290,27 @@ var _ = Describe("RequestSpec", func() {
 			_, err = rs.Parse()
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+		It("should parse output placement and exclusion list", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputPlacement).To(Equal(OutputPlacementHRW))
+			Expect(parsed.ExcludeTargets).To(BeEmpty())
+
+			rs.OutputPlacement = OutputPlacementSpread
+			rs.ExcludeTargets = []string{"t1", "t2", "t1"}
+			parsed, err = rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputPlacement).To(Equal(OutputPlacementSpread))
+			Expect(parsed.ExcludeTargets).To(Equal([]string{"t1", "t2"}))
+		})
 	})
 
 	Context("request specs which shall NOT pass", func() {
@@ -461,5 +482,19 @@ var _ = Describe("RequestSpec", func() {
 			_, err := rs.Parse()
 			Expect(err).Should(HaveOccurred())
 		})
+
+		It("should fail due to invalid output placement", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				OutputPlacement: "random",
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidOutputPlacement))
+		})
 	})
 })
@@ -9,6 +9,7 @@ import (
 	"os"
 	"sync"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -17,6 +18,16 @@ import (
 	"github.com/NVIDIA/aistore/xaction/registry"
 )
 
+const (
+	throttleNumObjects = 16  // unit of self-throttling (re-encoding)
+	maxMixedLayout     = 100 // max number of names of objects (still) in a different layout to report
+)
+
+// (can be replaced in tests)
+var reencodeObject = func(lom *cluster.LOM, md *Metadata, cb cluster.OnFinishObj) error {
+	return ECM.ReencodeObject(lom, md, cb)
+}
+
 type (
 	// Implements `registry.BucketEntryProvider` and `registry.BucketEntry` interface.
 	xactBckEncodeProvider struct {
@@ -35,6 +46,23 @@ type (
 		t        cluster.Target
 		bck      cmn.Bck
 		wg       *sync.WaitGroup // to wait for EC finishes all objects
+
+		// objects encoded with a different layout (e.g., after changing the
+		// number of data or parity slices) get re-encoded
+		reencoded atomic.Int64
+		mixed     atomic.Int64 // objects (still) in a different layout
+		mu        sync.Mutex
+		failed    []string // names of (up to maxMixedLayout) objects that failed to re-encode
+	}
+
+	ECEncodeTargetStats struct {
+		xaction.BaseXactStats
+		Ext ExtECEncodeStats `json:"ext"`
+	}
+	ExtECEncodeStats struct {
+		Reencoded int64    `json:"reencoded.n,string"` // objects re-encoded with the current layout
+		Mixed     int64    `json:"mixed.n,string"`     // objects (still) in a different layout
+		Objects   []string `json:"mixed"`              // (names of the first maxMixedLayout that failed to re-encode)
 	}
 
 	joggerBckEncode struct { // per mountpath
@@ -46,9 +74,14 @@ type (
 		// to cache some info for quick access
 		smap     *cluster.Smap
 		daemonID string
+
+		num int64 // objects re-encoded by this jogger
 	}
 )
 
+// interface guard
+var _ cluster.XactStats = &ECEncodeTargetStats{}
+
 func (*xactBckEncodeProvider) New(args registry.XactArgs) registry.BucketEntry {
 	return &xactBckEncodeProvider{
 		t:     args.T,
@@ -101,6 +134,38 @@ func (r *XactBckEncode) afterECObj(lom *cluster.LOM, err error) {
 	r.wg.Done()
 }
 
+func (r *XactBckEncode) afterReencode(lom *cluster.LOM, err error) {
+	if err == nil {
+		r.reencoded.Inc()
+		r.mixed.Dec()
+	} else {
+		r.addFailed(lom.ObjName)
+	}
+	r.afterECObj(lom, err)
+}
+
+func (r *XactBckEncode) addFailed(objName string) {
+	r.mu.Lock()
+	if len(r.failed) < maxMixedLayout {
+		r.failed = append(r.failed, objName)
+	}
+	r.mu.Unlock()
+}
+
+// override/extend cmn.XactBase.Stats()
+func (r *XactBckEncode) Stats() cluster.XactStats {
+	var (
+		baseStats = r.XactBase.Stats().(*xaction.BaseXactStats)
+		st        = ECEncodeTargetStats{BaseXactStats: *baseStats}
+	)
+	st.Ext.Reencoded = r.reencoded.Load()
+	st.Ext.Mixed = r.mixed.Load()
+	r.mu.Lock()
+	st.Ext.Objects = append([]string(nil), r.failed...)
+	r.mu.Unlock()
+	return &st
+}
+
 func (r *XactBckEncode) Run() (err error) {
 	var numjs int
 
@@ -111,6 +176,12 @@ func (r *XactBckEncode) Run() (err error) {
 	if !bck.Props.EC.Enabled {
 		return fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name)
 	}
+	if !r.Admit() {
+		err = cmn.NewAbortedError(r.String())
+		r.Finish(err)
+		return
+	}
+	defer r.Release()
 	if numjs, err = r.init(); err != nil {
 		return
 	}
@@ -155,6 +226,10 @@ func (r *XactBckEncode) run(numjs int) error {
 			if numjs == 0 {
 				glog.Infof("%s: all done. Waiting for EC finishes", r)
 				r.wg.Wait()
+				if mixed := r.mixed.Load(); mixed > 0 {
+					glog.Warningf("%s: %d object(s) remain in a different layout (reencoded: %d)",
+						r, mixed, r.reencoded.Load())
+				}
 				r.mpathers = nil
 				r.stop()
 				return nil
@@ -189,7 +264,8 @@ func (j *joggerBckEncode) jog() {
 
 // Walks through all files in 'obj' directory, and calls EC.Encode for every
 // file whose HRW points to this file and the file does not have corresponding
-// metadata file in 'meta' directory
+// metadata file in 'meta' directory - or the metadata shows that the file was
+// encoded with a layout that differs from the current bucket props (re-encode)
 func (j *joggerBckEncode) walk(fqn string, de fs.DirEntry) error {
 	select {
 	case <-j.stopCh.Listen():
@@ -228,13 +304,16 @@ func (j *joggerBckEncode) walk(fqn string, de fs.DirEntry) error {
 		glog.Warningf("metadata FQN generation failed %q: %v", fqn, err)
 		return nil
 	}
-	_, err = os.Stat(mdFQN)
-	// metadata file exists - the object was already EC'ed before
+	md, err := LoadMetadata(mdFQN)
 	if err == nil {
-		return nil
+		// metadata file exists - the object was already EC'ed before
+		if !LayoutDiffers(md, &lom.Bprops().EC) {
+			return nil
+		}
+		return j.reencode(lom, md)
 	}
 	if !os.IsNotExist(err) {
-		glog.Warningf("failed to stat %q: %v", mdFQN, err)
+		glog.Warningf("failed to load %q: %v", mdFQN, err)
 		return nil
 	}
 
@@ -243,9 +322,31 @@ func (j *joggerBckEncode) walk(fqn string, de fs.DirEntry) error {
 	// That means all objects have been processed and xaction can finalize.
 	j.parent.beforeECObj()
 	if err = ECM.EncodeObject(lom, j.parent.afterECObj); err != nil {
+		j.parent.afterECObj(lom, err)
 		// something wrong with EC, interrupt file walk - it is critical
 		return fmt.Errorf("failed to EC object %q: %v", fqn, err)
 	}
 
 	return nil
 }
+
+// the object that fails to re-encode gets counted (and reported - see Stats)
+// while the walk continues
+func (j *joggerBckEncode) reencode(lom *cluster.LOM, md *Metadata) error {
+	j.parent.mixed.Inc()
+	j.parent.beforeECObj()
+	if err := reencodeObject(lom, md, j.parent.afterReencode); err != nil {
+		j.parent.afterReencode(lom, err)
+		return nil
+	}
+	j.num++
+	if j.num%throttleNumObjects == 0 {
+		j.throttle()
+	}
+	return nil
+}
+
+// [throttle] re-encoding is a background job - yield to the foreground when the disk is busy
+func (j *joggerBckEncode) throttle() {
+	fs.Throttle(j.mpathInfo, fs.QoSNormal)
+}
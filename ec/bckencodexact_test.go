@@ -0,0 +1,79 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestLayoutDiffers(t *testing.T) {
+	ecConf := &cmn.ECConf{DataSlices: 2, ParitySlices: 2, ObjSizeLimit: 100}
+	tests := []struct {
+		md      Metadata
+		differs bool
+	}{
+		{Metadata{Size: 200, Data: 2, Parity: 2}, false},
+		{Metadata{Size: 200, Data: 1, Parity: 2}, true},
+		{Metadata{Size: 200, Data: 2, Parity: 1}, true},
+		{Metadata{Size: 50, Parity: 2, IsCopy: true}, false},
+		{Metadata{Size: 50, Parity: 1, IsCopy: true}, true},
+		{Metadata{Size: 50, Data: 2, Parity: 2}, true}, // encoded, must be replicated
+	}
+	for _, test := range tests {
+		tassert.Errorf(t, LayoutDiffers(&test.md, ecConf) == test.differs,
+			"%+v: expected differs=%t", test.md, test.differs)
+	}
+}
+
+func TestReencodeFailures(t *testing.T) {
+	defer func(f func(*cluster.LOM, *Metadata, cluster.OnFinishObj) error) { reencodeObject = f }(reencodeObject)
+
+	mpath, err := ioutil.TempDir("", "ec")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	fs.Init()
+	fs.DisableFsIDCheck()
+	tassert.CheckFatal(t, fs.Add(mpath))
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+
+	var (
+		bck   = cluster.NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{})
+		tMock = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+		r     = NewXactBckEncode(bck.Bck, tMock, "uuid")
+		j     = &joggerBckEncode{parent: r}
+	)
+	reencodeObject = func(lom *cluster.LOM, _ *Metadata, cb cluster.OnFinishObj) error {
+		switch lom.ObjName {
+		case "fail-sync":
+			return errors.New("failed to start")
+		case "fail-async":
+			cb(lom, errors.New("failed"))
+		default:
+			cb(lom, nil)
+		}
+		return nil
+	}
+	for _, objName := range []string{"obj1", "fail-sync", "obj2", "fail-async", "obj3"} {
+		lom := &cluster.LOM{T: tMock, ObjName: objName}
+		tassert.CheckFatal(t, lom.Init(bck.Bck))
+		// must not interrupt the walk
+		tassert.CheckFatal(t, j.reencode(lom, &Metadata{}))
+	}
+	r.wg.Wait()
+
+	st := r.Stats().(*ECEncodeTargetStats)
+	tassert.Errorf(t, st.Ext.Reencoded == 3, "expected 3 re-encoded, got %d", st.Ext.Reencoded)
+	tassert.Errorf(t, st.Ext.Mixed == 2, "expected 2 in a different layout, got %d", st.Ext.Mixed)
+	tassert.Errorf(t, len(st.Ext.Objects) == 2 && st.Ext.Objects[0] == "fail-sync" &&
+		st.Ext.Objects[1] == "fail-async", "unexpected failed objects %v", st.Ext.Objects)
+}
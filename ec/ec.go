@@ -149,6 +149,7 @@ type (
 		tm      time.Time // to measure different steps
 		IsCopy  bool      // replicate or use erasure coding
 		rebuild bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		prev    *Metadata // re-encoding: the layout (of the already encoded object) being replaced
 	}
 
 	RequestsControlMsg struct {
@@ -264,6 +265,26 @@ func IsECCopy(size int64, ecConf *cmn.ECConf) bool {
 	return size < ecConf.ObjSizeLimit
 }
 
+// LayoutDiffers returns true if the object was encoded with the layout (number
+// of data and parity slices, or replicas) that differs from the current `ecConf`.
+func LayoutDiffers(md *Metadata, ecConf *cmn.ECConf) bool {
+	if md.IsCopy != IsECCopy(md.Size, ecConf) {
+		return true
+	}
+	if md.IsCopy {
+		return md.Parity != ecConf.ParitySlices
+	}
+	return md.Data != ecConf.DataSlices || md.Parity != ecConf.ParitySlices
+}
+
+// the number of targets that hold the main replica, slices, and replicas
+func layoutTargets(md *Metadata) int {
+	if md.IsCopy {
+		return md.Parity + 1
+	}
+	return md.Data + md.Parity + 1
+}
+
 // returns whether EC must use disk instead of keeping everything in memory.
 // Depends on available free memory and size of an object to process
 func useDisk(objSize int64) bool {
@@ -285,6 +285,22 @@ func (mgr *Manager) recvResponse(w http.ResponseWriter, hdr transport.ObjHdr, ob
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) error {
+	var callback cluster.OnFinishObj
+	if len(cb) != 0 {
+		callback = cb[0]
+	}
+	return mgr.encode(lom, nil, callback)
+}
+
+// ReencodeObject encodes the object that has already been encoded with a different
+// layout (`prev`), e.g. after changing the number of data or parity slices, and
+// removes the slices (replicas) from the targets that the new layout does not use.
+func (mgr *Manager) ReencodeObject(lom *cluster.LOM, prev *Metadata, cb cluster.OnFinishObj) error {
+	cmn.Assert(prev != nil && cb != nil)
+	return mgr.encode(lom, prev, cb)
+}
+
+func (mgr *Manager) encode(lom *cluster.LOM, prev *Metadata, cb cluster.OnFinishObj) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -311,13 +327,12 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 	}
 
 	req := &Request{
-		Action:  ActSplit,
-		IsCopy:  IsECCopy(lom.Size(), &lom.Bprops().EC),
-		LOM:     lom,
-		rebuild: len(cb) != 0,
-	}
-	if len(cb) != 0 {
-		req.Callback = cb[0]
+		Action:   ActSplit,
+		IsCopy:   IsECCopy(lom.Size(), &lom.Bprops().EC),
+		LOM:      lom,
+		Callback: cb,
+		rebuild:  cb != nil,
+		prev:     prev,
 	}
 
 	mgr.RestoreBckPutXact(lom.Bck()).Encode(req)
@@ -195,18 +195,44 @@ func (c *putJogger) encode(req *Request) error {
 	if meta.IsCopy {
 		if err := c.createCopies(req, meta); err != nil {
 			c.cleanup(req)
+			return err
 		}
-		return nil
-	}
-
-	// big object is erasure encoded
-	if slices, err := c.sendSlices(req, meta); err != nil {
+	} else if slices, err := c.sendSlices(req, meta); err != nil { // big object is erasure encoded
 		freeSlices(slices)
 		c.cleanup(req)
+		return err
+	}
+	if req.prev != nil {
+		return c.cleanupPrev(req, meta)
 	}
 	return nil
 }
 
+// re-encoding: removes slices and replicas of the previous layout from the
+// targets that do not get any in the new one (with fewer slices or replicas);
+// the targets that do, simply overwrite theirs
+func (c *putJogger) cleanupPrev(req *Request, meta *Metadata) error {
+	var (
+		smap         = c.parent.smap.Get()
+		prevCnt, cnt = cmn.Min(layoutTargets(req.prev), smap.CountTargets()), layoutTargets(meta)
+	)
+	if prevCnt <= cnt {
+		return nil
+	}
+	targets, err := cluster.HrwTargetList(req.LOM.Uname(), smap, prevCnt)
+	if err != nil || len(targets) <= cnt {
+		return err
+	}
+	mm := c.parent.t.SmallMMSA()
+	request := c.parent.newIntraReq(reqDel, nil).NewPack(mm)
+	hdr := transport.ObjHdr{
+		Bck:     req.LOM.Bck().Bck,
+		ObjName: req.LOM.ObjName,
+		Opaque:  request,
+	}
+	return c.parent.reqBundle.Send(&transport.Obj{Hdr: hdr, Callback: c.ctSendCallback}, nil, targets[cnt:]...)
+}
+
 func (c *putJogger) ctSendCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, err error) {
 	c.parent.t.SmallMMSA().Free(hdr.Opaque)
 	if err != nil {
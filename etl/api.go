@@ -6,10 +6,8 @@ package etl
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/etl/runtime"
 )
 
 type (
@@ -55,14 +53,8 @@ type (
 var ErrMissingUUID = errors.New("ETL UUID can't be empty")
 
 func (m BuildMsg) Validate() error {
-	if len(m.Code) == 0 {
-		return fmt.Errorf("source code is empty")
-	}
-	if m.Runtime == "" {
-		return fmt.Errorf("runtime is not specified")
-	}
-	if _, ok := runtime.Runtimes[m.Runtime]; !ok {
-		return fmt.Errorf("unsupported runtime provided: %s", m.Runtime)
+	if errs := LintBuild(&m); len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
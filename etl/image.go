@@ -0,0 +1,209 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// checkImage checks that the container image exists and can be pulled anonymously,
+// by querying its manifest with the (Docker) Registry HTTP API V2:
+// `HEAD /v2/<name>/manifests/<reference>`. Since it's the proxy that makes the
+// requests, only the allowlisted public registries get queried (the images
+// from other registries are not checked), redirects are not followed, and
+// the bearer token is requested only from the registry itself or its
+// allowlisted auth host - otherwise, a spec could make the proxy send requests
+// anywhere in the cluster network. Images that require credentials fail the
+// check with errImageAuth (see Validate for imagePullSecrets).
+
+const (
+	dockerHub         = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+var (
+	errImageAuth = errors.New("access denied (private image?)")
+
+	// public registries that can be queried, and their token realm hosts
+	// (other than the registry itself)
+	imageRegistries = map[string][]string{
+		dockerHubRegistry: {"auth.docker.io"},
+		"gcr.io":          nil,
+		"quay.io":         nil,
+		"ghcr.io":         nil,
+		"nvcr.io":         nil,
+		"public.ecr.aws":  nil,
+	}
+
+	manifestTypes = []string{
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.oci.image.index.v1+json",
+	}
+	imageClient = newImageClient()
+)
+
+func newImageClient() *http.Client {
+	client := cmn.NewClient(cmn.TransportArgs{UseHTTPS: true, Timeout: cmn.DefaultTimeout})
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	return client
+}
+
+func isAuthErr(err error) bool { return errors.Is(err, errImageAuth) }
+
+// parseImage splits image reference into registry, repository and reference (tag or digest),
+// e.g. "python:3.8" => ("registry-1.docker.io", "library/python", "3.8").
+func parseImage(image string) (registry, repo, ref string, err error) {
+	if image == "" {
+		return "", "", "", errors.New("empty image")
+	}
+	name := image
+	ref = "latest"
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name, ref = name[:i], name[i+1:]
+	} else if i := strings.LastIndexByte(name, ':'); i > strings.LastIndexByte(name, '/') {
+		name, ref = name[:i], name[i+1:]
+	}
+	registry = dockerHub
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		if domain := name[:i]; strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			registry, name = domain, name[i+1:]
+		}
+	}
+	if name == "" || ref == "" {
+		return "", "", "", fmt.Errorf("invalid image reference %q", image)
+	}
+	if registry == dockerHub {
+		registry = dockerHubRegistry
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	return registry, name, ref, nil
+}
+
+func checkImage(image string) error {
+	registry, repo, ref, err := parseImage(image)
+	if err != nil {
+		return err
+	}
+	if _, ok := imageRegistries[registry]; !ok {
+		return nil // not checked
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repo, ref)
+	resp, err := headManifest(manifestURL, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := anonymousToken(registry, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return err
+		}
+		if resp, err = headManifest(manifestURL, token); err != nil {
+			return err
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("image %q not found", image)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("image %q: %w", image, errImageAuth)
+	default:
+		return fmt.Errorf("image %q: unexpected registry response: %s", image, resp.Status)
+	}
+}
+
+func headManifest(manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := imageClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken requests bearer token as per the registry's challenge, e.g.:
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/python:pull"`
+func anonymousToken(registry, challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", errImageAuth
+	}
+	params := make(map[string]string, 3)
+	for _, kv := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			params[strings.TrimSpace(kv[:i])] = strings.Trim(strings.TrimSpace(kv[i+1:]), `"`)
+		}
+	}
+	realm := params["realm"]
+	if realm == "" {
+		return "", errImageAuth
+	}
+	if err := checkRealm(registry, realm); err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			query.Set(k, v)
+		}
+	}
+	resp, err := imageClient.Get(realm + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errImageAuth
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := jsoniter.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// the token realm must be https and either the registry itself or its allowlisted auth host
+func checkRealm(registry, realm string) error {
+	u, err := url.Parse(realm)
+	if err != nil {
+		return fmt.Errorf("invalid token realm %q: %v", realm, err)
+	}
+	if u.Scheme == "https" && u.User == nil {
+		if u.Host == registry {
+			return nil
+		}
+		for _, host := range imageRegistries[registry] {
+			if u.Host == host {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("registry %q: token realm %q is not allowed", registry, realm)
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 )
@@ -84,33 +83,9 @@ func ValidateSpec(spec []byte) (msg InitMsg, err error) {
 	}
 	errCtx.ETLName = pod.GetName()
 
-	// Check pod specification constraints.
-	if len(pod.Spec.Containers) != 1 {
-		return msg, cmn.NewETLError(errCtx, "unsupported number of containers (%d), expected: 1", len(pod.Spec.Containers))
-	}
-	container := pod.Spec.Containers[0]
-	if len(container.Ports) != 1 {
-		return msg, cmn.NewETLError(errCtx, "unsupported number of container ports (%d), expected: 1", len(container.Ports))
-	}
-	if container.Ports[0].Name != k8s.Default {
-		return msg, cmn.NewETLError(errCtx, "expected port name: %q, got: %q", k8s.Default, container.Ports[0].Name)
-	}
-
-	// Validate that user container supports health check.
-	// Currently we need the `default` port (on which the application runs) to
-	// be same as the `readiness` probe port.
-	if container.ReadinessProbe == nil {
-		return msg, cmn.NewETLError(errCtx, "readinessProbe section is required in a container spec")
-	}
-	// TODO: Add support for other health checks.
-	if container.ReadinessProbe.HTTPGet == nil {
-		return msg, cmn.NewETLError(errCtx, "httpGet missing in the readinessProbe")
-	}
-	if container.ReadinessProbe.HTTPGet.Path == "" {
-		return msg, cmn.NewETLError(errCtx, "expected non-empty path for readinessProbe")
-	}
-	if container.ReadinessProbe.HTTPGet.Port.StrVal != k8s.Default {
-		return msg, cmn.NewETLError(errCtx, "readinessProbe port must be the %q port", k8s.Default)
+	// Check pod specification constraints (see `lintPod`).
+	if errs := lintPod(pod); len(errs) > 0 {
+		return msg, cmn.NewETLError(errCtx, errs.Error())
 	}
 
 	// Check annotations.
@@ -7,6 +7,8 @@ package runtime
 const (
 	Python2 = "python2"
 	Python3 = "python3"
+	Go      = "go"
+	Rust    = "rust"
 )
 
 var Runtimes map[string]runtime
@@ -21,9 +23,9 @@ type (
 )
 
 func init() {
-	Runtimes = make(map[string]runtime, 2)
+	Runtimes = make(map[string]runtime, 4)
 
-	for _, r := range []runtime{py2{}, py3{}} {
+	for _, r := range []runtime{py2{}, py3{}, golang{}, rust{}} {
 		Runtimes[r.Type()] = r
 	}
 }
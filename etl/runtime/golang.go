@@ -0,0 +1,62 @@
+// Package runtime provides skeletons and static specifications for building ETL from scratch.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runtime
+
+type (
+	// golang implements Runtime for "go".
+	//
+	// The code is a single file of `package transform` that defines
+	// `func Transform(r io.Reader, w io.Writer) error`; the dependencies (if any)
+	// are Go modules, one per line (e.g. `github.com/klauspost/compress@v1.11.2`).
+	// The builder image (see images/go) compiles the code together with the
+	// server into a static binary that runs in a minimal container.
+	golang struct{}
+)
+
+func (r golang) Type() string        { return Go }
+func (r golang) CodeEnvName() string { return "AISTORE_CODE" }
+func (r golang) DepsEnvName() string { return "AISTORE_DEPS" }
+func (r golang) PodSpec() string {
+	return `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: <NAME>
+spec:
+  containers:
+    - name: server
+      image: alpine:3.12
+      ports:
+        - name: default
+          containerPort: 80
+      command: ['/runtime/server', '-l', ':80']
+      readinessProbe:
+        httpGet:
+          path: /health
+          port: default
+      volumeMounts:
+        - name: runtime
+          mountPath: "/runtime"
+  initContainers:
+    - name: server-build
+      image: aistore/runtime_go:1.15
+      command:
+        - 'sh'
+        - '-c'
+        - |
+          set -e
+          printf '%s' "${AISTORE_CODE}" > /server/transform/transform.go
+          printf '%s\n' "${AISTORE_DEPS}" > /server/deps.txt
+          cd /server
+          grep -v '^\s*$' deps.txt | xargs -r go get
+          CGO_ENABLED=0 go build -o /runtime/server .
+      volumeMounts:
+        - name: runtime
+          mountPath: "/runtime"
+  volumes:
+    - name: runtime
+      emptyDir: {}
+`
+}
@@ -0,0 +1,3 @@
+module etlserver
+
+go 1.15
@@ -0,0 +1,58 @@
+// Package main is the server of the "go" ETL runtime (see etl/runtime/golang.go):
+// it streams the objects pushed by the targets (hpush://) through transform.Transform.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"etlserver/transform"
+)
+
+func main() {
+	addr := flag.String("l", ":80", "address to listen on")
+	flag.Parse()
+
+	http.HandleFunc("/health", healthHandler)
+	http.HandleFunc("/", transformHandler)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func healthHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("OK"))
+}
+
+// respWriter tracks whether the transform has written anything, so that an
+// error prior to any output can still be reported with the error status.
+type respWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *respWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func transformHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	rw := &respWriter{ResponseWriter: w}
+	if err := transform.Transform(r.Body, rw); err != nil {
+		if !rw.written {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// the status has already been sent - abort the response so that
+		// the target gets a truncated (failed) transfer rather than bad data
+		log.Printf("transform failed: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,53 @@
+// Package main is the server of the "go" ETL runtime (see etl/runtime/golang.go):
+// it streams the objects pushed by the targets (hpush://) through transform.Transform.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", healthHandler)
+	mux.HandleFunc("/", transformHandler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("health: expected %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	const obj = "line1\\nline2\n"
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(obj))
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(b) != obj {
+		t.Fatalf("transform: expected %d %q, got %d %q", http.StatusOK, obj, resp.StatusCode, b)
+	}
+
+	if resp, err = http.Get(srv.URL + "/obj"); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("GET: expected %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
@@ -0,0 +1,11 @@
+// Package transform is a placeholder of the user code: the ETL build replaces
+// this file with the code that defines `Transform`.
+package transform
+
+import "io"
+
+// Transform writes (transformed) object read from r to w.
+func Transform(r io.Reader, w io.Writer) error {
+	_, err := io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,137 @@
+// Server of the "rust" ETL runtime (see etl/runtime/rust.go): streams the objects
+// pushed by the targets (hpush://) through `transform::transform`.
+//
+// The server is deliberately dependency-free (HTTP/1.1, one request per
+// connection), so that the user dependencies never conflict with its own.
+mod transform;
+
+use std::env;
+use std::io::{self, BufRead, BufReader, BufWriter, Read, Write};
+use std::net::{TcpListener, TcpStream};
+use std::thread;
+
+fn main() {
+    let args: Vec<String> = env::args().collect();
+    let addr = match args.iter().position(|a| a == "-l") {
+        Some(i) if i + 1 < args.len() => args[i + 1].clone(),
+        _ => "0.0.0.0:80".to_string(),
+    };
+    let listener = TcpListener::bind(&addr).expect("failed to listen");
+    for stream in listener.incoming() {
+        match stream {
+            Ok(stream) => {
+                thread::spawn(move || {
+                    if let Err(e) = handle(stream) {
+                        eprintln!("request failed: {}", e);
+                    }
+                });
+            }
+            Err(e) => eprintln!("accept failed: {}", e),
+        }
+    }
+}
+
+struct Head {
+    method: String,
+    path: String,
+    content_length: u64,
+}
+
+fn read_head(r: &mut impl BufRead) -> io::Result<Head> {
+    let mut line = String::new();
+    r.read_line(&mut line)?;
+    let mut parts = line.split_whitespace();
+    let method = parts.next().unwrap_or("").to_string();
+    let path = parts.next().unwrap_or("").to_string();
+    let mut content_length = 0;
+    loop {
+        line.clear();
+        if r.read_line(&mut line)? == 0 {
+            break;
+        }
+        let hdr = line.trim_end();
+        if hdr.is_empty() {
+            break;
+        }
+        if let Some(idx) = hdr.find(':') {
+            if hdr[..idx].eq_ignore_ascii_case("content-length") {
+                content_length = hdr[idx + 1..].trim().parse().map_err(|_| {
+                    io::Error::new(io::ErrorKind::InvalidData, "invalid Content-Length")
+                })?;
+            }
+        }
+    }
+    Ok(Head {
+        method,
+        path,
+        content_length,
+    })
+}
+
+// Sends the response header upon the first write, so that an error prior to
+// any output can still be reported with the error status. The body is
+// delimited by closing the connection.
+struct Body<W: Write> {
+    w: W,
+    started: bool,
+}
+
+impl<W: Write> Body<W> {
+    fn start(&mut self) -> io::Result<()> {
+        if !self.started {
+            self.started = true;
+            self.w.write_all(
+                b"HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nConnection: close\r\n\r\n",
+            )?;
+        }
+        Ok(())
+    }
+}
+
+impl<W: Write> Write for Body<W> {
+    fn write(&mut self, buf: &[u8]) -> io::Result<usize> {
+        self.start()?;
+        self.w.write(buf)
+    }
+    fn flush(&mut self) -> io::Result<()> {
+        self.w.flush()
+    }
+}
+
+fn respond(w: &mut impl Write, status: &str, body: &str) -> io::Result<()> {
+    write!(
+        w,
+        "HTTP/1.1 {}\r\nContent-Length: {}\r\nConnection: close\r\n\r\n{}",
+        status,
+        body.len(),
+        body
+    )
+}
+
+fn handle(stream: TcpStream) -> io::Result<()> {
+    let mut w = stream.try_clone()?;
+    let mut r = BufReader::new(stream);
+    let head = read_head(&mut r)?;
+    match (head.method.as_str(), head.path.as_str()) {
+        ("GET", "/health") => respond(&mut w, "200 OK", "OK"),
+        ("PUT", _) | ("POST", _) => {
+            let mut obj = r.take(head.content_length);
+            let mut body = Body {
+                w: BufWriter::new(&mut w),
+                started: false,
+            };
+            match transform::transform(&mut obj, &mut body) {
+                Ok(()) => {
+                    body.start()?;
+                    body.flush()
+                }
+                Err(e) if !body.started => {
+                    drop(body);
+                    respond(&mut w, "500 Internal Server Error", &e.to_string())
+                }
+                Err(e) => Err(e), // truncated (failed) transfer
+            }
+        }
+        _ => respond(&mut w, "405 Method Not Allowed", ""),
+    }
+}
@@ -0,0 +1,8 @@
+// Placeholder of the user code: the ETL build replaces this file with the
+// code that defines `transform`.
+use std::io::{self, Read, Write};
+
+pub fn transform(r: &mut dyn Read, w: &mut dyn Write) -> io::Result<()> {
+    io::copy(r, w)?;
+    Ok(())
+}
@@ -0,0 +1,64 @@
+// Package runtime provides skeletons and static specifications for building ETL from scratch.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runtime
+
+type (
+	// rust implements Runtime for "rust".
+	//
+	// The code is a single module that defines
+	// `pub fn transform(r: &mut dyn Read, w: &mut dyn Write) -> io::Result<()>`;
+	// the dependencies (if any) are lines of the `[dependencies]` section of
+	// Cargo.toml (e.g. `md5 = "0.7"`). The builder image (see images/rust)
+	// compiles the code together with the server into a static binary that
+	// runs in a minimal container.
+	rust struct{}
+)
+
+func (r rust) Type() string        { return Rust }
+func (r rust) CodeEnvName() string { return "AISTORE_CODE" }
+func (r rust) DepsEnvName() string { return "AISTORE_DEPS" }
+func (r rust) PodSpec() string {
+	return `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: <NAME>
+spec:
+  containers:
+    - name: server
+      image: alpine:3.12
+      ports:
+        - name: default
+          containerPort: 80
+      command: ['/runtime/server', '-l', '0.0.0.0:80']
+      readinessProbe:
+        httpGet:
+          path: /health
+          port: default
+      volumeMounts:
+        - name: runtime
+          mountPath: "/runtime"
+  initContainers:
+    - name: server-build
+      image: aistore/runtime_rust:1.47
+      command:
+        - 'sh'
+        - '-c'
+        - |
+          set -e
+          printf '%s' "${AISTORE_CODE}" > /server/src/transform.rs
+          printf '%s\n' "${AISTORE_DEPS}" > /tmp/deps.toml
+          sed -i '/^\[dependencies\]/r /tmp/deps.toml' /server/Cargo.toml
+          cd /server
+          cargo build --release --target x86_64-unknown-linux-musl
+          cp target/x86_64-unknown-linux-musl/release/server /runtime/server
+      volumeMounts:
+        - name: runtime
+          mountPath: "/runtime"
+  volumes:
+    - name: runtime
+      emptyDir: {}
+`
+}
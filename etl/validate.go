@@ -0,0 +1,192 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/etl/runtime"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type (
+	// ValidateMsg is the body of the validation request (POST /v1/etl/validate) -
+	// either pod spec (as in `init`) or build message (as in `build`).
+	ValidateMsg struct {
+		Spec  []byte    `json:"spec,omitempty"`
+		Build *BuildMsg `json:"build,omitempty"`
+		// Additionally check that the container image can be pulled from its
+		// registry (pod spec only).
+		CheckImage bool `json:"check_image"`
+	}
+
+	// ValidationErr describes a single problem with ETL spec or build message.
+	ValidationErr struct {
+		Field  string `json:"field,omitempty"` // e.g. "spec.containers[0].ports"
+		Reason string `json:"reason"`
+	}
+	ValidationErrs []ValidationErr
+)
+
+func (e ValidationErr) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+	return e.Field + ": " + e.Reason
+}
+
+func (errs ValidationErrs) Error() string {
+	s := make([]string, 0, len(errs))
+	for _, e := range errs {
+		s = append(s, e.Error())
+	}
+	return strings.Join(s, "; ")
+}
+
+func (errs *ValidationErrs) add(field, format string, a ...interface{}) {
+	*errs = append(*errs, ValidationErr{Field: field, Reason: fmt.Sprintf(format, a...)})
+}
+
+// Validate lints the message and, optionally, checks the container image.
+// Returns all the problems found (empty if the message is valid).
+func Validate(msg *ValidateMsg) (errs ValidationErrs) {
+	switch {
+	case len(msg.Spec) > 0 && msg.Build != nil:
+		errs.add("", "pod spec and build message are mutually exclusive")
+	case len(msg.Spec) > 0:
+		pod, err := ParsePodSpec(nil, msg.Spec)
+		if err != nil {
+			errs.add("", err.Error())
+			return
+		}
+		if errs = lintPod(pod); len(errs) > 0 || !msg.CheckImage {
+			return
+		}
+		for i, container := range pod.Spec.Containers {
+			if err := checkImage(container.Image); err != nil {
+				if len(pod.Spec.ImagePullSecrets) > 0 && isAuthErr(err) {
+					continue // private registry - the cluster is expected to have the credentials
+				}
+				errs.add(fmt.Sprintf("spec.containers[%d].image", i), err.Error())
+			}
+		}
+	case msg.Build != nil:
+		errs = LintBuild(msg.Build)
+	default:
+		errs.add("", "either pod spec or build message must be provided")
+	}
+	return
+}
+
+// LintSpec validates pod spec without contacting the cluster.
+func LintSpec(spec []byte) ValidationErrs {
+	return Validate(&ValidateMsg{Spec: spec})
+}
+
+// LintBuild validates build message without contacting the cluster.
+func LintBuild(msg *BuildMsg) (errs ValidationErrs) {
+	if len(msg.Code) == 0 {
+		errs.add("code", "source code is empty")
+	}
+	if msg.Runtime == "" {
+		errs.add("runtime", "runtime is not specified")
+	} else if _, ok := runtime.Runtimes[msg.Runtime]; !ok {
+		errs.add("runtime", "unsupported runtime provided: %s", msg.Runtime)
+	}
+	if msg.WaitTimeout < 0 {
+		errs.add("wait_timeout", "must be non-negative, got: %v", msg.WaitTimeout)
+	}
+	return
+}
+
+// lintPod checks pod specification constraints and annotations
+// (see also `ValidateSpec`).
+func lintPod(pod *corev1.Pod) (errs ValidationErrs) {
+	if pod.GetName() == "" {
+		errs.add("metadata.name", "must be specified")
+	}
+	if pod.Annotations != nil {
+		if commType := pod.Annotations[commTypeAnnotation]; commType != "" {
+			if err := validateCommType(commType); err != nil {
+				errs.add("metadata.annotations."+commTypeAnnotation, err.Error())
+			}
+		}
+		if timeout := pod.Annotations[waitTimeoutAnnotation]; timeout != "" {
+			if v, err := time.ParseDuration(timeout); err != nil {
+				errs.add("metadata.annotations."+waitTimeoutAnnotation, err.Error())
+			} else if v < 0 {
+				errs.add("metadata.annotations."+waitTimeoutAnnotation, "must be non-negative, got: %v", v)
+			}
+		}
+	}
+
+	if len(pod.Spec.Containers) != 1 {
+		errs.add("spec.containers", "unsupported number of containers (%d), expected: 1", len(pod.Spec.Containers))
+		return
+	}
+	var (
+		container = pod.Spec.Containers[0]
+		field     = "spec.containers[0]"
+	)
+	if container.Image == "" {
+		errs.add(field+".image", "must be specified")
+	}
+	if len(container.Ports) != 1 {
+		errs.add(field+".ports", "unsupported number of container ports (%d), expected: 1", len(container.Ports))
+	} else if container.Ports[0].Name != k8s.Default {
+		errs.add(field+".ports[0].name", "expected port name: %q, got: %q", k8s.Default, container.Ports[0].Name)
+	}
+
+	// Validate that user container supports health check.
+	// Currently we need the `default` port (on which the application runs) to
+	// be same as the `readiness` probe port.
+	probe := container.ReadinessProbe
+	switch {
+	case probe == nil:
+		errs.add(field+".readinessProbe", "section is required in a container spec")
+	// TODO: Add support for other health checks.
+	case probe.HTTPGet == nil:
+		errs.add(field+".readinessProbe.httpGet", "missing in the readinessProbe")
+	default:
+		if probe.HTTPGet.Path == "" {
+			errs.add(field+".readinessProbe.httpGet.path", "expected non-empty path for readinessProbe")
+		}
+		if probe.HTTPGet.Port.StrVal != k8s.Default {
+			errs.add(field+".readinessProbe.httpGet.port", "readinessProbe port must be the %q port", k8s.Default)
+		}
+	}
+
+	// Resources: quantities must be non-negative and requests must not exceed limits.
+	for _, name := range resourceNames(container.Resources.Limits) {
+		if quantity := container.Resources.Limits[name]; quantity.Sign() < 0 {
+			errs.add(field+".resources.limits."+string(name), "must be non-negative, got: %s", quantity.String())
+		}
+	}
+	for _, name := range resourceNames(container.Resources.Requests) {
+		quantity := container.Resources.Requests[name]
+		if quantity.Sign() < 0 {
+			errs.add(field+".resources.requests."+string(name), "must be non-negative, got: %s", quantity.String())
+			continue
+		}
+		if limit, ok := container.Resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			errs.add(field+".resources.requests."+string(name), "request (%s) exceeds limit (%s)",
+				quantity.String(), limit.String())
+		}
+	}
+	return
+}
+
+func resourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,132 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/aistore/etl/runtime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const validSpec = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: echo
+  annotations:
+    communication_type: "hpush://"
+    wait_timeout: 2m
+spec:
+  containers:
+    - name: server
+      image: aistore/transformer_echo:latest
+      ports:
+        - name: default
+          containerPort: 80
+      resources:
+        requests:
+          cpu: 500m
+        limits:
+          cpu: "1"
+      readinessProbe:
+        httpGet:
+          path: /health
+          port: default
+`
+
+var _ = Describe("Validate", func() {
+	fields := func(errs ValidationErrs) []string {
+		names := make([]string, 0, len(errs))
+		for _, e := range errs {
+			names = append(names, e.Field)
+		}
+		return names
+	}
+
+	It("should accept valid spec", func() {
+		Expect(LintSpec([]byte(validSpec))).To(BeEmpty())
+	})
+
+	It("should report all the problems at once", func() {
+		spec := strings.NewReplacer(
+			`"hpush://"`, `"push"`,
+			"wait_timeout: 2m", "wait_timeout: 2x",
+			"name: default", "name: http",
+			"cpu: 500m", "cpu: 2",
+			"path: /health", `path: ""`,
+		).Replace(validSpec)
+		Expect(fields(LintSpec([]byte(spec)))).To(ConsistOf(
+			"metadata.annotations.communication_type",
+			"metadata.annotations.wait_timeout",
+			"spec.containers[0].ports[0].name",
+			"spec.containers[0].resources.requests.cpu",
+			"spec.containers[0].readinessProbe.httpGet.path",
+		))
+	})
+
+	It("should fail to parse malformed spec", func() {
+		errs := LintSpec([]byte("kind: Pod\nspec: ["))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(BeEmpty())
+	})
+
+	It("should lint build message", func() {
+		errs := LintBuild(&BuildMsg{Runtime: "python1", WaitTimeout: -1})
+		Expect(fields(errs)).To(ConsistOf("code", "runtime", "wait_timeout"))
+		Expect(LintBuild(&BuildMsg{Code: []byte("def transform(): pass"), Runtime: "python3"})).To(BeEmpty())
+	})
+
+	It("should provide valid pod specs for all runtimes", func() {
+		for name, r := range runtime.Runtimes {
+			spec := strings.ReplaceAll(r.PodSpec(), "<NAME>", "etl-"+name)
+			Expect(LintSpec([]byte(spec))).To(BeEmpty(), name)
+		}
+	})
+
+	It("should require either spec or build message", func() {
+		Expect(Validate(&ValidateMsg{})).To(HaveLen(1))
+		Expect(Validate(&ValidateMsg{Spec: []byte(validSpec), Build: &BuildMsg{}})).To(HaveLen(1))
+	})
+
+	DescribeTable("parseImage",
+		func(image, registry, repo, ref string) {
+			r, n, t, err := parseImage(image)
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]string{r, n, t}).To(Equal([]string{registry, repo, ref}))
+		},
+		Entry("official", "python", dockerHubRegistry, "library/python", "latest"),
+		Entry("official with tag", "python:3.8", dockerHubRegistry, "library/python", "3.8"),
+		Entry("user", "aistore/transformer_md5:v1", dockerHubRegistry, "aistore/transformer_md5", "v1"),
+		Entry("registry with port", "localhost:5000/md5", "localhost:5000", "md5", "latest"),
+		Entry("registry", "gcr.io/project/img:tag", "gcr.io", "project/img", "tag"),
+		Entry("digest", "quay.io/img@sha256:abc", "quay.io", "img", "sha256:abc"),
+	)
+
+	It("should not check images from registries that are not allowlisted", func() {
+		// (no requests are made - otherwise, these would fail)
+		Expect(checkImage("localhost:5000/md5")).To(Succeed())
+		Expect(checkImage("10.0.0.1/md5:v1")).To(Succeed())
+	})
+
+	DescribeTable("checkRealm",
+		func(registry, realm string, allowed bool) {
+			err := checkRealm(registry, realm)
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("docker hub auth", dockerHubRegistry, "https://auth.docker.io/token", true),
+		Entry("registry itself", "ghcr.io", "https://ghcr.io/token", true),
+		Entry("other registry's auth", "ghcr.io", "https://auth.docker.io/token", false),
+		Entry("internal host", dockerHubRegistry, "https://10.0.0.1:8080/token", false),
+		Entry("plain http", dockerHubRegistry, "http://auth.docker.io/token", false),
+		Entry("userinfo", "quay.io", "https://user@quay.io/v2/auth", false),
+	)
+})
@@ -0,0 +1,188 @@
+// Package evsink publishes object lifecycle events (PUT, DELETE, cold GET) to external
+// event sinks (Kafka, NATS) configured on a per-bucket basis.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package evsink
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Delivery is at-least-once: each event is first appended to the sink's local
+// spool (a file under `DirName`) and is removed from it only after the sink
+// acknowledges it. Sink failures are retried with exponential backoff; spool
+// is bounded by `maxSpoolSize` - once it is full new events are dropped (and
+// logged). Spools survive restarts: undelivered events get delivered once the
+// bucket with the same sink configuration generates a new event.
+
+// event ops
+const (
+	OpPut     = "put"
+	OpDelete  = "delete"
+	OpColdGet = "cold-get"
+)
+
+const (
+	DirName = "evsink" // relative to config.Confdir
+
+	maxSpoolSize = 64 * cmn.MiB
+	maxBatchSize = 256
+	minBackoff   = time.Second
+	maxBackoff   = 30 * time.Second
+	dropLogEvery = 1000
+)
+
+type (
+	Event struct {
+		Op      string  `json:"op"`
+		Bck     cmn.Bck `json:"bucket"`
+		ObjName string  `json:"name"`
+		Size    int64   `json:"size"`
+		Version string  `json:"version,omitempty"`
+		Time    int64   `json:"time,string"` // Unix time (nanoseconds)
+		Target  string  `json:"target"`      // ID of the target that generated the event
+	}
+
+	// publishes a batch of events (JSON-encoded); must either deliver all of them or fail
+	publisher interface {
+		publish(batch [][]byte) error
+	}
+
+	Manager struct {
+		mu      sync.Mutex
+		dir     string
+		sinks   map[string]*sink
+		dropped atomic.Int64
+	}
+
+	sink struct {
+		mu     sync.Mutex
+		name   string
+		spool  *spool
+		pub    publisher
+		kickCh chan struct{}
+	}
+)
+
+func NewEvent(op string, lom *cluster.LOM, tid string) *Event {
+	return &Event{
+		Op:      op,
+		Bck:     lom.Bck().Bck,
+		ObjName: lom.ObjName,
+		Size:    lom.Size(),
+		Version: lom.Version(),
+		Time:    time.Now().UnixNano(),
+		Target:  tid,
+	}
+}
+
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir, sinks: make(map[string]*sink)}
+}
+
+// Publish spools the event and (asynchronously) delivers it to the sink.
+func (m *Manager) Publish(conf *cmn.EventSinkConf, ev *Event) {
+	s, err := m.sink(conf)
+	if err != nil {
+		glog.Errorf("event sink %s: %v", conf, err)
+		return
+	}
+	if err := s.append(cmn.MustMarshal(ev)); err != nil {
+		if cnt := m.dropped.Inc(); cnt%dropLogEvery == 1 {
+			glog.Errorf("event sink %s: dropping %s event for %s/%s (total dropped: %d): %v",
+				conf, ev.Op, ev.Bck, ev.ObjName, cnt, err)
+		}
+		return
+	}
+	select {
+	case s.kickCh <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) sink(conf *cmn.EventSinkConf) (*sink, error) {
+	key := conf.String()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sinks[key]; ok {
+		return s, nil
+	}
+	var (
+		pub   publisher
+		fname = strconv.FormatUint(xxhash.ChecksumString64S(key, cmn.MLCG32), 16)
+	)
+	switch conf.Type {
+	case cmn.EventSinkKafka:
+		pub = newKafka(conf.URL, conf.Topic)
+	case cmn.EventSinkNATS:
+		pub = newNATS(conf.URL, conf.Topic)
+	default:
+		return nil, fmt.Errorf("unknown type %q", conf.Type)
+	}
+	sp, err := openSpool(filepath.Join(m.dir, fname), maxSpoolSize)
+	if err != nil {
+		return nil, err
+	}
+	s := &sink{name: key, spool: sp, pub: pub, kickCh: make(chan struct{}, 1)}
+	m.sinks[key] = s
+	go s.run()
+	return s, nil
+}
+
+//////////
+// sink //
+//////////
+
+func (s *sink) append(b []byte) error {
+	s.mu.Lock()
+	err := s.spool.append(b)
+	s.mu.Unlock()
+	return err
+}
+
+// delivers the events spooled prior to restart (if any) and then waits for new ones
+func (s *sink) run() {
+	backoff := minBackoff
+	for {
+		if err := s.deliver(); err != nil {
+			glog.Errorf("event sink %s: %v (retrying in %v)", s.name, err, backoff)
+			time.Sleep(backoff)
+			backoff = cmn.MinDuration(2*backoff, maxBackoff)
+			continue
+		}
+		backoff = minBackoff
+		<-s.kickCh
+	}
+}
+
+// delivers everything spooled so far
+func (s *sink) deliver() error {
+	for {
+		s.mu.Lock()
+		batch, end, err := s.spool.next(maxBatchSize)
+		s.mu.Unlock()
+		if err != nil || len(batch) == 0 {
+			return err
+		}
+		if err := s.pub.publish(batch); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		err = s.spool.commit(end)
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
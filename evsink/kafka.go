@@ -0,0 +1,87 @@
+// Package evsink publishes object lifecycle events (PUT, DELETE, cold GET) to external
+// event sinks (Kafka, NATS) configured on a per-bucket basis.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package evsink
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Kafka events are produced via Kafka REST Proxy (v2 API) - see
+// https://docs.confluent.io/current/kafka-rest/api.html#post--topics-(string-topic_name)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaTimeout     = 30 * time.Second
+)
+
+type (
+	kafka struct {
+		url    string // POST URL: <REST proxy>/topics/<topic>
+		client *http.Client
+	}
+	kafkaRecord struct {
+		Value jsoniter.RawMessage `json:"value"`
+	}
+	kafkaRequest struct {
+		Records []kafkaRecord `json:"records"`
+	}
+	kafkaResponse struct {
+		Offsets []struct {
+			Partition int     `json:"partition"`
+			ErrorCode *int    `json:"error_code"`
+			Error     *string `json:"error"`
+		} `json:"offsets"`
+	}
+)
+
+func newKafka(url, topic string) *kafka {
+	return &kafka{
+		url:    strings.TrimSuffix(url, "/") + "/topics/" + topic,
+		client: cmn.NewClient(cmn.TransportArgs{Timeout: kafkaTimeout}),
+	}
+}
+
+func (k *kafka) publish(batch [][]byte) error {
+	req := kafkaRequest{Records: make([]kafkaRecord, 0, len(batch))}
+	for _, b := range batch {
+		req.Records = append(req.Records, kafkaRecord{Value: b})
+	}
+	resp, err := k.client.Post(k.url, kafkaContentType, bytes.NewReader(cmn.MustMarshal(req)))
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka: POST %s: %s (%s)", k.url, resp.Status, bytes.TrimSpace(body))
+	}
+	kresp := &kafkaResponse{}
+	if err := jsoniter.Unmarshal(body, kresp); err != nil {
+		return fmt.Errorf("kafka: invalid response %q: %v", body, err)
+	}
+	// partial failure: the entire batch gets resent
+	for _, off := range kresp.Offsets {
+		if off.ErrorCode != nil {
+			msg := ""
+			if off.Error != nil {
+				msg = *off.Error
+			}
+			return fmt.Errorf("kafka: partition %d: error %d %s", off.Partition, *off.ErrorCode, msg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package evsink publishes object lifecycle events (PUT, DELETE, cold GET) to external
+// event sinks (Kafka, NATS) configured on a per-bucket basis.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package evsink
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// NATS events are published using the (plain-text) NATS client protocol - see
+// https://docs.nats.io/nats-protocol/nats-protocol. Each batch is followed by
+// PING: the server's PONG confirms that all preceding messages were processed.
+
+const (
+	natsTimeout = 30 * time.Second
+	natsConnect = "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"aistore\"}\r\n"
+)
+
+type nats struct {
+	addr    string
+	subject string
+	conn    net.Conn
+	rd      *bufio.Reader
+}
+
+func newNATS(url, subject string) *nats {
+	return &nats{addr: strings.TrimPrefix(url, "nats://"), subject: subject}
+}
+
+func (n *nats) publish(batch [][]byte) (err error) {
+	if n.conn == nil {
+		if err = n.connect(); err != nil {
+			return
+		}
+	}
+	defer func() {
+		if err != nil {
+			n.conn.Close()
+			n.conn = nil
+		}
+	}()
+	buf := &bytes.Buffer{}
+	for _, b := range batch {
+		fmt.Fprintf(buf, "PUB %s %d\r\n", n.subject, len(b))
+		buf.Write(b)
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("PING\r\n")
+	n.conn.SetDeadline(time.Now().Add(natsTimeout))
+	if _, err = n.conn.Write(buf.Bytes()); err != nil {
+		return
+	}
+	for {
+		var line string
+		if line, err = n.readLine(); err != nil {
+			return
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err = n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats %s: %s", n.addr, line)
+		}
+	}
+}
+
+func (n *nats) connect() error {
+	conn, err := net.DialTimeout("tcp", n.addr, natsTimeout)
+	if err != nil {
+		return err
+	}
+	n.conn, n.rd = conn, bufio.NewReader(conn)
+	conn.SetDeadline(time.Now().Add(natsTimeout))
+	line, err := n.readLine()
+	if err == nil && !strings.HasPrefix(line, "INFO") {
+		err = errors.New("unexpected greeting: " + line)
+	}
+	if err == nil {
+		_, err = conn.Write([]byte(natsConnect))
+	}
+	if err != nil {
+		conn.Close()
+		n.conn = nil
+		return fmt.Errorf("nats %s: %v", n.addr, err)
+	}
+	return nil
+}
+
+func (n *nats) readLine() (string, error) {
+	line, err := n.rd.ReadString('\n')
+	return strings.TrimSpace(line), err
+}
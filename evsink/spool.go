@@ -0,0 +1,170 @@
+// Package evsink publishes object lifecycle events (PUT, DELETE, cold GET) to external
+// event sinks (Kafka, NATS) configured on a per-bucket basis.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package evsink
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// spool is an append-only file of newline-separated (JSON) events along with
+// the offset of the first undelivered one; the latter is persisted separately
+// (`.off` file). Once all events are delivered both files are truncated.
+// Otherwise, when the delivered events take up a significant part of the spool,
+// the spool gets compacted: the undelivered events are copied into a new file
+// that then replaces the spool.
+
+const (
+	offSuffix = ".off"
+	tmpSuffix = ".tmp"
+)
+
+var errSpoolFull = errors.New("spool is full")
+
+type spool struct {
+	fqn  string
+	file *os.File // append-only
+	off  int64    // first undelivered
+	size int64
+	max  int64
+}
+
+func openSpool(fqn string, max int64) (*spool, error) {
+	if err := cmn.CreateDir(filepath.Dir(fqn)); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	s := &spool{fqn: fqn, file: file, size: finfo.Size(), max: max}
+	if b, err := ioutil.ReadFile(fqn + offSuffix); err == nil {
+		if off, err := strconv.ParseInt(string(b), 10, 64); err == nil && off <= s.size {
+			s.off = off
+		}
+	}
+	return s, nil
+}
+
+func (s *spool) append(b []byte) error {
+	if s.size+int64(len(b))+1 > s.max {
+		if s.off == 0 {
+			return errSpoolFull
+		}
+		if err := s.compact(); err != nil {
+			return err
+		}
+		if s.size+int64(len(b))+1 > s.max {
+			return errSpoolFull
+		}
+	}
+	n, err := s.file.Write(append(b, '\n'))
+	s.size += int64(n)
+	return err
+}
+
+// returns up to `max` undelivered events and the offset to commit upon their delivery
+func (s *spool) next(max int) (batch [][]byte, end int64, err error) {
+	if s.off >= s.size {
+		return nil, s.off, nil
+	}
+	file, err := os.Open(s.fqn)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+	var (
+		r  = bufio.NewReader(io.NewSectionReader(file, s.off, s.size-s.off))
+		ln []byte
+	)
+	end = s.off
+	for len(batch) < max {
+		if ln, err = r.ReadBytes('\n'); err != nil {
+			if err == io.EOF {
+				err = nil // incomplete last line (if any) is not ours yet
+			}
+			break
+		}
+		end += int64(len(ln))
+		if ln = bytes.TrimSpace(ln); len(ln) > 0 {
+			batch = append(batch, ln)
+		}
+	}
+	return
+}
+
+func (s *spool) commit(end int64) error {
+	s.off = end
+	if s.off >= s.size {
+		// all delivered - start over
+		if err := s.file.Truncate(0); err != nil {
+			return err
+		}
+		s.off, s.size = 0, 0
+		if err := os.Remove(s.fqn + offSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if s.off >= s.max/2 {
+		return s.compact()
+	}
+	return ioutil.WriteFile(s.fqn+offSuffix, []byte(strconv.FormatInt(s.off, 10)), 0o644)
+}
+
+// copies the undelivered events into a new file that replaces the spool;
+// a crash in the middle results in redelivery (never in a loss) of the events
+func (s *spool) compact() (err error) {
+	var (
+		src, dst *os.File
+		tmp      = s.fqn + tmpSuffix
+	)
+	if src, err = os.Open(s.fqn); err != nil {
+		return
+	}
+	defer src.Close()
+	if dst, err = os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644); err != nil {
+		return
+	}
+	n, err := io.Copy(dst, io.NewSectionReader(src, s.off, s.size-s.off))
+	if err == nil {
+		err = dst.Sync()
+	}
+	if errClose := dst.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return
+	}
+	// first, the offset (that would otherwise apply to the new file upon crash)
+	if err = os.Remove(s.fqn + offSuffix); err != nil && !os.IsNotExist(err) {
+		os.Remove(tmp)
+		return
+	}
+	if err = os.Rename(tmp, s.fqn); err != nil {
+		return
+	}
+	s.file.Close()
+	if s.file, err = os.OpenFile(s.fqn, os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return
+	}
+	s.off, s.size = 0, n
+	return nil
+}
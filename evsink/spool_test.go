@@ -0,0 +1,90 @@
+// Package evsink publishes object lifecycle events (PUT, DELETE, cold GET) to external
+// event sinks (Kafka, NATS) configured on a per-bucket basis.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package evsink
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "evsink")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	fqn := filepath.Join(dir, "spool")
+	s, err := openSpool(fqn, 40)
+	tassert.CheckFatal(t, err)
+	for _, ev := range []string{`{"a":1}`, `{"b":2}`, `{"c":3}`} {
+		tassert.CheckFatal(t, s.append([]byte(ev)))
+	}
+
+	batch, end, err := s.next(2)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(batch) == 2 && string(batch[1]) == `{"b":2}`, "unexpected batch %q", batch)
+	tassert.CheckFatal(t, s.commit(end))
+
+	// reopen (as if after restart): the first two events must not be redelivered
+	s.file.Close()
+	s, err = openSpool(fqn, 40)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, s.append([]byte(`{"d":4}`)))
+	// full, unless compacted (the delivered events are dropped)
+	tassert.CheckFatal(t, s.append([]byte(`{"e":5}`)))
+	tassert.CheckFatal(t, s.append([]byte(`{"f":6}`)))
+	tassert.CheckFatal(t, s.append([]byte(`{"g":7}`)))
+	err = s.append([]byte(`{"h":8}`))
+	tassert.Errorf(t, err == errSpoolFull, "expected spool to be full, got %v", err)
+
+	batch, end, err = s.next(10)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(batch) == 5 && string(batch[0]) == `{"c":3}`, "unexpected batch %q", batch)
+	tassert.CheckFatal(t, s.commit(end))
+	tassert.Errorf(t, s.size == 0 && s.off == 0, "expected empty spool, got size=%d, off=%d", s.size, s.off)
+
+	batch, _, err = s.next(10)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(batch) == 0, "unexpected batch %q", batch)
+	s.file.Close()
+}
+
+func TestSpoolCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "evsink")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	const ev = `{"n":0}` // 8 bytes with the newline
+	fqn := filepath.Join(dir, "spool")
+	s, err := openSpool(fqn, 40)
+	tassert.CheckFatal(t, err)
+
+	// keep delivering one of the two newly spooled events - the spool never
+	// drains but must not fill up either
+	tassert.CheckFatal(t, s.append([]byte(ev)))
+	for i := 0; i < 20; i++ {
+		tassert.CheckFatal(t, s.append([]byte(ev)))
+		batch, end, err := s.next(1)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(batch) == 1, "unexpected batch %q", batch)
+		tassert.CheckFatal(t, s.commit(end))
+		tassert.Fatalf(t, s.size <= s.max, "spool exceeds its bound: %d > %d", s.size, s.max)
+	}
+
+	// reopen: only the undelivered events remain
+	undelivered := s.size - s.off
+	s.file.Close()
+	s, err = openSpool(fqn, 40)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, s.size-s.off == undelivered, "expected %d undelivered bytes, got %d", undelivered, s.size-s.off)
+	finfo, err := os.Stat(fqn)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, finfo.Size() == s.size, "expected spool file of %d bytes, got %d", s.size, finfo.Size())
+	s.file.Close()
+}
@@ -0,0 +1,218 @@
+// Package export packs buckets into WebDataset-compatible tar shards.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package export
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Export packs the objects of a bucket (or a prefix) into tar shards laid out
+// as per WebDataset (https://github.com/webdataset/webdataset):
+//   * objects are grouped into samples by key - the object name up to the first
+//     dot of its basename (e.g., "train/0001.jpg" and "train/0001.cls" is sample
+//     "train/0001"), and the members of a sample are always stored contiguously
+//     in the same shard;
+//   * samples are packed, in the listing (name) order, into shards of (approximately)
+//     ExportMsg.ShardSize bytes: a shard is closed once its size reaches the limit.
+//
+// Each shard "<shard_prefix>NNNNNN.tar" comes with a JSON index sidecar
+// "<shard_prefix>NNNNNN.json" (see Index) that lists the shard's members with
+// their offsets, so that consumers can read any member with a single range read.
+//
+// The proxy pages through the source listing and makes the plan (see Planner)
+// as it goes, sending the shards to the targets in batches (see Job); each
+// shard is built by its (HRW) target in the destination bucket, which reads
+// the shard's members from wherever they are stored in the cluster.
+
+const (
+	DefaultShardSize   = cmn.GiB
+	DefaultShardPrefix = "shard-"
+
+	shardExt = ".tar"
+	indexExt = ".json"
+)
+
+type (
+	// Member is a source object to be packed
+	Member struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+	// Shard is a planned output shard
+	Shard struct {
+		Name    string    `json:"name"`
+		Members []*Member `json:"members"`
+		Samples int       `json:"samples"`
+	}
+	// Job is a batch of the shards (the part of the plan) executed by a given
+	// target: the first batch starts the target's export (Xaction), the
+	// subsequent ones (Cont) get added to it, and the Last one ends it.
+	Job struct {
+		UUID   string   `json:"uuid"`
+		BckTo  cmn.Bck  `json:"bck_to"`
+		Shards []*Shard `json:"shards"`
+		Cont   bool     `json:"cont,omitempty"`
+		Last   bool     `json:"last,omitempty"`
+	}
+
+	// Index is the JSON sidecar of a shard
+	Index struct {
+		Shard   string        `json:"shard"`
+		Size    int64         `json:"size"`
+		Samples int           `json:"samples"`
+		Members []*IndexEntry `json:"members"`
+	}
+	// IndexEntry locates a member's content within the shard
+	IndexEntry struct {
+		Name   string `json:"name"`
+		Key    string `json:"key"`
+		Offset int64  `json:"offset"` // of the content (i.e., past the tar header)
+		Size   int64  `json:"size"`
+	}
+
+	// OpenFunc opens a member for reading
+	OpenFunc func(objName string) (io.ReadCloser, error)
+)
+
+// SampleKey returns the WebDataset key of a given object name.
+func SampleKey(objName string) string {
+	dir, base := path.Split(objName)
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+	return dir + base
+}
+
+// IndexName returns the name of the index sidecar of a given shard.
+func IndexName(shardName string) string {
+	return strings.TrimSuffix(shardName, shardExt) + indexExt
+}
+
+// Validate fills in the defaults.
+func Validate(msg *cmn.ExportMsg) error {
+	if msg.ShardSize < 0 {
+		return fmt.Errorf("invalid shard size %d", msg.ShardSize)
+	}
+	if msg.ShardSize == 0 {
+		msg.ShardSize = DefaultShardSize
+	}
+	if msg.ShardPrefix == "" {
+		msg.ShardPrefix = DefaultShardPrefix
+	}
+	return nil
+}
+
+// Planner groups the listed objects into samples and packs the samples into
+// shards - incrementally, page by page, as the objects get listed. The objects
+// must be added in the listing (name) order, in which the members of a sample
+// are adjacent (the only exception being a virtual directory named after a
+// sample's member, e.g. "a.jpg/b" between "a.jpg" and "a.txt").
+type Planner struct {
+	msg    *cmn.ExportMsg
+	shard  *Shard // the one being packed
+	size   int64
+	key    string
+	shards int
+}
+
+func NewPlanner(msg *cmn.ExportMsg) *Planner { return &Planner{msg: msg} }
+
+// Add adds the next object and returns the previous shard once the latter is
+// complete (that is, when the object starts a new sample and a new shard).
+func (pl *Planner) Add(name string, size int64) (full *Shard) {
+	k := SampleKey(name)
+	if pl.shard == nil || k != pl.key { // new sample
+		if pl.shard == nil || pl.size >= pl.msg.ShardSize {
+			full = pl.shard
+			pl.shard = &Shard{Name: fmt.Sprintf("%s%06d%s", pl.msg.ShardPrefix, pl.shards, shardExt)}
+			pl.shards++
+			pl.size = 0
+		}
+		pl.shard.Samples++
+		pl.key = k
+	}
+	pl.shard.Members = append(pl.shard.Members, &Member{Name: name, Size: size})
+	pl.size += size
+	return
+}
+
+// Flush returns the last (incomplete) shard, if any.
+func (pl *Planner) Flush() (last *Shard) {
+	last, pl.shard = pl.shard, nil
+	return
+}
+
+// MakePlan is Planner for the entire listing at once.
+func MakePlan(entries []*cmn.BucketEntry, msg *cmn.ExportMsg) (shards []*Shard) {
+	sorted := make([]*cmn.BucketEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	pl := NewPlanner(msg)
+	for _, e := range sorted {
+		if shard := pl.Add(e.Name, e.Size); shard != nil {
+			shards = append(shards, shard)
+		}
+	}
+	if shard := pl.Flush(); shard != nil {
+		shards = append(shards, shard)
+	}
+	return
+}
+
+// WriteShard writes the shard's members in the tar format and returns the index.
+func WriteShard(w io.Writer, shard *Shard, open OpenFunc) (*Index, error) {
+	var (
+		cw  = &countingWriter{w: w}
+		tw  = tar.NewWriter(cw)
+		idx = &Index{Shard: shard.Name, Samples: shard.Samples, Members: make([]*IndexEntry, 0, len(shard.Members))}
+	)
+	for _, m := range shard.Members {
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: m.Name, Size: m.Size, Mode: 0o644, Format: tar.FormatPAX}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		// (the header is written out as is - no buffering)
+		idx.Members = append(idx.Members, &IndexEntry{Name: m.Name, Key: SampleKey(m.Name), Offset: cw.n, Size: m.Size})
+		if err := copyMember(tw, m, open); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	idx.Size = cw.n
+	return idx, nil
+}
+
+func copyMember(w io.Writer, m *Member, open OpenFunc) error {
+	r, err := open(m.Name)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(w, r)
+	cmn.Close(r)
+	if err == nil && n != m.Size {
+		err = fmt.Errorf("%s: size changed while exporting (%d != %d)", m.Name, n, m.Size)
+	}
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(b []byte) (n int, err error) {
+	n, err = cw.w.Write(b)
+	cw.n += int64(n)
+	return
+}
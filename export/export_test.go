@@ -0,0 +1,156 @@
+// Package export packs buckets into WebDataset-compatible tar shards.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestSampleKey(t *testing.T) {
+	tests := map[string]string{
+		"0001.jpg":             "0001",
+		"train/0001.jpg":       "train/0001",
+		"train/0001.seg.png":   "train/0001",
+		"train.v2/0001":        "train.v2/0001",
+		"a/b/c/sample-000.cls": "a/b/c/sample-000",
+	}
+	for name, key := range tests {
+		tassert.Errorf(t, SampleKey(name) == key, "%q: expected %q, got %q", name, key, SampleKey(name))
+	}
+}
+
+func TestMakePlan(t *testing.T) {
+	entries := []*cmn.BucketEntry{
+		{Name: "b.jpg", Size: 60}, {Name: "a.cls", Size: 10}, {Name: "a.jpg", Size: 50},
+		{Name: "a-1.jpg", Size: 10}, {Name: "b.cls", Size: 10}, {Name: "c.jpg", Size: 10},
+	}
+	msg := &cmn.ExportMsg{ShardSize: 70}
+	tassert.CheckFatal(t, Validate(msg))
+	shards := MakePlan(entries, msg)
+
+	// samples (in the name order): "a-1" (10), "a" (60), "b" (70), "c" (10);
+	// shards are closed once reaching 70
+	expected := [][]string{{"a-1.jpg", "a.cls", "a.jpg"}, {"b.cls", "b.jpg"}, {"c.jpg"}}
+	tassert.Fatalf(t, len(shards) == len(expected), "expected %d shards, got %d", len(expected), len(shards))
+	for i, shard := range shards {
+		names := make([]string, 0, len(shard.Members))
+		for _, m := range shard.Members {
+			names = append(names, m.Name)
+		}
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected[i], ","),
+			"shard %d: expected %v, got %v", i, expected[i], names)
+	}
+	tassert.Errorf(t, shards[0].Name == "shard-000000.tar" && shards[2].Name == "shard-000002.tar",
+		"unexpected shard names %q, %q", shards[0].Name, shards[2].Name)
+	tassert.Errorf(t, shards[0].Samples == 2 && shards[1].Samples == 1, "unexpected number of samples")
+	tassert.Errorf(t, IndexName(shards[1].Name) == "shard-000001.json", "unexpected index name")
+
+	// a sample is never split across shards
+	shards = MakePlan(entries, &cmn.ExportMsg{ShardSize: 1, ShardPrefix: "wds-"})
+	tassert.Errorf(t, len(shards) == 4 && shards[0].Name == "wds-000000.tar", "expected 4 single-sample shards")
+	tassert.Errorf(t, len(MakePlan(nil, msg)) == 0, "expected no shards")
+
+	tassert.Errorf(t, Validate(&cmn.ExportMsg{ShardSize: -1}) != nil, "expected invalid shard size")
+}
+
+func TestPlanner(t *testing.T) {
+	var (
+		entries []*cmn.BucketEntry
+		msg     = &cmn.ExportMsg{ShardSize: 100}
+	)
+	tassert.CheckFatal(t, Validate(msg))
+	for i := 0; i < 100; i++ {
+		for _, ext := range []string{".cls", ".jpg", ".txt"} {
+			entries = append(entries, &cmn.BucketEntry{Name: fmt.Sprintf("train/%04d%s", i, ext), Size: int64(i%7 + 1)})
+		}
+	}
+	expected := MakePlan(entries, msg)
+
+	// page by page, with the pages splitting the samples
+	var (
+		shards []*Shard
+		pl     = NewPlanner(msg)
+	)
+	for page := 0; page*16 < len(entries); page++ {
+		for _, e := range entries[page*16 : cmn.Min((page+1)*16, len(entries))] {
+			if shard := pl.Add(e.Name, e.Size); shard != nil {
+				shards = append(shards, shard)
+			}
+		}
+	}
+	if shard := pl.Flush(); shard != nil {
+		shards = append(shards, shard)
+	}
+	tassert.Fatalf(t, len(shards) == len(expected) && len(shards) > 1, "expected %d shards, got %d",
+		len(expected), len(shards))
+	var samples int
+	for i, shard := range shards {
+		tassert.Errorf(t, shard.Name == expected[i].Name && len(shard.Members) == len(expected[i].Members),
+			"shard %d: expected %s(%d), got %s(%d)", i, expected[i].Name, len(expected[i].Members),
+			shard.Name, len(shard.Members))
+		tassert.Errorf(t, len(shard.Members) == 3*shard.Samples, "shard %d: sample split across shards", i)
+		samples += shard.Samples
+	}
+	tassert.Errorf(t, samples == 100, "expected 100 samples, got %d", samples)
+	tassert.Errorf(t, pl.Flush() == nil, "expected nothing to flush")
+}
+
+func TestWriteShard(t *testing.T) {
+	objs := map[string]string{
+		"train/0001.jpg": "jpeg-0001",
+		"train/0001.cls": "7",
+		"train/0002.jpg": strings.Repeat("x", 1000),
+		"train/0002.cls": "3",
+	}
+	entries := make([]*cmn.BucketEntry, 0, len(objs))
+	for name, data := range objs {
+		entries = append(entries, &cmn.BucketEntry{Name: name, Size: int64(len(data))})
+	}
+	msg := &cmn.ExportMsg{}
+	tassert.CheckFatal(t, Validate(msg))
+	shards := MakePlan(entries, msg)
+	tassert.Fatalf(t, len(shards) == 1, "expected a single shard, got %d", len(shards))
+
+	open := func(objName string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(objs[objName])), nil
+	}
+	buf := &bytes.Buffer{}
+	idx, err := WriteShard(buf, shards[0], open)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, idx.Size == int64(buf.Len()), "index size %d != shard size %d", idx.Size, buf.Len())
+	tassert.Errorf(t, idx.Samples == 2 && len(idx.Members) == len(objs), "unexpected index %+v", idx)
+
+	// member offsets point to the members' content
+	b := buf.Bytes()
+	for _, e := range idx.Members {
+		data := string(b[e.Offset : e.Offset+e.Size])
+		tassert.Errorf(t, data == objs[e.Name], "%s: wrong content at offset %d", e.Name, e.Offset)
+	}
+
+	// the shard is a valid tar with samples' members stored contiguously
+	tr := tar.NewReader(bytes.NewReader(b))
+	for i := 0; ; i++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, hdr.Name == idx.Members[i].Name, "expected %q, got %q", idx.Members[i].Name, hdr.Name)
+	}
+
+	// a member that changed in the meantime fails the shard
+	objs["train/0001.cls"] = "77"
+	_, err = WriteShard(ioutil.Discard, shards[0], open)
+	tassert.Errorf(t, err != nil, "expected size mismatch error")
+}
@@ -0,0 +1,212 @@
+// Package export packs buckets into WebDataset-compatible tar shards.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+type (
+	// Storage is implemented by the target: it reads and writes objects
+	// wherever they are (HRW) stored in the cluster.
+	Storage interface {
+		OpenObj(bck cmn.Bck, objName string) (io.ReadCloser, error)
+		// size is -1 when not known in advance
+		PutObj(bck cmn.Bck, objName string, r io.ReadCloser, size int64) error
+	}
+	Args struct {
+		Job     *Job
+		Storage Storage
+	}
+
+	xactProvider struct {
+		registry.BaseBckEntry
+		xact *Xaction
+
+		t    cluster.Target
+		uuid string
+		args *Args
+	}
+	// Xaction builds the shards (and their indexes) assigned to this target,
+	// batch by batch (see Job).
+	Xaction struct {
+		xaction.XactBase
+		t    cluster.Target
+		bck  cmn.Bck // source
+		args *Args
+
+		mu      sync.Mutex
+		pending []*Shard
+		last    bool // received the last batch
+		wakeCh  chan struct{}
+	}
+	shardResult struct {
+		idx *Index
+		err error
+	}
+)
+
+// max time to wait for the next batch (the proxy lists the next page meanwhile)
+const batchTimeout = 10 * time.Minute
+
+func init() {
+	registry.Registry.RegisterBucketXact(&xactProvider{})
+}
+
+func (*xactProvider) New(args registry.XactArgs) registry.BucketEntry {
+	return &xactProvider{t: args.T, uuid: args.UUID, args: args.Custom.(*Args)}
+}
+
+func (p *xactProvider) Start(bck cmn.Bck) error {
+	p.xact = &Xaction{
+		XactBase: *xaction.NewXactBaseBck(p.uuid, cmn.ActExportBck, bck),
+		t:        p.t,
+		bck:      bck,
+		args:     p.args,
+		pending:  p.args.Job.Shards,
+		last:     p.args.Job.Last,
+		wakeCh:   make(chan struct{}, 1),
+	}
+	return nil
+}
+func (*xactProvider) Kind() string        { return cmn.ActExportBck }
+func (p *xactProvider) Get() cluster.Xact { return p.xact }
+
+// at most one export of a given bucket at a time
+func (p *xactProvider) PreRenewHook(previousEntry registry.BucketEntry) (keep bool, err error) {
+	prev := previousEntry.(*xactProvider)
+	err = fmt.Errorf("%s is already running - not starting %q", prev.xact, p.uuid)
+	return
+}
+
+func (r *Xaction) IsMountpathXact() bool { return false }
+
+func (r *Xaction) String() string {
+	return fmt.Sprintf("%s => %s", r.XactBase.String(), r.args.Job.BckTo)
+}
+
+// Add adds the next batch of shards to export.
+func (r *Xaction) Add(job *Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last {
+		return fmt.Errorf("%s: received %d shard(s) past the last batch", r, len(job.Shards))
+	}
+	if r.Finished() {
+		return fmt.Errorf("%s: already finished", r)
+	}
+	r.pending = append(r.pending, job.Shards...)
+	r.last = job.Last
+	select {
+	case r.wakeCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// returns the next shard to export, or nil when done with the last batch
+func (r *Xaction) next() (*Shard, error) {
+	timer := time.NewTimer(batchTimeout)
+	defer timer.Stop()
+	for {
+		r.mu.Lock()
+		if len(r.pending) > 0 {
+			shard := r.pending[0]
+			r.pending[0] = nil
+			r.pending = r.pending[1:]
+			r.mu.Unlock()
+			return shard, nil
+		}
+		last := r.last
+		r.mu.Unlock()
+		if last {
+			return nil, nil
+		}
+		select {
+		case <-r.wakeCh:
+		case <-r.ChanAbort():
+			return nil, cmn.NewAbortedError(r.String())
+		case <-timer.C:
+			return nil, fmt.Errorf("%s: timed out waiting for the next batch", r)
+		}
+	}
+}
+
+// Run is called by the target that has (asynchronously) started the export.
+func (r *Xaction) Run() (err error) {
+	glog.Infoln(r.String() + " started")
+	for {
+		var shard *Shard
+		if shard, err = r.next(); err != nil || shard == nil {
+			break
+		}
+		if r.Aborted() {
+			err = cmn.NewAbortedError(r.String())
+			break
+		}
+		if err = r.export(shard); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		glog.Errorf("%s failed: %v", r, err)
+	} else {
+		glog.Infof("%s done", r)
+	}
+	r.Finish(err)
+	return
+}
+
+// streams the shard to its destination while building it, and writes the index
+func (r *Xaction) export(shard *Shard) error {
+	var (
+		storage = r.args.Storage
+		bckTo   = r.args.Job.BckTo
+		pr, pw  = io.Pipe()
+		resCh   = make(chan shardResult, 1)
+	)
+	go func() {
+		idx, err := WriteShard(pw, shard, r.open)
+		pw.CloseWithError(err)
+		resCh <- shardResult{idx, err}
+	}()
+	if err := storage.PutObj(bckTo, shard.Name, pr, -1); err != nil {
+		pr.CloseWithError(err) // unblock the writer
+		if res := <-resCh; res.err != nil {
+			err = res.err // the writer's error (e.g., failed to read a member) takes precedence
+		}
+		return fmt.Errorf("failed to write %s/%s: %v", bckTo, shard.Name, err)
+	}
+	res := <-resCh
+	if res.err != nil {
+		return res.err
+	}
+	b := cmn.MustMarshal(res.idx)
+	idxName := IndexName(shard.Name)
+	if err := storage.PutObj(bckTo, idxName, ioutil.NopCloser(bytes.NewReader(b)), int64(len(b))); err != nil {
+		return fmt.Errorf("failed to write %s/%s: %v", bckTo, idxName, err)
+	}
+	r.ObjectsAdd(int64(len(shard.Members)))
+	return nil
+}
+
+func (r *Xaction) open(objName string) (io.ReadCloser, error) {
+	rc, err := r.args.Storage.OpenObj(r.bck, objName)
+	if err != nil {
+		return nil, err
+	}
+	return cmn.NewCallbackReadCloser(rc, func(n int, _ error) { r.BytesAdd(int64(n)) }), nil
+}
@@ -0,0 +1,93 @@
+// Package export packs buckets into WebDataset-compatible tar shards.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package export
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type memStorage struct {
+	mu   sync.Mutex
+	objs map[string]int64 // written: name => size
+}
+
+func (*memStorage) OpenObj(_ cmn.Bck, objName string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(objName)), nil
+}
+
+func (s *memStorage) PutObj(_ cmn.Bck, objName string, r io.ReadCloser, _ int64) error {
+	n, err := io.Copy(ioutil.Discard, r)
+	r.Close()
+	s.mu.Lock()
+	s.objs[objName] = n
+	s.mu.Unlock()
+	return err
+}
+
+func newTestXact(job *Job, storage Storage) *Xaction {
+	p := &xactProvider{uuid: job.UUID, args: &Args{Job: job, Storage: storage}}
+	if err := p.Start(cmn.Bck{Name: "src", Provider: cmn.ProviderAIS}); err != nil {
+		panic(err)
+	}
+	return p.xact
+}
+
+func TestXactionBatches(t *testing.T) {
+	var (
+		storage = &memStorage{objs: make(map[string]int64)}
+		msg     = &cmn.ExportMsg{ShardSize: 1}
+		shards  []*Shard
+	)
+	tassert.CheckFatal(t, Validate(msg))
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		shards = append(shards, MakePlan([]*cmn.BucketEntry{{Name: name, Size: int64(len(name))}}, msg)[0])
+		shards[len(shards)-1].Name = strings.TrimSuffix(name, ".jpg") + shardExt
+	}
+	bckTo := cmn.Bck{Name: "dst", Provider: cmn.ProviderAIS}
+
+	r := newTestXact(&Job{UUID: "batches", BckTo: bckTo}, storage)
+	errCh := make(chan error, 1)
+	go func() { errCh <- r.Run() }()
+
+	tassert.CheckFatal(t, r.Add(&Job{Shards: shards[:2], Cont: true}))
+	select {
+	case err := <-errCh:
+		t.Fatalf("finished prior to the last batch: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	tassert.CheckFatal(t, r.Add(&Job{Shards: shards[2:], Cont: true, Last: true}))
+	select {
+	case err := <-errCh:
+		tassert.CheckFatal(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the export to finish")
+	}
+	for _, shard := range shards {
+		_, ok := storage.objs[shard.Name]
+		_, okIdx := storage.objs[IndexName(shard.Name)]
+		tassert.Errorf(t, ok && okIdx, "%s: expected the shard and its index to be written", shard.Name)
+	}
+	tassert.Errorf(t, r.Add(&Job{Shards: shards, Cont: true}) != nil, "expected error adding past the last batch")
+
+	// abort while waiting for the next batch
+	r = newTestXact(&Job{UUID: "aborted", BckTo: bckTo}, storage)
+	go func() { errCh <- r.Run() }()
+	r.Abort()
+	select {
+	case err := <-errCh:
+		tassert.Errorf(t, errors.As(err, &cmn.AbortedError{}), "expected aborted error, got %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the aborted export")
+	}
+}
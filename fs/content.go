@@ -6,6 +6,8 @@ package fs
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -33,6 +35,7 @@ const (
 	contentTypeLen = 2
 	ObjectType     = "ob"
 	WorkfileType   = "wk"
+	CASType        = "ca" // content-addressed storage (see cmn.DedupConf)
 )
 
 type (
@@ -99,6 +102,37 @@ func (f *ContentSpecMgr) GenContentParsedFQN(parsedFQN ParsedFQN, contentType, p
 	return
 }
 
+// GenResumableFQN returns workfile fqn that, unlike GenContentParsedFQN, is the
+// same for the same object and `tag` so that the content can be resumed by a
+// subsequent attempt. The workfile left over by the previous run of the target
+// (that would be otherwise removed as old) is adopted.
+func (f *ContentSpecMgr) GenResumableFQN(parsedFQN ParsedFQN, prefix, tag string) (fqn string) {
+	var (
+		dir, fname = filepath.Split(parsedFQN.ObjName)
+		base       = prefix + "." + fname + "." + tag + "."
+	)
+	fqn = f.FQN(parsedFQN.MpathInfo, parsedFQN.Bck, WorkfileType, filepath.Join(dir, base+spid))
+	if _, err := os.Stat(fqn); err == nil || !os.IsNotExist(err) {
+		return
+	}
+	wdir := filepath.Dir(fqn)
+	finfos, err := ioutil.ReadDir(wdir)
+	if err != nil {
+		return
+	}
+	for _, finfo := range finfos {
+		name := finfo.Name()
+		if finfo.IsDir() || !strings.HasPrefix(name, base) || strings.Contains(name[len(base):], ".") {
+			continue
+		}
+		if err := os.Rename(filepath.Join(wdir, name), fqn); err == nil {
+			glog.Infof("adopted %s => %s", name, fqn)
+			break
+		}
+	}
+	return
+}
+
 // FileSpec returns the specification/attributes and information about fqn. spec
 // and info are only set when fqn was generated by GenContentFQN.
 func (f *ContentSpecMgr) FileSpec(fqn string) (resolver ContentResolver, info *ContentInfo) {
@@ -160,6 +194,7 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 type (
 	ObjectContentResolver   struct{}
 	WorkfileContentResolver struct{}
+	CASContentResolver      struct{}
 )
 
 func (wf *ObjectContentResolver) PermToMove() bool    { return true }
@@ -211,3 +246,17 @@ func (wf *WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// CAS content is shared with the objects (hard links) and is reference-counted
+// by the filesystem - it's never moved or evicted on its own.
+func (cas *CASContentResolver) PermToMove() bool    { return false }
+func (cas *CASContentResolver) PermToEvict() bool   { return false }
+func (cas *CASContentResolver) PermToProcess() bool { return false }
+
+func (cas *CASContentResolver) GenUniqueFQN(base, _ string) string {
+	return base
+}
+
+func (cas *CASContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -0,0 +1,24 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// OpenDirect opens file for reading with F_NOCACHE (the closest equivalent of O_DIRECT).
+func OpenDirect(fqn string) (*os.File, error) {
+	file, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = unix.FcntlInt(file.Fd(), unix.F_NOCACHE, 1); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,16 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenDirect opens file for reading with O_DIRECT - that is, bypassing page cache.
+// The caller must read into buffers aligned as per memsys.AlignBuf.
+func OpenDirect(fqn string) (*os.File, error) {
+	return os.OpenFile(fqn, os.O_RDONLY|syscall.O_DIRECT, 0)
+}
@@ -0,0 +1,60 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestOpenDirect(t *testing.T) {
+	dirs := []string{os.TempDir()}
+	if _, err := os.Stat("/dev/shm"); err == nil {
+		dirs = append(dirs, "/dev/shm") // tmpfs: O_DIRECT is not supported (on older kernels)
+	}
+	for _, dir := range dirs {
+		t.Run(dir, func(t *testing.T) {
+			tmpDir, err := ioutil.TempDir(dir, "directio")
+			tassert.CheckFatal(t, err)
+			defer os.RemoveAll(tmpDir)
+
+			// not a multiple of the page size: the last read is short
+			data := make([]byte, 3*memsys.PageSize+123)
+			rand.Read(data)
+			fqn := filepath.Join(tmpDir, "obj")
+			tassert.CheckFatal(t, ioutil.WriteFile(fqn, data, 0o644))
+
+			file, err := fs.OpenDirect(fqn)
+			if err != nil {
+				// the same as GET does: fall back to buffered read
+				tassert.Fatalf(t, errors.Is(err, syscall.EINVAL), "unexpected error: %v", err)
+				t.Logf("%s: O_DIRECT not supported, falling back to buffered read", dir)
+				file, err = os.Open(fqn)
+				tassert.CheckFatal(t, err)
+			}
+			defer file.Close()
+
+			var (
+				buf = make([]byte, 4*memsys.PageSize)
+				w   = &bytes.Buffer{}
+			)
+			// hide WriteTo and ReadFrom - read via the aligned buffer
+			_, err = io.CopyBuffer(struct{ io.Writer }{w}, struct{ io.Reader }{file}, memsys.AlignBuf(buf))
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, bytes.Equal(w.Bytes(), data), "content mismatch (read %d bytes)", w.Len())
+		})
+	}
+}
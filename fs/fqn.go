@@ -26,6 +26,8 @@ const (
 	WorkfilePut     = "put"    // object PUT
 	WorkfileAppend  = "append" // object APPEND
 	WorkfileFSHC    = "fshc"   // FSHC test file
+	WorkfileDlPart  = "dlpart" // downloader: partially downloaded object (to resume)
+	WorkfileMpt     = "mpt"    // multipart upload (the parts are prefixed with their numbers)
 )
 
 type ParsedFQN struct {
@@ -25,7 +25,8 @@ import (
 )
 
 const (
-	uQuantum = 10 // each GET adds a "quantum" of utilization to the mountpath
+	uQuantum          = 10 // each GET adds a "quantum" of utilization to the mountpath
+	maxAddConcurrency = 8  // max number of mountpaths initialized concurrently (see SetMountpaths)
 )
 
 // mountpath lifecycle-change enum
@@ -59,6 +60,7 @@ type (
 		Fsid       syscall.Fsid
 		FileSystem string
 		PathDigest uint64
+		Label      string // optional, e.g. media class (see cmn.FSPathsConf)
 
 		// LOM caches
 		lomCaches cmn.MultiSyncMap
@@ -66,6 +68,9 @@ type (
 		// capacity
 		cmu      sync.RWMutex
 		capacity Capacity
+
+		// QoS (see Throttle)
+		qos qosState
 	}
 	MPI map[string]*MountpathInfo
 
@@ -102,12 +107,12 @@ type (
 		capStatus CapStatus
 	}
 	CapStatus struct {
-		TotalUsed  uint64 // bytes
-		TotalAvail uint64 // bytes
-		PctAvg     int32  // used average (%)
-		PctMax     int32  // max used (%)
-		Err        error
-		OOS        bool
+		TotalUsed  uint64 `json:"total_used,string"`  // bytes
+		TotalAvail uint64 `json:"total_avail,string"` // bytes
+		PctAvg     int32  `json:"pct_avg"`            // used average (%)
+		PctMax     int32  `json:"pct_max"`            // max used (%)
+		Err        error  `json:"-"`
+		OOS        bool   `json:"oos"`
 	}
 )
 
@@ -115,18 +120,22 @@ type (
 // MountpathInfo //
 ///////////////////
 
-func newMountpath(cleanPath, origPath string, fsid syscall.Fsid, fs string) *MountpathInfo {
+func newMountpath(cleanPath, origPath string, fsid syscall.Fsid, fs, label string) *MountpathInfo {
 	mi := &MountpathInfo{
 		Path:       cleanPath,
 		OrigPath:   origPath,
 		Fsid:       fsid,
 		FileSystem: fs,
 		PathDigest: xxhash.ChecksumString64S(cleanPath, cmn.MLCG32),
+		Label:      label,
 	}
 	return mi
 }
 
 func (mi *MountpathInfo) String() string {
+	if mi.Label != "" {
+		return fmt.Sprintf("mp[%s, fs=%s, label=%s]", mi.Path, mi.FileSystem, mi.Label)
+	}
 	return fmt.Sprintf("mp[%s, fs=%s]", mi.Path, mi.FileSystem)
 }
 
@@ -344,23 +353,41 @@ func Init(iostater ...ios.IOStater) {
 	}
 }
 
-// SetMountpaths prepares, validates, and adds configured mountpaths.
-func SetMountpaths(fsPaths []string) error {
+// SetMountpaths prepares, validates, and adds configured mountpaths - in parallel
+// (with bounded concurrency) to speed up startup of targets with many mountpaths.
+// Labels (if any) are keyed by the configured mountpath.
+func SetMountpaths(fsPaths []string, labels map[string]string) error {
 	if len(fsPaths) == 0 {
 		// (usability) not to clutter the log with backtraces when starting up and validating config
 		return fmt.Errorf("FATAL: no fspaths - see README => Configuration and/or fspaths section in the config.sh")
 	}
 
+	var (
+		wg       = cmn.NewLimitedWaitGroup(maxAddConcurrency)
+		mu       sync.Mutex
+		firstErr error
+	)
 	for _, path := range fsPaths {
-		if err := Add(path); err != nil {
-			return err
-		}
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			if err := Add(path, labels[path]); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(path)
 	}
-
-	return nil
+	wg.Wait()
+	return firstErr
 }
 
-func LoadBalanceGET(objFQN, objMpath string, copies MPI) (fqn string) {
+// LoadBalanceGET selects the least utilized of the object and its copies.
+// Given hotLabel (see cmn.MirrorConf), the copies on the mountpaths labeled
+// as such (fast media) take precedence unless they are all (highly) utilized.
+func LoadBalanceGET(objFQN, objMpath string, copies MPI, hotLabel string) (fqn string) {
 	var (
 		nowTs                = mono.NanoTime()
 		mpathUtils, mpathRRs = mfs.ios.GetAllMpathUtils(nowTs)
@@ -376,6 +403,14 @@ func LoadBalanceGET(objFQN, objMpath string, copies MPI) (fqn string) {
 		debug.AssertMsg(len(mpathUtils) == 0, objMpath)
 		return
 	}
+	if hotLabel != "" {
+		if hot := hotCopies(copies, hotLabel, mpathUtils); len(hot) > 0 {
+			copies = hot
+			if _, ok := hot[objFQN]; !ok {
+				util, rr = math.MaxInt64/2, nil // any hot copy beats the object itself
+			}
+		}
+	}
 	for copyFQN, copyMPI := range copies {
 		var (
 			u        int64
@@ -411,7 +446,28 @@ func LoadBalanceGET(objFQN, objMpath string, copies MPI) (fqn string) {
 	return
 }
 
+// returns the copies on the hot mountpaths utilized below the high watermark
+func hotCopies(copies MPI, hotLabel string, mpathUtils map[string]int64) (hot MPI) {
+	highWM := cmn.GCO.Get().Disk.DiskUtilHighWM
+	for copyFQN, copyMPI := range copies {
+		if copyMPI.Label != hotLabel {
+			continue
+		}
+		if u, ok := mpathUtils[copyMPI.Path]; ok && u < highWM {
+			if hot == nil {
+				hot = make(MPI, 2)
+			}
+			hot[copyFQN] = copyMPI
+		}
+	}
+	return
+}
+
 // ios delegators
+
+// IsRotational returns true if the mountpath is (or includes) a spinning disk (HDD).
+func (mi *MountpathInfo) IsRotational() bool { return mfs.ios.IsRotational(mi.Path) }
+
 func GetMpathUtil(mpath string, nowTs int64) int64 {
 	return mfs.ios.GetMpathUtil(mpath, nowTs)
 }
@@ -429,6 +485,9 @@ func GetSelectedDiskStats() (m map[string]*ios.SelectedDiskStats) {
 	return mfs.ios.GetSelectedDiskStats()
 }
 
+func AddXactIO(xio *ios.XactIO)    { mfs.ios.AddXactIO(xio) }
+func RemoveXactIO(xio *ios.XactIO) { mfs.ios.RemoveXactIO(xio) }
+
 // DisableFsIDCheck disables fsid checking when adding new mountpath
 func DisableFsIDCheck() { mfs.checkFsID = false }
 
@@ -445,7 +504,7 @@ func updatePaths(available, disabled MPI) {
 
 // Add adds new mountpath to the target's mountpaths.
 // FIXME: unify error messages for original and clean mountpath
-func Add(mpath string) error {
+func Add(mpath string, label ...string) error {
 	cleanMpath, err := cmn.ValidateMpath(mpath)
 	if err != nil {
 		return err
@@ -463,7 +522,11 @@ func Add(mpath string) error {
 		return fmt.Errorf("cannot get filesystem: %v", err)
 	}
 
-	mp := newMountpath(cleanMpath, mpath, statfs.Fsid, fs)
+	var mpLabel string
+	if len(label) > 0 {
+		mpLabel = label[0]
+	}
+	mp := newMountpath(cleanMpath, mpath, statfs.Fsid, fs, mpLabel)
 
 	mfs.mu.Lock()
 	defer mfs.mu.Unlock()
@@ -715,6 +778,7 @@ func GetCapStatus() (cs CapStatus) {
 func RefreshCapStatus(config *cmn.Config, mpcap MPCap) (cs CapStatus, err error) {
 	var (
 		availablePaths, _ = Get()
+		caps              = make(MPCap, len(availablePaths))
 		c                 Capacity
 	)
 	if len(availablePaths) == 0 {
@@ -724,25 +788,17 @@ func RefreshCapStatus(config *cmn.Config, mpcap MPCap) (cs CapStatus, err error)
 	if config == nil {
 		config = cmn.GCO.Get()
 	}
-	high, oos := config.LRU.HighWM, config.LRU.OOS
 	for path, mi := range availablePaths {
 		if c, err = mi.getCapacity(config, true); err != nil {
 			glog.Error(err) // TODO: handle
 			return
 		}
-		cs.TotalUsed += c.Used
-		cs.TotalAvail += c.Avail
-		cs.PctMax = cmn.MaxI32(cs.PctMax, c.PctUsed)
-		cs.PctAvg += c.PctUsed
+		caps[path] = c
 		if mpcap != nil {
 			mpcap[path] = c
 		}
 	}
-	cs.PctAvg /= int32(len(availablePaths))
-	cs.OOS = int64(cs.PctMax) > oos
-	if cs.OOS || int64(cs.PctMax) > high {
-		cs.Err = cmn.NewErrorCapacityExceeded(high, cs.PctMax, cs.OOS)
-	}
+	cs = caps.CapStatus(config)
 	// cached cap state
 	mfs.cmu.Lock()
 	mfs.capStatus = cs
@@ -786,6 +842,28 @@ func CapPeriodic(mpcap MPCap) (cs CapStatus, err error, updated bool) {
 	return
 }
 
+// CapStatus aggregates per-mountpath capacities - the local ones (see
+// RefreshCapStatus) or those reported by a given target via stats.DaemonStatus
+func (mpcap MPCap) CapStatus(config *cmn.Config) (cs CapStatus) {
+	if len(mpcap) == 0 {
+		cs.Err = errors.New(cmn.NoMountpaths)
+		return
+	}
+	for _, c := range mpcap {
+		cs.TotalUsed += c.Used
+		cs.TotalAvail += c.Avail
+		cs.PctMax = cmn.MaxI32(cs.PctMax, c.PctUsed)
+		cs.PctAvg += c.PctUsed
+	}
+	cs.PctAvg /= int32(len(mpcap))
+	high, oos := config.LRU.HighWM, config.LRU.OOS
+	cs.OOS = int64(cs.PctMax) > oos
+	if cs.OOS || int64(cs.PctMax) > high {
+		cs.Err = cmn.NewErrorCapacityExceeded(high, cs.PctMax, cs.OOS)
+	}
+	return
+}
+
 // a slightly different view of the same
 func CapStatusAux() (fsInfo cmn.CapacityInfo) {
 	cs := GetCapStatus()
@@ -362,3 +362,29 @@ func BenchmarkMakePathFQN(b *testing.B) {
 		cmn.Assert(len(s) > 0)
 	}
 }
+
+func TestMPCapStatus(t *testing.T) {
+	config := &cmn.Config{}
+	config.LRU.HighWM, config.LRU.OOS = 90, 95
+
+	cs := fs.MPCap{}.CapStatus(config)
+	tassert.Errorf(t, cs.Err != nil, "expected error when there are no mountpaths")
+
+	mpcap := fs.MPCap{
+		"/mp1": {Used: 10, Avail: 90, PctUsed: 10},
+		"/mp2": {Used: 50, Avail: 50, PctUsed: 50},
+	}
+	cs = mpcap.CapStatus(config)
+	tassert.CheckError(t, cs.Err)
+	tassert.Errorf(t, cs.TotalUsed == 60 && cs.TotalAvail == 140, "wrong totals: %+v", cs)
+	tassert.Errorf(t, cs.PctAvg == 30 && cs.PctMax == 50, "wrong percentages: %+v", cs)
+	tassert.Errorf(t, !cs.OOS, "unexpected out of space: %+v", cs)
+
+	mpcap["/mp3"] = fs.Capacity{Used: 92, Avail: 8, PctUsed: 92}
+	cs = mpcap.CapStatus(config)
+	tassert.Errorf(t, cs.Err != nil && !cs.OOS, "expected high watermark to be exceeded: %+v", cs)
+
+	mpcap["/mp3"] = fs.Capacity{Used: 97, Avail: 3, PctUsed: 97}
+	cs = mpcap.CapStatus(config)
+	tassert.Errorf(t, cs.Err != nil && cs.OOS, "expected out of space: %+v", cs)
+}
@@ -0,0 +1,78 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Per-mountpath QoS: user (foreground) reads and writes are never throttled - they only
+// register themselves as recent activity on the mountpath. Background xactions call
+// Throttle(mi, class) per processed object (or batch of objects) and get throttled
+// based on the mountpath utilization relative to the configured disk watermarks:
+//
+// | class     | util >= DiskUtilHighWM | LowWM < util < HighWM and recent user IO |
+// | --------- | ---------------------- | ---------------------------------------- |
+// | QoSNormal | cmn.ThrottleMin        | -                                        |
+// | QoSLow    | cmn.ThrottleMax        | cmn.ThrottleAvg                          |
+
+type QoS int
+
+const (
+	QoSUser   QoS = iota // user GET and PUT
+	QoSNormal            // e.g. mirroring, EC encoding
+	QoSLow               // e.g. LRU, rebalance, resilver, downloader
+)
+
+// user IO is considered "recent" within this interval
+const qosUserActive = time.Second
+
+type qosState struct {
+	userTs    atomic.Int64 // mono time of the last user IO
+	throttled atomic.Int64 // number of times background work was throttled
+}
+
+// Throttle returns the time (possibly zero) the caller has slept.
+func Throttle(mi *MountpathInfo, class QoS) (d time.Duration) {
+	nowTs := mono.NanoTime()
+	if class == QoSUser {
+		mi.qos.userTs.Store(nowTs)
+		return
+	}
+	if d = mi.throttleDuration(class, cmn.GCO.Get(), nowTs); d > 0 {
+		mi.qos.throttled.Inc()
+		time.Sleep(d)
+	}
+	return
+}
+
+// Throttled returns the number of times background xactions were throttled on the mountpath.
+func (mi *MountpathInfo) Throttled() int64 { return mi.qos.throttled.Load() }
+
+func (mi *MountpathInfo) throttleDuration(class QoS, config *cmn.Config, nowTs int64) time.Duration {
+	curr := mfs.ios.GetMpathUtil(mi.Path, nowTs)
+	if curr < 0 {
+		return 0
+	}
+	if curr >= config.Disk.DiskUtilHighWM {
+		if class == QoSLow {
+			return cmn.ThrottleMax
+		}
+		return cmn.ThrottleMin
+	}
+	if class == QoSLow && curr > config.Disk.DiskUtilLowWM && mi.userActive(nowTs) {
+		return cmn.ThrottleAvg
+	}
+	return 0
+}
+
+func (mi *MountpathInfo) userActive(nowTs int64) bool {
+	ts := mi.qos.userTs.Load()
+	return ts != 0 && time.Duration(nowTs-ts) < qosUserActive
+}
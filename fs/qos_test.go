@@ -0,0 +1,60 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestThrottleQoS(t *testing.T) {
+	const mpath = "/tmp/qos"
+	var (
+		mock   = ios.NewIOStaterMock()
+		mi     = newMountpath(mpath, mpath, syscall.Fsid{}, "", "")
+		config = &cmn.Config{}
+	)
+	Init(mock)
+	config.Disk.DiskUtilLowWM, config.Disk.DiskUtilHighWM = 20, 80
+
+	tests := []struct {
+		util    int64
+		user    bool
+		normal  time.Duration
+		low     time.Duration
+		comment string
+	}{
+		{util: 10, normal: 0, low: 0, comment: "idle"},
+		{util: 10, user: true, normal: 0, low: 0, comment: "idle with user IO"},
+		{util: 50, normal: 0, low: 0, comment: "busy, no user IO"},
+		{util: 50, user: true, normal: 0, low: cmn.ThrottleAvg, comment: "busy with user IO"},
+		{util: 90, normal: cmn.ThrottleMin, low: cmn.ThrottleMax, comment: "overloaded"},
+		{util: -1, user: true, normal: 0, low: 0, comment: "unknown utilization"},
+	}
+	for _, test := range tests {
+		mock.Utils[mpath] = test.util
+		mi.qos.userTs.Store(0)
+		if test.user {
+			Throttle(mi, QoSUser)
+		}
+		nowTs := mono.NanoTime()
+		d := mi.throttleDuration(QoSNormal, config, nowTs)
+		tassert.Errorf(t, d == test.normal, "%s: normal class throttled for %v, expected %v", test.comment, d, test.normal)
+		d = mi.throttleDuration(QoSLow, config, nowTs)
+		tassert.Errorf(t, d == test.low, "%s: low class throttled for %v, expected %v", test.comment, d, test.low)
+	}
+
+	// user IO is considered recent only for a while
+	mock.Utils[mpath] = 50
+	Throttle(mi, QoSUser)
+	d := mi.throttleDuration(QoSLow, config, mono.NanoTime()+int64(qosUserActive))
+	tassert.Errorf(t, d == 0, "stale user IO: low class throttled for %v", d)
+}
@@ -0,0 +1,169 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/karrick/godirwalk"
+)
+
+// Undo-able deletion: objects deleted with an undo window are moved into
+// per-mountpath $trash/$undo-<token>-<deadline> directories, preserving their
+// paths relative to the mountpath. Until the deadline (Unix seconds) the whole
+// deletion set can be restored (see UndoWalk); past the deadline it gets
+// purged (see PurgeUndo) - the deadline is part of the name so that the
+// state survives restarts.
+
+const undoPrefix = "$undo-"
+
+var (
+	// ErrUndoExpired is returned by UndoWalk when the undo window has expired
+	ErrUndoExpired = errors.New("undo window expired")
+
+	errUndoBckFound = errors.New("found") // to stop walking
+)
+
+func (mi *MountpathInfo) MakePathUndo(token string, deadline time.Time) string {
+	name := undoPrefix + token + "-" + strconv.FormatInt(deadline.Unix(), 10)
+	return filepath.Join(mi.MakePathTrash(), name)
+}
+
+// MoveToUndo moves the file into the undo directory of the mountpath
+func (mi *MountpathInfo) MoveToUndo(fqn, token string, deadline time.Time) error {
+	rel, err := filepath.Rel(mi.Path, fqn)
+	if err != nil {
+		return err
+	}
+	dst := filepath.Join(mi.MakePathUndo(token, deadline), rel)
+	if err := cmn.CreateDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	return os.Rename(fqn, dst)
+}
+
+// ParseUndoDir returns the token and the deadline of a given $trash entry;
+// ok is false if the entry is not an undo directory
+func ParseUndoDir(name string) (token string, deadline time.Time, ok bool) {
+	if !strings.HasPrefix(name, undoPrefix) {
+		return
+	}
+	name = strings.TrimPrefix(name, undoPrefix)
+	i := strings.LastIndexByte(name, '-')
+	if i <= 0 {
+		return
+	}
+	secs, err := strconv.ParseInt(name[i+1:], 10, 64)
+	if err != nil {
+		return
+	}
+	return name[:i], time.Unix(secs, 0), true
+}
+
+// undoDirs returns all the undo directories of a given token
+func undoDirs(token string) (dirs map[*MountpathInfo]string, deadline time.Time) {
+	availablePaths, _ := Get()
+	dirs = make(map[*MountpathInfo]string, len(availablePaths))
+	for _, mi := range availablePaths {
+		trashDir := mi.MakePathTrash()
+		_ = Scanner(trashDir, func(fqn string, de DirEntry) error {
+			if !de.IsDir() {
+				return nil
+			}
+			if tok, dl, ok := ParseUndoDir(filepath.Base(fqn)); ok && tok == token {
+				dirs[mi] = fqn
+				deadline = dl
+			}
+			return nil
+		})
+	}
+	return
+}
+
+// UndoWalk calls the callback for each file of the deletion set with the
+// file's current (trash) location and the original one
+func UndoWalk(token string, cb func(trashFQN, fqn string) error) error {
+	dirs, deadline := undoDirs(token)
+	if len(dirs) == 0 {
+		return cmn.NewNotFoundError("undo token %q", token)
+	}
+	if time.Now().After(deadline) {
+		return ErrUndoExpired
+	}
+	for mi, dir := range dirs {
+		err := godirwalk.Walk(dir, &godirwalk.Options{
+			Callback: func(trashFQN string, de *godirwalk.Dirent) error {
+				if de.IsDir() {
+					return nil
+				}
+				rel, err := filepath.Rel(dir, trashFQN)
+				if err != nil {
+					return err
+				}
+				return cb(trashFQN, filepath.Join(mi.Path, rel))
+			},
+			Unsorted: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UndoBck returns the bucket of the deletion set - list and range deletions
+// are confined to a single bucket
+func UndoBck(token string) (bck cmn.Bck, err error) {
+	err = UndoWalk(token, func(_, fqn string) error {
+		parsed, err := ParseFQN(fqn)
+		if err != nil {
+			return err
+		}
+		bck = parsed.Bck
+		return errUndoBckFound
+	})
+	if err == errUndoBckFound {
+		return bck, nil
+	}
+	if err == nil {
+		err = cmn.NewNotFoundError("undo token %q", token)
+	}
+	return
+}
+
+// RemoveUndo removes the deletion set
+func RemoveUndo(token string) {
+	dirs, _ := undoDirs(token)
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			glog.Error(err)
+		}
+	}
+}
+
+// PurgeUndo removes expired deletion sets from all mountpaths
+func PurgeUndo() {
+	availablePaths, _ := Get()
+	now := time.Now()
+	for _, mi := range availablePaths {
+		_ = Scanner(mi.MakePathTrash(), func(fqn string, de DirEntry) error {
+			if _, deadline, ok := ParseUndoDir(filepath.Base(fqn)); ok && now.After(deadline) {
+				if err := os.RemoveAll(fqn); err != nil {
+					glog.Error(err)
+				} else {
+					glog.Infof("purged %s", fqn)
+				}
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestUndo(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "undo")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	tassert.CheckFatal(t, fs.Add(mpath))
+	defer fs.Remove(mpath)
+	mi, _ := fs.Get()
+
+	var (
+		token    = "tok-en"
+		deadline = time.Now().Add(time.Minute)
+		fqn      = filepath.Join(mpath, "dir", "obj")
+	)
+	tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+	tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte("data"), 0o644))
+	tassert.CheckFatal(t, mi[mpath].MoveToUndo(fqn, token, deadline))
+	_, err = os.Stat(fqn)
+	tassert.Fatalf(t, os.IsNotExist(err), "expected %q to be moved to trash", fqn)
+
+	tok, dl, ok := fs.ParseUndoDir(filepath.Base(mi[mpath].MakePathUndo(token, deadline)))
+	tassert.Errorf(t, ok && tok == token && dl.Unix() == deadline.Unix(), "parse: %q, %v, %t", tok, dl, ok)
+
+	// not expired: nothing to purge
+	fs.PurgeUndo()
+	var restored []string
+	err = fs.UndoWalk(token, func(trashFQN, origFQN string) error {
+		restored = append(restored, origFQN)
+		return os.Rename(trashFQN, origFQN)
+	})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(restored) == 1 && restored[0] == fqn, "restored %v, expected %q", restored, fqn)
+	fs.RemoveUndo(token)
+
+	err = fs.UndoWalk(token, func(_, _ string) error { return nil })
+	tassert.Errorf(t, err != nil, "expected %q to be removed", token)
+
+	// expired
+	tassert.CheckFatal(t, mi[mpath].MoveToUndo(fqn, token, time.Now().Add(-time.Second)))
+	err = fs.UndoWalk(token, func(_, _ string) error { return nil })
+	tassert.Errorf(t, err == fs.ErrUndoExpired, "expected %v, got %v", fs.ErrUndoExpired, err)
+	fs.PurgeUndo()
+	err = fs.UndoWalk(token, func(_, _ string) error { return nil })
+	tassert.Errorf(t, err != nil && err != fs.ErrUndoExpired, "expected %q to be purged, got %v", token, err)
+}
+
+func TestUndoBck(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "undo")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	tassert.CheckFatal(t, fs.Add(mpath))
+	defer fs.Remove(mpath)
+	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	mi, _ := fs.Get()
+
+	var (
+		token = "tok-en"
+		bck   = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		fqn   = mi[mpath].MakePathFQN(bck, fs.ObjectType, "dir/obj")
+	)
+	_, err = fs.UndoBck(token)
+	_, ok := err.(*cmn.NotFoundError)
+	tassert.Errorf(t, ok, "expected not-found error, got %v", err)
+
+	tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+	tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte("data"), 0o644))
+	tassert.CheckFatal(t, mi[mpath].MoveToUndo(fqn, token, time.Now().Add(time.Minute)))
+	defer fs.RemoveUndo(token)
+
+	undoBck, err := fs.UndoBck(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, undoBck.Equal(bck), "expected %s, got %s", bck, undoBck)
+}
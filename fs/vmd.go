@@ -0,0 +1,143 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Volume metadata (VMD): each mountpath persists a small record that identifies
+// the target and the mountpath the volume was (last) used as. At startup, the
+// records get checked to detect disks that were swapped or renamed (e.g., after
+// maintenance) and disks that belong to other targets - in order not to mix
+// content across mountpaths.
+
+const VmdFname = ".ais.vmd"
+
+type (
+	VMD struct {
+		DaemonID   string   `json:"daemon_id"`
+		Mountpath  string   `json:"mountpath"`  // the mountpath this volume was used as
+		Mountpaths []string `json:"mountpaths"` // all mountpaths of the target at the time
+		Version    int64    `json:"version,string"`
+	}
+	// VMDMismatch describes a volume found at a different mountpath
+	VMDMismatch struct {
+		Mountpath string // current
+		Was       string // as per the volume's VMD
+	}
+)
+
+// CheckVMD loads and validates volume metadata of all available mountpaths.
+// Volumes claimed by different targets always fail the check; volumes found
+// at different mountpaths (swapped or renamed) fail it unless `remap` is set,
+// in which case the (remapped) volumes are returned for the caller to resilver.
+// Upon success, CheckVMD persists the updated VMD on all mountpaths.
+func CheckVMD(daemonID string, remap bool) (remapped []VMDMismatch, err error) {
+	var (
+		version           int64
+		owners            = make(cmn.StringSet, 1)
+		claimed           = make(map[string]string, 4) // VMD.Mountpath => current mountpath
+		availablePaths, _ = Get()
+	)
+	for mpath, mi := range availablePaths {
+		vmd, errLoad := loadVMD(mi)
+		if errLoad != nil {
+			return nil, errLoad
+		}
+		if vmd == nil {
+			glog.Infof("%s: no volume metadata (new volume?)", mi)
+			continue
+		}
+		owners.Add(vmd.DaemonID)
+		if vmd.Version > version {
+			version = vmd.Version
+		}
+		if other, ok := claimed[vmd.Mountpath]; ok {
+			return nil, fmt.Errorf("volumes at %q and %q were both used as mountpath %q (cloned disk?)",
+				other, mpath, vmd.Mountpath)
+		}
+		claimed[vmd.Mountpath] = mpath
+		if vmd.Mountpath != mpath {
+			remapped = append(remapped, VMDMismatch{Mountpath: mpath, Was: vmd.Mountpath})
+		}
+	}
+	if len(owners) > 1 {
+		ids := owners.Keys()
+		sort.Strings(ids)
+		return nil, fmt.Errorf("mountpaths contain volumes of different targets: %v", ids)
+	}
+	if len(owners) == 1 && !owners.Contains(daemonID) {
+		// all volumes moved together: the target's ID has changed (e.g., new IP address)
+		glog.Warningf("volume metadata belongs to %v, current target ID %s", owners.Keys(), daemonID)
+	}
+	if len(remapped) > 0 {
+		sort.Slice(remapped, func(i, j int) bool { return remapped[i].Mountpath < remapped[j].Mountpath })
+		if !remap {
+			return nil, fmt.Errorf("swapped or renamed mountpaths: %s (set disk.vmd_remap to accept)",
+				mismatchesStr(remapped))
+		}
+		glog.Warningf("remapping mountpaths: %s", mismatchesStr(remapped))
+	}
+	return remapped, saveVMD(daemonID, version+1)
+}
+
+// SaveVMD (re)writes volume metadata on all available mountpaths - to be called
+// upon mountpath changes at runtime
+func SaveVMD(daemonID string) error {
+	var (
+		version           int64
+		availablePaths, _ = Get()
+	)
+	for _, mi := range availablePaths {
+		if vmd, err := loadVMD(mi); err == nil && vmd != nil && vmd.Version > version {
+			version = vmd.Version
+		}
+	}
+	return saveVMD(daemonID, version+1)
+}
+
+func loadVMD(mi *MountpathInfo) (*VMD, error) {
+	vmd := &VMD{}
+	if err := jsp.Load(filepath.Join(mi.Path, VmdFname), vmd, jsp.CCSign()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to load volume metadata: %v", mi, err)
+	}
+	return vmd, nil
+}
+
+func saveVMD(daemonID string, version int64) error {
+	availablePaths, _ := Get()
+	mpaths := make([]string, 0, len(availablePaths))
+	for mpath := range availablePaths {
+		mpaths = append(mpaths, mpath)
+	}
+	sort.Strings(mpaths)
+	for _, mpath := range mpaths {
+		vmd := &VMD{DaemonID: daemonID, Mountpath: mpath, Mountpaths: mpaths, Version: version}
+		if err := jsp.Save(filepath.Join(mpath, VmdFname), vmd, jsp.CCSign()); err != nil {
+			return fmt.Errorf("%s: failed to save volume metadata: %v", mpath, err)
+		}
+	}
+	return nil
+}
+
+func mismatchesStr(mm []VMDMismatch) string {
+	s := make([]string, 0, len(mm))
+	for _, m := range mm {
+		s = append(s, m.Was+" => "+m.Mountpath)
+	}
+	return strings.Join(s, ", ")
+}
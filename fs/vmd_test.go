@@ -0,0 +1,75 @@
+// Package fs_test provides tests for fs package
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func initVMDMountpaths(t *testing.T, mpaths ...string) {
+	fs.Init(ios.NewIOStaterMock())
+	fs.DisableFsIDCheck()
+	for _, mpath := range mpaths {
+		tassert.CheckFatal(t, fs.Add(mpath))
+	}
+}
+
+func TestVMD(t *testing.T) {
+	var (
+		root   = filepath.Join(os.TempDir(), "vmd-test")
+		mpaths = []string{filepath.Join(root, "mp1"), filepath.Join(root, "mp2"), filepath.Join(root, "mp3")}
+	)
+	for _, mpath := range mpaths {
+		tassert.CheckFatal(t, cmn.CreateDir(mpath))
+	}
+	defer os.RemoveAll(root)
+
+	// new volumes
+	initVMDMountpaths(t, mpaths...)
+	remapped, err := fs.CheckVMD("t1", false)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(remapped) == 0, "expected no remapped mountpaths, got %v", remapped)
+
+	// restart: same layout, new daemon ID
+	initVMDMountpaths(t, mpaths...)
+	_, err = fs.CheckVMD("t2", false)
+	tassert.CheckFatal(t, err)
+
+	// swap two disks
+	tmp := filepath.Join(root, "tmp")
+	tassert.CheckFatal(t, os.Rename(mpaths[0], tmp))
+	tassert.CheckFatal(t, os.Rename(mpaths[1], mpaths[0]))
+	tassert.CheckFatal(t, os.Rename(tmp, mpaths[1]))
+
+	initVMDMountpaths(t, mpaths...)
+	_, err = fs.CheckVMD("t2", false)
+	tassert.Fatalf(t, err != nil, "expected swapped mountpaths to fail the check")
+
+	remapped, err = fs.CheckVMD("t2", true /*remap*/)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(remapped) == 2, "expected 2 remapped mountpaths, got %v", remapped)
+
+	// remapped VMD gets persisted
+	_, err = fs.CheckVMD("t2", false)
+	tassert.CheckFatal(t, err)
+
+	// volume of another target
+	other := filepath.Join(root, "mp4")
+	tassert.CheckFatal(t, cmn.CreateDir(other))
+	initVMDMountpaths(t, other)
+	_, err = fs.CheckVMD("t3", false)
+	tassert.CheckFatal(t, err)
+
+	initVMDMountpaths(t, append(mpaths, other)...)
+	_, err = fs.CheckVMD("t2", true /*remap*/)
+	tassert.Fatalf(t, err != nil, "expected volumes of different targets to fail the check")
+}
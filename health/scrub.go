@@ -0,0 +1,320 @@
+// Package health provides a basic mountpath health monitor.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package health
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Scrubber verifies checksums of the stored objects in the background, one
+// object at a time and only while the mountpath is idle (see `scrub` config).
+// There's one jogger per mountpath; each jogger cycles through all the buckets
+// and persists its progress on the mountpath, so that a restarted target resumes
+// the current cycle where it left off. At low rates, a single cycle may take weeks.
+// Corrupted objects are logged and counted (`err.cksum.*` stats) but left intact.
+
+const (
+	scrubFname      = ".ais.scrub"     // per-mountpath progress (see ScrubProgress)
+	scrubIdleWait   = 10 * time.Second // re-check interval while the mountpath is busy (or scrubbing is disabled)
+	scrubCheckTime  = time.Minute      // how often to check for new mountpaths
+	scrubPersistNum = 100              // persist progress every so many objects
+)
+
+type (
+	Scrubber struct {
+		cmn.Named
+		t       cluster.Target
+		statsT  stats.Tracker
+		stopCh  *cmn.StopCh
+		mu      sync.Mutex
+		joggers map[string]*scrubJogger // mountpath => jogger
+	}
+	scrubJogger struct {
+		parent    *Scrubber
+		mpathInfo *fs.MountpathInfo
+		bck       cmn.Bck // bucket that is currently being walked
+		progress  ScrubProgress
+		resume    fs.ParsedFQN // resuming: the cursor (parsed)
+		skipping  bool         // resuming: skip objects up to (and including) the cursor
+		num       int64        // objects verified since the last persist
+	}
+	// ScrubProgress of a given mountpath (current cycle)
+	ScrubProgress struct {
+		Cursor    string    `json:"cursor"` // FQN of the last verified object
+		Started   time.Time `json:"started"`
+		Cycle     int64     `json:"cycle"` // number of completed cycles
+		Verified  int64     `json:"verified"`
+		Corrupted int64     `json:"corrupted"`
+	}
+)
+
+//////////////
+// Scrubber //
+//////////////
+
+func NewScrubber(t cluster.Target, statsT stats.Tracker) *Scrubber {
+	return &Scrubber{
+		t:       t,
+		statsT:  statsT,
+		stopCh:  cmn.NewStopCh(),
+		joggers: make(map[string]*scrubJogger, 4),
+	}
+}
+
+// as a runner
+func (s *Scrubber) Run() error {
+	glog.Infof("Starting %s", s.GetRunName())
+	ticker := time.NewTicker(scrubCheckTime)
+	defer ticker.Stop()
+	for {
+		if cmn.GCO.Get().Scrub.Enabled {
+			s.startJoggers()
+		}
+		select {
+		case <-ticker.C:
+		case <-s.stopCh.Listen():
+			return nil
+		}
+	}
+}
+
+func (s *Scrubber) Stop(err error) {
+	glog.Infof("Stopping %s, err: %v", s.GetRunName(), err)
+	s.stopCh.Close()
+}
+
+// start joggers for the mountpaths that don't have one (yet)
+func (s *Scrubber) startJoggers() {
+	availablePaths, _ := fs.Get()
+	s.mu.Lock()
+	for mpath, mpathInfo := range availablePaths {
+		if _, ok := s.joggers[mpath]; ok {
+			continue
+		}
+		j := &scrubJogger{parent: s, mpathInfo: mpathInfo}
+		s.joggers[mpath] = j
+		go j.run()
+	}
+	s.mu.Unlock()
+}
+
+/////////////////
+// scrubJogger //
+/////////////////
+
+func (j *scrubJogger) String() string { return fmt.Sprintf("scrub[%s]", j.mpathInfo) }
+
+func (j *scrubJogger) run() {
+	glog.Infof("%s started", j)
+	j.load()
+	for {
+		err := j.cycle()
+		if err == nil {
+			continue
+		}
+		j.persist()
+		if errors.As(err, &cmn.AbortedError{}) {
+			glog.Infof("%s stopped: %v", j, err)
+		} else {
+			glog.Errorf("%s stopped: %v", j, err)
+		}
+		break
+	}
+	j.parent.mu.Lock()
+	delete(j.parent.joggers, j.mpathInfo.Path)
+	j.parent.mu.Unlock()
+}
+
+func (j *scrubJogger) cycle() (err error) {
+	if j.progress.Started.IsZero() {
+		j.progress.Started = time.Now()
+	}
+	j.skipping = false
+	if j.progress.Cursor != "" {
+		if j.resume, err = fs.ParseFQN(j.progress.Cursor); err != nil {
+			glog.Warningf("%s: failed to resume from %q (%v), restarting the cycle", j, j.progress.Cursor, err)
+			j.progress.Cursor, err = "", nil
+		} else {
+			j.skipping = true
+		}
+	}
+	for _, bck := range j.buckets() {
+		if j.skipping {
+			if bck.Less(j.resume.Bck) {
+				continue // done in this cycle
+			}
+			if !bck.Equal(j.resume.Bck) {
+				j.skipping = false // the cursor's bucket is gone
+			}
+		}
+		j.bck = bck
+		opts := &fs.Options{
+			Mpath:    j.mpathInfo,
+			Bck:      bck,
+			CTs:      []string{fs.ObjectType},
+			Callback: j.walk,
+			Sorted:   true,
+		}
+		if err = fs.Walk(opts); err != nil {
+			return
+		}
+	}
+	glog.Infof("%s: cycle #%d done in %v: verified %d, corrupted %d", j, j.progress.Cycle+1,
+		time.Since(j.progress.Started), j.progress.Verified, j.progress.Corrupted)
+	j.progress = ScrubProgress{Cycle: j.progress.Cycle + 1}
+	j.persist()
+	return j.wait() // at least one pause between cycles
+}
+
+// all buckets in a stable order (to resume from the cursor)
+func (j *scrubJogger) buckets() (bcks []cmn.Bck) {
+	j.parent.t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		bcks = append(bcks, bck.Bck)
+		return false
+	})
+	sort.Slice(bcks, func(i, k int) bool { return bcks[i].Less(bcks[k]) })
+	return
+}
+
+func (j *scrubJogger) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if j.skipping && j.skip(fqn) {
+		return nil
+	}
+	if err := j.wait(); err != nil {
+		return err
+	}
+	j.verify(fqn)
+	j.progress.Cursor = fqn
+	if j.num++; j.num%scrubPersistNum == 0 {
+		j.persist()
+	}
+	return nil
+}
+
+// skip the objects up to (and including) the cursor - in the walk order, so that
+// the cycle resumes from the next object even if the cursor itself is gone
+func (j *scrubJogger) skip(fqn string) bool {
+	parsed, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return true
+	}
+	if !walkLess(j.resume.ObjName, parsed.ObjName) {
+		return true
+	}
+	j.skipping = false
+	return false
+}
+
+// walkLess compares object names in the order of the (sorted) walk - directory by
+// directory, e.g.: "a/b" < "a.b"
+func walkLess(a, b string) bool {
+	for {
+		i, k := strings.IndexByte(a, '/'), strings.IndexByte(b, '/')
+		ea, eb := a, b
+		if i >= 0 {
+			ea = a[:i]
+		}
+		if k >= 0 {
+			eb = b[:k]
+		}
+		if ea != eb || i < 0 || k < 0 {
+			if ea != eb {
+				return ea < eb
+			}
+			return i < 0 && k >= 0 // file vs directory with the same name
+		}
+		a, b = a[i+1:], b[k+1:]
+	}
+}
+
+func (j *scrubJogger) verify(fqn string) {
+	lom := &cluster.LOM{T: j.parent.t, FQN: fqn}
+	if err := lom.Init(j.bck); err != nil {
+		return
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false); err != nil || lom.IsCopy() {
+		return
+	}
+	if cksum := lom.Cksum(); cksum == nil || cksum.Type() == cmn.ChecksumNone {
+		return // nothing to verify against
+	}
+	err := lom.ValidateContentChecksum()
+	j.progress.Verified++
+	j.parent.statsT.AddMany(
+		stats.NamedVal64{Name: stats.ScrubCount, Value: 1},
+		stats.NamedVal64{Name: stats.ScrubSize, Value: lom.Size()},
+	)
+	if err == nil {
+		return
+	}
+	if _, ok := err.(*cmn.BadCksumError); ok {
+		j.progress.Corrupted++
+		glog.Errorf("%s: corrupted object detected: %v", j, err)
+		j.parent.statsT.AddMany(
+			stats.NamedVal64{Name: stats.ErrCksumCount, Value: 1},
+			stats.NamedVal64{Name: stats.ErrCksumSize, Value: lom.Size()},
+		)
+		return
+	}
+	glog.Errorf("%s: %v", j, err)
+	j.parent.t.FSHC(err, fqn)
+}
+
+// pause between objects, and keep waiting while the mountpath is busy
+func (j *scrubJogger) wait() error {
+	pause := cmn.GCO.Get().Scrub.Pause
+	for {
+		select {
+		case <-j.parent.stopCh.Listen():
+			return cmn.NewAbortedError(j.String())
+		case <-time.After(pause):
+		}
+		availablePaths, _ := fs.Get()
+		if _, ok := availablePaths[j.mpathInfo.Path]; !ok {
+			return cmn.NewAbortedError(j.String() + ": mountpath is not available")
+		}
+		config := cmn.GCO.Get()
+		if config.Scrub.Enabled && j.mpathInfo.IsIdle(config, mono.NanoTime()) {
+			return nil
+		}
+		pause = scrubIdleWait
+	}
+}
+
+func (j *scrubJogger) load() {
+	fpath := filepath.Join(j.mpathInfo.Path, scrubFname)
+	if err := jsp.Load(fpath, &j.progress, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("%s: failed to load progress, err: %v", j, err)
+		j.progress = ScrubProgress{}
+	}
+}
+
+func (j *scrubJogger) persist() {
+	fpath := filepath.Join(j.mpathInfo.Path, scrubFname)
+	if err := jsp.Save(fpath, &j.progress, jsp.Plain()); err != nil {
+		glog.Errorf("%s: failed to persist progress, err: %v", j, err)
+	}
+}
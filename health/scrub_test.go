@@ -0,0 +1,114 @@
+// Package health provides a basic mountpath health monitor.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package health
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestWalkLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		less bool
+	}{
+		{"a", "b", true},
+		{"b", "a", false},
+		{"a", "a", false},
+		{"a/b", "a.b", true}, // directory "a" is walked before "a.b"
+		{"a.b", "a/b", false},
+		{"a/b", "a/c", true},
+		{"a/z/z", "b", true},
+		{"b", "a/z/z", false},
+	}
+	for _, test := range tests {
+		if walkLess(test.a, test.b) != test.less {
+			t.Errorf("walkLess(%q, %q): expected %t", test.a, test.b, test.less)
+		}
+	}
+}
+
+func TestScrubResume(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "scrub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mpath)
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	if err := fs.Add(mpath); err != nil {
+		t.Fatal(err)
+	}
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+
+	var (
+		bck      = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		mi       = fs.MountpathInfo{Path: mpath}
+		objNames = []string{"a.b", "a/b", "a/c", "b", "c/d"}
+	)
+	for _, objName := range objNames {
+		fqn := mi.MakePathFQN(bck, fs.ObjectType, objName)
+		if err := cmn.CreateDir(filepath.Dir(fqn)); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fqn, []byte(objName), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	availablePaths, _ := fs.Get()
+	mpathInfo := availablePaths[mpath]
+
+	tests := []struct {
+		cursor   string
+		deleted  bool
+		expected []string
+	}{
+		{cursor: "a/b", expected: []string{"a/c", "a.b", "b", "c/d"}},
+		{cursor: "a/bb", deleted: true, expected: []string{"a/c", "a.b", "b", "c/d"}},
+		{cursor: "a.a", deleted: true, expected: []string{"a.b", "b", "c/d"}},
+		{cursor: "c/d", expected: nil},
+	}
+	for _, test := range tests {
+		var (
+			j = &scrubJogger{mpathInfo: mpathInfo, skipping: true}
+			// objects that the cycle would verify
+			verified []string
+		)
+		j.resume, err = fs.ParseFQN(mi.MakePathFQN(bck, fs.ObjectType, test.cursor))
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck,
+			CTs:   []string{fs.ObjectType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() || (j.skipping && j.skip(fqn)) {
+					return nil
+				}
+				parsed, err := fs.ParseFQN(fqn)
+				if err != nil {
+					return err
+				}
+				verified = append(verified, parsed.ObjName)
+				return nil
+			},
+			Sorted: true,
+		}
+		if err := fs.Walk(opts); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(verified, test.expected) {
+			t.Errorf("cursor %q (deleted: %t): expected %v, got %v", test.cursor, test.deleted, test.expected, verified)
+		}
+	}
+}
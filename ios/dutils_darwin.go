@@ -6,6 +6,12 @@
 package ios
 
 import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/lufia/iostat"
 )
 
@@ -23,3 +29,23 @@ func fs2disks(fs string) (disks fsDisks) {
 		drive.Name: drive.BlockSize,
 	}
 }
+
+// isRotational returns true if the disk is a spinning one (HDD), as reported by
+// `diskutil info`. Defaults to false (solid state) when the media type cannot
+// be determined.
+func isRotational(disk string) bool {
+	out, err := exec.Command("diskutil", "info", disk).Output()
+	if err != nil {
+		glog.Warningf("%s: failed to determine media type, err: %v", disk, err)
+		return false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// e.g. "   Solid State:              Yes"
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Solid State:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Solid State:")) == "No"
+		}
+	}
+	return false
+}
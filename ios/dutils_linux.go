@@ -7,6 +7,7 @@ package ios
 
 import (
 	"flag"
+	"io/ioutil"
 	"os/exec"
 	"strings"
 
@@ -52,6 +53,16 @@ func fs2disks(fs string) (disks fsDisks) {
 	return disks
 }
 
+// isRotational returns true if the disk is a spinning one (HDD), as reported by sysfs.
+func isRotational(disk string) bool {
+	b, err := ioutil.ReadFile("/sys/class/block/" + disk + "/queue/rotational")
+	if err != nil {
+		glog.Warningf("%s: failed to determine media type, err: %v", disk, err)
+		return false
+	}
+	return strings.TrimSpace(string(b)) == "1"
+}
+
 //
 // private
 //
@@ -28,3 +28,8 @@ func GetATime(osfi os.FileInfo) time.Time {
 	// NOTE: see https://en.wikipedia.org/wiki/Stat_(system_call)#Criticism_of_atime
 	return atime
 }
+
+// GetNlink returns the number of hard links to the file
+func GetNlink(osfi os.FileInfo) uint64 {
+	return uint64(osfi.Sys().(*syscall.Stat_t).Nlink)
+}
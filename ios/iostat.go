@@ -27,6 +27,7 @@ type (
 	IostatContext struct {
 		mpathLock   sync.Mutex
 		mpath2disks map[string]fsDisks
+		rotational  map[string]bool // mpath => true if any of its disks is rotational (HDD)
 		disk2mpath  cmn.SimpleKVs
 		sorted      []string
 		disk2sysfn  cmn.SimpleKVs
@@ -34,6 +35,8 @@ type (
 		cacheLock   *sync.Mutex
 		cacheHst    [16]*ioStatCache
 		cacheIdx    int
+		xactLock    sync.Mutex
+		xacts       map[string]*XactIO // xaction => disk I/O accounting (see xactio.go)
 	}
 	SelectedDiskStats struct {
 		RBps, WBps, Util int64
@@ -62,16 +65,21 @@ type (
 		RemoveMpath(mpath string)
 		LogAppend(log []string) []string
 		GetSelectedDiskStats() (m map[string]*SelectedDiskStats)
+		IsRotational(mpath string) bool
+		AddXactIO(xio *XactIO)
+		RemoveXactIO(xio *XactIO)
 	}
 )
 
 func NewIostatContext() (ctx *IostatContext) {
 	ctx = &IostatContext{
 		mpath2disks: make(map[string]fsDisks, 10),
+		rotational:  make(map[string]bool, 10),
 		disk2mpath:  make(cmn.SimpleKVs, 10),
 		sorted:      make([]string, 0, 10),
 		disk2sysfn:  make(cmn.SimpleKVs, 10),
 		cacheLock:   &sync.Mutex{},
+		xacts:       make(map[string]*XactIO, 4),
 	}
 	for i := 0; i < len(ctx.cacheHst); i++ {
 		ctx.cacheHst[i] = newIostatCache()
@@ -116,6 +124,9 @@ func (ctx *IostatContext) AddMpath(mpath, fs string) {
 	}
 	ctx.mpath2disks[mpath] = fsdisks
 	for disk := range fsdisks {
+		if isRotational(disk) {
+			ctx.rotational[mpath] = true
+		}
 		if mp, ok := ctx.disk2mpath[disk]; ok && !config.TestingEnv() {
 			s := fmt.Sprintf("disk sharing is not permitted: mp %s, add fs %s mp %s, disk %s", mp, fs, mpath, disk)
 			cmn.AssertMsg(false, s)
@@ -191,6 +202,15 @@ func (ctx *IostatContext) RemoveMpath(mpath string) {
 		}
 	}
 	delete(ctx.mpath2disks, mpath)
+	delete(ctx.rotational, mpath)
+}
+
+// IsRotational returns true if the mountpath is (or includes) a spinning disk.
+func (ctx *IostatContext) IsRotational(mpath string) bool {
+	ctx.mpathLock.Lock()
+	rotational := ctx.rotational[mpath]
+	ctx.mpathLock.Unlock()
+	return rotational
 }
 
 func (ctx *IostatContext) GetMpathUtil(mpath string, nowTs int64) int64 {
@@ -231,6 +251,47 @@ func (ctx *IostatContext) LogAppend(lines []string) []string {
 		line := fmt.Sprintf("%s: %s/s, %s/s, %d%%", disk, rbps, wbps, util)
 		lines = append(lines, line)
 	}
+	return ctx.logXacts(lines)
+}
+
+func (ctx *IostatContext) AddXactIO(xio *XactIO) {
+	ctx.xactLock.Lock()
+	xio.prevRead, xio.prevWritten, xio.prevTs = xio.ReadBytes(), xio.WrittenBytes(), mono.NanoTime()
+	ctx.xacts[xio.name] = xio
+	ctx.xactLock.Unlock()
+}
+
+func (ctx *IostatContext) RemoveXactIO(xio *XactIO) {
+	ctx.xactLock.Lock()
+	delete(ctx.xacts, xio.name)
+	ctx.xactLock.Unlock()
+}
+
+// logXacts appends disk throughput (since the previous call) of the currently
+// running xactions - those that have read or written anything
+func (ctx *IostatContext) logXacts(lines []string) []string {
+	nowTs := mono.NanoTime()
+	ctx.xactLock.Lock()
+	names := make([]string, 0, len(ctx.xacts))
+	for name := range ctx.xacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var (
+			xio             = ctx.xacts[name]
+			read, written   = xio.ReadBytes(), xio.WrittenBytes()
+			elapsed         = nowTs - xio.prevTs
+			dread, dwritten = read - xio.prevRead, written - xio.prevWritten
+		)
+		if elapsed > 0 && (dread > 0 || dwritten > 0) {
+			rbps := cmn.B2S(dread*second/elapsed, 0)
+			wbps := cmn.B2S(dwritten*second/elapsed, 0)
+			lines = append(lines, fmt.Sprintf("%s: %s/s, %s/s", name, rbps, wbps))
+		}
+		xio.prevRead, xio.prevWritten, xio.prevTs = read, written, nowTs
+	}
+	ctx.xactLock.Unlock()
 	return lines
 }
 
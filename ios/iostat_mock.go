@@ -14,13 +14,15 @@ var _ IOStater = &IOStaterMock{}
 
 type (
 	IOStaterMock struct {
-		Utils map[string]int64
+		Utils      map[string]int64
+		Rotational map[string]bool
 	}
 )
 
 func NewIOStaterMock() *IOStaterMock {
 	return &IOStaterMock{
-		Utils: make(map[string]int64, 10),
+		Utils:      make(map[string]int64, 10),
+		Rotational: make(map[string]bool, 10),
 	}
 }
 
@@ -32,3 +34,6 @@ func (m *IOStaterMock) AddMpath(mpath, fs string)                           {}
 func (m *IOStaterMock) RemoveMpath(mpath string)                            {}
 func (m *IOStaterMock) LogAppend(l []string) []string                       { return l }
 func (m *IOStaterMock) GetSelectedDiskStats() map[string]*SelectedDiskStats { return nil }
+func (m *IOStaterMock) IsRotational(mpath string) bool                      { return m.Rotational[mpath] }
+func (m *IOStaterMock) AddXactIO(xio *XactIO)                               {}
+func (m *IOStaterMock) RemoveXactIO(xio *XactIO)                            {}
@@ -0,0 +1,70 @@
+// Package ios is a collection of interfaces to the local storage subsystem;
+// the package includes OS-dependent implementations for those interfaces.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ios
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+)
+
+// XactIO attributes local disk reads and writes to a given xaction.
+// Xactions account their I/O either via the instrumented readers and writers
+// (below) or, when a file gets handed over to a component that requires a
+// specific type (e.g., Cloud PUT), by adding the bytes explicitly.
+// While registered with the iostat context (see IOStater.AddXactIO), the
+// xaction's disk throughput is logged alongside the per-disk statistics.
+
+type (
+	XactIO struct {
+		name    string
+		read    atomic.Int64
+		written atomic.Int64
+		// previous LogAppend (protected by IostatContext.xactLock)
+		prevRead, prevWritten, prevTs int64
+	}
+	acctReader struct {
+		io.ReadCloser
+		xio *XactIO
+	}
+	acctWriter struct {
+		io.WriteCloser
+		xio *XactIO
+	}
+)
+
+func NewXactIO(name string) *XactIO { return &XactIO{name: name} }
+
+func (x *XactIO) Init(name string)    { x.name = name }
+func (x *XactIO) Name() string        { return x.name }
+func (x *XactIO) ReadBytes() int64    { return x.read.Load() }
+func (x *XactIO) WrittenBytes() int64 { return x.written.Load() }
+func (x *XactIO) ReadAdd(n int64)     { x.read.Add(n) }
+func (x *XactIO) WrittenAdd(n int64)  { x.written.Add(n) }
+
+// NewReaderAcct returns reader that counts the bytes read from the underlying
+// (file) reader as the xaction's disk reads.
+func NewReaderAcct(r io.ReadCloser, xio *XactIO) io.ReadCloser {
+	return &acctReader{ReadCloser: r, xio: xio}
+}
+
+func (r *acctReader) Read(b []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(b)
+	r.xio.ReadAdd(int64(n))
+	return
+}
+
+// NewWriterAcct returns writer that counts the bytes written to the underlying
+// (file) writer as the xaction's disk writes.
+func NewWriterAcct(w io.WriteCloser, xio *XactIO) io.WriteCloser {
+	return &acctWriter{WriteCloser: w, xio: xio}
+}
+
+func (w *acctWriter) Write(b []byte) (n int, err error) {
+	n, err = w.WriteCloser.Write(b)
+	w.xio.WrittenAdd(int64(n))
+	return
+}
@@ -0,0 +1,67 @@
+// Package ios is a collection of interfaces to the local storage subsystem;
+// the package includes OS-dependent implementations for those interfaces.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ios
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXactIO(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		ctx  = NewIostatContext()
+		xio  = NewXactIO("mirror[xyz]")
+		fqn  = filepath.Join(dir, "obj")
+		data = strings.Repeat("0123456789", 100)
+	)
+	ctx.AddXactIO(xio)
+
+	file, err := os.Create(fqn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWriterAcct(file, xio)
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	if file, err = os.Open(fqn); err != nil {
+		t.Fatal(err)
+	}
+	r := NewReaderAcct(file, xio)
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if xio.ReadBytes() != int64(len(data)) || xio.WrittenBytes() != int64(len(data)) {
+		t.Fatalf("expected %d bytes read and written, got %d and %d", len(data), xio.ReadBytes(), xio.WrittenBytes())
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	lines := ctx.logXacts(nil)
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "mirror[xyz]: ") {
+		t.Fatalf("unexpected log %v", lines)
+	}
+	// idle since the previous log
+	if lines = ctx.logXacts(nil); len(lines) != 0 {
+		t.Fatalf("unexpected log %v", lines)
+	}
+	ctx.RemoveXactIO(xio)
+	xio.ReadAdd(1)
+	if lines = ctx.logXacts(nil); len(lines) != 0 {
+		t.Fatalf("unexpected log %v", lines)
+	}
+}
@@ -0,0 +1,286 @@
+// Package lifecycle provides age-based expiration of stored objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// Lifecycle enforces per-bucket object expiration rules (cmn.LifecycleConf):
+//   * `lifecycle.ttl` - delete objects that have not been accessed for longer than TTL;
+//   * `lifecycle.delete_after` - delete objects created (PUT) longer than that ago;
+//   * `lifecycle.evict_cloud_after` - evict (remove the cached copies of) Cloud
+//     objects that have not been accessed for longer than that.
+//
+// Unlike LRU, lifecycle does not depend on capacity usage: the target runs it
+// periodically (every Interval) and on demand (start xaction "lifecycle").
+// Similar to LRU, there's one jogger per mountpath; the joggers throttle
+// themselves when the mountpath is busy. Buckets without lifecycle rules are
+// skipped; so are objects that are not at their HRW location (resilvering
+// takes care of those).
+
+// Interval between the periodic runs (see ais/tgtlifecycle.go)
+const Interval = time.Hour
+
+type (
+	Init struct {
+		T       cluster.Target
+		Xaction *Xaction
+		Buckets []cmn.Bck // buckets to enforce the rules of (all buckets, if empty)
+	}
+
+	XactProvider struct {
+		registry.BaseGlobalEntry
+		xact *Xaction
+		id   string
+	}
+	Xaction struct {
+		xaction.XactBase
+		deleted atomic.Int64
+		evicted atomic.Int64
+	}
+
+	// the rules of a given bucket (zero - not set)
+	rules struct {
+		ttl, deleteAfter, evictAfter time.Duration
+	}
+	// jogger traverses a single mountpath
+	jogger struct {
+		ini       *Init
+		mpathInfo *fs.MountpathInfo
+		bck       *cluster.Bck
+		rules     rules
+		config    *cmn.Config
+		now       time.Time
+		num       int64 // objects visited since the last throttle check
+	}
+)
+
+// expiration actions
+const (
+	actNone = iota
+	actDelete
+	actEvict
+)
+
+const throttleNum = 1000 // check mountpath utilization every so many objects
+
+func init() {
+	registry.Registry.RegisterGlobalXact(&XactProvider{})
+}
+
+//////////////////
+// XactProvider //
+//////////////////
+
+func (*XactProvider) New(args registry.XactArgs) registry.GlobalEntry {
+	return &XactProvider{id: args.UUID}
+}
+
+func (p *XactProvider) Start(_ cmn.Bck) error {
+	p.xact = &Xaction{XactBase: *xaction.NewXactBase(xaction.XactBaseID(p.id), cmn.ActLifecycle)}
+	return nil
+}
+func (*XactProvider) Kind() string                               { return cmn.ActLifecycle }
+func (p *XactProvider) Get() cluster.Xact                        { return p.xact }
+func (p *XactProvider) PreRenewHook(_ registry.GlobalEntry) bool { return true } // one at a time
+
+/////////////
+// Xaction //
+/////////////
+
+func (r *Xaction) IsMountpathXact() bool { return true }
+
+// Run enforces the rules on all mountpaths in parallel (blocking).
+func Run(ini *Init) {
+	var (
+		r                 = ini.Xaction
+		availablePaths, _ = fs.Get()
+		wg                = &sync.WaitGroup{}
+		config            = cmn.GCO.Get()
+	)
+	glog.Infof("%s: %s started", ini.T.Snode(), r)
+	if len(availablePaths) == 0 {
+		r.Finish(errors.New(cmn.NoMountpaths))
+		return
+	}
+	errCh := make(chan error, len(availablePaths))
+	for _, mpathInfo := range availablePaths {
+		wg.Add(1)
+		j := &jogger{ini: ini, mpathInfo: mpathInfo, config: config}
+		go func() {
+			defer wg.Done()
+			if err := j.jog(); err != nil && !os.IsNotExist(err) {
+				errCh <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	err := <-errCh
+	if err != nil {
+		glog.Errorf("%s: %v", r, err)
+	} else {
+		glog.Infof("%s: %s done: deleted %d, evicted %d object(s) (%s)", ini.T.Snode(), r,
+			r.deleted.Load(), r.evicted.Load(), cmn.B2S(r.BytesCount(), 2))
+	}
+	r.Finish(err)
+}
+
+///////////
+// rules //
+///////////
+
+// action returns what to do with the object accessed at `atime` and created at `mtime`
+func (rs *rules) action(atime, mtime, now time.Time, remote bool) int {
+	switch {
+	case rs.ttl > 0 && now.Sub(atime) > rs.ttl:
+		return actDelete
+	case rs.deleteAfter > 0 && now.Sub(mtime) > rs.deleteAfter:
+		return actDelete
+	case remote && rs.evictAfter > 0 && now.Sub(atime) > rs.evictAfter:
+		return actEvict
+	}
+	return actNone
+}
+
+////////////
+// jogger //
+////////////
+
+func (j *jogger) String() string {
+	return fmt.Sprintf("%s: (%s, %s)", j.ini.T.Snode(), j.ini.Xaction, j.mpathInfo)
+}
+
+func (j *jogger) jog() error {
+	bcks := j.ini.Buckets
+	if len(bcks) == 0 {
+		for _, provider := range cmn.Providers.Keys() {
+			opts := fs.Options{Mpath: j.mpathInfo, Bck: cmn.Bck{Provider: provider, Ns: cmn.NsGlobal}}
+			provBcks, err := fs.AllMpathBcks(&opts)
+			if err != nil {
+				return err
+			}
+			bcks = append(bcks, provBcks...)
+		}
+	}
+	for _, bck := range bcks {
+		if err := j.jogBck(bck); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jogger) jogBck(bck cmn.Bck) (err error) {
+	j.bck = cluster.NewBckEmbed(bck)
+	if err = j.bck.Init(j.ini.T.Bowner(), j.ini.T.Snode()); err != nil {
+		glog.Errorf("%s: %v - skipping %s", j, err, bck)
+		return nil
+	}
+	if !j.bck.Props.Lifecycle.Enabled() {
+		return nil
+	}
+	if err = j.bck.Allow(cmn.AccessObjDELETE); err != nil {
+		glog.Warningf("%s: %v - skipping %s", j, err, j.bck)
+		return nil
+	}
+	r := &j.rules
+	if r.ttl, r.deleteAfter, r.evictAfter, err = j.bck.Props.Lifecycle.Parse(); err != nil {
+		glog.Errorf("%s: %v - skipping %s", j, err, j.bck)
+		return nil
+	}
+	j.now = time.Now()
+	opts := &fs.Options{
+		Mpath:    j.mpathInfo,
+		Bck:      bck,
+		CTs:      []string{fs.ObjectType},
+		Callback: j.walk,
+		Sorted:   false,
+	}
+	return fs.Walk(opts)
+}
+
+func (j *jogger) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yield(); err != nil {
+		return err
+	}
+	lom := &cluster.LOM{T: j.ini.T, FQN: fqn}
+	if err := lom.Init(j.bck.Bck, j.config); err != nil {
+		return nil
+	}
+	if err := lom.Load(false); err != nil {
+		return nil
+	}
+	if !lom.IsHRW() {
+		return nil
+	}
+	mtime := j.now
+	if j.rules.deleteAfter > 0 {
+		finfo, err := os.Stat(fqn)
+		if err != nil {
+			return nil
+		}
+		mtime = finfo.ModTime()
+	}
+	var (
+		size  = lom.Size()
+		evict bool
+	)
+	switch j.rules.action(lom.Atime(), mtime, j.now, lom.Bck().IsRemote()) {
+	case actNone:
+		return nil
+	case actEvict:
+		evict = true
+	}
+	if err, _ := j.ini.T.DeleteObject(context.Background(), lom, evict); err != nil {
+		if !cmn.IsObjNotExist(err) {
+			glog.Errorf("%s: failed to expire %s: %v", j, lom, err)
+		}
+		return nil
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: expired %s (evict: %t)", j, lom, evict)
+	}
+	r := j.ini.Xaction
+	if evict {
+		r.evicted.Inc()
+	} else {
+		r.deleted.Inc()
+	}
+	r.ObjectsInc()
+	r.BytesAdd(size)
+	return nil
+}
+
+func (j *jogger) yield() error {
+	r := j.ini.Xaction
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	j.num++
+	if j.num < throttleNum {
+		return nil
+	}
+	j.num = 0
+	fs.Throttle(j.mpathInfo, fs.QoSLow)
+	return nil
+}
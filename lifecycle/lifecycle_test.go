@@ -0,0 +1,55 @@
+// Package lifecycle provides age-based expiration of stored objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLifecycle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lifecycle Suite")
+}
+
+var _ = Describe("rules", func() {
+	var (
+		now     = time.Now()
+		hourAgo = now.Add(-time.Hour)
+		weekAgo = now.Add(-7 * 24 * time.Hour)
+	)
+
+	It("should keep everything when no rules are set", func() {
+		rs := &rules{}
+		Expect(rs.action(weekAgo, weekAgo, now, true)).To(Equal(actNone))
+	})
+
+	It("should delete objects not accessed for longer than TTL", func() {
+		rs := &rules{ttl: 24 * time.Hour}
+		Expect(rs.action(weekAgo, weekAgo, now, false)).To(Equal(actDelete))
+		Expect(rs.action(hourAgo, weekAgo, now, false)).To(Equal(actNone))
+	})
+
+	It("should delete objects created longer than delete_after ago", func() {
+		rs := &rules{deleteAfter: 24 * time.Hour}
+		Expect(rs.action(hourAgo, weekAgo, now, false)).To(Equal(actDelete))
+		Expect(rs.action(weekAgo, hourAgo, now, false)).To(Equal(actNone))
+	})
+
+	It("should evict only Cloud objects", func() {
+		rs := &rules{evictAfter: 24 * time.Hour}
+		Expect(rs.action(weekAgo, weekAgo, now, true)).To(Equal(actEvict))
+		Expect(rs.action(weekAgo, weekAgo, now, false)).To(Equal(actNone))
+		Expect(rs.action(hourAgo, weekAgo, now, true)).To(Equal(actNone))
+	})
+
+	It("should prefer deletion to eviction", func() {
+		rs := &rules{ttl: 48 * time.Hour, evictAfter: 24 * time.Hour}
+		Expect(rs.action(weekAgo, weekAgo, now, true)).To(Equal(actDelete))
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +34,10 @@ import (
 // When and if exceeded, AIStore target will start gradually evicting objects from its
 // stable storage: oldest first access-time wise.
 //
+// Buckets are evicted in the order of their LRU priority (bucket property lru.priority):
+// lower-priority buckets first, and higher-priority ones only if the former do not free
+// up enough space. Pinned objects (see cluster.PinnedObjMD) are never evicted.
+//
 // LRU is implemented as a so-called extended action (aka x-action, see xaction.go) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.LRU.HighWM). LRU then
 // runs automatically. In order to reduce its impact on the live workload, LRU throttles itself
@@ -96,6 +101,7 @@ type (
 		// runtime
 		throttle    bool
 		allowDelObj bool
+		dontEvict   []string // object name prefixes (see cmn.LRUConf.DontEvictPrefixes)
 	}
 
 	XactProvider struct {
@@ -244,7 +250,8 @@ func (j *lruJ) stop() { j.stopCh <- struct{}{} }
 
 func (j *lruJ) jog(providers []string) (err error) {
 	glog.Infof("%s: freeing-up %s", j, cmn.B2S(j.totalSize, 2))
-	for _, provider := range providers { // for each provider (note: ordering is random)
+	var all []cmn.Bck
+	for _, provider := range providers { // all buckets of all providers - to be evicted in priority order
 		var (
 			bcks []cmn.Bck
 			opts = fs.Options{
@@ -255,12 +262,9 @@ func (j *lruJ) jog(providers []string) (err error) {
 		if bcks, err = fs.AllMpathBcks(&opts); err != nil {
 			return
 		}
-
-		if err = j.jogBcks(bcks, false); err != nil {
-			return
-		}
+		all = append(all, bcks...)
 	}
-	return
+	return j.jogBcks(all, false)
 }
 
 func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
@@ -268,7 +272,7 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		return
 	}
 	if len(bcks) > 1 {
-		j.sortBsize(bcks)
+		j.sortBcks(bcks)
 	}
 
 	for _, bck := range bcks { // for each bucket under a given provider
@@ -301,6 +305,9 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 func (j *lruJ) removeTrash() (err error) {
 	trashDir := j.mpathInfo.MakePathTrash()
 	err = fs.Scanner(trashDir, func(fqn string, de fs.DirEntry) error {
+		if _, deadline, ok := fs.ParseUndoDir(filepath.Base(fqn)); ok && time.Now().Before(deadline) {
+			return nil // deleted with undo window (see fs.PurgeUndo)
+		}
 		if de.IsDir() {
 			if err := os.RemoveAll(fqn); err == nil {
 				usedPct, ok := j.ini.GetFSUsedPercentage(j.mpathInfo.Path)
@@ -372,10 +379,16 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if !j.allowDelObj {
 		return nil // ===>
 	}
+	if j.keep(lom.ObjName) {
+		return nil
+	}
 	err = lom.Load(false)
 	if err != nil {
 		return nil
 	}
+	if lom.IsPinned() {
+		return nil
+	}
 	if lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
 		return nil
 	}
@@ -544,8 +557,8 @@ func (j *lruJ) yieldTerm() error {
 	default:
 		if j.throttle {
 			time.Sleep(cmn.ThrottleMin)
+			fs.Throttle(j.mpathInfo, fs.QoSLow)
 		}
-		break
 	}
 	if xlru.Finished() {
 		return cmn.NewAbortedError(xlru.String())
@@ -553,18 +566,29 @@ func (j *lruJ) yieldTerm() error {
 	return nil
 }
 
-// sort buckets by size
-func (j *lruJ) sortBsize(bcks []cmn.Bck) {
-	sized := make([]struct {
-		b cmn.Bck
-		v uint64
-	}, len(bcks))
+// sort buckets by LRU priority (lowest first - evicted first) and, within the
+// same priority, by size (largest first)
+func (j *lruJ) sortBcks(bcks []cmn.Bck) {
+	var (
+		bowner = j.ini.T.Bowner()
+		sized  = make([]struct {
+			b        cmn.Bck
+			v        uint64
+			priority int64
+		}, len(bcks))
+	)
 	for i := range bcks {
 		path := j.mpathInfo.MakePathCT(bcks[i], fs.ObjectType)
 		sized[i].b = bcks[i]
 		sized[i].v, _ = ios.GetDirSize(path)
+		if b := cluster.NewBckEmbed(bcks[i]); b.Init(bowner, nil) == nil {
+			sized[i].priority = b.Props.LRU.Priority
+		}
 	}
 	sort.Slice(sized, func(i, j int) bool {
+		if sized[i].priority != sized[j].priority {
+			return sized[i].priority < sized[j].priority
+		}
 		return sized[i].v > sized[j].v
 	})
 	for i := range bcks {
@@ -572,6 +596,16 @@ func (j *lruJ) sortBsize(bcks []cmn.Bck) {
 	}
 }
 
+// keep returns true if the object must never be evicted
+func (j *lruJ) keep(objName string) bool {
+	for _, prefix := range j.dontEvict {
+		if strings.HasPrefix(objName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (j *lruJ) allow() (ok bool, err error) {
 	var (
 		bowner = j.ini.T.Bowner()
@@ -581,6 +615,7 @@ func (j *lruJ) allow() (ok bool, err error) {
 		return
 	}
 	ok = b.Props.LRU.Enabled && b.Allow(cmn.AccessObjDELETE) == nil
+	j.dontEvict = b.Props.LRU.DontEvictPrefixList()
 	return
 }
 
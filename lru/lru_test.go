@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/lru"
+	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/tutils"
 	"github.com/NVIDIA/aistore/xaction"
@@ -43,6 +44,10 @@ const (
 	basePath             = "/tmp/lru-tests"
 	bucketName           = "lru-bck"
 	bucketNameAnother    = bucketName + "-another"
+	bucketNameKeep       = bucketName + "-keep"
+	bucketNameLow        = bucketName + "-low"
+	bucketNameHigh       = bucketName + "-high"
+	keepPrefix           = "manifest/"
 )
 
 type fileMetadata struct {
@@ -93,6 +98,30 @@ func newTargetLRUMock() *cluster.TargetMock {
 					Access: cmn.AllAccess(),
 				},
 			),
+			cluster.NewBck(
+				bucketNameKeep, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cmn.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, DontEvictPrefixes: "index/, " + keepPrefix},
+					Access: cmn.AllAccess(),
+				},
+			),
+			cluster.NewBck(
+				bucketNameLow, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cmn.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Priority: 1},
+					Access: cmn.AllAccess(),
+				},
+			),
+			cluster.NewBck(
+				bucketNameHigh, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cmn.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Priority: 10},
+					Access: cmn.AllAccess(),
+				},
+			),
 		)
 		tMock = cluster.NewTargetMock(bmdMock)
 	)
@@ -125,7 +154,7 @@ func initConfig() {
 
 func createAndAddMountpath(path string) {
 	cmn.CreateDir(path)
-	fs.Init()
+	fs.Init(ios.NewIOStaterMock())
 	fs.Add(path)
 
 	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
@@ -148,6 +177,18 @@ func saveRandomFile(t cluster.Target, filename string, size int64) {
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
+func pinFiles(t cluster.Target, filesPath string) {
+	files, err := ioutil.ReadDir(filesPath)
+	Expect(err).NotTo(HaveOccurred())
+	for _, file := range files {
+		lom := &cluster.LOM{T: t, FQN: path.Join(filesPath, file.Name())}
+		Expect(lom.Init(cmn.Bck{})).NotTo(HaveOccurred())
+		Expect(lom.Load(false)).NotTo(HaveOccurred())
+		lom.SetCustomMD(cmn.SimpleKVs{cluster.PinnedObjMD: "true"})
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+	}
+}
+
 func saveRandomFilesWithMetadata(t cluster.Target, filesPath string, files []fileMetadata) {
 	for _, file := range files {
 		saveRandomFile(t, path.Join(filesPath, file.name), file.size)
@@ -297,6 +338,37 @@ var _ = Describe("LRU tests", func() {
 				// to many files evicted
 				Expect(float64(numFilesLeftAnother+1) / numberOfCreatedFiles * initialDiskUsagePct).To(BeNumerically(">", 0.01*lwm))
 			})
+
+			It("should evict lower-priority buckets first", func() {
+				var (
+					mpaths, _ = fs.Get()
+					bckLow    = cmn.Bck{Name: bucketNameLow, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+					bckHigh   = cmn.Bck{Name: bucketNameHigh, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+					fpLow     = mpaths[basePath].MakePathCT(bckLow, fs.ObjectType)
+					fpHigh    = mpaths[basePath].MakePathCT(bckHigh, fs.ObjectType)
+					numLow    = numberOfCreatedFiles / 3
+					numHigh   = numberOfCreatedFiles - numLow
+				)
+				cmn.CreateDir(fpLow)
+				cmn.CreateDir(fpHigh)
+				// the high-priority bucket is the larger one - size alone would get it evicted first
+				saveRandomFiles(t, fpLow, numLow)
+				saveRandomFiles(t, fpHigh, numHigh)
+
+				lru.Run(ini)
+
+				filesLow, err := ioutil.ReadDir(fpLow)
+				Expect(err).NotTo(HaveOccurred())
+				filesHigh, err := ioutil.ReadDir(fpHigh)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(len(filesLow)).To(Equal(0))
+				Expect(len(filesHigh)).To(BeNumerically(">", 0))
+				Expect(len(filesHigh)).To(BeNumerically("<", numHigh))
+
+				numFilesLeft := len(filesLow) + len(filesHigh)
+				Expect(float64(numFilesLeft) / numberOfCreatedFiles * initialDiskUsagePct).To(BeNumerically("<=", 0.01*lwm))
+			})
 		})
 
 		Describe("not evict files", func() {
@@ -335,6 +407,35 @@ var _ = Describe("LRU tests", func() {
 				Expect(len(files)).To(Equal(numberOfFiles))
 			})
 
+			It("should not evict objects with dont_evict_prefixes", func() {
+				var (
+					mpaths, _ = fs.Get()
+					bckKeep   = cmn.Bck{Name: bucketNameKeep, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+					fpKeep    = mpaths[basePath].MakePathCT(bckKeep, fs.ObjectType)
+					keepPath  = path.Join(fpKeep, keepPrefix)
+				)
+				cmn.CreateDir(keepPath)
+				saveRandomFiles(t, keepPath, numberOfCreatedFiles)
+
+				ini.Buckets = []cmn.Bck{bckKeep}
+				lru.Run(ini)
+
+				files, err := ioutil.ReadDir(keepPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfCreatedFiles))
+			})
+
+			It("should not evict pinned objects", func() {
+				saveRandomFiles(t, filesPath, numberOfCreatedFiles)
+				pinFiles(t, filesPath)
+
+				lru.Run(ini)
+
+				files, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfCreatedFiles))
+			})
+
 			It("should not evict if LRU disabled and force is false", func() {
 				saveRandomFiles(t, fpAnother, numberOfCreatedFiles)
 
@@ -0,0 +1,213 @@
+// Package mdindex provides inverted index over the custom metadata of objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mdindex
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+)
+
+// Each target indexes the custom metadata of the objects it stores, one
+// collection per bucket (and per bucket ID - a recreated bucket starts from
+// scratch) in the target's local database (see dbdriver):
+//   * "p<sepa>key<sepa>value<sepa>object" - postings, one per indexed key-value pair;
+//   * "o<sepa>object" - indexed key-value pairs of the object ("key<sepa>value<sepa>..."),
+//     to remove its postings when the object gets overwritten, updated, or deleted.
+// MDOpEq condition is then a prefix lookup, while numeric conditions scan
+// the postings of a given key (but not the bucket).
+//
+// Postings of the objects that are no longer stored by the target (e.g.,
+// migrated by rebalance - and indexed by their new targets upon receive)
+// are removed lazily - upon query.
+
+const (
+	sepa       = "\x00"
+	postingPfx = "p" + sepa
+	objPfx     = "o" + sepa
+)
+
+type Index struct {
+	db   dbdriver.Driver
+	conf *cmn.MDIndexConf
+	coll string
+}
+
+// New returns the index of a given bucket; the bucket must be initialized.
+func New(db dbdriver.Driver, bck *cluster.Bck) *Index {
+	return &Index{
+		db:   db,
+		conf: &bck.Props.MDIndex,
+		coll: "mdindex-" + strconv.FormatUint(bck.Props.BID, 10),
+	}
+}
+
+func posting(key, value, objName string) string {
+	return postingPfx + key + sepa + value + sepa + objName
+}
+
+// Update (re)indexes custom metadata of the object.
+func (idx *Index) Update(objName string, md cmn.SimpleKVs) error {
+	indexed := make(cmn.SimpleKVs, len(md))
+	for k, v := range md {
+		if idx.indexed(k, v) {
+			indexed[k] = v
+		}
+	}
+	prev, err := idx.get(objName)
+	if err != nil {
+		return err
+	}
+	for k, v := range prev {
+		if nv, ok := indexed[k]; ok && nv == v {
+			continue
+		}
+		if err := idx.db.Delete(idx.coll, posting(k, v, objName)); err != nil && !dbdriver.IsErrNotFound(err) {
+			return err
+		}
+	}
+	for k, v := range indexed {
+		if pv, ok := prev[k]; ok && pv == v {
+			continue
+		}
+		if err := idx.db.SetString(idx.coll, posting(k, v, objName), ""); err != nil {
+			return err
+		}
+	}
+	if len(indexed) == 0 {
+		if len(prev) == 0 {
+			return nil
+		}
+		return idx.db.Delete(idx.coll, objPfx+objName)
+	}
+	return idx.db.SetString(idx.coll, objPfx+objName, encode(indexed))
+}
+
+// indexed key-value pairs of the object
+func (idx *Index) get(objName string) (cmn.SimpleKVs, error) {
+	s, err := idx.db.GetString(idx.coll, objPfx+objName)
+	if err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			err = nil
+		}
+		return cmn.SimpleKVs{}, err
+	}
+	return decode(s), nil
+}
+
+func encode(md cmn.SimpleKVs) string {
+	kvs := make([]string, 0, 2*len(md))
+	for k, v := range md {
+		kvs = append(kvs, k, v)
+	}
+	return strings.Join(kvs, sepa)
+}
+
+func decode(s string) cmn.SimpleKVs {
+	kvs := strings.Split(s, sepa)
+	md := make(cmn.SimpleKVs, len(kvs)/2)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		md[kvs[i]] = kvs[i+1]
+	}
+	return md
+}
+
+// Drop removes the entire index (e.g., when the bucket gets destroyed).
+func (idx *Index) Drop() error { return idx.db.DeleteCollection(idx.coll) }
+
+// Remove removes the object from the index.
+func (idx *Index) Remove(objName string) error {
+	return idx.Update(objName, nil)
+}
+
+func (idx *Index) indexed(key, value string) bool {
+	if !idx.conf.Indexed(key) || strings.Contains(value, sepa) {
+		return false
+	}
+	// custom metadata that aistore itself maintains is not indexed unless listed explicitly
+	return idx.conf.Keys != "" || !cluster.IsInternalObjMD(key)
+}
+
+// Query returns (sorted) names of the objects that satisfy all the conditions;
+// `exists` confirms that the object is still stored by the target - otherwise,
+// the object gets removed from the index. Zero limit - no limit.
+func (idx *Index) Query(conds []cmn.MDCond, limit int, exists func(objName string) bool) ([]string, error) {
+	if len(conds) == 0 {
+		return []string{}, nil
+	}
+	// equality first - a prefix lookup and, typically, fewer candidates
+	conds = append([]cmn.MDCond{}, conds...)
+	sort.SliceStable(conds, func(i, j int) bool { return !conds[i].Numeric() && conds[j].Numeric() })
+
+	candidates, err := idx.lookup(&conds[0])
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(candidates))
+outer:
+	for _, objName := range candidates {
+		if len(conds) > 1 {
+			md, err := idx.get(objName)
+			if err != nil {
+				continue
+			}
+			for i := 1; i < len(conds); i++ {
+				if v, ok := md[conds[i].Key]; !ok || !conds[i].Match(v) {
+					continue outer
+				}
+			}
+		}
+		names = append(names, objName)
+	}
+	sort.Strings(names)
+
+	res := names[:0]
+	for _, objName := range names {
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+		if exists != nil && !exists(objName) {
+			idx.Remove(objName)
+			continue
+		}
+		res = append(res, objName)
+	}
+	return res, nil
+}
+
+// names of the objects that satisfy a given condition
+func (idx *Index) lookup(cond *cmn.MDCond) ([]string, error) {
+	var prefix string
+	if cond.Numeric() {
+		prefix = postingPfx + cond.Key + sepa
+	} else {
+		prefix = posting(cond.Key, cond.Value, "")
+	}
+	postings, err := idx.db.GetAll(idx.coll, prefix)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(postings))
+	for p := range postings {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rest := p[len(prefix):]
+		if !cond.Numeric() {
+			names = append(names, rest)
+			continue
+		}
+		i := strings.Index(rest, sepa)
+		if i < 0 || !cond.Match(rest[:i]) {
+			continue
+		}
+		names = append(names, rest[i+len(sepa):])
+	}
+	return names, nil
+}
@@ -0,0 +1,99 @@
+// Package mdindex provides inverted index over the custom metadata of objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mdindex
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+)
+
+func TestMDIndex(t *testing.T) {
+	var (
+		props = &cmn.BucketProps{BID: 1, MDIndex: cmn.MDIndexConf{Enabled: true}}
+		bck   = cluster.NewBck("images", cmn.ProviderAIS, cmn.NsGlobal, props)
+		idx   = New(dbdriver.NewDBMock(), bck)
+		query = func(limit int, conds ...string) []string {
+			mdConds := make([]cmn.MDCond, 0, len(conds))
+			for _, s := range conds {
+				cond, err := cmn.ParseMDCond(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				mdConds = append(mdConds, cond)
+			}
+			names, err := idx.Query(mdConds, limit, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return names
+		}
+		check = func(names []string, expected ...string) {
+			t.Helper()
+			if expected == nil {
+				expected = []string{}
+			}
+			if !reflect.DeepEqual(names, expected) {
+				t.Fatalf("expected %v, got %v", expected, names)
+			}
+		}
+	)
+	for objName, md := range map[string]cmn.SimpleKVs{
+		"001.jpg": {"label": "cat", "width": "640"},
+		"002.jpg": {"label": "dog", "width": "1024"},
+		"003.jpg": {"label": "cat", "width": "320"},
+		"004.jpg": {"label": "cat", "width": "1280"},
+	} {
+		if err := idx.Update(objName, md); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check(query(0, "label=cat"), "001.jpg", "003.jpg", "004.jpg")
+	check(query(0, "width>=640"), "001.jpg", "002.jpg", "004.jpg")
+	check(query(0, "label=cat", "width>=640"), "001.jpg", "004.jpg")
+	check(query(0, "width<640", "label=cat"), "003.jpg")
+	check(query(2, "label=cat"), "001.jpg", "003.jpg")
+	check(query(0, "label=bird"))
+
+	// overwrite (the old values are no longer indexed)
+	if err := idx.Update("001.jpg", cmn.SimpleKVs{"label": "dog"}); err != nil {
+		t.Fatal(err)
+	}
+	check(query(0, "label=cat"), "003.jpg", "004.jpg")
+	check(query(0, "label=dog"), "001.jpg", "002.jpg")
+	check(query(0, "width>0"), "002.jpg", "003.jpg", "004.jpg")
+
+	if err := idx.Remove("002.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	check(query(0, "label=dog"), "001.jpg")
+
+	// objects that are no longer stored get removed lazily
+	names, err := idx.Query([]cmn.MDCond{{Key: "label", Op: cmn.MDOpEq, Value: "cat"}}, 0,
+		func(objName string) bool { return objName != "003.jpg" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(names, "004.jpg")
+	check(query(0, "width<=320"))
+
+	// only the listed keys
+	props.MDIndex.Keys = "width"
+	if err := idx.Update("005.jpg", cmn.SimpleKVs{"label": "cat", "width": "320"}); err != nil {
+		t.Fatal(err)
+	}
+	check(query(0, "width=320"), "005.jpg")
+	check(query(0, "label=cat"), "004.jpg")
+
+	// destroyed bucket
+	if err := idx.Drop(); err != nil {
+		t.Fatal(err)
+	}
+	check(query(0, "width>0"))
+}
@@ -0,0 +1,60 @@
+// Package memsys provides memory management and Slab allocation
+// with io.Reader and io.Writer interfaces on top of a scatter-gather lists
+// (of reusable buffers)
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package memsys
+
+import (
+	"unsafe"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AlignBuf", func() {
+	addr := func(b []byte) uintptr { return uintptr(unsafe.Pointer(&b[0])) }
+
+	// returns the subslice of `buf` that starts `off` bytes past a page boundary
+	atOffset := func(buf []byte, off, size int) []byte {
+		start := int((PageSize-addr(buf)%PageSize)%PageSize) + off
+		return buf[start : start+size]
+	}
+
+	It("should align the buffer", func() {
+		var (
+			buf     = atOffset(make([]byte, 8*PageSize), 100, 3*PageSize+200)
+			aligned = AlignBuf(buf)
+		)
+		Expect(aligned).NotTo(BeNil())
+		Expect(addr(aligned) % PageSize).To(BeZero())
+		Expect(len(aligned) % PageSize).To(BeZero())
+		Expect(cap(aligned)).To(Equal(len(aligned)))
+		Expect(len(aligned)).To(Equal(2 * PageSize)) // skipping the first PageSize-100 bytes
+
+		// within the original buffer
+		Expect(addr(aligned) >= addr(buf)).To(BeTrue())
+		Expect(addr(aligned)+uintptr(len(aligned)) <= addr(buf)+uintptr(len(buf))).To(BeTrue())
+	})
+
+	It("should return the buffer as is if already aligned", func() {
+		var (
+			buf     = atOffset(make([]byte, 4*PageSize), 0, 2*PageSize)
+			aligned = AlignBuf(buf)
+		)
+		Expect(addr(aligned)).To(Equal(addr(buf)))
+		Expect(len(aligned)).To(Equal(len(buf)))
+	})
+
+	It("should return nil for undersized buffers", func() {
+		Expect(AlignBuf(nil)).To(BeNil())
+		Expect(AlignBuf(make([]byte, PageSize-1))).To(BeNil())
+
+		// large enough but no aligned page fits
+		buf := atOffset(make([]byte, 4*PageSize), 1, PageSize)
+		Expect(AlignBuf(buf)).To(BeNil())
+		buf = atOffset(make([]byte, 4*PageSize), 1, 2*PageSize-2)
+		Expect(AlignBuf(buf)).To(BeNil())
+	})
+})
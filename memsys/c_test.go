@@ -20,6 +20,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/tutils"
 	"github.com/NVIDIA/aistore/tutils/tassert"
@@ -87,3 +88,31 @@ func TestSGLStressN(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestAllocStats(t *testing.T) {
+	mem := &memsys.MMSA{MinPctFree: 50, Name: "smem"}
+	err := mem.Init(true /*panic on error*/)
+	defer mem.Terminate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf1, slab1 := mem.Alloc(memsys.PageSize)
+	buf2, _ := mem.Alloc(memsys.PageSize + 1)
+	buf3, _ := mem.Alloc(memsys.MaxPageSlabSize)
+	sgl := mem.NewSGL(10 * cmn.MiB)
+	defer sgl.Free()
+	mem.Free(buf2)
+	mem.Free(buf3)
+
+	stats := mem.GetStats()
+	expected := [memsys.NumAllocBins]uint64{0, 0, 1, 1, 1, 0, 1, 0} // 4KiB, 32KiB, 128KiB, 16MiB
+	tassert.Errorf(t, stats.AllocHist == expected, "expected alloc histogram %v, got %v", expected, stats.AllocHist)
+	idx := slab1.Size()/memsys.PageSlabIncStep - 1
+	tassert.Errorf(t, stats.Hits[idx] == 1, "expected 1 hit, got %d", stats.Hits[idx])
+	tassert.Errorf(t, stats.Depth[idx] > 0, "expected free buffers in %s", slab1.Tag())
+	tassert.Errorf(t, stats.SlabSize(int(idx)) == slab1.Size(), "expected slab size %d, got %d",
+		slab1.Size(), stats.SlabSize(int(idx)))
+	slab1.Free(buf1)
+	depth := mem.GetStats().Depth[idx]
+	tassert.Errorf(t, depth == stats.Depth[idx]+1, "expected depth %d, got %d", stats.Depth[idx]+1, depth)
+}
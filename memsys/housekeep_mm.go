@@ -52,13 +52,34 @@ func (r *MMSA) FreeSpec(spec FreeSpec) {
 	}
 }
 
+// GetStats returns per-slab hits, idle durations, and ring depths, as well as
+// the allocation-size histogram and the size accumulated for GC.
 func (r *MMSA) GetStats() (stats *Stats) {
 	r.slabStats.RLock()
 	stats = r.snapStats()
 	r.slabStats.RUnlock()
+	for i, s := range r.rings {
+		stats.Depth[i] = s.depth()
+	}
+	for i := range r.allocHist {
+		stats.AllocHist[i] = r.allocHist[i].Load()
+	}
+	stats.ToGC = r.toGC.Load()
+	stats.MinFree, stats.LowWM = r.MinFree, r.lowWM
+	stats.Pressure = r.MemPressure()
+	stats.Swapping, stats.SwapUsed = r.Swapping.Load(), r.swap.Load()
+	stats.Small = r.Small
 	return
 }
 
+// SlabSize returns the buffer size of the i-th slab.
+func (stats *Stats) SlabSize(i int) int64 {
+	if stats.Small {
+		return SmallSlabIncStep * int64(i+1)
+	}
+	return PageSlabIncStep * int64(i+1)
+}
+
 //
 // private
 //
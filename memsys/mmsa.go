@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -109,6 +110,14 @@ const (
 )
 const NumStats = NumPageSlabs // NOTE: must be greater or equal NumSmallSlabs, otherwise out-of-bounds at init time
 
+// allocation-size histogram: requested sizes of Alloc() and NewSGL()
+const NumAllocBins = 8
+
+// upper bounds (inclusive) of the allocation-size histogram bins; the last bin is unbounded
+var AllocSizeBounds = [NumAllocBins - 1]int64{
+	SmallSlabIncStep, cmn.KiB, PageSize, DefaultBufSize, MaxPageSlabSize, cmn.MiB, 16 * cmn.MiB,
+}
+
 // =================================== MMSA config defaults ==========================================
 // The minimum memory (that must remain available) gets computed as follows:
 // 1) environment AIS_MINMEM_FREE takes precedence over everything else;
@@ -172,8 +181,18 @@ type (
 		pos          int
 	}
 	Stats struct {
-		Hits [NumStats]uint64
-		Idle [NumStats]time.Duration
+		Hits [NumStats]uint64        `json:"hits"`
+		Idle [NumStats]time.Duration `json:"idle"`
+		// the rest is only filled in by GetStats()
+		Depth     [NumStats]int64       `json:"depth"`      // free buffers in the slab's rings
+		AllocHist [NumAllocBins]uint64 `json:"alloc_hist"` // see AllocSizeBounds
+		ToGC      int64                `json:"togc"`       // freed (or discarded) and not yet garbage-collected
+		MinFree   uint64               `json:"min_free"`
+		LowWM     uint64               `json:"low_wm"`
+		Pressure  int                  `json:"pressure"`  // see MemPressure
+		Swapping  int32                `json:"swapping"`  // recent swapping: 0 (none) to SwappingMax
+		SwapUsed  uint64               `json:"swap_used"` // system-wide, bytes
+		Small     bool                 `json:"small"`     // see SlabSize
 	}
 	MMSA struct {
 		// public
@@ -191,6 +210,7 @@ type (
 		slabStats     *slabStats    // private counters and idle timestamp
 		statsSnapshot *Stats        // is a limited "snapshot" of slabStats; preallocated to reuse when house-keeping
 		toGC          atomic.Int64  // accumulates over time and triggers GC upon reaching the spec-ed limit
+		allocHist     [NumAllocBins]atomic.Uint64
 		minDepth      atomic.Int64  // minimum ring depth aka length
 		swap          atomic.Uint64 // actual swap size
 		slabIncStep   int64
@@ -379,6 +399,9 @@ func (r *MMSA) NewSGL(immediateSize int64 /* size to preallocate */, sbufSize ..
 	} else {
 		slab = r.slabForSGL(immediateSize)
 	}
+	if immediateSize > 0 {
+		r.allocHistInc(immediateSize)
+	}
 	n = cmn.DivCeil(immediateSize, slab.Size())
 	sgl = make([][]byte, n)
 
@@ -445,6 +468,7 @@ func (r *MMSA) Alloc(sizes ...int64) (buf []byte, slab *Slab) {
 			return r.Sibling.Alloc(size)
 		}
 	}
+	r.allocHistInc(size)
 	slab = r._selectSlab(size)
 	buf = slab.Alloc()
 	return
@@ -707,6 +731,25 @@ func (s *Slab) cleanup() (freed int64) {
 	return
 }
 
+func (r *MMSA) allocHistInc(size int64) {
+	i := 0
+	for i < len(AllocSizeBounds) && size > AllocSizeBounds[i] {
+		i++
+	}
+	r.allocHist[i].Inc()
+}
+
+// number of free buffers
+func (s *Slab) depth() (depth int64) {
+	s.muget.Lock()
+	depth = int64(len(s.get) - s.pos)
+	s.muget.Unlock()
+	s.muput.Lock()
+	depth += int64(len(s.put))
+	s.muput.Unlock()
+	return
+}
+
 func (s *Slab) ringIdx() int { return int(s.bufSize/s.m.slabIncStep) - 1 }
 func (s *Slab) hitsInc()     { s.m.slabStats.hits[s.ringIdx()].Inc() }
 func (s *Slab) idleDur(statsSnapshot *Stats) (d time.Duration) {
@@ -720,3 +763,21 @@ func (s *Slab) idleDur(statsSnapshot *Stats) (d time.Duration) {
 	}
 	return
 }
+
+// AlignBuf returns the largest PageSize-aligned subslice of the buffer with
+// the length being a multiple of PageSize (or nil if there's none) - as required
+// by direct (O_DIRECT) IO. The original buffer must be used to free the memory.
+func AlignBuf(buf []byte) []byte {
+	if len(buf) < PageSize {
+		return nil
+	}
+	var (
+		addr = uintptr(unsafe.Pointer(&buf[0]))
+		off  = int((PageSize - addr%PageSize) % PageSize)
+		l    = (len(buf) - off) / PageSize * PageSize
+	)
+	if l == 0 {
+		return nil
+	}
+	return buf[off : off+l : off+l]
+}
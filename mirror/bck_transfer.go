@@ -6,6 +6,10 @@ package mirror
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -13,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/transport/bundle"
+	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/registry"
 )
 
@@ -37,14 +42,26 @@ type (
 		dm      *bundle.DataMover
 		dp      cluster.LomReaderProvider
 		meta    *cmn.Bck2BckMsg
+		filter  func(objName string) bool // selective input (nil - the entire bucket)
+		// offline ETL: limits the number of objects being transformed
+		// concurrently (batch size - see SetLimits)
+		sema          *cmn.DynSemaphore
+		dfltBatchSize int
 	}
 	bckTransferJogger struct { // one per mountpath
 		joggerBckBase
 		parent *XactTransferBck
 		buf    []byte
+		// offline ETL: objects are transformed asynchronously
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		err error // the first error, if any
 	}
 )
 
+// interface guard
+var _ xaction.Limiter = &XactTransferBck{}
+
 func (e *transferBckProvider) New(args registry.XactArgs) registry.BucketEntry {
 	return &transferBckProvider{
 		t:     args.T,
@@ -56,9 +73,19 @@ func (e *transferBckProvider) New(args registry.XactArgs) registry.BucketEntry {
 }
 
 func (e *transferBckProvider) Start(_ cmn.Bck) error {
+	filter, err := NewTransferFilter(e.args.Meta)
+	if err != nil {
+		return err
+	}
 	slab, err := e.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
 	cmn.AssertNoErr(err)
 	e.xact = NewXactTransferBck(e.uuid, e.kind, e.args.BckFrom, e.args.BckTo, e.t, slab, e.args.DM, e.args.DP, e.args.Meta)
+	e.xact.filter = filter
+	if e.kind == cmn.ActETLBucket {
+		availablePaths, _ := fs.Get()
+		e.xact.dfltBatchSize = cmn.Max(len(availablePaths), 1)
+		e.xact.sema = cmn.NewDynSemaphore(e.xact.dfltBatchSize)
+	}
 	return nil
 }
 func (e *transferBckProvider) Kind() string      { return e.kind }
@@ -96,15 +123,60 @@ func NewXactTransferBck(id, kind string, bckFrom, bckTo *cluster.Bck, t cluster.
 	}
 }
 
+// NewTransferFilter returns the filter that selects the source objects as per
+// the selective input of the message, or nil when the input is the entire bucket
+func NewTransferFilter(msg *cmn.Bck2BckMsg) (func(objName string) bool, error) {
+	if msg == nil || (msg.SrcPrefix == "" && msg.Template == "" && msg.Regex == "" && len(msg.ObjNames) == 0) {
+		return nil, nil
+	}
+	var (
+		pt    cmn.ParsedTemplate
+		re    *regexp.Regexp
+		names cmn.StringSet
+		err   error
+	)
+	if msg.Template != "" {
+		if pt, err = cmn.ParseBashTemplate(msg.Template); err != nil {
+			return nil, fmt.Errorf("invalid template %q: %v", msg.Template, err)
+		}
+	}
+	if msg.Regex != "" {
+		if re, err = regexp.Compile(msg.Regex); err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %v", msg.Regex, err)
+		}
+	}
+	if len(msg.ObjNames) > 0 {
+		names = cmn.NewStringSet(msg.ObjNames...)
+	}
+	return func(objName string) bool {
+		if !strings.HasPrefix(objName, msg.SrcPrefix) {
+			return false
+		}
+		if msg.Template != "" && !pt.Match(objName) {
+			return false
+		}
+		if re != nil && !re.MatchString(objName) {
+			return false
+		}
+		return names == nil || names.Contains(objName)
+	}, nil
+}
+
 func (r *XactTransferBck) Run() (err error) {
 	r.dm.SetXact(r)
-	r.dm.Open()
 
-	mpathCount := r.runJoggers()
+	// open the streams only when admitted (and not while queued)
+	if r.Admit() {
+		r.dm.Open()
+		mpathCount := r.runJoggers()
 
-	glog.Infoln(r.String(), r.bckFrom.Bck, "=>", r.bckTo.Bck)
-	err = r.xactBckBase.waitDone(mpathCount)
-	r.dm.Close(err)
+		glog.Infoln(r.String(), r.bckFrom.Bck, "=>", r.bckTo.Bck)
+		err = r.xactBckBase.waitDone(mpathCount)
+		r.dm.Close(err)
+		r.Release()
+	} else {
+		err = cmn.NewAbortedError(r.String())
+	}
 	r.dm.UnregRecv()
 
 	r.Finish(err)
@@ -115,6 +187,27 @@ func (r *XactTransferBck) String() string {
 	return fmt.Sprintf("%s <= %s", r.XactBase.String(), r.bckFrom)
 }
 
+// SetLimits implements xaction.Limiter: updates the batch size of offline ETL,
+// i.e., the number of objects this target transforms concurrently
+func (r *XactTransferBck) SetLimits(limits *xaction.JobLimits) error {
+	if r.sema == nil {
+		return fmt.Errorf("%s does not support updating limits at runtime", r)
+	}
+	if limits.Connections != 0 || limits.BytesPerHour != 0 || limits.Shared || len(limits.Shares) > 0 {
+		return fmt.Errorf("%s: only batch_size can be updated", r)
+	}
+	if limits.BatchSize < 0 {
+		return fmt.Errorf("%s: invalid batch_size %d", r, limits.BatchSize)
+	}
+	size := limits.BatchSize
+	if size == 0 {
+		size = r.dfltBatchSize
+	}
+	r.sema.SetSize(size)
+	glog.Infof("%s: batch size set to %d", r, size)
+	return nil
+}
+
 //
 // private methods
 //
@@ -148,10 +241,15 @@ func newBCCJogger(parent *XactTransferBck, mpathInfo *fs.MountpathInfo, config *
 			config:    config,
 			skipLoad:  true,
 			stopCh:    cmn.NewStopCh(),
+			filter:    parent.filter,
 		},
 		parent: parent,
 	}
 	j.joggerBckBase.callback = j.copyObject
+	if parent.sema != nil {
+		j.joggerBckBase.callback = j.transformObject
+		j.joggerBckBase.drain = j.wg.Wait
+	}
 	return j
 }
 
@@ -162,13 +260,47 @@ func (j *bckTransferJogger) jog() {
 	j.parent.slab.Free(j.buf)
 }
 
-func (j *bckTransferJogger) copyObject(lom *cluster.LOM) error {
+func (j *bckTransferJogger) copyObject(lom *cluster.LOM) error { return j.doCopy(lom, j.buf) }
+
+// transformObject transforms the object asynchronously - the number of objects
+// in flight is limited by the batch size (see SetLimits); the first error stops
+// the jogger, same as with copyObject
+func (j *bckTransferJogger) transformObject(lom *cluster.LOM) error {
+	if err := j.asyncErr(); err != nil {
+		return err
+	}
+	j.parent.sema.Acquire()
+	j.wg.Add(1)
+	go func() {
+		buf := j.parent.slab.Alloc()
+		if err := j.doCopy(lom, buf); err != nil {
+			j.mu.Lock()
+			if j.err == nil {
+				j.err = err
+			}
+			j.mu.Unlock()
+		}
+		j.parent.slab.Free(buf)
+		j.parent.sema.Release()
+		j.wg.Done()
+	}()
+	return nil
+}
+
+func (j *bckTransferJogger) asyncErr() (err error) {
+	j.mu.Lock()
+	err = j.err
+	j.mu.Unlock()
+	return
+}
+
+func (j *bckTransferJogger) doCopy(lom *cluster.LOM, buf []byte) error {
 	var (
 		objNameTo = cmn.ObjNameFromBck2BckMsg(lom.ObjName, j.parent.meta)
 		params    = cluster.CopyObjectParams{
 			BckTo:     j.parent.bckTo,
 			ObjNameTo: objNameTo,
-			Buf:       j.buf,
+			Buf:       buf,
 			DM:        j.parent.dm,
 			DP:        j.parent.dp,
 			DryRun:    j.parent.meta.DryRun,
@@ -181,9 +313,9 @@ func (j *bckTransferJogger) copyObject(lom *cluster.LOM) error {
 	if copied {
 		j.parent.ObjectsInc()
 		j.parent.BytesAdd(size)
-		j.num++
+		num := atomic.AddInt64(&j.num, 1)
 
-		if (j.num % throttleNumObjects) == 0 {
+		if (num % throttleNumObjects) == 0 {
 			if errstop := j.yieldTerm(); errstop != nil {
 				return errstop
 			}
@@ -193,9 +325,9 @@ func (j *bckTransferJogger) copyObject(lom *cluster.LOM) error {
 				return cmn.NewAbortedErrorDetails(what, cs.Err.Error())
 			}
 
-			if (j.num % logNumProcessed) == 0 {
+			if (num % logNumProcessed) == 0 {
 				glog.Infof("%s jogger[%s/%s] processed %d objects...", j.parent.Kind(), j.mpathInfo, j.parent.Bck(),
-					j.num)
+					num)
 			}
 		}
 	}
@@ -0,0 +1,75 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewTransferFilter", func() {
+	It("should select the entire bucket when no input is specified", func() {
+		filter, err := NewTransferFilter(&cmn.Bck2BckMsg{Prefix: "etl-"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter).To(BeNil())
+	})
+
+	It("should require all the conditions to hold", func() {
+		filter, err := NewTransferFilter(&cmn.Bck2BckMsg{
+			SrcPrefix: "shards/",
+			Template:  "shards/shard-{0..9}.tar",
+			Regex:     `[02468]\.tar$`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter("shards/shard-2.tar")).To(BeTrue())
+		Expect(filter("shards/shard-3.tar")).To(BeFalse())  // regex
+		Expect(filter("shards/shard-12.tar")).To(BeFalse()) // template
+		Expect(filter("other/shard-2.tar")).To(BeFalse())   // prefix
+	})
+
+	It("should select only the listed objects", func() {
+		filter, err := NewTransferFilter(&cmn.Bck2BckMsg{ObjNames: []string{"a", "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter("a")).To(BeTrue())
+		Expect(filter("c")).To(BeFalse())
+	})
+
+	It("should fail on invalid input", func() {
+		_, err := NewTransferFilter(&cmn.Bck2BckMsg{Regex: "shard-("})
+		Expect(err).To(HaveOccurred())
+		_, err = NewTransferFilter(&cmn.Bck2BckMsg{Template: "shard-{0..9"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("XactTransferBck", func() {
+	var (
+		bckFrom = cluster.NewBck("from", cmn.ProviderAIS, cmn.NsGlobal)
+		bckTo   = cluster.NewBck("to", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+
+	It("should update the batch size of offline ETL", func() {
+		xact := NewXactTransferBck("id", cmn.ActETLBucket, bckFrom, bckTo, nil, nil, nil, nil, &cmn.Bck2BckMsg{})
+		xact.dfltBatchSize = 2
+		xact.sema = cmn.NewDynSemaphore(xact.dfltBatchSize)
+
+		Expect(xact.SetLimits(&xaction.JobLimits{BatchSize: 16})).NotTo(HaveOccurred())
+		Expect(xact.sema.Size()).To(Equal(16))
+		Expect(xact.SetLimits(&xaction.JobLimits{})).NotTo(HaveOccurred())
+		Expect(xact.sema.Size()).To(Equal(2))
+
+		Expect(xact.SetLimits(&xaction.JobLimits{BatchSize: -1})).To(HaveOccurred())
+		Expect(xact.SetLimits(&xaction.JobLimits{BytesPerHour: 1024})).To(HaveOccurred())
+		Expect(xact.sema.Size()).To(Equal(2))
+	})
+
+	It("should not update limits of bucket copy", func() {
+		xact := NewXactTransferBck("id", cmn.ActCopyBucket, bckFrom, bckTo, nil, nil, nil, nil, &cmn.Bck2BckMsg{})
+		Expect(xact.SetLimits(&xaction.JobLimits{BatchSize: 16})).To(HaveOccurred())
+	})
+})
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/registry"
 )
 
@@ -22,6 +26,7 @@ const (
 	minThrottleSize    = 256 * cmn.KiB           // throttle every 4MB (= 16K*256) or greater
 	logNumProcessed    = throttleNumObjects * 16 // unit of house-keeping
 	MaxNCopies         = 16                      // validation
+	maxUnderReplicated = 100                     // max number of under-replicated object names to report
 )
 
 type (
@@ -29,33 +34,61 @@ type (
 		registry.BaseBckEntry
 		xact *xactMNC
 
-		t      cluster.Target
-		uuid   string
-		copies int
+		t    cluster.Target
+		uuid string
+		msg  *cmn.MNCMsg
 	}
 
 	// xactMNC runs in a background, traverses all local mountpaths, and makes sure
-	// the bucket is N-way replicated (where N >= 1).
+	// the bucket (or its subset - see cmn.MNCMsg) is N-way replicated (where N >= 1).
+	// Optionally, the post-phase (verify) traverses the bucket one more time to
+	// confirm the resulting number of copies.
 	xactMNC struct {
 		xactBckBase
 		slab   *memsys.Slab
 		copies int
+		filter func(objName string) bool // nil: entire bucket
+		verify bool
+		// verification report
+		mu        sync.Mutex
+		under     []string // names of (up to maxUnderReplicated) under-replicated objects
+		verified  atomic.Int64
+		underRepl atomic.Int64
 	}
 	mncJogger struct { // one per mountpath
 		joggerBckBase
 		parent *xactMNC
 		buf    []byte
 	}
+
+	MNCTargetStats struct {
+		xaction.BaseXactStats
+		Ext ExtMNCStats `json:"ext"`
+	}
+	ExtMNCStats struct {
+		Copies          int      `json:"copies"`
+		Verified        int64    `json:"verified.n,string"`         // objects verified (post-phase)
+		UnderReplicated int64    `json:"under_replicated.n,string"` // objects with fewer than `Copies` copies
+		Objects         []string `json:"under_replicated"`          // (names of the first maxUnderReplicated)
+	}
 )
 
+// interface guard
+var _ cluster.XactStats = &MNCTargetStats{}
+
 func (*mncProvider) New(args registry.XactArgs) registry.BucketEntry {
-	return &mncProvider{t: args.T, uuid: args.UUID, copies: args.Custom.(int)}
+	return &mncProvider{t: args.T, uuid: args.UUID, msg: args.Custom.(*cmn.MNCMsg)}
 }
 
 func (p *mncProvider) Start(bck cmn.Bck) error {
 	slab, err := p.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
 	cmn.AssertNoErr(err)
-	p.xact = newXactMNC(bck, p.t, slab, p.uuid, p.copies)
+	filter, err := NewScopeFilter(p.msg)
+	if err != nil {
+		return err
+	}
+	p.xact = newXactMNC(bck, p.t, slab, p.uuid, p.msg.Copies)
+	p.xact.filter, p.xact.verify = filter, p.msg.Verify
 	return nil
 }
 func (*mncProvider) Kind() string        { return cmn.ActMakeNCopies }
@@ -71,15 +104,57 @@ func newXactMNC(bck cmn.Bck, t cluster.Target, slab *memsys.Slab, id string, cop
 
 func (r *xactMNC) Run() (err error) {
 	var mpathersCount int
+	if !r.Admit() {
+		err = cmn.NewAbortedError(r.String())
+		r.Finish(err)
+		return
+	}
+	defer r.Release()
 	if mpathersCount, err = r.runJoggers(); err != nil {
 		return
 	}
 	glog.Infoln(r.String(), "copies=", r.copies)
 	err = r.xactBckBase.waitDone(mpathersCount)
+	if err == nil && r.verify {
+		err = r.runVerify()
+	}
 	r.Finish(err)
 	return
 }
 
+// override/extend cmn.XactBase.Stats()
+func (r *xactMNC) Stats() cluster.XactStats {
+	var (
+		baseStats = r.XactBase.Stats().(*xaction.BaseXactStats)
+		st        = MNCTargetStats{BaseXactStats: *baseStats}
+	)
+	st.Ext.Copies = r.copies
+	st.Ext.Verified = r.verified.Load()
+	st.Ext.UnderReplicated = r.underRepl.Load()
+	r.mu.Lock()
+	st.Ext.Objects = append([]string(nil), r.under...)
+	r.mu.Unlock()
+	return &st
+}
+
+// NewScopeFilter returns the filter that selects the objects in scope
+// of a given (scoped) operation, or nil when the scope is the entire bucket
+func NewScopeFilter(msg *cmn.MNCMsg) (func(objName string) bool, error) {
+	if msg.Template == "" {
+		if msg.Prefix == "" {
+			return nil, nil
+		}
+		return func(objName string) bool { return strings.HasPrefix(objName, msg.Prefix) }, nil
+	}
+	pt, err := cmn.ParseBashTemplate(msg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %q: %v", msg.Template, err)
+	}
+	return func(objName string) bool {
+		return strings.HasPrefix(objName, msg.Prefix) && pt.Match(objName)
+	}, nil
+}
+
 func ValidateNCopies(prefix string, copies int) error {
 	if _, err := cmn.CheckI64Range(int64(copies), 1, MaxNCopies); err != nil {
 		return fmt.Errorf("number of copies (%d) %s", copies, err.Error())
@@ -124,6 +199,42 @@ func (r *xactMNC) runJoggers() (mpathCount int, err error) {
 	return
 }
 
+// runVerify (post-phase) traverses all local mountpaths again and counts
+// the objects that could not reach the requested redundancy
+func (r *xactMNC) runVerify() error {
+	var (
+		availablePaths, _ = fs.Get()
+		config            = cmn.GCO.Get()
+	)
+	r.xactBckBase.init(len(availablePaths))
+	for _, mpathInfo := range availablePaths {
+		mncJogger := newMNCJogger(r, mpathInfo, config)
+		mncJogger.joggerBckBase.callback = mncJogger.verifyCopies
+		r.mpathers[mpathInfo.MakePathCT(r.Bck(), fs.ObjectType)] = mncJogger
+	}
+	for _, mpather := range r.mpathers {
+		go mpather.(*mncJogger).joggerBckBase.jog()
+	}
+	if err := r.xactBckBase.waitDone(len(availablePaths)); err != nil {
+		return err
+	}
+	n := r.underRepl.Load()
+	glog.Infof("%s: verified %d, under-replicated %d", r, r.verified.Load(), n)
+	if n > 0 {
+		return fmt.Errorf("%s: %d object(s) have fewer than %d copies", r, n, r.copies)
+	}
+	return nil
+}
+
+func (r *xactMNC) addUnderReplicated(objName string) {
+	r.underRepl.Inc()
+	r.mu.Lock()
+	if len(r.under) < maxUnderReplicated {
+		r.under = append(r.under, objName)
+	}
+	r.mu.Unlock()
+}
+
 //
 // mpath mncJogger - main
 //
@@ -135,6 +246,7 @@ func newMNCJogger(parent *xactMNC, mpathInfo *fs.MountpathInfo, config *cmn.Conf
 			bck:       parent.Bck(),
 			mpathInfo: mpathInfo,
 			config:    config,
+			filter:    parent.filter,
 		},
 		parent: parent,
 	}
@@ -161,6 +273,9 @@ func (j *mncJogger) delAddCopies(lom *cluster.LOM) (err error) {
 		size, err = delCopies(lom, j.parent.copies)
 	} else {
 		size, err = addCopies(lom, j.parent.copies, j.parent.Mpathers(), j.buf)
+		// each copy is a read from the object's mountpath and a write to another
+		j.parent.IO().ReadAdd(size)
+		j.parent.IO().WrittenAdd(size)
 	}
 
 	if os.IsNotExist(err) {
@@ -197,3 +312,23 @@ func (j *mncJogger) delAddCopies(lom *cluster.LOM) (err error) {
 	}
 	return
 }
+
+func (j *mncJogger) verifyCopies(lom *cluster.LOM) error {
+	if j.parent.Aborted() {
+		return cmn.NewAbortedError("makenaction xaction")
+	}
+	n := 1
+	if lom.HasCopies() {
+		n = 0
+		for fqn := range lom.GetCopies() {
+			if err := fs.Access(fqn); err == nil {
+				n++
+			}
+		}
+	}
+	j.parent.verified.Inc()
+	if n < j.parent.copies {
+		j.parent.addUnderReplicated(lom.ObjName)
+	}
+	return nil
+}
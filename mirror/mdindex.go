@@ -0,0 +1,113 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/mdindex"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// Objects get indexed upon PUT and custom metadata update (see cmn.MDIndexConf).
+// xactMDIndex (re)indexes the objects that are already stored - when the index
+// gets enabled or its keys (values) change.
+
+type (
+	mdIndexProvider struct {
+		xact *xactMDIndex
+
+		t    cluster.Target
+		uuid string
+	}
+	xactMDIndex struct {
+		xactBckBase
+		failed atomic.Int64
+	}
+	mdIndexJogger struct { // one per mountpath
+		joggerBckBase
+		parent *xactMDIndex
+	}
+)
+
+func (*mdIndexProvider) New(args registry.XactArgs) registry.BucketEntry {
+	return &mdIndexProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *mdIndexProvider) Start(bck cmn.Bck) error {
+	p.xact = &xactMDIndex{xactBckBase: *newXactBckBase(p.uuid, cmn.ActMDIndex, bck, p.t)}
+	return nil
+}
+func (*mdIndexProvider) Kind() string        { return cmn.ActMDIndex }
+func (p *mdIndexProvider) Get() cluster.Xact { return p.xact }
+
+// the index config has changed again - start over
+func (p *mdIndexProvider) PreRenewHook(_ registry.BucketEntry) (bool, error) { return false, nil }
+func (p *mdIndexProvider) PostRenewHook(previousEntry registry.BucketEntry) {
+	previousEntry.Get().Abort()
+}
+
+func (r *xactMDIndex) Run() (err error) {
+	mpathCount := r.runJoggers()
+	glog.Infoln(r.String())
+	err = r.xactBckBase.waitDone(mpathCount)
+	r.Finish(err)
+	glog.Infof("%s: indexed %d, failed %d", r, r.ObjCount(), r.failed.Load())
+	return
+}
+
+func (r *xactMDIndex) runJoggers() (mpathCount int) {
+	var (
+		availablePaths, _ = fs.Get()
+		config            = cmn.GCO.Get()
+	)
+	mpathCount = len(availablePaths)
+	r.xactBckBase.init(mpathCount)
+	for _, mpathInfo := range availablePaths {
+		j := &mdIndexJogger{
+			joggerBckBase: joggerBckBase{
+				parent:    &r.xactBckBase,
+				bck:       r.Bck(),
+				mpathInfo: mpathInfo,
+				config:    config,
+			},
+			parent: r,
+		}
+		j.joggerBckBase.callback = j.index
+		r.mpathers[mpathInfo.MakePathCT(r.Bck(), fs.ObjectType)] = j
+	}
+	for _, mpather := range r.mpathers {
+		go mpather.(*mdIndexJogger).jog()
+	}
+	return
+}
+
+//
+// mpath mdIndexJogger - main
+//
+
+func (j *mdIndexJogger) jog() {
+	glog.Infof("jogger[%s/%s] started", j.mpathInfo, j.parent.Bck())
+	j.joggerBckBase.jog()
+}
+
+func (j *mdIndexJogger) index(lom *cluster.LOM) error {
+	if j.parent.Aborted() {
+		return cmn.NewAbortedError("md-index xaction")
+	}
+	if !lom.Bprops().MDIndex.Enabled {
+		return cmn.NewAbortedError("md-index disabled")
+	}
+	if err := mdindex.New(lom.T.DB(), lom.Bck()).Update(lom.ObjName, lom.CustomMD()); err != nil {
+		j.parent.failed.Inc()
+		glog.Errorf("%s: failed to index %s: %v", j.parent, lom, err)
+		return nil
+	}
+	j.parent.ObjectsInc()
+	return nil
+}
@@ -231,6 +231,8 @@ func (j *xputJogger) jog() {
 					glog.Infof("%s: total=%d, copied=%d", j.parent.String(), j.parent.total.Load(), v)
 				}
 				j.parent.BytesAdd(lom.Size() * int64(copies))
+				j.parent.IO().ReadAdd(lom.Size() * int64(copies))
+				j.parent.IO().WrittenAdd(lom.Size() * int64(copies))
 			}
 			j.parent.DecPending() // to support action renewal on-demand
 		case <-j.stopCh.Listen():
@@ -0,0 +1,272 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// Changing bucket's `checksum.type` does not affect the objects that are already
+// stored - they keep (and get validated with) their original checksums.
+// xactRehash traverses the bucket and converts all its objects to the currently
+// configured checksum type. The existing checksum is validated in the same pass
+// (objects that fail validation are counted as corrupted and left intact).
+
+type (
+	rehashProvider struct {
+		registry.BaseBckEntry
+		xact *xactRehash
+
+		t    cluster.Target
+		uuid string
+	}
+	xactRehash struct {
+		xactBckBase
+		slab *memsys.Slab
+		// mixed-state report
+		mu        sync.Mutex
+		types     map[string]int64 // checksum type => number of objects (prior to conversion)
+		converted atomic.Int64
+		skipped   atomic.Int64
+		corrupted atomic.Int64
+		failed    atomic.Int64
+	}
+	rehashJogger struct { // one per mountpath
+		joggerBckBase
+		parent *xactRehash
+		buf    []byte
+	}
+
+	RehashTargetStats struct {
+		xaction.BaseXactStats
+		Ext ExtRehashStats `json:"ext"`
+	}
+	ExtRehashStats struct {
+		Types     map[string]int64 `json:"types"` // checksum type => number of objects found
+		Converted int64            `json:"converted.n,string"`
+		Skipped   int64            `json:"skipped.n,string"` // already of the required type
+		Corrupted int64            `json:"corrupted.n,string"`
+		Failed    int64            `json:"failed.n,string"`
+	}
+)
+
+// interface guard
+var _ cluster.XactStats = &RehashTargetStats{}
+
+func (*rehashProvider) New(args registry.XactArgs) registry.BucketEntry {
+	return &rehashProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *rehashProvider) Start(bck cmn.Bck) error {
+	slab, err := p.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
+	cmn.AssertNoErr(err)
+	p.xact = &xactRehash{
+		xactBckBase: *newXactBckBase(p.uuid, cmn.ActRehash, bck, p.t),
+		slab:        slab,
+		types:       make(map[string]int64, 2),
+	}
+	return nil
+}
+func (*rehashProvider) Kind() string        { return cmn.ActRehash }
+func (p *rehashProvider) Get() cluster.Xact { return p.xact }
+
+func (r *xactRehash) Run() (err error) {
+	if !r.Admit() {
+		err = cmn.NewAbortedError(r.String())
+		r.Finish(err)
+		return
+	}
+	defer r.Release()
+	mpathCount := r.runJoggers()
+	glog.Infoln(r.String())
+	err = r.xactBckBase.waitDone(mpathCount)
+	r.Finish(err)
+	glog.Infof("%s: converted %d, skipped %d, corrupted %d, failed %d", r, r.converted.Load(),
+		r.skipped.Load(), r.corrupted.Load(), r.failed.Load())
+	return
+}
+
+// override/extend cmn.XactBase.Stats()
+func (r *xactRehash) Stats() cluster.XactStats {
+	var (
+		baseStats = r.XactBase.Stats().(*xaction.BaseXactStats)
+		st        = RehashTargetStats{BaseXactStats: *baseStats}
+	)
+	r.mu.Lock()
+	st.Ext.Types = make(map[string]int64, len(r.types))
+	for ty, cnt := range r.types {
+		st.Ext.Types[ty] = cnt
+	}
+	r.mu.Unlock()
+	st.Ext.Converted = r.converted.Load()
+	st.Ext.Skipped = r.skipped.Load()
+	st.Ext.Corrupted = r.corrupted.Load()
+	st.Ext.Failed = r.failed.Load()
+	return &st
+}
+
+func (r *xactRehash) runJoggers() (mpathCount int) {
+	var (
+		availablePaths, _ = fs.Get()
+		config            = cmn.GCO.Get()
+	)
+	mpathCount = len(availablePaths)
+	r.xactBckBase.init(mpathCount)
+	for _, mpathInfo := range availablePaths {
+		j := &rehashJogger{
+			joggerBckBase: joggerBckBase{
+				parent:    &r.xactBckBase,
+				bck:       r.Bck(),
+				mpathInfo: mpathInfo,
+				config:    config,
+				skipLoad:  true,
+			},
+			parent: r,
+		}
+		j.joggerBckBase.callback = j.rehash
+		r.mpathers[mpathInfo.MakePathCT(r.Bck(), fs.ObjectType)] = j
+	}
+	for _, mpather := range r.mpathers {
+		go mpather.(*rehashJogger).jog()
+	}
+	return
+}
+
+func (r *xactRehash) addType(ty string) {
+	r.mu.Lock()
+	r.types[ty]++
+	r.mu.Unlock()
+}
+
+//
+// mpath rehashJogger - main
+//
+
+func (j *rehashJogger) jog() {
+	glog.Infof("jogger[%s/%s] started", j.mpathInfo, j.parent.Bck())
+	j.buf = j.parent.slab.Alloc()
+	j.joggerBckBase.jog()
+	j.parent.slab.Free(j.buf)
+}
+
+func (j *rehashJogger) rehash(lom *cluster.LOM) (err error) {
+	if j.parent.Aborted() {
+		return cmn.NewAbortedError("rehash xaction")
+	}
+	lom.Lock(true)
+	converted, err := j.convert(lom)
+	lom.Unlock(true)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if _, ok := err.(*cmn.BadCksumError); ok {
+			j.parent.corrupted.Inc()
+		} else {
+			j.parent.failed.Inc()
+		}
+		glog.Errorf("%s: %v", j.parent, err)
+		return nil
+	}
+	if !converted {
+		return nil
+	}
+
+	j.num++
+	j.size += lom.Size()
+	j.parent.ObjectsInc()
+	j.parent.BytesAdd(lom.Size())
+
+	if (j.num % throttleNumObjects) == 0 {
+		if j.size > minThrottleSize*throttleNumObjects {
+			j.size = 0
+			if errstop := j.yieldTerm(); errstop != nil {
+				return errstop
+			}
+		}
+		if (j.num % logNumProcessed) == 0 {
+			glog.Infof("jogger[%s/%s] converted %d objects...", j.mpathInfo, j.parent.Bck(), j.num)
+			j.config = cmn.GCO.Get()
+		}
+	} else {
+		runtime.Gosched()
+	}
+	return nil
+}
+
+// under exclusive lock: validate the existing and compute the new checksum (single pass)
+func (j *rehashJogger) convert(lom *cluster.LOM) (converted bool, err error) {
+	if err = lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if lom.IsCopy() {
+		return
+	}
+	var (
+		newType = lom.CksumConf().Type
+		oldType = cmn.ChecksumNone
+		cksum   = lom.Cksum()
+	)
+	if cksum != nil && cksum.Type() != "" {
+		oldType = cksum.Type()
+	}
+	j.parent.addType(oldType)
+	if oldType == newType {
+		j.parent.skipped.Inc()
+		return
+	}
+	if newType == cmn.ChecksumNone {
+		lom.SetCksum(cmn.NewCksum(cmn.ChecksumNone, ""))
+	} else {
+		var (
+			file    *os.File
+			oldHash = cmn.NewCksumHash(oldType)
+			newHash = cmn.NewCksumHash(newType)
+		)
+		if file, err = os.Open(lom.FQN); err != nil {
+			return
+		}
+		reader := ios.NewReaderAcct(file, j.parent.IO())
+		_, err = io.CopyBuffer(io.MultiWriter(oldHash.H, newHash.H), reader, j.buf)
+		cmn.Close(reader)
+		if err != nil {
+			return
+		}
+		if oldType != cmn.ChecksumNone {
+			oldHash.Finalize()
+			if !oldHash.Equal(cksum) {
+				err = cmn.NewBadDataCksumError(&oldHash.Cksum, cksum, lom.String())
+				return
+			}
+		}
+		newHash.Finalize()
+		lom.SetCksum(newHash.Clone())
+	}
+	if err = lom.Persist(); err != nil {
+		return
+	}
+	if err = lom.PersistCopies(); err != nil {
+		return
+	}
+	lom.ReCache()
+	j.parent.converted.Inc()
+	return true, nil
+}
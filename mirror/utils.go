@@ -35,17 +35,24 @@ func delCopies(lom *cluster.LOM, copies int) (size int64, err error) {
 		return
 	}
 
-	copiesFQN := make([]string, 0, ndel)
-	for copyFQN := range lom.GetCopies() {
+	// delete the copies on the hot mountpaths last (see cmn.MirrorConf.HotLabel)
+	var (
+		copiesFQN = make([]string, 0, ndel)
+		hotFQNs   []string
+		hotLabel  = lom.MirrorConf().HotLabel
+	)
+	for copyFQN, mpathInfo := range lom.GetCopies() {
 		if copyFQN == lom.FQN {
 			continue
 		}
-		copiesFQN = append(copiesFQN, copyFQN)
-		ndel--
-		if ndel == 0 {
-			break
+		if hotLabel != "" && mpathInfo.Label == hotLabel {
+			hotFQNs = append(hotFQNs, copyFQN)
+			continue
 		}
+		copiesFQN = append(copiesFQN, copyFQN)
 	}
+	copiesFQN = append(copiesFQN, hotFQNs...)
+	copiesFQN = copiesFQN[:cmn.Min(ndel, len(copiesFQN))]
 
 	size = int64(len(copiesFQN)) * lom.Size()
 	if err = lom.DelCopies(copiesFQN...); err != nil {
@@ -101,22 +108,39 @@ func addCopies(lom *cluster.LOM, copies int, mpathers map[string]mpather, buf []
 	return
 }
 
+// Given mirror.hot_label (see cmn.MirrorConf), the first copy goes to a hot
+// mountpath (fast media) and the rest - to the others, if possible.
 func findLeastUtilized(lom *cluster.LOM, mpathers map[string]mpather) (out mpather) {
 	var (
 		copiesMpath cmn.StringSet
 
-		util       int64 = 101
-		nowTs            = mono.NanoTime()
-		mpathUtils       = fs.GetAllMpathUtils(nowTs)
+		hotLabel   = lom.MirrorConf().HotLabel
+		hasHot     = lom.ParsedFQN.MpathInfo.Label == hotLabel
+		nowTs      = mono.NanoTime()
+		mpathUtils = fs.GetAllMpathUtils(nowTs)
 	)
 
 	if lom.HasCopies() {
 		copiesMpath = make(cmn.StringSet)
 		for _, mpathInfo := range lom.GetCopies() {
 			copiesMpath.Add(mpathInfo.Path)
+			hasHot = hasHot || mpathInfo.Label == hotLabel
 		}
 	}
+	if hotLabel != "" {
+		// preferred media first
+		if out = leastUtilized(lom, mpathers, copiesMpath, mpathUtils, func(mi *fs.MountpathInfo) bool {
+			return (mi.Label == hotLabel) != hasHot
+		}); out != nil {
+			return
+		}
+	}
+	return leastUtilized(lom, mpathers, copiesMpath, mpathUtils, nil)
+}
 
+func leastUtilized(lom *cluster.LOM, mpathers map[string]mpather, copiesMpath cmn.StringSet,
+	mpathUtils map[string]int64, filter func(*fs.MountpathInfo) bool) (out mpather) {
+	var util int64 = 101
 	for _, j := range mpathers {
 		jpath := j.mountpathInfo().Path
 		if jpath == lom.ParsedFQN.MpathInfo.Path {
@@ -128,6 +152,9 @@ func findLeastUtilized(lom *cluster.LOM, mpathers map[string]mpather) (out mpath
 				continue
 			}
 		}
+		if filter != nil && !filter(j.mountpathInfo()) {
+			continue
+		}
 		if u, ok := mpathUtils[jpath]; ok && u < util {
 			out = j
 			util = u
@@ -108,6 +108,65 @@ var _ = Describe("Mirror", func() {
 			Expect(copyLOM.HasCopies()).To(BeTrue())
 		})
 	})
+
+	Describe("rehash", func() {
+		var j *rehashJogger
+
+		BeforeEach(func() {
+			j = &rehashJogger{
+				parent: &xactRehash{types: make(map[string]int64)},
+				buf:    make([]byte, 32*cmn.KiB),
+			}
+		})
+
+		AfterEach(func() {
+			props.Cksum.Type = cmn.ChecksumXXHash
+		})
+
+		It("should convert checksum to the bucket's type", func() {
+			createTestFile(bucketPath, testObjectName, testObjectSize)
+			lom := newBasicLom(defaultObjFQN, tMock)
+			lom.SetSize(testObjectSize)
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+
+			props.Cksum.Type = cmn.ChecksumSHA256
+			converted, err := j.convert(newBasicLom(defaultObjFQN, tMock))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(converted).To(BeTrue())
+
+			newLOM := newBasicLom(defaultObjFQN, tMock)
+			Expect(newLOM.Load(false)).NotTo(HaveOccurred())
+			Expect(newLOM.Cksum().Type()).To(Equal(cmn.ChecksumSHA256))
+			cksum, err := newLOM.ComputeCksum()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cksum.Equal(newLOM.Cksum())).To(BeTrue())
+
+			// second pass is a no-op
+			converted, err = j.convert(newLOM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(converted).To(BeFalse())
+			Expect(j.parent.types).To(Equal(map[string]int64{cmn.ChecksumXXHash: 1, cmn.ChecksumSHA256: 1}))
+			Expect(j.parent.skipped.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should not convert corrupted object", func() {
+			createTestFile(bucketPath, testObjectName, testObjectSize)
+			lom := newBasicLom(defaultObjFQN, tMock)
+			lom.SetSize(testObjectSize)
+			lom.SetCksum(cmn.NewCksum(cmn.ChecksumXXHash, "01234567890abcde"))
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+
+			props.Cksum.Type = cmn.ChecksumMD5
+			lom = newBasicLom(defaultObjFQN, tMock)
+			converted, err := j.convert(lom)
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&cmn.BadCksumError{}))
+			Expect(converted).To(BeFalse())
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.Cksum().Type()).To(Equal(cmn.ChecksumXXHash))
+		})
+	})
 })
 
 func createTestFile(filePath, objName string, size int64) {
@@ -7,12 +7,10 @@ package mirror
 import (
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/registry"
@@ -45,8 +43,10 @@ type (
 		config    *cmn.Config
 		num, size int64
 		stopCh    *cmn.StopCh
+		filter    func(objName string) bool
 		callback  func(lom *cluster.LOM) error
-		skipLoad  bool // true: skip lom.Load() and further checks (e.g. done in callback under lock)
+		drain     func() // waits for the objects that are still being processed, if any
+		skipLoad  bool   // true: skip lom.Load() and further checks (e.g. done in callback under lock)
 	}
 )
 
@@ -57,15 +57,15 @@ func init() {
 	registry.Registry.RegisterBucketXact(&mncProvider{})
 	registry.Registry.RegisterBucketXact(&llcProvider{})
 	registry.Registry.RegisterBucketXact(&putMirrorProvider{})
+	registry.Registry.RegisterBucketXact(&rehashProvider{})
+	registry.Registry.RegisterBucketXact(&mdIndexProvider{})
 }
 
 func newXactBckBase(id, kind string, bck cmn.Bck, t cluster.Target) *xactBckBase {
 	return &xactBckBase{XactBase: *xaction.NewXactBaseBck(id, kind, bck), t: t}
 }
 
-//
 // as XactBck interface
-//
 func (r *xactBckBase) IsMountpathXact() bool        { return true }
 func (r *xactBckBase) DoneCh() chan struct{}        { return r.doneCh }
 func (r *xactBckBase) Target() cluster.Target       { return r.t }
@@ -130,6 +130,9 @@ func (j *joggerBckBase) jog() {
 			glog.Errorln(err)
 		}
 	}
+	if j.drain != nil {
+		j.drain()
+	}
 	j.parent.DoneCh() <- struct{}{}
 }
 
@@ -142,6 +145,9 @@ func (j *joggerBckBase) walk(fqn string, de fs.DirEntry) error {
 	if err != nil {
 		return nil
 	}
+	if j.filter != nil && !j.filter(lom.ObjName) {
+		return nil
+	}
 	if !j.skipLoad {
 		if err := lom.Load(); err != nil {
 			return nil
@@ -155,16 +161,11 @@ func (j *joggerBckBase) walk(fqn string, de fs.DirEntry) error {
 
 // [throttle]
 func (j *joggerBckBase) yieldTerm() error {
-	diskConf := &j.config.Disk
 	select {
 	case <-j.stopCh.Listen():
 		return fmt.Errorf("jogger[%s/%s] aborted", j.mpathInfo, j.bck)
 	default:
-		curr := fs.GetMpathUtil(j.mpathInfo.Path, mono.NanoTime())
-		if curr >= diskConf.DiskUtilHighWM {
-			time.Sleep(cmn.ThrottleMin)
-		}
-		break
+		fs.Throttle(j.mpathInfo, fs.QoSNormal)
 	}
 	return nil
 }
@@ -201,7 +201,9 @@ func (wi *WalkInfo) Callback(fqn string, de fs.DirEntry) (*cmn.BucketEntry, erro
 		if err != nil {
 			return nil, err
 		}
-		if wi.t.Snode().ID() != si.ID() {
+		// (the objects that haven't trickled onto the prefilling target yet
+		// are still served from here - see ais/prxprefill.go)
+		if wi.t.Snode().ID() != si.ID() && !si.InPrefill() {
 			objStatus = cmn.ObjStatusMoved
 		}
 	}
@@ -208,6 +208,8 @@ func (reb *Manager) rebInit(md *rebArgs, notif cluster.Notif) bool {
 	if xact == nil {
 		return false
 	}
+	// global rebalance supersedes prefill (see xaction/runners/prefill.go)
+	registry.Registry.DoAbort(cmn.ActPrefill, nil)
 	xact.AddNotif(notif)
 
 	// get EC rebalancer ready
@@ -614,6 +616,8 @@ func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if err := lom.Load(); err != nil {
 		return err
 	}
+	fs.Throttle(lom.ParsedFQN.MpathInfo, fs.QoSLow)
+	rj.m.xact().Pace(lom.Size())
 	if rj.sema == nil { // rebalance.multiplier == 1
 		err = rj.send(lom, tsi, true /*addAck*/)
 	} else { // // rebalance.multiplier > 1
@@ -685,6 +689,9 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 		}
 		o = &transport.Obj{Hdr: hdr, Callback: rj.objSentCallback, CmplPtr: unsafe.Pointer(lom)}
 	)
+	if !o.Hdr.ObjAttrs.SetCustomMD(lom.CustomMD()) {
+		glog.Warningf("%s: custom metadata of %s is too large to migrate", rj.m.t.Snode(), lom)
+	}
 
 	rj.m.inQueue.Inc()
 	if err = rj.m.dm.Send(o, file, tsi); err != nil {
@@ -30,6 +30,8 @@ import (
 const (
 	rebTrname     = "rebalance"
 	rebPushTrname = "rebpush" // broadcast push notifications
+
+	rebReplay = 256 // max objects to resend upon re-establishing broken stream (see transport.Extra.Replay)
 )
 
 // rebalance stage enum
@@ -129,6 +131,7 @@ func NewManager(t cluster.Target, config *cmn.Config, strunner *stats.Trunner) *
 		RecvAck:     reb.recvAck,
 		Compression: rebcfg.Compression,
 		Multiplier:  int(rebcfg.Multiplier),
+		Replay:      rebReplay,
 	}
 	dm, err := bundle.NewDataMover(t, rebTrname, reb.recvObj, dmExtra)
 	if err != nil {
@@ -250,6 +253,7 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetCustomMD(hdr.ObjAttrs.CustomMD) // (gets indexed upon PUT - see cmn.MDIndexConf)
 
 	params := cluster.PutObjectParams{
 		Reader:       ioutil.NopCloser(objReader),
@@ -8,10 +8,12 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
@@ -28,6 +30,9 @@ type (
 	}
 )
 
+// mpathUtil returns the current mountpath utilization (tests override it)
+var mpathUtil = fs.GetMpathUtil
+
 func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool, notifs ...cluster.Notif) {
 	cmn.Assert(id != "")
 
@@ -121,6 +126,9 @@ func (rj *resilverJogger) moveSlice(fqn string, ct *cluster.CT) {
 	if destMpath.Path == ct.ParsedFQN().MpathInfo.Path {
 		return
 	}
+	if !rj.throttle(ct.ParsedFQN().MpathInfo.Path, destMpath.Path) {
+		return
+	}
 
 	destFQN := destMpath.MakePathFQN(ct.Bck().Bck, ec.SliceType, ct.ObjName())
 	srcMetaFQN, destMetaFQN, err := rj.moveECMeta(ct, ct.ParsedFQN().MpathInfo, destMpath)
@@ -187,15 +195,18 @@ func (rj *resilverJogger) moveObject(fqn string, ct *cluster.CT) {
 	if lom.IsHRW() {
 		return
 	}
+	newMpath, _, err := cluster.ResolveFQN(lom.HrwFQN)
+	if err != nil {
+		glog.Warningf("%s: %v", lom, err)
+		return
+	}
+	if !rj.throttle(lom.ParsedFQN.MpathInfo.Path, newMpath.MpathInfo.Path) {
+		return
+	}
 
 	// First, copy metafile if EC is enables. Copy the object only if the
 	// metafile has been copies successfully
 	if lom.Bprops().EC.Enabled {
-		newMpath, _, err := cluster.ResolveFQN(lom.HrwFQN)
-		if err != nil {
-			glog.Warningf("%s: %v", lom, err)
-			return
-		}
 		metaOldPath, metaNewPath, err = rj.moveECMeta(ct, lom.ParsedFQN.MpathInfo, newMpath.MpathInfo)
 		if err != nil {
 			glog.Warningf("%s: failed to move metafile %q -> %q: %v",
@@ -228,6 +239,36 @@ func (rj *resilverJogger) moveObject(fqn string, ct *cluster.CT) {
 	// NOTE: rely on LRU to remove "misplaced"
 }
 
+// throttle pauses resilvering while either the source or the destination
+// mountpath is utilized above the configured high watermark
+// (see cmn.DiskConf.DiskUtilHighWM); returns false if aborted
+func (rj *resilverJogger) throttle(srcMpath, dstMpath string) bool {
+	return waitUtil(cmn.GCO.Get().Disk.DiskUtilHighWM, rj.xreb.ChanAbort(), srcMpath, dstMpath)
+}
+
+func waitUtil(highWM int64, abortCh <-chan struct{}, mpaths ...string) bool {
+	for {
+		var (
+			nowTs = mono.NanoTime()
+			busy  bool
+		)
+		for _, mpath := range mpaths {
+			if mpathUtil(mpath, nowTs) >= highWM {
+				busy = true
+				break
+			}
+		}
+		if !busy {
+			return true
+		}
+		select {
+		case <-time.After(cmn.ThrottleMax):
+		case <-abortCh:
+			return false
+		}
+	}
+}
+
 func (rj *resilverJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	t := rj.m.t
 	if rj.xreb.Aborted() {
@@ -258,6 +299,7 @@ func (rj *resilverJogger) walk(fqn string, de fs.DirEntry) (err error) {
 			return nil
 		}
 	}
+	fs.Throttle(ct.ParsedFQN().MpathInfo, fs.QoSLow)
 	if ct.ContentType() == ec.SliceType {
 		if !ct.Bprops().EC.Enabled {
 			// Since %ec directory is inside a bucket, it is safe to skip
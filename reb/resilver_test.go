@@ -0,0 +1,58 @@
+// Package reb provides resilvering and rebalancing functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resilver throttling", func() {
+	const highWM = 80
+
+	var (
+		utils   map[string]*atomic.Int64
+		abortCh chan struct{}
+	)
+
+	BeforeEach(func() {
+		utils = map[string]*atomic.Int64{"/src": atomic.NewInt64(0), "/dst": atomic.NewInt64(0)}
+		abortCh = make(chan struct{})
+		mpathUtil = func(mpath string, _ int64) int64 { return utils[mpath].Load() }
+	})
+
+	AfterEach(func() {
+		mpathUtil = fs.GetMpathUtil
+	})
+
+	It("should not wait when below the high watermark", func() {
+		utils["/src"].Store(highWM - 1)
+		Expect(waitUtil(highWM, abortCh, "/src", "/dst")).To(BeTrue())
+	})
+
+	It("should wait while either mountpath is above the high watermark", func() {
+		for _, busy := range []string{"/src", "/dst"} {
+			utils[busy].Store(highWM)
+			done := make(chan bool)
+			go func() { done <- waitUtil(highWM, abortCh, "/src", "/dst") }()
+			Consistently(done, 2*cmn.ThrottleMax).ShouldNot(Receive())
+			utils[busy].Store(highWM / 2)
+			Eventually(done, 5*cmn.ThrottleMax).Should(Receive(BeTrue()))
+		}
+	})
+
+	It("should stop waiting when aborted", func() {
+		utils["/dst"].Store(100)
+		done := make(chan bool)
+		go func() { done <- waitUtil(highWM, abortCh, "/src", "/dst") }()
+		close(abortCh)
+		Eventually(done, time.Second).Should(Receive(BeFalse()))
+	})
+})
@@ -0,0 +1,256 @@
+// Package replication provides continuous replication of ais buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// Replication propagates PUTs (including S3 PUT, multipart upload, append,
+// promote, rename, download, and dSort) and DELETEs of an ais bucket to its
+// mirror bucket in an attached remote AIS cluster (see cmn.RemoteReplConf):
+//   * sync - the target replicates the object as part of the request and fails
+//     the request if replication fails (the local change stays in effect);
+//   * async - the target queues the object to the bucket's on-demand xaction
+//     (kind cmn.ActReplicate) that replicates it in the background, retrying
+//     failures a few times; ops of a given object are executed in order by
+//     the same worker.
+//
+// Each target keeps per-bucket replication status (cmn.ReplStatus): pending
+// operations and the age of the oldest one (aka lag), and the objects that
+// failed to replicate - until they get successfully replicated by a subsequent
+// PUT or DELETE. The status outlives the (idle) xaction but not the target restart -
+// and neither do the queued (async) ops.
+//
+// Objects transferred between the targets (rebalance, copy bucket, ETL, and
+// promote or rename onto a different target) are not replicated.
+
+const (
+	OpPut    = "put"
+	OpDelete = "delete"
+)
+
+const (
+	numWorkers = 4    // concurrent replications per bucket (async)
+	workChSize = 1024 // async ops queued per bucket (the callers block when full)
+	numRetries = 2    // async only
+	maxFailed  = 1000 // max number of failed objects kept (per bucket)
+	retryDelay = time.Second
+)
+
+type (
+	// Remote is the destination of replication - see ais/cloud.AisCloudProvider
+	Remote interface {
+		PutObjRemote(remoteBck cmn.Bck, objName string, fh *cmn.FileHandle, size int64, cksum *cmn.Cksum) error
+		DeleteObjRemote(remoteBck cmn.Bck, objName string) error
+	}
+
+	op struct {
+		objName string
+		kind    string // OpPut | OpDelete
+		ts      int64  // Unix time (nanoseconds) the op was queued
+	}
+
+	// per-bucket status
+	status struct {
+		mu         sync.Mutex
+		pending    map[*op]struct{}
+		failed     map[string]*cmn.ReplFailure // by object name
+		replicated int64
+		bytes      int64
+		failedCnt  int64
+		lastTime   int64
+	}
+)
+
+var (
+	statuses    sync.Map // bucket uname => *status
+	errDisabled = errors.New("replication disabled")
+)
+
+// Replicate replicates a given PUT (object) or DELETE as per the bucket's
+// replication config; returns nil when replication is not enabled. In async
+// mode, only the failure to queue the op is returned.
+func Replicate(t cluster.Target, remote Remote, lom *cluster.LOM, kind string) (err error) {
+	conf := &lom.Bprops().Replication
+	if !conf.Enabled() {
+		return
+	}
+	o := &op{objName: lom.ObjName, kind: kind, ts: time.Now().UnixNano()}
+	st := bckStatus(lom.Bck().Bck)
+	if conf.Sync() {
+		st.add(o)
+		size, err := replicate(t, remote, lom.Bck(), o)
+		st.done(o, size, err)
+		if err == errDisabled {
+			err = nil
+		}
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		var xact cluster.Xact
+		xact, err = registry.Registry.RenewBucketXact(cmn.ActReplicate, lom.Bck(), registry.XactArgs{T: t, Custom: remote})
+		if err != nil {
+			return
+		}
+		if err = xact.(*Xaction).repl(o); !xaction.IsErrXactExpired(err) {
+			break
+		}
+		// retry upon race vs (just finished/timed_out)
+	}
+	return
+}
+
+// Status returns replication status of a given bucket.
+func Status(bck cmn.Bck) *cmn.ReplStatus {
+	return bckStatus(bck).get(time.Now().UnixNano())
+}
+
+// Aggregate combines per-target statuses of a given bucket.
+func Aggregate(bck cmn.Bck, conf cmn.RemoteReplConf, targets map[string]*cmn.ReplStatus) *cmn.ClusterReplStatus {
+	cs := &cmn.ClusterReplStatus{Bck: bck, Conf: conf, Targets: targets}
+	for _, ts := range targets {
+		cs.Cluster.Pending += ts.Pending
+		cs.Cluster.Lag = cmn.MaxDuration(cs.Cluster.Lag, ts.Lag)
+		cs.Cluster.Replicated += ts.Replicated
+		cs.Cluster.Bytes += ts.Bytes
+		cs.Cluster.FailedCnt += ts.FailedCnt
+		cs.Cluster.Failed = append(cs.Cluster.Failed, ts.Failed...)
+		if ts.LastTime > cs.Cluster.LastTime {
+			cs.Cluster.LastTime = ts.LastTime
+		}
+	}
+	sortFailed(cs.Cluster.Failed)
+	if len(cs.Cluster.Failed) > maxFailed {
+		cs.Cluster.Failed = cs.Cluster.Failed[:maxFailed]
+	}
+	return cs
+}
+
+// replicate a single op; returns errDisabled if replication has been disabled
+// in the meantime
+func replicate(t cluster.Target, remote Remote, bck *cluster.Bck, o *op) (size int64, err error) {
+	lom := &cluster.LOM{T: t, ObjName: o.objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		return
+	}
+	conf := &lom.Bprops().Replication
+	if !conf.Enabled() {
+		return 0, errDisabled
+	}
+	remoteBck := conf.RemoteBck(bck.Bck)
+	if o.kind == OpDelete {
+		err = remote.DeleteObjRemote(remoteBck, o.objName)
+	} else {
+		size, err = putRemote(lom, remote, remoteBck)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to replicate %s(%s): %v", o.kind, lom, err)
+	}
+	return
+}
+
+func putRemote(lom *cluster.LOM, remote Remote, remoteBck cmn.Bck) (int64, error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return 0, nil // deleted in the meantime - the delete will follow
+		}
+		return 0, err
+	}
+	fh, err := cmn.NewFileHandle(lom.FQN) // closed by `PutObjRemote`
+	if err != nil {
+		return 0, err
+	}
+	return lom.Size(), remote.PutObjRemote(remoteBck, lom.ObjName, fh, lom.Size(), lom.Cksum())
+}
+
+////////////
+// status //
+////////////
+
+func bckStatus(bck cmn.Bck) *status {
+	uname := cluster.NewBckEmbed(bck).MakeUname("")
+	if st, ok := statuses.Load(uname); ok {
+		return st.(*status)
+	}
+	st, _ := statuses.LoadOrStore(uname, &status{
+		pending: make(map[*op]struct{}),
+		failed:  make(map[string]*cmn.ReplFailure),
+	})
+	return st.(*status)
+}
+
+func (st *status) add(o *op) {
+	st.mu.Lock()
+	st.pending[o] = struct{}{}
+	st.mu.Unlock()
+}
+
+// (the op gets re-queued)
+func (st *status) cancel(o *op) {
+	st.mu.Lock()
+	delete(st.pending, o)
+	st.mu.Unlock()
+}
+
+func (st *status) done(o *op, size int64, err error) {
+	now := time.Now().UnixNano()
+	st.mu.Lock()
+	delete(st.pending, o)
+	if err == errDisabled {
+		st.mu.Unlock()
+		return
+	}
+	if err == nil {
+		st.replicated++
+		st.bytes += size
+		st.lastTime = now
+		delete(st.failed, o.objName)
+		st.mu.Unlock()
+		return
+	}
+	st.failedCnt++
+	if _, ok := st.failed[o.objName]; ok || len(st.failed) < maxFailed {
+		st.failed[o.objName] = &cmn.ReplFailure{ObjName: o.objName, Op: o.kind, Err: err.Error(), Time: now}
+	}
+	st.mu.Unlock()
+}
+
+func (st *status) get(now int64) *cmn.ReplStatus {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rs := &cmn.ReplStatus{
+		Pending:    int64(len(st.pending)),
+		Replicated: st.replicated,
+		Bytes:      st.bytes,
+		FailedCnt:  st.failedCnt,
+		LastTime:   st.lastTime,
+		Failed:     make([]*cmn.ReplFailure, 0, len(st.failed)),
+	}
+	for o := range st.pending {
+		rs.Lag = cmn.MaxDuration(rs.Lag, time.Duration(now-o.ts))
+	}
+	for _, f := range st.failed {
+		fcopy := *f
+		rs.Failed = append(rs.Failed, &fcopy)
+	}
+	sortFailed(rs.Failed)
+	return rs
+}
+
+func sortFailed(failed []*cmn.ReplFailure) {
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Time > failed[j].Time })
+}
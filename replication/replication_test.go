@@ -0,0 +1,98 @@
+// Package replication provides continuous replication of ais buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestConf(t *testing.T) {
+	conf := cmn.RemoteReplConf{TargetCluster: "remais"}
+	tassert.CheckFatal(t, conf.ValidateAsProps(nil))
+	tassert.Errorf(t, conf.Mode == cmn.ReplicationAsync, "expected async by default, got %q", conf.Mode)
+	bck := cmn.Bck{Name: "src", Provider: cmn.ProviderAIS}
+	tassert.Errorf(t, conf.RemoteBck(bck).Equal(cmn.Bck{Name: "src", Provider: cmn.ProviderAIS,
+		Ns: cmn.Ns{UUID: "remais"}}), "unexpected remote bucket %s", conf.RemoteBck(bck))
+	conf.Bucket = "dst"
+	tassert.Errorf(t, conf.RemoteBck(bck).Name == "dst", "unexpected remote bucket %s", conf.RemoteBck(bck))
+
+	conf.Mode = "eventual"
+	tassert.Errorf(t, conf.ValidateAsProps(nil) != nil, "expected invalid mode to fail validation")
+	tassert.Errorf(t, (&cmn.RemoteReplConf{Mode: "eventual"}).ValidateAsProps(nil) == nil,
+		"expected disabled replication to pass validation")
+}
+
+func TestStatus(t *testing.T) {
+	var (
+		st = &status{pending: make(map[*op]struct{}), failed: make(map[string]*cmn.ReplFailure)}
+		ts = time.Now().Add(-time.Minute).UnixNano()
+		o1 = &op{objName: "a", kind: OpPut, ts: ts}
+		o2 = &op{objName: "b", kind: OpDelete, ts: ts + int64(time.Second)}
+		o3 = &op{objName: "a", kind: OpPut, ts: ts + int64(2*time.Second)}
+	)
+	st.add(o1)
+	st.add(o2)
+	rs := st.get(ts + int64(10*time.Second))
+	tassert.Errorf(t, rs.Pending == 2, "expected 2 pending, got %d", rs.Pending)
+	tassert.Errorf(t, rs.Lag == 10*time.Second, "expected lag of the oldest, got %v", rs.Lag)
+
+	st.done(o1, 0, errors.New("unreachable"))
+	st.done(o2, 0, nil)
+	rs = st.get(ts)
+	tassert.Errorf(t, rs.Pending == 0 && rs.Lag == 0, "expected nothing pending, got %d (%v)", rs.Pending, rs.Lag)
+	tassert.Errorf(t, rs.Replicated == 1 && rs.FailedCnt == 1, "unexpected counters %+v", rs)
+	tassert.Fatalf(t, len(rs.Failed) == 1 && rs.Failed[0].ObjName == "a", "unexpected failures %+v", rs.Failed)
+
+	// subsequent successful replication clears the failure
+	st.add(o3)
+	st.done(o3, 100, nil)
+	rs = st.get(ts)
+	tassert.Errorf(t, len(rs.Failed) == 0 && rs.FailedCnt == 1, "unexpected failures %+v", rs.Failed)
+	tassert.Errorf(t, rs.Replicated == 2 && rs.Bytes == 100, "unexpected counters %+v", rs)
+
+	// disabled in the meantime - neither replicated nor failed
+	st.add(o1)
+	st.done(o1, 0, errDisabled)
+	rs = st.get(ts)
+	tassert.Errorf(t, rs.Pending == 0 && rs.Replicated == 2 && rs.FailedCnt == 1, "unexpected counters %+v", rs)
+}
+
+func TestAggregate(t *testing.T) {
+	targets := map[string]*cmn.ReplStatus{
+		"t1": {Pending: 1, Lag: time.Second, Replicated: 10, Bytes: 1000, LastTime: 5,
+			FailedCnt: 1, Failed: []*cmn.ReplFailure{{ObjName: "a", Time: 1}}},
+		"t2": {Pending: 2, Lag: time.Minute, Replicated: 5, Bytes: 500, LastTime: 7,
+			FailedCnt: 3, Failed: []*cmn.ReplFailure{{ObjName: "b", Time: 3}, {ObjName: "c", Time: 2}}},
+	}
+	cs := Aggregate(cmn.Bck{Name: "src"}, cmn.RemoteReplConf{TargetCluster: "remais"}, targets)
+	tassert.Errorf(t, cs.Cluster.Pending == 3 && cs.Cluster.Lag == time.Minute, "unexpected %+v", cs.Cluster)
+	tassert.Errorf(t, cs.Cluster.Replicated == 15 && cs.Cluster.Bytes == 1500, "unexpected %+v", cs.Cluster)
+	tassert.Errorf(t, cs.Cluster.FailedCnt == 4 && cs.Cluster.LastTime == 7, "unexpected %+v", cs.Cluster)
+	tassert.Fatalf(t, len(cs.Cluster.Failed) == 3, "expected 3 failures, got %d", len(cs.Cluster.Failed))
+	for i, name := range []string{"b", "c", "a"} { // most recent first
+		tassert.Errorf(t, cs.Cluster.Failed[i].ObjName == name, "failure %d: expected %q, got %q",
+			i, name, cs.Cluster.Failed[i].ObjName)
+	}
+}
+
+func TestShard(t *testing.T) {
+	var cnt [numWorkers]int
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("obj-%d", i)
+		s := shard(name)
+		tassert.Fatalf(t, s < numWorkers, "shard %d out of range", s)
+		tassert.Errorf(t, shard(name) == s, "%q: expected the same shard", name)
+		cnt[s]++
+	}
+	for i, n := range cnt {
+		tassert.Errorf(t, n > 0, "worker %d: no ops", i)
+	}
+}
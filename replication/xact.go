@@ -0,0 +1,179 @@
+// Package replication provides continuous replication of ais buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+	"github.com/OneOfOne/xxhash"
+)
+
+type (
+	xactProvider struct {
+		registry.BaseBckEntry
+		xact *Xaction
+
+		t      cluster.Target
+		remote Remote
+	}
+	// Xaction replicates (async) bucket's PUTs and DELETEs; on-demand.
+	Xaction struct {
+		xaction.XactDemandBase
+		t      cluster.Target
+		remote Remote
+		bck    *cluster.Bck
+		st     *status
+		// one channel per worker: ops of a given object always go to the same
+		// worker - in order
+		workChs []chan *op
+		stopCh  *cmn.StopCh
+	}
+)
+
+var errAborted = errors.New("replication aborted")
+
+func init() {
+	registry.Registry.RegisterBucketXact(&xactProvider{})
+}
+
+func (*xactProvider) New(args registry.XactArgs) registry.BucketEntry {
+	return &xactProvider{t: args.T, remote: args.Custom.(Remote)}
+}
+
+func (p *xactProvider) Start(bck cmn.Bck) error {
+	p.xact = newXact(p.t, p.remote, bck)
+	return nil
+}
+func (*xactProvider) Kind() string        { return cmn.ActReplicate }
+func (p *xactProvider) Get() cluster.Xact { return p.xact }
+
+func newXact(t cluster.Target, remote Remote, bck cmn.Bck) (r *Xaction) {
+	r = &Xaction{
+		XactDemandBase: *xaction.NewXactDemandBaseBck(cmn.ActReplicate, bck),
+		t:              t,
+		remote:         remote,
+		bck:            cluster.NewBckEmbed(bck),
+		st:             bckStatus(bck),
+		workChs:        make([]chan *op, numWorkers),
+		stopCh:         cmn.NewStopCh(),
+	}
+	for i := range r.workChs {
+		r.workChs[i] = make(chan *op, workChSize/numWorkers)
+	}
+	r.InitIdle()
+	go func() {
+		err := r.Run()
+		r.Finish(err)
+	}()
+	for _, workCh := range r.workChs {
+		go r.work(workCh)
+	}
+	return
+}
+
+func (r *Xaction) IsMountpathXact() bool { return false }
+
+func (r *Xaction) Run() error {
+	glog.Infoln(r.String())
+	select {
+	case <-r.IdleTimer():
+		r.stop()
+		return nil
+	case <-r.ChanAbort():
+		if n := r.stop(); n > 0 {
+			return cmn.NewAbortedError(fmt.Sprintf("%s: dropped %d pending op(s)", r, n))
+		}
+		return cmn.NewAbortedError(r.String())
+	}
+}
+
+// queue a given op for replication
+func (r *Xaction) repl(o *op) error {
+	if r.Finished() {
+		return xaction.NewErrXactExpired("Cannot replicate: " + r.String())
+	}
+	r.IncPending() // ref-count via base to support on-demand action
+	r.st.add(o)
+	select {
+	case r.workChs[shard(o.objName)] <- o:
+		return nil
+	case <-r.stopCh.Listen():
+		r.st.cancel(o)
+		r.DecPending()
+		return xaction.NewErrXactExpired("Cannot replicate: " + r.String())
+	}
+}
+
+func shard(objName string) uint64 { return xxhash.ChecksumString64S(objName, cmn.MLCG32) % numWorkers }
+
+func (r *Xaction) work(workCh chan *op) {
+	for {
+		select {
+		case o := <-workCh:
+			r.do(o)
+			r.DecPending()
+		case <-r.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (r *Xaction) do(o *op) {
+	var (
+		size int64
+		err  error
+	)
+	for i := 0; i <= numRetries; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(retryDelay):
+			case <-r.stopCh.Listen():
+				r.st.done(o, 0, errAborted)
+				return
+			}
+		}
+		if size, err = replicate(r.t, r.remote, r.bck, o); err == nil || err == errDisabled {
+			break
+		}
+	}
+	r.st.done(o, size, err)
+	switch err {
+	case nil:
+		r.ObjectsInc()
+		r.BytesAdd(size)
+	case errDisabled:
+	default:
+		glog.Errorf("%s: %v", r, err)
+	}
+}
+
+// stop and drop pending ops (recording them as failed)
+func (r *Xaction) stop() (n int) {
+	r.XactDemandBase.Stop()
+	r.stopCh.Close()
+	for _, workCh := range r.workChs {
+	drain:
+		for {
+			select {
+			case o := <-workCh:
+				r.st.done(o, 0, errAborted)
+				n++
+			default:
+				break drain
+			}
+		}
+	}
+	if n > 0 {
+		r.SubPending(n)
+	}
+	return
+}
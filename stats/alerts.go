@@ -0,0 +1,285 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Alerts: the rules (cmn.AlertsConf) get evaluated by the target's stats
+// runner every config.Periodic.StatsTime; each rule is either firing or not,
+// and every state change (firing, resolved) gets delivered to all sinks - the
+// ones configured (webhook, email) and the ones registered via RegisterAlertSink.
+// Notifications are delivered by a single worker, in the order of the state changes.
+
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+
+	alertNotifyTimeout = 10 * time.Second
+	alertQueueSize     = 64 // max pending notifications (the rest get dropped)
+)
+
+type (
+	Alert struct {
+		Rule    string    `json:"rule"`
+		Kind    string    `json:"kind"`
+		State   string    `json:"state"`
+		Node    string    `json:"node"`
+		Message string    `json:"message"`
+		Since   time.Time `json:"since"` // when the alert started firing
+		Time    time.Time `json:"time"`  // when the state last changed
+	}
+	// AlertSink delivers alert notifications
+	AlertSink interface {
+		Name() string
+		Notify(alert *Alert) error
+	}
+
+	alerter struct {
+		mu      sync.Mutex
+		firing  map[string]*Alert // by rule name
+		errCnt  int64             // total number of errors at the previous evaluation
+		errTime time.Time
+		once    sync.Once
+		notifCh chan alertNotif // see notifier
+	}
+	alertNotif struct {
+		config *cmn.Config
+		alert  *Alert
+	}
+	webhookSink struct {
+		url string
+	}
+	emailSink struct {
+		conf cmn.AlertEmailConf
+	}
+)
+
+var (
+	alertSinks   []AlertSink
+	alertSinksMu sync.RWMutex
+	alertClient  = &http.Client{Timeout: alertNotifyTimeout}
+)
+
+// RegisterAlertSink adds a sink in addition to the configured ones (see cmn.AlertsConf)
+func RegisterAlertSink(sink AlertSink) {
+	alertSinksMu.Lock()
+	alertSinks = append(alertSinks, sink)
+	alertSinksMu.Unlock()
+}
+
+/////////////
+// alerter //
+/////////////
+
+func (a *alerter) evaluate(r *Trunner, config *cmn.Config) {
+	if !config.Alerts.Enabled {
+		a.mu.Lock()
+		a.firing = nil
+		a.mu.Unlock()
+		return
+	}
+	var (
+		now     = time.Now()
+		errRate = a.errRate(r, now)
+		rules   = make(cmn.StringSet, len(config.Alerts.Rules))
+	)
+	for i := range config.Alerts.Rules {
+		rule := &config.Alerts.Rules[i]
+		rules.Add(rule.Name)
+		msg, firing := r.checkAlert(rule, config, errRate, now)
+		a.update(r, config, rule.Name, rule.Kind, msg, firing, now)
+	}
+	// rules that are no longer configured get resolved
+	a.mu.Lock()
+	var removed []*Alert
+	for name, alert := range a.firing {
+		if !rules.Contains(name) {
+			removed = append(removed, alert)
+		}
+	}
+	a.mu.Unlock()
+	for _, alert := range removed {
+		a.update(r, config, alert.Rule, alert.Kind, "rule removed", false, now)
+	}
+}
+
+// errRate returns the number of errors per second since the previous evaluation
+func (a *alerter) errRate(r *Trunner, now time.Time) (rate float64) {
+	var cnt int64
+	for name, v := range r.Core.Tracker {
+		if v.kind == KindCounter && strings.HasPrefix(name, "err.") && strings.HasSuffix(name, ".n") {
+			cnt += r.Core.get(name)
+		}
+	}
+	if !a.errTime.IsZero() {
+		if elapsed := now.Sub(a.errTime).Seconds(); elapsed > 0 {
+			rate = float64(cnt-a.errCnt) / elapsed
+		}
+	}
+	a.errCnt, a.errTime = cnt, now
+	return
+}
+
+func (a *alerter) update(r *Trunner, config *cmn.Config, name, kind, msg string, firing bool, now time.Time) {
+	a.mu.Lock()
+	alert, wasFiring := a.firing[name]
+	switch {
+	case firing && !wasFiring:
+		if a.firing == nil {
+			a.firing = make(map[string]*Alert, len(config.Alerts.Rules))
+		}
+		alert = &Alert{Rule: name, Kind: kind, State: AlertFiring, Node: r.T.Snode().ID(), Since: now}
+		a.firing[name] = alert
+	case !firing && wasFiring:
+		delete(a.firing, name)
+		alert.State = AlertResolved
+	default:
+		if firing {
+			alert.Message = msg // (keep it current)
+		}
+		a.mu.Unlock()
+		return
+	}
+	alert.Message, alert.Time = msg, now
+	notif := *alert
+	a.mu.Unlock()
+
+	if notif.State == AlertFiring {
+		glog.Warningf("alert %q firing: %s", notif.Rule, notif.Message)
+	} else {
+		glog.Infof("alert %q resolved", notif.Rule)
+	}
+	a.notify(config, &notif)
+}
+
+// notify queues the notification for the (single, ordered) notifier
+func (a *alerter) notify(config *cmn.Config, alert *Alert) {
+	a.once.Do(func() {
+		a.notifCh = make(chan alertNotif, alertQueueSize)
+		go a.notifier()
+	})
+	select {
+	case a.notifCh <- alertNotif{config: config, alert: alert}:
+	default:
+		glog.Errorf("alert %q %s: too many pending notifications - dropping", alert.Rule, alert.State)
+	}
+}
+
+func (a *alerter) notifier() {
+	for n := range a.notifCh {
+		notifyAlert(n.config, n.alert)
+	}
+}
+
+func (a *alerter) alerts() []*Alert {
+	a.mu.Lock()
+	alerts := make([]*Alert, 0, len(a.firing))
+	for _, alert := range a.firing {
+		cpy := *alert
+		alerts = append(alerts, &cpy)
+	}
+	a.mu.Unlock()
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Rule < alerts[j].Rule })
+	return alerts
+}
+
+// returns the message and whether a given rule is firing
+func (r *Trunner) checkAlert(rule *cmn.AlertRule, config *cmn.Config, errRate float64, now time.Time) (string, bool) {
+	switch rule.Kind {
+	case cmn.AlertCapacity:
+		cs := r.MPCap.CapStatus(config)
+		if float64(cs.PctMax) > rule.Threshold {
+			return fmt.Sprintf("used capacity %d%% exceeds %v%%", cs.PctMax, rule.Threshold), true
+		}
+	case cmn.AlertErrorRate:
+		if errRate > rule.Threshold {
+			return fmt.Sprintf("error rate %.2f/s exceeds %v/s", errRate, rule.Threshold), true
+		}
+	case cmn.AlertMpathDisabled:
+		_, disabled := fs.Get()
+		if len(disabled) > 0 {
+			mpaths := make([]string, 0, len(disabled))
+			for mpath := range disabled {
+				mpaths = append(mpaths, mpath)
+			}
+			sort.Strings(mpaths)
+			return fmt.Sprintf("disabled mountpaths: %s", strings.Join(mpaths, ", ")), true
+		}
+	case cmn.AlertRebalanceDuration:
+		if r.RebStarted == nil {
+			break
+		}
+		if started := r.RebStarted(); !started.IsZero() && now.Sub(started) > rule.Duration {
+			return fmt.Sprintf("rebalance running for %v (longer than %v)",
+				now.Sub(started).Round(time.Second), rule.Duration), true
+		}
+	}
+	return "", false
+}
+
+func notifyAlert(config *cmn.Config, alert *Alert) {
+	alertSinksMu.RLock()
+	sinks := make([]AlertSink, 0, len(alertSinks)+2)
+	sinks = append(sinks, alertSinks...)
+	alertSinksMu.RUnlock()
+	if config.Alerts.Webhook != "" {
+		sinks = append(sinks, &webhookSink{url: config.Alerts.Webhook})
+	}
+	if config.Alerts.Email.Server != "" {
+		sinks = append(sinks, &emailSink{conf: config.Alerts.Email})
+	}
+	for _, sink := range sinks {
+		if err := sink.Notify(alert); err != nil {
+			glog.Errorf("alert %q: failed to notify %s: %v", alert.Rule, sink.Name(), err)
+		}
+	}
+}
+
+///////////
+// sinks //
+///////////
+
+func (s *webhookSink) Name() string { return "webhook " + s.url }
+
+func (s *webhookSink) Notify(alert *Alert) error {
+	resp, err := alertClient.Post(s.url, cmn.ContentJSON, bytes.NewReader(cmn.MustMarshal(alert)))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *emailSink) Name() string { return "email " + s.conf.Server }
+
+func (s *emailSink) Notify(alert *Alert) error {
+	var (
+		body = alert.Message
+		msg  = fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: [ais] alert %s %s: %s\r\n\r\n",
+			s.conf.From, strings.Join(s.conf.To, ", "), alert.Rule, alert.State, alert.Node)
+	)
+	if body == "" {
+		body = alert.State
+	}
+	msg += fmt.Sprintf("%s\r\nsince: %s\r\n", body, alert.Since.Format(time.RFC3339))
+	return smtp.SendMail(s.conf.Server, nil, s.conf.From, s.conf.To, []byte(msg))
+}
@@ -0,0 +1,81 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type (
+	alertTargetMock struct {
+		cluster.TargetMock
+		si *cluster.Snode
+	}
+	alertSinkMock struct {
+		mu     sync.Mutex
+		alerts []Alert
+	}
+)
+
+func (t *alertTargetMock) Snode() *cluster.Snode { return t.si }
+
+func (*alertSinkMock) Name() string { return "mock" }
+
+func (s *alertSinkMock) Notify(alert *Alert) error {
+	time.Sleep(time.Millisecond) // slow sink
+	s.mu.Lock()
+	s.alerts = append(s.alerts, *alert)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *alertSinkMock) states() (states []string) {
+	s.mu.Lock()
+	for _, alert := range s.alerts {
+		states = append(states, alert.State)
+	}
+	s.mu.Unlock()
+	return
+}
+
+func TestAlertsOrder(t *testing.T) {
+	const flips = 20
+	var (
+		sink   = &alertSinkMock{}
+		r      = &Trunner{T: &alertTargetMock{si: &cluster.Snode{DaemonID: "t1"}}}
+		config = &cmn.Config{}
+		a      = &alerter{}
+	)
+	config.Alerts.Enabled = true
+	RegisterAlertSink(sink)
+
+	for i := 0; i < flips; i++ {
+		firing := i%2 == 0
+		a.update(r, config, "rule", cmn.AlertErrorRate, "msg", firing, time.Now())
+		// no change - no notification
+		a.update(r, config, "rule", cmn.AlertErrorRate, "msg", firing, time.Now())
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.states()) < flips && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	states := sink.states()
+	tassert.Fatalf(t, len(states) == flips, "expected %d notifications, got %d", flips, len(states))
+	for i, state := range states {
+		expected := AlertFiring
+		if i%2 == 1 {
+			expected = AlertResolved
+		}
+		tassert.Errorf(t, state == expected, "notification #%d: expected %q, got %q", i, expected, state)
+	}
+	tassert.Errorf(t, len(a.alerts()) == 0, "expected no firing alerts, got %v", a.alerts())
+}
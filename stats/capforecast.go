@@ -0,0 +1,170 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Capacity forecast: the target samples its (total) used and available
+// capacity every capSampleInterval and persists the last week worth of samples
+// under config.Confdir, so that the history survives restarts. The ingest rate
+// is the least-squares slope of the used bytes over the last capForecastWindow;
+// the projected times to reach the high watermark and OOS (cmn.LRUConf) assume
+// that the rate remains constant.
+
+const (
+	capSampleInterval = 10 * time.Minute
+	capHistoryMax     = 7 * 24 * int(time.Hour/capSampleInterval) // one week
+	capForecastWindow = 24 * time.Hour
+	capHistFname      = ".ais.caphist"
+)
+
+type (
+	capSample struct {
+		Time   time.Time `json:"time"`
+		Used   uint64    `json:"used,string"`
+		Avail  uint64    `json:"avail,string"`
+		PctMax int32     `json:"pct_max"`
+	}
+	capHistory struct {
+		mu      sync.RWMutex
+		samples []capSample
+		fpath   string
+	}
+)
+
+////////////////
+// capHistory //
+////////////////
+
+func (h *capHistory) init(confdir string) {
+	h.fpath = filepath.Join(confdir, capHistFname)
+	if err := jsp.Load(h.fpath, &h.samples, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("failed to load capacity history %q: %v", h.fpath, err)
+		h.samples = nil
+	}
+}
+
+func (h *capHistory) add(cs fs.CapStatus, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if l := len(h.samples); l > 0 && now.Sub(h.samples[l-1].Time) < capSampleInterval {
+		return
+	}
+	h.samples = append(h.samples, capSample{Time: now, Used: cs.TotalUsed, Avail: cs.TotalAvail, PctMax: cs.PctMax})
+	if l := len(h.samples); l > capHistoryMax {
+		h.samples = append(h.samples[:0], h.samples[l-capHistoryMax:]...)
+	}
+	if h.fpath == "" {
+		return
+	}
+	if err := jsp.Save(h.fpath, h.samples, jsp.Plain()); err != nil {
+		glog.Errorf("failed to save capacity history %q: %v", h.fpath, err)
+	}
+}
+
+func (h *capHistory) forecast(config *cmn.Config) (f cmn.CapForecast) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	l := len(h.samples)
+	if l == 0 {
+		f.TimeToHighWM, f.TimeToOOS = -1, -1
+		return
+	}
+	var (
+		last  = h.samples[l-1]
+		first = l - 1
+	)
+	for first > 0 && last.Time.Sub(h.samples[first-1].Time) <= capForecastWindow {
+		first--
+	}
+	window := h.samples[first:]
+	f.Samples = len(window)
+	f.Window = last.Time.Sub(window[0].Time)
+	f.Used, f.Total = last.Used, last.Used+last.Avail
+	f.IngestRate = ingestRate(window)
+	f.TimeToHighWM = timeToPct(f.Used, f.Total, f.IngestRate, config.LRU.HighWM)
+	f.TimeToOOS = timeToPct(f.Used, f.Total, f.IngestRate, config.LRU.OOS)
+	return
+}
+
+// ingestRate returns the least-squares slope of used bytes over time, in bytes per second.
+func ingestRate(samples []capSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	var (
+		n          = float64(len(samples))
+		t0         = samples[0].Time
+		sumT, sumU float64
+	)
+	for _, s := range samples {
+		sumT += s.Time.Sub(t0).Seconds()
+		sumU += float64(s.Used)
+	}
+	var (
+		meanT, meanU = sumT / n, sumU / n
+		cov, varT    float64
+	)
+	for _, s := range samples {
+		dt := s.Time.Sub(t0).Seconds() - meanT
+		cov += dt * (float64(s.Used) - meanU)
+		varT += dt * dt
+	}
+	if varT == 0 {
+		return 0
+	}
+	return cov / varT
+}
+
+// timeToPct returns the time it'll take to fill `total` up to `pct` percent
+// at the given rate: 0 if already there, -1 if never.
+func timeToPct(used, total uint64, rate float64, pct int64) time.Duration {
+	threshold := float64(total) * float64(pct) / 100
+	if float64(used) >= threshold {
+		return 0
+	}
+	if rate <= 0 {
+		return -1
+	}
+	secs := (threshold - float64(used)) / rate
+	if secs >= math.MaxInt64/float64(time.Second) {
+		return -1
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// AggregateCapForecast combines the targets' forecasts into the cluster-wide one
+// (as if all targets were a single storage pool).
+func AggregateCapForecast(targets map[string]*cmn.CapForecast, config *cmn.Config) *cmn.ClusterCapForecast {
+	res := &cmn.ClusterCapForecast{Targets: targets}
+	for _, f := range targets {
+		if f == nil {
+			continue
+		}
+		c := &res.Cluster
+		c.Samples = cmn.Max(c.Samples, f.Samples)
+		if f.Window > c.Window {
+			c.Window = f.Window
+		}
+		c.Used += f.Used
+		c.Total += f.Total
+		c.IngestRate += f.IngestRate
+	}
+	res.Cluster.TimeToHighWM = timeToPct(res.Cluster.Used, res.Cluster.Total, res.Cluster.IngestRate, config.LRU.HighWM)
+	res.Cluster.TimeToOOS = timeToPct(res.Cluster.Used, res.Cluster.Total, res.Cluster.IngestRate, config.LRU.OOS)
+	return res
+}
@@ -0,0 +1,113 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestIngestRate(t *testing.T) {
+	var (
+		start   = time.Now()
+		samples []capSample
+	)
+	tassert.Errorf(t, ingestRate(nil) == 0, "no samples: expected zero rate")
+	// 1000 bytes per second, with noise that cancels out
+	for i := 0; i < 10; i++ {
+		used := uint64(1000*i*60) + 100
+		if i%2 == 1 {
+			used -= 200
+		}
+		samples = append(samples, capSample{Time: start.Add(time.Duration(i) * time.Minute), Used: used})
+	}
+	rate := ingestRate(samples)
+	tassert.Errorf(t, math.Abs(rate-1000) < 1, "expected ~1000 B/s, got %f", rate)
+
+	// same time - undefined slope
+	same := []capSample{{Time: start, Used: 1}, {Time: start, Used: 100}}
+	tassert.Errorf(t, ingestRate(same) == 0, "expected zero rate, got %f", ingestRate(same))
+}
+
+func TestTimeToPct(t *testing.T) {
+	tests := []struct {
+		used, total uint64
+		rate        float64
+		pct         int64
+		expected    time.Duration
+	}{
+		{used: 90, total: 100, rate: 1, pct: 90, expected: 0},
+		{used: 50, total: 100, rate: 0, pct: 90, expected: -1},
+		{used: 50, total: 100, rate: -1, pct: 90, expected: -1},
+		{used: 50, total: 100, rate: 10, pct: 90, expected: 4 * time.Second},
+		{used: 0, total: math.MaxUint64, rate: 1e-9, pct: 100, expected: -1}, // overflow
+	}
+	for _, test := range tests {
+		d := timeToPct(test.used, test.total, test.rate, test.pct)
+		tassert.Errorf(t, d == test.expected, "%+v: expected %v, got %v", test, test.expected, d)
+	}
+}
+
+func TestCapForecast(t *testing.T) {
+	var (
+		h      = &capHistory{}
+		config = &cmn.Config{}
+		start  = time.Now().Add(-48 * time.Hour)
+		total  = uint64(100 * cmn.GiB)
+	)
+	config.LRU.HighWM, config.LRU.OOS = 90, 95
+
+	f := h.forecast(config)
+	tassert.Errorf(t, f.TimeToHighWM == -1 && f.TimeToOOS == -1, "no history: expected no forecast, got %+v", f)
+
+	// two days of history: 1 GiB/hour in the first day, 2 GiB/hour in the last
+	var used uint64
+	for now := start; now.Before(start.Add(48 * time.Hour)); now = now.Add(capSampleInterval) {
+		h.add(fs.CapStatus{TotalUsed: used, TotalAvail: total - used}, now)
+		h.add(fs.CapStatus{TotalUsed: used, TotalAvail: total - used}, now.Add(time.Second)) // too soon - ignored
+		if now.Sub(start) < 24*time.Hour {
+			used += cmn.GiB / 6
+		} else {
+			used += cmn.GiB / 3
+		}
+	}
+	f = h.forecast(config)
+	// the window is the last day only
+	tassert.Errorf(t, f.Window <= 24*time.Hour && f.Window > 23*time.Hour, "unexpected window %v", f.Window)
+	tassert.Errorf(t, f.Samples == int(24*time.Hour/capSampleInterval)+1, "unexpected number of samples %d", f.Samples)
+	rate := float64(2*cmn.GiB) / time.Hour.Seconds()
+	tassert.Errorf(t, math.Abs(f.IngestRate-rate)/rate < 0.01, "expected rate %f, got %f", rate, f.IngestRate)
+	tassert.Errorf(t, f.TimeToHighWM > 0 && f.TimeToHighWM < f.TimeToOOS, "unexpected forecast %+v", f)
+
+	// the history is capped
+	for i := 0; i < capHistoryMax; i++ {
+		now := start.Add(48*time.Hour + time.Duration(i)*capSampleInterval)
+		h.add(fs.CapStatus{TotalUsed: used, TotalAvail: total - used}, now)
+	}
+	tassert.Errorf(t, len(h.samples) == capHistoryMax, "expected %d samples, got %d", capHistoryMax, len(h.samples))
+}
+
+func TestAggregateCapForecast(t *testing.T) {
+	config := &cmn.Config{}
+	config.LRU.HighWM, config.LRU.OOS = 90, 95
+	targets := map[string]*cmn.CapForecast{
+		"t1": {Samples: 10, Window: time.Hour, Used: 40, Total: 100, IngestRate: 1},
+		"t2": {Samples: 5, Window: 2 * time.Hour, Used: 50, Total: 100, IngestRate: 2},
+		"t3": nil, // failed to respond
+	}
+	res := AggregateCapForecast(targets, config)
+	c := res.Cluster
+	tassert.Errorf(t, c.Used == 90 && c.Total == 200 && c.IngestRate == 3, "unexpected totals %+v", c)
+	tassert.Errorf(t, c.Samples == 10 && c.Window == 2*time.Hour, "unexpected samples/window %+v", c)
+	tassert.Errorf(t, c.TimeToHighWM == 30*time.Second, "expected 30s to high watermark, got %v", c.TimeToHighWM)
+	oos := (190 - 90) / 3.0 // seconds
+	tassert.Errorf(t, math.Abs(c.TimeToOOS.Seconds()-oos) < 0.001, "expected %.3fs to OOS, got %v", oos, c.TimeToOOS)
+}
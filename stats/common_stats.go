@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats/statsd"
 	"github.com/NVIDIA/aistore/xaction"
 	jsoniter "github.com/json-iterator/go"
@@ -102,6 +103,7 @@ type (
 		AddErrorHTTP(method string, val int64)
 		AddMany(namedVal64 ...NamedVal64)
 		RegisterAll()
+		MemStats() map[string]*memsys.Stats
 	}
 	NamedVal64 struct {
 		Name       string
@@ -173,6 +175,7 @@ type (
 		ticker    *time.Ticker
 		ctracker  copyTracker // to avoid making it at runtime
 		daemon    runnerHost
+		mt        memsysTracker
 		startedUp atomic.Bool
 	}
 	// Stats are tracked via a map of stats names (key) to statsValue (values).
@@ -184,7 +187,8 @@ type (
 		kind       string
 		numSamples int64
 		cumulative int64
-		isCommon   bool // optional, common to the proxy and target
+		samples    int64 // cumulative number of samples (latency only), never reset
+		isCommon   bool  // optional, common to the proxy and target
 	}
 	copyValue struct {
 		Value int64 `json:"v,string"`
@@ -246,6 +250,7 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 		}
 		v.Lock()
 		v.numSamples++
+		v.samples++
 		v.cumulative += val
 		v.Value += val
 		v.Unlock()
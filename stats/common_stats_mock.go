@@ -5,6 +5,8 @@
  */
 package stats
 
+import "github.com/NVIDIA/aistore/memsys"
+
 type (
 	TrackerMock struct{}
 )
@@ -22,3 +24,4 @@ func (*TrackerMock) Get(name string) int64                 { return 0 }
 func (*TrackerMock) AddErrorHTTP(method string, val int64) {}
 func (*TrackerMock) AddMany(namedVal64 ...NamedVal64)      {}
 func (*TrackerMock) RegisterAll()                          {}
+func (*TrackerMock) MemStats() map[string]*memsys.Stats    { return nil }
@@ -0,0 +1,89 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"expvar"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats/statsd"
+)
+
+// Memory manager (memsys) stats of the node's MMSA instances: per-slab hits
+// and ring depths, size accumulated for GC, and allocation-size histogram
+// (see memsys.Stats). The stats are:
+// - sent to StatsD every stats period (hits and histogram as counters,
+//   the rest as gauges), e.g. "memsys.ais.mm.32KiB.hits";
+// - published as expvar "memsys";
+// - returned by GET /v1/daemon?what=memsys.
+
+type memsysTracker struct {
+	mms  []*memsys.MMSA
+	prev map[string]*memsys.Stats // by MMSA name - to send the increments
+}
+
+var memsysVarOnce sync.Once
+
+func (r *statsRunner) initMemsys(mms ...*memsys.MMSA) {
+	r.mt = memsysTracker{mms: mms, prev: make(map[string]*memsys.Stats, len(mms))}
+	memsysVarOnce.Do(func() {
+		expvar.Publish("memsys", expvar.Func(func() interface{} { return r.MemStats() }))
+	})
+}
+
+// MemStats returns memsys stats by MMSA name.
+func (r *statsRunner) MemStats() map[string]*memsys.Stats {
+	m := make(map[string]*memsys.Stats, len(r.mt.mms))
+	for _, mm := range r.mt.mms {
+		m[mm.Name] = mm.GetStats()
+	}
+	return m
+}
+
+// is called every stats period (see log())
+func (r *statsRunner) sendMemStats(statsdC *statsd.Client) {
+	for _, mm := range r.mt.mms {
+		var (
+			stats = mm.GetStats()
+			prev  = r.mt.prev[mm.Name]
+			nroot = "memsys." + strings.TrimPrefix(mm.Name, ".")
+		)
+		if prev == nil {
+			prev = &memsys.Stats{}
+		}
+		for i := range stats.Hits {
+			hits := int64(stats.Hits[i] - prev.Hits[i])
+			if hits == 0 && stats.Depth[i] == 0 {
+				continue // unused
+			}
+			statsdC.Send(nroot+"."+cmn.B2S(stats.SlabSize(i), 0), 1,
+				metric{Type: statsd.Counter, Name: "hits", Value: hits},
+				metric{Type: statsd.Gauge, Name: "depth", Value: stats.Depth[i]},
+			)
+		}
+		hist := make([]metric, 0, memsys.NumAllocBins)
+		for i := range stats.AllocHist {
+			name := "inf"
+			if i < len(memsys.AllocSizeBounds) {
+				name = cmn.B2S(memsys.AllocSizeBounds[i], 0)
+			}
+			if cnt := int64(stats.AllocHist[i] - prev.AllocHist[i]); cnt > 0 {
+				hist = append(hist, metric{Type: statsd.Counter, Name: name, Value: cnt})
+			}
+		}
+		if len(hist) > 0 {
+			statsdC.Send(nroot+".alloc", 1, hist...)
+		}
+		statsdC.Send(nroot, 1,
+			metric{Type: statsd.Gauge, Name: "togc", Value: stats.ToGC},
+			metric{Type: statsd.Gauge, Name: "pressure", Value: int64(stats.Pressure)},
+		)
+		r.mt.prev[mm.Name] = stats
+	}
+}
@@ -0,0 +1,276 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+)
+
+// Prometheus text exposition format (version 0.0.4), see
+// https://prometheus.io/docs/instrumenting/exposition_formats
+//
+// Stats names get converted as follows (node ID is always a label):
+//  -> counter "get.n"           => ais_get_total
+//  -> counter "get.cold.size"   => ais_get_cold_bytes_total
+//  -> latency "get.ns"          => ais_get_latency_seconds (summary: _sum and _count)
+//  -> throughput "get.bps"      => ais_get_bytes_total (cumulative)
+//  -> special "up.ns.time"      => ais_up_time_seconds (gauge)
+
+const PromContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	PromCounter = "counter"
+	PromGauge   = "gauge"
+	PromSummary = "summary"
+)
+
+// PromWriter writes metric families, one at a time, labeling each sample with
+// the node ID. All samples of a given family must be written right after the
+// family itself (see Family).
+type PromWriter struct {
+	w    io.Writer
+	node string
+	err  error
+	buf  []byte
+}
+
+func NewPromWriter(w io.Writer, nodeID string) *PromWriter {
+	return &PromWriter{w: w, node: nodeID, buf: make([]byte, 0, 256)}
+}
+
+// Err returns the first write error, if any (subsequent writes are no-ops).
+func (pw *PromWriter) Err() error { return pw.err }
+
+// Family writes the HELP and TYPE lines of the metric family.
+func (pw *PromWriter) Family(name, typ, help string) {
+	b := append(pw.buf[:0], "# HELP "...)
+	b = append(b, name...)
+	b = append(b, ' ')
+	b = append(b, strings.ReplaceAll(help, "\n", " ")...)
+	b = append(b, "\n# TYPE "...)
+	b = append(b, name...)
+	b = append(b, ' ')
+	b = append(b, typ...)
+	b = append(b, '\n')
+	pw.write(b)
+}
+
+// Sample writes a single sample; `labels` are name-value pairs (in addition
+// to the node label).
+func (pw *PromWriter) Sample(name string, value float64, labels ...string) {
+	b := append(pw.buf[:0], name...)
+	b = append(b, `{node="`...)
+	b = appendLabelValue(b, pw.node)
+	b = append(b, '"')
+	for i := 0; i+1 < len(labels); i += 2 {
+		b = append(b, ',')
+		b = append(b, labels[i]...)
+		b = append(b, `="`...)
+		b = appendLabelValue(b, labels[i+1])
+		b = append(b, '"')
+	}
+	b = append(b, "} "...)
+	b = strconv.AppendFloat(b, value, 'f', -1, 64)
+	b = append(b, '\n')
+	pw.write(b)
+}
+
+func (pw *PromWriter) write(b []byte) {
+	if pw.err == nil {
+		_, pw.err = pw.w.Write(b)
+	}
+	pw.buf = b
+}
+
+func appendLabelValue(b []byte, v string) []byte {
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; c {
+		case '\\':
+			b = append(b, `\\`...)
+		case '"':
+			b = append(b, `\"`...)
+		case '\n':
+			b = append(b, `\n`...)
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+func promName(name, kind string) (string, string) {
+	var root, suffix, typ string
+	switch kind {
+	case KindCounter:
+		if strings.HasSuffix(name, ".size") {
+			root = strings.TrimSuffix(name, ".size") + ".bytes"
+		} else {
+			root = strings.TrimSuffix(name, ".n")
+		}
+		suffix, typ = "_total", PromCounter
+	case KindLatency:
+		root = strings.Replace(name, ".ns", "", 1)
+		suffix, typ = "_latency_seconds", PromSummary
+	case KindThroughput:
+		root = strings.TrimSuffix(name, ".bps") + ".bytes"
+		suffix, typ = "_total", PromCounter
+	default:
+		if strings.Contains(name, ".ns") {
+			root, suffix = strings.Replace(name, ".ns", "", 1), "_seconds"
+		} else {
+			root = name
+		}
+		typ = PromGauge
+	}
+	root = strings.NewReplacer(".", "_", "-", "_").Replace(root)
+	return "ais_" + root + suffix, typ
+}
+
+///////////////
+// CoreStats //
+///////////////
+
+// WriteProm writes all tracked stats - cumulative values, the same ones as
+// GetWhatStats reports.
+func (s *CoreStats) WriteProm(pw *PromWriter) {
+	names := make([]string, 0, len(s.Tracker))
+	for name := range s.Tracker {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := s.Tracker[name]
+		v.RLock()
+		var (
+			kind       = v.kind
+			value      = v.Value
+			cumulative = v.cumulative
+			samples    = v.samples
+		)
+		v.RUnlock()
+		pname, typ := promName(name, kind)
+		pw.Family(pname, typ, "AIS stats "+strconv.Quote(name))
+		switch kind {
+		case KindLatency:
+			pw.Sample(pname+"_sum", time.Duration(cumulative).Seconds())
+			pw.Sample(pname+"_count", float64(samples))
+		case KindThroughput:
+			pw.Sample(pname, float64(cumulative))
+		case KindCounter:
+			pw.Sample(pname, float64(value))
+		default:
+			if strings.Contains(name, ".ns") {
+				pw.Sample(pname, time.Duration(value).Seconds())
+			} else {
+				pw.Sample(pname, float64(value))
+			}
+		}
+	}
+}
+
+/////////////
+// Prunner //
+/////////////
+
+func (r *Prunner) WriteProm(pw *PromWriter) { r.Core.WriteProm(pw) }
+
+/////////////
+// Trunner //
+/////////////
+
+// WriteProm writes the core stats followed by the per-mountpath capacity.
+func (r *Trunner) WriteProm(pw *PromWriter) {
+	r.Core.WriteProm(pw)
+
+	mpaths := make([]string, 0, len(r.MPCap))
+	for mpath := range r.MPCap {
+		mpaths = append(mpaths, mpath)
+	}
+	sort.Strings(mpaths)
+	pw.Family("ais_mountpath_used_bytes", PromGauge, "used capacity of the mountpath's filesystem")
+	for _, mpath := range mpaths {
+		pw.Sample("ais_mountpath_used_bytes", float64(r.MPCap[mpath].Used), "mountpath", mpath)
+	}
+	pw.Family("ais_mountpath_avail_bytes", PromGauge, "available capacity of the mountpath's filesystem")
+	for _, mpath := range mpaths {
+		pw.Sample("ais_mountpath_avail_bytes", float64(r.MPCap[mpath].Avail), "mountpath", mpath)
+	}
+	pw.Family("ais_mountpath_used_percent", PromGauge, "used capacity of the mountpath's filesystem, in percent")
+	for _, mpath := range mpaths {
+		pw.Sample("ais_mountpath_used_percent", float64(r.MPCap[mpath].PctUsed), "mountpath", mpath)
+	}
+}
+
+//////////////
+// xactions //
+//////////////
+
+// WriteXactProm writes the object and byte counters of the given xactions
+// summed up by kind and bucket (if any) - xaction IDs are not used as labels
+// to keep the number of series bounded. The sums include the recently
+// finished xactions; removing those from the registry (see cleanup) shows as
+// a counter reset.
+func WriteXactProm(pw *PromWriter, xacts []cluster.XactStats) {
+	type (
+		xactKey struct {
+			kind, bck string
+		}
+		xactSum struct {
+			objs, bytes, running int64
+		}
+	)
+	var (
+		sums = make(map[xactKey]*xactSum, len(xacts))
+		keys = make([]xactKey, 0, len(xacts))
+	)
+	for _, xact := range xacts {
+		key := xactKey{kind: xact.Kind()}
+		if bck := xact.Bck(); !bck.IsEmpty() {
+			key.bck = bck.String()
+		}
+		sum, ok := sums[key]
+		if !ok {
+			sum = &xactSum{}
+			sums[key] = sum
+			keys = append(keys, key)
+		}
+		sum.objs += xact.ObjCount()
+		sum.bytes += xact.BytesCount()
+		if xact.Running() {
+			sum.running++
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].bck < keys[j].bck
+	})
+	labels := func(key xactKey) []string {
+		if key.bck == "" {
+			return []string{"kind", key.kind}
+		}
+		return []string{"kind", key.kind, "bucket", key.bck}
+	}
+	pw.Family("ais_xaction_objects_total", PromCounter, "number of objects processed by the xactions")
+	for _, key := range keys {
+		pw.Sample("ais_xaction_objects_total", float64(sums[key].objs), labels(key)...)
+	}
+	pw.Family("ais_xaction_bytes_total", PromCounter, "number of bytes processed by the xactions")
+	for _, key := range keys {
+		pw.Sample("ais_xaction_bytes_total", float64(sums[key].bytes), labels(key)...)
+	}
+	pw.Family("ais_xaction_running", PromGauge, "number of running xactions")
+	for _, key := range keys {
+		pw.Sample("ais_xaction_running", float64(sums[key].running), labels(key)...)
+	}
+}
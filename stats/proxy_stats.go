@@ -12,9 +12,18 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
 	jsoniter "github.com/json-iterator/go"
 )
 
+// Prunner stats
+const (
+	// metasync in gossip mode (see cmn.MetasyncConf)
+	MetasyncGossipCount     = "msync.gossip.n"      // KindCounter: number of gossip rounds
+	MetasyncGossipMissCount = "msync.gossip.miss.n" // KindCounter: nodes not reached by gossip (and sync-ed directly)
+	MetasyncGossipLatency   = "msync.gossip.ns"     // KindLatency: time for a round to propagate through the cluster
+)
+
 type (
 	Prunner struct {
 		statsRunner
@@ -38,14 +47,18 @@ func (r *Prunner) Run() error                  { return r.runcommon(r) }
 func (r *Prunner) Get(name string) (val int64) { return r.Core.get(name) }
 
 // All stats that proxy currently has are CoreStats which are registered at startup
-func (r *Prunner) Init(p cluster.Node) *atomic.Bool {
+func (r *Prunner) Init(p cluster.Node, mms ...*memsys.MMSA) *atomic.Bool {
 	r.Core = &CoreStats{}
 	r.Core.init(24)
+	r.Core.Tracker.register(MetasyncGossipCount, KindCounter)
+	r.Core.Tracker.register(MetasyncGossipMissCount, KindCounter)
+	r.Core.Tracker.register(MetasyncGossipLatency, KindLatency)
 	r.Core.statsTime = cmn.GCO.Get().Periodic.StatsTime
 	r.ctracker = make(copyTracker, 24)
 	r.Core.initStatsD(p.Snode())
 
 	r.statsRunner.daemon = p
+	r.statsRunner.initMemsys(mms...)
 
 	r.statsRunner.stopCh = make(chan struct{}, 4)
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
@@ -75,6 +88,7 @@ func (r *Prunner) log(uptime time.Duration) {
 		b, _ := jsonCompat.Marshal(r.ctracker)
 		glog.Infoln(string(b))
 	}
+	r.sendMemStats(r.Core.statsdC)
 }
 
 func (r *Prunner) doAdd(nv NamedVal64) {
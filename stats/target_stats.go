@@ -28,6 +28,8 @@ const (
 	// KindCounter - QPS and byte counts (always incremented, never reset)
 	GetColdCount   = "get.cold.n"
 	GetColdSize    = "get.cold.size"
+	GetDirectCount = "get.direct.n"
+	GetDirectSize  = "get.direct.size"
 	LruEvictSize   = "lru.evict.size"
 	LruEvictCount  = "lru.evict.n"
 	VerChangeCount = "vchange.n"
@@ -44,6 +46,16 @@ const (
 	ErrIOCount       = "err.io.n"
 	// special
 	RestartCount = "restart.n"
+	OrphanCount  = "orphan.n"    // workfiles left behind by the previous run and removed at startup
+	OrphanSize   = "orphan.size" // ditto, reclaimed bytes
+	// PUT and append requests rejected by admission control (OOS, swapping)
+	PutRejectCount = "put.reject.n"
+	// cold GETs that had to wait for a slot and those that timed out waiting (see cmn.ColdGetConf)
+	GetColdQueuedCount = "get.cold.queued.n"
+	GetColdRejectCount = "get.cold.reject.n"
+	// background scrubbing (health.Scrubber)
+	ScrubCount = "scrub.n"
+	ScrubSize  = "scrub.size"
 
 	// KindLatency
 	PutLatency      = "put.ns"
@@ -51,6 +63,8 @@ const (
 	GetRedirLatency = "get.redir.ns"
 	PutRedirLatency = "put.redir.ns"
 	DownloadLatency = "dl.ns"
+	// GET with O_DIRECT (compare with `get.ns`)
+	GetDirectLatency = "get.direct.ns"
 
 	// DSort
 	DSortCreationReqCount    = "dsort.creation.req.n"
@@ -74,7 +88,11 @@ type (
 		T     cluster.Target `json:"-"`
 		Core  *CoreStats     `json:"core"`
 		MPCap fs.MPCap       `json:"capacity"`
-		lines []string
+		// RebStarted returns the start time of the running rebalance (zero if none)
+		RebStarted func() time.Time `json:"-"`
+		lines      []string
+		alerter    alerter
+		capHist    capHistory
 	}
 	copyRunner struct {
 		Tracker copyTracker `json:"core"`
@@ -90,6 +108,9 @@ func (r *Trunner) Register(name, kind string)  { r.Core.Tracker.register(name, k
 func (r *Trunner) Run() error                  { return r.runcommon(r) }
 func (r *Trunner) Get(name string) (val int64) { return r.Core.get(name) }
 
+// Alerts returns the currently firing alerts (see alerts.go)
+func (r *Trunner) Alerts() []*Alert { return r.alerter.alerts() }
+
 func (r *Trunner) Init(t cluster.Target) *atomic.Bool {
 	r.Core = &CoreStats{}
 	r.Core.init(48) // and register common stats (target's own stats are registered elsewhere via the Register() above)
@@ -102,6 +123,7 @@ func (r *Trunner) Init(t cluster.Target) *atomic.Bool {
 	r.Core.statsTime = config.Periodic.StatsTime
 
 	r.statsRunner.daemon = t
+	r.statsRunner.initMemsys(t.MMSA(), t.SmallMMSA())
 
 	r.statsRunner.stopCh = make(chan struct{}, 4)
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
@@ -121,14 +143,22 @@ func (r *Trunner) InitCapacity() error {
 	if cs.Err != nil {
 		glog.Errorf("%s: %v", r.T.Snode(), cs.Err)
 	}
+	r.capHist.init(cmn.GCO.Get().Confdir)
+	r.capHist.add(cs, time.Now())
 	return nil
 }
 
+// CapForecast returns the capacity usage forecast (see capforecast.go)
+func (r *Trunner) CapForecast() cmn.CapForecast { return r.capHist.forecast(cmn.GCO.Get()) }
+
 func (r *Trunner) RegisterAll() {
 	r.Register(PutLatency, KindLatency)
 	r.Register(AppendLatency, KindLatency)
 	r.Register(GetColdCount, KindCounter)
 	r.Register(GetColdSize, KindCounter)
+	r.Register(GetDirectCount, KindCounter)
+	r.Register(GetDirectSize, KindCounter)
+	r.Register(GetDirectLatency, KindLatency)
 	r.Register(GetThroughput, KindThroughput)
 	r.Register(LruEvictSize, KindCounter)
 	r.Register(LruEvictCount, KindCounter)
@@ -140,6 +170,8 @@ func (r *Trunner) RegisterAll() {
 	// errors
 	r.Register(ErrCksumCount, KindCounter)
 	r.Register(ErrCksumSize, KindCounter)
+	r.Register(ScrubCount, KindCounter)
+	r.Register(ScrubSize, KindCounter)
 	r.Register(ErrMetadataCount, KindCounter)
 	r.Register(ErrIOCount, KindCounter)
 
@@ -151,6 +183,11 @@ func (r *Trunner) RegisterAll() {
 
 	// special
 	r.Register(RestartCount, KindCounter)
+	r.Register(OrphanCount, KindCounter)
+	r.Register(OrphanSize, KindCounter)
+	r.Register(PutRejectCount, KindCounter)
+	r.Register(GetColdQueuedCount, KindCounter)
+	r.Register(GetColdRejectCount, KindCounter)
 
 	// download
 	r.Register(DownloadSize, KindCounter)
@@ -189,11 +226,14 @@ func (r *Trunner) log(uptime time.Duration) {
 	}
 
 	// 2. capacity
-	cs, _, updated := fs.CapPeriodic(r.MPCap)
+	cs, err, updated := fs.CapPeriodic(r.MPCap)
 	if updated {
 		if cs.Err != nil {
 			go r.T.RunLRU("" /*uuid*/, false)
 		}
+		if err == nil {
+			r.capHist.add(cs, time.Now())
+		}
 		for mpath, fsCapacity := range r.MPCap {
 			b := cmn.MustMarshal(fsCapacity)
 			r.lines = append(r.lines, mpath+": "+string(b))
@@ -203,7 +243,13 @@ func (r *Trunner) log(uptime time.Duration) {
 	// 3. io stats
 	r.lines = fs.LogAppend(r.lines)
 
-	// 4. log
+	// 4. memsys
+	r.sendMemStats(r.Core.statsdC)
+
+	// 5. alerts
+	r.alerter.evaluate(r, cmn.GCO.Get())
+
+	// 6. log
 	for _, ln := range r.lines {
 		glog.Infoln(ln)
 	}
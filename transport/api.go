@@ -22,6 +22,7 @@ type (
 		workCh   chan streamable // aka SQ: next object to stream
 		cmplCh   chan cmpl       // aka SCQ; note that SQ and SCQ together form a FIFO
 		callback ObjSentCB       // to free SGLs, close files, etc.
+		rpl      *replay         // when enabled (see Extra.Replay): replay buffer and stream re-establishment
 		sendoff  sendoff
 		lz4s     lz4Stream
 		streamBase
@@ -33,6 +34,12 @@ type (
 		Compression string        // see CompressAlways, etc. enum
 		MMSA        *memsys.MMSA  // compression-related buffering
 		Config      *cmn.Config
+		// Replay: max number of sent objects to keep for possible resending; when non-zero, a broken
+		// connection gets re-established, and the objects that the receiver did not get are resent
+		// NOTE: only readers that implement cmn.ReadOpenCloser can be resent; in addition, completed
+		// objects get buffered only if their readers can be reopened after the send completion
+		// callback (header-only, cmn.FileHandle, cmn.ByteHandle) - see replay.go
+		Replay int
 	}
 	// stream stats
 	Stats struct {
@@ -40,16 +47,19 @@ type (
 		Size           atomic.Int64 // transferred object size (does not include transport headers)
 		Offset         atomic.Int64 // stream offset, in bytes
 		CompressedSize atomic.Int64 // compressed size (NOTE: converges to the actual compressed size over time)
+		Reconnects     atomic.Int64 // number of times the stream was re-established after a connection error
+		Retransmits    atomic.Int64 // number of objects resent upon re-establishment (see Extra.Replay)
 	}
 	EndpointStats map[uint64]*Stats // all stats for a given http endpoint defined by a tuple(network, trname) by session ID
 
 	// object attrs
 	ObjectAttrs struct {
-		Atime      int64  // access time - nanoseconds since UNIX epoch
-		Size       int64  // size of objects in bytes
-		CksumType  string // checksum type
-		CksumValue string // checksum of the object produced by given checksum type
-		Version    string // version of the object
+		Atime      int64         // access time - nanoseconds since UNIX epoch
+		Size       int64         // size of objects in bytes
+		CksumType  string        // checksum type
+		CksumValue string        // checksum of the object produced by given checksum type
+		Version    string        // version of the object
+		CustomMD   cmn.SimpleKVs // custom metadata of the object, if any (see SetCustomMD)
 	}
 	// object header
 	ObjHdr struct {
@@ -57,6 +67,7 @@ type (
 		ObjName  string
 		ObjAttrs ObjectAttrs // attributes/metadata of the sent object
 		Opaque   []byte      // custom control (optional)
+		seq      int64       // sequence number (replay-enabled streams only)
 	}
 	// object to transmit
 	Obj struct {
@@ -65,6 +76,7 @@ type (
 		Callback ObjSentCB      // callback fired when sending is done OR when the stream terminates (see term.reason)
 		CmplPtr  unsafe.Pointer // local pointer that gets returned to the caller via Send completion callback
 		prc      *atomic.Int64  // private; if present, ref-counts to call ObjSentCB only once
+		resent   bool           // private; true when resending an object that has been already completed
 	}
 	Msg struct {
 		Flags       int64
@@ -94,6 +106,9 @@ func NewStream(client Client, toURL string, extra *Extra) (s *Stream) {
 		if extra.Compressed() {
 			s.initCompression(extra)
 		}
+		if extra.Replay > 0 {
+			s.rpl = newReplay(extra.Replay)
+		}
 	}
 
 	// burst size: the number of objects the caller is permitted to post for sending
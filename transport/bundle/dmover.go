@@ -39,6 +39,7 @@ type (
 		}
 		mem         *memsys.MMSA
 		compression string // enum { cmn.CompressNever, ... }
+		replay      int    // see transport.Extra.Replay
 		xact        cluster.Xact
 		isOpen      atomic.Bool
 		laterx      atomic.Bool
@@ -49,6 +50,7 @@ type (
 		RecvAck     transport.Receive
 		Compression string
 		Multiplier  int
+		Replay      int // resend in-flight objects upon connection errors (see transport.Extra.Replay)
 	}
 )
 
@@ -69,6 +71,7 @@ func NewDataMover(t cluster.Target, trname string, recvCB transport.Receive, ext
 		return nil, fmt.Errorf("invalid multiplier %d", extra.Multiplier)
 	}
 	dm.multiplier = extra.Multiplier
+	dm.replay = extra.Replay
 	switch extra.Compression {
 	case "":
 		dm.compression = cmn.CompressNever
@@ -125,6 +128,7 @@ func (dm *DataMover) Open() {
 				Compression: dm.compression,
 				Config:      config,
 				MMSA:        dm.mem,
+				Replay:      dm.replay,
 			},
 			Ntype:        cluster.Targets,
 			Multiplier:   dm.multiplier,
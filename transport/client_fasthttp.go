@@ -9,6 +9,7 @@ package transport
 
 import (
 	"crypto/tls"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
@@ -60,6 +61,9 @@ func (s *Stream) do(body io.Reader) (err error) {
 		req.Header.Set(cmn.HeaderCompress, cmn.LZ4Compression)
 	}
 	req.Header.Set(cmn.HeaderSessID, strconv.FormatInt(s.sessID, 10))
+	if s.rpl != nil {
+		req.Header.Set(cmn.HeaderSessUUID, s.rpl.uuid)
+	}
 	// do
 	err = s.client.Do(req, resp)
 	if err != nil {
@@ -74,5 +78,27 @@ func (s *Stream) do(body io.Reader) (err error) {
 		s.lz4s.sgl.Reset()
 		s.lz4s.zw.Reset(nil)
 	}
+	if s.rpl != nil {
+		s.rpl.acked()
+	}
 	return
 }
+
+// get the last sequence number delivered to the receiver (see replay)
+func (s *Stream) getAck() (ack int64, err error) {
+	req, resp := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+	}()
+	req.Header.SetMethod(http.MethodGet)
+	req.SetRequestURI(s.toURL)
+	req.Header.Set(cmn.HeaderSessUUID, s.rpl.uuid)
+	if err = s.client.Do(req, resp); err != nil {
+		return
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("%s: get-ack status %d", s, resp.StatusCode())
+	}
+	return strconv.ParseInt(string(resp.Header.Peek(cmn.HeaderSessSeq)), 10, 64)
+}
@@ -8,6 +8,7 @@
 package transport
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -48,6 +49,9 @@ func (s *Stream) do(body io.Reader) (err error) {
 		request.Header.Set(cmn.HeaderCompress, cmn.LZ4Compression)
 	}
 	request.Header.Set(cmn.HeaderSessID, strconv.FormatInt(s.sessID, 10))
+	if s.rpl != nil {
+		request.Header.Set(cmn.HeaderSessUUID, s.rpl.uuid)
+	}
 
 	// do
 	response, err = s.client.Do(request)
@@ -63,5 +67,29 @@ func (s *Stream) do(body io.Reader) (err error) {
 		s.lz4s.sgl.Reset()
 		s.lz4s.zw.Reset(nil)
 	}
+	if s.rpl != nil {
+		s.rpl.acked()
+	}
 	return
 }
+
+// get the last sequence number delivered to the receiver (see replay)
+func (s *Stream) getAck() (ack int64, err error) {
+	var (
+		request  *http.Request
+		response *http.Response
+	)
+	if request, err = http.NewRequest(http.MethodGet, s.toURL, nil); err != nil {
+		return
+	}
+	request.Header.Set(cmn.HeaderSessUUID, s.rpl.uuid)
+	if response, err = s.client.Do(request); err != nil {
+		return
+	}
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: get-ack status %d", s, response.StatusCode)
+	}
+	return strconv.ParseInt(response.Header.Get(cmn.HeaderSessSeq), 10, 64)
+}
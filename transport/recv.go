@@ -16,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
 	"github.com/NVIDIA/aistore/cmn"
@@ -53,9 +54,14 @@ type (
 		callback    Receive
 		sessions    sync.Map // map[uint64]*Stats
 		oldSessions sync.Map // map[uint64]time.Time
+		acks        sync.Map // map[string]*rxAck - replay-enabled streams by cmn.HeaderSessUUID
 		hkName      string   // house-keeping name
 		mem         *memsys.MMSA
 	}
+	rxAck struct {
+		seq  atomic.Int64 // last delivered (in full) sequence number
+		last atomic.Int64 // last access time (unix nano)
+	}
 	fixedBuffer struct {
 		slab *memsys.Slab
 		buf  []byte
@@ -197,6 +203,10 @@ func GetNetworkStats(network string) (netstats map[string]EndpointStats, err err
 //
 
 func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.getAck(w, r)
+		return
+	}
 	if r.Method != http.MethodPut {
 		cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("Invalid http method %s", r.Method))
 		return
@@ -235,6 +245,10 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 		debug.Infof("%s[%d:%d]: start-of-stream from %s", trname, xxh, sessID, r.RemoteAddr) // r.RemoteAddr => xxh
 	}
 	stats := statsif.(*Stats)
+	var ack *rxAck
+	if uuid := r.Header.Get(cmn.HeaderSessUUID); uuid != "" {
+		ack = h.rxAck(uuid)
+	}
 
 	// Rx loop
 	it := &iterator{trname: trname, body: reader, fbuf: fbuf, headerBuf: make([]byte, maxHeaderSize)}
@@ -248,9 +262,20 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 			if er == io.EOF {
 				er = nil
 			}
-			h.callback(w, objReader.hdr, objReader, er)
 			hdr := &objReader.hdr
+			if ack != nil && hdr.seq != 0 && hdr.seq <= ack.seq.Load() {
+				// duplicate: resent after the stream's re-establishment
+				if err := cmn.DrainReader(objReader); err == nil {
+					continue
+				}
+			} else {
+				h.callback(w, objReader.hdr, objReader, er)
+			}
 			if hdr.ObjAttrs.Size == objReader.off {
+				if ack != nil && hdr.seq != 0 {
+					ack.seq.Store(hdr.seq)
+					ack.last.Store(time.Now().UnixNano())
+				}
 				var (
 					num = stats.Num.Inc()
 					siz = stats.Size.Add(hdr.ObjAttrs.Size)
@@ -296,9 +321,35 @@ func (h *handler) cleanupOldSessions() time.Duration {
 		return true
 	}
 	h.oldSessions.Range(f)
+	g := func(key, value interface{}) bool {
+		ack := value.(*rxAck)
+		if now.Sub(time.Unix(0, ack.last.Load())) > cleanupInterval {
+			h.acks.Delete(key)
+		}
+		return true
+	}
+	h.acks.Range(g)
 	return cleanupInterval
 }
 
+// replay-enabled stream: respond with the last delivered sequence number
+func (h *handler) getAck(w http.ResponseWriter, r *http.Request) {
+	uuid := r.Header.Get(cmn.HeaderSessUUID)
+	if uuid == "" {
+		cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("%s: missing stream UUID", h.trname))
+		return
+	}
+	ack := h.rxAck(uuid)
+	w.Header().Set(cmn.HeaderSessSeq, strconv.FormatInt(ack.seq.Load(), 10))
+}
+
+func (h *handler) rxAck(uuid string) *rxAck {
+	ackif, _ := h.acks.LoadOrStore(uuid, &rxAck{})
+	ack := ackif.(*rxAck)
+	ack.last.Store(time.Now().UnixNano())
+	return ack
+}
+
 //
 // iterator
 //
@@ -442,6 +493,9 @@ func ExtHeader(body []byte, hlen int) (hdr ObjHdr) {
 	off, hdr.Bck.Ns.UUID = extString(off, body)
 	off, hdr.Opaque = extByte(off, body)
 	off, hdr.ObjAttrs = extAttrs(off, body)
+	if off < hlen { // replay-enabled stream
+		off, hdr.seq = extInt64(off, body)
+	}
 	debug.Assertf(off == hlen, "off %d, hlen %d", off, hlen)
 	return
 }
@@ -474,6 +528,16 @@ func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, cnt := extInt64(off, from)
+	if cnt > 0 {
+		attr.CustomMD = make(cmn.SimpleKVs, cnt)
+		for i := int64(0); i < cnt; i++ {
+			var k, v string
+			off, k = extString(off, from)
+			off, v = extString(off, from)
+			attr.CustomMD[k] = v
+		}
+	}
 	return off, attr
 }
 
@@ -0,0 +1,300 @@
+// Package transport provides streaming object-based transport over http for intra-cluster continuous
+// intra-cluster communications (see README for details and usage example).
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package transport
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Stream re-establishment with in-flight replay (see Extra.Replay)
+//
+// 1. Each object sent via replay-enabled stream carries a sequence number (ObjHdr.seq).
+// 2. Receiving side keeps track of the last delivered (in full) sequence number per sender's stream
+//    (identified by cmn.HeaderSessUUID) and drops duplicates.
+// 3. Sending side keeps a bounded buffer of the most recently sent objects. The buffer is
+//    trimmed upon each successful completion of the HTTP request (at which point the receiver
+//    has consumed the entire request body) and otherwise when it reaches its configured capacity.
+// 4. Upon connection error, the sender queries the receiver for the last delivered sequence number
+//    (the acknowledgment), re-opens the readers of the buffered objects that follow, and
+//    resends them - followed by the interrupted object (if any) - via a new HTTP request.
+// 5. The resend must pick up exactly where the receiver left off: when any of the objects that follow
+//    the acknowledgment can no longer be resent (evicted from the full buffer, not reopenable, failed
+//    to reopen), the stream terminates with error that all pending objects get completed with.
+
+const (
+	maxReconnects  = 3
+	reconnectSleep = time.Second
+)
+
+type (
+	replay struct {
+		mu      sync.Mutex
+		uuid    string     // identifies the stream across reconnects (compare with sessID)
+		buf     []rplEntry // sent and completed objects (FIFO)
+		pending []rplEntry // to resend next
+		cur     *rplEntry  // being resent
+		size    int        // max buffered
+		seq     int64      // last assigned sequence number
+		retries int        // consecutive reconnect attempts
+		err     error      // non-recoverable: cannot resend
+	}
+	rplEntry struct {
+		obj Obj
+		roc cmn.ReadOpenCloser // to reopen when resending (nil when header-only)
+	}
+)
+
+func newReplay(size int) *replay {
+	return &replay{uuid: cmn.RandString(16), size: size, buf: make([]rplEntry, 0, size)}
+}
+
+func (rpl *replay) nextSeq() int64 {
+	rpl.mu.Lock()
+	rpl.seq++
+	seq := rpl.seq
+	rpl.mu.Unlock()
+	return seq
+}
+
+// upon successful (object) send
+func (rpl *replay) sent(obj *Obj) {
+	rpl.mu.Lock()
+	rpl.retries = 0
+	if !obj.resent {
+		if roc, ok := rpl.replayable(obj); ok && reopenable(roc) {
+			if len(rpl.buf) >= rpl.size {
+				copy(rpl.buf, rpl.buf[1:])
+				rpl.buf = rpl.buf[:len(rpl.buf)-1]
+			}
+			e := rplEntry{obj: *obj, roc: roc}
+			e.obj.Reader, e.obj.resent = nil, true
+			e.obj.Callback, e.obj.CmplPtr, e.obj.prc = nil, nil, nil
+			rpl.buf = append(rpl.buf, e)
+		}
+	}
+	rpl.cur = nil
+	rpl.mu.Unlock()
+}
+
+// upon successful completion of the HTTP request: all sent objects have been received
+func (rpl *replay) acked() {
+	rpl.mu.Lock()
+	rpl.buf = rpl.buf[:0]
+	rpl.retries = 0
+	rpl.mu.Unlock()
+}
+
+// given the last sequence number delivered to the receiver, prepare the resend;
+// `interrupted` is the (not yet completed) object that was being sent when the connection broke
+func (rpl *replay) rewind(ack int64, interrupted *rplEntry, last bool) (cnt int, err error) {
+	rpl.mu.Lock()
+	defer rpl.mu.Unlock()
+	var (
+		pending = make([]rplEntry, 0, len(rpl.buf)+len(rpl.pending)+1)
+		buf     = rpl.buf[:0]
+	)
+	for _, e := range rpl.buf {
+		if e.obj.Hdr.seq > ack {
+			buf = append(buf, e)
+			pending = append(pending, e)
+		}
+	}
+	rpl.buf = buf
+	// not-yet-completed objects from the previous rewind (resent copies are regenerated from the buffer)
+	for _, e := range rpl.pending {
+		if !e.obj.resent {
+			pending = append(pending, e)
+		}
+	}
+	if interrupted != nil {
+		pending = append(pending, *interrupted)
+	}
+	rpl.cur = nil
+	sort.Slice(pending, func(i, j int) bool { return pending[i].obj.Hdr.seq < pending[j].obj.Hdr.seq })
+	rpl.pending = pending
+	next := ack + 1
+	for _, e := range pending {
+		if e.obj.Hdr.seq != next {
+			break
+		}
+		next++
+	}
+	if next <= rpl.seq {
+		rpl.err = fmt.Errorf("cannot resend objects starting from seq %d (ack %d, last sent %d): no longer available",
+			next, ack, rpl.seq)
+		return 0, rpl.err
+	}
+	if last {
+		e := rplEntry{obj: Obj{Hdr: ObjHdr{ObjAttrs: ObjectAttrs{Size: lastMarker}}, resent: true}}
+		pending = append(pending, e)
+	}
+	rpl.pending = pending
+	return len(pending), nil
+}
+
+// next object to resend (if any)
+func (rpl *replay) next(s *Stream) (obj Obj, ok bool, err error) {
+	rpl.mu.Lock()
+	defer rpl.mu.Unlock()
+	if rpl.err != nil {
+		return obj, false, rpl.err
+	}
+	if len(rpl.pending) == 0 {
+		return
+	}
+	e := rpl.pending[0]
+	obj = e.obj
+	if e.roc != nil {
+		r, errOpen := e.roc.Open()
+		if errOpen != nil {
+			// remains pending, to be completed upon termination (see abort)
+			rpl.err = fmt.Errorf("%s: failed to reopen %s for resending: %v", s, &obj, errOpen)
+			return obj, false, rpl.err
+		}
+		obj.Reader = r
+	}
+	rpl.pending = rpl.pending[1:]
+	rpl.cur = &e
+	if !obj.IsLast() {
+		s.stats.Retransmits.Inc()
+	}
+	return obj, true, nil
+}
+
+// upon stream termination: complete the objects that were pending resend
+func (rpl *replay) abort(s *Stream, err error) {
+	rpl.mu.Lock()
+	pending := rpl.pending
+	rpl.pending, rpl.buf, rpl.cur = nil, nil, nil
+	rpl.mu.Unlock()
+	for i := range pending {
+		if !pending[i].obj.IsLast() {
+			s.objDone(&pending[i].obj, err)
+		}
+	}
+}
+
+func (rpl *replay) failed() (err error) {
+	rpl.mu.Lock()
+	err = rpl.err
+	rpl.mu.Unlock()
+	return
+}
+
+// NOTE: when resending, the object's reader is the one returned by Open() of the original
+func (rpl *replay) replayable(obj *Obj) (roc cmn.ReadOpenCloser, ok bool) {
+	if obj.IsHeaderOnly() {
+		return nil, true
+	}
+	if rpl.cur != nil {
+		return rpl.cur.roc, true
+	}
+	roc, ok = obj.Reader.(cmn.ReadOpenCloser)
+	return
+}
+
+// can be reopened after the completion callback (that may, e.g., free SGL)
+func reopenable(roc cmn.ReadOpenCloser) bool {
+	switch roc.(type) {
+	case nil, *cmn.FileHandle, *cmn.ByteHandle:
+		return true
+	default:
+		return false
+	}
+}
+
+//////////////////////////////
+// Stream: re-establishment //
+//////////////////////////////
+
+// returns nil if the stream can continue via new HTTP request, otherwise
+// the error to terminate the stream with
+func (s *Stream) reconnect(err error) error {
+	if s.rpl == nil {
+		return err
+	}
+	for {
+		if errRpl := s.rpl.failed(); errRpl != nil {
+			glog.Errorf("%s: %v (connection error: %v)", s, errRpl, err)
+			return errRpl
+		}
+		s.rpl.mu.Lock()
+		retries := s.rpl.retries
+		s.rpl.retries++
+		s.rpl.mu.Unlock()
+		if retries >= maxReconnects {
+			glog.Errorf("%s: giving up after %d reconnect attempts: %v", s, retries, err)
+			return err
+		}
+		select {
+		case <-time.After(reconnectSleep * time.Duration(retries+1)):
+		case <-s.stopCh.Listen():
+			return err
+		}
+		ack, errAck := s.getAck()
+		if errAck != nil {
+			glog.Errorf("%s: failed to get ack: %v (connection error: %v)", s, errAck, err)
+			continue
+		}
+		var (
+			interrupted *rplEntry
+			last        bool // end-of-stream has been (partially) sent and must be resent as well
+		)
+		select {
+		case <-s.lastCh.Listen():
+			last = true
+		default:
+		}
+		if s.inSend() {
+			if s.sendoff.obj.IsLast() {
+				last = true
+			} else {
+				interrupted = s.interrupted(ack, err)
+			}
+		}
+		cnt, errRpl := s.rpl.rewind(ack, interrupted, last)
+		s.sendoff = sendoff{ins: inEOB}
+		if errRpl != nil {
+			glog.Errorf("%s: %v (connection error: %v)", s, errRpl, err)
+			return errRpl
+		}
+		s.stats.Reconnects.Inc()
+		glog.Warningf("%s: re-established after %v (ack=%d, resending %d)", s, err, ack, cnt)
+		return nil
+	}
+}
+
+// handle the object that was being sent when the connection broke:
+// complete it or return it for resending
+func (s *Stream) interrupted(ack int64, err error) *rplEntry {
+	obj := s.sendoff.obj
+	if obj.resent {
+		s.cmplCh <- cmpl{obj, nil} // (resent copies are regenerated from the buffer)
+		return nil
+	}
+	if obj.Hdr.seq <= ack {
+		s.cmplCh <- cmpl{obj, nil} // delivered in its entirety
+		return nil
+	}
+	s.rpl.mu.Lock()
+	roc, ok := s.rpl.replayable(&obj)
+	s.rpl.mu.Unlock()
+	if !ok {
+		s.cmplCh <- cmpl{obj, err}
+		return nil
+	}
+	if obj.Reader != nil {
+		cmn.Close(obj.Reader)
+		obj.Reader = nil
+	}
+	return &rplEntry{obj: obj, roc: roc}
+}
@@ -25,7 +25,8 @@ import (
 
 // transport defaults
 const (
-	maxHeaderSize  = 1024
+	maxHeaderSize  = 2048
+	maxCustomMD    = 1024 // max serialized size of the custom metadata (see ObjectAttrs.SetCustomMD)
 	lastMarker     = math.MaxInt64
 	tickMarker     = math.MaxInt64 ^ 0xa5a5a5a5
 	tickUnit       = time.Second
@@ -175,6 +176,9 @@ func (s *Stream) sendLoop(dryrun bool) {
 			if dryrun {
 				s.dryrun()
 			} else if err := s.doRequest(); err != nil {
+				if err = s.reconnect(err); err == nil {
+					continue
+				}
 				*s.term.reason = reasonError
 				s.term.err = err
 				break
@@ -205,6 +209,10 @@ func (s *Stream) sendLoop(dryrun bool) {
 			obj := &s.sendoff.obj
 			s.objDone(obj, s.term.err)
 		}
+		// third, objects pending resend (see replay)
+		if s.rpl != nil {
+			s.rpl.abort(s, s.term.err)
+		}
 		// finally, handle pending SQ
 		for streamable := range s.workCh {
 			obj := streamable.obj() // TODO -- FIXME
@@ -228,6 +236,13 @@ func (s *Stream) cmplLoop() {
 // refcount, invoke Sendcallback, and *always* close the reader
 func (s *Stream) objDone(obj *Obj, err error) {
 	var rc int64
+	if obj.resent {
+		// the object has been already completed (and its callback called) - see replay
+		if obj.Reader != nil {
+			cmn.Close(obj.Reader)
+		}
+		return
+	}
 	if obj.prc != nil {
 		rc = obj.prc.Dec()
 		debug.Assert(rc >= 0)
@@ -284,6 +299,18 @@ func (s *Stream) Read(b []byte) (n int, err error) {
 		}
 	}
 repeat:
+	if s.rpl != nil {
+		var (
+			obj Obj
+			ok  bool
+		)
+		if obj, ok, err = s.rpl.next(s); err != nil {
+			return
+		} else if ok {
+			s.sendoff.obj = obj
+			goto hdr
+		}
+	}
 	select {
 	case streamable, ok := <-s.workCh: // next object OR idle tick
 		if !ok {
@@ -298,16 +325,21 @@ repeat:
 			}
 			return s.deactivate()
 		}
-		l := s.insHeader(s.sendoff.obj.Hdr)
-		s.header = s.maxheader[:l]
-		s.sendoff.ins = inHdr
-		return s.sendHdr(b)
+		if s.rpl != nil && !s.sendoff.obj.IsLast() {
+			s.sendoff.obj.Hdr.seq = s.rpl.nextSeq()
+		}
+		goto hdr
 	case <-s.stopCh.Listen():
 		num := s.stats.Num.Load()
 		glog.Infof("%s: stopped (%d/%d)", s, s.Numcur, num)
 		err = io.EOF
 		return
 	}
+hdr:
+	l := s.insHeader(s.sendoff.obj.Hdr)
+	s.header = s.maxheader[:l]
+	s.sendoff.ins = inHdr
+	return s.sendHdr(b)
 }
 
 func (s *Stream) sendHdr(b []byte) (n int, err error) {
@@ -373,6 +405,9 @@ func (s *Stream) eoObj(err error) {
 	s.stats.Size.Add(size)
 	s.Numcur++
 	s.stats.Num.Inc()
+	if s.rpl != nil {
+		s.rpl.sent(obj)
+	}
 	if glog.FastV(4, glog.SmoduleTransport) {
 		glog.Infof("%s: sent %s (%d/%d)", s, obj, s.Numcur, s.stats.Num.Load())
 	}
@@ -451,6 +486,9 @@ func (s *Stream) insHeader(hdr ObjHdr) (l int) {
 	l = insString(l, s.maxheader, hdr.Bck.Ns.UUID)
 	l = insByte(l, s.maxheader, hdr.Opaque)
 	l = insAttrs(l, s.maxheader, hdr.ObjAttrs)
+	if s.rpl != nil {
+		l = insInt64(l, s.maxheader, hdr.seq)
+	}
 	hlen := l - cmn.SizeofI64*2
 	insInt64(0, s.maxheader, int64(hlen))
 	checksum := xoshiro256.Hash(uint64(hlen))
@@ -486,9 +524,28 @@ func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insInt64(off, to, int64(len(attr.CustomMD)))
+	for k, v := range attr.CustomMD {
+		off = insString(off, to, k)
+		off = insString(off, to, v)
+	}
 	return off
 }
 
+// SetCustomMD sets custom metadata to be sent along with the object
+// unless it doesn't fit - returns false in the latter case.
+func (attr *ObjectAttrs) SetCustomMD(md cmn.SimpleKVs) bool {
+	size := 0
+	for k, v := range md {
+		size += 2*cmn.SizeofI64 + len(k) + len(v)
+	}
+	if size > maxCustomMD {
+		return false
+	}
+	attr.CustomMD = md
+	return true
+}
+
 /////////////
 // dry-run //
 /////////////
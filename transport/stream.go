@@ -110,6 +110,8 @@ func (s *streamBase) GetStats() (stats Stats) {
 	stats.Offset.Store(s.stats.Offset.Load())
 	stats.Size.Store(s.stats.Size.Load())
 	stats.CompressedSize.Store(s.stats.CompressedSize.Load())
+	stats.Reconnects.Store(s.stats.Reconnects.Load())
+	stats.Retransmits.Store(s.stats.Retransmits.Load())
 	return
 }
 
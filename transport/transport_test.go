@@ -21,6 +21,7 @@ package transport_test
 //
 
 import (
+	"bytes"
 	"encoding/binary"
 	"flag"
 	"fmt"
@@ -34,6 +35,7 @@ import (
 	"path"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -121,8 +123,20 @@ func Example_headers() {
 	stream.Fin()
 
 	// Output:
-	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2} Opaque:[]} (119)
-	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2} Opaque:[49 50 51]} (121)
+	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2 CustomMD:map[]} Opaque:[] seq:0} (127)
+	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2 CustomMD:map[source:web]} Opaque:[49 50 51] seq:0} (154)
+}
+
+func Test_SetCustomMD(t *testing.T) {
+	var attrs transport.ObjectAttrs
+	md := cmn.SimpleKVs{"pinned": "true"}
+	if !attrs.SetCustomMD(md) || attrs.CustomMD["pinned"] != "true" {
+		t.Fatalf("expected custom metadata %v to be set, got %v", md, attrs.CustomMD)
+	}
+	large := cmn.SimpleKVs{"large": strings.Repeat("x", 2048)}
+	if attrs.SetCustomMD(large) || attrs.CustomMD["pinned"] != "true" {
+		t.Fatalf("expected large custom metadata not to be set, got %d key(s)", len(attrs.CustomMD))
+	}
 }
 
 func sendText(stream *transport.Stream, txt1, txt2 string) {
@@ -167,6 +181,7 @@ func sendText(stream *transport.Stream, txt1, txt2 string) {
 			CksumType:  cmn.ChecksumXXHash,
 			CksumValue: "hash",
 			Version:    "2",
+			CustomMD:   cmn.SimpleKVs{"source": "web"},
 		},
 		Opaque: []byte{'1', '2', '3'},
 	}
@@ -454,6 +469,139 @@ func Test_ObjAttrs(t *testing.T) {
 	}
 }
 
+// break the connection mid-stream and make sure that each object gets delivered exactly once
+func Test_Replay(t *testing.T) {
+	const (
+		objCnt   = 100
+		objSize  = 32 * cmn.KiB
+		breakOff = 20*objSize + objSize/3
+	)
+	var (
+		mux      = mux.NewServeMux()
+		received = make(map[string]int, objCnt)
+		mu       sync.Mutex
+		broken   atomic.Bool
+	)
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && broken.CAS(false, true) {
+			r.Body = &breakingReader{ReadCloser: r.Body, w: w, rem: breakOff}
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	recvFunc := func(w http.ResponseWriter, hdr transport.ObjHdr, objReader io.Reader, err error) {
+		if err != nil {
+			return
+		}
+		data, err := ioutil.ReadAll(objReader)
+		if err != nil || int64(len(data)) != hdr.ObjAttrs.Size {
+			return // broken connection
+		}
+		cmn.Assert(data[0] == hdr.Opaque[0])
+		mu.Lock()
+		received[hdr.ObjName]++
+		mu.Unlock()
+	}
+	path, err := transport.Register("n1", "replay", recvFunc)
+	tassert.CheckFatal(t, err)
+
+	var (
+		sent   atomic.Int64
+		stream = transport.NewStream(transport.NewIntraDataClient(), ts.URL+path, &transport.Extra{Replay: objCnt})
+		cb     = func(_ transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+			if err == nil {
+				sent.Inc()
+			}
+		}
+	)
+	for i := 0; i < objCnt; i++ {
+		hdr := transport.ObjHdr{
+			Bck:      cmn.Bck{Name: "replay", Provider: cmn.ProviderAIS},
+			ObjName:  fmt.Sprintf("obj-%d", i),
+			ObjAttrs: transport.ObjectAttrs{Size: objSize},
+			Opaque:   []byte{byte(i)},
+		}
+		data := bytes.Repeat([]byte{byte(i)}, objSize)
+		err := stream.Send(&transport.Obj{Hdr: hdr, Reader: cmn.NewByteHandle(data), Callback: cb})
+		tassert.CheckFatal(t, err)
+	}
+	stream.Fin()
+
+	stats := stream.GetStats()
+	tassert.Errorf(t, stats.Reconnects.Load() == 1, "expected 1 reconnect, got %d", stats.Reconnects.Load())
+	tassert.Errorf(t, sent.Load() == objCnt, "expected %d completions, got %d", objCnt, sent.Load())
+	tassert.Errorf(t, len(received) == objCnt, "expected %d objects, received %d", objCnt, len(received))
+	for name, cnt := range received {
+		tassert.Errorf(t, cnt == 1, "%s received %d times", name, cnt)
+	}
+	tutils.Logf("retransmitted %d objects\n", stats.Retransmits.Load())
+}
+
+// break the connection mid-stream when the objects cannot be resent (not reopenable)
+// and make sure that the stream terminates and each object gets completed exactly once
+func Test_ReplayGap(t *testing.T) {
+	const (
+		objCnt   = 100
+		objSize  = 32 * cmn.KiB
+		breakOff = 20*objSize + objSize/3
+	)
+	var (
+		mux    = mux.NewServeMux()
+		broken atomic.Bool
+	)
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && broken.CAS(false, true) {
+			r.Body = &breakingReader{ReadCloser: r.Body, w: w, rem: breakOff}
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	recvFunc := func(w http.ResponseWriter, hdr transport.ObjHdr, objReader io.Reader, err error) {
+		if err == nil {
+			cmn.DrainReader(objReader)
+		}
+	}
+	path, err := transport.Register("n1", "replay-gap", recvFunc)
+	tassert.CheckFatal(t, err)
+
+	var (
+		completed, failed, rejected atomic.Int64
+		stream                      = transport.NewStream(transport.NewIntraDataClient(), ts.URL+path, &transport.Extra{Replay: objCnt})
+		cb                          = func(_ transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+			completed.Inc()
+			if err != nil {
+				failed.Inc()
+			}
+		}
+	)
+	for i := 0; i < objCnt; i++ {
+		hdr := transport.ObjHdr{
+			Bck:      cmn.Bck{Name: "replay", Provider: cmn.ProviderAIS},
+			ObjName:  fmt.Sprintf("obj-%d", i),
+			ObjAttrs: transport.ObjectAttrs{Size: objSize},
+		}
+		reader := ioutil.NopCloser(bytes.NewReader(make([]byte, objSize)))
+		if err := stream.Send(&transport.Obj{Hdr: hdr, Reader: reader, Callback: cb}); err != nil {
+			rejected.Inc()
+		}
+	}
+	stream.Fin()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for completed.Load()+rejected.Load() < objCnt && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	tassert.Errorf(t, completed.Load()+rejected.Load() == objCnt, "expected %d completions, got %d (rejected %d)",
+		objCnt, completed.Load(), rejected.Load())
+	tassert.Errorf(t, failed.Load()+rejected.Load() > 0, "expected failed completions")
+	stats := stream.GetStats()
+	tassert.Errorf(t, stats.Reconnects.Load() == 0, "expected no reconnects, got %d", stats.Reconnects.Load())
+}
+
 //
 // test helpers
 //
@@ -668,3 +816,25 @@ func (rrc *randReaderCtx) sentCallback(hdr transport.ObjHdr, reader io.ReadClose
 	rrc.posted[rrc.idx] = nil
 	rrc.mu.Unlock()
 }
+
+// closes the underlying connection after reading a given number of bytes
+type breakingReader struct {
+	io.ReadCloser
+	w   http.ResponseWriter
+	rem int64
+}
+
+func (r *breakingReader) Read(p []byte) (n int, err error) {
+	if r.rem <= 0 {
+		if conn, _, err := r.w.(http.Hijacker).Hijack(); err == nil {
+			conn.Close()
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+	if int64(len(p)) > r.rem {
+		p = p[:r.rem]
+	}
+	n, err = r.ReadCloser.Read(p)
+	r.rem -= int64(n)
+	return
+}
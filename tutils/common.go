@@ -31,6 +31,7 @@ type SkipTestArgs struct {
 	Long           bool
 	Cloud          bool
 	K8s            bool
+	Local          bool // cluster must be deployed on the same host (e.g., to reach a local mock server)
 }
 
 func prependTime(msg string) string {
@@ -129,6 +130,11 @@ func CheckSkip(tb testing.TB, args SkipTestArgs) {
 			tb.Skipf("%s requires Kubernetes", tb.Name())
 		}
 	}
+	if args.Local {
+		if err := k8s.Detect(); err == nil {
+			tb.Skipf("%s requires local deployment", tb.Name())
+		}
+	}
 }
 
 func IsCloudBucket(tb testing.TB, proxyURL string, bck cmn.Bck) bool {
@@ -0,0 +1,205 @@
+// Package tdownload provides a local (mock) HTTP source of objects to download -
+// with configurable latency, throttling, range support, and injected failures.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tdownload
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server serves registered objects at `URL(name)`. It supports HEAD and range
+// (`Range` header) requests, and reports `Content-Length` and `Last-Modified`
+// the way most of the external sources do. All requests are counted per object.
+//
+// Faults are injected per object, in the order the requests arrive:
+//   * FailFirst(name, n, code) - the first n requests fail with a given status;
+//   * AbortAfter(name, n, size) - the first n requests are cut off (connection
+//     closed) after sending `size` bytes of the body.
+// Latency and throttling apply to all objects.
+//
+// NOTE: the server listens on the loopback interface - targets must run on the same host.
+type (
+	Server struct {
+		srv *httptest.Server
+		mu  sync.Mutex
+		// all guarded by `mu`
+		objs     map[string]*object
+		latency  time.Duration
+		bps      int64 // bytes per second (0 - unlimited)
+		modified time.Time
+	}
+	object struct {
+		data     []byte
+		requests int
+		failN    int   // number of requests to fail ...
+		failCode int   // ... with this status
+		abortN   int   // number of requests to abort ...
+		abortAt  int64 // ... after sending this many bytes
+	}
+)
+
+func NewServer() *Server {
+	s := &Server{objs: make(map[string]*object), modified: time.Now()}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handler))
+	return s
+}
+
+func (s *Server) Close() { s.srv.Close() }
+
+// URL returns the link to the object (registered or not).
+func (s *Server) URL(name string) string { return s.srv.URL + "/" + name }
+
+// AddObject registers object with random content of a given size and returns the content.
+func (s *Server) AddObject(name string, size int64) []byte {
+	data := make([]byte, size)
+	rand.Read(data)
+	s.AddContent(name, data)
+	return data
+}
+
+func (s *Server) AddContent(name string, data []byte) {
+	s.mu.Lock()
+	s.objs[name] = &object{data: data}
+	s.mu.Unlock()
+}
+
+// SetLatency delays each response by a given duration.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	s.latency = latency
+	s.mu.Unlock()
+}
+
+// SetThrottle limits the rate at which each response body is sent.
+func (s *Server) SetThrottle(bytesPerSec int64) {
+	s.mu.Lock()
+	s.bps = bytesPerSec
+	s.mu.Unlock()
+}
+
+func (s *Server) FailFirst(name string, n, code int) {
+	s.mu.Lock()
+	if obj, ok := s.objs[name]; ok {
+		obj.failN, obj.failCode = n, code
+	}
+	s.mu.Unlock()
+}
+
+func (s *Server) AbortAfter(name string, n int, size int64) {
+	s.mu.Lock()
+	if obj, ok := s.objs[name]; ok {
+		obj.abortN, obj.abortAt = n, size
+	}
+	s.mu.Unlock()
+}
+
+// Requests returns the number of requests (including HEAD and failed ones) for the object.
+func (s *Server) Requests(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if obj, ok := s.objs[name]; ok {
+		return obj.requests
+	}
+	return 0
+}
+
+func (s *Server) handler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	s.mu.Lock()
+	var (
+		obj, ok  = s.objs[name]
+		latency  = s.latency
+		bps      = s.bps
+		modified = s.modified
+		failCode int
+		abortAt  = int64(-1)
+	)
+	if ok {
+		obj.requests++
+		if obj.failN > 0 {
+			obj.failN--
+			failCode = obj.failCode
+		} else if obj.abortN > 0 && r.Method == http.MethodGet {
+			obj.abortN--
+			abortAt = obj.abortAt
+		}
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if failCode != 0 {
+		http.Error(w, "injected failure", failCode)
+		return
+	}
+	if abortAt >= 0 || bps > 0 {
+		w = &faultyWriter{ResponseWriter: w, bps: bps, left: abortAt, ctx: r.Context().Done()}
+	}
+	http.ServeContent(w, r, name, modified, bytes.NewReader(obj.data))
+}
+
+// faultyWriter throttles and/or aborts the response
+type faultyWriter struct {
+	http.ResponseWriter
+	ctx  <-chan struct{}
+	bps  int64
+	left int64 // bytes to send before aborting (negative - never)
+}
+
+func (fw *faultyWriter) Write(b []byte) (n int, err error) {
+	if fw.bps == 0 {
+		return fw.write(b)
+	}
+	chunk := int(fw.bps/10) + 1 // ~10 writes per second
+	for len(b) > 0 {
+		m := chunk
+		if m > len(b) {
+			m = len(b)
+		}
+		k, err := fw.write(b[:m])
+		n += k
+		if err != nil {
+			return n, err
+		}
+		b = b[m:]
+		select {
+		case <-time.After(time.Duration(int64(m) * int64(time.Second) / fw.bps)):
+		case <-fw.ctx:
+			return n, io.ErrClosedPipe
+		}
+	}
+	return n, nil
+}
+
+func (fw *faultyWriter) write(b []byte) (int, error) {
+	if fw.left >= 0 && int64(len(b)) > fw.left {
+		if fw.left > 0 {
+			fw.ResponseWriter.Write(b[:fw.left])
+			fw.ResponseWriter.(http.Flusher).Flush()
+		}
+		panic(http.ErrAbortHandler) // closes connection without completing the response
+	}
+	n, err := fw.ResponseWriter.Write(b)
+	if fw.left >= 0 {
+		fw.left -= int64(n)
+	}
+	return n, err
+}
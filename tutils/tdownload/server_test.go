@@ -0,0 +1,69 @@
+// Package tdownload provides a local (mock) HTTP source of objects to download -
+// with configurable latency, throttling, range support, and injected failures.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tdownload
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	data := s.AddObject("obj", 1000)
+	s.FailFirst("obj", 2, http.StatusServiceUnavailable)
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(s.URL("obj"))
+		tassert.CheckFatal(t, err)
+		resp.Body.Close()
+		tassert.Errorf(t, resp.StatusCode == http.StatusServiceUnavailable, "expected failure, got %s", resp.Status)
+	}
+
+	// range
+	req, err := http.NewRequest(http.MethodGet, s.URL("obj"), nil)
+	tassert.CheckFatal(t, err)
+	req.Header.Set("Range", "bytes=100-199")
+	resp, err := http.DefaultClient.Do(req)
+	tassert.CheckFatal(t, err)
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resp.StatusCode == http.StatusPartialContent, "expected partial content, got %s", resp.Status)
+	tassert.Errorf(t, bytes.Equal(b, data[100:200]), "range content mismatch")
+
+	// abort
+	s.AbortAfter("obj", 1, 500)
+	resp, err = http.Get(s.URL("obj"))
+	tassert.CheckFatal(t, err)
+	b, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	tassert.Errorf(t, err != nil && len(b) == 500, "expected aborted read of 500 bytes, got %d (err: %v)", len(b), err)
+
+	// throttle
+	s.SetThrottle(2000)
+	started := time.Now()
+	resp, err = http.Get(s.URL("obj"))
+	tassert.CheckFatal(t, err)
+	b, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, data), "content mismatch")
+	tassert.Errorf(t, time.Since(started) >= 400*time.Millisecond, "expected throttled response, took %v", time.Since(started))
+
+	tassert.Errorf(t, s.Requests("obj") == 5, "expected 5 requests, got %d", s.Requests("obj"))
+
+	resp, err = http.Get(s.URL("nonexistent"))
+	tassert.CheckFatal(t, err)
+	resp.Body.Close()
+	tassert.Errorf(t, resp.StatusCode == http.StatusNotFound, "expected not found, got %s", resp.Status)
+}
@@ -27,12 +27,71 @@ type (
 	}
 
 	XactReqMsg struct {
-		ID          string    `json:"id"`
-		Kind        string    `json:"kind"`
-		Bck         cmn.Bck   `json:"bck"`
-		OnlyRunning *bool     `json:"show_active"`
-		Force       *bool     `json:"force"`             // true: force LRU
-		Buckets     []cmn.Bck `json:"buckets,omitempty"` // list of buckets on which LRU should run
+		ID          string       `json:"id"`
+		Kind        string       `json:"kind"`
+		Bck         cmn.Bck      `json:"bck"`
+		OnlyRunning *bool        `json:"show_active"`
+		Force       *bool        `json:"force"`              // true: force LRU
+		Buckets     []cmn.Bck    `json:"buckets,omitempty"`  // list of buckets on which LRU should run
+		Limits      *JobLimits   `json:"limits,omitempty"`   // new limits (cmn.ActXactLimits)
+		Evacuate    *EvacuateMsg `json:"evacuate,omitempty"` // cmn.ActEvacuate
+		Backup      *BackupMsg   `json:"backup,omitempty"`   // cmn.ActBackupBck, cmn.ActRestoreBck
+	}
+
+	// BackupMsg specifies the Cloud location of the backup of ais bucket `XactReqMsg.Bck`:
+	//   * object `obj` is stored as `<prefix>/<backup-ID>/obj`;
+	//   * the bucket's metadata (see BackupMeta) of the last complete backup - as `<prefix>/.backup/bucket.json`;
+	//   * the objects' manifests (one per target) - as `<prefix>/.backup/<backup-ID>/<target-ID>.manifest`.
+	// Restore recreates the bucket (that must not exist) with the same props
+	// and verifies each restored object against the manifest.
+	BackupMsg struct {
+		Cloud  cmn.Bck `json:"cloud"`
+		Prefix string  `json:"prefix,omitempty"`
+	}
+	// BackupMeta is stored (by the primary) once all targets have successfully backed up the bucket
+	BackupMeta struct {
+		ID      string           `json:"id"` // backup xaction ID
+		Bck     cmn.Bck          `json:"bck"`
+		Props   *cmn.BucketProps `json:"props"`
+		Targets []string         `json:"targets"` // IDs of the targets that have stored their manifests
+		Time    time.Time        `json:"time"`
+	}
+	// BackupEntry is a single line (JSON) of the manifest
+	BackupEntry struct {
+		Name       string `json:"name"`
+		Size       int64  `json:"size,string"`
+		Version    string `json:"version,omitempty"`
+		CksumType  string `json:"cksum_type,omitempty"`
+		CksumValue string `json:"cksum_value,omitempty"`
+	}
+
+	// EvacuateMsg configures evacuation of ais buckets (`XactReqMsg.Buckets`,
+	// all ais buckets if empty) to external storage.
+	EvacuateMsg struct {
+		Dst          cmn.Bck `json:"destination"`    // Cloud or remote AIS bucket
+		BytesPerHour int64   `json:"bytes_per_hour"` // cluster-wide upload rate (0 - unlimited)
+		Verify       bool    `json:"verify"`         // HEAD each uploaded object and compare sizes
+	}
+
+	// JobLimits are the limits of a job that can be adjusted while the job is running;
+	// zero means unlimited.
+	JobLimits struct {
+		Connections int `json:"connections"` // download: number of concurrent connections per target
+		// cluster-wide throughput: download; rebalance and evacuate (the latter two
+		// divide it equally between the targets)
+		BytesPerHour int `json:"bytes_per_hour"`
+		// offline ETL: number of objects each target transforms concurrently
+		// (zero - the default, one per mountpath)
+		BatchSize int `json:"batch_size,omitempty"`
+		// download: BytesPerHour is allocated by the proxy (see downloader.DlLimits.Shared)
+		Shared bool           `json:"shared,omitempty"`
+		Shares map[string]int `json:"shares,omitempty"`
+	}
+
+	// Implemented by xactions that support updating their limits at runtime
+	// (rebalance, evacuate, offline ETL); download jobs are updated by the downloader.
+	Limiter interface {
+		SetLimits(limits *JobLimits) error
 	}
 
 	BaseXactStats struct {
@@ -43,7 +102,10 @@ type (
 		EndTimeX    time.Time `json:"end_time"`
 		ObjCountX   int64     `json:"obj_count,string"`
 		BytesCountX int64     `json:"bytes_count,string"`
+		DiskReadX   int64     `json:"disk_read,string"`  // local disk reads (bytes) attributed to the xaction
+		DiskWriteX  int64     `json:"disk_write,string"` // ditto, writes
 		AbortedX    bool      `json:"aborted"`
+		QueuePosX   int       `json:"queue_pos,omitempty"` // waiting to run (heavy xactions only)
 	}
 
 	BaseXactStatsExt struct {
@@ -61,11 +123,16 @@ var (
 // properties of a given xaction kind: `Startable`, `Owned`, etc.
 var XactsDtor = map[string]XactDescriptor{
 	// bucket-less (aka "global") xactions with scope = (target | cluster)
-	cmn.ActLRU:       {Type: XactTypeGlobal, Startable: true},
-	cmn.ActElection:  {Type: XactTypeGlobal, Startable: false},
-	cmn.ActResilver:  {Type: XactTypeGlobal, Startable: true},
-	cmn.ActRebalance: {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false},
-	cmn.ActDownload:  {Type: XactTypeGlobal, Startable: false},
+	cmn.ActLRU:        {Type: XactTypeGlobal, Startable: true},
+	cmn.ActLifecycle:  {Type: XactTypeGlobal, Startable: true},
+	cmn.ActElection:   {Type: XactTypeGlobal, Startable: false},
+	cmn.ActResilver:   {Type: XactTypeGlobal, Startable: true},
+	cmn.ActRebalance:  {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false},
+	cmn.ActDownload:   {Type: XactTypeGlobal, Startable: false},
+	cmn.ActEvacuate:   {Type: XactTypeGlobal, Startable: true},
+	cmn.ActBackupBck:  {Type: XactTypeGlobal, Startable: true},
+	cmn.ActRestoreBck: {Type: XactTypeGlobal, Startable: true},
+	cmn.ActPrefill:    {Type: XactTypeGlobal, Startable: false},
 
 	// xactions that run on a given bucket or buckets
 	cmn.ActECGet:         {Type: XactTypeBck, Startable: false},
@@ -73,18 +140,24 @@ var XactsDtor = map[string]XactDescriptor{
 	cmn.ActECRespond:     {Type: XactTypeBck, Startable: false},
 	cmn.ActMakeNCopies:   {Type: XactTypeBck, Startable: true, Metasync: true, Owned: false},
 	cmn.ActPutCopies:     {Type: XactTypeBck, Startable: false},
+	cmn.ActReplicate:     {Type: XactTypeBck, Startable: false},
 	cmn.ActRenameLB:      {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false},
 	cmn.ActCopyBucket:    {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false},
 	cmn.ActETLBucket:     {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false},
+	cmn.ActExportBck:     {Type: XactTypeBck, Startable: false, Metasync: true, Owned: true},
 	cmn.ActECEncode:      {Type: XactTypeBck, Startable: true, Metasync: true, Owned: false},
 	cmn.ActEvictObjects:  {Type: XactTypeBck, Startable: false},
 	cmn.ActDelete:        {Type: XactTypeBck, Startable: false},
 	cmn.ActLoadLomCache:  {Type: XactTypeBck, Startable: false},
 	cmn.ActPrefetch:      {Type: XactTypeBck, Startable: true},
+	cmn.ActRehash:        {Type: XactTypeBck, Startable: true},
+	cmn.ActMDIndex:       {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false},
 	cmn.ActPromote:       {Type: XactTypeBck, Startable: false},
 	cmn.ActQueryObjects:  {Type: XactTypeBck, Startable: false, Metasync: false, Owned: true},
 	cmn.ActListObjects:   {Type: XactTypeBck, Startable: false, Metasync: false, Owned: true},
 	cmn.ActSummaryBucket: {Type: XactTypeTask, Startable: false, Metasync: false, Owned: true},
+	cmn.ActAnalyzeBucket: {Type: XactTypeTask, Startable: false, Metasync: false, Owned: true},
+	cmn.ActMoveObject:    {Type: XactTypeTask, Startable: false, Metasync: false, Owned: true},
 }
 
 func IsValidXaction(kind string) bool { _, ok := XactsDtor[kind]; return ok }
@@ -105,6 +178,22 @@ func (b *BaseXactStats) Aborted() bool        { return b.AbortedX }
 func (b *BaseXactStats) Running() bool        { return b.EndTimeX.IsZero() }
 func (b *BaseXactStats) Finished() bool       { return !b.EndTimeX.IsZero() }
 
+// DiskThroughput returns average disk read and write throughput (bytes/s)
+// attributed to the xaction over its lifetime (so far)
+func (b *BaseXactStats) DiskThroughput() (rbps, wbps int64) {
+	end := b.EndTimeX
+	if end.IsZero() {
+		end = time.Now()
+	}
+	elapsed := end.Sub(b.StartTimeX)
+	if elapsed <= 0 {
+		return
+	}
+	rbps = int64(float64(b.DiskReadX) / elapsed.Seconds())
+	wbps = int64(float64(b.DiskWriteX) / elapsed.Seconds())
+	return
+}
+
 ////////////////
 // XactReqMsg //
 ////////////////
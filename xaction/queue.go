@@ -0,0 +1,147 @@
+// Package xaction provides core functionality for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xaction
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Heavy xactions traverse (read and, often, write) entire buckets. To prevent
+// automation from overloading the cluster by starting too many of them at once,
+// the `xaction` config limits the number of concurrently running heavy xactions
+// per bucket, per namespace, and per target. Xactions over the limit wait in a
+// queue: whenever a heavy xaction finishes (or the limits change), the first
+// (FIFO) waiting xactions that fit get to run. While waiting, the xaction reports
+// its 1-based position in the queue via `queue_pos` stats.
+
+var HeavyKinds = cmn.StringSet{
+	cmn.ActCopyBucket:  {},
+	cmn.ActETLBucket:   {},
+	cmn.ActECEncode:    {},
+	cmn.ActMakeNCopies: {},
+	cmn.ActRehash:      {},
+}
+
+type (
+	heavyQueue struct {
+		mu      sync.Mutex
+		running []*XactBase
+		waiting []*heavyWaiter
+		regOnce sync.Once
+	}
+	heavyWaiter struct {
+		xact *XactBase
+		ch   chan struct{} // closed upon admission
+	}
+)
+
+var hq = &heavyQueue{}
+
+// Admit blocks until the (heavy) xaction is allowed to run. Returns false if the
+// xaction gets aborted while waiting; otherwise, the caller must call `Release`.
+func (xact *XactBase) Admit() bool {
+	hq.regOnce.Do(func() { cmn.GCO.Reg("xaction-heavy-queue", hq) })
+	w := &heavyWaiter{xact: xact, ch: make(chan struct{})}
+	hq.mu.Lock()
+	hq.waiting = append(hq.waiting, w)
+	hq.schedule(&cmn.GCO.Get().Xaction)
+	hq.mu.Unlock()
+
+	select {
+	case <-w.ch:
+		return true
+	default:
+		glog.Infof("%s: queued (position %d)", xact, xact.queuePos.Load())
+	}
+	select {
+	case <-w.ch:
+		return true
+	case <-xact.ChanAbort():
+		hq.mu.Lock()
+		admitted := hq.remove(w)
+		hq.mu.Unlock()
+		if admitted {
+			xact.Release()
+		}
+		return false
+	}
+}
+
+// Release must be called by the admitted xaction when done.
+func (xact *XactBase) Release() {
+	hq.mu.Lock()
+	for i, x := range hq.running {
+		if x == xact {
+			hq.running = append(hq.running[:i], hq.running[i+1:]...)
+			break
+		}
+	}
+	hq.schedule(&cmn.GCO.Get().Xaction)
+	hq.mu.Unlock()
+}
+
+// ConfigUpdate implements cmn.ConfigListener - the limits may have been raised
+func (q *heavyQueue) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if oldConf.Xaction == newConf.Xaction {
+		return
+	}
+	q.mu.Lock()
+	q.schedule(&newConf.Xaction)
+	q.mu.Unlock()
+}
+
+// under lock: admit the waiters that fit (in order) and update positions of the rest
+func (q *heavyQueue) schedule(conf *cmn.XactionConf) {
+	waiting := q.waiting[:0]
+	for _, w := range q.waiting {
+		if q.fits(w.xact, conf) {
+			q.running = append(q.running, w.xact)
+			w.xact.queuePos.Store(0)
+			close(w.ch)
+			continue
+		}
+		waiting = append(waiting, w)
+		w.xact.queuePos.Store(int32(len(waiting)))
+	}
+	for i := len(waiting); i < len(q.waiting); i++ {
+		q.waiting[i] = nil
+	}
+	q.waiting = waiting
+}
+
+func (q *heavyQueue) fits(xact *XactBase, conf *cmn.XactionConf) bool {
+	if conf.MaxHeavyPerTarget > 0 && len(q.running) >= conf.MaxHeavyPerTarget {
+		return false
+	}
+	var perBck, perNs int
+	for _, x := range q.running {
+		if x.bck.Provider != xact.bck.Provider || x.bck.Ns != xact.bck.Ns {
+			continue
+		}
+		perNs++
+		if x.bck.Name == xact.bck.Name {
+			perBck++
+		}
+	}
+	if conf.MaxHeavyPerNs > 0 && perNs >= conf.MaxHeavyPerNs {
+		return false
+	}
+	return conf.MaxHeavyPerBucket == 0 || perBck < conf.MaxHeavyPerBucket
+}
+
+// under lock: returns true if the waiter has already been admitted
+func (q *heavyQueue) remove(w *heavyWaiter) (admitted bool) {
+	for i, x := range q.waiting {
+		if x == w {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			q.schedule(&cmn.GCO.Get().Xaction)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Package xaction provides core functionality for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func setHeavyLimits(perBck, perNs, perTarget int) {
+	config := cmn.GCO.BeginUpdate()
+	config.Xaction = cmn.XactionConf{MaxHeavyPerBucket: perBck, MaxHeavyPerNs: perNs, MaxHeavyPerTarget: perTarget}
+	cmn.GCO.CommitUpdate(config)
+}
+
+func admitAsync(xact *XactBase) chan bool {
+	ch := make(chan bool, 1)
+	go func() { ch <- xact.Admit() }()
+	return ch
+}
+
+func expectAdmitted(t *testing.T, ch chan bool, admitted bool) {
+	select {
+	case ok := <-ch:
+		tassert.Fatalf(t, admitted && ok, "unexpected admission (ok=%t)", ok)
+	case <-time.After(100 * time.Millisecond):
+		tassert.Fatalf(t, !admitted, "expected xaction to be admitted")
+	}
+}
+
+func TestHeavyQueue(t *testing.T) {
+	setHeavyLimits(1, 0, 2)
+	defer setHeavyLimits(0, 0, 0)
+
+	var (
+		bckA = cmn.Bck{Name: "a", Provider: cmn.ProviderAIS}
+		bckB = cmn.Bck{Name: "b", Provider: cmn.ProviderAIS}
+		x1   = NewXactBaseBck("x1", cmn.ActCopyBucket, bckA)
+		x2   = NewXactBaseBck("x2", cmn.ActCopyBucket, bckA)
+		x3   = NewXactBaseBck("x3", cmn.ActECEncode, bckB)
+		x4   = NewXactBaseBck("x4", cmn.ActECEncode, bckB)
+	)
+	tassert.Fatalf(t, x1.Admit(), "first xaction must be admitted")
+
+	// per-bucket limit
+	ch2 := admitAsync(x2)
+	expectAdmitted(t, ch2, false)
+	tassert.Errorf(t, x2.queuePos.Load() == 1, "expected queue position 1, got %d", x2.queuePos.Load())
+
+	// another bucket fits (first-fit)
+	expectAdmitted(t, admitAsync(x3), true)
+
+	// per-target limit
+	ch4 := admitAsync(x4)
+	expectAdmitted(t, ch4, false)
+	tassert.Errorf(t, x4.queuePos.Load() == 2, "expected queue position 2, got %d", x4.queuePos.Load())
+
+	// aborted while waiting
+	x4.Abort()
+	tassert.Fatalf(t, !<-ch4, "aborted xaction must not be admitted")
+	tassert.Errorf(t, x2.queuePos.Load() == 1, "expected queue position 1, got %d", x2.queuePos.Load())
+
+	x1.Release()
+	expectAdmitted(t, ch2, true)
+	tassert.Errorf(t, x2.queuePos.Load() == 0, "expected no queue position, got %d", x2.queuePos.Load())
+
+	// raising the limit applies to the waiting xactions
+	x5 := NewXactBaseBck("x5", cmn.ActMakeNCopies, bckA)
+	ch5 := admitAsync(x5)
+	expectAdmitted(t, ch5, false)
+	setHeavyLimits(0, 0, 0)
+	expectAdmitted(t, ch5, true)
+
+	x2.Release()
+	x3.Release()
+	x5.Release()
+}
@@ -7,6 +7,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -70,6 +71,9 @@ type (
 		RangeMsg *cmn.RangeMsg
 		ListMsg  *cmn.ListMsg
 		Evict    bool
+		// delete only: move objects to trash and keep them there for the
+		// specified duration - to be restored by UUID (see fs.MoveToUndo)
+		UndoWindow time.Duration
 	}
 
 	BckRenameArgs struct {
@@ -120,7 +124,7 @@ func (r *registry) RenewMakeNCopies(t cluster.Target, tag string) {
 	)
 	bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
 		if bck.Props.Mirror.Enabled {
-			xact, err := r.RenewBckMakeNCopies(t, bck, tag, int(bck.Props.Mirror.Copies))
+			xact, err := r.RenewBckMakeNCopies(t, bck, tag, &cmn.MNCMsg{Copies: int(bck.Props.Mirror.Copies)})
 			if err == nil {
 				go xact.Run()
 			}
@@ -131,7 +135,7 @@ func (r *registry) RenewMakeNCopies(t cluster.Target, tag string) {
 	for name, ns := range cfg.Cloud.Providers {
 		bmd.Range(&name, &ns, func(bck *cluster.Bck) bool {
 			if bck.Props.Mirror.Enabled {
-				xact, err := r.RenewBckMakeNCopies(t, bck, tag, int(bck.Props.Mirror.Copies))
+				xact, err := r.RenewBckMakeNCopies(t, bck, tag, &cmn.MNCMsg{Copies: int(bck.Props.Mirror.Copies)})
 				if err == nil {
 					go xact.Run()
 				}
@@ -141,11 +145,12 @@ func (r *registry) RenewMakeNCopies(t cluster.Target, tag string) {
 	}
 }
 
-func (r *registry) RenewBckMakeNCopies(t cluster.Target, bck *cluster.Bck, uuid string, copies int) (cluster.Xact, error) {
+func (r *registry) RenewBckMakeNCopies(t cluster.Target, bck *cluster.Bck, uuid string,
+	msg *cmn.MNCMsg) (cluster.Xact, error) {
 	e := r.bckXacts[cmn.ActMakeNCopies].New(XactArgs{
 		T:      t,
 		UUID:   uuid,
-		Custom: copies,
+		Custom: msg,
 	})
 	res := r.renewBucketXaction(e, bck)
 	if res.err != nil {
@@ -157,6 +162,22 @@ func (r *registry) RenewBckMakeNCopies(t cluster.Target, bck *cluster.Bck, uuid
 	return res.entry.Get(), nil
 }
 
+func (r *registry) RenewBckRehash(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	e := r.bckXacts[cmn.ActRehash].New(XactArgs{T: t, UUID: uuid})
+	res := r.renewBucketXaction(e, bck)
+	if res.err != nil {
+		return nil, res.err
+	}
+	if !res.isNew {
+		return nil, fmt.Errorf("%s xaction already running", e.Kind())
+	}
+	return res.entry.Get(), nil
+}
+
+func (r *registry) RenewBckMDIndex(t cluster.Target, bck *cluster.Bck, uuid string) (cluster.Xact, error) {
+	return r.RenewBucketXact(cmn.ActMDIndex, bck, XactArgs{T: t, UUID: uuid})
+}
+
 func (r *registry) RenewDirPromote(t cluster.Target, bck *cluster.Bck, dir string, params *cmn.ActValPromote) (cluster.Xact, error) {
 	return r.RenewBucketXact(cmn.ActPromote, bck, XactArgs{
 		T: t,
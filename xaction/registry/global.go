@@ -5,6 +5,8 @@
 package registry
 
 import (
+	"fmt"
+
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/stats"
@@ -40,6 +42,25 @@ type (
 		ID          xaction.RebID
 		StatsRunner *stats.Trunner
 	}
+
+	EvacuateArgs struct {
+		Bcks    []*cluster.Bck // source ais buckets
+		Dst     *cluster.Bck   // destination Cloud or remote AIS bucket
+		Msg     *xaction.EvacuateMsg
+		Confdir string // where to keep (resumable) progress
+	}
+
+	PrefillArgs struct {
+		// moves the (local) object to its HRW target that is being prefilled
+		Move func(lom *cluster.LOM, tsi *cluster.Snode) (sent bool, err error)
+	}
+
+	BackupArgs struct {
+		Bck     *cluster.Bck // ais bucket to back up (restore)
+		Cloud   *cluster.Bck // Cloud bucket
+		Prefix  string
+		Confdir string // where to keep the manifest while backing up
+	}
 )
 
 func (r *registry) RegisterGlobalXact(entry GlobalEntryProvider) {
@@ -89,6 +110,15 @@ func (r *registry) RenewLRU(id string) cluster.Xact {
 	return res.entry.Get()
 }
 
+func (r *registry) RenewLifecycle(id string) cluster.Xact {
+	e := r.globalXacts[cmn.ActLifecycle].New(XactArgs{UUID: id})
+	res := r.renewGlobalXaction(e)
+	if !res.isNew { // previous run is still in progress
+		return nil
+	}
+	return res.entry.Get()
+}
+
 func (r *registry) RenewDownloader(t cluster.Target, statsT stats.Tracker) (cluster.Xact, error) {
 	e := r.globalXacts[cmn.ActDownload].New(XactArgs{
 		T:      t,
@@ -100,3 +130,39 @@ func (r *registry) RenewDownloader(t cluster.Target, statsT stats.Tracker) (clus
 	}
 	return res.entry.Get(), nil
 }
+
+func (r *registry) RenewEvacuate(t cluster.Target, id string, args *EvacuateArgs) (cluster.Xact, error) {
+	e := r.globalXacts[cmn.ActEvacuate].New(XactArgs{T: t, UUID: id, Custom: args})
+	res := r.renewGlobalXaction(e)
+	if res.err != nil {
+		return nil, res.err
+	}
+	if !res.isNew {
+		return nil, fmt.Errorf("%s is already running", res.entry.Get())
+	}
+	return res.entry.Get(), nil
+}
+
+// RenewPrefill starts prefilling the targets that joined in prefill mode;
+// the one that's already running (if any) gets aborted and replaced.
+func (r *registry) RenewPrefill(t cluster.Target, id string, args *PrefillArgs) (cluster.Xact, error) {
+	e := r.globalXacts[cmn.ActPrefill].New(XactArgs{T: t, UUID: id, Custom: args})
+	res := r.renewGlobalXaction(e)
+	if res.err != nil {
+		return nil, res.err
+	}
+	return res.entry.Get(), nil
+}
+
+// RenewBackup starts backup or restore (`kind`) of a bucket - one at a time.
+func (r *registry) RenewBackup(t cluster.Target, id, kind string, args *BackupArgs) (cluster.Xact, error) {
+	e := r.globalXacts[kind].New(XactArgs{T: t, UUID: id, Custom: args})
+	res := r.renewGlobalXaction(e)
+	if res.err != nil {
+		return nil, res.err
+	}
+	if !res.isNew {
+		return nil, fmt.Errorf("%s is already running", res.entry.Get())
+	}
+	return res.entry.Get(), nil
+}
@@ -7,11 +7,16 @@ package registry
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	gatomic "sync/atomic"
+	"time"
 	"unsafe"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
@@ -276,3 +281,364 @@ func (t *bckSummaryTask) Result() (interface{}, error) {
 	}
 	return ts.Result, ts.Err
 }
+
+//
+// bckAnalysisTask
+//
+
+type (
+	bckAnalysisTask struct {
+		xaction.XactBase
+		t   cluster.Target
+		msg *cmn.BucketAnalysisMsg
+		res atomic.Pointer
+	}
+
+	bckAnalysisTaskEntry struct {
+		xact *bckAnalysisTask
+
+		t    cluster.Target
+		uuid string
+		msg  *cmn.BucketAnalysisMsg
+	}
+)
+
+func (r *registry) RenewBckAnalysis(t cluster.Target, bck *cluster.Bck,
+	msg *cmn.BucketAnalysisMsg) (*bckAnalysisTask, error) {
+	if err := r.removeFinishedByID(msg.UUID); err != nil {
+		return nil, err
+	}
+	e := &bckAnalysisTaskEntry{t: t, uuid: msg.UUID, msg: msg}
+	if err := e.Start(bck.Bck); err != nil {
+		return nil, err
+	}
+	r.storeEntry(e)
+	return e.xact, nil
+}
+
+func (e *bckAnalysisTaskEntry) Start(bck cmn.Bck) error {
+	xact := &bckAnalysisTask{
+		XactBase: *xaction.NewXactBaseBck(e.uuid, cmn.ActAnalyzeBucket, bck),
+		t:        e.t,
+		msg:      e.msg,
+	}
+	e.xact = xact
+	go xact.Run()
+	return nil
+}
+func (e *bckAnalysisTaskEntry) Kind() string      { return cmn.ActAnalyzeBucket }
+func (e *bckAnalysisTaskEntry) Get() cluster.Xact { return e.xact }
+
+func (t *bckAnalysisTask) IsMountpathXact() bool { return true }
+
+// walks all mountpaths in parallel (one analysis per mountpath), merges the results
+func (t *bckAnalysisTask) Run() error {
+	var (
+		availablePaths, _ = fs.Get()
+		started           = time.Now()
+		config            = cmn.GCO.Get()
+		bck               = cluster.NewBckEmbed(t.Bck())
+		results           = make([]*cmn.BucketAnalysis, 0, len(availablePaths))
+		group, _          = errgroup.WithContext(context.Background())
+	)
+	if err := bck.Init(t.t.Bowner(), t.t.Snode()); err != nil {
+		t.UpdateResult(nil, err)
+		return err
+	}
+	for _, mpathInfo := range availablePaths {
+		res := cmn.NewBucketAnalysis(bck.Bck, started)
+		if t.msg.Duplicates {
+			res.Dedup = cmn.NewDedupReport()
+		}
+		results = append(results, res)
+		group.Go(func(mpathInfo *fs.MountpathInfo, res *cmn.BucketAnalysis) func() error {
+			return func() error {
+				opts := &fs.Options{
+					Mpath: mpathInfo,
+					Bck:   bck.Bck,
+					CTs:   []string{fs.ObjectType},
+					Callback: func(fqn string, de fs.DirEntry) error {
+						return t.walk(fqn, de, bck, config, res)
+					},
+				}
+				return fs.Walk(opts)
+			}
+		}(mpathInfo, res))
+	}
+	if err := group.Wait(); err != nil {
+		t.UpdateResult(nil, err)
+		return err
+	}
+	analysis := cmn.NewBucketAnalysis(bck.Bck, started)
+	for _, res := range results {
+		analysis.Merge(res)
+	}
+	t.UpdateResult(analysis, nil)
+	return nil
+}
+
+func (t *bckAnalysisTask) walk(fqn string, de fs.DirEntry, bck *cluster.Bck, config *cmn.Config,
+	res *cmn.BucketAnalysis) error {
+	if t.Aborted() {
+		return cmn.NewAbortedError(t.String())
+	}
+	if de.IsDir() {
+		return nil
+	}
+	lom := &cluster.LOM{T: t.t, FQN: fqn}
+	if err := lom.Init(bck.Bck, config); err != nil {
+		return nil
+	}
+	if !strings.HasPrefix(lom.ObjName, t.msg.Prefix) {
+		return nil
+	}
+	if err := lom.Load(); err != nil || lom.IsCopy() {
+		return nil
+	}
+	res.Add(lom.ObjName, lom.Size(), lom.Atime(), t.msg.Depth)
+	if res.Dedup != nil {
+		res.Dedup.Add(lom.ObjName, lom.Size(), t.contentCksum(lom))
+	}
+	t.ObjectsInc()
+	t.BytesAdd(lom.Size())
+	return nil
+}
+
+// contentCksum returns the stored checksum of the object or, if there's none,
+// computes one (not persisting it as the bucket may be configured without checksums)
+func (t *bckAnalysisTask) contentCksum(lom *cluster.LOM) *cmn.Cksum {
+	if cksum := lom.Cksum(); cksum != nil && cksum.Type() != cmn.ChecksumNone {
+		return cksum
+	}
+	cksumType := lom.CksumConf().Type
+	if cksumType == cmn.ChecksumNone {
+		cksumType = cmn.ChecksumXXHash
+	}
+	lom.Lock(false)
+	cksumHash, err := lom.ComputeCksum(cksumType)
+	lom.Unlock(false)
+	if err != nil {
+		glog.Warningf("%s: failed to compute checksum of %s: %v", t, lom, err)
+		return nil
+	}
+	return cksumHash.Clone()
+}
+
+func (t *bckAnalysisTask) UpdateResult(result interface{}, err error) {
+	res := &taskState{Err: err}
+	if err == nil {
+		res.Result = result
+	}
+	t.res.Store(unsafe.Pointer(res))
+	t.Finish(err)
+}
+
+func (t *bckAnalysisTask) Result() (interface{}, error) {
+	ts := (*taskState)(t.res.Load())
+	if ts == nil {
+		return nil, errors.New("no result to load")
+	}
+	return ts.Result, ts.Err
+}
+
+//
+// objMoveTask
+//
+
+type (
+	// ObjMover is implemented by the target: it writes the source object to
+	// its destination (the HRW target of the latter), reports the size and
+	// checksum of the destination as stored in the cluster, and deletes the
+	// (write-locked by the caller) source.
+	ObjMover interface {
+		CopyObj(lom *cluster.LOM, bckTo *cluster.Bck, objNameTo string, progress func(n int64)) error
+		StatObj(bckTo *cluster.Bck, objNameTo string) (size int64, cksum *cmn.Cksum, err error)
+		DeleteObj(lom *cluster.LOM) error
+	}
+
+	ObjMoveArgs struct {
+		LOM       *cluster.LOM // source
+		BckTo     *cluster.Bck
+		ObjNameTo string
+		Mover     ObjMover
+	}
+
+	objMoveTask struct {
+		xaction.XactBase
+		t    cluster.Target
+		args *ObjMoveArgs
+		res  atomic.Pointer
+	}
+
+	objMoveTaskEntry struct {
+		xact *objMoveTask
+
+		t    cluster.Target
+		uuid string
+		args *ObjMoveArgs
+	}
+)
+
+// RenewObjMove creates (but does not run) the task to move a single object
+// across buckets: the caller is expected to add notifications and run it.
+func (r *registry) RenewObjMove(t cluster.Target, uuid string, args *ObjMoveArgs) (cluster.Xact, error) {
+	if err := r.removeFinishedByID(uuid); err != nil {
+		return nil, err
+	}
+	e := &objMoveTaskEntry{t: t, uuid: uuid, args: args}
+	if err := e.Start(args.LOM.Bck().Bck); err != nil {
+		return nil, err
+	}
+	r.storeEntry(e)
+	return e.xact, nil
+}
+
+func (e *objMoveTaskEntry) Start(bck cmn.Bck) error {
+	e.xact = &objMoveTask{
+		XactBase: *xaction.NewXactBaseBck(e.uuid, cmn.ActMoveObject, bck),
+		t:        e.t,
+		args:     e.args,
+	}
+	return nil
+}
+func (e *objMoveTaskEntry) Kind() string      { return cmn.ActMoveObject }
+func (e *objMoveTaskEntry) Get() cluster.Xact { return e.xact }
+
+func (t *objMoveTask) String() string {
+	return fmt.Sprintf("%s: %s => %s/%s", t.XactBase.String(), t.args.LOM, t.args.BckTo, t.args.ObjNameTo)
+}
+
+// Run copies the object, verifies the copy, and only then deletes the source.
+// Upon any failure the source remains intact (and the destination, if already
+// written, is not removed - it may have existed prior to the move).
+func (t *objMoveTask) Run() error {
+	var (
+		lom  = t.args.LOM
+		size int64
+	)
+	glog.Infof("%s started", t)
+	err := t.loadSource()
+	if err == nil {
+		size = lom.Size()
+		err = t.move(size, lom.Cksum(), lom.Version())
+	}
+	if err != nil {
+		glog.Errorf("%s failed: %v", t, err)
+	} else {
+		t.ObjectsInc()
+		glog.Infof("%s done (%s)", t, cmn.B2S(size, 2))
+	}
+	t.UpdateResult(nil, err)
+	return err
+}
+
+// loads the source, cold-GETs the object first if it's not present in the cluster
+func (t *objMoveTask) loadSource() (err error) {
+	lom := t.args.LOM
+	lom.Lock(false)
+	err = lom.Load(false)
+	lom.Unlock(false)
+	if err == nil || !cmn.IsObjNotExist(err) || !lom.Bck().IsRemote() {
+		return
+	}
+	if err, _ = t.t.GetCold(context.Background(), lom, false); err != nil {
+		return
+	}
+	lom.Unlock(false) // NOTE: cold GET returns read-locked
+	return
+}
+
+func (t *objMoveTask) move(size int64, cksum *cmn.Cksum, version string) error {
+	var (
+		args = t.args
+		lom  = args.LOM
+	)
+	// 1. copy
+	if err := args.Mover.CopyObj(lom, args.BckTo, args.ObjNameTo, func(n int64) { t.BytesAdd(n) }); err != nil {
+		return fmt.Errorf("copy failed: %v", err)
+	}
+	if t.Aborted() {
+		return cmn.NewAbortedError(t.String())
+	}
+	// 2. verify
+	if err := t.verify(size, cksum); err != nil {
+		return fmt.Errorf("verification failed: %v", err)
+	}
+	// 3. the source must not have changed while being copied - checked and
+	// deleted (from the Cloud as well, if applicable) under the same write lock
+	src := &cluster.LOM{T: t.t, ObjName: lom.ObjName}
+	if err := src.Init(lom.Bck().Bck); err != nil {
+		return err
+	}
+	src.Lock(true)
+	defer src.Unlock(true)
+	if err := src.Load(false); err != nil {
+		return err
+	}
+	if src.Size() != size || src.Version() != version || !cksumMatch(src.Cksum(), cksum) {
+		return fmt.Errorf("source %s has changed while being moved", src)
+	}
+	if t.Aborted() {
+		return cmn.NewAbortedError(t.String())
+	}
+	if err := args.Mover.DeleteObj(src); err != nil {
+		return fmt.Errorf("failed to delete source (the object is now duplicated): %v", err)
+	}
+	return nil
+}
+
+func (t *objMoveTask) verify(size int64, cksum *cmn.Cksum) error {
+	args := t.args
+	dstSize, dstCksum, err := args.Mover.StatObj(args.BckTo, args.ObjNameTo)
+	if err != nil {
+		return err
+	}
+	if dstSize != size {
+		return fmt.Errorf("size %d != %d", dstSize, size)
+	}
+	if !cksumMatch(dstCksum, cksum) {
+		return fmt.Errorf("checksum %s != %s", dstCksum, cksum)
+	}
+	if !args.BckTo.IsRemote() {
+		return nil
+	}
+	// remote destination: the object must be there as well
+	dst := &cluster.LOM{T: t.t, ObjName: args.ObjNameTo}
+	if err := dst.Init(args.BckTo.Bck); err != nil {
+		return err
+	}
+	objMeta, err, _ := t.t.Cloud(args.BckTo).HeadObj(context.Background(), dst)
+	if err != nil {
+		return err
+	}
+	if remSize, err := strconv.ParseInt(objMeta[cmn.HeaderObjSize], 10, 64); err == nil && remSize != size {
+		return fmt.Errorf("remote size %d != %d", remSize, size)
+	}
+	return nil
+}
+
+// checksums of different types (or none at all) cannot be compared and are
+// considered matching
+func cksumMatch(a, b *cmn.Cksum) bool {
+	if a == nil || b == nil || a.Type() != b.Type() || a.Type() == cmn.ChecksumNone {
+		return true
+	}
+	return a.Equal(b)
+}
+
+func (t *objMoveTask) UpdateResult(result interface{}, err error) {
+	res := &taskState{Err: err}
+	if err == nil {
+		res.Result = result
+	}
+	t.res.Store(unsafe.Pointer(res))
+	t.Finish(err)
+}
+
+func (t *objMoveTask) Result() (interface{}, error) {
+	ts := (*taskState)(t.res.Load())
+	if ts == nil {
+		return nil, errors.New("no result to load")
+	}
+	return ts.Result, ts.Err
+}
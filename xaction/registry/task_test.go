@@ -0,0 +1,120 @@
+// Package registry provides core functionality for the AIStore extended actions registry.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package registry_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/readers"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+type (
+	objMoverMock struct {
+		copyErr error
+		size    int64 // size of the destination; negative - same as source
+		deleted []string
+	}
+)
+
+func (m *objMoverMock) DeleteObj(lom *cluster.LOM) error {
+	// must be called under the source's write lock
+	if lom.TryLock(false) {
+		lom.Unlock(false)
+		return errors.New("source is not write-locked")
+	}
+	m.deleted = append(m.deleted, lom.ObjName)
+	return nil
+}
+
+func (m *objMoverMock) CopyObj(lom *cluster.LOM, _ *cluster.Bck, _ string, progress func(n int64)) error {
+	if m.copyErr != nil {
+		return m.copyErr
+	}
+	progress(lom.Size())
+	if m.size < 0 {
+		m.size = lom.Size()
+	}
+	return nil
+}
+
+func (m *objMoverMock) StatObj(_ *cluster.Bck, _ string) (int64, *cmn.Cksum, error) {
+	return m.size, nil, nil
+}
+
+func TestObjMove(t *testing.T) {
+	const (
+		objName = "obj"
+		objSize = 4 * cmn.KiB
+	)
+	mpath, err := ioutil.TempDir("", "objmove")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+
+	cluster.InitTarget()
+	fs.Init()
+	fs.DisableFsIDCheck()
+	tassert.CheckFatal(t, fs.Add(mpath))
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	cmn.InitShortID(0)
+
+	var (
+		props = &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}}
+		bck   = cluster.NewBck("src", cmn.ProviderAIS, cmn.NsGlobal, props)
+		bckTo = cluster.NewBck("dst", cmn.ProviderAIS, cmn.NsGlobal, props)
+		bmd   = cluster.NewBaseBownerMock(bck, bckTo)
+		mi    = fs.MountpathInfo{Path: mpath}
+	)
+	bckPath := mi.MakePathCT(bck.Bck, fs.ObjectType)
+	tassert.CheckFatal(t, cmn.CreateDir(bckPath))
+	r, err := readers.NewFileReader(bckPath, objName, objSize, cmn.ChecksumXXHash)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, r.Close())
+
+	tests := []struct {
+		name    string
+		mover   *objMoverMock
+		deleted bool
+	}{
+		{name: "copy-fails", mover: &objMoverMock{copyErr: errors.New("copy failed")}},
+		{name: "verify-fails", mover: &objMoverMock{size: objSize - 1}},
+		{name: "success", mover: &objMoverMock{size: -1}, deleted: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				xactions = registry.NewRegistry()
+				tMock    = &cluster.TargetMock{BO: bmd}
+				lom      = &cluster.LOM{T: tMock, ObjName: objName}
+			)
+			tassert.CheckFatal(t, lom.Init(bck.Bck))
+			lom.SetSize(objSize)
+			tassert.CheckFatal(t, lom.Persist())
+			lom.Uncache()
+
+			args := &registry.ObjMoveArgs{LOM: lom, BckTo: bckTo, ObjNameTo: objName, Mover: test.mover}
+			xact, err := xactions.RenewObjMove(tMock, cmn.GenUUID(), args)
+			tassert.CheckFatal(t, err)
+			err = xact.Run()
+
+			tassert.Errorf(t, xact.Finished(), "expected %s to finish", xact)
+			tassert.Errorf(t, (err == nil) == test.deleted, "unexpected error: %v", err)
+			tassert.Errorf(t, (len(test.mover.deleted) == 1) == test.deleted,
+				"source deleted: %v, expected: %v", test.mover.deleted, test.deleted)
+			if test.mover.copyErr == nil {
+				tassert.Errorf(t, xact.Stats().BytesCount() == objSize, "expected %d bytes, got %d",
+					objSize, xact.Stats().BytesCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,531 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Backup uploads all objects of an ais bucket to Cloud (see xaction.BackupMsg for
+// the layout): each target uploads the objects it stores, followed by the manifest
+// (lines: xaction.BackupEntry) of the uploaded objects. Once all targets have
+// succeeded, the primary has the bucket's metadata (xaction.BackupMeta) stored -
+// a backup with any failed uploads is incomplete and does not replace the
+// previous (complete) one, the objects of which remain under their own backup ID.
+//
+// Restore reads the metadata and all the manifests, and downloads the objects
+// that (according to the current cluster map) belong to a given target. Each
+// object is verified against its manifest entry (size and checksum) before it
+// is committed, with its version preserved. The restored bucket itself is
+// created by the primary (with the backed-up props) prior to starting Restore.
+
+const (
+	BackupDir         = "backup"  // local manifests, relative to config.Confdir
+	BackupMetaDir     = ".backup" // uploaded metadata, relative to the backup prefix
+	BackupMetaObjName = "bucket.json"
+	backupManifestExt = ".manifest"
+)
+
+type (
+	backupProvider struct {
+		xact *Backup
+		t    cluster.Target
+		id   string
+		args *registry.BackupArgs
+	}
+	restoreProvider struct {
+		xact *Restore
+		t    cluster.Target
+		id   string
+		args *registry.BackupArgs
+	}
+	Backup struct {
+		xaction.XactBase
+		t      cluster.Target
+		args   *registry.BackupArgs
+		failed atomic.Int64
+		mu     sync.Mutex
+		file   *os.File // manifest (guarded by `mu`)
+	}
+	Restore struct {
+		xaction.XactBase
+		t      cluster.Target
+		args   *registry.BackupArgs
+		meta   *xaction.BackupMeta
+		failed atomic.Int64
+	}
+	// verifies size and checksum of the restored object: fails the last read
+	// (that would otherwise return io.EOF) upon mismatch
+	backupReader struct {
+		r     io.ReadCloser
+		entry *xaction.BackupEntry
+		cksum *cmn.CksumHash
+		size  int64
+	}
+)
+
+func init() {
+	registry.Registry.RegisterGlobalXact(&backupProvider{})
+	registry.Registry.RegisterGlobalXact(&restoreProvider{})
+}
+
+// BackupObjName returns the Cloud name of a backed-up object (or metadata).
+func BackupObjName(prefix, objName string) string {
+	if prefix == "" {
+		return objName
+	}
+	return prefix + "/" + objName
+}
+
+// BackupMetaName returns the Cloud name of the bucket's backup metadata.
+func BackupMetaName(prefix string) string {
+	return BackupObjName(prefix, BackupMetaDir+"/"+BackupMetaObjName)
+}
+
+// BackupDataName returns the Cloud name of an object backed up by a given backup.
+func BackupDataName(prefix, id, objName string) string {
+	return BackupObjName(prefix, id+"/"+objName)
+}
+
+func backupManifestName(prefix, id, tid string) string {
+	return BackupObjName(prefix, BackupMetaDir+"/"+id+"/"+tid+backupManifestExt)
+}
+
+//
+// backupProvider & restoreProvider
+//
+
+func (*backupProvider) New(args registry.XactArgs) registry.GlobalEntry {
+	return &backupProvider{t: args.T, id: args.UUID, args: args.Custom.(*registry.BackupArgs)}
+}
+
+func (p *backupProvider) Start(_ cmn.Bck) error {
+	p.xact = &Backup{
+		XactBase: *xaction.NewXactBaseBck(p.id, cmn.ActBackupBck, p.args.Bck.Bck),
+		t:        p.t,
+		args:     p.args,
+	}
+	return nil
+}
+func (*backupProvider) Kind() string                               { return cmn.ActBackupBck }
+func (p *backupProvider) Get() cluster.Xact                        { return p.xact }
+func (p *backupProvider) PreRenewHook(_ registry.GlobalEntry) bool { return true } // one at a time
+func (p *backupProvider) PostRenewHook(_ registry.GlobalEntry)     {}
+
+func (*restoreProvider) New(args registry.XactArgs) registry.GlobalEntry {
+	return &restoreProvider{t: args.T, id: args.UUID, args: args.Custom.(*registry.BackupArgs)}
+}
+
+func (p *restoreProvider) Start(_ cmn.Bck) error {
+	p.xact = &Restore{
+		XactBase: *xaction.NewXactBaseBck(p.id, cmn.ActRestoreBck, p.args.Bck.Bck),
+		t:        p.t,
+		args:     p.args,
+	}
+	return nil
+}
+func (*restoreProvider) Kind() string                               { return cmn.ActRestoreBck }
+func (p *restoreProvider) Get() cluster.Xact                        { return p.xact }
+func (p *restoreProvider) PreRenewHook(_ registry.GlobalEntry) bool { return true } // one at a time
+func (p *restoreProvider) PostRenewHook(_ registry.GlobalEntry)     {}
+
+//
+// Backup
+//
+
+func (r *Backup) IsMountpathXact() bool { return true }
+
+func (r *Backup) String() string {
+	return fmt.Sprintf("%s => %s", r.XactBase.String(), backupLocation(r.args))
+}
+
+func (r *Backup) Run() (err error) {
+	glog.Infoln(r.String() + " started")
+	if err = r.run(); err != nil {
+		glog.Error(err)
+	} else {
+		glog.Infof("%s done: %d object(s), %s", r, r.ObjCount(), cmn.B2S(r.BytesCount(), 2))
+	}
+	r.Finish(err)
+	return
+}
+
+func (r *Backup) run() (err error) {
+	var (
+		dir = filepath.Join(r.args.Confdir, BackupDir)
+		fqn = filepath.Join(dir, r.ID().String()+backupManifestExt)
+	)
+	if err = cmn.CreateDir(dir); err != nil {
+		return
+	}
+	if r.file, err = os.OpenFile(fqn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644); err != nil {
+		return
+	}
+	defer os.Remove(fqn)
+	err = r.walk()
+	if errClose := r.file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return
+	}
+	if n := r.failed.Load(); n > 0 {
+		return fmt.Errorf("%s: failed to upload %d object(s)", r, n)
+	}
+	manifestName := backupManifestName(r.args.Prefix, r.ID().String(), r.t.Snode().ID())
+	return r.uploadFile(fqn, manifestName)
+}
+
+// walk all mountpaths in parallel
+func (r *Backup) walk() error {
+	var (
+		wg        = &sync.WaitGroup{}
+		avail, _  = fs.Get()
+		config    = cmn.GCO.Get()
+		mpathErrs = make(chan error, len(avail))
+	)
+	for _, mpathInfo := range avail {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			opts := &fs.Options{
+				Mpath: mi,
+				Bck:   r.args.Bck.Bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					return r.backup(fqn, config)
+				},
+			}
+			if err := fs.Walk(opts); err != nil {
+				mpathErrs <- err
+			}
+		}(mpathInfo)
+	}
+	wg.Wait()
+	close(mpathErrs)
+	err, ok := <-mpathErrs
+	if !ok {
+		return nil
+	}
+	if errors.As(err, &cmn.AbortedError{}) || r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	return err
+}
+
+func (r *Backup) backup(fqn string, config *cmn.Config) error {
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	lom := &cluster.LOM{T: r.t, FQN: fqn}
+	if err := lom.Init(r.args.Bck.Bck, config); err != nil {
+		return nil
+	}
+	// the lock protects the metadata and the open; the upload itself reads
+	// the opened file that stays intact (PUT, APPEND, etc. rename new content
+	// over the object)
+	lom.Lock(false)
+	if err := lom.Load(); err != nil || lom.IsCopy() {
+		lom.Unlock(false)
+		return nil
+	}
+	fh, err := cmn.NewFileHandle(lom.FQN) // closed by `PutObj`
+	if err != nil {
+		lom.Unlock(false)
+		r.failed.Inc()
+		glog.Errorf("%s: failed to open %s: %v", r, lom, err)
+		return nil
+	}
+	var (
+		size  = lom.Size()
+		cksum = lom.Cksum()
+		entry = &xaction.BackupEntry{Name: lom.ObjName, Size: size, Version: lom.Version()}
+	)
+	lom.Unlock(false)
+	if cksum != nil && cksum.Type() != cmn.ChecksumNone {
+		entry.CksumType, entry.CksumValue = cksum.Get()
+	}
+	objName := BackupDataName(r.args.Prefix, r.ID().String(), lom.ObjName)
+	if err := r.upload(fh, size, objName, cksum); err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: failed to upload %s: %v", r, lom, err)
+		return nil
+	}
+	r.IO().ReadAdd(size) // (the file handle is read in its entirety by `PutObj`)
+	line := append(cmn.MustMarshal(entry), '\n')
+	r.mu.Lock()
+	_, err = r.file.Write(line)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	r.ObjectsInc()
+	r.BytesAdd(size)
+	return nil
+}
+
+func (r *Backup) uploadFile(fqn, objName string) error {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return err
+	}
+	fh, err := cmn.NewFileHandle(fqn)
+	if err != nil {
+		return err
+	}
+	return r.upload(fh, finfo.Size(), objName)
+}
+
+func (r *Backup) upload(reader io.Reader, size int64, objName string, cksums ...*cmn.Cksum) error {
+	return BackupUpload(r.t, r.args.Cloud, reader, size, objName, cksums...)
+}
+
+// BackupUpload puts a given reader to the backup location (is also used to store
+// the backup metadata).
+func BackupUpload(t cluster.Target, cloud *cluster.Bck, reader io.Reader, size int64, objName string,
+	cksums ...*cmn.Cksum) error {
+	dstLOM := &cluster.LOM{T: t, ObjName: objName}
+	if err := dstLOM.Init(cloud.Bck); err != nil {
+		if rc, ok := reader.(io.Closer); ok {
+			rc.Close()
+		}
+		return err
+	}
+	cksum := cmn.NewCksum(cmn.ChecksumNone, "")
+	if len(cksums) > 0 && cksums[0] != nil {
+		cksum = cksums[0]
+	}
+	dstLOM.SetCksum(cksum)
+	dstLOM.SetSize(size)
+	_, err, _ := t.Cloud(cloud).PutObj(context.Background(), reader, dstLOM)
+	return err
+}
+
+//
+// Restore
+//
+
+func (r *Restore) IsMountpathXact() bool { return true }
+
+func (r *Restore) String() string {
+	return fmt.Sprintf("%s <= %s", r.XactBase.String(), backupLocation(r.args))
+}
+
+func (r *Restore) Run() (err error) {
+	glog.Infoln(r.String() + " started")
+	if err = r.run(); err != nil {
+		glog.Error(err)
+	} else {
+		glog.Infof("%s done: %d object(s), %s", r, r.ObjCount(), cmn.B2S(r.BytesCount(), 2))
+	}
+	r.Finish(err)
+	return
+}
+
+func (r *Restore) run() error {
+	meta := &xaction.BackupMeta{}
+	if err := r.download(BackupMetaName(r.args.Prefix), func(reader io.Reader) error {
+		return jsoniter.NewDecoder(reader).Decode(meta)
+	}); err != nil {
+		return fmt.Errorf("%s: failed to read backup metadata: %v", r, err)
+	}
+	r.meta = meta
+	var (
+		smap     = r.t.Sowner().Get()
+		avail, _ = fs.Get()
+		workCh   = make(chan *xaction.BackupEntry, len(avail)*4)
+		wg       = &sync.WaitGroup{}
+	)
+	for i := 0; i < cmn.Max(len(avail), 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range workCh {
+				if r.Aborted() {
+					continue
+				}
+				if err := r.restore(entry); err != nil {
+					r.failed.Inc()
+					glog.Errorf("%s: failed to restore %q: %v", r, entry.Name, err)
+				}
+			}
+		}()
+	}
+	var err error
+	for _, tid := range meta.Targets {
+		manifestName := backupManifestName(r.args.Prefix, meta.ID, tid)
+		err = r.download(manifestName, func(reader io.Reader) error {
+			return r.dispatch(reader, smap, workCh)
+		})
+		if err != nil {
+			err = fmt.Errorf("%s: failed to read manifest %q (incomplete backup?): %v", r, manifestName, err)
+			break
+		}
+	}
+	close(workCh)
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	if n := r.failed.Load(); n > 0 {
+		return fmt.Errorf("%s: failed to restore %d object(s)", r, n)
+	}
+	return nil
+}
+
+// sends to workers the manifest entries that belong to this target
+func (r *Restore) dispatch(reader io.Reader, smap *cluster.Smap, workCh chan *xaction.BackupEntry) error {
+	entries, err := parseBackupManifest(reader)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		si, err := cluster.HrwTarget(r.args.Bck.MakeUname(entry.Name), smap)
+		if err != nil {
+			return err
+		}
+		if si.ID() != r.t.Snode().ID() {
+			continue
+		}
+		select {
+		case workCh <- entry:
+		case <-r.ChanAbort():
+			return cmn.NewAbortedError(r.String())
+		}
+	}
+	return nil
+}
+
+func (r *Restore) restore(entry *xaction.BackupEntry) error {
+	srcLOM := &cluster.LOM{T: r.t, ObjName: BackupDataName(r.args.Prefix, r.meta.ID, entry.Name)}
+	if err := srcLOM.Init(r.args.Cloud.Bck); err != nil {
+		return err
+	}
+	lom := &cluster.LOM{T: r.t, ObjName: entry.Name}
+	if err := lom.Init(r.args.Bck.Bck); err != nil {
+		return err
+	}
+	reader, _, err, _ := r.t.Cloud(r.args.Cloud).GetObjReader(context.Background(), srcLOM)
+	if err != nil {
+		return err
+	}
+	lom.SetVersion(entry.Version)
+	var cksum *cmn.Cksum
+	if entry.CksumType != "" {
+		cksum = cmn.NewCksum(entry.CksumType, entry.CksumValue)
+	}
+	params := cluster.PutObjectParams{
+		Reader:       newBackupReader(reader, entry), // closed by `PutObject`
+		WorkFQN:      fs.CSM.GenContentFQN(lom.FQN, fs.WorkfileType, "restore"),
+		RecvType:     cluster.Migrated, // keeps the version
+		Cksum:        cksum,
+		Started:      time.Now(),
+		WithFinalize: true,
+	}
+	if err := r.t.PutObject(lom, params); err != nil {
+		return err
+	}
+	r.IO().WrittenAdd(entry.Size)
+	r.ObjectsInc()
+	r.BytesAdd(entry.Size)
+	return nil
+}
+
+func (r *Restore) download(objName string, cb func(io.Reader) error) error {
+	lom := &cluster.LOM{T: r.t, ObjName: objName}
+	if err := lom.Init(r.args.Cloud.Bck); err != nil {
+		return err
+	}
+	reader, _, err, _ := r.t.Cloud(r.args.Cloud).GetObjReader(context.Background(), lom)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return cb(reader)
+}
+
+//
+// helpers
+//
+
+func backupLocation(args *registry.BackupArgs) string {
+	return args.Cloud.String() + "/" + args.Prefix
+}
+
+func parseBackupManifest(reader io.Reader) (entries []*xaction.BackupEntry, err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*cmn.KiB), cmn.MiB)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		entry := &xaction.BackupEntry{}
+		if err = jsoniter.Unmarshal(line, entry); err != nil {
+			return nil, fmt.Errorf("invalid manifest line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	err = scanner.Err()
+	return
+}
+
+func newBackupReader(r io.ReadCloser, entry *xaction.BackupEntry) *backupReader {
+	br := &backupReader{r: r, entry: entry}
+	if entry.CksumType != "" && entry.CksumType != cmn.ChecksumNone {
+		br.cksum = cmn.NewCksumHash(entry.CksumType)
+	}
+	return br
+}
+
+func (br *backupReader) Read(b []byte) (n int, err error) {
+	n, err = br.r.Read(b)
+	if n > 0 {
+		br.size += int64(n)
+		if br.cksum != nil {
+			br.cksum.H.Write(b[:n])
+		}
+	}
+	if err != io.EOF {
+		return
+	}
+	if br.size != br.entry.Size {
+		return n, fmt.Errorf("%q: size %d != %d (backed up)", br.entry.Name, br.size, br.entry.Size)
+	}
+	if br.cksum != nil {
+		br.cksum.Finalize()
+		expected := cmn.NewCksum(br.entry.CksumType, br.entry.CksumValue)
+		if !br.cksum.Equal(expected) {
+			return n, cmn.NewBadDataCksumError(expected, &br.cksum.Cksum, br.entry.Name)
+		}
+	}
+	return
+}
+
+func (br *backupReader) Close() error { return br.r.Close() }
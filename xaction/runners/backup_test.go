@@ -0,0 +1,67 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestBackupManifestAndVerify(t *testing.T) {
+	const data = "0123456789"
+	cksum := cmn.NewCksumHash(cmn.ChecksumXXHash)
+	cksum.H.Write([]byte(data))
+	cksum.Finalize()
+
+	manifest := `{"name":"a/obj","size":"10","version":"3","cksum_type":"xxhash","cksum_value":"` + cksum.Value() + `"}
+{"name":"b","size":"11"}
+`
+	entries, err := parseBackupManifest(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name != "a/obj" || entries[0].Version != "3" || entries[1].Size != 11 {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	// checksum and size match
+	if _, err := ioutil.ReadAll(newBackupReader(ioutil.NopCloser(strings.NewReader(data)), entries[0])); err != nil {
+		t.Error(err)
+	}
+	// corrupted
+	if _, err := ioutil.ReadAll(newBackupReader(ioutil.NopCloser(strings.NewReader("0123456780")), entries[0])); err == nil {
+		t.Error("expected checksum mismatch")
+	}
+	// truncated (no checksum in the manifest)
+	if _, err := ioutil.ReadAll(newBackupReader(ioutil.NopCloser(strings.NewReader(data)), entries[1])); err == nil {
+		t.Error("expected size mismatch")
+	}
+	if _, err := parseBackupManifest(strings.NewReader("not-json\n")); err == nil {
+		t.Error("expected invalid manifest")
+	}
+}
+
+func TestBackupNames(t *testing.T) {
+	tests := []struct {
+		prefix, id, objName string
+		data, meta          string
+	}{
+		{"", "id1", "a/b", "id1/a/b", ".backup/bucket.json"},
+		{"daily", "id2", "obj", "daily/id2/obj", "daily/.backup/bucket.json"},
+	}
+	for _, test := range tests {
+		if name := BackupDataName(test.prefix, test.id, test.objName); name != test.data {
+			t.Errorf("expected %q, got %q", test.data, name)
+		}
+		if name := BackupMetaName(test.prefix); name != test.meta {
+			t.Errorf("expected %q, got %q", test.meta, name)
+		}
+	}
+	if m1, m2 := backupManifestName("p", "id1", "t1"), backupManifestName("p", "id2", "t1"); m1 == m2 {
+		t.Errorf("manifests of different backups must not collide: %q", m1)
+	}
+}
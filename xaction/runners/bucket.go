@@ -125,8 +125,9 @@ func (r *bckRename) Run() error {
 type (
 	listRangeBase struct {
 		xaction.XactBase
-		t    cluster.Target
-		args *registry.DeletePrefetchArgs
+		t      cluster.Target
+		args   *registry.DeletePrefetchArgs
+		owners *cluster.HrwOwners
 	}
 	evictDeleteProvider struct {
 		registry.BaseBckEntry
@@ -138,6 +139,7 @@ type (
 	}
 	evictDelete struct {
 		listRangeBase
+		undoDeadline time.Time // when args.UndoWindow is set
 	}
 	objCallback = func(args *registry.DeletePrefetchArgs, objName string) error
 )
@@ -158,19 +160,24 @@ func (p *evictDeleteProvider) Kind() string      { return p.kind }
 func (p *evictDeleteProvider) Get() cluster.Xact { return p.xact }
 
 func newEvictDelete(uuid, kind string, bck cmn.Bck, t cluster.Target, args *registry.DeletePrefetchArgs) *evictDelete {
-	return &evictDelete{
+	r := &evictDelete{
 		listRangeBase: listRangeBase{
 			XactBase: *xaction.NewXactBaseBck(uuid, kind, bck),
 			t:        t,
 			args:     args,
 		},
 	}
+	if args.UndoWindow > 0 {
+		r.undoDeadline = time.Now().Add(args.UndoWindow)
+	}
+	return r
 }
 
 func (r *evictDelete) IsMountpathXact() bool { return false }
 
 func (r *evictDelete) Run() error {
 	var err error
+	r.owners = cluster.NewHrwOwners(r.t.Sowner(), r.t.Snode().ID())
 	if r.args.RangeMsg != nil {
 		err = r.iterateBucketRange(r.args)
 	} else {
@@ -223,12 +230,21 @@ func newPrefetch(uuid, kind string, bck cmn.Bck, t cluster.Target, args *registr
 
 func (r *prefetch) IsMountpathXact() bool { return false }
 
+// NOTE: upon completion, prefetch requeues (and goes over again) the objects
+// of the targets that have entered maintenance or left the cluster in the meantime
 func (r *prefetch) Run() error {
 	var err error
-	if r.args.RangeMsg != nil {
-		err = r.iterateBucketRange(r.args)
-	} else {
-		err = r.listOperation(r.args, r.args.ListMsg)
+	r.owners = cluster.NewHrwOwners(r.t.Sowner(), r.t.Snode().ID())
+	for {
+		if r.args.RangeMsg != nil {
+			err = r.iterateBucketRange(r.args)
+		} else {
+			err = r.listOperation(r.args, r.args.ListMsg)
+		}
+		if err != nil || r.Aborted() || !r.owners.Requeue() {
+			break
+		}
+		glog.Infof("%s: requeueing - target(s) entered maintenance or left the cluster", r)
 	}
 	r.Finish()
 	return err
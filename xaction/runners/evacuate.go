@@ -0,0 +1,364 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Evacuate uploads all objects of the given ais buckets to external storage
+// (Cloud or remote AIS bucket) - typically, to migrate the data off the cluster
+// that is about to be decommissioned:
+//   * object `obj` of the bucket `src` is uploaded as `src/obj`;
+//   * uploads are paced not to exceed the configured (per-target) rate - the latter
+//     can be changed at runtime (see cmn.ActXactLimits);
+//   * each target keeps a local manifest (JSON lines, same as the backup's - see
+//     xaction.BackupEntry) of the objects uploaded so far - restarted evacuation
+//     skips them;
+//   * with `Verify`, each upload is followed by HEAD that must report the same size;
+//   * upon completion, the manifest is uploaded as `.evacuate/<target-ID>/<src>.manifest`.
+// Evacuation never modifies the source buckets.
+
+const (
+	EvacuateDir         = "evacuate"  // local manifests, relative to config.Confdir
+	EvacuateManifestDir = ".evacuate" // uploaded manifests, relative to the destination bucket
+	evacManifestExt     = ".manifest"
+)
+
+type (
+	evacuateProvider struct {
+		xact *Evacuate
+		t    cluster.Target
+		id   string
+		args *registry.EvacuateArgs
+	}
+	Evacuate struct {
+		xaction.XactBase
+		t      cluster.Target
+		args   *registry.EvacuateArgs
+		pacer  pacer // per-target upload rate (see xaction.Limiter)
+		failed atomic.Int64
+	}
+	// manifest of the objects uploaded from a given source bucket
+	evacManifest struct {
+		mu   sync.Mutex
+		file *os.File
+		done map[string]int64 // object name => size
+	}
+)
+
+func init() {
+	registry.Registry.RegisterGlobalXact(&evacuateProvider{})
+}
+
+//
+// evacuateProvider
+//
+
+func (*evacuateProvider) New(args registry.XactArgs) registry.GlobalEntry {
+	return &evacuateProvider{t: args.T, id: args.UUID, args: args.Custom.(*registry.EvacuateArgs)}
+}
+
+func (p *evacuateProvider) Start(_ cmn.Bck) error {
+	p.xact = NewEvacuate(p.id, p.t, p.args)
+	return nil
+}
+func (*evacuateProvider) Kind() string                               { return cmn.ActEvacuate }
+func (p *evacuateProvider) Get() cluster.Xact                        { return p.xact }
+func (p *evacuateProvider) PreRenewHook(_ registry.GlobalEntry) bool { return true } // one at a time
+func (p *evacuateProvider) PostRenewHook(_ registry.GlobalEntry)     {}
+
+//
+// Evacuate
+//
+
+func NewEvacuate(uuid string, t cluster.Target, args *registry.EvacuateArgs) *Evacuate {
+	xact := &Evacuate{
+		XactBase: *xaction.NewXactBase(xaction.XactBaseID(uuid), cmn.ActEvacuate),
+		t:        t,
+		args:     args,
+	}
+	xact.pacer.setRate(bytesPerHourToBps(args.Msg.BytesPerHour))
+	return xact
+}
+
+func (r *Evacuate) IsMountpathXact() bool { return true }
+
+func (r *Evacuate) String() string {
+	return fmt.Sprintf("%s => %s", r.XactBase.String(), r.args.Dst)
+}
+
+func (r *Evacuate) Run() (err error) {
+	glog.Infof("%s started: %d bucket(s), rate %s/s", r, len(r.args.Bcks), cmn.B2S(r.pacer.rate(), 0))
+	for _, bck := range r.args.Bcks {
+		if err = r.evacuate(bck); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		if n := r.failed.Load(); n > 0 {
+			err = fmt.Errorf("%s: failed to upload %d object(s)", r, n)
+		}
+	}
+	if err != nil {
+		glog.Error(err)
+	} else {
+		glog.Infof("%s done: %d object(s), %s", r, r.ObjCount(), cmn.B2S(r.BytesCount(), 2))
+	}
+	r.Finish(err)
+	return
+}
+
+// evacuate a single source bucket (all mountpaths in parallel)
+func (r *Evacuate) evacuate(bck *cluster.Bck) error {
+	manifest, err := r.openManifest(bck)
+	if err != nil {
+		return err
+	}
+	var (
+		wg        = &sync.WaitGroup{}
+		avail, _  = fs.Get()
+		config    = cmn.GCO.Get()
+		uploaded  = r.ObjCount()
+		mpathErrs = make(chan error, len(avail))
+	)
+	for _, mpathInfo := range avail {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			opts := &fs.Options{
+				Mpath: mi,
+				Bck:   bck.Bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					return r.upload(fqn, bck, config, manifest)
+				},
+			}
+			if err := fs.Walk(opts); err != nil {
+				mpathErrs <- err
+			}
+		}(mpathInfo)
+	}
+	wg.Wait()
+	close(mpathErrs)
+	if err := manifest.file.Close(); err != nil {
+		glog.Errorf("%s: failed to close %q: %v", r, manifest.file.Name(), err)
+	}
+	for err := range mpathErrs {
+		if errors.As(err, &cmn.AbortedError{}) || r.Aborted() {
+			return cmn.NewAbortedErrorDetails(r.String(), bck.String())
+		}
+		glog.Errorf("%s: %v", r, err)
+	}
+	if r.failed.Load() > 0 {
+		return nil // not uploading the manifest of a partially evacuated bucket
+	}
+	glog.Infof("%s: %s evacuated (%d object(s) this run, %d total)",
+		r, bck, r.ObjCount()-uploaded, len(manifest.done))
+	return r.uploadManifest(bck, manifest.file.Name())
+}
+
+func (r *Evacuate) upload(fqn string, bck *cluster.Bck, config *cmn.Config, manifest *evacManifest) error {
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	lom := &cluster.LOM{T: r.t, FQN: fqn}
+	if err := lom.Init(bck.Bck, config); err != nil {
+		return nil
+	}
+	lom.Lock(false)
+	err := lom.Load()
+	lom.Unlock(false)
+	if err != nil || lom.IsCopy() || manifest.has(lom.ObjName, lom.Size()) {
+		return nil
+	}
+	r.pacer.pace(lom.Size(), r.ChanAbort())
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+
+	// (re)load and open under the lock - the object may have changed while pacing;
+	// the upload itself reads the opened file that stays intact (PUT, APPEND, etc.
+	// rename new content over the object)
+	lom.Lock(false)
+	if err := lom.Load(); err != nil {
+		lom.Unlock(false)
+		return nil
+	}
+	fh, err := cmn.NewFileHandle(lom.FQN) // closed by `PutObj`
+	if err != nil {
+		lom.Unlock(false)
+		r.failed.Inc()
+		glog.Errorf("%s: failed to open %s: %v", r, lom, err)
+		return nil
+	}
+	var (
+		size  = lom.Size()
+		cksum = lom.Cksum()
+		entry = &xaction.BackupEntry{Name: lom.ObjName, Size: size, Version: lom.Version()}
+	)
+	lom.Unlock(false)
+	if cksum != nil && cksum.Type() != cmn.ChecksumNone {
+		entry.CksumType, entry.CksumValue = cksum.Get()
+	}
+	if err := r.put(fh, bck.Name+"/"+lom.ObjName, size, cksum); err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: failed to upload %s: %v", r, lom, err)
+		return nil
+	}
+	if err := manifest.add(entry); err != nil {
+		glog.Errorf("%s: failed to update manifest: %v", r, err)
+	}
+	r.ObjectsInc()
+	r.BytesAdd(size)
+	return nil
+}
+
+func (r *Evacuate) put(fh *cmn.FileHandle, objName string, size int64, cksum *cmn.Cksum) error {
+	if cksum == nil {
+		cksum = cmn.NewCksum(cmn.ChecksumNone, "")
+	}
+	dstLOM, err := r.dstLOM(objName, cksum, size)
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	if _, err, _ = r.t.Cloud(r.args.Dst).PutObj(context.Background(), fh, dstLOM); err != nil {
+		return err
+	}
+	r.IO().ReadAdd(size) // (the file handle is read in its entirety by `PutObj`)
+	if !r.args.Msg.Verify {
+		return nil
+	}
+	objMeta, err, _ := r.t.Cloud(r.args.Dst).HeadObj(context.Background(), dstLOM)
+	if err != nil {
+		return fmt.Errorf("verification failed: %v", err)
+	}
+	if dstSize, err := strconv.ParseInt(objMeta[cmn.HeaderObjSize], 10, 64); err == nil && dstSize != size {
+		return fmt.Errorf("verification failed: size %d != %d", dstSize, size)
+	}
+	return nil
+}
+
+func (r *Evacuate) dstLOM(objName string, cksum *cmn.Cksum, size int64) (*cluster.LOM, error) {
+	dstLOM := &cluster.LOM{T: r.t, ObjName: objName}
+	if err := dstLOM.Init(r.args.Dst.Bck); err != nil {
+		return nil, err
+	}
+	dstLOM.SetCksum(cksum)
+	dstLOM.SetSize(size)
+	return dstLOM, nil
+}
+
+func (r *Evacuate) uploadManifest(bck *cluster.Bck, fqn string) error {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return err
+	}
+	fh, err := cmn.NewFileHandle(fqn)
+	if err != nil {
+		return err
+	}
+	objName := filepath.Join(EvacuateManifestDir, r.t.Snode().ID(), bck.Name+evacManifestExt)
+	dstLOM, err := r.dstLOM(objName, cmn.NewCksum(cmn.ChecksumNone, ""), finfo.Size())
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	if _, err, _ = r.t.Cloud(r.args.Dst).PutObj(context.Background(), fh, dstLOM); err != nil {
+		return fmt.Errorf("%s: failed to upload %s manifest: %v", r, bck, err)
+	}
+	return nil
+}
+
+// SetLimits implements xaction.Limiter: updates the upload rate
+func (r *Evacuate) SetLimits(limits *xaction.JobLimits) error {
+	return setRateLimits(r, &r.pacer, limits)
+}
+
+//
+// manifest
+//
+
+func (r *Evacuate) openManifest(bck *cluster.Bck) (*evacManifest, error) {
+	var (
+		dst  = r.args.Dst.Bck
+		dir  = filepath.Join(r.args.Confdir, EvacuateDir, dst.Provider, dst.Ns.Uname(), dst.Name)
+		fqn  = filepath.Join(dir, bck.Name+evacManifestExt)
+		m    = &evacManifest{done: make(map[string]int64)}
+		file *os.File
+		err  error
+	)
+	if err = cmn.CreateDir(dir); err != nil {
+		return nil, err
+	}
+	if file, err = os.Open(fqn); err == nil {
+		err = m.load(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read manifest %q: %v", r, fqn, err)
+		}
+		if len(m.done) > 0 {
+			glog.Infof("%s: resuming %s - skipping %d uploaded object(s)", r, bck, len(m.done))
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if m.file, err = os.OpenFile(fqn, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *evacManifest) has(objName string, size int64) bool {
+	m.mu.Lock()
+	uploadedSize, ok := m.done[objName]
+	m.mu.Unlock()
+	return ok && uploadedSize == size
+}
+
+// reads the entries uploaded by the previous run(s); a malformed (eg., partially
+// written upon crash) line is skipped - the object will be uploaded again
+func (m *evacManifest) load(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*cmn.KiB), cmn.MiB)
+	for scanner.Scan() {
+		entry := &xaction.BackupEntry{}
+		if err := jsoniter.Unmarshal(scanner.Bytes(), entry); err != nil || entry.Name == "" {
+			continue
+		}
+		m.done[entry.Name] = entry.Size
+	}
+	return scanner.Err()
+}
+
+func (m *evacManifest) add(entry *xaction.BackupEntry) (err error) {
+	line := append(cmn.MustMarshal(entry), '\n')
+	m.mu.Lock()
+	m.done[entry.Name] = entry.Size
+	_, err = m.file.Write(line)
+	m.mu.Unlock()
+	return
+}
@@ -0,0 +1,58 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+func TestEvacManifest(t *testing.T) {
+	file, err := ioutil.TempFile("", "evac-manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	names := []string{"a/obj", "with\ttab", "with\nnewline", `with"quote`}
+	m := &evacManifest{file: file, done: make(map[string]int64)}
+	for i, name := range names {
+		entry := &xaction.BackupEntry{Name: name, Size: int64(i + 1), CksumType: "xxhash", CksumValue: "1"}
+		if err := m.add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// crash in the middle of writing the line
+	if _, err := file.WriteString(`{"name":"partial","si`); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &evacManifest{done: make(map[string]int64)}
+	if err := loaded.load(strings.NewReader(string(b))); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.done) != len(names) {
+		t.Fatalf("expected %d entries, got %v", len(names), loaded.done)
+	}
+	for i, name := range names {
+		if !loaded.has(name, int64(i+1)) {
+			t.Errorf("expected %q (size %d) to be uploaded", name, i+1)
+		}
+	}
+	if loaded.has(names[0], 100) {
+		t.Errorf("expected %q of a different size not to be uploaded", names[0])
+	}
+}
@@ -37,6 +37,7 @@ type (
 		RebBase
 		statsRunner  *stats.Trunner // extended stats
 		getRebMarked getMarked
+		pacer        pacer // send rate, unlimited unless set at runtime (see xaction.Limiter)
 	}
 
 	resilverProvider struct {
@@ -120,6 +121,15 @@ func NewRebalance(id cluster.XactID, kind string, statsRunner *stats.Trunner, ge
 
 func (xact *Rebalance) IsMountpathXact() bool { return false }
 
+// SetLimits implements xaction.Limiter: updates the rate at which this target
+// sends objects to other targets
+func (xact *Rebalance) SetLimits(limits *xaction.JobLimits) error {
+	return setRateLimits(xact, &xact.pacer, limits)
+}
+
+// Pace delays the caller so that the objects sent by the rebalance don't exceed the rate
+func (xact *Rebalance) Pace(size int64) { xact.pacer.pace(size, xact.ChanAbort()) }
+
 func (xact *Rebalance) String() string {
 	return fmt.Sprintf("%s, %s", xact.RebBase.String(), xact.ID())
 }
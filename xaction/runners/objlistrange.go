@@ -15,13 +15,9 @@ import (
 	"github.com/NVIDIA/aistore/xaction/registry"
 )
 
-func isLocalObject(smap *cluster.Smap, b cmn.Bck, objName, sid string) (bool, error) {
+func isLocalObject(owners *cluster.HrwOwners, b cmn.Bck, objName string) (bool, error) {
 	bck := cluster.NewBckEmbed(b)
-	si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
-	if err != nil {
-		return false, err
-	}
-	return si.ID() == sid, nil
+	return owners.Owns(bck.MakeUname(objName))
 }
 
 // Try to parse string as template:
@@ -47,6 +43,10 @@ func parseTemplate(template string) (cmn.ParsedTemplate, error) {
 //
 
 func (r *evictDelete) objDelete(args *registry.DeletePrefetchArgs, lom *cluster.LOM) (err error) {
+	if args.UndoWindow > 0 {
+		// to be restored by the xaction's UUID (the undo token)
+		return r.t.TrashObject(args.Ctx, lom, r.ID().String(), r.undoDeadline)
+	}
 	err, _ = r.t.DeleteObject(args.Ctx, lom, args.Evict)
 	return
 }
@@ -142,23 +142,19 @@ func (r *listRangeBase) iterateRange(args *registry.DeletePrefetchArgs, cb objCa
 		return err
 	}
 
-	smap := r.t.Sowner().Get()
 	if len(pt.Ranges) != 0 {
-		return r.iterateTemplate(args, smap, &pt, cb)
+		return r.iterateTemplate(args, &pt, cb)
 	}
-	return r.iteratePrefix(args, smap, pt.Prefix, cb)
+	return r.iteratePrefix(args, pt.Prefix, cb)
 }
 
-func (r *listRangeBase) iterateTemplate(args *registry.DeletePrefetchArgs, smap *cluster.Smap, pt *cmn.ParsedTemplate, cb objCallback) error {
-	var (
-		getNext = pt.Iter()
-		sid     = r.t.Snode().ID()
-	)
+func (r *listRangeBase) iterateTemplate(args *registry.DeletePrefetchArgs, pt *cmn.ParsedTemplate, cb objCallback) error {
+	getNext := pt.Iter()
 	for objName, hasNext := getNext(); !r.Aborted() && hasNext; objName, hasNext = getNext() {
 		if r.Aborted() {
 			return nil
 		}
-		local, err := isLocalObject(smap, r.Bck(), objName, sid)
+		local, err := isLocalObject(r.owners, r.Bck(), objName)
 		if err != nil {
 			return err
 		}
@@ -172,10 +168,9 @@ func (r *listRangeBase) iterateTemplate(args *registry.DeletePrefetchArgs, smap
 	return nil
 }
 
-func (r *listRangeBase) iteratePrefix(args *registry.DeletePrefetchArgs, smap *cluster.Smap, prefix string, cb objCallback) error {
+func (r *listRangeBase) iteratePrefix(args *registry.DeletePrefetchArgs, prefix string, cb objCallback) error {
 	var (
 		objList *cmn.BucketList
-		sid     = r.t.Snode().ID()
 		err     error
 	)
 
@@ -203,7 +198,7 @@ func (r *listRangeBase) iteratePrefix(args *registry.DeletePrefetchArgs, smap *c
 				return nil
 			}
 			if bck.IsRemote() {
-				local, err := isLocalObject(smap, r.Bck(), be.Name, sid)
+				local, err := isLocalObject(r.owners, r.Bck(), be.Name)
 				if err != nil {
 					return err
 				}
@@ -229,15 +224,11 @@ func (r *listRangeBase) iteratePrefix(args *registry.DeletePrefetchArgs, smap *c
 }
 
 func (r *listRangeBase) iterateList(args *registry.DeletePrefetchArgs, listMsg *cmn.ListMsg, cb objCallback) error {
-	var (
-		smap = r.t.Sowner().Get()
-		sid  = r.t.Snode().ID()
-	)
 	for _, obj := range listMsg.ObjNames {
 		if r.Aborted() {
 			break
 		}
-		local, err := isLocalObject(smap, r.Bck(), obj, sid)
+		local, err := isLocalObject(r.owners, r.Bck(), obj)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,78 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// pacer delays the callers so that the data they transfer doesn't exceed the
+// rate - the latter can be changed at any time (see xaction.Limiter)
+type pacer struct {
+	mu   sync.Mutex
+	bps  int64     // bytes per second; 0 - unlimited
+	next time.Time // when the next transfer may start
+}
+
+func bytesPerHourToBps(bytesPerHour int64) int64 {
+	if bytesPerHour <= 0 {
+		return 0
+	}
+	return cmn.MaxI64(bytesPerHour/3600, 1)
+}
+
+// updates the rate of a running xaction - the only limit that applies (other
+// limits are downloader-specific)
+func setRateLimits(xact fmt.Stringer, p *pacer, limits *xaction.JobLimits) error {
+	if limits.Connections != 0 || limits.BatchSize != 0 || limits.Shared || len(limits.Shares) > 0 {
+		return fmt.Errorf("%s: only bytes_per_hour can be updated", xact)
+	}
+	if limits.BytesPerHour < 0 {
+		return fmt.Errorf("%s: invalid bytes_per_hour %d", xact, limits.BytesPerHour)
+	}
+	p.setRate(bytesPerHourToBps(int64(limits.BytesPerHour)))
+	glog.Infof("%s: rate set to %s/s (0 - unlimited)", xact, cmn.B2S(p.rate(), 0))
+	return nil
+}
+
+func (p *pacer) rate() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.bps
+}
+
+func (p *pacer) setRate(bps int64) {
+	p.mu.Lock()
+	p.bps = bps
+	p.next = time.Time{} // start over
+	p.mu.Unlock()
+}
+
+func (p *pacer) pace(size int64, abortCh <-chan struct{}) {
+	p.mu.Lock()
+	if p.bps == 0 {
+		p.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	wait := p.next.Sub(now)
+	p.next = p.next.Add(time.Duration(float64(size) / float64(p.bps) * float64(time.Second)))
+	p.mu.Unlock()
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-abortCh:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// xactions that support updating their limits at runtime
+var (
+	_ xaction.Limiter = &Rebalance{}
+	_ xaction.Limiter = &Evacuate{}
+)
+
+func TestPacer(t *testing.T) {
+	var (
+		p       pacer
+		abortCh = make(chan struct{})
+	)
+	// unlimited
+	started := time.Now()
+	p.pace(cmn.GiB, abortCh)
+	p.pace(cmn.GiB, abortCh)
+	tassert.Errorf(t, time.Since(started) < 50*time.Millisecond, "unlimited rate must not delay")
+
+	// 1MiB/s: the first 100KiB go right away, the next ones wait for the previous
+	p.setRate(cmn.MiB)
+	started = time.Now()
+	for i := 0; i < 3; i++ {
+		p.pace(100*cmn.KiB, abortCh)
+	}
+	elapsed := time.Since(started)
+	tassert.Errorf(t, elapsed > 150*time.Millisecond && elapsed < time.Second, "unexpected delay %v", elapsed)
+
+	// rate change takes effect immediately
+	p.pace(10*cmn.MiB, abortCh) // 10s worth at 1MiB/s
+	p.setRate(0)
+	started = time.Now()
+	p.pace(cmn.GiB, abortCh)
+	tassert.Errorf(t, time.Since(started) < 50*time.Millisecond, "unlimited rate must not delay")
+
+	// abort
+	p.setRate(1)
+	p.pace(cmn.MiB, abortCh)
+	close(abortCh)
+	started = time.Now()
+	p.pace(1, abortCh)
+	tassert.Errorf(t, time.Since(started) < 50*time.Millisecond, "aborted pacing must not delay")
+}
+
+func TestSetRateLimits(t *testing.T) {
+	xact := &Rebalance{}
+	tests := []struct {
+		limits xaction.JobLimits
+		bps    int64
+		valid  bool
+	}{
+		{xaction.JobLimits{BytesPerHour: 3600 * cmn.MiB}, cmn.MiB, true},
+		{xaction.JobLimits{BytesPerHour: 1}, 1, true},
+		{xaction.JobLimits{}, 0, true},
+		{xaction.JobLimits{BytesPerHour: -1}, 0, false},
+		{xaction.JobLimits{Connections: 4}, 0, false},
+		{xaction.JobLimits{BytesPerHour: cmn.GiB, Shared: true}, 0, false},
+	}
+	for _, test := range tests {
+		xact.pacer.setRate(0)
+		err := xact.SetLimits(&test.limits)
+		if (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid=%t, got err: %v", test.limits, test.valid, err)
+			continue
+		}
+		tassert.Errorf(t, xact.pacer.rate() == test.bps, "%+v: expected rate %d, got %d", test.limits, test.bps, xact.pacer.rate())
+	}
+}
@@ -0,0 +1,224 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+// Prefill is the background "trickle" that moves existing objects onto the
+// targets that joined the cluster in prefill mode (see cmn.RebalanceConf.Prefill):
+//   * each target walks its buckets and moves the objects that (as per the
+//     current cluster map) belong to a prefilling target - one at a time;
+//   * the moves are paced not to exceed rebalance.prefill_rate (re-read on
+//     every object, so that the rate can be changed at runtime);
+//   * an object that the prefilling target already has (e.g., written or
+//     gotten-from-neighbor after the target joined) is not sent - the local
+//     copy is simply removed;
+//   * erasure coded buckets are skipped (left to the full rebalance).
+// Prefill does not stop user traffic: new writes go to the prefilling targets
+// right away, and the objects that haven't trickled yet are gotten from neighbors.
+
+type (
+	prefillProvider struct {
+		xact *Prefill
+		t    cluster.Target
+		id   string
+		args *registry.PrefillArgs
+	}
+	Prefill struct {
+		xaction.XactBase
+		t       cluster.Target
+		args    *registry.PrefillArgs
+		scanned atomic.Int64
+		failed  atomic.Int64
+		mu      sync.Mutex
+		next    time.Time // pacing: when the next move may start (guarded by `mu`)
+	}
+)
+
+func init() {
+	registry.Registry.RegisterGlobalXact(&prefillProvider{})
+}
+
+/////////////////////
+// prefillProvider //
+/////////////////////
+
+func (*prefillProvider) New(args registry.XactArgs) registry.GlobalEntry {
+	return &prefillProvider{t: args.T, id: args.UUID, args: args.Custom.(*registry.PrefillArgs)}
+}
+
+func (p *prefillProvider) Start(_ cmn.Bck) error {
+	p.xact = NewPrefill(p.id, p.t, p.args)
+	return nil
+}
+func (*prefillProvider) Kind() string                             { return cmn.ActPrefill }
+func (p *prefillProvider) Get() cluster.Xact                      { return p.xact }
+func (*prefillProvider) PreRenewHook(_ registry.GlobalEntry) bool { return false } // replace
+func (*prefillProvider) PostRenewHook(prev registry.GlobalEntry)  { prev.Get().Abort() }
+
+/////////////
+// Prefill //
+/////////////
+
+func NewPrefill(uuid string, t cluster.Target, args *registry.PrefillArgs) *Prefill {
+	return &Prefill{
+		XactBase: *xaction.NewXactBase(xaction.XactBaseID(uuid), cmn.ActPrefill),
+		t:        t,
+		args:     args,
+	}
+}
+
+func (r *Prefill) IsMountpathXact() bool { return true }
+
+func (r *Prefill) Status() *cmn.PrefillStatus {
+	return &cmn.PrefillStatus{
+		ID:      r.ID().String(),
+		Scanned: r.scanned.Load(),
+		Moved:   r.ObjCount(),
+		Bytes:   r.BytesCount(),
+		Running: !r.Finished(),
+		Aborted: r.Aborted(),
+	}
+}
+
+func (r *Prefill) Run() (err error) {
+	var (
+		bcks []*cluster.Bck
+		smap = r.t.Sowner().Get()
+	)
+	glog.Infof("%s started: prefilling %v", r, smap.PrefillTargets())
+	r.t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if !bck.Props.EC.Enabled {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	for _, bck := range bcks {
+		if err = r.prefill(bck); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		if n := r.failed.Load(); n > 0 {
+			err = fmt.Errorf("%s: failed to move %d object(s)", r, n)
+		}
+	}
+	if err != nil {
+		glog.Error(err)
+	} else {
+		glog.Infof("%s done: %d object(s) checked, %d moved (%s)",
+			r, r.scanned.Load(), r.ObjCount(), cmn.B2S(r.BytesCount(), 2))
+	}
+	r.Finish(err)
+	return
+}
+
+// prefill from a single bucket (all mountpaths in parallel)
+func (r *Prefill) prefill(bck *cluster.Bck) error {
+	var (
+		wg        = &sync.WaitGroup{}
+		avail, _  = fs.Get()
+		config    = cmn.GCO.Get()
+		mpathErrs = make(chan error, len(avail))
+	)
+	for _, mpathInfo := range avail {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			opts := &fs.Options{
+				Mpath: mi,
+				Bck:   bck.Bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					return r.move(fqn, bck, config)
+				},
+			}
+			if err := fs.Walk(opts); err != nil {
+				mpathErrs <- err
+			}
+		}(mpathInfo)
+	}
+	wg.Wait()
+	close(mpathErrs)
+	for err := range mpathErrs {
+		if errors.As(err, &cmn.AbortedError{}) || r.Aborted() {
+			return cmn.NewAbortedErrorDetails(r.String(), bck.String())
+		}
+		glog.Errorf("%s: %v", r, err)
+	}
+	return nil
+}
+
+func (r *Prefill) move(fqn string, bck *cluster.Bck, config *cmn.Config) error {
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	lom := &cluster.LOM{T: r.t, FQN: fqn}
+	if err := lom.Init(bck.Bck, config); err != nil {
+		return nil
+	}
+	if err := lom.Load(); err != nil || lom.IsCopy() {
+		return nil
+	}
+	r.scanned.Inc()
+	tsi, err := cluster.HrwTarget(lom.Uname(), r.t.Sowner().Get())
+	if err != nil || tsi.ID() == r.t.Snode().ID() || !tsi.InPrefill() {
+		return nil
+	}
+	r.pace(lom.Size())
+	if r.Aborted() {
+		return cmn.NewAbortedError(r.String())
+	}
+	sent, err := r.args.Move(lom, tsi)
+	if err != nil {
+		r.failed.Inc()
+		glog.Errorf("%s: failed to move %s => %s: %v", r, lom, tsi, err)
+		return nil
+	}
+	r.ObjectsInc()
+	if sent {
+		r.BytesAdd(lom.Size())
+		r.IO().ReadAdd(lom.Size())
+	}
+	return nil
+}
+
+// pace delays the caller so that the moves don't exceed the configured rate
+func (r *Prefill) pace(size int64) {
+	bps := cmn.GCO.Get().Rebalance.PrefillRate
+	if bps == 0 {
+		return
+	}
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(time.Duration(float64(size) / float64(bps) * float64(time.Second)))
+	r.mu.Unlock()
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-r.ChanAbort():
+		}
+	}
+}
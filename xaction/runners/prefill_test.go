@@ -0,0 +1,171 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package runners
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction/registry"
+)
+
+type (
+	prefillSowner struct{ smap *cluster.Smap }
+	prefillTarget struct {
+		*cluster.TargetMock
+		si    *cluster.Snode
+		owner *prefillSowner
+	}
+)
+
+func (o *prefillSowner) Get() *cluster.Smap               { return o.smap }
+func (o *prefillSowner) Listeners() cluster.SmapListeners { return nil }
+func (t *prefillTarget) Snode() *cluster.Snode            { return t.si }
+func (t *prefillTarget) Sowner() cluster.Sowner           { return t.owner }
+
+func setPrefillRate(bps int64) {
+	config := cmn.GCO.BeginUpdate()
+	config.Rebalance.PrefillRate = bps
+	cmn.GCO.CommitUpdate(config)
+}
+
+func TestPrefillPace(t *testing.T) {
+	r := NewPrefill("prefill-pace", nil, &registry.PrefillArgs{})
+	defer setPrefillRate(0)
+
+	setPrefillRate(0)
+	started := time.Now()
+	for i := 0; i < 10; i++ {
+		r.pace(cmn.GiB)
+	}
+	if elapsed := time.Since(started); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected no pacing with unlimited rate, took %v", elapsed)
+	}
+
+	// 3 x 100KiB at 1MiB/s: the first move starts right away, the last - in ~200ms
+	setPrefillRate(cmn.MiB)
+	started = time.Now()
+	for i := 0; i < 3; i++ {
+		r.pace(100 * cmn.KiB)
+	}
+	if elapsed := time.Since(started); elapsed < 180*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatalf("expected ~200ms, took %v", elapsed)
+	}
+
+	// abort interrupts the wait
+	r.pace(10 * cmn.MiB)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		r.Abort()
+	}()
+	started = time.Now()
+	r.pace(cmn.KiB)
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("expected abort to interrupt pacing, took %v", elapsed)
+	}
+}
+
+func TestPrefillMove(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "prefill-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mpath)
+	fs.Init()
+	fs.DisableFsIDCheck()
+	if err := fs.Add(mpath); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Remove(mpath)
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+
+	var (
+		bck    = cmn.Bck{Name: "prefill", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal, Props: &cmn.BucketProps{}}
+		cbck   = &cluster.Bck{Bck: bck}
+		t1     = &cluster.Snode{DaemonID: "t1"}
+		t2     = &cluster.Snode{DaemonID: "t2"}
+		smap   = &cluster.Smap{Tmap: cluster.NodeMap{"t1": t1, "t2": t2}}
+		target = &prefillTarget{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock(cbck)), si: t1,
+			owner: &prefillSowner{smap: smap}}
+		moved = make(map[string]string)
+		args  = &registry.PrefillArgs{Move: func(lom *cluster.LOM, tsi *cluster.Snode) (bool, error) {
+			moved[lom.ObjName] = tsi.ID()
+			return true, nil
+		}}
+		r      = NewPrefill("prefill-move", target, args)
+		config = cmn.GCO.Get()
+		local  string // belongs to t1
+		remote string // belongs to t2
+	)
+	t1.Digest()
+	t2.Digest()
+	for i := 0; local == "" || remote == ""; i++ {
+		objName := fmt.Sprintf("obj-%d", i)
+		tsi, err := cluster.HrwTarget(cbck.MakeUname(objName), smap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tsi.ID() == "t1" && local == "" {
+			local = objName
+		} else if tsi.ID() == "t2" && remote == "" {
+			remote = objName
+		}
+	}
+	fqns := make(map[string]string, 2)
+	for _, objName := range []string{local, remote} {
+		lom := &cluster.LOM{T: target, ObjName: objName}
+		if err := lom.Init(bck); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmn.CreateDir(filepath.Dir(lom.FQN)); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(lom.FQN, []byte("data"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		lom.SetSize(4)
+		if err := lom.Persist(); err != nil {
+			t.Fatal(err)
+		}
+		fqns[objName] = lom.FQN
+	}
+
+	// t2 is not prefilling: nothing moves (left to the global rebalance)
+	for _, fqn := range fqns {
+		if err := r.move(fqn, cbck, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(moved) != 0 {
+		t.Fatalf("expected no moves, got %v", moved)
+	}
+
+	// t2 is prefilling: only its object moves, and only to t2
+	t2.Flags = t2.Flags.Set(cluster.SnodePrefill)
+	for _, fqn := range fqns {
+		if err := r.move(fqn, cbck, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(moved) != 1 || moved[remote] != "t2" {
+		t.Fatalf("expected %q => t2, got %v", remote, moved)
+	}
+	if r.ObjCount() != 1 || r.scanned.Load() != 4 {
+		t.Fatalf("expected 1 moved and 4 scanned, got %d and %d", r.ObjCount(), r.scanned.Load())
+	}
+
+	// aborted
+	r.Abort()
+	if err := r.move(fqns[remote], cbck, config); err == nil {
+		t.Fatal("expected aborted error")
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
 )
 
@@ -30,6 +32,11 @@ type (
 		abrt    chan struct{}
 		aborted atomic.Bool
 		notif   *NotifXact
+		// position in the queue of heavy xactions (0 - not queued), see queue.go
+		queuePos atomic.Int32
+		// local disk reads and writes (see IO())
+		xio    ios.XactIO
+		xioReg atomic.Bool
 	}
 
 	XactBaseID string
@@ -80,6 +87,7 @@ compare:
 func NewXactBase(id cluster.XactID, kind string) *XactBase {
 	cmn.Assert(kind != "")
 	xact := &XactBase{id: id, kind: kind, abrt: make(chan struct{})}
+	xact.xio.Init(kind + "[" + id.String() + "]")
 	xact.setStartTime(time.Now())
 	return xact
 }
@@ -153,6 +161,9 @@ func (xact *XactBase) EndTime() time.Time {
 
 func (xact *XactBase) _setEndTime(errs ...error) {
 	xact.eutime.Store(time.Now().UnixNano())
+	if xact.xioReg.Load() {
+		fs.RemoveXactIO(&xact.xio)
+	}
 
 	// notifications
 	if n := xact.Notif(); n != nil {
@@ -217,6 +228,17 @@ func (xact *XactBase) ObjectsAdd(cnt int64) int64 { return xact.objects.Add(cnt)
 func (xact *XactBase) BytesCount() int64          { return xact.bytes.Load() }
 func (xact *XactBase) BytesAdd(size int64) int64  { return xact.bytes.Add(size) }
 
+// IO returns the xaction's disk I/O accounting - to wrap file readers and
+// writers or add the bytes directly (see ios.XactIO). Upon first call the
+// accounting gets registered with the iostat context that, in turn, logs
+// the xaction's disk throughput for as long as the xaction is running.
+func (xact *XactBase) IO() *ios.XactIO {
+	if !xact.Finished() && xact.xioReg.CAS(false, true) {
+		fs.AddXactIO(&xact.xio)
+	}
+	return &xact.xio
+}
+
 func (xact *XactBase) IsMountpathXact() bool { cmn.Assert(false); return true } // must implement
 
 func (xact *XactBase) Stats() cluster.XactStats {
@@ -228,7 +250,10 @@ func (xact *XactBase) Stats() cluster.XactStats {
 		BckX:        xact.Bck(),
 		ObjCountX:   xact.ObjCount(),
 		BytesCountX: xact.BytesCount(),
+		DiskReadX:   xact.xio.ReadBytes(),
+		DiskWriteX:  xact.xio.WrittenBytes(),
 		AbortedX:    xact.Aborted(),
+		QueuePosX:   int(xact.queuePos.Load()),
 	}
 }
 